@@ -2,6 +2,7 @@ package disasterrecovery
 
 import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/gravitational/trace"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery"
 	cnpgrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/restore"
@@ -16,9 +17,46 @@ type AuthentikBackupConfigCNPG struct {
 	CloneClusterOptions clonedcluster.CloneClusterOptions `yaml:"clusterCloning,omitempty"`
 }
 
+// AuthentikBackupConfigS3 locates the media bucket. Exactly one of Credentials and CredentialsSecretRef must
+// be set, although the endpoint, region and path-style settings may still be given inline when the keys are
+// read from the Kubernetes Secret that CredentialsSecretRef references.
 type AuthentikBackupConfigS3 struct {
-	S3Path      string         `yaml:"s3Path" jsonschema:"required"`
-	Credentials s3.Credentials `yaml:"credentials" jsonschema:"required"`
+	S3Path               string                      `yaml:"s3Path" jsonschema:"required"`
+	Credentials          s3.Credentials              `yaml:"credentials,omitempty"`
+	CredentialsSecretRef *disasterrecovery.SecretRef `yaml:"credentialsSecretRef,omitempty"`
+}
+
+// validate checks that the keys are either inline, or read from a secret, but not both.
+func (c AuthentikBackupConfigS3) validate() error {
+	hasInlineKeys := c.Credentials.AccessKeyID != "" || c.Credentials.SecretAccessKey != "" || c.Credentials.SessionToken != ""
+	switch {
+	case c.CredentialsSecretRef == nil && c.Credentials == (s3.Credentials{}):
+		return trace.BadParameter("one of credentials and credentialsSecretRef is required")
+	case c.CredentialsSecretRef != nil && hasInlineKeys:
+		return trace.BadParameter("credentials must not include keys when credentialsSecretRef is set")
+	}
+
+	return nil
+}
+
+// credentials returns the media bucket credentials, with the values held by the credentials secret applied
+// if one is referenced. It is called before the event starts, so that an invalid config or a missing secret
+// fails it before any resource is created.
+func (c AuthentikBackupConfigS3) credentials(ctx *contexts.Context, kubeCluster kubecluster.ClientInterface, namespace string) (*s3.Credentials, error) {
+	if err := c.validate(); err != nil {
+		return nil, trace.Wrap(err, "invalid s3 configuration")
+	}
+
+	if c.CredentialsSecretRef == nil {
+		return &c.Credentials, nil
+	}
+
+	credentials, err := disasterrecovery.ResolveS3CredentialsSecretRef(ctx, kubeCluster.Core(), namespace, c.Credentials, c.CredentialsSecretRef)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to resolve media bucket credentials")
+	}
+
+	return &credentials, nil
 }
 
 type AuthentikBackupConfig struct {
@@ -30,6 +68,7 @@ type AuthentikBackupConfig struct {
 	BackupSnapshot     disasterrecovery.OptionsBackupSnapshot `yaml:"backupSnapshot" jsonschema:"omitempty"`
 	BackupToolInstance ConfigBTI                              `yaml:"backupToolInstance,omitempty"`
 	CleanupTimeout     helpers.MaxWaitTime                    `yaml:"cleanupTimeout,omitempty"`
+	Export             *disasterrecovery.OffsiteExport        `yaml:"export,omitempty"`
 }
 
 type AuthentikRestoreConfigCNPG struct {
@@ -56,6 +95,11 @@ func NewAuthentikDRCommand() *AuthentikDRCommand {
 	aBackup := func(ctx *contexts.Context, config AuthentikBackupConfig, kubeCluster kubecluster.ClientInterface) error {
 		a := disasterrecovery.NewAuthentik(kubeCluster)
 
+		credentials, err := config.S3.credentials(ctx.Child(), kubeCluster, config.Namespace)
+		if err != nil {
+			return err
+		}
+
 		opts := disasterrecovery.AuthentikBackupOptions{
 			VolumeSize:              config.BackupVolume.Size,
 			VolumeStorageClass:      config.BackupVolume.StorageClass,
@@ -63,10 +107,11 @@ func NewAuthentikDRCommand() *AuthentikDRCommand {
 			RemoteBackupToolOptions: config.BackupToolInstance.CreationOptions,
 			BackupSnapshot:          config.BackupSnapshot,
 			CleanupTimeout:          config.CleanupTimeout,
+			Export:                  config.Export,
 		}
 
-		_, err := a.Backup(ctx, config.Namespace, config.BackupName, config.Cluster.Name,
-			config.S3.S3Path, &config.S3.Credentials, opts)
+		_, err = a.Backup(ctx, config.Namespace, config.BackupName, config.Cluster.Name,
+			config.S3.S3Path, credentials, opts)
 
 		return err
 	}
@@ -74,14 +119,19 @@ func NewAuthentikDRCommand() *AuthentikDRCommand {
 	aRestore := func(ctx *contexts.Context, config AuthentikRestoreConfig, kubeCluster kubecluster.ClientInterface) error {
 		a := disasterrecovery.NewAuthentik(kubeCluster)
 
+		credentials, err := config.S3.credentials(ctx.Child(), kubeCluster, config.Namespace)
+		if err != nil {
+			return err
+		}
+
 		opts := disasterrecovery.AuthentikRestoreOptions{
 			PostgresUserCert:        config.Cluster.PostgresUserCertOptions,
 			RemoteBackupToolOptions: config.BackupToolInstance.CreationOptions,
 			CleanupTimeout:          config.CleanupTimeout,
 		}
 
-		_, err := a.Restore(ctx, config.Namespace, config.BackupName, config.Cluster.Name, config.Cluster.ServingCertName,
-			config.Cluster.ClientCAIssuer, config.S3.S3Path, &config.S3.Credentials, opts)
+		_, err = a.Restore(ctx, config.Namespace, config.BackupName, config.Cluster.Name, config.Cluster.ServingCertName,
+			config.Cluster.ClientCAIssuer, config.S3.S3Path, credentials, opts)
 
 		return err
 	}
@@ -3,6 +3,8 @@ package disasterrecovery
 import (
 	"testing"
 
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,3 +38,51 @@ func TestAuthentikDRCommandGetRestoreCommand(t *testing.T) {
 	require.NotNil(t, cmd)
 	assert.Implements(t, (*DREventGenerateSchemaCommand)(nil), cmd)
 }
+
+func TestAuthentikBackupConfigS3Validate(t *testing.T) {
+	tests := []struct {
+		desc      string
+		config    AuthentikBackupConfigS3
+		shouldErr bool
+	}{
+		{
+			desc:   "inline credentials",
+			config: AuthentikBackupConfigS3{Credentials: *s3.NewCredentials("id", "secret")},
+		},
+		{
+			desc:   "credentials secret",
+			config: AuthentikBackupConfigS3{CredentialsSecretRef: &disasterrecovery.SecretRef{Name: "creds"}},
+		},
+		{
+			desc: "credentials secret with an inline endpoint",
+			config: AuthentikBackupConfigS3{
+				Credentials:          s3.Credentials{Endpoint: "https://s3.example.com"},
+				CredentialsSecretRef: &disasterrecovery.SecretRef{Name: "creds"},
+			},
+		},
+		{
+			desc:      "neither",
+			shouldErr: true,
+		},
+		{
+			desc: "both",
+			config: AuthentikBackupConfigS3{
+				Credentials:          *s3.NewCredentials("id", "secret"),
+				CredentialsSecretRef: &disasterrecovery.SecretRef{Name: "creds"},
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.config.validate()
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
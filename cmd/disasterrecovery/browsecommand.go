@@ -0,0 +1,135 @@
+package disasterrecovery
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/cli/features"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/browse"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/spf13/cobra"
+)
+
+type ClusterDRBrowseCommandRun func(ctx *contexts.Context, kubeCluster kubecluster.ClientInterface, namespace, snapshotName string, visit browse.VisitFunc, opts browse.BrowseOptions) error
+
+// Lists the contents of a backup snapshot without copying any data out of it.
+type ClusterDRBrowseCommand struct {
+	name         string
+	run          ClusterDRBrowseCommandRun
+	output       io.Writer
+	kubeCluster  features.KubeClusterCommandInterface
+	context      features.ContextCommandInterface
+	snapshotName string
+	include      []string
+	exclude      []string
+	opts         browse.BrowseOptions
+}
+
+func NewClusterDRBrowseCommand(name string) *ClusterDRBrowseCommand {
+	return &ClusterDRBrowseCommand{
+		name: name,
+		run: func(ctx *contexts.Context, kubeCluster kubecluster.ClientInterface, namespace, snapshotName string, visit browse.VisitFunc, opts browse.BrowseOptions) error {
+			return disasterrecovery.NewSnapshotBrowser(kubeCluster).Browse(ctx, namespace, snapshotName, visit, opts)
+		},
+		output:      os.Stdout,
+		context:     features.NewContextCommand(true),
+		kubeCluster: features.NewKubeClusterCommand(),
+	}
+}
+
+func (cdrbc *ClusterDRBrowseCommand) ConfigureFlags(cmd *cobra.Command) {
+	cdrbc.context.ConfigureFlags(cmd)
+	cdrbc.kubeCluster.ConfigureFlags(cmd)
+
+	cmd.Flags().StringVar(&cdrbc.snapshotName, "snapshot", "", "Name of the backup volume snapshot to browse, in the namespace selected by --kube-namespace.")
+	_ = cmd.MarkFlagRequired("snapshot")
+	cmd.Flags().IntVar(&cdrbc.opts.MaxDepth, "max-depth", 0, "Maximum depth to list. Zero means unlimited.")
+	cmd.Flags().StringSliceVar(&cdrbc.include, "include", nil, "Only list files matching these glob patterns. Directories are always listed.")
+	cmd.Flags().StringSliceVar(&cdrbc.exclude, "exclude", nil, "Omit entries matching these glob patterns. Excluded directories are not descended into.")
+	cmd.Flags().StringVar(&cdrbc.opts.StorageClassName, "storage-class", "", "Storage class of the temporary volume created from the snapshot. Defaults to the storage class of the snapshotted volume.")
+}
+
+func filePatternsFromGlobs(globs []string) []files.FilePattern {
+	if len(globs) == 0 {
+		return nil
+	}
+
+	patterns := make([]files.FilePattern, len(globs))
+	for i, glob := range globs {
+		patterns[i] = files.FilePattern{Glob: glob}
+	}
+	return patterns
+}
+
+// formatTreeEntry renders an entry similarly to `ls -l`: mode, owner, group, size, modification time
+// and path (plus the target, for symlinks).
+func formatTreeEntry(entry files.TreeEntry) string {
+	mode := entry.Mode
+	switch entry.Type {
+	case files.EntryTypeDirectory:
+		mode |= fs.ModeDir
+	case files.EntryTypeSymlink:
+		mode |= fs.ModeSymlink
+	case files.EntryTypeOther:
+		mode |= fs.ModeIrregular
+	}
+
+	line := fmt.Sprintf("%s %6d %6d %12d %s %s", mode, entry.UID, entry.GID, entry.Size, entry.ModTime.UTC().Format(time.RFC3339), entry.Path)
+	if entry.Type == files.EntryTypeSymlink {
+		line += " -> " + entry.LinkTarget
+	}
+	return line
+}
+
+func (cdrbc *ClusterDRBrowseCommand) visit(entries []files.TreeEntry) error {
+	for _, entry := range entries {
+		if _, err := fmt.Fprintln(cdrbc.output, formatTreeEntry(entry)); err != nil {
+			return trace.Wrap(err, "failed to write entry %q", entry.Path)
+		}
+	}
+	return nil
+}
+
+func (cdrbc *ClusterDRBrowseCommand) Run() error {
+	ctx, cancel := cdrbc.context.GetCommandContext()
+	defer cancel()
+
+	namespace, err := cdrbc.kubeCluster.GetNamespace()
+	if err != nil {
+		return trace.Wrap(err, "failed to get kubernetes namespace")
+	}
+
+	kubeCluster, err := cdrbc.kubeCluster.NewKubeClusterClient()
+	if err != nil {
+		return trace.Wrap(err, "failed to create new kubernetes cluster client")
+	}
+
+	opts := cdrbc.opts
+	opts.Filter = files.FileFilter{
+		Include: filePatternsFromGlobs(cdrbc.include),
+		Exclude: filePatternsFromGlobs(cdrbc.exclude),
+	}
+
+	err = cdrbc.run(ctx, kubeCluster, namespace, cdrbc.snapshotName, cdrbc.visit, opts)
+	return trace.Wrap(err, "failed to browse %s snapshot %q", cdrbc.name, cdrbc.snapshotName)
+}
+
+func buildDRBrowseCommand(browseCmd DREventCommand, drName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: fmt.Sprintf("List the contents of a %s backup snapshot", drName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return browseCmd.Run()
+		},
+	}
+	browseCmd.ConfigureFlags(cmd)
+
+	return cmd
+}
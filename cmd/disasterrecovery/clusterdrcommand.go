@@ -6,6 +6,7 @@ import (
 	"github.com/gravitational/trace"
 	"github.com/solidDoWant/backup-tool/pkg/cli/features"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/spf13/cobra"
 )
@@ -96,3 +97,16 @@ func (cdrc *ClusterDRCommand[TBackupConfig, TRestoreConfig]) GetBackupCommand()
 func (cdrc *ClusterDRCommand[TBackupConfig, TRestoreConfig]) GetRestoreCommand() DREventCommand {
 	return NewClusterDREventCommand(cdrc.Name(), cdrc.restoreCommand)
 }
+
+func (cdrc *ClusterDRCommand[TBackupConfig, TRestoreConfig]) GetBrowseCommand() DREventCommand {
+	return NewClusterDRBrowseCommand(cdrc.Name())
+}
+
+// GetImportCommand rebuilds a DR volume from an exported backup. Exports hold the DR volume's contents
+// whatever the app, so the command is the same for every app.
+func (cdrc *ClusterDRCommand[TBackupConfig, TRestoreConfig]) GetImportCommand() DREventCommand {
+	return NewClusterDREventCommand(cdrc.Name(), func(ctx *contexts.Context, config disasterrecovery.ImportConfig, kubeCluster kubecluster.ClientInterface) error {
+		_, err := disasterrecovery.NewBackupImporter(kubeCluster).Import(ctx, config)
+		return err
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/solidDoWant/backup-tool/pkg/cli/features"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -131,6 +132,8 @@ func TestClusterDRCommand(t *testing.T) {
 	assert.Implements(t, (*DRCommand)(nil), cmd)
 	assert.Implements(t, (*DRBackupCommand)(nil), cmd)
 	assert.Implements(t, (*DRRestoreCommand)(nil), cmd)
+	assert.Implements(t, (*DRBrowseCommand)(nil), cmd)
+	assert.Implements(t, (*DRImportCommand)(nil), cmd)
 }
 
 func TestNewClusterDRCommand(t *testing.T) {
@@ -177,3 +180,20 @@ func TestClusterDRCommandGetRestoreCommand(t *testing.T) {
 	require.NotNil(t, cmd)
 	assert.Error(t, cmd.Run())
 }
+
+func TestClusterDRCommandGetBrowseCommand(t *testing.T) {
+	cmdName := "test-command"
+
+	cmd := NewClusterDRCommand[interface{}, interface{}](cmdName, nil, nil).GetBrowseCommand()
+	require.NotNil(t, cmd)
+	assert.IsType(t, &ClusterDRBrowseCommand{}, cmd)
+}
+
+func TestClusterDRCommandGetImportCommand(t *testing.T) {
+	cmdName := "test-command"
+
+	cmd := NewClusterDRCommand[interface{}, interface{}](cmdName, nil, nil).GetImportCommand()
+	require.NotNil(t, cmd)
+	assert.IsType(t, &ClusterDREventCommand[disasterrecovery.ImportConfig]{}, cmd)
+	assert.Implements(t, (*DREventGenerateSchemaCommand)(nil), cmd)
+}
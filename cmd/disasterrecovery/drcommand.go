@@ -18,6 +18,16 @@ type DRRestoreCommand interface {
 	GetRestoreCommand() DREventCommand
 }
 
+type DRBrowseCommand interface {
+	DRCommand
+	GetBrowseCommand() DREventCommand
+}
+
+type DRImportCommand interface {
+	DRCommand
+	GetImportCommand() DREventCommand
+}
+
 func buildDRCommand(drCmd DRCommand) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   drCmd.Name(),
@@ -33,6 +43,14 @@ func buildDRCommand(drCmd DRCommand) *cobra.Command {
 		cmd.AddCommand(buildDREventCommand(restoreDRCmd.GetRestoreCommand(), drCmd.Name(), "restore"))
 	}
 
+	if browseDRCmd, ok := drCmd.(DRBrowseCommand); ok {
+		cmd.AddCommand(buildDRBrowseCommand(browseDRCmd.GetBrowseCommand(), drCmd.Name()))
+	}
+
+	if importDRCmd, ok := drCmd.(DRImportCommand); ok {
+		cmd.AddCommand(buildDREventCommand(importDRCmd.GetImportCommand(), drCmd.Name(), "import"))
+	}
+
 	if len(cmd.Commands()) == 0 {
 		return nil
 	}
@@ -23,6 +23,14 @@ func TestBuildDRCommand(t *testing.T) {
 	restoreEventCommand.EXPECT().Name().Return("restore-event-command")
 	restoreEventCommand.EXPECT().GetRestoreCommand().Return(mockEventCommand)
 
+	browseEventCommand := NewMockDRBrowseCommand(t)
+	browseEventCommand.EXPECT().Name().Return("browse-event-command")
+	browseEventCommand.EXPECT().GetBrowseCommand().Return(mockEventCommand)
+
+	importEventCommand := NewMockDRImportCommand(t)
+	importEventCommand.EXPECT().Name().Return("import-event-command")
+	importEventCommand.EXPECT().GetImportCommand().Return(mockEventCommand)
+
 	tests := []struct {
 		desc                 string
 		command              DRCommand
@@ -44,6 +52,16 @@ func TestBuildDRCommand(t *testing.T) {
 			command:              restoreEventCommand,
 			expectedCommandCount: 1,
 		},
+		{
+			desc:                 "browse event",
+			command:              browseEventCommand,
+			expectedCommandCount: 1,
+		},
+		{
+			desc:                 "import event",
+			command:              importEventCommand,
+			expectedCommandCount: 1,
+		},
 	}
 
 	for _, tt := range tests {
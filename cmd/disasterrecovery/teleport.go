@@ -5,6 +5,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery"
 	cnpgrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/restore"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
@@ -12,8 +13,10 @@ import (
 )
 
 type TeleportConfigAuditSessionLogs struct {
-	S3Path      string         `yaml:"s3Path,omitempty"`
-	Credentials s3.Credentials `yaml:"credentials,omitempty"`
+	S3Path               string                      `yaml:"s3Path,omitempty"`
+	Credentials          s3.Credentials              `yaml:"credentials,omitempty"`
+	CredentialsSecretRef *disasterrecovery.SecretRef `yaml:"credentialsSecretRef,omitempty"`
+	files.FileFilter     `yaml:",inline"`
 }
 
 type TeleportBackupConfigClusterConfig struct {
@@ -35,6 +38,7 @@ type TeleportBackupConfig struct {
 	CloneClusterOptions clonedcluster.CloneClusterOptions      `yaml:"clusterCloning,omitempty"`
 	BackupToolInstance  ConfigBTI                              `yaml:"backupToolInstance,omitempty"`
 	CleanupTimeout      helpers.MaxWaitTime                    `yaml:"cleanupTimeout,omitempty"`
+	Export              *disasterrecovery.OffsiteExport        `yaml:"export,omitempty"`
 }
 
 type TeleportRestoreClusterConfig struct {
@@ -78,13 +82,16 @@ func NewTeleportDRCommand() *TeleportDRCommand {
 				},
 			},
 			AuditSessionLogs: disasterrecovery.TeleportOptionsS3Sync{
-				Enabled:     config.AuditSessionLogs.S3Path != "",
-				S3Path:      config.AuditSessionLogs.S3Path,
-				Credentials: config.AuditSessionLogs.Credentials,
+				Enabled:              config.AuditSessionLogs.S3Path != "",
+				S3Path:               config.AuditSessionLogs.S3Path,
+				Credentials:          config.AuditSessionLogs.Credentials,
+				CredentialsSecretRef: config.AuditSessionLogs.CredentialsSecretRef,
+				FileFilter:           config.AuditSessionLogs.FileFilter,
 			},
 			RemoteBackupToolOptions: config.BackupToolInstance.CreationOptions,
 			BackupSnapshot:          config.BackupSnapshot,
 			CleanupTimeout:          config.CleanupTimeout,
+			Export:                  config.Export,
 		}
 
 		_, err := t.Backup(ctx, config.Namespace, config.BackupName, config.CNPGClusters.Core.CNPGClusterName, opts)
@@ -107,9 +114,11 @@ func NewTeleportDRCommand() *TeleportDRCommand {
 					PostgresUserCert: config.CNPGClusters.Audit.ClusterUserCert,
 				},
 				AuditSessionLogs: disasterrecovery.TeleportOptionsS3Sync{
-					Enabled:     config.AuditSessionLogs.S3Path != "",
-					S3Path:      config.AuditSessionLogs.S3Path,
-					Credentials: config.AuditSessionLogs.Credentials,
+					Enabled:              config.AuditSessionLogs.S3Path != "",
+					S3Path:               config.AuditSessionLogs.S3Path,
+					Credentials:          config.AuditSessionLogs.Credentials,
+					CredentialsSecretRef: config.AuditSessionLogs.CredentialsSecretRef,
+					FileFilter:           config.AuditSessionLogs.FileFilter,
 				},
 				PostgresUserCert:        config.CNPGClusters.Core.ClusterUserCert,
 				RemoteBackupToolOptions: config.BackupToolInstance.CreationOptions,
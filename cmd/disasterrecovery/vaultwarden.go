@@ -24,6 +24,7 @@ type VaultWardenBackupConfig struct {
 	BackupSnapshot     disasterrecovery.OptionsBackupSnapshot `yaml:"backupSnapshot" jsonschema:"omitempty"`
 	BackupToolInstance ConfigBTI                              `yaml:"backupToolInstance,omitempty"`
 	CleanupTimeout     helpers.MaxWaitTime                    `yaml:"cleanupTimeout,omitempty"`
+	Export             *disasterrecovery.OffsiteExport        `yaml:"export,omitempty"`
 }
 
 type VaultWardenRestoreConfigCNPG struct {
@@ -57,6 +58,7 @@ func NewVaultWardenDRCommand() *VaultWardenDRCommand {
 			RemoteBackupToolOptions: config.BackupToolInstance.CreationOptions,
 			BackupSnapshot:          config.BackupSnapshot,
 			CleanupTimeout:          config.CleanupTimeout,
+			Export:                  config.Export,
 		}
 
 		_, err := vw.Backup(ctx, config.Namespace, config.BackupName, config.DataPVCName, config.Cluster.Name, opts)
@@ -14,6 +14,7 @@ import (
 type KubernetesCommandInterface interface {
 	ConfigureFlags(cmd *cobra.Command)
 	GetClusterConfig() (*rest.Config, error)
+	GetNamespace() (string, error)
 }
 
 // Gives a command the ability to interact with Kubernetes API.
@@ -40,20 +41,28 @@ func (kc *KubernetesCommand) ConfigureFlags(cmd *cobra.Command) {
 	cmd.Flags().AddFlagSet(kubeFlags)
 }
 
-// Get the cluster configurations from the following sources, in order of precedence:
-// 1. A provided "kubeconfig" flag
-// 2. The default kubeconfig file, at ~/.kube/config
-// 3. The in-cluster kubeconfig, if running in a pod
-func (kc *KubernetesCommand) GetClusterConfig() (*rest.Config, error) {
+func (kc *KubernetesCommand) getClientConfig() clientcmd.ClientConfig {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	loadingRules.ExplicitPath = kc.ExplicitConfigPath
 
-	var clientConfig clientcmd.ClientConfig
 	if term.IsTerminal(int(os.Stdin.Fd())) {
-		clientConfig = clientcmd.NewInteractiveDeferredLoadingClientConfig(loadingRules, &kc.ConfigOverrides, os.Stdin)
-	} else {
-		clientConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &kc.ConfigOverrides)
+		return clientcmd.NewInteractiveDeferredLoadingClientConfig(loadingRules, &kc.ConfigOverrides, os.Stdin)
 	}
 
-	return clientConfig.ClientConfig()
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &kc.ConfigOverrides)
+}
+
+// Get the cluster configurations from the following sources, in order of precedence:
+// 1. A provided "kubeconfig" flag
+// 2. The default kubeconfig file, at ~/.kube/config
+// 3. The in-cluster kubeconfig, if running in a pod
+func (kc *KubernetesCommand) GetClusterConfig() (*rest.Config, error) {
+	return kc.getClientConfig().ClientConfig()
+}
+
+// Get the namespace to operate in. This is the "kube-namespace" flag if provided, otherwise the namespace
+// of the current kubeconfig context (or the pod's namespace, when running in-cluster).
+func (kc *KubernetesCommand) GetNamespace() (string, error) {
+	namespace, _, err := kc.getClientConfig().Namespace()
+	return namespace, err
 }
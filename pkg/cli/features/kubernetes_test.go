@@ -1,12 +1,15 @@
 package features
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestKubernetesCommand(t *testing.T) {
@@ -27,3 +30,16 @@ func TestKubernetesCommandConfigureFlags(t *testing.T) {
 		assert.True(t, strings.HasPrefix(f.Name, "kube"))
 	})
 }
+
+func TestKubernetesCommandGetNamespace(t *testing.T) {
+	// Don't read the environment's kubeconfig
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, nil, 0600))
+
+	kc := &KubernetesCommand{ExplicitConfigPath: kubeconfigPath}
+	kc.ConfigOverrides.Context.Namespace = "test-namespace"
+
+	namespace, err := kc.GetNamespace()
+	assert.NoError(t, err)
+	assert.Equal(t, "test-namespace", namespace)
+}
@@ -112,6 +112,61 @@ func (_c *MockKubernetesCommandInterface_GetClusterConfig_Call) RunAndReturn(run
 	return _c
 }
 
+// GetNamespace provides a mock function with no fields
+func (_m *MockKubernetesCommandInterface) GetNamespace() (string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespace")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKubernetesCommandInterface_GetNamespace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNamespace'
+type MockKubernetesCommandInterface_GetNamespace_Call struct {
+	*mock.Call
+}
+
+// GetNamespace is a helper method to define mock.On call
+func (_e *MockKubernetesCommandInterface_Expecter) GetNamespace() *MockKubernetesCommandInterface_GetNamespace_Call {
+	return &MockKubernetesCommandInterface_GetNamespace_Call{Call: _e.mock.On("GetNamespace")}
+}
+
+func (_c *MockKubernetesCommandInterface_GetNamespace_Call) Run(run func()) *MockKubernetesCommandInterface_GetNamespace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockKubernetesCommandInterface_GetNamespace_Call) Return(_a0 string, _a1 error) *MockKubernetesCommandInterface_GetNamespace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKubernetesCommandInterface_GetNamespace_Call) RunAndReturn(run func() (string, error)) *MockKubernetesCommandInterface_GetNamespace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockKubernetesCommandInterface creates a new instance of MockKubernetesCommandInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockKubernetesCommandInterface(t interface {
@@ -0,0 +1,209 @@
+package browse
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/cleanup"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonepvc"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const defaultPageSize = 1000
+
+// VisitFunc is called with each page of entries listed from the snapshot, in listing order.
+type VisitFunc func(entries []files.TreeEntry) error
+
+type BrowseOptions struct {
+	MaxDepth               int                 `yaml:"maxDepth,omitempty"`         // Limits how deep the listing descends. Zero means unlimited.
+	Filter                 files.FileFilter    `yaml:",inline"`                    // Selects which entries are listed.
+	PageSize               int                 `yaml:"pageSize,omitempty"`         // Number of entries requested per call. Defaults to 1000.
+	StorageClassName       string              `yaml:"storageClassName,omitempty"` // Override the storage class of the volume created from the snapshot.
+	WaitForSnapshotTimeout helpers.MaxWaitTime `yaml:"waitForSnapshotTimeout,omitempty"`
+	CleanupTimeout         helpers.MaxWaitTime `yaml:"cleanupTimeout,omitempty"`
+}
+
+// BrowseInterface is a RemoteStage action that lists the contents of a volume snapshot (typically a DR
+// volume snapshot) without copying any data out of it. The snapshot is restored to a temporary PVC, which
+// is mounted into the tool pod and listed page by page. The temporary PVC is removed on Cleanup.
+type BrowseInterface interface {
+	remote.CleanupAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace, snapshotName string, visit VisitFunc, opts BrowseOptions) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	snapshotName      string
+	visit             VisitFunc
+	opts              BrowseOptions
+}
+
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace, snapshotName string, visit VisitFunc, opts BrowseOptions) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.snapshotName = snapshotName
+	cs.visit = visit
+	cs.opts = opts
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("snapshot", cs.snapshotName, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for browse")
+	defer ctx.Log.Info("Completed browse configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if vs.snapshotName == "" {
+		return trace.BadParameter("no snapshot name provided")
+	}
+
+	if vs.visit == nil {
+		return trace.BadParameter("no visit function provided")
+	}
+
+	if vs.opts.MaxDepth < 0 {
+		return trace.BadParameter("max depth must not be negative")
+	}
+
+	if vs.opts.PageSize < 0 {
+		return trace.BadParameter("page size must not be negative")
+	}
+
+	if err := vs.opts.Filter.Validate(); err != nil {
+		return trace.Wrap(err, "invalid filter")
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+type setupState struct {
+	validateState
+	restoredPVC *corev1.PersistentVolumeClaim
+	mountPath   string
+	isSetup     bool
+}
+
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for browse")
+	defer ctx.Log.Info("Browse setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	// The snapshot is not deleted afterwards, so the restored PVC does not need to be force bound. It
+	// will bind when the tool pod is scheduled.
+	restoredPVC, err := ss.kubeClusterClient.RestoreSnapshot(ctx.Child(), ss.namespace, ss.snapshotName, clonepvc.RestoreSnapshotOptions{
+		WaitForSnapshotTimeout: ss.opts.WaitForSnapshotTimeout,
+		DestStorageClassName:   ss.opts.StorageClassName,
+	})
+	if err != nil {
+		return trace.Wrap(err, "failed to restore snapshot %q to a new PVC", helpers.FullNameStr(ss.namespace, ss.snapshotName))
+	}
+	ss.restoredPVC = restoredPVC
+
+	ss.mountPath = filepath.Join("/mnt", "browse", ss.uid)
+	btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.restoredPVC.Name, ss.mountPath))
+
+	ss.isSetup = true
+	return nil
+}
+
+// Cleanup tears down the PVC restored from the snapshot. It tolerates partial state (e.g. the action
+// never reached Setup because another action failed first), deleting nothing in that case.
+func (ss *setupState) Cleanup(ctx *contexts.Context) error {
+	if ss.restoredPVC == nil {
+		return nil
+	}
+
+	err := cleanup.To(func(ctx *contexts.Context) error {
+		return ss.kubeClusterClient.Core().DeletePVC(ctx, ss.namespace, ss.restoredPVC.Name)
+	}).WithErrMessage("failed to cleanup restored snapshot PVC %q", helpers.FullName(ss.restoredPVC)).
+		WithParentCtx(ctx).WithTimeout(ss.opts.CleanupTimeout.MaxWait(time.Minute)).
+		RunError()
+	return trace.Wrap(err, "failed to cleanup browse resources")
+}
+
+type executeState struct {
+	setupState
+}
+
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing browse")
+	defer ctx.Log.Info("Browse complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	pageSize := es.opts.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	listOpts := files.ListTreeOptions{
+		MaxDepth: es.opts.MaxDepth,
+		Filter:   es.opts.Filter,
+		PageSize: pageSize,
+	}
+
+	for {
+		result, err := backupToolClient.Files().ListTree(ctx.Child(), es.mountPath, listOpts)
+		if err != nil {
+			return trace.Wrap(err, "failed to list the contents of snapshot %q", es.snapshotName)
+		}
+
+		if err := es.visit(result.Entries); err != nil {
+			return trace.Wrap(err, "failed to process the contents of snapshot %q", es.snapshotName)
+		}
+
+		if result.NextPageToken == "" {
+			return nil
+		}
+		listOpts.PageToken = result.NextPageToken
+	}
+}
+
+type Browse struct {
+	executeState
+}
+
+func NewBrowse() BrowseInterface {
+	return &Browse{}
+}
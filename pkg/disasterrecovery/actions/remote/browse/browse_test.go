@@ -0,0 +1,397 @@
+package browse
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonepvc"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBrowseOptions(t *testing.T) {
+	th.OptStructTest[BrowseOptions](t)
+}
+
+func noopVisit([]files.TreeEntry) error {
+	return nil
+}
+
+func TestConfigure(t *testing.T) {
+	expectedState := &configureState{
+		kubeClusterClient: kubecluster.NewMockClientInterface(t),
+		namespace:         "namespace",
+		snapshotName:      "snapshotName",
+		opts: BrowseOptions{
+			MaxDepth:       2,
+			CleanupTimeout: helpers.ShortWaitTime,
+		},
+	}
+
+	b := NewBrowse()
+	err := b.Configure(
+		expectedState.kubeClusterClient,
+		expectedState.namespace,
+		expectedState.snapshotName,
+		noopVisit,
+		expectedState.opts,
+	)
+
+	t.Run("successfully configures the first time", func(t *testing.T) {
+		require.NoError(t, err)
+	})
+
+	t.Run("all state vars are populated", func(t *testing.T) {
+		casted := b.(*Browse)
+
+		assert.NotEqual(t, "", casted.uid)
+		assert.NotEqual(t, uuid.Nil.String(), casted.uid)
+		expectedState.uid = casted.uid
+
+		assert.True(t, casted.isConfigured)
+		expectedState.isConfigured = casted.isConfigured
+
+		// Functions cannot be compared, so check it separately
+		actualState := casted.configureState
+		assert.NotNil(t, actualState.visit)
+		actualState.visit = nil
+
+		assert.Equal(t, expectedState, &actualState)
+	})
+
+	t.Run("fails to configure because already configured", func(t *testing.T) {
+		err = b.Configure(
+			expectedState.kubeClusterClient,
+			expectedState.namespace,
+			expectedState.snapshotName,
+			noopVisit,
+			expectedState.opts,
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc               string
+		notConfigured      bool
+		isAlreadyValidated bool
+		snapshotName       string
+		visit              VisitFunc
+		opts               BrowseOptions
+		shouldErr          bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:               "succeeds if called multiple times",
+			isAlreadyValidated: true,
+		},
+		{
+			desc:          "fails because not configured",
+			notConfigured: true,
+			shouldErr:     true,
+		},
+		{
+			desc:         "fails without a snapshot name",
+			snapshotName: "-",
+			shouldErr:    true,
+		},
+		{
+			desc:      "fails with a negative max depth",
+			opts:      BrowseOptions{MaxDepth: -1},
+			shouldErr: true,
+		},
+		{
+			desc:      "fails with a negative page size",
+			opts:      BrowseOptions{PageSize: -1},
+			shouldErr: true,
+		},
+		{
+			desc:      "fails with an invalid filter",
+			opts:      BrowseOptions{Filter: files.FileFilter{Exclude: []files.FilePattern{{Glob: "["}}}},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			snapshotName := "snapshotName"
+			if tt.snapshotName == "-" {
+				snapshotName = ""
+			}
+
+			currentState := &validateState{isValidated: tt.isAlreadyValidated}
+			if !tt.notConfigured {
+				require.NoError(t, currentState.Configure(kubecluster.NewMockClientInterface(t), "namespace", snapshotName, noopVisit, tt.opts))
+			}
+
+			err := currentState.Validate(th.NewTestContext())
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isValidated)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		desc               string
+		notValidated       bool
+		isAlreadySetup     bool
+		simulateRestoreErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:         "fails because not validated first",
+			notValidated: true,
+		},
+		{
+			desc:           "fails if called multiple times",
+			isAlreadySetup: true,
+		},
+		{
+			desc:               "fails to restore the snapshot",
+			simulateRestoreErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			restoredPVC := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "restored-pvc"}}
+
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:               "uid",
+						isConfigured:      true,
+						kubeClusterClient: mockClient,
+						namespace:         "namespace",
+						snapshotName:      "snapshotName",
+						visit:             noopVisit,
+						opts: BrowseOptions{
+							StorageClassName:       "storage-class",
+							WaitForSnapshotTimeout: helpers.ShortWaitTime,
+						},
+					},
+					isValidated: !tt.notValidated,
+				},
+				isSetup: tt.isAlreadySetup,
+			}
+
+			ctx := th.NewTestContext()
+			if !tt.notValidated && !tt.isAlreadySetup {
+				mockClient.EXPECT().RestoreSnapshot(mock.Anything, "namespace", "snapshotName", clonepvc.RestoreSnapshotOptions{
+					WaitForSnapshotTimeout: helpers.ShortWaitTime,
+					DestStorageClassName:   "storage-class",
+				}).RunAndReturn(func(calledCtx *contexts.Context, namespace, snapshotName string, opts clonepvc.RestoreSnapshotOptions) (*corev1.PersistentVolumeClaim, error) {
+					assert.True(t, calledCtx.IsChildOf(ctx))
+					return th.ErrOr1Val(restoredPVC, tt.simulateRestoreErr)
+				})
+			}
+
+			btiOpts := &backuptoolinstance.CreateBackupToolInstanceOptions{}
+			err := currentState.Setup(ctx, btiOpts)
+			if th.ErrExpected(tt.notValidated, tt.isAlreadySetup, tt.simulateRestoreErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isSetup)
+			assert.Equal(t, restoredPVC, currentState.restoredPVC)
+			assert.Contains(t, currentState.mountPath, currentState.uid)
+
+			require.Len(t, btiOpts.Volumes, 1)
+			assert.Equal(t, []string{currentState.mountPath}, btiOpts.Volumes[0].MountPaths)
+			require.NotNil(t, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim)
+			assert.Equal(t, restoredPVC.Name, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName)
+		})
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	tests := []struct {
+		desc            string
+		nothingRestored bool
+		simulateDelErr  bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:            "succeeds and does nothing if nothing was restored",
+			nothingRestored: true,
+		},
+		{
+			desc:           "fails to delete the restored PVC",
+			simulateDelErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockCoreClient := core.NewMockClientInterface(t)
+
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:               "uid",
+						isConfigured:      true,
+						kubeClusterClient: mockClient,
+						namespace:         "namespace",
+						snapshotName:      "snapshotName",
+						opts:              BrowseOptions{CleanupTimeout: helpers.ShortWaitTime},
+					},
+					isValidated: true,
+				},
+			}
+
+			if !tt.nothingRestored {
+				currentState.restoredPVC = &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "restored-pvc", Namespace: "namespace"}}
+				mockClient.EXPECT().Core().Return(mockCoreClient)
+				mockCoreClient.EXPECT().DeletePVC(mock.Anything, currentState.namespace, currentState.restoredPVC.Name).
+					Return(th.ErrIfTrue(tt.simulateDelErr))
+			}
+
+			err := currentState.Cleanup(th.NewTestContext())
+			if tt.simulateDelErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	firstPage := []files.TreeEntry{{Path: "a", Type: files.EntryTypeDirectory}}
+	secondPage := []files.TreeEntry{{Path: "a/b", Type: files.EntryTypeFile}}
+
+	tests := []struct {
+		desc             string
+		hasNotBeenSetup  bool
+		pageSize         int
+		simulateListErr  bool
+		simulateVisitErr bool
+	}{
+		{
+			desc: "succeeds with the default page size",
+		},
+		{
+			desc:     "succeeds with a custom page size",
+			pageSize: 5,
+		},
+		{
+			desc:            "fails if not setup first",
+			hasNotBeenSetup: true,
+		},
+		{
+			desc:            "fails to list the tree",
+			simulateListErr: true,
+		},
+		{
+			desc:             "fails to visit the entries",
+			simulateVisitErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockFilesRuntime := files.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().Files().Return(mockFilesRuntime).Maybe()
+
+			var visited []files.TreeEntry
+			filter := files.FileFilter{Exclude: []files.FilePattern{{Glob: "*.tmp"}}}
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							kubeClusterClient: kubecluster.NewMockClientInterface(t),
+							namespace:         "namespace",
+							snapshotName:      "snapshotName",
+							visit: func(entries []files.TreeEntry) error {
+								visited = append(visited, entries...)
+								return th.ErrIfTrue(tt.simulateVisitErr)
+							},
+							opts: BrowseOptions{MaxDepth: 3, Filter: filter, PageSize: tt.pageSize},
+						},
+						isValidated: true,
+					},
+					mountPath: "/mnt/browse/uid",
+					isSetup:   !tt.hasNotBeenSetup,
+				},
+			}
+
+			ctx := th.NewTestContext()
+			func() {
+				if tt.hasNotBeenSetup {
+					return
+				}
+
+				expectedPageSize := tt.pageSize
+				if expectedPageSize == 0 {
+					expectedPageSize = defaultPageSize
+				}
+				listOpts := files.ListTreeOptions{MaxDepth: 3, Filter: filter, PageSize: expectedPageSize}
+
+				mockFilesRuntime.EXPECT().ListTree(mock.Anything, currentState.mountPath, listOpts).
+					RunAndReturn(func(calledCtx *contexts.Context, path string, opts files.ListTreeOptions) (files.ListTreeResult, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return files.ListTreeResult{Entries: firstPage, NextPageToken: "a"}, th.ErrIfTrue(tt.simulateListErr)
+					}).Once()
+				if tt.simulateListErr || tt.simulateVisitErr {
+					return
+				}
+
+				listOpts.PageToken = "a"
+				mockFilesRuntime.EXPECT().ListTree(mock.Anything, currentState.mountPath, listOpts).
+					Return(files.ListTreeResult{Entries: secondPage}, nil).Once()
+			}()
+
+			err := currentState.Execute(ctx, mockGRPC)
+			if th.ErrExpected(tt.hasNotBeenSetup, tt.simulateListErr, tt.simulateVisitErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, append(append([]files.TreeEntry{}, firstPage...), secondPage...), visited)
+		})
+	}
+}
+
+func TestBrowse(t *testing.T) {
+	assert.Implements(t, (*BrowseInterface)(nil), (*Browse)(nil))
+	assert.Implements(t, (*remote.RemoteAction)(nil), (*Browse)(nil))
+	assert.Implements(t, (*remote.CleanupAction)(nil), (*Browse)(nil))
+}
+
+func TestNewBrowse(t *testing.T) {
+	assert.Equal(t, &Browse{}, NewBrowse())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/constants"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/common"
 	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
@@ -26,6 +27,71 @@ import (
 type CNPGBackupOptions struct {
 	CloningOpts    clonedcluster.CloneClusterOptions `yaml:"clusterCloning,omitempty"`
 	CleanupTimeout helpers.MaxWaitTime               `yaml:"cleanupTimeout,omitempty"`
+	Format         postgres.DumpFormat               `yaml:"format,omitempty"` // How the dump is written. Empty means plain.
+	Jobs           int                               `yaml:"jobs,omitempty"`   // Tables of each database dumped concurrently, with the directory format.
+	// Selection limits the dump to some of the cluster's databases, schemas and tables. Empty dumps everything.
+	Selection postgres.DatabaseSelection `yaml:"selection,omitempty"`
+	// Roles configures how the statements that involve roles are rewritten in the dump.
+	Roles postgres.RoleHandling `yaml:"roles,omitempty"`
+	// Mode selects how the cluster is backed up. Empty means logical.
+	Mode BackupMode `yaml:"mode,omitempty"`
+	// RestorePointName creates a restore point on the cluster, after the base backup and the consistency point,
+	// so that later restores can recover to it with a targetName recovery target. The base backup's name is
+	// appended to it so that every event's restore point is unique. The full name and location are logged, and
+	// recorded in a physical backup's manifest. Empty creates none.
+	RestorePointName string `yaml:"restorePointName,omitempty"`
+}
+
+// The longest restore point name that PostgreSQL allows.
+const maxRestorePointNameLength = 63
+
+// BackupMode selects how a CNPG cluster is backed up.
+type BackupMode string
+
+const (
+	// BackupModeLogical clones the cluster from its base backup and dumps the clone to the DR volume. The base
+	// backup is deleted once the event completes. This is the default.
+	BackupModeLogical BackupMode = "logical"
+	// BackupModePhysical keeps the base backup, and records it (and the event's consistency point) in a
+	// manifest on the DR volume instead of dumping the cluster. No clone is created, so this is much faster
+	// for large clusters, but the backup is only as durable as the base backup's volume snapshots. Kept base
+	// backups are never deleted, so old ones need to be deleted by hand.
+	BackupModePhysical BackupMode = "physical"
+)
+
+// Validate checks the mode, and the dump options that it uses. The physical mode doesn't dump the cluster,
+// so it doesn't support any of them. The clone is always recovered to the event's consistency point, so a
+// clone recovery target isn't supported either.
+func (opts CNPGBackupOptions) Validate() error {
+	if !opts.CloningOpts.RecoveryTarget.IsZero() {
+		return trace.BadParameter("a backup does not support a clone recovery target")
+	}
+
+	switch opts.Mode {
+	case "", BackupModeLogical:
+		return trace.Wrap(opts.dumpAllOptions().Validate(), "invalid dump options")
+	case BackupModePhysical:
+		if opts.Format != "" || opts.Jobs != 0 || !opts.Selection.IsZero() || !opts.Roles.IsZero() {
+			return trace.BadParameter("the %q backup mode does not support the format, jobs, selection or roles options", BackupModePhysical)
+		}
+		return nil
+	default:
+		return trace.BadParameter("invalid backup mode %q (must be %q or %q)", opts.Mode, BackupModeLogical, BackupModePhysical)
+	}
+}
+
+func (opts CNPGBackupOptions) isPhysical() bool {
+	return opts.Mode == BackupModePhysical
+}
+
+func (opts CNPGBackupOptions) dumpAllOptions() postgres.DumpAllOptions {
+	return postgres.DumpAllOptions{
+		CleanupTimeout: opts.CleanupTimeout,
+		Format:         opts.Format,
+		Jobs:           opts.Jobs,
+		Selection:      opts.Selection,
+		Roles:          opts.Roles,
+	}
 }
 
 // CNPGBackupInterface is a RemoteStage action. Beyond the base RemoteAction/CleanupAction contract it
@@ -85,6 +151,10 @@ func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
 		return trace.Errorf("attempted to validate without configuring")
 	}
 
+	if err := vs.opts.Validate(); err != nil {
+		return trace.Wrap(err, "invalid backup options")
+	}
+
 	cluster, err := vs.kubeClusterClient.CNPG().GetCluster(ctx.Child(), vs.namespace, vs.clusterName)
 	if err != nil {
 		return trace.Wrap(err, "failed to get CNPG cluster %q", vs.clusterName)
@@ -110,17 +180,20 @@ func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
 // and receives that point once the stage has stamped it.
 type baseBackupState struct {
 	validateState
+	sourceCluster    clonedcluster.SourceCluster // What the clone (or a later physical restore) copies from the cluster.
 	baseBackup       *apiv1.Backup
 	consistencyPoint time.Time // The shared consistency point the clone should recover forward to (zero until set).
 	isBaseBackedUp   bool
+	// Set once a physical backup has recorded the base backup in its manifest, after which it is kept.
+	isBaseBackupRetained bool
 }
 
 // BeforeConsistencyPoint takes the base backup that fixes this cluster's recoverable state. It runs
 // before the stage establishes the event's shared consistency point, so that point lands after this
 // backup completes and the clone can recover forward to it. The base backup pins no instant of its own —
 // it only needs to precede the point — so it returns the zero time. The base backup is owned by this
-// action and torn down in Cleanup; it must outlive clone creation, since the clone's recovery volume
-// snapshots are owned by it. Implements remote.PreConsistencyPointAction.
+// action and torn down in Cleanup (unless a physical backup kept it); it must outlive clone creation,
+// since the clone's recovery volume snapshots are owned by it. Implements remote.PreConsistencyPointAction.
 func (bs *baseBackupState) BeforeConsistencyPoint(ctx *contexts.Context) (_ time.Time, err error) {
 	bs.ctxLogWith(ctx).Info("Taking base backup for CNPG backup")
 	defer ctx.Log.Info("CNPG base backup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
@@ -137,6 +210,14 @@ func (bs *baseBackupState) BeforeConsistencyPoint(ctx *contexts.Context) (_ time
 		bs.opts.CloningOpts.CleanupTimeout = bs.opts.CleanupTimeout
 	}
 
+	// Describe the cluster as it is when its base backup is taken, so that a physical backup's manifest
+	// records what a restore needs even if the cluster is later lost.
+	sourceCluster, err := bs.kubeClusterClient.DescribeCluster(ctx.Child(), bs.namespace, bs.clusterName)
+	if err != nil {
+		return time.Time{}, trace.Wrap(err, "failed to describe cluster %q", bs.clusterName)
+	}
+	bs.sourceCluster = sourceCluster
+
 	backup, err := bs.kubeClusterClient.CreateClusterBackup(ctx.Child(), bs.namespace, bs.clusterName, bs.opts.CloningOpts)
 	if err != nil {
 		return time.Time{}, trace.Wrap(err, "failed to back up cluster %q", bs.clusterName)
@@ -162,6 +243,7 @@ type setupStateMountPaths struct {
 
 type setupState struct {
 	baseBackupState
+	restorePoint  common.PhysicalBackupRestorePoint // The restore point created on the cluster, if any.
 	clonedCluster clonedcluster.ClonedClusterInterface
 	mountPaths    setupStateMountPaths
 	isSetup       bool
@@ -179,6 +261,31 @@ func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.C
 		return trace.Errorf("attempted to setup multiple times")
 	}
 
+	// Write the source recovery fence after the consistency point is set and before the clone, so the
+	// clone's forward recovery to that point can reach it. See ForceSourceWALArchive. A physical backup is
+	// never cloned here, but it is recovered forward to the same point when it is restored.
+	if err := ForceSourceWALArchive(ctx.Child(), ss.kubeClusterClient, ss.namespace, ss.clusterName); err != nil {
+		return trace.Wrap(err, "failed to force source WAL archive for cluster %q", ss.clusterName)
+	}
+
+	if ss.opts.RestorePointName != "" {
+		if err := ss.createRestorePoint(ctx.Child()); err != nil {
+			return err
+		}
+	}
+
+	baseMountPath := filepath.Join("/mnt", "cnpgbackup", ss.clusterName, ss.uid)
+	secretsVolumeMountPath := filepath.Join(baseMountPath, "secrets")
+
+	// A physical backup only writes its manifest, so it doesn't need a clone to dump.
+	if ss.opts.isPhysical() {
+		ss.mountPaths = setupStateMountPaths{drVolume: filepath.Join(baseMountPath, "dr")}
+		btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.drVolName, ss.mountPaths.drVolume))
+
+		ss.isSetup = true
+		return nil
+	}
+
 	clonedClusterName := helpers.CleanName(helpers.TruncateString(fmt.Sprintf("%s-%s-cloned-%s", constants.ToolShortName, ss.uid, ss.clusterName), 40, ""))
 
 	// Recover the clone forward to the shared consistency point. When no point was established (the action
@@ -188,22 +295,13 @@ func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.C
 		cloneOpts.RecoveryTargetTime = ss.consistencyPoint.Format(time.RFC3339)
 	}
 
-	// Write the source recovery fence after the consistency point is set and before the clone, so the
-	// clone's forward recovery to that point can reach it. See ForceSourceWALArchive.
-	if err := ForceSourceWALArchive(ctx.Child(), ss.kubeClusterClient, ss.namespace, ss.clusterName); err != nil {
-		return trace.Wrap(err, "failed to force source WAL archive for cluster %q", ss.clusterName)
-	}
-
-	clonedCluster, err := ss.kubeClusterClient.CloneClusterFromBackup(ctx.Child(), ss.namespace, ss.clusterName,
+	clonedCluster, err := ss.kubeClusterClient.CloneClusterFromBackup(ctx.Child(), ss.namespace, ss.sourceCluster,
 		clonedClusterName, ss.baseBackup, cloneOpts)
 	if err != nil {
 		return trace.Wrap(err, "failed to clone cluster")
 	}
 	ss.clonedCluster = clonedCluster
 
-	baseMountPath := filepath.Join("/mnt", "cnpgbackup", ss.clusterName, ss.uid)
-	secretsVolumeMountPath := filepath.Join(baseMountPath, "secrets")
-
 	ss.mountPaths = setupStateMountPaths{
 		drVolume:    filepath.Join(baseMountPath, "dr"),
 		servingCert: filepath.Join(secretsVolumeMountPath, "serving-cert"),
@@ -220,10 +318,28 @@ func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.C
 	return nil
 }
 
+// createRestorePoint creates this event's restore point on the cluster. It is named after the base backup, so
+// that a restore of this event's backup can recover to it without stopping at another event's restore point.
+func (ss *setupState) createRestorePoint(ctx *contexts.Context) error {
+	restorePointName := ss.opts.RestorePointName + "-" + ss.baseBackup.Name
+	if len(restorePointName) > maxRestorePointNameLength {
+		return trace.BadParameter("restore point name %q must be %d characters or less", restorePointName, maxRestorePointNameLength)
+	}
+
+	lsn, err := CreateSourceRestorePoint(ctx.Child(), ss.kubeClusterClient, ss.namespace, ss.clusterName, restorePointName)
+	if err != nil {
+		return trace.Wrap(err, "failed to create restore point %q on cluster %q", restorePointName, ss.clusterName)
+	}
+
+	ss.restorePoint = common.PhysicalBackupRestorePoint{Name: restorePointName, LSN: lsn}
+	ctx.Log.Info("Created restore point", "restorePoint", restorePointName, "lsn", lsn)
+	return nil
+}
+
 // Cleanup tears down whatever this action created, tolerating partial state (e.g. a base backup taken
 // but the clone never created because another action failed first). The clone is deleted before the base
 // backup it recovered from. The base backup is deleted only here, at the end of the event, so it outlives
-// clone creation.
+// clone creation. A base backup that a physical backup recorded in its manifest is kept.
 func (ss *setupState) Cleanup(ctx *contexts.Context) error {
 	cleanupErrs := make([]error, 0, 2)
 
@@ -236,7 +352,7 @@ func (ss *setupState) Cleanup(ctx *contexts.Context) error {
 		}
 	}
 
-	if ss.baseBackup != nil {
+	if ss.baseBackup != nil && !ss.isBaseBackupRetained {
 		if err := cleanup.To(func(ctx *contexts.Context) error {
 			return ss.kubeClusterClient.CNPG().DeleteBackup(ctx, ss.namespace, ss.baseBackup.Name)
 		}).WithErrMessage("failed to cleanup base backup %q", helpers.FullNameStr(ss.namespace, ss.baseBackup.Name)).
@@ -262,11 +378,37 @@ func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.
 	}
 
 	podSQLFilePath := filepath.Join(es.mountPaths.drVolume, es.backupFileRelPath)
+	if es.opts.isPhysical() {
+		return trace.Wrap(es.writeManifest(ctx.Child(), backupToolClient, podSQLFilePath), "failed to record physical backup of cluster %q", es.clusterName)
+	}
+
 	credentials := es.clonedCluster.GetCredentials(es.mountPaths.servingCert, es.mountPaths.clientCert)
-	err = backupToolClient.Postgres().DumpAll(ctx.Child(), credentials, podSQLFilePath, postgres.DumpAllOptions{CleanupTimeout: es.opts.CleanupTimeout})
+	err = backupToolClient.Postgres().DumpAll(ctx.Child(), credentials, podSQLFilePath, es.opts.dumpAllOptions())
 	return trace.Wrap(err, "failed to create logical backup for postgres server at %q", postgres.GetServerAddress(credentials))
 }
 
+// writeManifest records the base backup in a manifest at the path, and keeps the base backup from then on.
+// The backup is kept even if the event fails after this, so that it is never deleted once a manifest refers
+// to it.
+func (es *executeState) writeManifest(ctx *contexts.Context, backupToolClient clients.ClientInterface, manifestPath string) error {
+	contents, err := common.EncodePhysicalBackupManifest(common.PhysicalBackupManifest{
+		Cluster:          es.sourceCluster,
+		Backup:           es.baseBackup.Name,
+		ConsistencyPoint: es.consistencyPoint,
+		RestorePoint:     es.restorePoint,
+	})
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	if err := backupToolClient.Files().WriteFile(ctx.Child(), manifestPath, contents); err != nil {
+		return trace.Wrap(err, "failed to write manifest %q", manifestPath)
+	}
+
+	es.isBaseBackupRetained = true
+	return nil
+}
+
 type CNPGBackup struct {
 	executeState
 }
@@ -13,6 +13,8 @@ import (
 	"github.com/samber/lo"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/common"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
@@ -88,6 +90,81 @@ func TestConfigure(t *testing.T) {
 	})
 }
 
+func TestCNPGBackupOptionsValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    CNPGBackupOptions
+		wantErr bool
+	}{
+		{
+			desc: "defaults to logical",
+		},
+		{
+			desc: "logical with dump options",
+			opts: CNPGBackupOptions{Mode: BackupModeLogical, Format: postgres.DumpFormatDirectory, Jobs: 4},
+		},
+		{
+			desc:    "logical with invalid dump options",
+			opts:    CNPGBackupOptions{Mode: BackupModeLogical, Jobs: 4},
+			wantErr: true,
+		},
+		{
+			desc: "physical",
+			opts: CNPGBackupOptions{Mode: BackupModePhysical},
+		},
+		{
+			desc:    "physical with a format",
+			opts:    CNPGBackupOptions{Mode: BackupModePhysical, Format: postgres.DumpFormatDirectory},
+			wantErr: true,
+		},
+		{
+			desc:    "physical with jobs",
+			opts:    CNPGBackupOptions{Mode: BackupModePhysical, Jobs: 4},
+			wantErr: true,
+		},
+		{
+			desc:    "physical with a selection",
+			opts:    CNPGBackupOptions{Mode: BackupModePhysical, Selection: postgres.DatabaseSelection{ExcludeDatabases: []string{"analytics"}}},
+			wantErr: true,
+		},
+		{
+			desc:    "physical with role handling",
+			opts:    CNPGBackupOptions{Mode: BackupModePhysical, Roles: postgres.RoleHandling{StripPasswords: true}},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid mode",
+			opts:    CNPGBackupOptions{Mode: "incremental"},
+			wantErr: true,
+		},
+		{
+			desc:    "clone recovery target",
+			opts:    CNPGBackupOptions{CloningOpts: clonedcluster.CloneClusterOptions{RecoveryTarget: clonedcluster.CloneClusterOptionsRecoveryTarget{TargetName: "before-migration"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateInvalidDumpOptions(t *testing.T) {
+	currentState := &validateState{}
+	require.NoError(t, currentState.Configure(kubecluster.NewMockClientInterface(t), "namespace", "clusterName", "drVolName", "backupFileRelPath", CNPGBackupOptions{Jobs: 4}))
+
+	assert.Error(t, currentState.Validate(th.NewTestContext()))
+	assert.False(t, currentState.isValidated)
+}
+
 func TestValidate(t *testing.T) {
 	readyCluster := &apiv1.Cluster{
 		Status: apiv1.ClusterStatus{
@@ -221,10 +298,11 @@ func TestValidate(t *testing.T) {
 
 func TestBeforeConsistencyPoint(t *testing.T) {
 	tests := []struct {
-		desc              string
-		notValidated      bool
-		alreadyBackedUp   bool
-		simulateBackupErr bool
+		desc                string
+		notValidated        bool
+		alreadyBackedUp     bool
+		simulateDescribeErr bool
+		simulateBackupErr   bool
 	}{
 		{
 			desc: "succeeds",
@@ -237,6 +315,10 @@ func TestBeforeConsistencyPoint(t *testing.T) {
 			desc:            "fails if called multiple times",
 			alreadyBackedUp: true,
 		},
+		{
+			desc:                "fails to describe the cluster",
+			simulateDescribeErr: true,
+		},
 		{
 			desc:              "fails to create the base backup",
 			simulateBackupErr: true,
@@ -269,10 +351,24 @@ func TestBeforeConsistencyPoint(t *testing.T) {
 			}
 
 			baseBackup := &apiv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "base-backup"}}
+			sourceCluster := clonedcluster.SourceCluster{Name: "clusterName", StorageSize: "1Gi"}
 
 			ctx := th.NewTestContext()
 
-			if !tt.notValidated && !tt.alreadyBackedUp {
+			func() {
+				if tt.notValidated || tt.alreadyBackedUp {
+					return
+				}
+
+				mockClient.EXPECT().DescribeCluster(mock.Anything, currentState.namespace, currentState.clusterName).
+					RunAndReturn(func(calledCtx *contexts.Context, _, _ string) (clonedcluster.SourceCluster, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return sourceCluster, th.ErrIfTrue(tt.simulateDescribeErr)
+					})
+				if tt.simulateDescribeErr {
+					return
+				}
+
 				// The action's cleanup timeout is defaulted onto the cloning options before backing up.
 				expectedCloningOpts := currentState.opts.CloningOpts
 				expectedCloningOpts.CleanupTimeout = currentState.opts.CleanupTimeout
@@ -283,10 +379,10 @@ func TestBeforeConsistencyPoint(t *testing.T) {
 
 						return th.ErrOr1Val(baseBackup, tt.simulateBackupErr)
 					})
-			}
+			}()
 
 			pinnedTime, err := currentState.BeforeConsistencyPoint(ctx)
-			if th.ErrExpected(tt.notValidated, tt.alreadyBackedUp, tt.simulateBackupErr) {
+			if th.ErrExpected(tt.notValidated, tt.alreadyBackedUp, tt.simulateDescribeErr, tt.simulateBackupErr) {
 				assert.Error(t, err)
 				return
 			}
@@ -294,6 +390,7 @@ func TestBeforeConsistencyPoint(t *testing.T) {
 			require.NoError(t, err)
 			// The base backup pins no instant of its own — it only needs to precede the consistency point.
 			assert.True(t, pinnedTime.IsZero())
+			assert.Equal(t, sourceCluster, currentState.sourceCluster)
 			assert.Equal(t, baseBackup, currentState.baseBackup)
 			assert.True(t, currentState.isBaseBackedUp)
 		})
@@ -316,11 +413,29 @@ func TestSetup(t *testing.T) {
 		hasNotBeenBaseBackedUp    bool
 		isAlreadySetup            bool
 		recoverForwardToTarget    bool
+		physical                  bool
+		createRestorePoint        bool
+		restorePointNameTooLong   bool
 		simulateCloneClusterError bool
 	}{
 		{
 			desc: "succeeds recovering to the consistency point",
 		},
+		{
+			desc:                   "succeeds creating a restore point",
+			recoverForwardToTarget: true,
+			createRestorePoint:     true,
+		},
+		{
+			desc:                    "fails when the restore point name is too long",
+			recoverForwardToTarget:  true,
+			restorePointNameTooLong: true,
+		},
+		{
+			desc:                   "succeeds without cloning for a physical backup",
+			recoverForwardToTarget: true,
+			physical:               true,
+		},
 		{
 			desc:                   "succeeds recovering forward to C",
 			recoverForwardToTarget: true,
@@ -385,14 +500,25 @@ func TestSetup(t *testing.T) {
 						isValidated: true,
 						cluster:     &apiv1.Cluster{},
 					},
+					sourceCluster:    clonedcluster.SourceCluster{Name: "clusterName", StorageSize: "1Gi"},
 					baseBackup:       baseBackup,
 					consistencyPoint: consistencyPoint,
 					isBaseBackedUp:   !tt.hasNotBeenBaseBackedUp,
 				},
 				isSetup: tt.isAlreadySetup,
 			}
+			if tt.physical {
+				currentState.opts.Mode = BackupModePhysical
+			}
+			if tt.createRestorePoint {
+				currentState.opts.RestorePointName = "before-migration"
+			}
+			if tt.restorePointNameTooLong {
+				currentState.opts.RestorePointName = strings.Repeat("a", 60)
+			}
 
 			ctx := th.NewTestContext()
+			var statements []string
 
 			func() {
 				if tt.hasNotBeenBaseBackedUp || currentState.isSetup {
@@ -414,7 +540,9 @@ func TestSetup(t *testing.T) {
 				mockCoreClient.EXPECT().ExecInPod(mock.Anything, currentState.namespace, primary, "postgres", mock.Anything, nil).
 					RunAndReturn(func(_ *contexts.Context, _, _, _ string, command []string, _ io.Reader) (string, string, error) {
 						const segment = "000000010000000000000001"
-						switch sql := command[len(command)-1]; {
+						sql := command[len(command)-1]
+						statements = append(statements, sql)
+						switch {
 						case strings.Contains(sql, "pg_walfile_name"):
 							return segment + "\n", "", nil
 						case strings.Contains(sql, "pg_stat_archiver"):
@@ -423,9 +551,12 @@ func TestSetup(t *testing.T) {
 							return "0/0\n", "", nil
 						}
 					})
+				if tt.physical || tt.restorePointNameTooLong {
+					return
+				}
 
-				mockClient.EXPECT().CloneClusterFromBackup(mock.Anything, currentState.namespace, currentState.clusterName, mock.Anything, baseBackup, mock.Anything).
-					RunAndReturn(func(calledCtx *contexts.Context, namespace, existingClusterName, newClusterName string, backup *apiv1.Backup, opts clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error) {
+				mockClient.EXPECT().CloneClusterFromBackup(mock.Anything, currentState.namespace, currentState.sourceCluster, mock.Anything, baseBackup, mock.Anything).
+					RunAndReturn(func(calledCtx *contexts.Context, namespace string, source clonedcluster.SourceCluster, newClusterName string, backup *apiv1.Backup, opts clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error) {
 						assert.True(t, calledCtx.IsChildOf(ctx))
 						assert.Contains(t, newClusterName, currentState.uid)
 						assert.LessOrEqual(t, len(newClusterName), 50)
@@ -450,14 +581,36 @@ func TestSetup(t *testing.T) {
 
 			btiOpts := &backuptoolinstance.CreateBackupToolInstanceOptions{}
 			err := currentState.Setup(ctx, btiOpts)
-			if th.ErrExpected(tt.hasNotBeenBaseBackedUp, tt.isAlreadySetup, tt.simulateCloneClusterError) {
+			if th.ErrExpected(tt.hasNotBeenBaseBackedUp, tt.isAlreadySetup, tt.restorePointNameTooLong, tt.simulateCloneClusterError) {
 				assert.Error(t, err)
 				return
 			}
 
 			require.NoError(t, err)
 
+			// The restore point is only created when named, and is suffixed with the base backup's name
+			restorePointStatements := lo.Filter(statements, func(sql string, _ int) bool {
+				return strings.Contains(sql, "pg_create_restore_point")
+			})
+			if tt.createRestorePoint {
+				assert.Equal(t, []string{"SELECT pg_create_restore_point('before-migration-base-backup')"}, restorePointStatements)
+				assert.Equal(t, common.PhysicalBackupRestorePoint{Name: "before-migration-base-backup", LSN: "0/0"}, currentState.restorePoint)
+			} else {
+				assert.Empty(t, restorePointStatements)
+				assert.Zero(t, currentState.restorePoint)
+			}
+
 			assert.Contains(t, currentState.mountPaths.drVolume, currentState.uid)
+			if tt.physical {
+				// Only the DR volume is needed to write the manifest
+				assert.Nil(t, currentState.clonedCluster)
+				require.Len(t, btiOpts.Volumes, 1)
+				assert.Equal(t, []string{currentState.mountPaths.drVolume}, btiOpts.Volumes[0].MountPaths)
+				require.NotNil(t, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim)
+				assert.Equal(t, currentState.drVolName, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName)
+				return
+			}
+
 			assert.Contains(t, currentState.mountPaths.servingCert, currentState.uid)
 			assert.Contains(t, currentState.mountPaths.clientCert, currentState.uid)
 
@@ -499,12 +652,17 @@ func TestCleanup(t *testing.T) {
 	tests := []struct {
 		desc                           string
 		nothingCreated                 bool
+		baseBackupRetained             bool
 		simulateClonedClusterDeleteErr bool
 		simulateBaseBackupDeleteErr    bool
 	}{
 		{
 			desc: "succeeds",
 		},
+		{
+			desc:               "succeeds and keeps a retained base backup",
+			baseBackupRetained: true,
+		},
 		{
 			desc:           "succeeds and does nothing if nothing was created",
 			nothingCreated: true,
@@ -549,12 +707,15 @@ func TestCleanup(t *testing.T) {
 			if !tt.nothingCreated {
 				currentState.baseBackup = &apiv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "base-backup"}}
 				currentState.clonedCluster = mockCloneCluster
+				currentState.isBaseBackupRetained = tt.baseBackupRetained
 
 				mockCloneCluster.EXPECT().Delete(mock.Anything).Return(th.ErrIfTrue(tt.simulateClonedClusterDeleteErr))
 
-				mockClient.EXPECT().CNPG().Return(mockCNPGClient)
-				mockCNPGClient.EXPECT().DeleteBackup(mock.Anything, currentState.namespace, currentState.baseBackup.Name).
-					Return(th.ErrIfTrue(tt.simulateBaseBackupDeleteErr))
+				if !tt.baseBackupRetained {
+					mockClient.EXPECT().CNPG().Return(mockCNPGClient)
+					mockCNPGClient.EXPECT().DeleteBackup(mock.Anything, currentState.namespace, currentState.baseBackup.Name).
+						Return(th.ErrIfTrue(tt.simulateBaseBackupDeleteErr))
+				}
 			}
 
 			err := currentState.Cleanup(th.NewTestContext())
@@ -610,6 +771,10 @@ func TestExecute(t *testing.T) {
 								opts: CNPGBackupOptions{
 									CloningOpts:    clonedcluster.CloneClusterOptions{},
 									CleanupTimeout: helpers.ShortWaitTime,
+									Format:         postgres.DumpFormatDirectory,
+									Jobs:           4,
+									Selection:      postgres.DatabaseSelection{ExcludeDatabases: []string{"analytics"}},
+									Roles:          postgres.RoleHandling{SkipRoles: []string{"app"}},
 								},
 							},
 							isValidated: true,
@@ -641,7 +806,7 @@ func TestExecute(t *testing.T) {
 
 				mockCloneCluster.EXPECT().GetCredentials(currentState.mountPaths.servingCert, currentState.mountPaths.clientCert).Return(credentials)
 
-				mockPGR.EXPECT().DumpAll(mock.Anything, credentials, drFilePath, postgres.DumpAllOptions{CleanupTimeout: currentState.opts.CleanupTimeout}).
+				mockPGR.EXPECT().DumpAll(mock.Anything, credentials, drFilePath, postgres.DumpAllOptions{CleanupTimeout: currentState.opts.CleanupTimeout, Format: postgres.DumpFormatDirectory, Jobs: 4, Selection: postgres.DatabaseSelection{ExcludeDatabases: []string{"analytics"}}, Roles: postgres.RoleHandling{SkipRoles: []string{"app"}}}).
 					RunAndReturn(func(calledCtx *contexts.Context, credentials postgres.Credentials, backupFilePath string, opts postgres.DumpAllOptions) error {
 						assert.True(t, calledCtx.IsChildOf(ctx))
 
@@ -660,6 +825,119 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecutePhysical(t *testing.T) {
+	consistencyPoint := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	sourceCluster := clonedcluster.SourceCluster{
+		Name:        "clusterName",
+		StorageSize: "1Gi",
+		WALArchive:  clonedcluster.SourceClusterWALArchive{ObjectStoreName: "store", ServerName: "clusterName"},
+	}
+
+	tests := []struct {
+		desc                string
+		hasNotBeenSetup     bool
+		simulateWriteErr    bool
+		hasConsistencyPoint bool
+		hasRestorePoint     bool
+	}{
+		{
+			desc:                "succeeds",
+			hasConsistencyPoint: true,
+		},
+		{
+			desc: "succeeds without a consistency point",
+		},
+		{
+			desc:                "succeeds with a restore point",
+			hasConsistencyPoint: true,
+			hasRestorePoint:     true,
+		},
+		{
+			desc:            "fails if not setup first",
+			hasNotBeenSetup: true,
+		},
+		{
+			desc:             "fails to write the manifest",
+			simulateWriteErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockFilesRuntime := files.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().Files().Return(mockFilesRuntime).Maybe()
+
+			currentState := &executeState{
+				setupState: setupState{
+					baseBackupState: baseBackupState{
+						validateState: validateState{
+							configureState: configureState{
+								uid:               "uid",
+								isConfigured:      true,
+								kubeClusterClient: kubecluster.NewMockClientInterface(t),
+								namespace:         "namespace",
+								clusterName:       "clusterName",
+								drVolName:         "drVolName",
+								backupFileRelPath: "backupFileRelPath",
+								opts: CNPGBackupOptions{
+									CleanupTimeout: helpers.ShortWaitTime,
+									Mode:           BackupModePhysical,
+								},
+							},
+							isValidated: true,
+							cluster:     &apiv1.Cluster{},
+						},
+						sourceCluster:  sourceCluster,
+						baseBackup:     &apiv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "base-backup"}},
+						isBaseBackedUp: true,
+					},
+					mountPaths: setupStateMountPaths{
+						drVolume: "/dr-volume",
+					},
+					isSetup: !tt.hasNotBeenSetup,
+				},
+			}
+
+			expectedManifest := common.PhysicalBackupManifest{Cluster: sourceCluster, Backup: "base-backup"}
+			if tt.hasConsistencyPoint {
+				currentState.consistencyPoint = consistencyPoint
+				expectedManifest.ConsistencyPoint = consistencyPoint
+			}
+			if tt.hasRestorePoint {
+				currentState.restorePoint = common.PhysicalBackupRestorePoint{Name: "before-migration-base-backup", LSN: "0/3000060"}
+				expectedManifest.RestorePoint = currentState.restorePoint
+			}
+
+			ctx := th.NewTestContext()
+			if currentState.isSetup {
+				manifestPath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupFileRelPath)
+				mockFilesRuntime.EXPECT().WriteFile(mock.Anything, manifestPath, mock.Anything).
+					RunAndReturn(func(calledCtx *contexts.Context, _ string, contents []byte) error {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+
+						manifest, err := common.DecodePhysicalBackupManifest(contents)
+						require.NoError(t, err)
+						assert.Equal(t, expectedManifest, manifest)
+
+						return th.ErrIfTrue(tt.simulateWriteErr)
+					})
+			}
+
+			err := currentState.Execute(ctx, mockGRPC)
+			if tt.hasNotBeenSetup || tt.simulateWriteErr {
+				assert.Error(t, err)
+				// The base backup is only kept once a manifest refers to it
+				assert.False(t, currentState.isBaseBackupRetained)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isBaseBackupRetained)
+		})
+	}
+}
+
 func TestCNPGBackup(t *testing.T) {
 	assert.Implements(t, (*CNPGBackupInterface)(nil), (*CNPGBackup)(nil))
 	assert.Implements(t, (*remote.RemoteAction)(nil), (*CNPGBackup)(nil))
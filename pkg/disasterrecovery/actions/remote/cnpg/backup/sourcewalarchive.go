@@ -51,3 +51,19 @@ func ForceSourceWALArchive(ctx *contexts.Context, kubeClient kubecluster.ClientI
 
 	return postgres.ForceWALArchive(ctx.Child(), run, postgres.ForceWALArchiveOptions{})
 }
+
+// CreateSourceRestorePoint creates a named restore point on the source cluster's primary and waits for it to
+// archive — see postgres.CreateRestorePoint. A clone of a later backup can't recover backwards to it, but a
+// clone of an earlier backup (or a physical restore) can recover forward to it with a targetName recovery
+// target, such as to just before a risky change. Returns the restore point's LSN.
+func CreateSourceRestorePoint(ctx *contexts.Context, kubeClient kubecluster.ClientInterface, namespace, sourceClusterName, restorePointName string) (lsn string, err error) {
+	ctx.Log.With("sourceCluster", sourceClusterName).Info("Creating restore point on source cluster", "restorePoint", restorePointName)
+	defer ctx.Log.Info("Finished creating restore point on source cluster", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	run, err := sourcePSQLRunner(ctx, kubeClient, namespace, sourceClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	return postgres.CreateRestorePoint(ctx.Child(), run, restorePointName, postgres.CreateRestorePointOptions{})
+}
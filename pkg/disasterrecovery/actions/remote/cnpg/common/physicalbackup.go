@@ -0,0 +1,66 @@
+package common
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
+)
+
+// PhysicalBackupManifest records the CNPG base backup that a physical backup kept, so that a restore can later
+// bootstrap a new cluster from it. It is written to the DR volume in place of a dump.
+type PhysicalBackupManifest struct {
+	// Cluster describes the CNPG cluster that was backed up when the backup was taken. The restore copies the
+	// new cluster's image, storage and WAL archive from it, so the backed up cluster doesn't need to exist.
+	Cluster clonedcluster.SourceCluster `json:"cluster"`
+	// Backup is the name of the CNPG Backup (and so of its volume snapshots) that was kept.
+	Backup string `json:"backup"`
+	// ConsistencyPoint is the event's consistency point, which the restore recovers forward to. It is zero
+	// when the backup ran outside of the stage's consistency-point protocol, in which case the restore
+	// recovers to the backup's own consistency point.
+	ConsistencyPoint time.Time `json:"consistencyPoint,omitzero"`
+	// RestorePoint is the restore point that the backup created on the cluster, if any.
+	RestorePoint PhysicalBackupRestorePoint `json:"restorePoint,omitzero"`
+}
+
+// PhysicalBackupRestorePoint is a named restore point that a backup created on the cluster, after its base
+// backup. A restore of the backup can recover to it with a targetName recovery target of Name.
+type PhysicalBackupRestorePoint struct {
+	Name string `json:"name"`
+	// LSN is the restore point's write-ahead log location, which can also be recovered to with a targetLSN.
+	LSN string `json:"lsn"`
+}
+
+// EncodePhysicalBackupManifest encodes the manifest as JSON.
+func EncodePhysicalBackupManifest(manifest PhysicalBackupManifest) ([]byte, error) {
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	return contents, trace.Wrap(err, "failed to encode physical backup manifest")
+}
+
+// DecodePhysicalBackupManifest decodes a manifest written by EncodePhysicalBackupManifest, and checks that it
+// describes the cluster and names the backup.
+func DecodePhysicalBackupManifest(contents []byte) (PhysicalBackupManifest, error) {
+	var manifest PhysicalBackupManifest
+	if err := json.Unmarshal(contents, &manifest); err != nil {
+		return PhysicalBackupManifest{}, trace.Wrap(err, "failed to decode physical backup manifest")
+	}
+
+	if manifest.Cluster.Name == "" {
+		return PhysicalBackupManifest{}, trace.BadParameter("physical backup manifest does not name a cluster")
+	}
+
+	if manifest.Cluster.StorageSize == "" {
+		return PhysicalBackupManifest{}, trace.BadParameter("physical backup manifest does not record the cluster's storage size")
+	}
+
+	if manifest.Cluster.WALArchive.ObjectStoreName == "" || manifest.Cluster.WALArchive.ServerName == "" {
+		return PhysicalBackupManifest{}, trace.BadParameter("physical backup manifest does not record the cluster's WAL archive")
+	}
+
+	if manifest.Backup == "" {
+		return PhysicalBackupManifest{}, trace.BadParameter("physical backup manifest does not name a backup")
+	}
+
+	return manifest, nil
+}
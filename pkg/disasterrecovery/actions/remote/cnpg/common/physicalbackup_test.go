@@ -0,0 +1,111 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodePhysicalBackupManifest(t *testing.T) {
+	manifest := PhysicalBackupManifest{
+		Cluster: clonedcluster.SourceCluster{
+			Name:         "cluster",
+			ImageName:    "postgres:17",
+			StorageClass: "fast",
+			StorageSize:  "10Gi",
+			WALArchive:   clonedcluster.SourceClusterWALArchive{ObjectStoreName: "store", ServerName: "server"},
+		},
+		Backup:           "backup",
+		ConsistencyPoint: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+		RestorePoint:     PhysicalBackupRestorePoint{Name: "before-migration-backup", LSN: "0/3000060"},
+	}
+
+	contents, err := EncodePhysicalBackupManifest(manifest)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"cluster": {
+			"name": "cluster",
+			"imageName": "postgres:17",
+			"storageClass": "fast",
+			"storageSize": "10Gi",
+			"walArchive": {"objectStoreName": "store", "serverName": "server"}
+		},
+		"backup": "backup",
+		"consistencyPoint": "2026-01-01T10:00:00Z",
+		"restorePoint": {"name": "before-migration-backup", "lsn": "0/3000060"}
+	}`, string(contents))
+
+	decoded, err := DecodePhysicalBackupManifest(contents)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, decoded)
+}
+
+func TestDecodePhysicalBackupManifest(t *testing.T) {
+	cluster := `{"name": "cluster", "storageSize": "1Gi", "walArchive": {"objectStoreName": "store", "serverName": "server"}}`
+
+	tests := []struct {
+		desc             string
+		contents         string
+		expectedManifest PhysicalBackupManifest
+		wantErr          bool
+	}{
+		{
+			desc:     "without a consistency point",
+			contents: `{"cluster": ` + cluster + `, "backup": "backup"}`,
+			expectedManifest: PhysicalBackupManifest{
+				Cluster: clonedcluster.SourceCluster{
+					Name:        "cluster",
+					StorageSize: "1Gi",
+					WALArchive:  clonedcluster.SourceClusterWALArchive{ObjectStoreName: "store", ServerName: "server"},
+				},
+				Backup: "backup",
+			},
+		},
+		{
+			desc:     "invalid JSON",
+			contents: `{"cluster": `,
+			wantErr:  true,
+		},
+		{
+			desc:     "no cluster",
+			contents: `{"backup": "backup"}`,
+			wantErr:  true,
+		},
+		{
+			desc:     "cluster by name only",
+			contents: `{"cluster": "cluster", "backup": "backup"}`,
+			wantErr:  true,
+		},
+		{
+			desc:     "no cluster storage size",
+			contents: `{"cluster": {"name": "cluster", "walArchive": {"objectStoreName": "store", "serverName": "server"}}, "backup": "backup"}`,
+			wantErr:  true,
+		},
+		{
+			desc:     "no cluster WAL archive",
+			contents: `{"cluster": {"name": "cluster", "storageSize": "1Gi"}, "backup": "backup"}`,
+			wantErr:  true,
+		},
+		{
+			desc:     "no backup",
+			contents: `{"cluster": ` + cluster + `}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			manifest, err := DecodePhysicalBackupManifest([]byte(tt.contents))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedManifest, manifest)
+		})
+	}
+}
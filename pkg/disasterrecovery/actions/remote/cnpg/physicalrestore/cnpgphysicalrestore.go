@@ -0,0 +1,197 @@
+package physicalrestore
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/common"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/cnpg"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+)
+
+// The longest name that CNPG allows for a cluster that is bootstrapped from a backup.
+const maxClusterNameLength = 40
+
+type CNPGPhysicalRestoreOptions struct {
+	CloningOpts    clonedcluster.CloneClusterOptions `yaml:"clusterCloning,omitempty"`
+	CleanupTimeout helpers.MaxWaitTime               `yaml:"cleanupTimeout,omitempty"`
+}
+
+// CNPGPhysicalRestoreInterface is a RemoteStage action that restores a physical CNPG backup. It reads the
+// manifest that the backup wrote to the DR volume, and creates a new cluster bootstrapped from the base backup
+// that the manifest names, recovered forward to the backup event's consistency point. The new cluster's image,
+// storage and WAL archive are copied from the manifest, so the backed up cluster doesn't need to exist (only
+// the base backup and its WAL archive do). Like a clone, the new cluster's certificates are minted from PKI
+// that is created for it, so clients need to be pointed at the new cluster (and trust it) before it is used.
+type CNPGPhysicalRestoreInterface interface {
+	remote.RemoteAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace, newClusterName, drVolName, manifestRelPath string, opts CNPGPhysicalRestoreOptions) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	newClusterName    string
+	drVolName         string
+	manifestRelPath   string
+	opts              CNPGPhysicalRestoreOptions
+}
+
+// Configures the action prior to validation and execution. This should be called before
+// any other methods. Returns an error if the action is already configured.
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace, newClusterName, drVolName, manifestRelPath string, opts CNPGPhysicalRestoreOptions) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.newClusterName = newClusterName
+	cs.drVolName = drVolName
+	cs.manifestRelPath = manifestRelPath
+	cs.opts = opts
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("newClusterName", cs.newClusterName, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+// Validates that the required resources are ready. This should be called after `Configure`
+// and before `Setup`. Returns an error if the resources are not ready.
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for CNPG physical restore")
+	defer ctx.Log.Info("Completed CNPG physical restore configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if vs.newClusterName == "" {
+		return trace.BadParameter("no new cluster name provided")
+	}
+
+	if len(vs.newClusterName) > maxClusterNameLength {
+		return trace.BadParameter("new cluster name %q must be %d characters or less", vs.newClusterName, maxClusterNameLength)
+	}
+
+	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.drVolName); err != nil {
+		return trace.Wrap(err, "failed to get DR PVC %q", vs.drVolName)
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+type setupStateMountPaths struct {
+	drVolume string
+}
+
+type setupState struct {
+	validateState
+	mountPaths setupStateMountPaths
+	isSetup    bool
+}
+
+// Prepares the backup tool pod to be able to read the manifest. This should be called
+// after `Validate` and before `Execute`. Returns an error if the pod cannot be prepared.
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for CNPG physical restore")
+	defer ctx.Log.Info("CNPG physical restore setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	ss.mountPaths = setupStateMountPaths{
+		drVolume: filepath.Join("/mnt", "cnpgphysicalrestore", ss.newClusterName, ss.uid, "dr"),
+	}
+
+	btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.drVolName, ss.mountPaths.drVolume))
+
+	ss.isSetup = true
+	return nil
+}
+
+type executeState struct {
+	setupState
+	restoredCluster clonedcluster.ClonedClusterInterface
+}
+
+// Creates the new cluster from the backup that the manifest names. This should be called after `Setup`.
+// Returns an error if the restore fails, in which case the new cluster is not left behind.
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing CNPG physical restore")
+	defer ctx.Log.Info("CNPG physical restore complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	manifestPath := filepath.Join(es.mountPaths.drVolume, es.manifestRelPath)
+	contents, err := backupToolClient.Files().ReadFile(ctx.Child(), manifestPath)
+	if err != nil {
+		return trace.Wrap(err, "failed to read manifest %q", manifestPath)
+	}
+
+	manifest, err := common.DecodePhysicalBackupManifest(contents)
+	if err != nil {
+		return trace.Wrap(err, "invalid manifest %q", manifestPath)
+	}
+
+	backup, err := es.kubeClusterClient.CNPG().WaitForReadyBackup(ctx.Child(), es.namespace, manifest.Backup, cnpg.WaitForReadyBackupOpts{MaxWaitTime: es.opts.CloningOpts.WaitForBackupTimeout})
+	if err != nil {
+		return trace.Wrap(err, "failed to get backup %q of cluster %q", manifest.Backup, manifest.Cluster.Name)
+	}
+
+	// Recover forward to the backup event's consistency point, unless another recovery target was asked for
+	// (such as just before a known-bad transaction). When the backup recorded none, recover straight to the
+	// backup's own consistency point.
+	cloneOpts := es.opts.CloningOpts
+	if !manifest.ConsistencyPoint.IsZero() && cloneOpts.RecoveryTargetTime == "" && cloneOpts.RecoveryTarget.IsZero() {
+		cloneOpts.RecoveryTargetTime = manifest.ConsistencyPoint.Format(time.RFC3339)
+	}
+
+	if cloneOpts.CleanupTimeout == 0 {
+		cloneOpts.CleanupTimeout = es.opts.CleanupTimeout
+	}
+
+	restoredCluster, err := es.kubeClusterClient.CloneClusterFromBackup(ctx.Child(), es.namespace, manifest.Cluster, es.newClusterName, backup, cloneOpts)
+	if err != nil {
+		return trace.Wrap(err, "failed to create cluster %q from backup %q", es.newClusterName, manifest.Backup)
+	}
+	es.restoredCluster = restoredCluster
+
+	return nil
+}
+
+type CNPGPhysicalRestore struct {
+	executeState
+}
+
+func NewCNPGPhysicalRestore() CNPGPhysicalRestoreInterface {
+	return &CNPGPhysicalRestore{}
+}
@@ -0,0 +1,366 @@
+package physicalrestore
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "github.com/cloudnative-pg/cloudnative-pg/api/v1"
+	"github.com/google/uuid"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/common"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/cnpg"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCNPGPhysicalRestoreOptions(t *testing.T) {
+	th.OptStructTest[CNPGPhysicalRestoreOptions](t)
+}
+
+func TestConfigure(t *testing.T) {
+	expectedState := &configureState{
+		kubeClusterClient: kubecluster.NewMockClientInterface(t),
+		namespace:         "namespace",
+		newClusterName:    "newClusterName",
+		drVolName:         "drVolName",
+		manifestRelPath:   "manifestRelPath",
+		opts: CNPGPhysicalRestoreOptions{
+			CleanupTimeout: helpers.ShortWaitTime,
+		},
+	}
+
+	action := NewCNPGPhysicalRestore()
+	err := action.Configure(
+		expectedState.kubeClusterClient,
+		expectedState.namespace,
+		expectedState.newClusterName,
+		expectedState.drVolName,
+		expectedState.manifestRelPath,
+		expectedState.opts,
+	)
+
+	t.Run("successfully configures the first time", func(t *testing.T) {
+		require.NoError(t, err)
+	})
+
+	t.Run("all state vars are populated", func(t *testing.T) {
+		casted := action.(*CNPGPhysicalRestore)
+
+		assert.NotEqual(t, "", casted.uid)
+		assert.NotEqual(t, uuid.Nil.String(), casted.uid)
+		expectedState.uid = casted.uid
+
+		assert.True(t, casted.isConfigured)
+		expectedState.isConfigured = casted.isConfigured
+
+		assert.Equal(t, expectedState, &casted.configureState)
+	})
+
+	t.Run("fails to configure because already configured", func(t *testing.T) {
+		err = action.Configure(
+			expectedState.kubeClusterClient,
+			expectedState.namespace,
+			expectedState.newClusterName,
+			expectedState.drVolName,
+			expectedState.manifestRelPath,
+			expectedState.opts,
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc               string
+		notConfigured      bool
+		newClusterName     string
+		isAlreadyValidated bool
+		simulateGetDRErr   bool
+		wantNameErr        bool
+	}{
+		{
+			desc:           "succeeds",
+			newClusterName: "restored",
+		},
+		{
+			desc:               "succeeds if called multiple times",
+			newClusterName:     "restored",
+			isAlreadyValidated: true,
+		},
+		{
+			desc:          "fails because not configured",
+			notConfigured: true,
+		},
+		{
+			desc:        "fails because there is no new cluster name",
+			wantNameErr: true,
+		},
+		{
+			desc:           "fails because the new cluster name is too long",
+			newClusterName: "restored-cluster-with-a-very-long-name-41",
+			wantNameErr:    true,
+		},
+		{
+			desc:             "fails to get DR PVC",
+			newClusterName:   "restored",
+			simulateGetDRErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCoreClient := core.NewMockClientInterface(t)
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockClient.EXPECT().Core().Return(mockCoreClient).Maybe()
+
+			currentState := &validateState{isValidated: tt.isAlreadyValidated}
+			if !tt.notConfigured {
+				require.NoError(t, currentState.Configure(mockClient, "namespace", tt.newClusterName, "drVolName", "manifestRelPath", CNPGPhysicalRestoreOptions{}))
+			}
+
+			ctx := th.NewTestContext()
+			if !tt.notConfigured && !tt.wantNameErr {
+				mockCoreClient.EXPECT().GetPVC(mock.Anything, "namespace", "drVolName").
+					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return nil, th.ErrIfTrue(tt.simulateGetDRErr)
+					})
+			}
+
+			err := currentState.Validate(ctx)
+			if th.ErrExpected(tt.notConfigured, tt.wantNameErr, tt.simulateGetDRErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isValidated)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		desc           string
+		notValidated   bool
+		isAlreadySetup bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:         "fails because not validated first",
+			notValidated: true,
+		},
+		{
+			desc:           "fails if called multiple times",
+			isAlreadySetup: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:               "uid",
+						isConfigured:      true,
+						kubeClusterClient: kubecluster.NewMockClientInterface(t),
+						namespace:         "namespace",
+						newClusterName:    "newClusterName",
+						drVolName:         "drVolName",
+						manifestRelPath:   "manifestRelPath",
+					},
+					isValidated: !tt.notValidated,
+				},
+				isSetup: tt.isAlreadySetup,
+			}
+
+			btiOpts := &backuptoolinstance.CreateBackupToolInstanceOptions{}
+			err := currentState.Setup(th.NewTestContext(), btiOpts)
+			if tt.notValidated || tt.isAlreadySetup {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Contains(t, currentState.mountPaths.drVolume, currentState.uid)
+			require.Len(t, btiOpts.Volumes, 1)
+			assert.Equal(t, []string{currentState.mountPaths.drVolume}, btiOpts.Volumes[0].MountPaths)
+			require.NotNil(t, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim)
+			assert.Equal(t, currentState.drVolName, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	consistencyPoint := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc                    string
+		hasNotBeenSetup         bool
+		noConsistencyPoint      bool
+		recoveryTarget          bool
+		simulateReadErr         bool
+		returnInvalidManifest   bool
+		simulateGetBackupErr    bool
+		simulateCloneClusterErr bool
+	}{
+		{
+			desc: "succeeds recovering to the consistency point",
+		},
+		{
+			desc:               "succeeds recovering to the backup",
+			noConsistencyPoint: true,
+		},
+		{
+			desc:           "succeeds recovering to the requested recovery target",
+			recoveryTarget: true,
+		},
+		{
+			desc:            "fails if not setup first",
+			hasNotBeenSetup: true,
+		},
+		{
+			desc:            "fails to read the manifest",
+			simulateReadErr: true,
+		},
+		{
+			desc:                  "fails because the manifest is invalid",
+			returnInvalidManifest: true,
+		},
+		{
+			desc:                 "fails to get the backup",
+			simulateGetBackupErr: true,
+		},
+		{
+			desc:                    "fails to create the cluster",
+			simulateCloneClusterErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockCNPGClient := cnpg.NewMockClientInterface(t)
+			mockClonedCluster := clonedcluster.NewMockClonedClusterInterface(t)
+			mockFilesRuntime := files.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockClient.EXPECT().CNPG().Return(mockCNPGClient).Maybe()
+			mockGRPC.EXPECT().Files().Return(mockFilesRuntime).Maybe()
+
+			cloningOpts := clonedcluster.CloneClusterOptions{WaitForBackupTimeout: helpers.ShortWaitTime}
+			if tt.recoveryTarget {
+				cloningOpts.RecoveryTarget = clonedcluster.CloneClusterOptionsRecoveryTarget{TargetLSN: "0/3000060", Exclusive: true}
+			}
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							kubeClusterClient: mockClient,
+							namespace:         "namespace",
+							newClusterName:    "newClusterName",
+							drVolName:         "drVolName",
+							manifestRelPath:   "postgres.physical.json",
+							opts: CNPGPhysicalRestoreOptions{
+								CloningOpts:    cloningOpts,
+								CleanupTimeout: helpers.ShortWaitTime,
+							},
+						},
+						isValidated: true,
+					},
+					mountPaths: setupStateMountPaths{
+						drVolume: "/dr-volume",
+					},
+					isSetup: !tt.hasNotBeenSetup,
+				},
+			}
+
+			sourceCluster := clonedcluster.SourceCluster{
+				Name:        "cluster",
+				StorageSize: "1Gi",
+				WALArchive:  clonedcluster.SourceClusterWALArchive{ObjectStoreName: "store", ServerName: "cluster"},
+			}
+			manifest := common.PhysicalBackupManifest{Cluster: sourceCluster, Backup: "base-backup"}
+			if !tt.noConsistencyPoint {
+				manifest.ConsistencyPoint = consistencyPoint
+			}
+			if tt.returnInvalidManifest {
+				manifest.Backup = ""
+			}
+			contents, err := common.EncodePhysicalBackupManifest(manifest)
+			require.NoError(t, err)
+
+			backup := &apiv1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "base-backup"}}
+
+			ctx := th.NewTestContext()
+			func() {
+				if tt.hasNotBeenSetup {
+					return
+				}
+
+				mockFilesRuntime.EXPECT().ReadFile(mock.Anything, "/dr-volume/postgres.physical.json").
+					RunAndReturn(func(calledCtx *contexts.Context, _ string) ([]byte, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return th.ErrOr1Val(contents, tt.simulateReadErr)
+					})
+				if tt.simulateReadErr || tt.returnInvalidManifest {
+					return
+				}
+
+				mockCNPGClient.EXPECT().WaitForReadyBackup(mock.Anything, "namespace", "base-backup", cnpg.WaitForReadyBackupOpts{MaxWaitTime: helpers.ShortWaitTime}).
+					Return(th.ErrOr1Val(backup, tt.simulateGetBackupErr))
+				if tt.simulateGetBackupErr {
+					return
+				}
+
+				// The cluster recovers forward to the consistency point only when the backup recorded one, and no
+				// other target was requested.
+				expectedCloningOpts := cloningOpts
+				expectedCloningOpts.CleanupTimeout = helpers.ShortWaitTime
+				if !tt.noConsistencyPoint && !tt.recoveryTarget {
+					expectedCloningOpts.RecoveryTargetTime = "2026-01-01T10:00:00Z"
+				}
+
+				mockClient.EXPECT().CloneClusterFromBackup(mock.Anything, "namespace", sourceCluster, "newClusterName", backup, expectedCloningOpts).
+					RunAndReturn(func(calledCtx *contexts.Context, _ string, _ clonedcluster.SourceCluster, _ string, _ *apiv1.Backup, _ clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return th.ErrOr1Val(mockClonedCluster, tt.simulateCloneClusterErr)
+					})
+			}()
+
+			err = currentState.Execute(ctx, mockGRPC)
+			if th.ErrExpected(tt.hasNotBeenSetup, tt.simulateReadErr, tt.returnInvalidManifest, tt.simulateGetBackupErr, tt.simulateCloneClusterErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, mockClonedCluster, currentState.restoredCluster)
+		})
+	}
+}
+
+func TestCNPGPhysicalRestore(t *testing.T) {
+	assert.Implements(t, (*CNPGPhysicalRestoreInterface)(nil), (*CNPGPhysicalRestore)(nil))
+	assert.Implements(t, (*remote.RemoteAction)(nil), (*CNPGPhysicalRestore)(nil))
+}
+
+func TestNewCNPGPhysicalRestore(t *testing.T) {
+	assert.Equal(t, &CNPGPhysicalRestore{}, NewCNPGPhysicalRestore())
+}
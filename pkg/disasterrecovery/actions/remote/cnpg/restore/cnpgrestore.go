@@ -32,6 +32,31 @@ type CNPGRestoreOptionsCert struct {
 type CNPGRestoreOptions struct {
 	PostgresUserCert CNPGRestoreOptionsCert `yaml:"postgresUserCert,omitempty"`
 	CleanupTimeout   helpers.MaxWaitTime    `yaml:"cleanupTimeout,omitempty"`
+	Format           postgres.DumpFormat    `yaml:"format,omitempty"` // How the dump was written. Empty means plain.
+	Jobs             int                    `yaml:"jobs,omitempty"`   // Tables of each database restored concurrently, with the directory format.
+	// Selection restores part of the dump, such as a single table, possibly into another database. Empty
+	// restores everything.
+	Selection postgres.RestoreSelection `yaml:"selection,omitempty"`
+	// StopOnError stops the restore at the first statement that fails, rather than only logging it.
+	StopOnError bool `yaml:"stopOnError,omitempty"`
+	// SingleTransaction restores the contents of each database in a single transaction. Implies StopOnError.
+	SingleTransaction bool `yaml:"singleTransaction,omitempty"`
+	// DropAndRecreate drops the databases that the restore replaces first, disconnecting any sessions.
+	DropAndRecreate bool `yaml:"dropAndRecreate,omitempty"`
+	// Force allows the restore to replace databases that are not empty.
+	Force bool `yaml:"force,omitempty"`
+}
+
+func (opts CNPGRestoreOptions) restoreOptions() postgres.RestoreOptions {
+	return postgres.RestoreOptions{
+		Format:            opts.Format,
+		Jobs:              opts.Jobs,
+		Selection:         opts.Selection,
+		StopOnError:       opts.StopOnError,
+		SingleTransaction: opts.SingleTransaction,
+		DropAndRecreate:   opts.DropAndRecreate,
+		Force:             opts.Force,
+	}
 }
 
 // Performs a CNPG logical recovery. Fields are for state tracking. Callers should:
@@ -102,6 +127,10 @@ func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
 		return trace.Errorf("attempted to validate without configuring")
 	}
 
+	if err := vs.opts.restoreOptions().Validate(); err != nil {
+		return trace.Wrap(err, "invalid restore options")
+	}
+
 	cluster, err := vs.kubeClusterClient.CNPG().GetCluster(ctx.Child(), vs.namespace, vs.clusterName)
 	if err != nil {
 		return trace.Wrap(err, "failed to get CNPG cluster %q", vs.clusterName)
@@ -225,7 +254,7 @@ func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.
 
 	podSQLFilePath := filepath.Join(es.mountPaths.drVolume, es.backupFileRelPath)
 	credentials := es.clusterCredentials()
-	err = backupToolClient.Postgres().Restore(ctx.Child(), credentials, podSQLFilePath, postgres.RestoreOptions{})
+	err = backupToolClient.Postgres().Restore(ctx.Child(), credentials, podSQLFilePath, es.opts.restoreOptions())
 	return trace.Wrap(err, "failed to restore logical backup for postgres server at %q", postgres.GetServerAddress(credentials))
 }
 
@@ -97,6 +97,15 @@ func TestConfigure(t *testing.T) {
 	})
 }
 
+func TestValidateInvalidRestoreOptions(t *testing.T) {
+	currentState := &validateState{}
+	require.NoError(t, currentState.Configure(kubecluster.NewMockClientInterface(t), "namespace", "clusterName", "servingCertName",
+		cmmeta.IssuerReference{Name: "clientCertIssuerName"}, "drVolName", "backupFileRelPath", CNPGRestoreOptions{Format: "custom"}))
+
+	assert.Error(t, currentState.Validate(th.NewTestContext()))
+	assert.False(t, currentState.isValidated)
+}
+
 func TestValidate(t *testing.T) {
 	readyCluster := &apiv1.Cluster{
 		Status: apiv1.ClusterStatus{
@@ -581,6 +590,11 @@ func TestExecute(t *testing.T) {
 									WaitForCertTimeout: helpers.ShortWaitTime,
 								},
 								CleanupTimeout: helpers.ShortWaitTime,
+								Format:         postgres.DumpFormatDirectory,
+								Jobs:           4,
+								Selection:      postgres.RestoreSelection{Databases: []string{"app"}},
+								StopOnError:    true,
+								Force:          true,
 							},
 						},
 						isValidated: true,
@@ -607,7 +621,7 @@ func TestExecute(t *testing.T) {
 			ctx := th.NewTestContext()
 			if currentState.isSetup {
 				drFilePath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupFileRelPath) // Important: Changing this is a breaking change!
-				mockPGR.EXPECT().Restore(mock.Anything, currentState.clusterCredentials(), drFilePath, postgres.RestoreOptions{}).
+				mockPGR.EXPECT().Restore(mock.Anything, currentState.clusterCredentials(), drFilePath, postgres.RestoreOptions{Format: postgres.DumpFormatDirectory, Jobs: 4, Selection: postgres.RestoreSelection{Databases: []string{"app"}}, StopOnError: true, Force: true}).
 					RunAndReturn(func(calledCtx *contexts.Context, credentials postgres.Credentials, backupFilePath string, opts postgres.RestoreOptions) error {
 						assert.True(t, calledCtx.IsChildOf(ctx))
 
@@ -16,6 +16,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonepvc"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -25,7 +26,13 @@ type FilesBackupOptions struct {
 	// volume. When empty, the entire data directory is captured. Only the backup direction filters;
 	// restore reads back the already-filtered capture.
 	Filter         files.FileFilter    `yaml:",inline"`
+	RateLimit      throttle.Limits     `yaml:"rateLimit,omitempty"`   // Caps the rate that files are captured at, to limit the impact on the source volume's storage.
+	Parallelism    int                 `yaml:"parallelism,omitempty"` // Number of files copied concurrently. Zero copies one file at a time.
 	CleanupTimeout helpers.MaxWaitTime `yaml:"cleanupTimeout,omitempty"`
+	// SQLiteDatabases are the paths (relative to the volume root) of SQLite databases on the volume. These
+	// are captured with the SQLite online backup API instead of being copied, so that each one is
+	// consistent, and restore reinstates them as regular files.
+	SQLiteDatabases []string `yaml:"sqliteDatabases,omitempty"`
 }
 
 // FilesBackupInterface is a RemoteStage action that captures a live data-directory PVC into the DR
@@ -203,7 +210,7 @@ func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.
 	}
 
 	drDataPath := filepath.Join(es.mountPaths.drVolume, es.backupDirRelPath)
-	err = backupToolClient.Files().SyncFiles(ctx.Child(), es.mountPaths.data, drDataPath, files.SyncFilesOptions{Filter: es.opts.Filter})
+	err = backupToolClient.Files().SyncFiles(ctx.Child(), es.mountPaths.data, drDataPath, files.SyncFilesOptions{Filter: es.opts.Filter, Limits: es.opts.RateLimit, Parallelism: es.opts.Parallelism, SQLiteDatabases: es.opts.SQLiteDatabases})
 	return trace.Wrap(err, "failed to sync data directory files at %q to the disaster recovery volume at %q", es.mountPaths.data, drDataPath)
 }
 
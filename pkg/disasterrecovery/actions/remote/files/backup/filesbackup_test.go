@@ -18,6 +18,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -429,7 +430,7 @@ func TestExecute(t *testing.T) {
 								sourcePVCName:     "sourcePVCName",
 								drVolName:         "drVolName",
 								backupDirRelPath:  "data-vol",
-								opts:              FilesBackupOptions{Filter: files.FileFilter{Include: []files.FilePattern{{Glob: "*.db"}}, Exclude: []files.FilePattern{{Glob: "*.tmp"}}}},
+								opts:              FilesBackupOptions{Filter: files.FileFilter{Include: []files.FilePattern{{Glob: "*.db"}}, Exclude: []files.FilePattern{{Glob: "*.tmp"}}}, RateLimit: throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10}, Parallelism: 4, SQLiteDatabases: []string{"app.db"}},
 							},
 							isValidated: true,
 						},
@@ -448,7 +449,7 @@ func TestExecute(t *testing.T) {
 				drDataPath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupDirRelPath)
 				// The configured filter must be plumbed through to the sync verbatim; matching on the exact
 				// SyncFilesOptions here asserts the backup direction whitelists/blacklists files.
-				mockFilesRuntime.EXPECT().SyncFiles(mock.Anything, currentState.mountPaths.data, drDataPath, files.SyncFilesOptions{Filter: currentState.opts.Filter}).
+				mockFilesRuntime.EXPECT().SyncFiles(mock.Anything, currentState.mountPaths.data, drDataPath, files.SyncFilesOptions{Filter: currentState.opts.Filter, Limits: currentState.opts.RateLimit, Parallelism: currentState.opts.Parallelism, SQLiteDatabases: currentState.opts.SQLiteDatabases}).
 					RunAndReturn(func(calledCtx *contexts.Context, src, dest string, _ files.SyncFilesOptions) error {
 						assert.True(t, calledCtx.IsChildOf(ctx))
 						return th.ErrIfTrue(tt.simulateSyncErr)
@@ -17,6 +17,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonepvc"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,6 +29,8 @@ type FilesGroupBackupOptions struct {
 	// fileGroups/<group>/<pvc> subdirectory and the restore-side 1:1 member check is unaffected. Backup-only;
 	// restore reads back the already-filtered capture.
 	Filter         files.FileFilter    `yaml:",inline"`
+	RateLimit      throttle.Limits     `yaml:"rateLimit,omitempty"`   // Caps the rate that files are captured at. Applied to each member PVC's sync in turn.
+	Parallelism    int                 `yaml:"parallelism,omitempty"` // Number of files copied concurrently within each member PVC's sync. Zero copies one file at a time.
 	CleanupTimeout helpers.MaxWaitTime `yaml:"cleanupTimeout,omitempty"`
 }
 
@@ -237,7 +240,7 @@ func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.
 
 	for sourcePVCName, mountPath := range es.memberMountPaths {
 		drDataPath := filepath.Join(es.drVolumeMountPath, layout.FileGroupsDirName, es.groupName, sourcePVCName)
-		if err := backupToolClient.Files().SyncFiles(ctx.Child(), mountPath, drDataPath, files.SyncFilesOptions{Filter: es.opts.Filter}); err != nil {
+		if err := backupToolClient.Files().SyncFiles(ctx.Child(), mountPath, drDataPath, files.SyncFilesOptions{Filter: es.opts.Filter, Limits: es.opts.RateLimit, Parallelism: es.opts.Parallelism}); err != nil {
 			return trace.Wrap(err, "failed to sync member %q files at %q to the disaster recovery volume at %q", sourcePVCName, mountPath, drDataPath)
 		}
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/externalsnapshotter"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -472,7 +473,7 @@ func TestExecute(t *testing.T) {
 								kubeClusterClient: kubecluster.NewMockClientInterface(t),
 								namespace:         "namespace",
 								groupName:         "app",
-								opts:              FilesGroupBackupOptions{Filter: files.FileFilter{Exclude: []files.FilePattern{{Glob: "**/*.tmp"}}}},
+								opts:              FilesGroupBackupOptions{Filter: files.FileFilter{Exclude: []files.FilePattern{{Glob: "**/*.tmp"}}}, RateLimit: throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10}, Parallelism: 4},
 							},
 							isValidated: true,
 						},
@@ -492,7 +493,7 @@ func TestExecute(t *testing.T) {
 				for sourcePVC, mountPath := range currentState.memberMountPaths {
 					drDataPath := filepath.Join(currentState.drVolumeMountPath, layout.FileGroupsDirName, currentState.groupName, sourcePVC)
 					// The group-wide filter must be plumbed through to every member's sync verbatim.
-					mockFilesRuntime.EXPECT().SyncFiles(mock.Anything, mountPath, drDataPath, files.SyncFilesOptions{Filter: currentState.opts.Filter}).
+					mockFilesRuntime.EXPECT().SyncFiles(mock.Anything, mountPath, drDataPath, files.SyncFilesOptions{Filter: currentState.opts.Filter, Limits: currentState.opts.RateLimit, Parallelism: currentState.opts.Parallelism}).
 						RunAndReturn(func(calledCtx *contexts.Context, src, dest string, _ files.SyncFilesOptions) error {
 							assert.True(t, calledCtx.IsChildOf(ctx))
 							return th.ErrIfTrue(tt.simulateSyncErr)
@@ -14,10 +14,14 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-type FilesGroupRestoreOptions struct{}
+type FilesGroupRestoreOptions struct {
+	RateLimit   throttle.Limits `yaml:"rateLimit,omitempty"`   // Caps the rate that files are restored at. Applied to each member PVC's sync in turn.
+	Parallelism int             `yaml:"parallelism,omitempty"` // Number of files copied concurrently within each member PVC's sync. Zero copies one file at a time.
+}
 
 // FilesGroupRestoreInterface is a RemoteStage action that restores a file-group capture from the DR
 // volume back onto its target PVCs. Membership is supplied the same way it was at backup time - a label
@@ -167,7 +171,7 @@ func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.
 	// 1:1 confirmed - restore each captured member into its identically-named target PVC.
 	for targetPVCName, mountPath := range es.targetMountPaths {
 		srcPath := filepath.Join(groupDirPath, targetPVCName)
-		if err := backupToolClient.Files().SyncFiles(ctx.Child(), srcPath, mountPath, files.SyncFilesOptions{}); err != nil {
+		if err := backupToolClient.Files().SyncFiles(ctx.Child(), srcPath, mountPath, files.SyncFilesOptions{Limits: es.opts.RateLimit, Parallelism: es.opts.Parallelism}); err != nil {
 			return trace.Wrap(err, "failed to sync captured member %q at %q onto target PVC at %q", targetPVCName, srcPath, mountPath)
 		}
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -318,7 +319,7 @@ func TestExecute(t *testing.T) {
 							kubeClusterClient: kubecluster.NewMockClientInterface(t),
 							namespace:         "namespace",
 							groupName:         groupName,
-							opts:              FilesGroupRestoreOptions{},
+							opts:              FilesGroupRestoreOptions{RateLimit: throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10}, Parallelism: 4},
 						},
 						isValidated: true,
 					},
@@ -344,7 +345,7 @@ func TestExecute(t *testing.T) {
 				if !tt.simulateListErr && !tt.expectMismatch {
 					for targetPVCName, mountPath := range tt.targetMountPaths {
 						srcPath := filepath.Join(groupDirPath, targetPVCName)
-						mockFilesRuntime.EXPECT().SyncFiles(mock.Anything, srcPath, mountPath, files.SyncFilesOptions{}).
+						mockFilesRuntime.EXPECT().SyncFiles(mock.Anything, srcPath, mountPath, files.SyncFilesOptions{Limits: currentState.opts.RateLimit, Parallelism: currentState.opts.Parallelism}).
 							RunAndReturn(func(calledCtx *contexts.Context, src, dest string, _ files.SyncFilesOptions) error {
 								assert.True(t, calledCtx.IsChildOf(ctx))
 								return th.ErrIfTrue(tt.simulateSyncErr)
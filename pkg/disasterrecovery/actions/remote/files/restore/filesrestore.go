@@ -12,9 +12,13 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 )
 
-type FilesRestoreOptions struct{}
+type FilesRestoreOptions struct {
+	RateLimit   throttle.Limits `yaml:"rateLimit,omitempty"`   // Caps the rate that files are restored at, to limit the impact on the target volume's storage.
+	Parallelism int             `yaml:"parallelism,omitempty"` // Number of files copied concurrently. Zero copies one file at a time.
+}
 
 // FilesRestoreInterface is a RemoteStage action that restores a data-directory capture from the DR
 // volume back onto a target PVC. The target PVC must already exist and not be in use (a restore
@@ -134,7 +138,7 @@ func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.
 	}
 
 	drDataPath := filepath.Join(es.mountPaths.drVolume, es.backupDirRelPath)
-	err = backupToolClient.Files().SyncFiles(ctx.Child(), drDataPath, es.mountPaths.data, files.SyncFilesOptions{})
+	err = backupToolClient.Files().SyncFiles(ctx.Child(), drDataPath, es.mountPaths.data, files.SyncFilesOptions{Limits: es.opts.RateLimit, Parallelism: es.opts.Parallelism})
 	return trace.Wrap(err, "failed to sync data directory files at %q to the data PVC at %q", drDataPath, es.mountPaths.data)
 }
 
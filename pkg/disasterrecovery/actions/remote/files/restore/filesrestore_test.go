@@ -15,6 +15,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -275,7 +276,7 @@ func TestExecute(t *testing.T) {
 							targetPVCName:     "targetPVCName",
 							drVolName:         "drVolName",
 							backupDirRelPath:  "data-vol",
-							opts:              FilesRestoreOptions{},
+							opts:              FilesRestoreOptions{RateLimit: throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10}, Parallelism: 4},
 						},
 						isValidated: true,
 					},
@@ -290,7 +291,7 @@ func TestExecute(t *testing.T) {
 			ctx := th.NewTestContext()
 			if currentState.isSetup {
 				drDataPath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupDirRelPath)
-				mockFilesRuntime.EXPECT().SyncFiles(mock.Anything, drDataPath, currentState.mountPaths.data, files.SyncFilesOptions{}).
+				mockFilesRuntime.EXPECT().SyncFiles(mock.Anything, drDataPath, currentState.mountPaths.data, files.SyncFilesOptions{Limits: currentState.opts.RateLimit, Parallelism: currentState.opts.Parallelism}).
 					RunAndReturn(func(calledCtx *contexts.Context, src, dest string, _ files.SyncFilesOptions) error {
 						assert.True(t, calledCtx.IsChildOf(ctx))
 						return th.ErrIfTrue(tt.simulateSyncErr)
@@ -0,0 +1,188 @@
+package backup
+
+import (
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/mysql/common"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+)
+
+type MySQLBackupOptions struct {
+	// Consistency selects how the dump is kept consistent while the server is written to. Empty means a
+	// single transaction, which is consistent for InnoDB tables without locking them.
+	Consistency mysql.Consistency `yaml:"consistency,omitempty"`
+	// RecordBinlogPosition records the server's binary log position in the dump. Requires the RELOAD privilege.
+	RecordBinlogPosition bool     `yaml:"recordBinlogPosition,omitempty"`
+	IncludeDatabases     []string `yaml:"includeDatabases,omitempty"` // Empty dumps every database other than the system ones.
+	ExcludeDatabases     []string `yaml:"excludeDatabases,omitempty"`
+	ExcludeTables        []string `yaml:"excludeTables,omitempty"` // Each one is named as "database.table".
+	// ServingCASecret optionally names a Secret holding the CA certificate (ca.crt) that the server's
+	// certificate is verified against. It is mounted into the backup-tool instance.
+	ServingCASecret string `yaml:"servingCASecret,omitempty"`
+}
+
+func (opts MySQLBackupOptions) dumpOptions() mysql.DumpOptions {
+	return mysql.DumpOptions{
+		Consistency:          opts.Consistency,
+		RecordBinlogPosition: opts.RecordBinlogPosition,
+		IncludeDatabases:     opts.IncludeDatabases,
+		ExcludeDatabases:     opts.ExcludeDatabases,
+		ExcludeTables:        opts.ExcludeTables,
+	}
+}
+
+// MySQLBackupInterface is a RemoteStage action that logically dumps a MySQL or MariaDB server, directly from
+// the backup-tool instance. Like a postgres server backup, the dump is taken from the live server and does
+// not participate in the stage's consistency-point protocol.
+type MySQLBackupInterface interface {
+	remote.RemoteAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace string, credentials mysql.Credentials, drVolName, backupFileRelPath string, opts MySQLBackupOptions) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	credentials       mysql.Credentials
+	drVolName         string
+	backupFileRelPath string
+	opts              MySQLBackupOptions
+}
+
+// Configures the action prior to validation and execution. This should be called before
+// any other methods. Returns an error if the action is already configured.
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace string, credentials mysql.Credentials, drVolName, backupFileRelPath string, opts MySQLBackupOptions) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.credentials = credentials
+	cs.drVolName = drVolName
+	cs.backupFileRelPath = backupFileRelPath
+	cs.opts = opts
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("host", cs.credentials.Host, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+// Validates that the required resources are ready. This should be called after `Configure`
+// and before `Setup`. Returns an error if the resources are not ready.
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for MySQL backup")
+	defer ctx.Log.Info("Completed MySQL backup configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if err := vs.credentials.Validate(); err != nil {
+		return trace.Wrap(err, "invalid credentials")
+	}
+
+	if err := vs.opts.dumpOptions().Validate(); err != nil {
+		return trace.Wrap(err, "invalid dump options")
+	}
+
+	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.drVolName); err != nil {
+		return trace.Wrap(err, "failed to get DR PVC %q", vs.drVolName)
+	}
+
+	if vs.opts.ServingCASecret != "" {
+		if err := common.ValidateServingCASecret(ctx.Child(), vs.kubeClusterClient, vs.namespace, vs.opts.ServingCASecret); err != nil {
+			return trace.Wrap(err, "invalid serving CA secret")
+		}
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+type setupStateMountPaths struct {
+	drVolume  string
+	servingCA string
+}
+
+type setupState struct {
+	validateState
+	mountPaths setupStateMountPaths
+	isSetup    bool
+}
+
+// Prepares the backup tool pod to be able to perform the backup. This should be called
+// after `Validate` and before `Execute`. Returns an error if the pod cannot be prepared.
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for MySQL backup")
+	defer ctx.Log.Info("MySQL backup setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	baseMountPath := filepath.Join("/mnt", "mysqlbackup", ss.uid)
+	ss.mountPaths = setupStateMountPaths{
+		drVolume: filepath.Join(baseMountPath, "dr"),
+	}
+
+	btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.drVolName, ss.mountPaths.drVolume))
+
+	if ss.opts.ServingCASecret != "" {
+		ss.mountPaths.servingCA = filepath.Join(baseMountPath, "serving-ca")
+		servingCAVolume, caFilePath := common.ServingCAVolume(ss.opts.ServingCASecret, ss.mountPaths.servingCA)
+		btiOpts.Volumes = append(btiOpts.Volumes, servingCAVolume)
+		ss.credentials.SSLCAFilePath = caFilePath
+	}
+
+	ss.isSetup = true
+	return nil
+}
+
+type executeState struct {
+	setupState
+}
+
+// Backs up the server. This should be called after `Setup`. Returns an error if the backup fails.
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing MySQL backup")
+	defer ctx.Log.Info("MySQL backup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	podSQLFilePath := filepath.Join(es.mountPaths.drVolume, es.backupFileRelPath)
+	err = backupToolClient.MySQL().Dump(ctx.Child(), es.credentials, podSQLFilePath, es.opts.dumpOptions())
+	return trace.Wrap(err, "failed to create logical backup for MySQL server at %q", es.credentials.Address())
+}
+
+type MySQLBackup struct {
+	executeState
+}
+
+func NewMySQLBackup() MySQLBackupInterface {
+	return &MySQLBackup{}
+}
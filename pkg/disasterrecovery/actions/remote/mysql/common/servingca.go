@@ -0,0 +1,37 @@
+package common
+
+import (
+	"path/filepath"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The key of the CA certificate that is mounted from a serving CA Secret. This matches the key of the Secrets
+// that cert-manager issues.
+const servingCASecretKey = "ca.crt"
+
+// ValidateServingCASecret checks that the Secret exists in the namespace, and holds the CA certificate that is
+// mounted from it.
+func ValidateServingCASecret(ctx *contexts.Context, kubeClusterClient kubecluster.ClientInterface, namespace, name string) error {
+	secret, err := kubeClusterClient.Core().GetSecret(ctx.Child(), namespace, name)
+	if err != nil {
+		return trace.Wrap(err, "failed to get secret %q", name)
+	}
+
+	if _, ok := secret.Data[servingCASecretKey]; !ok {
+		return trace.NotFound("secret %q does not hold a %q key", name, servingCASecretKey)
+	}
+
+	return nil
+}
+
+// ServingCAVolume returns the volume that mounts the CA certificate from the Secret under the mount path, and
+// where the certificate is in the backup-tool instance.
+func ServingCAVolume(name, mountPath string) (core.SingleContainerVolume, string) {
+	volume := core.NewSingleContainerSecret(name, mountPath, corev1.KeyToPath{Key: servingCASecretKey, Path: servingCASecretKey})
+	return volume, filepath.Join(mountPath, servingCASecretKey)
+}
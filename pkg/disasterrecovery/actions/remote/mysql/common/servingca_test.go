@@ -0,0 +1,71 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateServingCASecret(t *testing.T) {
+	tests := []struct {
+		desc                   string
+		secret                 *corev1.Secret
+		simulateGetSecretError bool
+		wantErr                bool
+	}{
+		{
+			desc:   "succeeds",
+			secret: &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca")}},
+		},
+		{
+			desc:                   "fails to get the secret",
+			simulateGetSecretError: true,
+			wantErr:                true,
+		},
+		{
+			desc:    "fails because the secret does not hold the CA certificate",
+			secret:  &corev1.Secret{Data: map[string][]byte{"tls.crt": []byte("cert")}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockCoreClient := core.NewMockClientInterface(t)
+			mockClient.EXPECT().Core().Return(mockCoreClient)
+
+			ctx := th.NewTestContext()
+			mockCoreClient.EXPECT().GetSecret(mock.Anything, "namespace", "serving-ca").
+				RunAndReturn(func(calledCtx *contexts.Context, _, _ string) (*corev1.Secret, error) {
+					assert.True(t, calledCtx.IsChildOf(ctx))
+					return th.ErrOr1Val(tt.secret, tt.simulateGetSecretError)
+				})
+
+			err := ValidateServingCASecret(ctx, mockClient, "namespace", "serving-ca")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServingCAVolume(t *testing.T) {
+	volume, caFilePath := ServingCAVolume("serving-ca", "/mnt/serving-ca")
+
+	assert.Equal(t, "/mnt/serving-ca/ca.crt", caFilePath)
+	assert.Equal(t, []string{"/mnt/serving-ca"}, volume.MountPaths)
+	require.NotNil(t, volume.VolumeSource.Secret)
+	assert.Equal(t, "serving-ca", volume.VolumeSource.Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}}, volume.VolumeSource.Secret.Items)
+}
@@ -0,0 +1,335 @@
+package restore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var testCredentials = mysql.Credentials{
+	Host:     "db.example.com",
+	User:     "root",
+	Password: "password",
+}
+
+func TestMySQLRestoreOptions(t *testing.T) {
+	th.OptStructTest[MySQLRestoreOptions](t)
+}
+
+func TestConfigure(t *testing.T) {
+	expectedState := &configureState{
+		kubeClusterClient: kubecluster.NewMockClientInterface(t),
+		namespace:         "namespace",
+		credentials:       testCredentials,
+		drVolName:         "drVolName",
+		backupFileRelPath: "backupFileRelPath",
+		opts:              MySQLRestoreOptions{Force: true},
+	}
+
+	action := NewMySQLRestore()
+	err := action.Configure(
+		expectedState.kubeClusterClient,
+		expectedState.namespace,
+		expectedState.credentials,
+		expectedState.drVolName,
+		expectedState.backupFileRelPath,
+		expectedState.opts,
+	)
+
+	t.Run("successfully configures the first time", func(t *testing.T) {
+		require.NoError(t, err)
+	})
+
+	t.Run("all state vars are populated", func(t *testing.T) {
+		casted := action.(*MySQLRestore)
+
+		assert.NotEqual(t, "", casted.uid)
+		assert.NotEqual(t, uuid.Nil.String(), casted.uid)
+		expectedState.uid = casted.uid
+
+		assert.True(t, casted.isConfigured)
+		expectedState.isConfigured = casted.isConfigured
+
+		assert.Equal(t, expectedState, &casted.configureState)
+	})
+
+	t.Run("fails to configure because already configured", func(t *testing.T) {
+		err = action.Configure(
+			expectedState.kubeClusterClient,
+			expectedState.namespace,
+			expectedState.credentials,
+			expectedState.drVolName,
+			expectedState.backupFileRelPath,
+			expectedState.opts,
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	notConfiguredState := &configureState{}
+	configuredState := &configureState{}
+	require.NoError(t, configuredState.Configure(nil, "namespace", testCredentials, "drVolName", "backupFileRelPath", MySQLRestoreOptions{}))
+
+	invalidCredentialsState := &configureState{}
+	require.NoError(t, invalidCredentialsState.Configure(nil, "namespace", mysql.Credentials{Host: "db.example.com"}, "drVolName", "backupFileRelPath", MySQLRestoreOptions{}))
+
+	servingCAState := &configureState{}
+	require.NoError(t, servingCAState.Configure(nil, "namespace", testCredentials, "drVolName", "backupFileRelPath", MySQLRestoreOptions{ServingCASecret: "serving-ca"}))
+
+	tests := []struct {
+		desc                 string
+		configState          *configureState
+		isAlreadyValidated   bool
+		simulateGetPVCErr    bool
+		simulateGetSecretErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:               "succeeds if called multiple times",
+			isAlreadyValidated: true,
+		},
+		{
+			desc:        "fails because not configured",
+			configState: notConfiguredState,
+		},
+		{
+			desc:        "fails because the credentials are invalid",
+			configState: invalidCredentialsState,
+		},
+		{
+			desc:              "fails to get DR PVC",
+			simulateGetPVCErr: true,
+		},
+		{
+			desc:        "succeeds with a serving CA secret",
+			configState: servingCAState,
+		},
+		{
+			desc:                 "fails to get the serving CA secret",
+			configState:          servingCAState,
+			simulateGetSecretErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockCoreClient := core.NewMockClientInterface(t)
+			mockClient.EXPECT().Core().Return(mockCoreClient).Maybe()
+
+			if tt.configState == nil {
+				tt.configState = configuredState
+			}
+
+			currentState := &validateState{
+				configureState: *tt.configState,
+				isValidated:    tt.isAlreadyValidated,
+			}
+			currentState.kubeClusterClient = mockClient
+
+			ctx := th.NewTestContext()
+
+			isValid := currentState.isConfigured && tt.configState != invalidCredentialsState
+			wantErr := th.ErrExpected(
+				!isValid,
+				tt.simulateGetPVCErr,
+				tt.simulateGetSecretErr,
+			)
+
+			if isValid {
+				mockCoreClient.EXPECT().GetPVC(mock.Anything, currentState.namespace, currentState.drVolName).
+					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+
+						return nil, th.ErrIfTrue(tt.simulateGetPVCErr)
+					})
+			}
+
+			if isValid && !tt.simulateGetPVCErr && currentState.opts.ServingCASecret != "" {
+				mockCoreClient.EXPECT().GetSecret(mock.Anything, currentState.namespace, currentState.opts.ServingCASecret).
+					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.Secret, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+
+						secret := &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca")}}
+						return th.ErrOr1Val(secret, tt.simulateGetSecretErr)
+					})
+			}
+
+			err := currentState.Validate(ctx)
+			if wantErr {
+				assert.Error(t, err)
+				assert.False(t, currentState.isValidated)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isValidated)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		desc                    string
+		hasBeenNotBeenValidated bool
+		isAlreadySetup          bool
+		servingCASecret         string
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:            "succeeds with a serving CA secret",
+			servingCASecret: "serving-ca",
+		},
+		{
+			desc:                    "fails because not validated first",
+			hasBeenNotBeenValidated: true,
+		},
+		{
+			desc:           "fails if called multiple times",
+			isAlreadySetup: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:               "uid",
+						isConfigured:      true,
+						namespace:         "namespace",
+						credentials:       testCredentials,
+						drVolName:         "drVolName",
+						backupFileRelPath: "backupFileRelPath",
+						opts:              MySQLRestoreOptions{ServingCASecret: tt.servingCASecret},
+					},
+					isValidated: !tt.hasBeenNotBeenValidated,
+				},
+				isSetup: tt.isAlreadySetup,
+			}
+
+			btiOpts := &backuptoolinstance.CreateBackupToolInstanceOptions{}
+			err := currentState.Setup(th.NewTestContext(), btiOpts)
+			if th.ErrExpected(tt.hasBeenNotBeenValidated, tt.isAlreadySetup) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Contains(t, currentState.mountPaths.drVolume, currentState.uid)
+
+			if tt.servingCASecret == "" {
+				require.Len(t, btiOpts.Volumes, 1)
+				assert.Empty(t, currentState.credentials.SSLCAFilePath)
+			} else {
+				require.Len(t, btiOpts.Volumes, 2)
+				assert.Contains(t, currentState.mountPaths.servingCA, currentState.uid)
+				assert.Equal(t, []string{currentState.mountPaths.servingCA}, btiOpts.Volumes[1].MountPaths)
+				require.NotNil(t, btiOpts.Volumes[1].VolumeSource.Secret)
+				assert.Equal(t, tt.servingCASecret, btiOpts.Volumes[1].VolumeSource.Secret.SecretName)
+				assert.Equal(t, filepath.Join(currentState.mountPaths.servingCA, "ca.crt"), currentState.credentials.SSLCAFilePath)
+			}
+
+			assert.Equal(t, []string{currentState.mountPaths.drVolume}, btiOpts.Volumes[0].MountPaths)
+			require.NotNil(t, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim)
+			assert.Equal(t, currentState.drVolName, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		desc               string
+		hasNotBeenSetup    bool
+		simulateRestoreErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:            "fails if not setup first",
+			hasNotBeenSetup: true,
+		},
+		{
+			desc:               "fails to restore",
+			simulateRestoreErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockMySQL := mysql.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().MySQL().Return(mockMySQL).Maybe()
+
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							namespace:         "namespace",
+							credentials:       testCredentials,
+							drVolName:         "drVolName",
+							backupFileRelPath: "backupFileRelPath",
+							opts:              MySQLRestoreOptions{Force: true},
+						},
+						isValidated: true,
+					},
+					mountPaths: setupStateMountPaths{
+						drVolume: "/dr-volume",
+					},
+					isSetup: !tt.hasNotBeenSetup,
+				},
+			}
+
+			ctx := th.NewTestContext()
+			if currentState.isSetup {
+				drFilePath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupFileRelPath) // Important: Changing this is a breaking change!
+				mockMySQL.EXPECT().Restore(mock.Anything, testCredentials, drFilePath, mysql.RestoreOptions{Force: true}).
+					RunAndReturn(func(calledCtx *contexts.Context, credentials mysql.Credentials, filePath string, opts mysql.RestoreOptions) error {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+
+						return th.ErrIfTrue(tt.simulateRestoreErr)
+					})
+			}
+
+			err := currentState.Execute(ctx, mockGRPC)
+			if tt.hasNotBeenSetup || tt.simulateRestoreErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMySQLRestore(t *testing.T) {
+	assert.Implements(t, (*MySQLRestoreInterface)(nil), (*MySQLRestore)(nil))
+	assert.Implements(t, (*remote.RemoteAction)(nil), (*MySQLRestore)(nil))
+}
+
+func TestNewMySQLRestore(t *testing.T) {
+	// State vars should not be populated yet
+	assert.Equal(t, &MySQLRestore{}, NewMySQLRestore())
+}
@@ -0,0 +1,325 @@
+package export
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/cleanup"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonepvc"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type ExportOptions struct {
+	RateLimit              throttle.Limits     `yaml:"rateLimit,omitempty"`        // Caps the rate that objects are uploaded at, across all concurrent object transfers.
+	Multipart              s3.MultipartOptions `yaml:"multipart,omitempty"`        // Controls which files are uploaded in parts, and the part size.
+	StorageClassName       string              `yaml:"storageClassName,omitempty"` // Override the storage class of the volume created from the snapshot.
+	WaitForSnapshotTimeout helpers.MaxWaitTime `yaml:"waitForSnapshotTimeout,omitempty"`
+	CleanupTimeout         helpers.MaxWaitTime `yaml:"cleanupTimeout,omitempty"`
+	// ObjectLock protects the uploaded objects from being deleted or overwritten. The bucket must have Object
+	// Lock enabled.
+	ObjectLock s3.ObjectLock `yaml:"objectLock,omitempty"`
+	// Repository adds the volume to a deduplicating repository at the S3 path as a new snapshot, instead of
+	// mirroring its files there. Nil mirrors the files.
+	Repository *RepositoryOptions `yaml:"repository,omitempty"`
+	// ManifestPath is the s3://bucket/key that a mirror writes its manifest to once the files are uploaded.
+	// It must not be under the S3 path, or the next export would delete it. Empty writes no manifest. The
+	// repository records the same details in its own snapshots.
+	ManifestPath string `yaml:"manifestPath,omitempty"`
+}
+
+// MirrorManifest records when a mirror export was taken, and the Object Lock protection that its objects
+// were given. The manifest itself is given the same protection.
+type MirrorManifest struct {
+	ExportedAt time.Time          `json:"exportedAt"`
+	Retention  s3.ObjectRetention `json:"retention,omitzero"`
+}
+
+// RepositoryOptions configures exporting to a deduplicating repository (see the repository package). Only the
+// chunks that the repository doesn't already hold are uploaded, so exporting successive backups of the same
+// volume is incremental.
+type RepositoryOptions struct {
+	SnapshotName string   `yaml:"snapshotName,omitempty"` // Groups the snapshots of one volume. Files unchanged since the previous snapshot are not read again.
+	Tags         []string `yaml:"tags,omitempty"`         // Labels the snapshot, such as with the backup event that it was taken for.
+	KeepLast     int      `yaml:"keepLast,omitempty"`     // Prunes the repository to this many snapshots of SnapshotName after exporting. Zero keeps every snapshot.
+}
+
+// ExportInterface is a RemoteStage action that copies the contents of a volume snapshot (typically a DR
+// volume snapshot) to object storage, so that the backup survives the loss of the storage that the snapshot
+// lives on. The snapshot is restored to a temporary PVC, which is mounted into the tool pod and uploaded to
+// the S3 path. The temporary PVC is removed on Cleanup.
+type ExportInterface interface {
+	remote.CleanupAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace, snapshotName, s3Path string, credentials s3.CredentialsInterface, opts ExportOptions) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	snapshotName      string
+	s3Path            string
+	credentials       s3.CredentialsInterface
+	opts              ExportOptions
+}
+
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace, snapshotName, s3Path string, credentials s3.CredentialsInterface, opts ExportOptions) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.snapshotName = snapshotName
+	cs.s3Path = s3Path
+	cs.credentials = credentials
+	cs.opts = opts
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("snapshot", cs.snapshotName, "s3Path", cs.s3Path, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for export")
+	defer ctx.Log.Info("Completed export configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if vs.snapshotName == "" {
+		return trace.BadParameter("no snapshot name provided")
+	}
+
+	if vs.s3Path == "" {
+		return trace.BadParameter("no S3 path provided")
+	}
+
+	if vs.credentials == nil {
+		return trace.BadParameter("no S3 credentials provided")
+	}
+
+	if err := vs.opts.RateLimit.Validate(); err != nil {
+		return trace.Wrap(err, "invalid rate limit")
+	}
+
+	if err := vs.opts.Multipart.Validate(); err != nil {
+		return trace.Wrap(err, "invalid multipart options")
+	}
+
+	if err := vs.opts.ObjectLock.Validate(); err != nil {
+		return trace.Wrap(err, "invalid object lock")
+	}
+
+	if vs.opts.ManifestPath != "" {
+		if vs.opts.Repository != nil {
+			return trace.BadParameter("a manifest is only written when mirroring")
+		}
+
+		if _, _, err := splitManifestPath(vs.opts.ManifestPath); err != nil {
+			return trace.Wrap(err, "invalid manifest path")
+		}
+	}
+
+	if vs.opts.Repository != nil {
+		if vs.opts.Repository.SnapshotName == "" {
+			return trace.BadParameter("no repository snapshot name provided")
+		}
+
+		if vs.opts.Repository.KeepLast < 0 {
+			return trace.BadParameter("the number of repository snapshots to keep must not be negative")
+		}
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+type setupState struct {
+	validateState
+	restoredPVC *corev1.PersistentVolumeClaim
+	mountPath   string
+	isSetup     bool
+}
+
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for export")
+	defer ctx.Log.Info("Export setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	// The snapshot is not deleted afterwards, so the restored PVC does not need to be force bound. It
+	// will bind when the tool pod is scheduled.
+	restoredPVC, err := ss.kubeClusterClient.RestoreSnapshot(ctx.Child(), ss.namespace, ss.snapshotName, clonepvc.RestoreSnapshotOptions{
+		WaitForSnapshotTimeout: ss.opts.WaitForSnapshotTimeout,
+		DestStorageClassName:   ss.opts.StorageClassName,
+	})
+	if err != nil {
+		return trace.Wrap(err, "failed to restore snapshot %q to a new PVC", helpers.FullNameStr(ss.namespace, ss.snapshotName))
+	}
+	ss.restoredPVC = restoredPVC
+
+	ss.mountPath = filepath.Join("/mnt", "export", ss.uid)
+	btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.restoredPVC.Name, ss.mountPath))
+
+	ss.isSetup = true
+	return nil
+}
+
+// Cleanup tears down the PVC restored from the snapshot. It tolerates partial state (e.g. the action
+// never reached Setup because another action failed first), deleting nothing in that case.
+func (ss *setupState) Cleanup(ctx *contexts.Context) error {
+	if ss.restoredPVC == nil {
+		return nil
+	}
+
+	err := cleanup.To(func(ctx *contexts.Context) error {
+		return ss.kubeClusterClient.Core().DeletePVC(ctx, ss.namespace, ss.restoredPVC.Name)
+	}).WithErrMessage("failed to cleanup restored snapshot PVC %q", helpers.FullName(ss.restoredPVC)).
+		WithParentCtx(ctx).WithTimeout(ss.opts.CleanupTimeout.MaxWait(time.Minute)).
+		RunError()
+	return trace.Wrap(err, "failed to cleanup export resources")
+}
+
+type executeState struct {
+	setupState
+}
+
+// Execute uploads the whole volume, including the manifests that S3 source backups write beside their
+// directories, so that an import reproduces the DR volume exactly.
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing export")
+	defer ctx.Log.Info("Export complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	if es.opts.Repository != nil {
+		return trace.Wrap(es.exportToRepository(ctx.Child(), backupToolClient.Repository()))
+	}
+
+	err = backupToolClient.S3().Sync(ctx.Child(), es.credentials, es.mountPath, es.s3Path, time.Time{}, s3.SyncOptions{
+		Limits:     es.opts.RateLimit,
+		Multipart:  es.opts.Multipart,
+		ObjectLock: es.opts.ObjectLock,
+	})
+	if err != nil {
+		return trace.Wrap(err, "failed to upload the contents of snapshot %q to %q", es.snapshotName, es.s3Path)
+	}
+
+	return trace.Wrap(es.writeMirrorManifest(ctx.Child()))
+}
+
+// writeMirrorManifest writes the mirror's manifest, if configured to. The retention is counted from after
+// the upload completes, so it expires no earlier than that of any uploaded object.
+func (es *executeState) writeMirrorManifest(ctx *contexts.Context) error {
+	if es.opts.ManifestPath == "" {
+		return nil
+	}
+
+	now := time.Now()
+	manifest := MirrorManifest{
+		ExportedAt: now.UTC(),
+		Retention:  es.opts.ObjectLock.At(now),
+	}
+
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return trace.Wrap(err, "failed to encode the manifest")
+	}
+
+	dirPath, key, err := splitManifestPath(es.opts.ManifestPath)
+	if err != nil {
+		return trace.Wrap(err, "invalid manifest path")
+	}
+
+	err = putObject(ctx, es.credentials, dirPath, key, contents, manifest.Retention)
+	return trace.Wrap(err, "failed to write the manifest to %q", es.opts.ManifestPath)
+}
+
+// splitManifestPath splits an s3://bucket/key path into the path of the directory holding the object, and
+// the object's key relative to it.
+func splitManifestPath(manifestPath string) (string, string, error) {
+	if !strings.HasPrefix(manifestPath, "s3://") {
+		return "", "", trace.BadParameter("path %q is not an s3:// URL", manifestPath)
+	}
+
+	i := strings.LastIndex(manifestPath, "/")
+	dirPath, key := manifestPath[:i], manifestPath[i+1:]
+	if key == "" || dirPath == "s3:/" {
+		return "", "", trace.BadParameter("path %q does not name an object", manifestPath)
+	}
+
+	return dirPath, key, nil
+}
+
+// putObject writes a single object, protected by the retention. Testing injection.
+var putObject = func(ctx *contexts.Context, credentials s3.CredentialsInterface, dirPath, key string, contents []byte, retention s3.ObjectRetention) error {
+	store, err := s3.NewObjectStore(credentials, dirPath)
+	if err != nil {
+		return trace.Wrap(err, "failed to open %q", dirPath)
+	}
+
+	return trace.Wrap(store.WithRetention(retention).Put(ctx, key, contents))
+}
+
+// exportToRepository adds the volume to the repository at the S3 path, and then prunes the repository if
+// configured to.
+func (es *executeState) exportToRepository(ctx *contexts.Context, repositoryRuntime repository.Runtime) error {
+	snapshot, err := repositoryRuntime.Backup(ctx.Child(), es.credentials, es.s3Path, es.mountPath, repository.BackupOptions{
+		Name:       es.opts.Repository.SnapshotName,
+		Tags:       es.opts.Repository.Tags,
+		Limits:     es.opts.RateLimit,
+		ObjectLock: es.opts.ObjectLock,
+	})
+	if err != nil {
+		return trace.Wrap(err, "failed to back up the contents of snapshot %q to repository %q", es.snapshotName, es.s3Path)
+	}
+	ctx.Log.With("repositorySnapshot", snapshot.ID, "addedBytes", snapshot.AddedBytes).Info("Exported to repository")
+
+	if es.opts.Repository.KeepLast == 0 {
+		return nil
+	}
+
+	_, err = repositoryRuntime.Prune(ctx.Child(), es.credentials, es.s3Path, repository.PruneOptions{KeepLast: es.opts.Repository.KeepLast})
+	return trace.Wrap(err, "failed to prune repository %q", es.s3Path)
+}
+
+type Export struct {
+	executeState
+}
+
+func NewExport() ExportInterface {
+	return &Export{}
+}
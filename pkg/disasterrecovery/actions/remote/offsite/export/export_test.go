@@ -0,0 +1,541 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonepvc"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExportOptions(t *testing.T) {
+	th.OptStructTest[ExportOptions](t)
+}
+
+func TestRepositoryOptions(t *testing.T) {
+	th.OptStructTest[RepositoryOptions](t)
+}
+
+func TestConfigure(t *testing.T) {
+	expectedState := &configureState{
+		kubeClusterClient: kubecluster.NewMockClientInterface(t),
+		namespace:         "namespace",
+		snapshotName:      "snapshotName",
+		s3Path:            "s3://bucket/prefix",
+		credentials:       s3.NewMockCredentialsInterface(t),
+		opts: ExportOptions{
+			RateLimit:      throttle.Limits{BytesPerSecond: 1024},
+			CleanupTimeout: helpers.ShortWaitTime,
+		},
+	}
+
+	e := NewExport()
+	err := e.Configure(
+		expectedState.kubeClusterClient,
+		expectedState.namespace,
+		expectedState.snapshotName,
+		expectedState.s3Path,
+		expectedState.credentials,
+		expectedState.opts,
+	)
+
+	t.Run("successfully configures the first time", func(t *testing.T) {
+		require.NoError(t, err)
+	})
+
+	t.Run("all state vars are populated", func(t *testing.T) {
+		casted := e.(*Export)
+
+		assert.NotEqual(t, "", casted.uid)
+		assert.NotEqual(t, uuid.Nil.String(), casted.uid)
+		expectedState.uid = casted.uid
+
+		assert.True(t, casted.isConfigured)
+		expectedState.isConfigured = casted.isConfigured
+
+		assert.Equal(t, expectedState, &casted.configureState)
+	})
+
+	t.Run("fails to configure because already configured", func(t *testing.T) {
+		err = e.Configure(
+			expectedState.kubeClusterClient,
+			expectedState.namespace,
+			expectedState.snapshotName,
+			expectedState.s3Path,
+			expectedState.credentials,
+			expectedState.opts,
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc               string
+		notConfigured      bool
+		isAlreadyValidated bool
+		noSnapshotName     bool
+		noS3Path           bool
+		noCredentials      bool
+		opts               ExportOptions
+		shouldErr          bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:               "succeeds if called multiple times",
+			isAlreadyValidated: true,
+		},
+		{
+			desc:          "fails because not configured",
+			notConfigured: true,
+			shouldErr:     true,
+		},
+		{
+			desc:           "fails without a snapshot name",
+			noSnapshotName: true,
+			shouldErr:      true,
+		},
+		{
+			desc:      "fails without an S3 path",
+			noS3Path:  true,
+			shouldErr: true,
+		},
+		{
+			desc:          "fails without credentials",
+			noCredentials: true,
+			shouldErr:     true,
+		},
+		{
+			desc:      "fails with an invalid rate limit",
+			opts:      ExportOptions{RateLimit: throttle.Limits{BytesPerSecond: -1}},
+			shouldErr: true,
+		},
+		{
+			desc:      "fails with invalid multipart options",
+			opts:      ExportOptions{Multipart: s3.MultipartOptions{Threshold: -1}},
+			shouldErr: true,
+		},
+		{
+			desc: "succeeds with a repository",
+			opts: ExportOptions{Repository: &RepositoryOptions{SnapshotName: "backup", KeepLast: 3}},
+		},
+		{
+			desc: "succeeds with an object lock",
+			opts: ExportOptions{ObjectLock: s3.ObjectLock{Mode: s3.ObjectLockModeGovernance, Retention: time.Hour}},
+		},
+		{
+			desc:      "fails with an invalid object lock",
+			opts:      ExportOptions{ObjectLock: s3.ObjectLock{Retention: time.Hour}},
+			shouldErr: true,
+		},
+		{
+			desc:      "fails with a repository without a snapshot name",
+			opts:      ExportOptions{Repository: &RepositoryOptions{}},
+			shouldErr: true,
+		},
+		{
+			desc:      "fails with a repository with a negative number of snapshots to keep",
+			opts:      ExportOptions{Repository: &RepositoryOptions{SnapshotName: "backup", KeepLast: -1}},
+			shouldErr: true,
+		},
+		{
+			desc: "succeeds with a manifest path",
+			opts: ExportOptions{ManifestPath: "s3://bucket/prefix.json"},
+		},
+		{
+			desc:      "fails with a manifest path that is not an S3 URL",
+			opts:      ExportOptions{ManifestPath: "/prefix.json"},
+			shouldErr: true,
+		},
+		{
+			desc:      "fails with a manifest path that does not name an object",
+			opts:      ExportOptions{ManifestPath: "s3://bucket/"},
+			shouldErr: true,
+		},
+		{
+			desc:      "fails with a manifest path and a repository",
+			opts:      ExportOptions{ManifestPath: "s3://bucket/prefix.json", Repository: &RepositoryOptions{SnapshotName: "backup"}},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			snapshotName := "snapshotName"
+			if tt.noSnapshotName {
+				snapshotName = ""
+			}
+
+			s3Path := "s3://bucket/prefix"
+			if tt.noS3Path {
+				s3Path = ""
+			}
+
+			var credentials s3.CredentialsInterface = s3.NewMockCredentialsInterface(t)
+			if tt.noCredentials {
+				credentials = nil
+			}
+
+			currentState := &validateState{isValidated: tt.isAlreadyValidated}
+			if !tt.notConfigured {
+				require.NoError(t, currentState.Configure(kubecluster.NewMockClientInterface(t), "namespace", snapshotName, s3Path, credentials, tt.opts))
+			}
+
+			err := currentState.Validate(th.NewTestContext())
+			if tt.shouldErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isValidated)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		desc               string
+		notValidated       bool
+		isAlreadySetup     bool
+		simulateRestoreErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:         "fails because not validated first",
+			notValidated: true,
+		},
+		{
+			desc:           "fails if called multiple times",
+			isAlreadySetup: true,
+		},
+		{
+			desc:               "fails to restore the snapshot",
+			simulateRestoreErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			restoredPVC := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "restored-pvc"}}
+
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:               "uid",
+						isConfigured:      true,
+						kubeClusterClient: mockClient,
+						namespace:         "namespace",
+						snapshotName:      "snapshotName",
+						s3Path:            "s3://bucket/prefix",
+						credentials:       s3.NewMockCredentialsInterface(t),
+						opts: ExportOptions{
+							StorageClassName:       "storage-class",
+							WaitForSnapshotTimeout: helpers.ShortWaitTime,
+						},
+					},
+					isValidated: !tt.notValidated,
+				},
+				isSetup: tt.isAlreadySetup,
+			}
+
+			ctx := th.NewTestContext()
+			if !tt.notValidated && !tt.isAlreadySetup {
+				mockClient.EXPECT().RestoreSnapshot(mock.Anything, "namespace", "snapshotName", clonepvc.RestoreSnapshotOptions{
+					WaitForSnapshotTimeout: helpers.ShortWaitTime,
+					DestStorageClassName:   "storage-class",
+				}).RunAndReturn(func(calledCtx *contexts.Context, namespace, snapshotName string, opts clonepvc.RestoreSnapshotOptions) (*corev1.PersistentVolumeClaim, error) {
+					assert.True(t, calledCtx.IsChildOf(ctx))
+					return th.ErrOr1Val(restoredPVC, tt.simulateRestoreErr)
+				})
+			}
+
+			btiOpts := &backuptoolinstance.CreateBackupToolInstanceOptions{}
+			err := currentState.Setup(ctx, btiOpts)
+			if th.ErrExpected(tt.notValidated, tt.isAlreadySetup, tt.simulateRestoreErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isSetup)
+			assert.Equal(t, restoredPVC, currentState.restoredPVC)
+			assert.Contains(t, currentState.mountPath, currentState.uid)
+
+			require.Len(t, btiOpts.Volumes, 1)
+			assert.Equal(t, []string{currentState.mountPath}, btiOpts.Volumes[0].MountPaths)
+			require.NotNil(t, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim)
+			assert.Equal(t, restoredPVC.Name, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName)
+		})
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	tests := []struct {
+		desc            string
+		nothingRestored bool
+		simulateDelErr  bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:            "succeeds and does nothing if nothing was restored",
+			nothingRestored: true,
+		},
+		{
+			desc:           "fails to delete the restored PVC",
+			simulateDelErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockCoreClient := core.NewMockClientInterface(t)
+
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:               "uid",
+						isConfigured:      true,
+						kubeClusterClient: mockClient,
+						namespace:         "namespace",
+						snapshotName:      "snapshotName",
+						opts:              ExportOptions{CleanupTimeout: helpers.ShortWaitTime},
+					},
+					isValidated: true,
+				},
+			}
+
+			if !tt.nothingRestored {
+				currentState.restoredPVC = &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "restored-pvc", Namespace: "namespace"}}
+				mockClient.EXPECT().Core().Return(mockCoreClient)
+				mockCoreClient.EXPECT().DeletePVC(mock.Anything, currentState.namespace, currentState.restoredPVC.Name).
+					Return(th.ErrIfTrue(tt.simulateDelErr))
+			}
+
+			err := currentState.Cleanup(th.NewTestContext())
+			if tt.simulateDelErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		desc                   string
+		hasNotBeenSetup        bool
+		simulateSyncErr        bool
+		noManifest             bool
+		simulateWriteObjectErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:       "succeeds without a manifest",
+			noManifest: true,
+		},
+		{
+			desc:            "fails if not setup first",
+			hasNotBeenSetup: true,
+		},
+		{
+			desc:            "fails to upload the snapshot contents",
+			simulateSyncErr: true,
+		},
+		{
+			desc:                   "fails to write the manifest",
+			simulateWriteObjectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockS3Runtime := s3.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().S3().Return(mockS3Runtime).Maybe()
+
+			rateLimit := throttle.Limits{BytesPerSecond: 1024}
+			multipart := s3.MultipartOptions{Threshold: 1024}
+			objectLock := s3.ObjectLock{Mode: s3.ObjectLockModeCompliance, Retention: time.Hour}
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							kubeClusterClient: kubecluster.NewMockClientInterface(t),
+							namespace:         "namespace",
+							snapshotName:      "snapshotName",
+							s3Path:            "s3://bucket/prefix",
+							credentials:       s3.NewMockCredentialsInterface(t),
+							opts:              ExportOptions{RateLimit: rateLimit, Multipart: multipart, ObjectLock: objectLock, ManifestPath: "s3://bucket/prefix.json"},
+						},
+						isValidated: true,
+					},
+					mountPath: "/mnt/export/uid",
+					isSetup:   !tt.hasNotBeenSetup,
+				},
+			}
+			if tt.noManifest {
+				currentState.opts.ManifestPath = ""
+			}
+
+			wroteManifest := false
+			originalPutObject := putObject
+			t.Cleanup(func() { putObject = originalPutObject })
+			putObject = func(calledCtx *contexts.Context, credentials s3.CredentialsInterface, dirPath, key string, contents []byte, retention s3.ObjectRetention) error {
+				wroteManifest = true
+				assert.Equal(t, currentState.credentials, credentials)
+				assert.Equal(t, "s3://bucket", dirPath)
+				assert.Equal(t, "prefix.json", key)
+
+				var manifest MirrorManifest
+				require.NoError(t, json.Unmarshal(contents, &manifest))
+				assert.Equal(t, retention, manifest.Retention)
+				// The manifest is retained for at least as long as the uploaded objects.
+				assert.Equal(t, s3.ObjectLockModeCompliance, retention.Mode)
+				assert.WithinDuration(t, time.Now().Add(time.Hour), retention.RetainUntil, time.Minute)
+				assert.False(t, retention.RetainUntil.Before(manifest.ExportedAt.Add(time.Hour)))
+
+				return th.ErrIfTrue(tt.simulateWriteObjectErr)
+			}
+
+			ctx := th.NewTestContext()
+			if !tt.hasNotBeenSetup {
+				mockS3Runtime.EXPECT().Sync(mock.Anything, currentState.credentials, currentState.mountPath, currentState.s3Path, time.Time{}, s3.SyncOptions{Limits: rateLimit, Multipart: multipart, ObjectLock: objectLock}).
+					RunAndReturn(func(calledCtx *contexts.Context, _ s3.CredentialsInterface, _, _ string, _ time.Time, _ s3.SyncOptions) error {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return th.ErrIfTrue(tt.simulateSyncErr)
+					})
+			}
+
+			err := currentState.Execute(ctx, mockGRPC)
+			assert.Equal(t, !th.ErrExpected(tt.hasNotBeenSetup, tt.simulateSyncErr, tt.noManifest), wroteManifest)
+			if th.ErrExpected(tt.hasNotBeenSetup, tt.simulateSyncErr, tt.simulateWriteObjectErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExecuteRepository(t *testing.T) {
+	tests := []struct {
+		desc              string
+		keepLast          int
+		simulateBackupErr bool
+		simulatePruneErr  bool
+	}{
+		{
+			desc: "succeeds without pruning",
+		},
+		{
+			desc:     "succeeds and prunes",
+			keepLast: 3,
+		},
+		{
+			desc:              "fails to back up the snapshot contents",
+			simulateBackupErr: true,
+		},
+		{
+			desc:             "fails to prune",
+			keepLast:         3,
+			simulatePruneErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockRepositoryRuntime := repository.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().Repository().Return(mockRepositoryRuntime)
+
+			rateLimit := throttle.Limits{BytesPerSecond: 1024}
+			objectLock := s3.ObjectLock{LegalHold: true}
+			repositoryOpts := &RepositoryOptions{SnapshotName: "backup", Tags: []string{"backup-event"}, KeepLast: tt.keepLast}
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							kubeClusterClient: kubecluster.NewMockClientInterface(t),
+							namespace:         "namespace",
+							snapshotName:      "snapshotName",
+							s3Path:            "s3://bucket/repository",
+							credentials:       s3.NewMockCredentialsInterface(t),
+							opts:              ExportOptions{RateLimit: rateLimit, ObjectLock: objectLock, Repository: repositoryOpts},
+						},
+						isValidated: true,
+					},
+					mountPath: "/mnt/export/uid",
+					isSetup:   true,
+				},
+			}
+
+			ctx := th.NewTestContext()
+			expectedBackupOpts := repository.BackupOptions{Name: "backup", Tags: []string{"backup-event"}, Limits: rateLimit, ObjectLock: objectLock}
+			mockRepositoryRuntime.EXPECT().Backup(mock.Anything, currentState.credentials, currentState.s3Path, currentState.mountPath, expectedBackupOpts).
+				RunAndReturn(func(calledCtx *contexts.Context, _ s3.CredentialsInterface, _, _ string, _ repository.BackupOptions) (repository.Snapshot, error) {
+					assert.True(t, calledCtx.IsChildOf(ctx))
+					return repository.Snapshot{ID: "snapshotID"}, th.ErrIfTrue(tt.simulateBackupErr)
+				})
+			if tt.keepLast > 0 && !tt.simulateBackupErr {
+				mockRepositoryRuntime.EXPECT().Prune(mock.Anything, currentState.credentials, currentState.s3Path, repository.PruneOptions{KeepLast: tt.keepLast}).
+					RunAndReturn(func(calledCtx *contexts.Context, _ s3.CredentialsInterface, _ string, _ repository.PruneOptions) (repository.PruneResult, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return repository.PruneResult{}, th.ErrIfTrue(tt.simulatePruneErr)
+					})
+			}
+
+			err := currentState.Execute(ctx, mockGRPC)
+			if th.ErrExpected(tt.simulateBackupErr, tt.simulatePruneErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	assert.Implements(t, (*ExportInterface)(nil), (*Export)(nil))
+	assert.Implements(t, (*remote.RemoteAction)(nil), (*Export)(nil))
+	assert.Implements(t, (*remote.CleanupAction)(nil), (*Export)(nil))
+}
+
+func TestNewExport(t *testing.T) {
+	assert.Equal(t, &Export{}, NewExport())
+}
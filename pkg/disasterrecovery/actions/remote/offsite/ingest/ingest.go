@@ -0,0 +1,188 @@
+package ingest
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+)
+
+type IngestOptions struct {
+	RateLimit throttle.Limits     `yaml:"rateLimit,omitempty"` // Caps the rate that objects are downloaded at, across all concurrent object transfers.
+	Multipart s3.MultipartOptions `yaml:"multipart,omitempty"` // Controls which objects are downloaded in parts, and the part size.
+	// Repository restores a snapshot from a deduplicating repository at the S3 path (see the offsite/export
+	// action), instead of downloading the files mirrored there. Nil downloads the mirrored files.
+	Repository *RepositoryOptions `yaml:"repository,omitempty"`
+}
+
+// RepositoryOptions selects the repository snapshot to restore: the latest one named SnapshotName, that is
+// tagged with Tag unless it is empty.
+type RepositoryOptions struct {
+	SnapshotName string `yaml:"snapshotName,omitempty"`
+	Tag          string `yaml:"tag,omitempty"`
+}
+
+// IngestInterface is a RemoteStage action that rebuilds a DR volume from a backup exported to object storage
+// (see the offsite/export action). The DR PVC is mounted into the tool pod and the exported backup is
+// downloaded into it. Files on the volume that are not part of the export are removed, so that the volume
+// matches the exported backup exactly.
+type IngestInterface interface {
+	remote.RemoteAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace, drVolName, s3Path string, credentials s3.CredentialsInterface, opts IngestOptions) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	drVolName         string
+	s3Path            string
+	credentials       s3.CredentialsInterface
+	opts              IngestOptions
+}
+
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace, drVolName, s3Path string, credentials s3.CredentialsInterface, opts IngestOptions) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.drVolName = drVolName
+	cs.s3Path = s3Path
+	cs.credentials = credentials
+	cs.opts = opts
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("drVolName", cs.drVolName, "s3Path", cs.s3Path, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for ingest")
+	defer ctx.Log.Info("Completed ingest configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if vs.s3Path == "" {
+		return trace.BadParameter("no S3 path provided")
+	}
+
+	if vs.credentials == nil {
+		return trace.BadParameter("no S3 credentials provided")
+	}
+
+	if err := vs.opts.RateLimit.Validate(); err != nil {
+		return trace.Wrap(err, "invalid rate limit")
+	}
+
+	if err := vs.opts.Multipart.Validate(); err != nil {
+		return trace.Wrap(err, "invalid multipart options")
+	}
+
+	if vs.opts.Repository != nil && vs.opts.Repository.SnapshotName == "" {
+		return trace.BadParameter("no repository snapshot name provided")
+	}
+
+	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.drVolName); err != nil {
+		return trace.Wrap(err, "failed to get DR PVC %q", vs.drVolName)
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+type setupState struct {
+	validateState
+	mountPath string
+	isSetup   bool
+}
+
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for ingest")
+	defer ctx.Log.Info("Ingest setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	ss.mountPath = filepath.Join("/mnt", "ingest", ss.uid)
+	btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.drVolName, ss.mountPath))
+
+	ss.isSetup = true
+	return nil
+}
+
+type executeState struct {
+	setupState
+}
+
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing ingest")
+	defer ctx.Log.Info("Ingest complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	if es.opts.Repository != nil {
+		return trace.Wrap(es.ingestFromRepository(ctx.Child(), backupToolClient.Repository()))
+	}
+
+	err = backupToolClient.S3().Sync(ctx.Child(), es.credentials, es.s3Path, es.mountPath, time.Time{}, s3.SyncOptions{
+		Limits:    es.opts.RateLimit,
+		Multipart: es.opts.Multipart,
+	})
+	return trace.Wrap(err, "failed to download %q to DR PVC %q", es.s3Path, es.drVolName)
+}
+
+// ingestFromRepository restores the selected snapshot of the repository at the S3 path into the DR PVC.
+func (es *executeState) ingestFromRepository(ctx *contexts.Context, repositoryRuntime repository.Runtime) error {
+	snapshots, err := repositoryRuntime.ListSnapshots(ctx.Child(), es.credentials, es.s3Path)
+	if err != nil {
+		return trace.Wrap(err, "failed to list the snapshots of repository %q", es.s3Path)
+	}
+
+	snapshot, ok := repository.LatestSnapshot(snapshots, es.opts.Repository.SnapshotName, es.opts.Repository.Tag)
+	if !ok {
+		return trace.NotFound("repository %q has no snapshot named %q with tag %q", es.s3Path, es.opts.Repository.SnapshotName, es.opts.Repository.Tag)
+	}
+	ctx.Log.With("repositorySnapshot", snapshot.ID, "time", snapshot.Time, "tags", snapshot.Tags).Info("Restoring repository snapshot")
+
+	err = repositoryRuntime.Restore(ctx.Child(), es.credentials, es.s3Path, snapshot.ID, es.mountPath, repository.RestoreOptions{Limits: es.opts.RateLimit})
+	return trace.Wrap(err, "failed to restore snapshot %q of repository %q to DR PVC %q", snapshot.ID, es.s3Path, es.drVolName)
+}
+
+type Ingest struct {
+	executeState
+}
+
+func NewIngest() IngestInterface {
+	return &Ingest{}
+}
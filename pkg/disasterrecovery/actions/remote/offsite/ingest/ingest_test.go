@@ -0,0 +1,402 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestIngestOptions(t *testing.T) {
+	th.OptStructTest[IngestOptions](t)
+}
+
+func TestRepositoryOptions(t *testing.T) {
+	th.OptStructTest[RepositoryOptions](t)
+}
+
+func TestConfigure(t *testing.T) {
+	expectedState := &configureState{
+		kubeClusterClient: kubecluster.NewMockClientInterface(t),
+		namespace:         "namespace",
+		drVolName:         "drVolName",
+		s3Path:            "s3://bucket/prefix",
+		credentials:       s3.NewMockCredentialsInterface(t),
+		opts:              IngestOptions{RateLimit: throttle.Limits{BytesPerSecond: 1024}},
+	}
+
+	i := NewIngest()
+	err := i.Configure(
+		expectedState.kubeClusterClient,
+		expectedState.namespace,
+		expectedState.drVolName,
+		expectedState.s3Path,
+		expectedState.credentials,
+		expectedState.opts,
+	)
+
+	t.Run("successfully configures the first time", func(t *testing.T) {
+		require.NoError(t, err)
+	})
+
+	t.Run("all state vars are populated", func(t *testing.T) {
+		casted := i.(*Ingest)
+
+		assert.NotEqual(t, "", casted.uid)
+		assert.NotEqual(t, uuid.Nil.String(), casted.uid)
+		expectedState.uid = casted.uid
+
+		assert.True(t, casted.isConfigured)
+		expectedState.isConfigured = casted.isConfigured
+
+		assert.Equal(t, expectedState, &casted.configureState)
+	})
+
+	t.Run("fails to configure because already configured", func(t *testing.T) {
+		err = i.Configure(
+			expectedState.kubeClusterClient,
+			expectedState.namespace,
+			expectedState.drVolName,
+			expectedState.s3Path,
+			expectedState.credentials,
+			expectedState.opts,
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc               string
+		notConfigured      bool
+		isAlreadyValidated bool
+		noS3Path           bool
+		noCredentials      bool
+		opts               IngestOptions
+		invalidOpts        bool
+		simulateGetPVCErr  bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:               "succeeds if called multiple times",
+			isAlreadyValidated: true,
+		},
+		{
+			desc:          "fails because not configured",
+			notConfigured: true,
+		},
+		{
+			desc:        "fails without an S3 path",
+			noS3Path:    true,
+			invalidOpts: true,
+		},
+		{
+			desc:          "fails without credentials",
+			noCredentials: true,
+			invalidOpts:   true,
+		},
+		{
+			desc:        "fails with an invalid rate limit",
+			opts:        IngestOptions{RateLimit: throttle.Limits{BytesPerSecond: -1}},
+			invalidOpts: true,
+		},
+		{
+			desc:        "fails with invalid multipart options",
+			opts:        IngestOptions{Multipart: s3.MultipartOptions{Threshold: -1}},
+			invalidOpts: true,
+		},
+		{
+			desc: "succeeds with a repository",
+			opts: IngestOptions{Repository: &RepositoryOptions{SnapshotName: "backup"}},
+		},
+		{
+			desc:        "fails with a repository without a snapshot name",
+			opts:        IngestOptions{Repository: &RepositoryOptions{Tag: "backup-event"}},
+			invalidOpts: true,
+		},
+		{
+			desc:              "fails to get DR PVC",
+			simulateGetPVCErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCoreClient := core.NewMockClientInterface(t)
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockClient.EXPECT().Core().Return(mockCoreClient).Maybe()
+
+			s3Path := "s3://bucket/prefix"
+			if tt.noS3Path {
+				s3Path = ""
+			}
+
+			var credentials s3.CredentialsInterface = s3.NewMockCredentialsInterface(t)
+			if tt.noCredentials {
+				credentials = nil
+			}
+
+			currentState := &validateState{isValidated: tt.isAlreadyValidated}
+			if !tt.notConfigured {
+				require.NoError(t, currentState.Configure(mockClient, "namespace", "drVolName", s3Path, credentials, tt.opts))
+			}
+
+			ctx := th.NewTestContext()
+			if !tt.notConfigured && !tt.invalidOpts {
+				mockCoreClient.EXPECT().GetPVC(mock.Anything, "namespace", "drVolName").
+					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return nil, th.ErrIfTrue(tt.simulateGetPVCErr)
+					})
+			}
+
+			err := currentState.Validate(ctx)
+			if th.ErrExpected(tt.notConfigured, tt.invalidOpts, tt.simulateGetPVCErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isValidated)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		desc           string
+		notValidated   bool
+		isAlreadySetup bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:         "fails because not validated first",
+			notValidated: true,
+		},
+		{
+			desc:           "fails if called multiple times",
+			isAlreadySetup: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:          "uid",
+						isConfigured: true,
+						namespace:    "namespace",
+						drVolName:    "drVolName",
+					},
+					isValidated: !tt.notValidated,
+				},
+				isSetup: tt.isAlreadySetup,
+			}
+
+			btiOpts := &backuptoolinstance.CreateBackupToolInstanceOptions{}
+			err := currentState.Setup(th.NewTestContext(), btiOpts)
+			if th.ErrExpected(tt.notValidated, tt.isAlreadySetup) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isSetup)
+			assert.Contains(t, currentState.mountPath, currentState.uid)
+
+			require.Len(t, btiOpts.Volumes, 1)
+			assert.Equal(t, []string{currentState.mountPath}, btiOpts.Volumes[0].MountPaths)
+			require.NotNil(t, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim)
+			assert.Equal(t, "drVolName", btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		desc            string
+		hasNotBeenSetup bool
+		simulateSyncErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:            "fails if not setup first",
+			hasNotBeenSetup: true,
+		},
+		{
+			desc:            "fails to download the export",
+			simulateSyncErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockS3Runtime := s3.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().S3().Return(mockS3Runtime).Maybe()
+
+			rateLimit := throttle.Limits{BytesPerSecond: 1024}
+			multipart := s3.MultipartOptions{Threshold: 1024}
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							kubeClusterClient: kubecluster.NewMockClientInterface(t),
+							namespace:         "namespace",
+							drVolName:         "drVolName",
+							s3Path:            "s3://bucket/prefix",
+							credentials:       s3.NewMockCredentialsInterface(t),
+							opts:              IngestOptions{RateLimit: rateLimit, Multipart: multipart},
+						},
+						isValidated: true,
+					},
+					mountPath: "/mnt/ingest/uid",
+					isSetup:   !tt.hasNotBeenSetup,
+				},
+			}
+
+			ctx := th.NewTestContext()
+			if !tt.hasNotBeenSetup {
+				mockS3Runtime.EXPECT().Sync(mock.Anything, currentState.credentials, currentState.s3Path, currentState.mountPath, time.Time{}, s3.SyncOptions{Limits: rateLimit, Multipart: multipart}).
+					RunAndReturn(func(calledCtx *contexts.Context, _ s3.CredentialsInterface, _, _ string, _ time.Time, _ s3.SyncOptions) error {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return th.ErrIfTrue(tt.simulateSyncErr)
+					})
+			}
+
+			err := currentState.Execute(ctx, mockGRPC)
+			if th.ErrExpected(tt.hasNotBeenSetup, tt.simulateSyncErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExecuteRepository(t *testing.T) {
+	snapshotTime := time.Date(2026, time.June, 2, 12, 0, 0, 0, time.UTC)
+	snapshots := []repository.Snapshot{
+		{ID: "first", Name: "backup", Tags: []string{"backup-event-1"}, Time: snapshotTime},
+		{ID: "other", Name: "other", Tags: []string{"other-event-1"}, Time: snapshotTime.Add(time.Hour)},
+		{ID: "second", Name: "backup", Tags: []string{"backup-event-2"}, Time: snapshotTime.Add(2 * time.Hour)},
+	}
+
+	tests := []struct {
+		desc               string
+		tag                string
+		simulateListErr    bool
+		expectedSnapshotID string
+		simulateRestoreErr bool
+	}{
+		{
+			desc:               "restores the latest snapshot",
+			expectedSnapshotID: "second",
+		},
+		{
+			desc:               "restores the snapshot with the tag",
+			tag:                "backup-event-1",
+			expectedSnapshotID: "first",
+		},
+		{
+			desc: "fails without a matching snapshot",
+			tag:  "other-event-1",
+		},
+		{
+			desc:            "fails to list the snapshots",
+			simulateListErr: true,
+		},
+		{
+			desc:               "fails to restore the snapshot",
+			expectedSnapshotID: "second",
+			simulateRestoreErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockRepositoryRuntime := repository.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().Repository().Return(mockRepositoryRuntime)
+
+			rateLimit := throttle.Limits{BytesPerSecond: 1024}
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							kubeClusterClient: kubecluster.NewMockClientInterface(t),
+							namespace:         "namespace",
+							drVolName:         "drVolName",
+							s3Path:            "s3://bucket/repository",
+							credentials:       s3.NewMockCredentialsInterface(t),
+							opts:              IngestOptions{RateLimit: rateLimit, Repository: &RepositoryOptions{SnapshotName: "backup", Tag: tt.tag}},
+						},
+						isValidated: true,
+					},
+					mountPath: "/mnt/ingest/uid",
+					isSetup:   true,
+				},
+			}
+
+			ctx := th.NewTestContext()
+			mockRepositoryRuntime.EXPECT().ListSnapshots(mock.Anything, currentState.credentials, currentState.s3Path).
+				RunAndReturn(func(calledCtx *contexts.Context, _ s3.CredentialsInterface, _ string) ([]repository.Snapshot, error) {
+					assert.True(t, calledCtx.IsChildOf(ctx))
+					if tt.simulateListErr {
+						return nil, assert.AnError
+					}
+					return snapshots, nil
+				})
+			if tt.expectedSnapshotID != "" {
+				mockRepositoryRuntime.EXPECT().Restore(mock.Anything, currentState.credentials, currentState.s3Path, tt.expectedSnapshotID, currentState.mountPath, repository.RestoreOptions{Limits: rateLimit}).
+					RunAndReturn(func(calledCtx *contexts.Context, _ s3.CredentialsInterface, _, _, _ string, _ repository.RestoreOptions) error {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return th.ErrIfTrue(tt.simulateRestoreErr)
+					})
+			}
+
+			err := currentState.Execute(ctx, mockGRPC)
+			if th.ErrExpected(tt.expectedSnapshotID == "", tt.simulateListErr, tt.simulateRestoreErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestIngest(t *testing.T) {
+	assert.Implements(t, (*IngestInterface)(nil), (*Ingest)(nil))
+	assert.Implements(t, (*remote.RemoteAction)(nil), (*Ingest)(nil))
+}
+
+func TestNewIngest(t *testing.T) {
+	assert.Equal(t, &Ingest{}, NewIngest())
+}
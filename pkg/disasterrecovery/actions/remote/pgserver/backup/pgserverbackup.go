@@ -0,0 +1,182 @@
+package backup
+
+import (
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/common"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/postgres"
+)
+
+type PGServerBackupOptions struct {
+	CleanupTimeout helpers.MaxWaitTime `yaml:"cleanupTimeout,omitempty"`
+	Format         postgres.DumpFormat `yaml:"format,omitempty"` // How the dump is written. Empty means plain.
+	Jobs           int                 `yaml:"jobs,omitempty"`   // Tables of each database dumped concurrently, with the directory format.
+	// Selection limits the dump to some of the server's databases, schemas and tables. Empty dumps everything.
+	Selection postgres.DatabaseSelection `yaml:"selection,omitempty"`
+	// Roles configures how the statements that involve roles are rewritten in the dump.
+	Roles postgres.RoleHandling `yaml:"roles,omitempty"`
+}
+
+func (opts PGServerBackupOptions) dumpAllOptions() postgres.DumpAllOptions {
+	return postgres.DumpAllOptions{
+		CleanupTimeout: opts.CleanupTimeout,
+		Format:         opts.Format,
+		Jobs:           opts.Jobs,
+		Selection:      opts.Selection,
+		Roles:          opts.Roles,
+	}
+}
+
+// PGServerBackupInterface is a RemoteStage action that logically dumps a postgres server that is not managed
+// by CNPG, directly from the backup-tool instance. Unlike a CNPG backup, the server is not cloned first, so
+// the dump is taken from the live server and does not participate in the stage's consistency-point protocol.
+// Each database is still dumped from a consistent snapshot.
+type PGServerBackupInterface interface {
+	remote.RemoteAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace string, conn common.Connection, drVolName, backupFileRelPath string, opts PGServerBackupOptions) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	conn              common.Connection
+	drVolName         string
+	backupFileRelPath string
+	opts              PGServerBackupOptions
+}
+
+// Configures the action prior to validation and execution. This should be called before
+// any other methods. Returns an error if the action is already configured.
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace string, conn common.Connection, drVolName, backupFileRelPath string, opts PGServerBackupOptions) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.conn = conn
+	cs.drVolName = drVolName
+	cs.backupFileRelPath = backupFileRelPath
+	cs.opts = opts
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("host", cs.conn.Host, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+// Validates that the required resources are ready. This should be called after `Configure`
+// and before `Setup`. Returns an error if the resources are not ready.
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for postgres server backup")
+	defer ctx.Log.Info("Completed postgres server backup configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if err := vs.conn.Validate(); err != nil {
+		return trace.Wrap(err, "invalid connection")
+	}
+
+	if err := vs.opts.dumpAllOptions().Validate(); err != nil {
+		return trace.Wrap(err, "invalid dump options")
+	}
+
+	if err := vs.conn.ValidateSecrets(ctx.Child(), vs.kubeClusterClient, vs.namespace); err != nil {
+		return trace.Wrap(err, "invalid connection")
+	}
+
+	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.drVolName); err != nil {
+		return trace.Wrap(err, "failed to get DR PVC %q", vs.drVolName)
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+type setupStateMountPaths struct {
+	drVolume   string
+	connection common.MountPaths
+}
+
+type setupState struct {
+	validateState
+	mountPaths setupStateMountPaths
+	isSetup    bool
+}
+
+// Prepares the backup tool pod to be able to perform the backup. This should be called
+// after `Validate` and before `Execute`. Returns an error if the pod cannot be prepared.
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for postgres server backup")
+	defer ctx.Log.Info("Postgres server backup setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	baseMountPath := filepath.Join("/mnt", "pgserverbackup", ss.uid)
+	connectionVolumes, connectionMountPaths := ss.conn.Volumes(filepath.Join(baseMountPath, "secrets"))
+
+	ss.mountPaths = setupStateMountPaths{
+		drVolume:   filepath.Join(baseMountPath, "dr"),
+		connection: connectionMountPaths,
+	}
+
+	btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.drVolName, ss.mountPaths.drVolume))
+	btiOpts.Volumes = append(btiOpts.Volumes, connectionVolumes...)
+
+	ss.isSetup = true
+	return nil
+}
+
+type executeState struct {
+	setupState
+}
+
+// Backs up the server. This should be called after `Setup`. Returns an error if the backup fails.
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing postgres server backup")
+	defer ctx.Log.Info("Postgres server backup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	podSQLFilePath := filepath.Join(es.mountPaths.drVolume, es.backupFileRelPath)
+	credentials := es.conn.Credentials(es.mountPaths.connection)
+	err = backupToolClient.Postgres().DumpAll(ctx.Child(), credentials, podSQLFilePath, es.opts.dumpAllOptions())
+	return trace.Wrap(err, "failed to create logical backup for postgres server at %q", postgres.GetServerAddress(credentials))
+}
+
+type PGServerBackup struct {
+	executeState
+}
+
+func NewPGServerBackup() PGServerBackupInterface {
+	return &PGServerBackup{}
+}
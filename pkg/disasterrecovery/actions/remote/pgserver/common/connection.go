@@ -0,0 +1,161 @@
+package common
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/postgres"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The keys that are mounted from the connection's Secrets. These match the keys of the Secrets that
+// cert-manager issues.
+const (
+	clientCertSecretCertKey = "tls.crt"
+	clientCertSecretKeyKey  = "tls.key"
+	servingCASecretKey      = "ca.crt"
+)
+
+// The libpq SSL modes.
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Connection locates a postgres server that is not managed by CNPG, and how to authenticate with it.
+type Connection struct {
+	Host     string
+	Port     int // Optional. Defaults to 5432.
+	User     string
+	Password string // Optional. Resolved by the caller, and only passed on to the backup-tool instance.
+	SSLMode  string // Optional. Defaults to libpq's default ("prefer").
+	// ClientCertSecret optionally names a Secret holding a client certificate (tls.crt) and key (tls.key) to
+	// authenticate with.
+	ClientCertSecret string
+	// ServingCASecret optionally names a Secret holding the CA certificate (ca.crt) that the server's
+	// certificate is verified against.
+	ServingCASecret string
+}
+
+// Validate checks the connection's fields, without contacting the cluster or the server.
+func (c Connection) Validate() error {
+	if c.Host == "" {
+		return trace.BadParameter("host is required")
+	}
+
+	if c.Port < 0 || c.Port > 65535 {
+		return trace.BadParameter("port %d is out of range", c.Port)
+	}
+
+	if c.User == "" {
+		return trace.BadParameter("user is required")
+	}
+
+	if c.Password == "" && c.ClientCertSecret == "" {
+		return trace.BadParameter("a password or a client certificate secret is required")
+	}
+
+	if c.SSLMode != "" && !slices.Contains(sslModes, c.SSLMode) {
+		return trace.BadParameter("invalid SSL mode %q (must be one of %s)", c.SSLMode, strings.Join(sslModes, ", "))
+	}
+
+	return nil
+}
+
+// ValidateSecrets checks that the connection's Secrets exist in the namespace, and hold the keys that are
+// mounted from them.
+func (c Connection) ValidateSecrets(ctx *contexts.Context, kubeClusterClient kubecluster.ClientInterface, namespace string) error {
+	if c.ClientCertSecret != "" {
+		if err := validateSecretKeys(ctx, kubeClusterClient, namespace, c.ClientCertSecret, clientCertSecretCertKey, clientCertSecretKeyKey); err != nil {
+			return trace.Wrap(err, "invalid client certificate secret")
+		}
+	}
+
+	if c.ServingCASecret != "" {
+		if err := validateSecretKeys(ctx, kubeClusterClient, namespace, c.ServingCASecret, servingCASecretKey); err != nil {
+			return trace.Wrap(err, "invalid serving CA secret")
+		}
+	}
+
+	return nil
+}
+
+func validateSecretKeys(ctx *contexts.Context, kubeClusterClient kubecluster.ClientInterface, namespace, name string, keys ...string) error {
+	secret, err := kubeClusterClient.Core().GetSecret(ctx.Child(), namespace, name)
+	if err != nil {
+		return trace.Wrap(err, "failed to get secret %q", name)
+	}
+
+	for _, key := range keys {
+		if _, ok := secret.Data[key]; !ok {
+			return trace.NotFound("secret %q does not hold a %q key", name, key)
+		}
+	}
+
+	return nil
+}
+
+// MountPaths are where the connection's Secrets are mounted in the backup-tool instance.
+type MountPaths struct {
+	ClientCert string
+	ServingCA  string
+}
+
+// Volumes returns the volumes that mount the connection's Secrets under the base path. Only the keys that
+// are used are mounted.
+func (c Connection) Volumes(baseMountPath string) ([]core.SingleContainerVolume, MountPaths) {
+	var volumes []core.SingleContainerVolume
+	var mountPaths MountPaths
+
+	if c.ClientCertSecret != "" {
+		mountPaths.ClientCert = filepath.Join(baseMountPath, "client-cert")
+		volumes = append(volumes, core.NewSingleContainerSecret(c.ClientCertSecret, mountPaths.ClientCert,
+			corev1.KeyToPath{Key: clientCertSecretCertKey, Path: clientCertSecretCertKey},
+			corev1.KeyToPath{Key: clientCertSecretKeyKey, Path: clientCertSecretKeyKey},
+		))
+	}
+
+	if c.ServingCASecret != "" {
+		mountPaths.ServingCA = filepath.Join(baseMountPath, "serving-ca")
+		volumes = append(volumes, core.NewSingleContainerSecret(c.ServingCASecret, mountPaths.ServingCA,
+			corev1.KeyToPath{Key: servingCASecretKey, Path: servingCASecretKey},
+		))
+	}
+
+	return volumes, mountPaths
+}
+
+// Credentials returns the credentials that the backup-tool instance connects to the server with, given where
+// the connection's Secrets are mounted.
+func (c Connection) Credentials(mountPaths MountPaths) postgres.Credentials {
+	credentials := postgres.EnvironmentCredentials{
+		postgres.HostVarName: c.Host,
+		postgres.UserVarName: c.User,
+	}
+
+	if c.Port != 0 {
+		credentials[postgres.PortVarName] = strconv.Itoa(c.Port)
+	}
+
+	if c.Password != "" {
+		credentials[postgres.PasswordVarName] = c.Password
+	}
+
+	if c.SSLMode != "" {
+		credentials[postgres.SSLModeVarName] = c.SSLMode
+	}
+
+	if c.ClientCertSecret != "" {
+		credentials[postgres.SSLCertVarName] = filepath.Join(mountPaths.ClientCert, clientCertSecretCertKey)
+		credentials[postgres.SSLKeyVarName] = filepath.Join(mountPaths.ClientCert, clientCertSecretKeyKey)
+	}
+
+	if c.ServingCASecret != "" {
+		credentials[postgres.SSLRootCertVarName] = filepath.Join(mountPaths.ServingCA, servingCASecretKey)
+	}
+
+	return &credentials
+}
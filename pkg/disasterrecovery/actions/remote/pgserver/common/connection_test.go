@@ -0,0 +1,189 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/postgres"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConnectionValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		conn    Connection
+		wantErr bool
+	}{
+		{
+			desc: "password",
+			conn: Connection{Host: "db.example.com", User: "postgres", Password: "password"},
+		},
+		{
+			desc: "client certificate",
+			conn: Connection{Host: "db.example.com", Port: 5433, User: "postgres", SSLMode: "verify-full", ClientCertSecret: "client-cert", ServingCASecret: "serving-ca"},
+		},
+		{
+			desc:    "no host",
+			conn:    Connection{User: "postgres", Password: "password"},
+			wantErr: true,
+		},
+		{
+			desc:    "negative port",
+			conn:    Connection{Host: "db.example.com", Port: -1, User: "postgres", Password: "password"},
+			wantErr: true,
+		},
+		{
+			desc:    "port out of range",
+			conn:    Connection{Host: "db.example.com", Port: 65536, User: "postgres", Password: "password"},
+			wantErr: true,
+		},
+		{
+			desc:    "no user",
+			conn:    Connection{Host: "db.example.com", Password: "password"},
+			wantErr: true,
+		},
+		{
+			desc:    "no password or client certificate",
+			conn:    Connection{Host: "db.example.com", User: "postgres"},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid SSL mode",
+			conn:    Connection{Host: "db.example.com", User: "postgres", Password: "password", SSLMode: "always"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.conn.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConnectionValidateSecrets(t *testing.T) {
+	clientCertSecret := &corev1.Secret{Data: map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca")}}
+	servingCASecret := &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("ca")}}
+
+	tests := []struct {
+		desc                   string
+		conn                   Connection
+		secrets                map[string]*corev1.Secret
+		simulateGetSecretError bool
+		wantErr                bool
+	}{
+		{
+			desc: "no secrets",
+			conn: Connection{Password: "password"},
+		},
+		{
+			desc:    "both secrets",
+			conn:    Connection{ClientCertSecret: "client-cert", ServingCASecret: "serving-ca"},
+			secrets: map[string]*corev1.Secret{"client-cert": clientCertSecret, "serving-ca": servingCASecret},
+		},
+		{
+			desc:    "one secret for both",
+			conn:    Connection{ClientCertSecret: "client-cert", ServingCASecret: "client-cert"},
+			secrets: map[string]*corev1.Secret{"client-cert": clientCertSecret},
+		},
+		{
+			desc:                   "fails to get a secret",
+			conn:                   Connection{ClientCertSecret: "client-cert"},
+			secrets:                map[string]*corev1.Secret{"client-cert": clientCertSecret},
+			simulateGetSecretError: true,
+			wantErr:                true,
+		},
+		{
+			desc:    "client certificate secret without a key",
+			conn:    Connection{ClientCertSecret: "serving-ca"},
+			secrets: map[string]*corev1.Secret{"serving-ca": servingCASecret},
+			wantErr: true,
+		},
+		{
+			desc:    "serving CA secret without a CA certificate",
+			conn:    Connection{ServingCASecret: "serving-ca"},
+			secrets: map[string]*corev1.Secret{"serving-ca": {}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx := th.NewTestContext()
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockCoreClient := core.NewMockClientInterface(t)
+			mockClient.EXPECT().Core().Return(mockCoreClient).Maybe()
+
+			for name, secret := range tt.secrets {
+				mockCoreClient.EXPECT().GetSecret(mock.Anything, "namespace", name).
+					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.Secret, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+
+						return th.ErrOr1Val(secret, tt.simulateGetSecretError)
+					})
+			}
+
+			err := tt.conn.ValidateSecrets(ctx, mockClient, "namespace")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConnectionVolumes(t *testing.T) {
+	volumes, mountPaths := Connection{}.Volumes("/mnt/base")
+	assert.Empty(t, volumes)
+	assert.Equal(t, MountPaths{}, mountPaths)
+
+	volumes, mountPaths = Connection{ClientCertSecret: "client-cert", ServingCASecret: "serving-ca"}.Volumes("/mnt/base")
+	assert.Equal(t, MountPaths{ClientCert: "/mnt/base/client-cert", ServingCA: "/mnt/base/serving-ca"}, mountPaths)
+	require.Len(t, volumes, 2)
+
+	assert.Equal(t, []string{mountPaths.ClientCert}, volumes[0].MountPaths)
+	require.NotNil(t, volumes[0].VolumeSource.Secret)
+	assert.Equal(t, "client-cert", volumes[0].VolumeSource.Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "tls.crt", Path: "tls.crt"}, {Key: "tls.key", Path: "tls.key"}}, volumes[0].VolumeSource.Secret.Items)
+
+	assert.Equal(t, []string{mountPaths.ServingCA}, volumes[1].MountPaths)
+	require.NotNil(t, volumes[1].VolumeSource.Secret)
+	assert.Equal(t, "serving-ca", volumes[1].VolumeSource.Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}}, volumes[1].VolumeSource.Secret.Items) // The CA's key is never mounted
+}
+
+func TestConnectionCredentials(t *testing.T) {
+	creds := Connection{Host: "db.example.com", User: "app", Password: "password"}.Credentials(MountPaths{})
+	assert.Equal(t, &postgres.EnvironmentCredentials{
+		postgres.HostVarName:     "db.example.com",
+		postgres.UserVarName:     "app",
+		postgres.PasswordVarName: "password",
+	}, creds)
+	assert.Equal(t, "db.example.com:5432", postgres.GetServerAddress(creds))
+
+	conn := Connection{Host: "db.example.com", Port: 5433, User: "app", SSLMode: "verify-full", ClientCertSecret: "client-cert", ServingCASecret: "serving-ca"}
+	creds = conn.Credentials(MountPaths{ClientCert: "/client-cert", ServingCA: "/serving-ca"})
+	assert.Equal(t, &postgres.EnvironmentCredentials{
+		postgres.HostVarName:        "db.example.com",
+		postgres.PortVarName:        "5433",
+		postgres.UserVarName:        "app",
+		postgres.SSLModeVarName:     "verify-full",
+		postgres.SSLCertVarName:     "/client-cert/tls.crt",
+		postgres.SSLKeyVarName:      "/client-cert/tls.key",
+		postgres.SSLRootCertVarName: "/serving-ca/ca.crt",
+	}, creds)
+}
@@ -0,0 +1,187 @@
+package restore
+
+import (
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/common"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/postgres"
+)
+
+type PGServerRestoreOptions struct {
+	Format postgres.DumpFormat `yaml:"format,omitempty"` // How the dump was written. Empty means plain.
+	Jobs   int                 `yaml:"jobs,omitempty"`   // Tables of each database restored concurrently, with the directory format.
+	// Selection restores part of the dump, such as a single table, possibly into another database. Empty
+	// restores everything.
+	Selection postgres.RestoreSelection `yaml:"selection,omitempty"`
+	// StopOnError stops the restore at the first statement that fails, rather than only logging it.
+	StopOnError bool `yaml:"stopOnError,omitempty"`
+	// SingleTransaction restores the contents of each database in a single transaction. Implies StopOnError.
+	SingleTransaction bool `yaml:"singleTransaction,omitempty"`
+	// DropAndRecreate drops the databases that the restore replaces first, disconnecting any sessions.
+	DropAndRecreate bool `yaml:"dropAndRecreate,omitempty"`
+	// Force allows the restore to replace databases that are not empty.
+	Force bool `yaml:"force,omitempty"`
+}
+
+func (opts PGServerRestoreOptions) restoreOptions() postgres.RestoreOptions {
+	return postgres.RestoreOptions{
+		Format:            opts.Format,
+		Jobs:              opts.Jobs,
+		Selection:         opts.Selection,
+		StopOnError:       opts.StopOnError,
+		SingleTransaction: opts.SingleTransaction,
+		DropAndRecreate:   opts.DropAndRecreate,
+		Force:             opts.Force,
+	}
+}
+
+// PGServerRestoreInterface is a RemoteStage action that restores a logical dump to a postgres server that is
+// not managed by CNPG, directly from the backup-tool instance. It is the inverse of the postgres server backup.
+type PGServerRestoreInterface interface {
+	remote.RemoteAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace string, conn common.Connection, drVolName, backupFileRelPath string, opts PGServerRestoreOptions) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	conn              common.Connection
+	drVolName         string
+	backupFileRelPath string
+	opts              PGServerRestoreOptions
+}
+
+// Configures the action prior to validation and execution. This should be called before
+// any other methods. Returns an error if the action is already configured.
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace string, conn common.Connection, drVolName, backupFileRelPath string, opts PGServerRestoreOptions) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.conn = conn
+	cs.drVolName = drVolName
+	cs.backupFileRelPath = backupFileRelPath
+	cs.opts = opts
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("host", cs.conn.Host, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+// Validates that the required resources are ready. This should be called after `Configure`
+// and before `Setup`. Returns an error if the resources are not ready.
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for postgres server restore")
+	defer ctx.Log.Info("Completed postgres server restore configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if err := vs.conn.Validate(); err != nil {
+		return trace.Wrap(err, "invalid connection")
+	}
+
+	if err := vs.opts.restoreOptions().Validate(); err != nil {
+		return trace.Wrap(err, "invalid restore options")
+	}
+
+	if err := vs.conn.ValidateSecrets(ctx.Child(), vs.kubeClusterClient, vs.namespace); err != nil {
+		return trace.Wrap(err, "invalid connection")
+	}
+
+	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.drVolName); err != nil {
+		return trace.Wrap(err, "failed to get DR PVC %q", vs.drVolName)
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+type setupStateMountPaths struct {
+	drVolume   string
+	connection common.MountPaths
+}
+
+type setupState struct {
+	validateState
+	mountPaths setupStateMountPaths
+	isSetup    bool
+}
+
+// Prepares the backup tool pod to be able to perform the restore. This should be called
+// after `Validate` and before `Execute`. Returns an error if the pod cannot be prepared.
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for postgres server restore")
+	defer ctx.Log.Info("Postgres server restore setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	baseMountPath := filepath.Join("/mnt", "pgserverrestore", ss.uid)
+	connectionVolumes, connectionMountPaths := ss.conn.Volumes(filepath.Join(baseMountPath, "secrets"))
+
+	ss.mountPaths = setupStateMountPaths{
+		drVolume:   filepath.Join(baseMountPath, "dr"),
+		connection: connectionMountPaths,
+	}
+
+	btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.drVolName, ss.mountPaths.drVolume))
+	btiOpts.Volumes = append(btiOpts.Volumes, connectionVolumes...)
+
+	ss.isSetup = true
+	return nil
+}
+
+type executeState struct {
+	setupState
+}
+
+// Restores the backup. This should be called after `Setup`. Returns an error if the restore fails.
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing postgres server restore")
+	defer ctx.Log.Info("Postgres server restore complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	podSQLFilePath := filepath.Join(es.mountPaths.drVolume, es.backupFileRelPath)
+	credentials := es.conn.Credentials(es.mountPaths.connection)
+	err = backupToolClient.Postgres().Restore(ctx.Child(), credentials, podSQLFilePath, es.opts.restoreOptions())
+	return trace.Wrap(err, "failed to restore logical backup for postgres server at %q", postgres.GetServerAddress(credentials))
+}
+
+type PGServerRestore struct {
+	executeState
+}
+
+func NewPGServerRestore() PGServerRestoreInterface {
+	return &PGServerRestore{}
+}
@@ -0,0 +1,358 @@
+package restore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/common"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/postgres"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var testConn = common.Connection{
+	Host:             "db.example.com",
+	User:             "postgres",
+	Password:         "password",
+	SSLMode:          "verify-full",
+	ClientCertSecret: "client-cert",
+	ServingCASecret:  "serving-ca",
+}
+
+func TestPGServerRestoreOptions(t *testing.T) {
+	th.OptStructTest[PGServerRestoreOptions](t)
+}
+
+func TestConfigure(t *testing.T) {
+	expectedState := &configureState{
+		kubeClusterClient: kubecluster.NewMockClientInterface(t),
+		namespace:         "namespace",
+		conn:              testConn,
+		drVolName:         "drVolName",
+		backupFileRelPath: "backupFileRelPath",
+		opts: PGServerRestoreOptions{
+			StopOnError: true,
+		},
+	}
+
+	pgsr := NewPGServerRestore()
+	err := pgsr.Configure(
+		expectedState.kubeClusterClient,
+		expectedState.namespace,
+		expectedState.conn,
+		expectedState.drVolName,
+		expectedState.backupFileRelPath,
+		expectedState.opts,
+	)
+
+	t.Run("successfully configures the first time", func(t *testing.T) {
+		require.NoError(t, err)
+	})
+
+	t.Run("all state vars are populated", func(t *testing.T) {
+		casted := pgsr.(*PGServerRestore)
+
+		assert.NotEqual(t, "", casted.uid)
+		assert.NotEqual(t, uuid.Nil.String(), casted.uid)
+		expectedState.uid = casted.uid
+
+		assert.True(t, casted.isConfigured)
+		expectedState.isConfigured = casted.isConfigured
+
+		assert.Equal(t, expectedState, &casted.configureState)
+	})
+
+	t.Run("fails to configure because already configured", func(t *testing.T) {
+		err = pgsr.Configure(
+			expectedState.kubeClusterClient,
+			expectedState.namespace,
+			expectedState.conn,
+			expectedState.drVolName,
+			expectedState.backupFileRelPath,
+			expectedState.opts,
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	secrets := map[string]*corev1.Secret{
+		testConn.ClientCertSecret: {Data: map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}},
+		testConn.ServingCASecret:  {Data: map[string][]byte{"ca.crt": []byte("ca")}},
+	}
+
+	notConfiguredState := &configureState{}
+	configuredState := &configureState{}
+	require.NoError(t, configuredState.Configure(nil, "namespace", testConn, "drVolName", "backupFileRelPath", PGServerRestoreOptions{}))
+
+	invalidConnState := &configureState{}
+	require.NoError(t, invalidConnState.Configure(nil, "namespace", common.Connection{Host: "db.example.com"}, "drVolName", "backupFileRelPath", PGServerRestoreOptions{}))
+
+	invalidOptsState := &configureState{}
+	require.NoError(t, invalidOptsState.Configure(nil, "namespace", testConn, "drVolName", "backupFileRelPath", PGServerRestoreOptions{Format: "custom"}))
+
+	tests := []struct {
+		desc                   string
+		configState            *configureState
+		isAlreadyValidated     bool
+		simulateGetSecretError bool
+		simulateGetPVCErr      bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:               "succeeds if called multiple times",
+			isAlreadyValidated: true,
+		},
+		{
+			desc:        "fails because not configured",
+			configState: notConfiguredState,
+		},
+		{
+			desc:        "fails because the connection is invalid",
+			configState: invalidConnState,
+		},
+		{
+			desc:        "fails because the restore options are invalid",
+			configState: invalidOptsState,
+		},
+		{
+			desc:                   "fails to get a connection secret",
+			simulateGetSecretError: true,
+		},
+		{
+			desc:              "fails to get DR PVC",
+			simulateGetPVCErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockCoreClient := core.NewMockClientInterface(t)
+			mockClient.EXPECT().Core().Return(mockCoreClient).Maybe()
+
+			if tt.configState == nil {
+				tt.configState = configuredState
+			}
+
+			currentState := &validateState{
+				configureState: *tt.configState,
+				isValidated:    tt.isAlreadyValidated,
+			}
+			currentState.kubeClusterClient = mockClient
+
+			ctx := th.NewTestContext()
+
+			isValid := currentState.isConfigured && tt.configState != invalidConnState && tt.configState != invalidOptsState
+			wantErr := th.ErrExpected(
+				!isValid,
+				tt.simulateGetSecretError,
+				tt.simulateGetPVCErr,
+			)
+
+			func() {
+				if !isValid {
+					return
+				}
+
+				for name, secret := range secrets {
+					mockCoreClient.EXPECT().GetSecret(mock.Anything, currentState.namespace, name).
+						RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.Secret, error) {
+							assert.True(t, calledCtx.IsChildOf(ctx))
+
+							return th.ErrOr1Val(secret, tt.simulateGetSecretError)
+						}).Maybe()
+				}
+				if tt.simulateGetSecretError {
+					return
+				}
+
+				mockCoreClient.EXPECT().GetPVC(mock.Anything, currentState.namespace, currentState.drVolName).
+					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+
+						return nil, th.ErrIfTrue(tt.simulateGetPVCErr)
+					})
+			}()
+
+			err := currentState.Validate(ctx)
+			if wantErr {
+				assert.Error(t, err)
+				assert.False(t, currentState.isValidated)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isValidated)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		desc                    string
+		hasBeenNotBeenValidated bool
+		isAlreadySetup          bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:                    "fails because not validated first",
+			hasBeenNotBeenValidated: true,
+		},
+		{
+			desc:           "fails if called multiple times",
+			isAlreadySetup: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:               "uid",
+						isConfigured:      true,
+						namespace:         "namespace",
+						conn:              testConn,
+						drVolName:         "drVolName",
+						backupFileRelPath: "backupFileRelPath",
+					},
+					isValidated: !tt.hasBeenNotBeenValidated,
+				},
+				isSetup: tt.isAlreadySetup,
+			}
+
+			btiOpts := &backuptoolinstance.CreateBackupToolInstanceOptions{}
+			err := currentState.Setup(th.NewTestContext(), btiOpts)
+			if th.ErrExpected(tt.hasBeenNotBeenValidated, tt.isAlreadySetup) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Contains(t, currentState.mountPaths.drVolume, currentState.uid)
+			assert.Contains(t, currentState.mountPaths.connection.ClientCert, currentState.uid)
+			assert.Contains(t, currentState.mountPaths.connection.ServingCA, currentState.uid)
+
+			require.Len(t, btiOpts.Volumes, 3)
+
+			// DR vol
+			assert.Equal(t, []string{currentState.mountPaths.drVolume}, btiOpts.Volumes[0].MountPaths)
+			require.NotNil(t, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim)
+			assert.Equal(t, currentState.drVolName, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName)
+
+			// Connection secret vols
+			require.NotNil(t, btiOpts.Volumes[1].VolumeSource.Secret)
+			assert.Equal(t, testConn.ClientCertSecret, btiOpts.Volumes[1].VolumeSource.Secret.SecretName)
+			require.NotNil(t, btiOpts.Volumes[2].VolumeSource.Secret)
+			assert.Equal(t, testConn.ServingCASecret, btiOpts.Volumes[2].VolumeSource.Secret.SecretName)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		desc               string
+		hasNotBeenSetup    bool
+		simulateRestoreErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:            "fails if not setup first",
+			hasNotBeenSetup: true,
+		},
+		{
+			desc:               "fails to restore",
+			simulateRestoreErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockPGR := postgres.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().Postgres().Return(mockPGR).Maybe()
+
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							namespace:         "namespace",
+							conn:              testConn,
+							drVolName:         "drVolName",
+							backupFileRelPath: "backupFileRelPath",
+							opts: PGServerRestoreOptions{
+								Format:      postgres.DumpFormatDirectory,
+								Jobs:        4,
+								Selection:   postgres.RestoreSelection{Databases: []string{"app"}},
+								StopOnError: true,
+								Force:       true,
+							},
+						},
+						isValidated: true,
+					},
+					mountPaths: setupStateMountPaths{
+						drVolume:   "/dr-volume",
+						connection: common.MountPaths{ClientCert: "/client-cert", ServingCA: "/serving-ca"},
+					},
+					isSetup: !tt.hasNotBeenSetup,
+				},
+			}
+
+			ctx := th.NewTestContext()
+			if currentState.isSetup {
+				drFilePath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupFileRelPath) // Important: Changing this is a breaking change!
+				expectedCreds := testConn.Credentials(currentState.mountPaths.connection)
+				mockPGR.EXPECT().Restore(mock.Anything, expectedCreds, drFilePath, postgres.RestoreOptions{
+					Format:      postgres.DumpFormatDirectory,
+					Jobs:        4,
+					Selection:   postgres.RestoreSelection{Databases: []string{"app"}},
+					StopOnError: true,
+					Force:       true,
+				}).RunAndReturn(func(calledCtx *contexts.Context, credentials postgres.Credentials, backupFilePath string, opts postgres.RestoreOptions) error {
+					assert.True(t, calledCtx.IsChildOf(ctx))
+					assert.Equal(t, testConn.Password, credentials.GetVariables()[postgres.PasswordVarName])
+
+					return th.ErrIfTrue(tt.simulateRestoreErr)
+				})
+			}
+
+			err := currentState.Execute(ctx, mockGRPC)
+			if tt.hasNotBeenSetup || tt.simulateRestoreErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPGServerRestore(t *testing.T) {
+	assert.Implements(t, (*PGServerRestoreInterface)(nil), (*PGServerRestore)(nil))
+	assert.Implements(t, (*remote.RemoteAction)(nil), (*PGServerRestore)(nil))
+}
+
+func TestNewPGServerRestore(t *testing.T) {
+	// State vars should not be populated yet
+	assert.Equal(t, &PGServerRestore{}, NewPGServerRestore())
+}
@@ -0,0 +1,144 @@
+package backup
+
+import (
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+)
+
+// RedisBackupInterface is a RemoteStage action that streams an RDB snapshot of a Redis or Valkey server
+// onto the DR volume, directly from the backup-tool instance. The server forks to take the snapshot, so it
+// is consistent with itself (unlike a copy of the server's data directory), but like a postgres server
+// backup it is taken from the live server and does not participate in the stage's consistency-point
+// protocol.
+type RedisBackupInterface interface {
+	remote.RemoteAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace string, credentials redis.Credentials, drVolName, backupFileRelPath string) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	credentials       redis.Credentials
+	drVolName         string
+	backupFileRelPath string
+}
+
+// Configures the action prior to validation and execution. This should be called before
+// any other methods. Returns an error if the action is already configured.
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace string, credentials redis.Credentials, drVolName, backupFileRelPath string) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.credentials = credentials
+	cs.drVolName = drVolName
+	cs.backupFileRelPath = backupFileRelPath
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("host", cs.credentials.Host, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+// Validates that the required resources are ready. This should be called after `Configure`
+// and before `Setup`. Returns an error if the resources are not ready.
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for Redis backup")
+	defer ctx.Log.Info("Completed Redis backup configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if err := vs.credentials.Validate(); err != nil {
+		return trace.Wrap(err, "invalid credentials")
+	}
+
+	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.drVolName); err != nil {
+		return trace.Wrap(err, "failed to get DR PVC %q", vs.drVolName)
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+type setupStateMountPaths struct {
+	drVolume string
+}
+
+type setupState struct {
+	validateState
+	mountPaths setupStateMountPaths
+	isSetup    bool
+}
+
+// Prepares the backup tool pod to be able to perform the backup. This should be called
+// after `Validate` and before `Execute`. Returns an error if the pod cannot be prepared.
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for Redis backup")
+	defer ctx.Log.Info("Redis backup setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	ss.mountPaths = setupStateMountPaths{
+		drVolume: filepath.Join("/mnt", "redisbackup", ss.uid, "dr"),
+	}
+
+	btiOpts.Volumes = append(btiOpts.Volumes, core.NewSingleContainerPVC(ss.drVolName, ss.mountPaths.drVolume))
+
+	ss.isSetup = true
+	return nil
+}
+
+type executeState struct {
+	setupState
+}
+
+// Backs up the server. This should be called after `Setup`. Returns an error if the backup fails.
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing Redis backup")
+	defer ctx.Log.Info("Redis backup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	podRDBFilePath := filepath.Join(es.mountPaths.drVolume, es.backupFileRelPath)
+	err = backupToolClient.Redis().Snapshot(ctx.Child(), es.credentials, podRDBFilePath)
+	return trace.Wrap(err, "failed to snapshot Redis server at %q", es.credentials.Address())
+}
+
+type RedisBackup struct {
+	executeState
+}
+
+func NewRedisBackup() RedisBackupInterface {
+	return &RedisBackup{}
+}
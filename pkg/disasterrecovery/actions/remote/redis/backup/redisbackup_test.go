@@ -0,0 +1,285 @@
+package backup
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var testCredentials = redis.Credentials{
+	Host:     "valkey.example.com",
+	Password: "password",
+}
+
+func TestConfigure(t *testing.T) {
+	expectedState := &configureState{
+		kubeClusterClient: kubecluster.NewMockClientInterface(t),
+		namespace:         "namespace",
+		credentials:       testCredentials,
+		drVolName:         "drVolName",
+		backupFileRelPath: "backupFileRelPath",
+	}
+
+	action := NewRedisBackup()
+	err := action.Configure(
+		expectedState.kubeClusterClient,
+		expectedState.namespace,
+		expectedState.credentials,
+		expectedState.drVolName,
+		expectedState.backupFileRelPath,
+	)
+
+	t.Run("successfully configures the first time", func(t *testing.T) {
+		require.NoError(t, err)
+	})
+
+	t.Run("all state vars are populated", func(t *testing.T) {
+		casted := action.(*RedisBackup)
+
+		assert.NotEqual(t, "", casted.uid)
+		assert.NotEqual(t, uuid.Nil.String(), casted.uid)
+		expectedState.uid = casted.uid
+
+		assert.True(t, casted.isConfigured)
+		expectedState.isConfigured = casted.isConfigured
+
+		assert.Equal(t, expectedState, &casted.configureState)
+	})
+
+	t.Run("fails to configure because already configured", func(t *testing.T) {
+		err = action.Configure(
+			expectedState.kubeClusterClient,
+			expectedState.namespace,
+			expectedState.credentials,
+			expectedState.drVolName,
+			expectedState.backupFileRelPath,
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	notConfiguredState := &configureState{}
+	configuredState := &configureState{}
+	require.NoError(t, configuredState.Configure(nil, "namespace", testCredentials, "drVolName", "backupFileRelPath"))
+
+	invalidCredentialsState := &configureState{}
+	require.NoError(t, invalidCredentialsState.Configure(nil, "namespace", redis.Credentials{Host: "valkey.example.com", User: "backup"}, "drVolName", "backupFileRelPath"))
+
+	tests := []struct {
+		desc               string
+		configState        *configureState
+		isAlreadyValidated bool
+		simulateGetPVCErr  bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:               "succeeds if called multiple times",
+			isAlreadyValidated: true,
+		},
+		{
+			desc:        "fails because not configured",
+			configState: notConfiguredState,
+		},
+		{
+			desc:        "fails because the credentials are invalid",
+			configState: invalidCredentialsState,
+		},
+		{
+			desc:              "fails to get DR PVC",
+			simulateGetPVCErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockCoreClient := core.NewMockClientInterface(t)
+			mockClient.EXPECT().Core().Return(mockCoreClient).Maybe()
+
+			if tt.configState == nil {
+				tt.configState = configuredState
+			}
+
+			currentState := &validateState{
+				configureState: *tt.configState,
+				isValidated:    tt.isAlreadyValidated,
+			}
+			currentState.kubeClusterClient = mockClient
+
+			ctx := th.NewTestContext()
+
+			isValid := currentState.isConfigured && tt.configState != invalidCredentialsState
+			wantErr := th.ErrExpected(
+				!isValid,
+				tt.simulateGetPVCErr,
+			)
+
+			if isValid {
+				mockCoreClient.EXPECT().GetPVC(mock.Anything, currentState.namespace, currentState.drVolName).
+					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+
+						return nil, th.ErrIfTrue(tt.simulateGetPVCErr)
+					})
+			}
+
+			err := currentState.Validate(ctx)
+			if wantErr {
+				assert.Error(t, err)
+				assert.False(t, currentState.isValidated)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, currentState.isValidated)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		desc                    string
+		hasBeenNotBeenValidated bool
+		isAlreadySetup          bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:                    "fails because not validated first",
+			hasBeenNotBeenValidated: true,
+		},
+		{
+			desc:           "fails if called multiple times",
+			isAlreadySetup: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			currentState := &setupState{
+				validateState: validateState{
+					configureState: configureState{
+						uid:               "uid",
+						isConfigured:      true,
+						namespace:         "namespace",
+						credentials:       testCredentials,
+						drVolName:         "drVolName",
+						backupFileRelPath: "backupFileRelPath",
+					},
+					isValidated: !tt.hasBeenNotBeenValidated,
+				},
+				isSetup: tt.isAlreadySetup,
+			}
+
+			btiOpts := &backuptoolinstance.CreateBackupToolInstanceOptions{}
+			err := currentState.Setup(th.NewTestContext(), btiOpts)
+			if th.ErrExpected(tt.hasBeenNotBeenValidated, tt.isAlreadySetup) {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Contains(t, currentState.mountPaths.drVolume, currentState.uid)
+
+			require.Len(t, btiOpts.Volumes, 1)
+			assert.Equal(t, []string{currentState.mountPaths.drVolume}, btiOpts.Volumes[0].MountPaths)
+			require.NotNil(t, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim)
+			assert.Equal(t, currentState.drVolName, btiOpts.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		desc                string
+		hasNotBeenSetup     bool
+		simulateSnapshotErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:            "fails if not setup first",
+			hasNotBeenSetup: true,
+		},
+		{
+			desc:                "fails to snapshot",
+			simulateSnapshotErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockRedis := redis.NewMockRuntime(t)
+			mockGRPC := clients.NewMockClientInterface(t)
+			mockGRPC.EXPECT().Redis().Return(mockRedis).Maybe()
+
+			currentState := &executeState{
+				setupState: setupState{
+					validateState: validateState{
+						configureState: configureState{
+							uid:               "uid",
+							isConfigured:      true,
+							namespace:         "namespace",
+							credentials:       testCredentials,
+							drVolName:         "drVolName",
+							backupFileRelPath: "backupFileRelPath",
+						},
+						isValidated: true,
+					},
+					mountPaths: setupStateMountPaths{
+						drVolume: "/dr-volume",
+					},
+					isSetup: !tt.hasNotBeenSetup,
+				},
+			}
+
+			ctx := th.NewTestContext()
+			if currentState.isSetup {
+				drFilePath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupFileRelPath) // Important: Changing this is a breaking change!
+				mockRedis.EXPECT().Snapshot(mock.Anything, testCredentials, drFilePath).
+					RunAndReturn(func(calledCtx *contexts.Context, credentials redis.Credentials, filePath string) error {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+
+						return th.ErrIfTrue(tt.simulateSnapshotErr)
+					})
+			}
+
+			err := currentState.Execute(ctx, mockGRPC)
+			if tt.hasNotBeenSetup || tt.simulateSnapshotErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRedisBackup(t *testing.T) {
+	assert.Implements(t, (*RedisBackupInterface)(nil), (*RedisBackup)(nil))
+	assert.Implements(t, (*remote.RemoteAction)(nil), (*RedisBackup)(nil))
+}
+
+func TestNewRedisBackup(t *testing.T) {
+	// State vars should not be populated yet
+	assert.Equal(t, &RedisBackup{}, NewRedisBackup())
+}
@@ -0,0 +1,224 @@
+package restore
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+)
+
+// The name that Redis and Valkey load their snapshot from by default.
+const DefaultRDBFileName = "dump.rdb"
+
+// The names of the append only file in the server's data directory, which is also where the snapshot is
+// loaded from. Redis 7 and later write a directory of files, while earlier versions write a single file.
+var appendOnlyFileNames = []string{"appendonlydir", "appendonly.aof"}
+
+type RedisRestoreOptions struct {
+	// RDBFilePath is where the server loads its snapshot from, relative to the root of the target PVC. Defaults
+	// to "dump.rdb", which is where the server looks when its data directory is the root of the PVC.
+	RDBFilePath string `yaml:"rdbFilePath,omitempty"`
+}
+
+func (opts RedisRestoreOptions) getRDBFilePath() string {
+	if opts.RDBFilePath != "" {
+		return opts.RDBFilePath
+	}
+
+	return DefaultRDBFileName
+}
+
+// RedisRestoreInterface is a RemoteStage action that places an RDB snapshot from the DR volume onto the
+// target PVC of a Redis or Valkey server, replacing any snapshot that is already there. The server loads the
+// snapshot when it next starts, so the server must not be running (a restore precondition). Servers that
+// have the append only file enabled load that instead, so the restore fails if one is found next to the
+// snapshot path; it must be removed from the PVC first. Like a files restore, the target PVC is mounted
+// directly and the action creates no resources of its own.
+type RedisRestoreInterface interface {
+	remote.RemoteAction
+	Configure(kubeClusterClient kubecluster.ClientInterface, namespace, targetPVCName, drVolName, backupFileRelPath string, opts RedisRestoreOptions) error
+}
+
+type configureState struct {
+	uid               string // Unique identifier to prevent accidental collisions between multiple instances
+	isConfigured      bool
+	kubeClusterClient kubecluster.ClientInterface
+	namespace         string
+	targetPVCName     string
+	drVolName         string
+	backupFileRelPath string
+	opts              RedisRestoreOptions
+}
+
+// Configures the action prior to validation and execution. This should be called before
+// any other methods. Returns an error if the action is already configured.
+func (cs *configureState) Configure(kubeClusterClient kubecluster.ClientInterface, namespace, targetPVCName, drVolName, backupFileRelPath string, opts RedisRestoreOptions) error {
+	if cs.isConfigured {
+		return trace.Errorf("attempted to configure multiple times")
+	}
+
+	cs.uid = uuid.NewString()
+	cs.kubeClusterClient = kubeClusterClient
+	cs.namespace = namespace
+	cs.targetPVCName = targetPVCName
+	cs.drVolName = drVolName
+	cs.backupFileRelPath = backupFileRelPath
+	cs.opts = opts
+
+	cs.isConfigured = true
+	return nil
+}
+
+func (cs *configureState) ctxLogWith(ctx *contexts.Context) *contexts.LoggerContext {
+	return ctx.Log.With("targetPVC", cs.targetPVCName, "uid", cs.uid)
+}
+
+type validateState struct {
+	configureState
+	isValidated bool
+}
+
+// Validates that the required resources are ready. This should be called after `Configure`
+// and before `Setup`. Returns an error if the resources are not ready.
+func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
+	vs.ctxLogWith(ctx).Info("Validating configuration for Redis restore")
+	defer ctx.Log.Info("Completed Redis restore configuration validation", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !vs.isConfigured {
+		return trace.Errorf("attempted to validate without configuring")
+	}
+
+	if err := ValidateRDBFilePath(vs.opts.getRDBFilePath()); err != nil {
+		return trace.Wrap(err, "invalid RDB file path")
+	}
+
+	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.targetPVCName); err != nil {
+		return trace.Wrap(err, "failed to get target data PVC %q", vs.targetPVCName)
+	}
+
+	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.drVolName); err != nil {
+		return trace.Wrap(err, "failed to get DR PVC %q", vs.drVolName)
+	}
+
+	vs.isValidated = true
+	return nil
+}
+
+// ValidateRDBFilePath checks that the path names a file within the target PVC.
+func ValidateRDBFilePath(rdbFilePath string) error {
+	if !filepath.IsLocal(rdbFilePath) || filepath.Clean(rdbFilePath) == "." {
+		return trace.BadParameter("path %q must name a file within the PVC, relative to its root", rdbFilePath)
+	}
+
+	return nil
+}
+
+type setupStateMountPaths struct {
+	drVolume string
+	data     string
+}
+
+type setupState struct {
+	validateState
+	mountPaths setupStateMountPaths
+	isSetup    bool
+}
+
+// Prepares the backup tool pod to be able to perform the restore. This should be called
+// after `Validate` and before `Execute`. Returns an error if the pod cannot be prepared.
+func (ss *setupState) Setup(ctx *contexts.Context, btiOpts *backuptoolinstance.CreateBackupToolInstanceOptions) (err error) {
+	ss.ctxLogWith(ctx).Info("Setting up for Redis restore")
+	defer ctx.Log.Info("Redis restore setup complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !ss.isValidated {
+		return trace.Errorf("attempted to setup without validating")
+	}
+
+	if ss.isSetup {
+		return trace.Errorf("attempted to setup multiple times")
+	}
+
+	baseMountPath := filepath.Join("/mnt", "redisrestore", ss.uid)
+	ss.mountPaths = setupStateMountPaths{
+		drVolume: filepath.Join(baseMountPath, "dr"),
+		data:     filepath.Join(baseMountPath, "data"),
+	}
+
+	btiOpts.Volumes = append(btiOpts.Volumes,
+		core.NewSingleContainerPVC(ss.drVolName, ss.mountPaths.drVolume),
+		core.NewSingleContainerPVC(ss.targetPVCName, ss.mountPaths.data),
+	)
+
+	ss.isSetup = true
+	return nil
+}
+
+type executeState struct {
+	setupState
+}
+
+// Restores the snapshot. This should be called after `Setup`. Returns an error if the restore fails.
+func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.ClientInterface) (err error) {
+	es.ctxLogWith(ctx).Info("Executing Redis restore")
+	defer ctx.Log.Info("Redis restore complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if !es.isSetup {
+		return trace.Errorf("attempted to execute without setting up")
+	}
+
+	if err := es.checkForAppendOnlyFile(ctx.Child(), backupToolClient); err != nil {
+		return err
+	}
+
+	drRDBFilePath := filepath.Join(es.mountPaths.drVolume, es.backupFileRelPath)
+	dataRDBFilePath := filepath.Join(es.mountPaths.data, es.opts.getRDBFilePath())
+	err = backupToolClient.Files().CopyFiles(ctx.Child(), drRDBFilePath, dataRDBFilePath, files.CopyFilesOptions{})
+	return trace.Wrap(err, "failed to copy the snapshot at %q to the data PVC at %q", drRDBFilePath, dataRDBFilePath)
+}
+
+// checkForAppendOnlyFile fails when the data PVC holds an append only file in the directory that the snapshot
+// is placed in, as the server would load it instead of the restored snapshot.
+func (es *executeState) checkForAppendOnlyFile(ctx *contexts.Context, backupToolClient clients.ClientInterface) error {
+	rdbDirPath := path.Dir(filepath.ToSlash(filepath.Clean(es.opts.getRDBFilePath())))
+
+	// Only list as deep as the snapshot's directory, rather than the whole PVC.
+	maxDepth := 1
+	if rdbDirPath != "." {
+		maxDepth += strings.Count(rdbDirPath, "/") + 1
+	}
+
+	aofPaths := make(map[string]struct{}, len(appendOnlyFileNames))
+	for _, name := range appendOnlyFileNames {
+		aofPaths[path.Join(rdbDirPath, name)] = struct{}{}
+	}
+
+	result, err := backupToolClient.Files().ListTree(ctx.Child(), es.mountPaths.data, files.ListTreeOptions{MaxDepth: maxDepth})
+	if err != nil {
+		return trace.Wrap(err, "failed to list the contents of the data PVC")
+	}
+
+	for _, entry := range result.Entries {
+		if _, ok := aofPaths[entry.Path]; ok {
+			return trace.BadParameter("the data PVC holds an append only file at %q, which the server loads instead of the snapshot; remove it before restoring", entry.Path)
+		}
+	}
+
+	return nil
+}
+
+type RedisRestore struct {
+	executeState
+}
+
+func NewRedisRestore() RedisRestoreInterface {
+	return &RedisRestore{}
+}
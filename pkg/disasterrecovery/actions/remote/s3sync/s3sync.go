@@ -8,11 +8,13 @@ import (
 	"github.com/gravitational/trace"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 )
 
 type Direction int
@@ -33,7 +35,33 @@ const (
 	DirectionUpload
 )
 
-type S3SyncOptions struct{}
+// RestoreMode selects how an upload restores the bucket.
+type RestoreMode string
+
+const (
+	// RestoreModeUpload uploads the DR directory back to the bucket. This is the default.
+	RestoreModeUpload RestoreMode = "upload"
+	// RestoreModeRewind rolls the (versioned) bucket back in place to the instant that the backup captured it
+	// as of, without reading the DR directory's objects. It requires a point-in-time backup.
+	RestoreModeRewind RestoreMode = "rewind"
+)
+
+type S3SyncOptions struct {
+	RateLimit   throttle.Limits     `yaml:"rateLimit,omitempty"`   // Caps the rate that objects are transferred at, across all concurrent object transfers.
+	Multipart   s3.MultipartOptions `yaml:"multipart,omitempty"`   // Controls which objects are transferred in parts, and the part size.
+	RestoreMode RestoreMode         `yaml:"restoreMode,omitempty"` // How an upload restores the bucket. Empty means upload.
+	DryRun      bool                `yaml:"dryRun,omitempty"`      // Logs the changes that a rewind would make, without making them.
+	Rehash      bool                `yaml:"rehash,omitempty"`      // Revalidates the checksums of local copies that a download skips as up to date.
+	// Filter selects which objects are synced, by their keys relative to the prefix. A rewind leaves alone the
+	// objects excluded by the filter that the backup was taken with, which is recorded in its manifest.
+	Filter files.FileFilter `yaml:",inline"`
+}
+
+// manifestSuffix is appended to the slot's directory name to form the path of the manifest that a download
+// writes, and that an upload verifies the directory's files against. The manifest sits beside the slot's
+// directory rather than in it, so that it is neither pruned by the download nor uploaded by a restore.
+// Changing this breaks rewinding to existing backups.
+const manifestSuffix = ".manifest.json"
 
 // S3SyncInterface is a RemoteStage action. Beyond the base RemoteAction contract it implements
 // remote.ConsistencyPointConsumer: it receives the event's shared consistency point so a download
@@ -110,6 +138,27 @@ func (vs *validateState) Validate(ctx *contexts.Context) (err error) {
 		return trace.Errorf("invalid direction %q", vs.direction)
 	}
 
+	switch vs.opts.RestoreMode {
+	case "", RestoreModeUpload:
+		if vs.opts.DryRun {
+			return trace.BadParameter("dry run is only supported by the %q restore mode", RestoreModeRewind)
+		}
+	case RestoreModeRewind:
+		if vs.direction != DirectionUpload {
+			return trace.BadParameter("the %q restore mode is only supported when restoring", RestoreModeRewind)
+		}
+	default:
+		return trace.BadParameter("invalid restore mode %q", vs.opts.RestoreMode)
+	}
+
+	if vs.opts.Rehash && vs.direction != DirectionDownload {
+		return trace.BadParameter("rehashing is only supported when backing up")
+	}
+
+	if err := vs.opts.Filter.Validate(); err != nil {
+		return trace.Wrap(err, "invalid include/exclude filter")
+	}
+
 	if _, err := vs.kubeClusterClient.Core().GetPVC(ctx.Child(), vs.namespace, vs.drVolName); err != nil {
 		return trace.Wrap(err, "failed to get DR PVC %q", vs.drVolName)
 	}
@@ -160,6 +209,11 @@ func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.
 	}
 
 	backupPath := filepath.Join(es.mountPaths.drVolume, es.backupDirRelPath)
+	manifestPath := backupPath + manifestSuffix
+
+	if es.opts.RestoreMode == RestoreModeRewind {
+		return trace.Wrap(es.rewind(ctx.Child(), backupToolClient, manifestPath), "failed to rewind %q", es.s3Path)
+	}
 
 	source := es.s3Path
 	destination := backupPath
@@ -175,10 +229,41 @@ func (es *executeState) Execute(ctx *contexts.Context, backupToolClient clients.
 		asOf = es.consistencyPoint
 	}
 
-	err = backupToolClient.S3().Sync(ctx.Child(), es.credentials, source, destination, asOf)
+	syncOpts := s3.SyncOptions{
+		Limits:       es.opts.RateLimit,
+		Multipart:    es.opts.Multipart,
+		ManifestPath: manifestPath,
+		Rehash:       es.opts.Rehash,
+		Filter:       es.opts.Filter,
+	}
+
+	err = backupToolClient.S3().Sync(ctx.Child(), es.credentials, source, destination, asOf, syncOpts)
 	return trace.Wrap(err, "failed to sync files from %q to %q", source, destination)
 }
 
+// rewind rolls the bucket back in place to the instant recorded in the backup's manifest, logging each
+// change so that a dry run produces a reviewable diff.
+func (es *executeState) rewind(ctx *contexts.Context, backupToolClient clients.ClientInterface, manifestPath string) error {
+	changes, err := backupToolClient.S3().Rewind(ctx.Child(), es.credentials, es.s3Path, manifestPath, s3.RewindOptions{
+		DryRun: es.opts.DryRun,
+		Limits: es.opts.RateLimit,
+	})
+	if err != nil {
+		return trace.Wrap(err, "failed to rewind bucket")
+	}
+
+	message := "Rewound object"
+	if es.opts.DryRun {
+		message = "Would rewind object"
+	}
+	for _, change := range changes {
+		ctx.Log.Info(message, "key", change.Key, "action", change.Action, "versionID", change.VersionID)
+	}
+
+	ctx.Log.Info("Bucket rewind summary", "changes", len(changes), "dryRun", es.opts.DryRun)
+	return nil
+}
+
 type S3Sync struct {
 	executeState
 }
@@ -9,12 +9,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	"github.com/solidDoWant/backup-tool/pkg/grpc/clients"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -102,11 +104,55 @@ func TestValidate(t *testing.T) {
 		configState        *configureState
 		isAlreadyValidated bool
 		invalidDirection   bool
+		direction          Direction
+		opts               S3SyncOptions
+		invalidOpts        bool
 		simulateGetPVCErr  bool
 	}{
 		{
 			desc: "succeeds",
 		},
+		{
+			desc:      "succeeds rewinding on restore",
+			direction: DirectionUpload,
+			opts:      S3SyncOptions{RestoreMode: RestoreModeRewind, DryRun: true},
+		},
+		{
+			desc:        "fails rewinding on backup",
+			opts:        S3SyncOptions{RestoreMode: RestoreModeRewind},
+			invalidOpts: true,
+		},
+		{
+			desc:        "fails with dry run when uploading",
+			direction:   DirectionUpload,
+			opts:        S3SyncOptions{RestoreMode: RestoreModeUpload, DryRun: true},
+			invalidOpts: true,
+		},
+		{
+			desc: "succeeds rehashing on backup",
+			opts: S3SyncOptions{Rehash: true},
+		},
+		{
+			desc:        "fails rehashing on restore",
+			direction:   DirectionUpload,
+			opts:        S3SyncOptions{Rehash: true},
+			invalidOpts: true,
+		},
+		{
+			desc: "succeeds with a filter",
+			opts: S3SyncOptions{Filter: files.FileFilter{Exclude: []files.FilePattern{{Glob: "thumbnails"}}}},
+		},
+		{
+			desc:        "fails with an invalid filter",
+			opts:        S3SyncOptions{Filter: files.FileFilter{Exclude: []files.FilePattern{{Glob: "[bad"}}}},
+			invalidOpts: true,
+		},
+		{
+			desc:        "fails with invalid restore mode",
+			direction:   DirectionUpload,
+			opts:        S3SyncOptions{RestoreMode: "invalid"},
+			invalidOpts: true,
+		},
 		{
 			desc:               "succeeds if called multiple times",
 			isAlreadyValidated: true,
@@ -140,6 +186,10 @@ func TestValidate(t *testing.T) {
 				configureState: *tt.configState,
 				isValidated:    tt.isAlreadyValidated,
 			}
+			if currentState.isConfigured {
+				currentState.direction = tt.direction
+				currentState.opts = tt.opts
+			}
 			ctx := th.NewTestContext()
 
 			func() {
@@ -152,6 +202,10 @@ func TestValidate(t *testing.T) {
 					return
 				}
 
+				if tt.invalidOpts {
+					return
+				}
+
 				mockCoreClient.EXPECT().GetPVC(mock.Anything, "namespace", "drVolName").
 					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
 						assert.True(t, calledCtx.IsChildOf(ctx))
@@ -162,7 +216,7 @@ func TestValidate(t *testing.T) {
 
 			err := currentState.Validate(ctx)
 
-			if th.ErrExpected(!currentState.isConfigured, tt.invalidDirection, tt.simulateGetPVCErr) {
+			if th.ErrExpected(!currentState.isConfigured, tt.invalidDirection, tt.invalidOpts, tt.simulateGetPVCErr) {
 				assert.Error(t, err)
 				return
 			}
@@ -240,6 +294,8 @@ func TestExecute(t *testing.T) {
 		hasNotBeenSetup   bool
 		simulateS3SyncErr bool
 		direction         Direction
+		restoreMode       RestoreMode
+		dryRun            bool
 	}{
 		{
 			desc:      "succeeds download",
@@ -249,6 +305,23 @@ func TestExecute(t *testing.T) {
 			desc:      "succeeds upload",
 			direction: DirectionUpload,
 		},
+		{
+			desc:        "succeeds rewind",
+			direction:   DirectionUpload,
+			restoreMode: RestoreModeRewind,
+		},
+		{
+			desc:        "succeeds rewind dry run",
+			direction:   DirectionUpload,
+			restoreMode: RestoreModeRewind,
+			dryRun:      true,
+		},
+		{
+			desc:              "fails to rewind",
+			direction:         DirectionUpload,
+			restoreMode:       RestoreModeRewind,
+			simulateS3SyncErr: true,
+		},
 		{
 			desc:            "fails if not setup first",
 			hasNotBeenSetup: true,
@@ -280,8 +353,15 @@ func TestExecute(t *testing.T) {
 							s3Path:            "s3Path",
 							credentials:       s3.NewMockCredentialsInterface(t),
 							direction:         tt.direction,
-							opts:              S3SyncOptions{},
-							consistencyPoint:  consistencyPoint,
+							opts: S3SyncOptions{
+								RateLimit:   throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10},
+								Multipart:   s3.MultipartOptions{Threshold: 128 << 20},
+								RestoreMode: tt.restoreMode,
+								DryRun:      tt.dryRun,
+								Rehash:      tt.direction == DirectionDownload,
+								Filter:      files.FileFilter{Exclude: []files.FilePattern{{Glob: "thumbnails"}}},
+							},
+							consistencyPoint: consistencyPoint,
 						},
 						isValidated: true,
 					},
@@ -294,20 +374,38 @@ func TestExecute(t *testing.T) {
 
 			ctx := th.NewTestContext()
 
-			if currentState.isSetup {
-				backupPath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupDirRelPath)
+			backupPath := filepath.Join(currentState.mountPaths.drVolume, currentState.backupDirRelPath)
+			manifestPath := backupPath + ".manifest.json"
+			if currentState.isSetup && tt.restoreMode == RestoreModeRewind {
+				expectedOpts := s3.RewindOptions{DryRun: tt.dryRun, Limits: currentState.opts.RateLimit}
+				mockS3Runtime.EXPECT().Rewind(mock.Anything, currentState.credentials, currentState.s3Path, manifestPath, expectedOpts).
+					RunAndReturn(func(calledCtx *contexts.Context, creds s3.CredentialsInterface, path, manifestPath string, opts s3.RewindOptions) ([]s3.RewindChange, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						if tt.simulateS3SyncErr {
+							return nil, assert.AnError
+						}
+						return []s3.RewindChange{{Key: "key", Action: s3.RewindActionDelete}}, nil
+					})
+			} else if currentState.isSetup {
 				source := currentState.s3Path
 				destination := backupPath
 				// The consistency point is applied only when capturing the bucket (download); on upload it
 				// must not be propagated.
 				expectedAsOf := consistencyPoint
+				expectedOpts := s3.SyncOptions{
+					Limits:       currentState.opts.RateLimit,
+					Multipart:    currentState.opts.Multipart,
+					ManifestPath: manifestPath,
+					Rehash:       currentState.opts.Rehash,
+					Filter:       currentState.opts.Filter,
+				}
 				if currentState.direction == DirectionUpload {
 					source, destination = destination, source
 					expectedAsOf = time.Time{}
 				}
 
-				mockS3Runtime.EXPECT().Sync(mock.Anything, currentState.credentials, source, destination, expectedAsOf).
-					RunAndReturn(func(calledCtx *contexts.Context, creds s3.CredentialsInterface, src, dst string, asOf time.Time) error {
+				mockS3Runtime.EXPECT().Sync(mock.Anything, currentState.credentials, source, destination, expectedAsOf, expectedOpts).
+					RunAndReturn(func(calledCtx *contexts.Context, creds s3.CredentialsInterface, src, dst string, asOf time.Time, opts s3.SyncOptions) error {
 						assert.True(t, calledCtx.IsChildOf(ctx))
 						return th.ErrIfTrue(tt.simulateS3SyncErr)
 					})
@@ -7,6 +7,7 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
 	cnpgbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/backup"
 	cnpgrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/restore"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/export"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/s3sync"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
@@ -29,6 +30,7 @@ type AuthentikBackupOptions struct {
 	RemoteBackupToolOptions backuptoolinstance.CreateBackupToolInstanceOptions `yaml:"remoteBackupToolOptions,omitempty"`
 	BackupSnapshot          OptionsBackupSnapshot                              `yaml:"backupSnapshot,omitempty"`
 	CleanupTimeout          helpers.MaxWaitTime                                `yaml:"cleanupTimeout,omitempty"`
+	Export                  *OffsiteExport                                     `yaml:"export,omitempty"`
 }
 
 type Authentik struct {
@@ -37,6 +39,7 @@ type Authentik struct {
 	newCNPGBackup  func() cnpgbackup.CNPGBackupInterface
 	newCNPGRestore func() cnpgrestore.CNPGRestoreInterface
 	newS3Sync      func() s3sync.S3SyncInterface
+	newExport      func() export.ExportInterface
 	newRemoteStage func(kubeClusterClient kubecluster.ClientInterface, namespace, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface
 }
 
@@ -46,6 +49,7 @@ func NewAuthentik(kubeClusterClient kubecluster.ClientInterface) *Authentik {
 		newCNPGBackup:     cnpgbackup.NewCNPGBackup,
 		newCNPGRestore:    cnpgrestore.NewCNPGRestore,
 		newS3Sync:         s3sync.NewS3Sync,
+		newExport:         export.NewExport,
 		newRemoteStage:    remote.NewRemoteStage,
 	}
 }
@@ -63,6 +67,11 @@ func (a *Authentik) Backup(ctx *contexts.Context, namespace, backupName, cluster
 		}
 	}()
 
+	offsiteExport, err := prepareExport(ctx.Child(), a.kubeClusterClient, namespace, opts.Export)
+	if err != nil {
+		return backup, trace.Wrap(err, "invalid backup configuration")
+	}
+
 	// Create the DR PVC if not exists
 	ctx.Log.Step()
 	drv, err := a.kubeClusterClient.NewDRVolume(ctx.Child(), namespace, backup.Name, opts.VolumeSize, drvolume.DRVolumeCreateOptions{
@@ -112,6 +121,10 @@ func (a *Authentik) Backup(ctx *contexts.Context, namespace, backupName, cluster
 		return backup, trace.Wrap(err, "failed to snapshot the backup volume")
 	}
 
+	if err := offsiteExport.run(ctx.Child(), a.kubeClusterClient, namespace, backup, a.newRemoteStage, a.newExport, opts.CleanupTimeout); err != nil {
+		return backup, trace.Wrap(err, "failed to export the backup")
+	}
+
 	return backup, nil
 }
 
@@ -144,6 +157,7 @@ func (a *Authentik) Restore(ctx *contexts.Context, namespace, restoreName, clust
 	if err := cnpgRestore.Configure(a.kubeClusterClient, namespace, clusterName, servingCertName, clientCAIssuer, restoreName, authentikSQLFileName, cnpgrestore.CNPGRestoreOptions{
 		PostgresUserCert: opts.PostgresUserCert,
 		CleanupTimeout:   opts.CleanupTimeout,
+		Force:            true, // The restore replaces the app's database, which is expected to exist.
 	}); err != nil {
 		return restore, trace.Wrap(err, "failed to configure CNPG cluster restoration")
 	}
@@ -280,6 +280,7 @@ func TestAuthentikRestore(t *testing.T) {
 				mockCNPGRestore.EXPECT().Configure(mockClient, namespace, clusterName, servingCertName, clientCAIssuer, restoreName, "dump.sql", cnpgrestore.CNPGRestoreOptions{
 					PostgresUserCert: tt.opts.PostgresUserCert,
 					CleanupTimeout:   tt.opts.CleanupTimeout,
+					Force:            true,
 				}).Return(th.ErrIfTrue(tt.simulateCNPGRestoreError))
 				if tt.simulateCNPGRestoreError {
 					return
@@ -0,0 +1,47 @@
+package disasterrecovery
+
+import (
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/browse"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+)
+
+// SnapshotBrowser lists the contents of backup snapshots in place. It works with any app's DR volume
+// snapshots, as it only depends on the snapshot's filesystem.
+type SnapshotBrowser struct {
+	kubeClusterClient kubecluster.ClientInterface
+	// Testing injection
+	newBrowse      func() browse.BrowseInterface
+	newRemoteStage func(kubeClusterClient kubecluster.ClientInterface, namespace, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface
+}
+
+func NewSnapshotBrowser(client kubecluster.ClientInterface) *SnapshotBrowser {
+	return &SnapshotBrowser{
+		kubeClusterClient: client,
+		newBrowse:         browse.NewBrowse,
+		newRemoteStage:    remote.NewRemoteStage,
+	}
+}
+
+// Browse lists the contents of the snapshot, calling visit with each page of entries. No data is copied
+// out of the snapshot: it is restored to a temporary volume, which is listed and then removed.
+func (sb *SnapshotBrowser) Browse(ctx *contexts.Context, namespace, snapshotName string, visit browse.VisitFunc, opts browse.BrowseOptions) (err error) {
+	event := NewDREventNow("browse")
+	ctx.Log.With("snapshot", snapshotName, "namespace", namespace).Info("Browsing snapshot")
+	defer ctx.Log.Info("Finished browsing snapshot", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	stage := sb.newRemoteStage(sb.kubeClusterClient, namespace, event.GetFullName(), remote.RemoteStageOptions{
+		CleanupTimeout: opts.CleanupTimeout,
+	})
+
+	action := sb.newBrowse()
+	if err := action.Configure(sb.kubeClusterClient, namespace, snapshotName, visit, opts); err != nil {
+		return trace.Wrap(err, "failed to configure browse of snapshot %q", snapshotName)
+	}
+	stage.WithAction("browse", action)
+
+	err = stage.Run(ctx.Child())
+	return trace.Wrap(err, "failed to browse snapshot %q", snapshotName)
+}
@@ -0,0 +1,87 @@
+package disasterrecovery
+
+import (
+	"testing"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/browse"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSnapshotBrowser(t *testing.T) {
+	mockClient := kubecluster.NewMockClientInterface(t)
+	sb := NewSnapshotBrowser(mockClient)
+
+	require.NotNil(t, sb)
+	assert.Equal(t, mockClient, sb.kubeClusterClient)
+	assert.NotNil(t, sb.newBrowse)
+	assert.NotNil(t, sb.newRemoteStage)
+}
+
+func TestSnapshotBrowserBrowse(t *testing.T) {
+	namespace := "namespace"
+	snapshotName := "snapshot"
+	opts := browse.BrowseOptions{MaxDepth: 2, CleanupTimeout: helpers.ShortWaitTime}
+
+	tests := []struct {
+		desc                 string
+		simulateConfigureErr bool
+		simulateRunErr       bool
+	}{
+		{desc: "success"},
+		{desc: "error configuring", simulateConfigureErr: true},
+		{desc: "error running", simulateRunErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockStage := remote.NewMockRemoteStageInterface(t)
+			mockBrowse := browse.NewMockBrowseInterface(t)
+
+			sb := &SnapshotBrowser{
+				kubeClusterClient: mockClient,
+				newBrowse:         func() browse.BrowseInterface { return mockBrowse },
+				newRemoteStage: func(c kubecluster.ClientInterface, ns, eventName string, stageOpts remote.RemoteStageOptions) remote.RemoteStageInterface {
+					assert.Equal(t, mockClient, c)
+					assert.Equal(t, namespace, ns)
+					assert.Contains(t, eventName, "browse")
+					assert.Equal(t, opts.CleanupTimeout, stageOpts.CleanupTimeout)
+					return mockStage
+				},
+			}
+
+			visit := func([]files.TreeEntry) error { return nil }
+			rootCtx := th.NewTestContext()
+
+			func() {
+				mockBrowse.EXPECT().Configure(mockClient, namespace, snapshotName, mock.Anything, opts).
+					Return(th.ErrIfTrue(tt.simulateConfigureErr))
+				if tt.simulateConfigureErr {
+					return
+				}
+
+				mockStage.EXPECT().WithAction("browse", mockBrowse).Return(mockStage)
+				mockStage.EXPECT().Run(mock.Anything).RunAndReturn(func(ctx *contexts.Context) error {
+					assert.True(t, ctx.IsChildOf(rootCtx))
+					return th.ErrIfTrue(tt.simulateRunErr)
+				})
+			}()
+
+			err := sb.Browse(rootCtx, namespace, snapshotName, visit, opts)
+			if th.ErrExpected(tt.simulateConfigureErr, tt.simulateRunErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
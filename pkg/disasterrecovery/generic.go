@@ -9,19 +9,32 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
 	cnpgbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/backup"
+	physicalrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/physicalrestore"
 	cnpgrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/restore"
 	filesbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/backup"
 	filesgroupbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/groupbackup"
 	filesgrouprestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/grouprestore"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/layout"
 	filesrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/restore"
+	mysqlbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/mysql/backup"
+	mysqlrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/mysql/restore"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/export"
+	pgserverbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/backup"
+	pgservercommon "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/common"
+	pgserverrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/restore"
+	redisbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/redis/backup"
+	redisrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/redis/restore"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/s3sync"
 	"github.com/solidDoWant/backup-tool/pkg/files"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/drvolume"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+	"github.com/solidDoWant/backup-tool/pkg/postgres"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,16 +45,22 @@ import (
 // N volumes / M CNPG clusters / O S3 buckets. The engine (RemoteStage + the existing actions) is reused
 // verbatim; only the composition is lifted out of Go.
 //
-// Sources are grouped by kind (postgres/files/fileGroups/s3) as plain typed slices, which the existing config
-// toolchain (goccy strict YAML + go-playground/validator + invopop/jsonschema) handles directly. Backup
-// and restore are separate types (and files) because their direction-specific fields differ materially;
-// goccy strict mode then rejects a restore-only field in a backup file and vice-versa.
+// Sources are grouped by kind (postgres/postgresServers/mysql/redis/files/fileGroups/s3) as plain typed
+// slices, which the existing config toolchain (goccy strict YAML + go-playground/validator +
+// invopop/jsonschema) handles directly. Backup and restore are separate types (and files) because their
+// direction-specific fields differ materially; goccy strict mode then rejects a restore-only field in a
+// backup file and vice-versa.
 
 // GenericFilesSource captures (backup) / restores a data-directory PVC into / from a subdirectory of the
-// DR volume. Shared by both directions — v1 restores in place (same target as backup).
+// DR volume. Shared by both directions — v1 restores in place (same target as backup). RateLimit optionally
+// caps the bytes and files per second read while copying, so that a capture or restore running alongside the
+// app does not saturate the volume's storage. Parallelism optionally copies that many files concurrently,
+// which hides per-file latency on network-backed volumes; the rate limit applies to all workers combined.
 type GenericFilesSource struct {
-	Name string `yaml:"name" jsonschema:"required"` // slot id => DR subdir "<name>"
-	PVC  string `yaml:"pvc" jsonschema:"required"`  // sourcePVCName (backup) / targetPVCName (restore)
+	Name        string          `yaml:"name" jsonschema:"required"` // slot id => DR subdir "<name>"
+	PVC         string          `yaml:"pvc" jsonschema:"required"`  // sourcePVCName (backup) / targetPVCName (restore)
+	RateLimit   throttle.Limits `yaml:"rateLimit,omitempty"`
+	Parallelism int             `yaml:"parallelism,omitempty"`
 }
 
 // GenericFilesBackupSource is a files source plus backup-only capture options. SnapshotClass selects the
@@ -49,11 +68,15 @@ type GenericFilesSource struct {
 // empty the cluster default VolumeSnapshotClass is used. Include/Exclude (inlined files.FileFilter)
 // optionally whitelist/blacklist which files within the source PVC are captured. These are backup-only
 // fields and live on a backup-specific type (mirroring the postgres backup/restore split): the capture is
-// already filtered on disk, so restore reads it back verbatim and needs no filter.
+// already filtered on disk, so restore reads it back verbatim and needs no filter. SQLiteDatabases lists
+// the paths (relative to the PVC root) of SQLite databases on the PVC, which are captured with the SQLite
+// online backup API rather than copied; the capture holds a self-contained copy of each database, so
+// restore reinstates them like any other file.
 type GenericFilesBackupSource struct {
 	GenericFilesSource `yaml:",inline"`
 	SnapshotClass      string `yaml:"snapshotClass,omitempty"`
 	files.FileFilter   `yaml:",inline"`
+	SQLiteDatabases    []string `yaml:"sqliteDatabases,omitempty"`
 }
 
 // GenericFileGroupSource captures (backup) / restores a label-selected group of data-directory PVCs into /
@@ -61,10 +84,14 @@ type GenericFilesBackupSource struct {
 // both directions: at backup it selects the live member PVCs to snapshot together; at restore it re-resolves
 // the (already-hydrated) target PVCs so each captured member syncs back onto its identically-named PVC. The
 // capture lands under "fileGroups/<name>/<pvc>" on the DR volume (one member subdir per PVC). Shared by both
-// directions — v1 restores in place.
+// directions — v1 restores in place. RateLimit optionally caps the bytes and files per second read while
+// copying; members are copied one at a time, so the limit applies to each member's copy in turn.
+// Parallelism optionally copies that many files concurrently within each member's copy.
 type GenericFileGroupSource struct {
-	Name     string               `yaml:"name" jsonschema:"required"`     // slot id => DR subdir "fileGroups/<name>"
-	Selector metav1.LabelSelector `yaml:"selector" jsonschema:"required"` // member PVC selector (must match >=1 PVC)
+	Name        string               `yaml:"name" jsonschema:"required"`     // slot id => DR subdir "fileGroups/<name>"
+	Selector    metav1.LabelSelector `yaml:"selector" jsonschema:"required"` // member PVC selector (must match >=1 PVC)
+	RateLimit   throttle.Limits      `yaml:"rateLimit,omitempty"`
+	Parallelism int                  `yaml:"parallelism,omitempty"`
 }
 
 // GenericFileGroupBackupSource is a file-group source plus backup-only capture options. SnapshotClass
@@ -82,30 +109,354 @@ type GenericFileGroupBackupSource struct {
 
 // GenericS3Source syncs an object-store prefix to (backup) / from (restore) a subdirectory of the DR
 // volume. Credentials are an optional inline s3.Credentials (matching the per-app configs); when omitted
-// the AWS environment variables are used (s3.NewCredentialsFromEnv).
+// the AWS environment variables are used (s3.NewCredentialsFromEnv). CredentialsSecretRef instead reads the
+// keys (and optionally the endpoint, region and path style) from a Kubernetes Secret when the event starts,
+// so that they aren't written into the config. RateLimit optionally caps the bytes and
+// objects per second transferred, in aggregate across the sync's concurrent object transfers. Multipart
+// optionally sets the object size at and above which objects are transferred in parts (each retried on its
+// own, with interrupted downloads resuming from a partial file on the DR volume), and the part size.
+// Include/Exclude (inlined files.FileFilter) optionally whitelist/blacklist which objects are synced, matched
+// against their keys relative to the prefix. The filter applies in both directions: excluded objects are
+// neither captured nor restored, and a restore leaves the bucket's excluded objects in place rather than
+// pruning them.
 type GenericS3Source struct {
-	Name        string         `yaml:"name" jsonschema:"required"` // slot id => DR subdir "<name>"
-	Path        string         `yaml:"path" jsonschema:"required"` // s3://bucket/prefix
-	Credentials s3.Credentials `yaml:"credentials,omitempty"`
+	Name                 string              `yaml:"name" jsonschema:"required"` // slot id => DR subdir "<name>"
+	Path                 string              `yaml:"path" jsonschema:"required"` // s3://bucket/prefix
+	Credentials          s3.Credentials      `yaml:"credentials,omitempty"`
+	CredentialsSecretRef *SecretRef          `yaml:"credentialsSecretRef,omitempty"`
+	RateLimit            throttle.Limits     `yaml:"rateLimit,omitempty"`
+	Multipart            s3.MultipartOptions `yaml:"multipart,omitempty"`
+	files.FileFilter     `yaml:",inline"`
+}
+
+// GenericS3BackupSource is an S3 source plus backup-only options. Every downloaded object is verified against
+// its checksum, and the checksums are recorded in the manifest beside the subdirectory (so that a restore can
+// verify the files before uploading them). Objects whose copies on the DR volume are up to date by size and
+// modification time are skipped; Rehash revalidates those copies against their recorded checksums too, and
+// downloads any that fail again.
+type GenericS3BackupSource struct {
+	GenericS3Source `yaml:",inline"`
+	Rehash          bool `yaml:"rehash,omitempty"`
+}
+
+// GenericS3RestoreSource is an S3 source plus restore-only options. Mode selects how the bucket is restored:
+// "upload" (the default) pushes the DR subdirectory back to the prefix, while "rewind" rolls a versioned
+// bucket back in place to the instant the backup captured it as of, re-promoting the historical object
+// versions and deleting objects created since. Rewind requires that the backup captured the bucket as of the
+// event's consistency point (i.e. versioning was enabled), and reads the as-of time from the manifest that
+// the backup wrote beside the subdirectory. DryRun (rewind only) logs the planned changes without making
+// them.
+type GenericS3RestoreSource struct {
+	GenericS3Source `yaml:",inline"`
+	Mode            s3sync.RestoreMode `yaml:"mode,omitempty"`
+	DryRun          bool               `yaml:"dryRun,omitempty"`
 }
 
 // GenericPostgresBackupSource clones a CNPG cluster and logically dumps it to the DR volume. The clone's
 // serving cert and client-CA cert are minted from a self-signed issuer created internally during
 // cloning, so no issuer needs to be supplied; clusterCloning carries the remaining cloning options
 // (recovery target, snapshot/cert timeouts, and the self-signed issuer's CertificateRequestPolicy).
+//
+// Format "directory" dumps each database with pg_dump's directory format instead of one pg_dumpall SQL
+// file, which lets Jobs tables of each database be dumped (and restored) in parallel. The inlined database
+// selection limits the dump to some databases, and (with the directory format) some of their schemas and tables.
+// Roles skips or renames roles in the dump, such as the owner of an app's tables, and can leave out passwords.
+//
+// Mode "physical" skips the clone and the dump: the cluster's base backup is kept instead of being deleted
+// once the event completes, and is recorded (with the event's consistency point) in "<name>.physical.json" on
+// the DR volume. This is much faster for large clusters, but the backup lives in the base backup's volume
+// snapshots rather than on the DR volume, so it is not exported with it. The dump options don't apply to it.
+// Kept base backups are never deleted by the tool, so every physical backup keeps its volume snapshots until
+// its CNPG Backup ("<cluster>-cloned*", named in the manifest) is deleted by hand.
+//
+// RestorePointName creates a named restore point on the cluster during the backup, after its base backup, such
+// as before a risky change. The base backup's name is appended to it so that each event's restore point is
+// unique; the full name and its location (LSN) are logged, and recorded in a physical backup's manifest. A later
+// restore can recover to it with clusterCloning.recoveryTarget.targetName.
 type GenericPostgresBackupSource struct {
-	Name           string                            `yaml:"name" jsonschema:"required"`           // slot id => dump file "<name>.sql"
+	Name           string                            `yaml:"name" jsonschema:"required"`           // slot id => dump "<name>.sql" (or directory "<name>.dump")
 	Cluster        string                            `yaml:"cluster" jsonschema:"required"`        // clusterName
 	ClusterCloning clonedcluster.CloneClusterOptions `yaml:"clusterCloning" jsonschema:"required"` // CNPGBackupOptions.CloningOpts
+	Format         postgres.DumpFormat               `yaml:"format,omitempty"`                     // "plain" (default) or "directory"
+	Jobs           int                               `yaml:"jobs,omitempty"`                       // directory format only
+	Roles          postgres.RoleHandling             `yaml:"roles,omitempty"`                      // renames require the plain format
+	Mode           cnpgbackup.BackupMode             `yaml:"mode,omitempty" jsonschema:"description=How the cluster is backed up: logical (the default) dumps a clone of it\\, and physical keeps its CNPG base backup. Kept base backups are never deleted by the tool; delete old ones (the CNPG Backups named <cluster>-cloned*) by hand once no kept manifest names them"`
+
+	postgres.DatabaseSelection `yaml:",inline"` // includeDatabases, excludeDatabases, databases
+
+	// Named restore point created on the cluster after the consistency point, for later restores to recover to.
+	// Suffixed with "-<base backup name>"
+	RestorePointName string `yaml:"restorePointName,omitempty"`
 }
 
-// GenericPostgresRestoreSource logically restores a SQL dump from the DR volume into a live cluster.
+// backupOptions returns the options of the source's backup action.
+func (src GenericPostgresBackupSource) backupOptions(cleanupTimeout helpers.MaxWaitTime) cnpgbackup.CNPGBackupOptions {
+	return cnpgbackup.CNPGBackupOptions{
+		CloningOpts:      src.ClusterCloning,
+		CleanupTimeout:   cleanupTimeout,
+		Format:           src.Format,
+		Jobs:             src.Jobs,
+		Selection:        src.DatabaseSelection,
+		Roles:            src.Roles,
+		Mode:             src.Mode,
+		RestorePointName: src.RestorePointName,
+	}
+}
+
+// backupFileName is the on-disk path that the source's backup writes: the dump, or the manifest of a
+// physical backup.
+func (src GenericPostgresBackupSource) backupFileName() string {
+	if src.Mode == cnpgbackup.BackupModePhysical {
+		return physicalBackupManifestFileName(src.Name)
+	}
+	return dumpFileName(src.Name, src.Format)
+}
+
+// GenericPostgresRestoreSource logically restores a dump from the DR volume into a live cluster. Format must
+// match the format that the backup source dumped with. The inlined restore selection restores part of the
+// dump, such as a single table, optionally into a new database alongside the original.
+//
+// The restore refuses to replace databases that aren't empty unless Force is set. StopOnError and
+// SingleTransaction fail the restore on the first failed statement, rather than only logging it, and
+// DropAndRecreate drops the replaced databases first, disconnecting their sessions.
+//
+// Mode "physical" restores a physical backup instead: Cluster names a new cluster (at most 40 characters) that
+// is created from the base backup recorded in "<name>.physical.json", recovered forward to the backup event's
+// consistency point, and left running. The new cluster's image, storage and WAL archive are copied from the
+// manifest, so the backed up cluster doesn't need to exist. Like a clone, the new cluster's serving and
+// client-CA certs are minted from a self-signed issuer created for it; clusterCloning carries the cloning
+// options. None of the logical restore options apply to it.
 type GenericPostgresRestoreSource struct {
-	Name             string                             `yaml:"name" jsonschema:"required"`           // slot id => dump file "<name>.sql"
-	Cluster          string                             `yaml:"cluster" jsonschema:"required"`        // clusterName (v1: same target as backup)
-	ServingCert      string                             `yaml:"servingCert" jsonschema:"required"`    // existing serving cert on the live target cluster
-	ClientCAIssuer   cmmeta.IssuerReference             `yaml:"clientCAIssuer" jsonschema:"required"` // issuer that mints the postgres user cert (name + kind + group)
-	PostgresUserCert cnpgrestore.CNPGRestoreOptionsCert `yaml:"postgresUserCert,omitempty"`
+	Name              string                             `yaml:"name" jsonschema:"required"`    // slot id => dump "<name>.sql" (or directory "<name>.dump")
+	Cluster           string                             `yaml:"cluster" jsonschema:"required"` // clusterName (v1: same target as backup); the new cluster when physical
+	ServingCert       string                             `yaml:"servingCert,omitempty"`         // existing serving cert on the live target cluster (required when logical)
+	ClientCAIssuer    cmmeta.IssuerReference             `yaml:"clientCAIssuer,omitempty"`      // issuer that mints the postgres user cert (name + kind + group) (required when logical)
+	PostgresUserCert  cnpgrestore.CNPGRestoreOptionsCert `yaml:"postgresUserCert,omitempty"`
+	Format            postgres.DumpFormat                `yaml:"format,omitempty"` // "plain" (default) or "directory"
+	Jobs              int                                `yaml:"jobs,omitempty"`   // directory format only
+	StopOnError       bool                               `yaml:"stopOnError,omitempty"`
+	SingleTransaction bool                               `yaml:"singleTransaction,omitempty"` // per database; not with jobs
+	DropAndRecreate   bool                               `yaml:"dropAndRecreate,omitempty"`
+	Force             bool                               `yaml:"force,omitempty"` // allow replacing databases that aren't empty
+	Mode              cnpgbackup.BackupMode              `yaml:"mode,omitempty"`  // "logical" (default) or "physical", matching the backup source
+	// ClusterCloning configures how the new cluster is created from the base backup (physical only).
+	ClusterCloning clonedcluster.CloneClusterOptions `yaml:"clusterCloning,omitempty"`
+
+	postgres.RestoreSelection `yaml:",inline"` // databases, schemas, tables, targetDatabase
+}
+
+// isPhysical reports whether the source restores a physical backup.
+func (src GenericPostgresRestoreSource) isPhysical() bool {
+	return src.Mode == cnpgbackup.BackupModePhysical
+}
+
+// GenericPostgresServer locates a postgres server that isn't managed by CNPG, such as a managed cloud
+// database, and how to authenticate with it. The password is read from PasswordSecretRef (the "password" key,
+// unless mapped) when the event starts. ClientCertSecret instead (or as well) names a Secret in the namespace
+// holding a client cert (tls.crt and tls.key), which is mounted into the backup-tool instance. ServingCASecret
+// optionally names a Secret in the namespace holding the CA cert (ca.crt) that the server's cert is verified
+// against, which SSLMode "verify-ca" or "verify-full" requires.
+type GenericPostgresServer struct {
+	Host              string     `yaml:"host" jsonschema:"required"`
+	Port              int        `yaml:"port,omitempty"` // 5432 by default
+	User              string     `yaml:"user" jsonschema:"required"`
+	PasswordSecretRef *SecretRef `yaml:"passwordSecretRef,omitempty"`
+	ClientCertSecret  string     `yaml:"clientCertSecret,omitempty"`
+	ServingCASecret   string     `yaml:"servingCASecret,omitempty"`
+	SSLMode           string     `yaml:"sslMode,omitempty"` // libpq sslmode, "prefer" by default
+}
+
+// connection returns the connection to the server, given the password read from PasswordSecretRef.
+func (s GenericPostgresServer) connection(password string) pgservercommon.Connection {
+	return pgservercommon.Connection{
+		Host:             s.Host,
+		Port:             s.Port,
+		User:             s.User,
+		Password:         password,
+		SSLMode:          s.SSLMode,
+		ClientCertSecret: s.ClientCertSecret,
+		ServingCASecret:  s.ServingCASecret,
+	}
+}
+
+func (s GenericPostgresServer) validate() error {
+	if s.PasswordSecretRef != nil {
+		if err := s.PasswordSecretRef.validate(postgresCredentialFields...); err != nil {
+			return trace.Wrap(err, "invalid passwordSecretRef")
+		}
+	}
+
+	// The password is only read when the event starts, so stand in for it to check the rest of the connection.
+	password := ""
+	if s.PasswordSecretRef != nil {
+		password = "unresolved"
+	}
+
+	return trace.Wrap(s.connection(password).Validate())
+}
+
+// GenericPostgresServerBackupSource logically dumps a postgres server that isn't managed by CNPG to the DR
+// volume, directly from the backup-tool instance. Nothing is cloned, so the dump is taken from the live server:
+// each database is dumped from a consistent snapshot, but the dump is not part of the event's consistency
+// point. The dump options are the same as for a CNPG cluster; like there, the "postgres" database is left out
+// unless it is included explicitly.
+type GenericPostgresServerBackupSource struct {
+	Name   string                `yaml:"name" jsonschema:"required"` // slot id => dump "<name>.sql" (or directory "<name>.dump")
+	Format postgres.DumpFormat   `yaml:"format,omitempty"`           // "plain" (default) or "directory"
+	Jobs   int                   `yaml:"jobs,omitempty"`             // directory format only
+	Roles  postgres.RoleHandling `yaml:"roles,omitempty"`            // renames require the plain format
+
+	GenericPostgresServer      `yaml:",inline"` // host, port, user, passwordSecretRef, clientCertSecret, servingCASecret, sslMode
+	postgres.DatabaseSelection `yaml:",inline"` // includeDatabases, excludeDatabases, databases
+}
+
+// GenericPostgresServerRestoreSource logically restores a dump from the DR volume into a postgres server that
+// isn't managed by CNPG, directly from the backup-tool instance. The restore options are the same as for a
+// CNPG cluster.
+type GenericPostgresServerRestoreSource struct {
+	Name              string              `yaml:"name" jsonschema:"required"` // slot id => dump "<name>.sql" (or directory "<name>.dump")
+	Format            postgres.DumpFormat `yaml:"format,omitempty"`           // "plain" (default) or "directory"
+	Jobs              int                 `yaml:"jobs,omitempty"`             // directory format only
+	StopOnError       bool                `yaml:"stopOnError,omitempty"`
+	SingleTransaction bool                `yaml:"singleTransaction,omitempty"` // per database; not with jobs
+	DropAndRecreate   bool                `yaml:"dropAndRecreate,omitempty"`
+	Force             bool                `yaml:"force,omitempty"` // allow replacing databases that aren't empty
+
+	GenericPostgresServer     `yaml:",inline"` // host, port, user, passwordSecretRef, clientCertSecret, servingCASecret, sslMode
+	postgres.RestoreSelection `yaml:",inline"` // databases, schemas, tables, targetDatabase
+}
+
+// GenericMySQLServer locates a MySQL or MariaDB server, and how to authenticate with it. The credentials are
+// read from CredentialsSecretRef when the event starts: the password from the "password" key and the user
+// from the "user" key (unless mapped), falling back to User when the Secret doesn't hold one. ServingCASecret
+// optionally names a Secret in the namespace holding the CA cert (ca.crt) that the server's cert is verified
+// against, rather than the system's CA certs, which is mounted into the backup-tool instance.
+type GenericMySQLServer struct {
+	Host                 string        `yaml:"host" jsonschema:"required"`
+	Port                 int           `yaml:"port,omitempty"` // 3306 by default
+	User                 string        `yaml:"user,omitempty"`
+	CredentialsSecretRef *SecretRef    `yaml:"credentialsSecretRef" jsonschema:"required"`
+	ServingCASecret      string        `yaml:"servingCASecret,omitempty"`
+	SSLMode              mysql.SSLMode `yaml:"sslMode,omitempty"` // "DISABLED", "REQUIRED" or "VERIFY_IDENTITY"; the client's default otherwise
+}
+
+// credentials returns the credentials for the server, before the secret's values are applied.
+func (s GenericMySQLServer) credentials() mysql.Credentials {
+	return mysql.Credentials{
+		Host:    s.Host,
+		Port:    s.Port,
+		User:    s.User,
+		SSLMode: s.SSLMode,
+	}
+}
+
+func (s GenericMySQLServer) validate() error {
+	if s.CredentialsSecretRef == nil {
+		return trace.BadParameter("credentialsSecretRef is required")
+	}
+	if err := s.CredentialsSecretRef.validate(mysqlCredentialFields...); err != nil {
+		return trace.Wrap(err, "invalid credentialsSecretRef")
+	}
+
+	// The user may only be read when the event starts, and the CA cert is only mounted into the backup-tool
+	// instance, so stand in for them to check the rest of the credentials.
+	creds := s.credentials()
+	if creds.User == "" {
+		creds.User = "unresolved"
+	}
+	if s.ServingCASecret != "" {
+		creds.SSLCAFilePath = "unresolved"
+	}
+
+	return trace.Wrap(creds.Validate())
+}
+
+// GenericMySQLBackupSource logically dumps a MySQL or MariaDB server to the DR volume, directly from the
+// backup-tool instance. Like a postgresServer source, the dump is taken from the live server and is not part
+// of the event's consistency point. By default every database other than the system ones is dumped from a
+// single transaction, which is consistent for InnoDB tables without blocking writes; Consistency
+// "lock-all-tables" instead blocks writes to the server for the duration of the dump, which non-transactional
+// tables need.
+type GenericMySQLBackupSource struct {
+	Name                 string            `yaml:"name" jsonschema:"required"`     // slot id => dump "<name>.mysql.sql"
+	Consistency          mysql.Consistency `yaml:"consistency,omitempty"`          // "single-transaction" (default), "lock-all-tables" or "none"
+	RecordBinlogPosition bool              `yaml:"recordBinlogPosition,omitempty"` // requires the RELOAD privilege
+	IncludeDatabases     []string          `yaml:"includeDatabases,omitempty"`
+	ExcludeDatabases     []string          `yaml:"excludeDatabases,omitempty"`
+	ExcludeTables        []string          `yaml:"excludeTables,omitempty"` // "database.table"
+
+	GenericMySQLServer `yaml:",inline"` // host, port, user, credentialsSecretRef, servingCASecret, sslMode
+}
+
+// GenericMySQLRestoreSource replays a dump from the DR volume against a MySQL or MariaDB server, directly from
+// the backup-tool instance. The dump drops and recreates each of the databases that it holds. Force carries
+// on past failed statements, rather than stopping at the first one.
+type GenericMySQLRestoreSource struct {
+	Name  string `yaml:"name" jsonschema:"required"` // slot id => dump "<name>.mysql.sql"
+	Force bool   `yaml:"force,omitempty"`
+
+	GenericMySQLServer `yaml:",inline"` // host, port, user, credentialsSecretRef, servingCASecret, sslMode
+}
+
+// GenericRedisServer locates a Redis or Valkey server, and how to authenticate with it. Servers without
+// authentication need no CredentialsSecretRef. Otherwise the credentials are read from it when the event
+// starts: the password from the "password" key and the ACL user from the "user" key (unless mapped), falling
+// back to User (or the default user) when the Secret doesn't hold one.
+type GenericRedisServer struct {
+	Host                 string     `yaml:"host" jsonschema:"required"`
+	Port                 int        `yaml:"port,omitempty"` // 6379 by default
+	User                 string     `yaml:"user,omitempty"`
+	CredentialsSecretRef *SecretRef `yaml:"credentialsSecretRef,omitempty"`
+}
+
+// credentials returns the credentials for the server, before the secret's values are applied.
+func (s GenericRedisServer) credentials() redis.Credentials {
+	return redis.Credentials{
+		Host: s.Host,
+		Port: s.Port,
+		User: s.User,
+	}
+}
+
+func (s GenericRedisServer) validate() error {
+	if s.User != "" && s.CredentialsSecretRef == nil {
+		return trace.BadParameter("a credentialsSecretRef is required when a user is set")
+	}
+
+	creds := s.credentials()
+	if s.CredentialsSecretRef != nil {
+		if err := s.CredentialsSecretRef.validate(redisCredentialFields...); err != nil {
+			return trace.Wrap(err, "invalid credentialsSecretRef")
+		}
+
+		// The password is only read when the event starts, so stand in for it to check the rest of the
+		// credentials.
+		creds.Password = "unresolved"
+	}
+
+	return trace.Wrap(creds.Validate())
+}
+
+// GenericRedisBackupSource streams an RDB snapshot of a Redis or Valkey server to the DR volume, directly
+// from the backup-tool instance, the same way that a replica syncs. The server forks to take the snapshot,
+// so unlike a files capture of its PVC it is always consistent, but like a postgresServer source it is taken
+// from the live server and is not part of the event's consistency point. The user must be allowed to run the
+// SYNC and REPLCONF commands.
+type GenericRedisBackupSource struct {
+	Name string `yaml:"name" jsonschema:"required"` // slot id => snapshot "<name>.rdb"
+
+	GenericRedisServer `yaml:",inline"` // host, port, user, credentialsSecretRef
+}
+
+// GenericRedisRestoreSource places an RDB snapshot from the DR volume onto the PVC that a Redis or Valkey
+// server loads it from, replacing the snapshot that is there. Like a files source, the PVC must already
+// exist and the server must not be running, so that it loads the snapshot when it next starts. Servers with
+// the append only file enabled load that instead, so it must be removed from the PVC first (the restore fails
+// if one is found next to the snapshot path).
+type GenericRedisRestoreSource struct {
+	Name        string `yaml:"name" jsonschema:"required"` // slot id => snapshot "<name>.rdb"
+	PVC         string `yaml:"pvc" jsonschema:"required"`
+	RDBFilePath string `yaml:"rdbFilePath,omitempty"` // relative to the root of the PVC; "dump.rdb" by default
 }
 
 // GenericBackupVolume configures the DR volume and its snapshot for a backup event.
@@ -117,16 +468,20 @@ type GenericBackupVolume struct {
 }
 
 // GenericBackupConfig is the declarative backup config for the generic app. A backup produces the event
-// named backupName.
+// named backupName. Export optionally copies the backup's snapshot to object storage once it is taken.
 type GenericBackupConfig struct {
-	Namespace      string                         `yaml:"namespace" jsonschema:"required"`
-	BackupName     string                         `yaml:"backupName" jsonschema:"required"`
-	BackupVolume   GenericBackupVolume            `yaml:"backupVolume,omitempty"`
-	CleanupTimeout helpers.MaxWaitTime            `yaml:"cleanupTimeout,omitempty"`
-	Postgres       []GenericPostgresBackupSource  `yaml:"postgres,omitempty"`
-	Files          []GenericFilesBackupSource     `yaml:"files,omitempty"`
-	FileGroups     []GenericFileGroupBackupSource `yaml:"fileGroups,omitempty"`
-	S3             []GenericS3Source              `yaml:"s3,omitempty"`
+	Namespace       string                              `yaml:"namespace" jsonschema:"required"`
+	BackupName      string                              `yaml:"backupName" jsonschema:"required"`
+	BackupVolume    GenericBackupVolume                 `yaml:"backupVolume,omitempty"`
+	CleanupTimeout  helpers.MaxWaitTime                 `yaml:"cleanupTimeout,omitempty"`
+	Postgres        []GenericPostgresBackupSource       `yaml:"postgres,omitempty"`
+	PostgresServers []GenericPostgresServerBackupSource `yaml:"postgresServers,omitempty"`
+	MySQL           []GenericMySQLBackupSource          `yaml:"mysql,omitempty"`
+	Redis           []GenericRedisBackupSource          `yaml:"redis,omitempty"`
+	Files           []GenericFilesBackupSource          `yaml:"files,omitempty"`
+	FileGroups      []GenericFileGroupBackupSource      `yaml:"fileGroups,omitempty"`
+	S3              []GenericS3BackupSource             `yaml:"s3,omitempty"`
+	Export          *OffsiteExport                      `yaml:"export,omitempty"`
 }
 
 // GenericRestoreConfig is the declarative restore config for the generic app. A restore reads the DR PVC
@@ -134,13 +489,16 @@ type GenericBackupConfig struct {
 // out-of-band, as for the per-app restores). v1 restores in place — the targets are the same resources
 // the backup captured.
 type GenericRestoreConfig struct {
-	Namespace      string                         `yaml:"namespace" jsonschema:"required"`
-	BackupName     string                         `yaml:"backupName" jsonschema:"required"`
-	CleanupTimeout helpers.MaxWaitTime            `yaml:"cleanupTimeout,omitempty"`
-	Postgres       []GenericPostgresRestoreSource `yaml:"postgres,omitempty"`
-	Files          []GenericFilesSource           `yaml:"files,omitempty"`
-	FileGroups     []GenericFileGroupSource       `yaml:"fileGroups,omitempty"`
-	S3             []GenericS3Source              `yaml:"s3,omitempty"`
+	Namespace       string                               `yaml:"namespace" jsonschema:"required"`
+	BackupName      string                               `yaml:"backupName" jsonschema:"required"`
+	CleanupTimeout  helpers.MaxWaitTime                  `yaml:"cleanupTimeout,omitempty"`
+	Postgres        []GenericPostgresRestoreSource       `yaml:"postgres,omitempty"`
+	PostgresServers []GenericPostgresServerRestoreSource `yaml:"postgresServers,omitempty"`
+	MySQL           []GenericMySQLRestoreSource          `yaml:"mysql,omitempty"`
+	Redis           []GenericRedisRestoreSource          `yaml:"redis,omitempty"`
+	Files           []GenericFilesSource                 `yaml:"files,omitempty"`
+	FileGroups      []GenericFileGroupSource             `yaml:"fileGroups,omitempty"`
+	S3              []GenericS3RestoreSource             `yaml:"s3,omitempty"`
 }
 
 // Validation. The shared config-load path (features.ConfigFileCommand.validateConfig) runs go-playground
@@ -168,6 +526,50 @@ func validateSlotName(kind, name string) error {
 	return nil
 }
 
+// validatePostgresServerSlot checks a postgresServer source's slot name against the postgres slot names seen
+// so far (recording it), and its connection.
+func validatePostgresServerSlot(pgNames map[string]struct{}, name string, server GenericPostgresServer) error {
+	if err := validateSlotName("postgresServer", name); err != nil {
+		return trace.Wrap(err)
+	}
+	if _, dup := pgNames[name]; dup {
+		return trace.BadParameter("duplicate postgres slot name %q (collides on the SQL dump file)", name)
+	}
+	pgNames[name] = struct{}{}
+	if err := server.validate(); err != nil {
+		return trace.Wrap(err, "postgresServer source %q", name)
+	}
+	return nil
+}
+
+// validateMySQLSlot checks a mysql source's slot name against the mysql slot names seen so far (recording it),
+// and its server.
+func validateMySQLSlot(mysqlNames map[string]struct{}, name string, server GenericMySQLServer) error {
+	if err := validateSlotName("mysql", name); err != nil {
+		return trace.Wrap(err)
+	}
+	if _, dup := mysqlNames[name]; dup {
+		return trace.BadParameter("duplicate mysql slot name %q (collides on the SQL dump file)", name)
+	}
+	mysqlNames[name] = struct{}{}
+	if err := server.validate(); err != nil {
+		return trace.Wrap(err, "mysql source %q", name)
+	}
+	return nil
+}
+
+// validateRedisSlot checks a redis source's slot name against the redis slot names seen so far (recording it).
+func validateRedisSlot(redisNames map[string]struct{}, name string) error {
+	if err := validateSlotName("redis", name); err != nil {
+		return trace.Wrap(err)
+	}
+	if _, dup := redisNames[name]; dup {
+		return trace.BadParameter("duplicate redis slot name %q (collides on the RDB snapshot file)", name)
+	}
+	redisNames[name] = struct{}{}
+	return nil
+}
+
 func validateFilesSources(files []GenericFilesSource) error {
 	seen := make(map[string]struct{}, len(files))
 	for _, src := range files {
@@ -181,6 +583,12 @@ func validateFilesSources(files []GenericFilesSource) error {
 		if src.PVC == "" {
 			return trace.BadParameter("files source %q: pvc is required", src.Name)
 		}
+		if err := src.RateLimit.Validate(); err != nil {
+			return trace.Wrap(err, "files source %q: invalid rateLimit", src.Name)
+		}
+		if src.Parallelism < 0 {
+			return trace.BadParameter("files source %q: parallelism must not be negative", src.Name)
+		}
 	}
 	return nil
 }
@@ -205,6 +613,12 @@ func validateFileGroupSources(groups []GenericFileGroupSource) error {
 		if isEmptyLabelSelector(src.Selector) {
 			return trace.BadParameter("fileGroup source %q: selector must match on at least one label or expression (an empty selector would match every PVC in the namespace)", src.Name)
 		}
+		if err := src.RateLimit.Validate(); err != nil {
+			return trace.Wrap(err, "fileGroup source %q: invalid rateLimit", src.Name)
+		}
+		if src.Parallelism < 0 {
+			return trace.BadParameter("fileGroup source %q: parallelism must not be negative", src.Name)
+		}
 	}
 	return nil
 }
@@ -222,15 +636,63 @@ func validateS3Sources(s3Sources []GenericS3Source) error {
 		if src.Path == "" {
 			return trace.BadParameter("s3 source %q: path is required", src.Name)
 		}
-		// Credentials are optional (empty => AWS env-var fallback), so nothing to validate beyond the path.
+		if err := src.RateLimit.Validate(); err != nil {
+			return trace.Wrap(err, "s3 source %q: invalid rateLimit", src.Name)
+		}
+		if err := src.Multipart.Validate(); err != nil {
+			return trace.Wrap(err, "s3 source %q: invalid multipart", src.Name)
+		}
+		if err := src.FileFilter.Validate(); err != nil {
+			return trace.Wrap(err, "s3 source %q has an invalid include/exclude filter", src.Name)
+		}
+		// Credentials are optional (empty => AWS env-var fallback), but can't mix inline keys with a secret.
+		if err := validateS3CredentialsSecretRef(src.Credentials, src.CredentialsSecretRef); err != nil {
+			return trace.Wrap(err, "s3 source %q", src.Name)
+		}
 	}
 	return nil
 }
 
+// s3Sources returns the direction-independent part of each s3 source.
+func (c GenericBackupConfig) s3Sources() []GenericS3Source {
+	s3Sources := make([]GenericS3Source, len(c.S3))
+	for i := range c.S3 {
+		s3Sources[i] = c.S3[i].GenericS3Source
+	}
+	return s3Sources
+}
+
+// postgresServers returns the server that each postgresServer source connects to.
+func (c GenericBackupConfig) postgresServers() []GenericPostgresServer {
+	servers := make([]GenericPostgresServer, len(c.PostgresServers))
+	for i := range c.PostgresServers {
+		servers[i] = c.PostgresServers[i].GenericPostgresServer
+	}
+	return servers
+}
+
+// mysqlServers returns the server that each mysql source connects to.
+func (c GenericBackupConfig) mysqlServers() []GenericMySQLServer {
+	servers := make([]GenericMySQLServer, len(c.MySQL))
+	for i := range c.MySQL {
+		servers[i] = c.MySQL[i].GenericMySQLServer
+	}
+	return servers
+}
+
+// redisServers returns the server that each redis source connects to.
+func (c GenericBackupConfig) redisServers() []GenericRedisServer {
+	servers := make([]GenericRedisServer, len(c.Redis))
+	for i := range c.Redis {
+		servers[i] = c.Redis[i].GenericRedisServer
+	}
+	return servers
+}
+
 // Validate enforces the cross-field and per-source rules for a backup config.
 func (c GenericBackupConfig) Validate() error {
-	if len(c.Postgres)+len(c.Files)+len(c.FileGroups)+len(c.S3) == 0 {
-		return trace.BadParameter("at least one source (postgres, files, fileGroups, or s3) must be configured")
+	if len(c.Postgres)+len(c.PostgresServers)+len(c.MySQL)+len(c.Redis)+len(c.Files)+len(c.FileGroups)+len(c.S3) == 0 {
+		return trace.BadParameter("at least one source (postgres, postgresServers, mysql, redis, files, fileGroups, or s3) must be configured")
 	}
 
 	pgNames := make(map[string]struct{}, len(c.Postgres))
@@ -245,9 +707,48 @@ func (c GenericBackupConfig) Validate() error {
 		if src.Cluster == "" {
 			return trace.BadParameter("postgres source %q: cluster is required", src.Name)
 		}
+		if err := src.backupOptions(0).Validate(); err != nil {
+			return trace.Wrap(err, "postgres source %q", src.Name)
+		}
 		// The clone's serving and client-CA certs are minted from an internally-created self-signed
 		// issuer, so there is no issuer to require here.
 	}
+	// Server dumps land beside the cluster dumps, so the two kinds share slot names.
+	for _, src := range c.PostgresServers {
+		if err := validatePostgresServerSlot(pgNames, src.Name, src.GenericPostgresServer); err != nil {
+			return trace.Wrap(err)
+		}
+		if err := (postgres.DumpAllOptions{Format: src.Format, Jobs: src.Jobs, Selection: src.DatabaseSelection, Roles: src.Roles}).Validate(); err != nil {
+			return trace.Wrap(err, "postgresServer source %q", src.Name)
+		}
+	}
+
+	mysqlNames := make(map[string]struct{}, len(c.MySQL))
+	for _, src := range c.MySQL {
+		if err := validateMySQLSlot(mysqlNames, src.Name, src.GenericMySQLServer); err != nil {
+			return trace.Wrap(err)
+		}
+		dumpOpts := mysql.DumpOptions{
+			Consistency:          src.Consistency,
+			RecordBinlogPosition: src.RecordBinlogPosition,
+			IncludeDatabases:     src.IncludeDatabases,
+			ExcludeDatabases:     src.ExcludeDatabases,
+			ExcludeTables:        src.ExcludeTables,
+		}
+		if err := dumpOpts.Validate(); err != nil {
+			return trace.Wrap(err, "mysql source %q", src.Name)
+		}
+	}
+
+	redisNames := make(map[string]struct{}, len(c.Redis))
+	for _, src := range c.Redis {
+		if err := validateRedisSlot(redisNames, src.Name); err != nil {
+			return trace.Wrap(err)
+		}
+		if err := src.GenericRedisServer.validate(); err != nil {
+			return trace.Wrap(err, "redis source %q", src.Name)
+		}
+	}
 
 	filesSources := make([]GenericFilesSource, len(c.Files))
 	for i := range c.Files {
@@ -260,6 +761,9 @@ func (c GenericBackupConfig) Validate() error {
 		if err := src.FileFilter.Validate(); err != nil {
 			return trace.Wrap(err, "files source %q has an invalid include/exclude filter", src.Name)
 		}
+		if err := files.ValidateSQLiteDatabasePaths(src.SQLiteDatabases, src.FileFilter); err != nil {
+			return trace.Wrap(err, "files source %q has invalid sqliteDatabases", src.Name)
+		}
 	}
 
 	fileGroupSources := make([]GenericFileGroupSource, len(c.FileGroups))
@@ -274,24 +778,57 @@ func (c GenericBackupConfig) Validate() error {
 			return trace.Wrap(err, "fileGroup source %q has an invalid include/exclude filter", src.Name)
 		}
 	}
-	if err := validateS3Sources(c.S3); err != nil {
+	if err := validateS3Sources(c.s3Sources()); err != nil {
 		return trace.Wrap(err)
 	}
 
 	// A files source contributes its source PVC's requested storage, but postgres, S3, and fileGroup sources
 	// have no well-defined size contribution (a fileGroup's membership is selector-resolved and variable), so
 	// size must be set explicitly whenever the config has any of them.
-	if (len(c.Postgres) > 0 || len(c.S3) > 0 || len(c.FileGroups) > 0) && c.BackupVolume.Size.IsZero() {
-		return trace.BadParameter("backupVolume.size is required when the config has postgres, s3, or fileGroup sources (their size cannot be inferred)")
+	if (len(c.Postgres) > 0 || len(c.PostgresServers) > 0 || len(c.MySQL) > 0 || len(c.Redis) > 0 || len(c.S3) > 0 || len(c.FileGroups) > 0) && c.BackupVolume.Size.IsZero() {
+		return trace.BadParameter("backupVolume.size is required when the config has postgres, postgresServer, mysql, redis, s3, or fileGroup sources (their size cannot be inferred)")
+	}
+
+	if c.Export != nil {
+		if err := c.Export.validate(); err != nil {
+			return trace.Wrap(err, "invalid export")
+		}
 	}
 
 	return nil
 }
 
+// s3Sources returns the direction-independent part of each s3 source.
+func (c GenericRestoreConfig) s3Sources() []GenericS3Source {
+	s3Sources := make([]GenericS3Source, len(c.S3))
+	for i := range c.S3 {
+		s3Sources[i] = c.S3[i].GenericS3Source
+	}
+	return s3Sources
+}
+
+// postgresServers returns the server that each postgresServer source connects to.
+func (c GenericRestoreConfig) postgresServers() []GenericPostgresServer {
+	servers := make([]GenericPostgresServer, len(c.PostgresServers))
+	for i := range c.PostgresServers {
+		servers[i] = c.PostgresServers[i].GenericPostgresServer
+	}
+	return servers
+}
+
+// mysqlServers returns the server that each mysql source connects to.
+func (c GenericRestoreConfig) mysqlServers() []GenericMySQLServer {
+	servers := make([]GenericMySQLServer, len(c.MySQL))
+	for i := range c.MySQL {
+		servers[i] = c.MySQL[i].GenericMySQLServer
+	}
+	return servers
+}
+
 // Validate enforces the cross-field and per-source rules for a restore config.
 func (c GenericRestoreConfig) Validate() error {
-	if len(c.Postgres)+len(c.Files)+len(c.FileGroups)+len(c.S3) == 0 {
-		return trace.BadParameter("at least one source (postgres, files, fileGroups, or s3) must be configured")
+	if len(c.Postgres)+len(c.PostgresServers)+len(c.MySQL)+len(c.Redis)+len(c.Files)+len(c.FileGroups)+len(c.S3) == 0 {
+		return trace.BadParameter("at least one source (postgres, postgresServers, mysql, redis, files, fileGroups, or s3) must be configured")
 	}
 
 	pgNames := make(map[string]struct{}, len(c.Postgres))
@@ -306,12 +843,76 @@ func (c GenericRestoreConfig) Validate() error {
 		if src.Cluster == "" {
 			return trace.BadParameter("postgres source %q: cluster is required", src.Name)
 		}
+		switch src.Mode {
+		case "", cnpgbackup.BackupModeLogical:
+		case cnpgbackup.BackupModePhysical:
+			if err := validatePhysicalPostgresRestoreSource(src); err != nil {
+				return trace.Wrap(err, "postgres source %q", src.Name)
+			}
+			continue
+		default:
+			return trace.BadParameter("postgres source %q: invalid mode %q (must be %q or %q)", src.Name, src.Mode, cnpgbackup.BackupModeLogical, cnpgbackup.BackupModePhysical)
+		}
 		if src.ClientCAIssuer.Name == "" {
 			return trace.BadParameter("postgres source %q: clientCAIssuer.name is required", src.Name)
 		}
 		if src.ServingCert == "" {
 			return trace.BadParameter("postgres source %q: servingCert is required", src.Name)
 		}
+		if src.ClusterCloning != (clonedcluster.CloneClusterOptions{}) {
+			return trace.BadParameter("postgres source %q: clusterCloning is only supported by the %q mode", src.Name, cnpgbackup.BackupModePhysical)
+		}
+		restoreOpts := postgres.RestoreOptions{
+			Format:            src.Format,
+			Jobs:              src.Jobs,
+			Selection:         src.RestoreSelection,
+			StopOnError:       src.StopOnError,
+			SingleTransaction: src.SingleTransaction,
+			DropAndRecreate:   src.DropAndRecreate,
+			Force:             src.Force,
+		}
+		if err := restoreOpts.Validate(); err != nil {
+			return trace.Wrap(err, "postgres source %q", src.Name)
+		}
+	}
+	for _, src := range c.PostgresServers {
+		if err := validatePostgresServerSlot(pgNames, src.Name, src.GenericPostgresServer); err != nil {
+			return trace.Wrap(err)
+		}
+		restoreOpts := postgres.RestoreOptions{
+			Format:            src.Format,
+			Jobs:              src.Jobs,
+			Selection:         src.RestoreSelection,
+			StopOnError:       src.StopOnError,
+			SingleTransaction: src.SingleTransaction,
+			DropAndRecreate:   src.DropAndRecreate,
+			Force:             src.Force,
+		}
+		if err := restoreOpts.Validate(); err != nil {
+			return trace.Wrap(err, "postgresServer source %q", src.Name)
+		}
+	}
+
+	mysqlNames := make(map[string]struct{}, len(c.MySQL))
+	for _, src := range c.MySQL {
+		if err := validateMySQLSlot(mysqlNames, src.Name, src.GenericMySQLServer); err != nil {
+			return trace.Wrap(err)
+		}
+	}
+
+	redisNames := make(map[string]struct{}, len(c.Redis))
+	for _, src := range c.Redis {
+		if err := validateRedisSlot(redisNames, src.Name); err != nil {
+			return trace.Wrap(err)
+		}
+		if src.PVC == "" {
+			return trace.BadParameter("redis source %q: pvc is required", src.Name)
+		}
+		if src.RDBFilePath != "" {
+			if err := redisrestore.ValidateRDBFilePath(src.RDBFilePath); err != nil {
+				return trace.Wrap(err, "redis source %q: invalid rdbFilePath", src.Name)
+			}
+		}
 	}
 
 	if err := validateFilesSources(c.Files); err != nil {
@@ -320,9 +921,38 @@ func (c GenericRestoreConfig) Validate() error {
 	if err := validateFileGroupSources(c.FileGroups); err != nil {
 		return trace.Wrap(err)
 	}
-	if err := validateS3Sources(c.S3); err != nil {
+	if err := validateS3Sources(c.s3Sources()); err != nil {
 		return trace.Wrap(err)
 	}
+	for _, src := range c.S3 {
+		switch src.Mode {
+		case "", s3sync.RestoreModeUpload:
+			if src.DryRun {
+				return trace.BadParameter("s3 source %q: dryRun is only supported by the %q mode", src.Name, s3sync.RestoreModeRewind)
+			}
+		case s3sync.RestoreModeRewind:
+		default:
+			return trace.BadParameter("s3 source %q: invalid mode %q (must be %q or %q)", src.Name, src.Mode, s3sync.RestoreModeUpload, s3sync.RestoreModeRewind)
+		}
+	}
+
+	return nil
+}
+
+// validatePhysicalPostgresRestoreSource checks a physical postgres restore source. The new cluster is created
+// rather than restored into, so none of the options of a logical restore apply.
+func validatePhysicalPostgresRestoreSource(src GenericPostgresRestoreSource) error {
+	if len(src.Cluster) > 40 { // Max length that CNPG allows for clusters bootstrapped from a backup
+		return trace.BadParameter("cluster %q must be 40 characters or less", src.Cluster)
+	}
+
+	if src.ServingCert != "" || src.ClientCAIssuer != (cmmeta.IssuerReference{}) || src.PostgresUserCert != (cnpgrestore.CNPGRestoreOptionsCert{}) {
+		return trace.BadParameter("the %q mode creates the cluster's certificates, so servingCert, clientCAIssuer and postgresUserCert are not supported", cnpgbackup.BackupModePhysical)
+	}
+
+	if src.Format != "" || src.Jobs != 0 || src.StopOnError || src.SingleTransaction || src.DropAndRecreate || src.Force || !src.RestoreSelection.IsZero() {
+		return trace.BadParameter("the %q mode does not support the options of a logical restore", cnpgbackup.BackupModePhysical)
+	}
 
 	return nil
 }
@@ -330,36 +960,73 @@ func (c GenericRestoreConfig) Validate() error {
 type GenericApp struct {
 	kubeClusterClient kubecluster.ClientInterface
 	// Testing injection
-	newCNPGBackup        func() cnpgbackup.CNPGBackupInterface
-	newCNPGRestore       func() cnpgrestore.CNPGRestoreInterface
-	newFilesBackup       func() filesbackup.FilesBackupInterface
-	newFilesRestore      func() filesrestore.FilesRestoreInterface
-	newFilesGroupBackup  func() filesgroupbackup.FilesGroupBackupInterface
-	newFilesGroupRestore func() filesgrouprestore.FilesGroupRestoreInterface
-	newS3Sync            func() s3sync.S3SyncInterface
-	newRemoteStage       func(kubeClusterClient kubecluster.ClientInterface, namespace, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface
+	newCNPGBackup          func() cnpgbackup.CNPGBackupInterface
+	newCNPGRestore         func() cnpgrestore.CNPGRestoreInterface
+	newCNPGPhysicalRestore func() physicalrestore.CNPGPhysicalRestoreInterface
+	newPGServerBackup      func() pgserverbackup.PGServerBackupInterface
+	newPGServerRestore     func() pgserverrestore.PGServerRestoreInterface
+	newMySQLBackup         func() mysqlbackup.MySQLBackupInterface
+	newMySQLRestore        func() mysqlrestore.MySQLRestoreInterface
+	newRedisBackup         func() redisbackup.RedisBackupInterface
+	newRedisRestore        func() redisrestore.RedisRestoreInterface
+	newFilesBackup         func() filesbackup.FilesBackupInterface
+	newFilesRestore        func() filesrestore.FilesRestoreInterface
+	newFilesGroupBackup    func() filesgroupbackup.FilesGroupBackupInterface
+	newFilesGroupRestore   func() filesgrouprestore.FilesGroupRestoreInterface
+	newS3Sync              func() s3sync.S3SyncInterface
+	newExport              func() export.ExportInterface
+	newRemoteStage         func(kubeClusterClient kubecluster.ClientInterface, namespace, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface
 }
 
 func NewGenericApp(client kubecluster.ClientInterface) *GenericApp {
 	return &GenericApp{
-		kubeClusterClient:    client,
-		newCNPGBackup:        cnpgbackup.NewCNPGBackup,
-		newCNPGRestore:       cnpgrestore.NewCNPGRestore,
-		newFilesBackup:       filesbackup.NewFilesBackup,
-		newFilesRestore:      filesrestore.NewFilesRestore,
-		newFilesGroupBackup:  filesgroupbackup.NewFilesGroupBackup,
-		newFilesGroupRestore: filesgrouprestore.NewFilesGroupRestore,
-		newS3Sync:            s3sync.NewS3Sync,
-		newRemoteStage:       remote.NewRemoteStage,
+		kubeClusterClient:      client,
+		newCNPGBackup:          cnpgbackup.NewCNPGBackup,
+		newCNPGRestore:         cnpgrestore.NewCNPGRestore,
+		newCNPGPhysicalRestore: physicalrestore.NewCNPGPhysicalRestore,
+		newPGServerBackup:      pgserverbackup.NewPGServerBackup,
+		newPGServerRestore:     pgserverrestore.NewPGServerRestore,
+		newMySQLBackup:         mysqlbackup.NewMySQLBackup,
+		newMySQLRestore:        mysqlrestore.NewMySQLRestore,
+		newRedisBackup:         redisbackup.NewRedisBackup,
+		newRedisRestore:        redisrestore.NewRedisRestore,
+		newFilesBackup:         filesbackup.NewFilesBackup,
+		newFilesRestore:        filesrestore.NewFilesRestore,
+		newFilesGroupBackup:    filesgroupbackup.NewFilesGroupBackup,
+		newFilesGroupRestore:   filesgrouprestore.NewFilesGroupRestore,
+		newS3Sync:              s3sync.NewS3Sync,
+		newExport:              export.NewExport,
+		newRemoteStage:         remote.NewRemoteStage,
 	}
 }
 
-// dumpFileName is the on-disk SQL dump path for a postgres slot, derived from its slot name. Restore
-// re-derives the same path, so this single rule is the backup<->restore contract for postgres dumps.
-func dumpFileName(slotName string) string {
+// dumpFileName is the on-disk dump path for a postgres slot, derived from its slot name and dump format.
+// Restore re-derives the same path, so this single rule is the backup<->restore contract for postgres dumps.
+// Slot names can't contain '.', so neither path collides with another slot.
+func dumpFileName(slotName string, format postgres.DumpFormat) string {
+	if format == postgres.DumpFormatDirectory {
+		return slotName + ".dump"
+	}
 	return slotName + ".sql"
 }
 
+// physicalBackupManifestFileName is the on-disk path of the manifest that a physical backup of a postgres slot
+// writes in place of a dump. Slot names can't contain '.', so it doesn't collide with a dump or another slot.
+func physicalBackupManifestFileName(slotName string) string {
+	return slotName + ".physical.json"
+}
+
+// mysqlDumpFileName is the on-disk dump path for a mysql slot. Slot names can't contain '.', so it doesn't
+// collide with the postgres dumps or another slot.
+func mysqlDumpFileName(slotName string) string {
+	return slotName + ".mysql.sql"
+}
+
+// redisSnapshotFileName is the on-disk snapshot path for a redis slot.
+func redisSnapshotFileName(slotName string) string {
+	return slotName + ".rdb"
+}
+
 // resolveS3Credentials uses the inline credentials when supplied, otherwise the AWS environment variables.
 func resolveS3Credentials(creds s3.Credentials) s3.CredentialsInterface {
 	if creds == (s3.Credentials{}) {
@@ -368,16 +1035,113 @@ func resolveS3Credentials(creds s3.Credentials) s3.CredentialsInterface {
 	return &creds
 }
 
+// resolveS3SourceCredentials resolves the credentials of every s3 source, in order. It runs before any
+// resource is created, so that a missing or incomplete credentials secret fails the event early.
+func (g *GenericApp) resolveS3SourceCredentials(ctx *contexts.Context, namespace string, sources []GenericS3Source) ([]s3.CredentialsInterface, error) {
+	resolved := make([]s3.CredentialsInterface, 0, len(sources))
+	for _, src := range sources {
+		creds := src.Credentials
+		if src.CredentialsSecretRef != nil {
+			var err error
+			creds, err = ResolveS3CredentialsSecretRef(ctx, g.kubeClusterClient.Core(), namespace, creds, src.CredentialsSecretRef)
+			if err != nil {
+				return nil, trace.Wrap(err, "failed to resolve s3 source %q credentials", src.Name)
+			}
+		}
+
+		resolved = append(resolved, resolveS3Credentials(creds))
+	}
+
+	return resolved, nil
+}
+
+// resolvePostgresServerConnections returns the connection to each postgres server, in order, reading the
+// passwords from their secrets. Like resolveS3SourceCredentials, it runs before any resource is created.
+func (g *GenericApp) resolvePostgresServerConnections(ctx *contexts.Context, namespace string, servers []GenericPostgresServer) ([]pgservercommon.Connection, error) {
+	connections := make([]pgservercommon.Connection, 0, len(servers))
+	for _, server := range servers {
+		var password string
+		if server.PasswordSecretRef != nil {
+			var err error
+			password, err = ResolvePostgresPasswordSecretRef(ctx, g.kubeClusterClient.Core(), namespace, server.PasswordSecretRef)
+			if err != nil {
+				return nil, trace.Wrap(err, "failed to resolve the password for postgres server %q", server.Host)
+			}
+		}
+
+		connections = append(connections, server.connection(password))
+	}
+
+	return connections, nil
+}
+
+// resolveMySQLCredentials returns the credentials for each mysql server, in order, reading them from their
+// secrets. Like resolveS3SourceCredentials, it runs before any resource is created.
+func (g *GenericApp) resolveMySQLCredentials(ctx *contexts.Context, namespace string, servers []GenericMySQLServer) ([]mysql.Credentials, error) {
+	credentials := make([]mysql.Credentials, 0, len(servers))
+	for _, server := range servers {
+		creds, err := ResolveMySQLCredentialsSecretRef(ctx, g.kubeClusterClient.Core(), namespace, server.credentials(), server.CredentialsSecretRef)
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to resolve the credentials for mysql server %q", server.Host)
+		}
+
+		credentials = append(credentials, creds)
+	}
+
+	return credentials, nil
+}
+
+// resolveRedisCredentials returns the credentials for each redis server, in order, reading them from their
+// secrets (when they have one).
+func (g *GenericApp) resolveRedisCredentials(ctx *contexts.Context, namespace string, servers []GenericRedisServer) ([]redis.Credentials, error) {
+	credentials := make([]redis.Credentials, 0, len(servers))
+	for _, server := range servers {
+		creds, err := ResolveRedisCredentialsSecretRef(ctx, g.kubeClusterClient.Core(), namespace, server.credentials(), server.CredentialsSecretRef)
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to resolve the credentials for redis server %q", server.Host)
+		}
+
+		credentials = append(credentials, creds)
+	}
+
+	return credentials, nil
+}
+
 // Backup captures every configured source into the DR volume and snapshots it. Sources are registered in
-// a fixed kind order — postgres, then files, then fileGroups, then s3 — independent of their order in the
-// config. This is consistency-load-bearing: the postgres base backups must precede the filesystem freezes
-// (both files and fileGroups) that define the event's consistency point (see CLAUDE.md, RemoteStage
-// consistency-point protocol).
+// a fixed kind order — postgres, then postgresServers, then mysql, then redis, then files, then fileGroups,
+// then s3 — independent of their order in the config. This is consistency-load-bearing: the postgres base
+// backups must precede the filesystem freezes (both files and fileGroups) that define the event's
+// consistency point (see CLAUDE.md, RemoteStage consistency-point protocol).
 func (g *GenericApp) Backup(ctx *contexts.Context, config GenericBackupConfig) (backup *DREvent, err error) {
 	if err := config.Validate(); err != nil {
 		return nil, trace.Wrap(err, "invalid backup configuration")
 	}
 
+	s3Credentials, err := g.resolveS3SourceCredentials(ctx.Child(), config.Namespace, config.s3Sources())
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid backup configuration")
+	}
+
+	pgServerConnections, err := g.resolvePostgresServerConnections(ctx.Child(), config.Namespace, config.postgresServers())
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid backup configuration")
+	}
+
+	mysqlCredentials, err := g.resolveMySQLCredentials(ctx.Child(), config.Namespace, config.mysqlServers())
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid backup configuration")
+	}
+
+	redisCredentials, err := g.resolveRedisCredentials(ctx.Child(), config.Namespace, config.redisServers())
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid backup configuration")
+	}
+
+	offsiteExport, err := prepareExport(ctx.Child(), g.kubeClusterClient, config.Namespace, config.Export)
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid backup configuration")
+	}
+
 	backup = NewDREventNow(config.BackupName)
 	ctx.Log.With("backupName", backup.GetFullName(), "namespace", config.Namespace).Info("Starting backup process")
 	defer func() {
@@ -416,21 +1180,58 @@ func (g *GenericApp) Backup(ctx *contexts.Context, config GenericBackupConfig) (
 
 	for _, src := range config.Postgres {
 		action := g.newCNPGBackup()
-		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.Cluster, backup.Name, dumpFileName(src.Name), cnpgbackup.CNPGBackupOptions{
-			CloningOpts:    src.ClusterCloning,
-			CleanupTimeout: config.CleanupTimeout,
-		}); err != nil {
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.Cluster, backup.Name, src.backupFileName(), src.backupOptions(config.CleanupTimeout)); err != nil {
 			return backup, trace.Wrap(err, "failed to configure postgres source %q backup", src.Name)
 		}
 		stage.WithAction(fmt.Sprintf("postgres %q backup", src.Name), action)
 	}
 
+	for i, src := range config.PostgresServers {
+		action := g.newPGServerBackup()
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, pgServerConnections[i], backup.Name, dumpFileName(src.Name, src.Format), pgserverbackup.PGServerBackupOptions{
+			CleanupTimeout: config.CleanupTimeout,
+			Format:         src.Format,
+			Jobs:           src.Jobs,
+			Selection:      src.DatabaseSelection,
+			Roles:          src.Roles,
+		}); err != nil {
+			return backup, trace.Wrap(err, "failed to configure postgresServer source %q backup", src.Name)
+		}
+		stage.WithAction(fmt.Sprintf("postgresServer %q backup", src.Name), action)
+	}
+
+	for i, src := range config.MySQL {
+		action := g.newMySQLBackup()
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, mysqlCredentials[i], backup.Name, mysqlDumpFileName(src.Name), mysqlbackup.MySQLBackupOptions{
+			Consistency:          src.Consistency,
+			RecordBinlogPosition: src.RecordBinlogPosition,
+			IncludeDatabases:     src.IncludeDatabases,
+			ExcludeDatabases:     src.ExcludeDatabases,
+			ExcludeTables:        src.ExcludeTables,
+			ServingCASecret:      src.ServingCASecret,
+		}); err != nil {
+			return backup, trace.Wrap(err, "failed to configure mysql source %q backup", src.Name)
+		}
+		stage.WithAction(fmt.Sprintf("mysql %q backup", src.Name), action)
+	}
+
+	for i, src := range config.Redis {
+		action := g.newRedisBackup()
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, redisCredentials[i], backup.Name, redisSnapshotFileName(src.Name)); err != nil {
+			return backup, trace.Wrap(err, "failed to configure redis source %q backup", src.Name)
+		}
+		stage.WithAction(fmt.Sprintf("redis %q backup", src.Name), action)
+	}
+
 	for _, src := range config.Files {
 		action := g.newFilesBackup()
 		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.PVC, backup.Name, src.Name, filesbackup.FilesBackupOptions{
-			SnapshotClass:  src.SnapshotClass,
-			Filter:         src.FileFilter,
-			CleanupTimeout: config.CleanupTimeout,
+			SnapshotClass:   src.SnapshotClass,
+			Filter:          src.FileFilter,
+			RateLimit:       src.RateLimit,
+			Parallelism:     src.Parallelism,
+			CleanupTimeout:  config.CleanupTimeout,
+			SQLiteDatabases: src.SQLiteDatabases,
 		}); err != nil {
 			return backup, trace.Wrap(err, "failed to configure files source %q backup", src.Name)
 		}
@@ -442,6 +1243,8 @@ func (g *GenericApp) Backup(ctx *contexts.Context, config GenericBackupConfig) (
 		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.Selector, backup.Name, src.Name, filesgroupbackup.FilesGroupBackupOptions{
 			SnapshotClass:  src.SnapshotClass,
 			Filter:         src.FileFilter,
+			RateLimit:      src.RateLimit,
+			Parallelism:    src.Parallelism,
 			CleanupTimeout: config.CleanupTimeout,
 		}); err != nil {
 			return backup, trace.Wrap(err, "failed to configure fileGroup source %q backup", src.Name)
@@ -449,9 +1252,9 @@ func (g *GenericApp) Backup(ctx *contexts.Context, config GenericBackupConfig) (
 		stage.WithAction(fmt.Sprintf("fileGroup %q backup", src.Name), action)
 	}
 
-	for _, src := range config.S3 {
+	for i, src := range config.S3 {
 		action := g.newS3Sync()
-		if err := action.Configure(g.kubeClusterClient, config.Namespace, backup.Name, src.Name, src.Path, resolveS3Credentials(src.Credentials), s3sync.DirectionDownload, s3sync.S3SyncOptions{}); err != nil {
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, backup.Name, src.Name, src.Path, s3Credentials[i], s3sync.DirectionDownload, s3sync.S3SyncOptions{RateLimit: src.RateLimit, Multipart: src.Multipart, Rehash: src.Rehash, Filter: src.FileFilter}); err != nil {
 			return backup, trace.Wrap(err, "failed to configure s3 source %q backup", src.Name)
 		}
 		stage.WithAction(fmt.Sprintf("s3 %q sync", src.Name), action)
@@ -470,13 +1273,17 @@ func (g *GenericApp) Backup(ctx *contexts.Context, config GenericBackupConfig) (
 		return backup, trace.Wrap(err, "failed to snapshot the backup volume")
 	}
 
+	if err := offsiteExport.run(ctx.Child(), g.kubeClusterClient, config.Namespace, backup, g.newRemoteStage, g.newExport, config.CleanupTimeout); err != nil {
+		return backup, trace.Wrap(err, "failed to export the backup")
+	}
+
 	return backup, nil
 }
 
 // backupVolumeSize returns the explicit backupVolume.size when set. Otherwise (only reachable for a
-// files-only config — Validate requires an explicit size when postgres/s3/fileGroup sources are present) it
-// sums the files sources' PVC requests and doubles the total, the same sizing the per-app Vaultwarden backup
-// uses.
+// files-only config — Validate requires an explicit size when postgres/mysql/redis/s3/fileGroup sources are
+// present) it sums the files sources' PVC requests and doubles the total, the same sizing the per-app
+// Vaultwarden backup uses.
 func (g *GenericApp) backupVolumeSize(ctx *contexts.Context, config GenericBackupConfig) (resource.Quantity, error) {
 	if !config.BackupVolume.Size.IsZero() {
 		return config.BackupVolume.Size, nil
@@ -508,6 +1315,21 @@ func (g *GenericApp) Restore(ctx *contexts.Context, config GenericRestoreConfig)
 		return nil, trace.Wrap(err, "invalid restore configuration")
 	}
 
+	s3Credentials, err := g.resolveS3SourceCredentials(ctx.Child(), config.Namespace, config.s3Sources())
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid restore configuration")
+	}
+
+	pgServerConnections, err := g.resolvePostgresServerConnections(ctx.Child(), config.Namespace, config.postgresServers())
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid restore configuration")
+	}
+
+	mysqlCredentials, err := g.resolveMySQLCredentials(ctx.Child(), config.Namespace, config.mysqlServers())
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid restore configuration")
+	}
+
 	restore = NewDREventNow(config.BackupName)
 	ctx.Log.With("restoreName", restore.GetFullName(), "namespace", config.Namespace).Info("Starting restore process")
 	defer func() {
@@ -526,19 +1348,75 @@ func (g *GenericApp) Restore(ctx *contexts.Context, config GenericRestoreConfig)
 	})
 
 	for _, src := range config.Postgres {
+		if src.isPhysical() {
+			action := g.newCNPGPhysicalRestore()
+			if err := action.Configure(g.kubeClusterClient, config.Namespace, src.Cluster, restore.Name, physicalBackupManifestFileName(src.Name), physicalrestore.CNPGPhysicalRestoreOptions{
+				CloningOpts:    src.ClusterCloning,
+				CleanupTimeout: config.CleanupTimeout,
+			}); err != nil {
+				return restore, trace.Wrap(err, "failed to configure postgres source %q physical restoration", src.Name)
+			}
+			stage.WithAction(fmt.Sprintf("postgres %q physical restore", src.Name), action)
+			continue
+		}
+
 		action := g.newCNPGRestore()
-		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.Cluster, src.ServingCert, src.ClientCAIssuer, restore.Name, dumpFileName(src.Name), cnpgrestore.CNPGRestoreOptions{
-			PostgresUserCert: src.PostgresUserCert,
-			CleanupTimeout:   config.CleanupTimeout,
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.Cluster, src.ServingCert, src.ClientCAIssuer, restore.Name, dumpFileName(src.Name, src.Format), cnpgrestore.CNPGRestoreOptions{
+			PostgresUserCert:  src.PostgresUserCert,
+			CleanupTimeout:    config.CleanupTimeout,
+			Format:            src.Format,
+			Jobs:              src.Jobs,
+			Selection:         src.RestoreSelection,
+			StopOnError:       src.StopOnError,
+			SingleTransaction: src.SingleTransaction,
+			DropAndRecreate:   src.DropAndRecreate,
+			Force:             src.Force,
 		}); err != nil {
 			return restore, trace.Wrap(err, "failed to configure postgres source %q restoration", src.Name)
 		}
 		stage.WithAction(fmt.Sprintf("postgres %q restore", src.Name), action)
 	}
 
+	for i, src := range config.PostgresServers {
+		action := g.newPGServerRestore()
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, pgServerConnections[i], restore.Name, dumpFileName(src.Name, src.Format), pgserverrestore.PGServerRestoreOptions{
+			Format:            src.Format,
+			Jobs:              src.Jobs,
+			Selection:         src.RestoreSelection,
+			StopOnError:       src.StopOnError,
+			SingleTransaction: src.SingleTransaction,
+			DropAndRecreate:   src.DropAndRecreate,
+			Force:             src.Force,
+		}); err != nil {
+			return restore, trace.Wrap(err, "failed to configure postgresServer source %q restoration", src.Name)
+		}
+		stage.WithAction(fmt.Sprintf("postgresServer %q restore", src.Name), action)
+	}
+
+	for i, src := range config.MySQL {
+		action := g.newMySQLRestore()
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, mysqlCredentials[i], restore.Name, mysqlDumpFileName(src.Name), mysqlrestore.MySQLRestoreOptions{
+			Force:           src.Force,
+			ServingCASecret: src.ServingCASecret,
+		}); err != nil {
+			return restore, trace.Wrap(err, "failed to configure mysql source %q restoration", src.Name)
+		}
+		stage.WithAction(fmt.Sprintf("mysql %q restore", src.Name), action)
+	}
+
+	for _, src := range config.Redis {
+		action := g.newRedisRestore()
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.PVC, restore.Name, redisSnapshotFileName(src.Name), redisrestore.RedisRestoreOptions{
+			RDBFilePath: src.RDBFilePath,
+		}); err != nil {
+			return restore, trace.Wrap(err, "failed to configure redis source %q restoration", src.Name)
+		}
+		stage.WithAction(fmt.Sprintf("redis %q restore", src.Name), action)
+	}
+
 	for _, src := range config.Files {
 		action := g.newFilesRestore()
-		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.PVC, restore.Name, src.Name, filesrestore.FilesRestoreOptions{}); err != nil {
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.PVC, restore.Name, src.Name, filesrestore.FilesRestoreOptions{RateLimit: src.RateLimit, Parallelism: src.Parallelism}); err != nil {
 			return restore, trace.Wrap(err, "failed to configure files source %q restoration", src.Name)
 		}
 		stage.WithAction(fmt.Sprintf("files %q restore", src.Name), action)
@@ -546,15 +1424,21 @@ func (g *GenericApp) Restore(ctx *contexts.Context, config GenericRestoreConfig)
 
 	for _, src := range config.FileGroups {
 		action := g.newFilesGroupRestore()
-		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.Selector, restore.Name, src.Name, filesgrouprestore.FilesGroupRestoreOptions{}); err != nil {
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, src.Selector, restore.Name, src.Name, filesgrouprestore.FilesGroupRestoreOptions{RateLimit: src.RateLimit, Parallelism: src.Parallelism}); err != nil {
 			return restore, trace.Wrap(err, "failed to configure fileGroup source %q restoration", src.Name)
 		}
 		stage.WithAction(fmt.Sprintf("fileGroup %q restore", src.Name), action)
 	}
 
-	for _, src := range config.S3 {
+	for i, src := range config.S3 {
 		action := g.newS3Sync()
-		if err := action.Configure(g.kubeClusterClient, config.Namespace, restore.Name, src.Name, src.Path, resolveS3Credentials(src.Credentials), s3sync.DirectionUpload, s3sync.S3SyncOptions{}); err != nil {
+		if err := action.Configure(g.kubeClusterClient, config.Namespace, restore.Name, src.Name, src.Path, s3Credentials[i], s3sync.DirectionUpload, s3sync.S3SyncOptions{
+			RateLimit:   src.RateLimit,
+			Multipart:   src.Multipart,
+			RestoreMode: src.Mode,
+			DryRun:      src.DryRun,
+			Filter:      src.FileFilter,
+		}); err != nil {
 			return restore, trace.Wrap(err, "failed to configure s3 source %q restoration", src.Name)
 		}
 		stage.WithAction(fmt.Sprintf("s3 %q sync", src.Name), action)
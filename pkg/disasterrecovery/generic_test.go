@@ -9,20 +9,34 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
 	cnpgbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/backup"
+	physicalrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/physicalrestore"
 	cnpgrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/restore"
 	filesbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/backup"
 	filesgroupbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/groupbackup"
 	filesgrouprestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/grouprestore"
 	filesrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/restore"
+	mysqlbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/mysql/backup"
+	mysqlrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/mysql/restore"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/export"
+	pgserverbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/backup"
+	pgservercommon "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/common"
+	pgserverrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/pgserver/restore"
+	redisbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/redis/backup"
+	redisrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/redis/restore"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/s3sync"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clusterusercert"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/drvolume"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+	"github.com/solidDoWant/backup-tool/pkg/postgres"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -31,6 +45,42 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// validPostgresServer authenticates with a client cert, so that no password secret is read.
+func validPostgresServer() GenericPostgresServer {
+	return GenericPostgresServer{
+		Host:             "db.example.com",
+		User:             "postgres",
+		ClientCertSecret: "db-client-cert",
+		ServingCASecret:  "db-serving-ca",
+		SSLMode:          "verify-full",
+	}
+}
+
+// validMySQLServer reads its password (and user) from a secret, which is always required for mysql servers.
+func validMySQLServer() GenericMySQLServer {
+	return GenericMySQLServer{
+		Host:                 "mariadb.example.com",
+		User:                 "backup",
+		CredentialsSecretRef: &SecretRef{Name: "mariadb-credentials"},
+		ServingCASecret:      "mariadb-ca",
+		SSLMode:              mysql.SSLModeVerifyIdentity,
+	}
+}
+
+// expectMySQLCredentialsSecret serves the secret that validMySQLServer references.
+func expectMySQLCredentialsSecret(t *testing.T, mockClient *kubecluster.MockClientInterface, namespace string) {
+	mockCore := core.NewMockClientInterface(t)
+	mockClient.EXPECT().Core().Return(mockCore)
+	mockCore.EXPECT().GetSecret(mock.Anything, namespace, "mariadb-credentials").Return(&corev1.Secret{
+		Data: map[string][]byte{"password": []byte("secret")},
+	}, nil)
+}
+
+// resolvedMySQLCredentials are validMySQLServer's credentials, once its secret is read.
+func resolvedMySQLCredentials() mysql.Credentials {
+	return mysql.Credentials{Host: "mariadb.example.com", User: "backup", Password: "secret", SSLMode: mysql.SSLModeVerifyIdentity}
+}
+
 // validBackupConfig returns a minimal, valid Vaultwarden-shaped backup config.
 func validBackupConfig() GenericBackupConfig {
 	return GenericBackupConfig{
@@ -47,15 +97,38 @@ func validBackupConfig() GenericBackupConfig {
 				},
 			},
 		}},
-		Files: []GenericFilesBackupSource{{GenericFilesSource: GenericFilesSource{Name: "data", PVC: "vw-data"}, SnapshotClass: "ceph-block-snap"}},
+		PostgresServers: []GenericPostgresServerBackupSource{{
+			Name:                  "external",
+			GenericPostgresServer: validPostgresServer(),
+		}},
+		MySQL: []GenericMySQLBackupSource{{
+			Name:               "wiki",
+			ExcludeTables:      []string{"wiki.objectcache"},
+			GenericMySQLServer: validMySQLServer(),
+		}},
+		Redis: []GenericRedisBackupSource{{
+			Name:               "sessions",
+			GenericRedisServer: GenericRedisServer{Host: "valkey.example.com"},
+		}},
+		Files: []GenericFilesBackupSource{{
+			GenericFilesSource: GenericFilesSource{Name: "data", PVC: "vw-data", RateLimit: throttle.Limits{BytesPerSecond: 50 << 20}, Parallelism: 8},
+			SnapshotClass:      "ceph-block-snap",
+			SQLiteDatabases:    []string{"db.sqlite3"},
+		}},
 		FileGroups: []GenericFileGroupBackupSource{{
 			GenericFileGroupSource: GenericFileGroupSource{Name: "shards", Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "vw-shard"}}},
 			SnapshotClass:          "ceph-block-group-snap",
 		}},
-		S3: []GenericS3Source{{
-			Name:        "media",
-			Path:        "s3://media-bucket/vw",
-			Credentials: s3.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"},
+		S3: []GenericS3BackupSource{{
+			GenericS3Source: GenericS3Source{
+				Name:        "media",
+				Path:        "s3://media-bucket/vw",
+				Credentials: s3.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"},
+				RateLimit:   throttle.Limits{FilesPerSecond: 100},
+				Multipart:   s3.MultipartOptions{Threshold: 1 << 30, PartSize: 64 << 20},
+				FileFilter:  files.FileFilter{Exclude: []files.FilePattern{{Glob: "thumbnails"}}},
+			},
+			Rehash: true,
 		}},
 	}
 }
@@ -70,15 +143,35 @@ func validRestoreConfig() GenericRestoreConfig {
 			ClientCAIssuer: cmmeta.IssuerReference{Name: "cnpg-client-ca"},
 			ServingCert:    "vw-db-serving",
 		}},
-		Files: []GenericFilesSource{{Name: "data", PVC: "vw-data"}},
+		PostgresServers: []GenericPostgresServerRestoreSource{{
+			Name:                  "external",
+			GenericPostgresServer: validPostgresServer(),
+			Force:                 true,
+		}},
+		MySQL: []GenericMySQLRestoreSource{{
+			Name:               "wiki",
+			GenericMySQLServer: validMySQLServer(),
+		}},
+		Redis: []GenericRedisRestoreSource{{
+			Name: "sessions",
+			PVC:  "valkey-data",
+		}},
+		Files: []GenericFilesSource{{Name: "data", PVC: "vw-data", RateLimit: throttle.Limits{BytesPerSecond: 50 << 20}, Parallelism: 8}},
 		FileGroups: []GenericFileGroupSource{{
 			Name:     "shards",
 			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "vw-shard"}},
 		}},
-		S3: []GenericS3Source{{
-			Name:        "media",
-			Path:        "s3://media-bucket/vw",
-			Credentials: s3.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"},
+		S3: []GenericS3RestoreSource{{
+			GenericS3Source: GenericS3Source{
+				Name:        "media",
+				Path:        "s3://media-bucket/vw",
+				Credentials: s3.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"},
+				RateLimit:   throttle.Limits{FilesPerSecond: 100},
+				Multipart:   s3.MultipartOptions{Threshold: 1 << 30},
+				FileFilter:  files.FileFilter{Exclude: []files.FilePattern{{Glob: "thumbnails"}}},
+			},
+			Mode:   s3sync.RestoreModeRewind,
+			DryRun: true,
 		}},
 	}
 }
@@ -97,6 +190,34 @@ func TestGenericBackupConfigValidate(t *testing.T) {
 		require.NoError(t, c.Validate())
 	})
 
+	t.Run("postgres database selection", func(t *testing.T) {
+		c := validBackupConfig()
+		c.Postgres[0].Format = postgres.DumpFormatDirectory
+		c.Postgres[0].ExcludeDatabases = []string{"analytics"}
+		c.Postgres[0].Databases = []postgres.DatabaseFilter{{Name: "app", SchemaOnlyTables: []string{"public.sessions"}}}
+		require.NoError(t, c.Validate())
+	})
+
+	t.Run("postgres physical mode", func(t *testing.T) {
+		c := validBackupConfig()
+		c.Postgres[0].Mode = cnpgbackup.BackupModePhysical
+		require.NoError(t, c.Validate())
+	})
+
+	t.Run("postgresServer password secret", func(t *testing.T) {
+		c := validBackupConfig()
+		c.PostgresServers[0].ClientCertSecret = ""
+		c.PostgresServers[0].PasswordSecretRef = &SecretRef{Name: "db-password"}
+		require.NoError(t, c.Validate())
+	})
+
+	t.Run("redis credentials secret", func(t *testing.T) {
+		c := validBackupConfig()
+		c.Redis[0].User = "backup"
+		c.Redis[0].CredentialsSecretRef = &SecretRef{Name: "valkey-credentials"}
+		require.NoError(t, c.Validate())
+	})
+
 	t.Run("s3 credentials optional (env fallback)", func(t *testing.T) {
 		c := validBackupConfig()
 		c.S3[0].Credentials = s3.Credentials{}
@@ -109,10 +230,56 @@ func TestGenericBackupConfigValidate(t *testing.T) {
 		errSubstr string
 	}{
 		{
-			name:      "no sources",
-			mutate:    func(c *GenericBackupConfig) { c.Postgres = nil; c.Files = nil; c.FileGroups = nil; c.S3 = nil },
+			name: "no sources",
+			mutate: func(c *GenericBackupConfig) {
+				c.Postgres = nil
+				c.PostgresServers = nil
+				c.MySQL = nil
+				c.Redis = nil
+				c.Files = nil
+				c.FileGroups = nil
+				c.S3 = nil
+			},
 			errSubstr: "at least one source",
 		},
+		{
+			name:      "postgres jobs with the plain format",
+			mutate:    func(c *GenericBackupConfig) { c.Postgres[0].Jobs = 4 },
+			errSubstr: "jobs are only supported",
+		},
+		{
+			name:      "invalid postgres format",
+			mutate:    func(c *GenericBackupConfig) { c.Postgres[0].Format = "custom" },
+			errSubstr: "invalid format",
+		},
+		{
+			name:      "invalid postgres mode",
+			mutate:    func(c *GenericBackupConfig) { c.Postgres[0].Mode = "incremental" },
+			errSubstr: "invalid backup mode",
+		},
+		{
+			name: "postgres physical mode with dump options",
+			mutate: func(c *GenericBackupConfig) {
+				c.Postgres[0].Mode = cnpgbackup.BackupModePhysical
+				c.Postgres[0].Format = postgres.DumpFormatDirectory
+			},
+			errSubstr: "does not support",
+		},
+		{
+			name: "postgres table selection with the plain format",
+			mutate: func(c *GenericBackupConfig) {
+				c.Postgres[0].Databases = []postgres.DatabaseFilter{{Name: "app", SchemaOnlyTables: []string{"public.sessions"}}}
+			},
+			errSubstr: "invalid database selection",
+		},
+		{
+			name: "postgres role renames with the directory format",
+			mutate: func(c *GenericBackupConfig) {
+				c.Postgres[0].Format = postgres.DumpFormatDirectory
+				c.Postgres[0].Roles = postgres.RoleHandling{RenameRoles: map[string]string{"app": "application"}}
+			},
+			errSubstr: "invalid role handling",
+		},
 		{
 			name:      "size required with postgres source",
 			mutate:    func(c *GenericBackupConfig) { c.BackupVolume.Size = resource.Quantity{} },
@@ -122,6 +289,9 @@ func TestGenericBackupConfigValidate(t *testing.T) {
 			name: "size required with s3 source only",
 			mutate: func(c *GenericBackupConfig) {
 				c.Postgres = nil
+				c.PostgresServers = nil
+				c.MySQL = nil
+				c.Redis = nil
 				c.Files = nil
 				c.FileGroups = nil
 				c.BackupVolume.Size = resource.Quantity{}
@@ -132,6 +302,9 @@ func TestGenericBackupConfigValidate(t *testing.T) {
 			name: "size required with fileGroup source only",
 			mutate: func(c *GenericBackupConfig) {
 				c.Postgres = nil
+				c.PostgresServers = nil
+				c.MySQL = nil
+				c.Redis = nil
 				c.Files = nil
 				c.S3 = nil
 				c.BackupVolume.Size = resource.Quantity{}
@@ -158,6 +331,141 @@ func TestGenericBackupConfigValidate(t *testing.T) {
 			mutate:    func(c *GenericBackupConfig) { c.Postgres[0].Cluster = "" },
 			errSubstr: "cluster is required",
 		},
+		{
+			name: "size required with postgresServer source only",
+			mutate: func(c *GenericBackupConfig) {
+				c.Postgres = nil
+				c.MySQL = nil
+				c.Redis = nil
+				c.Files = nil
+				c.FileGroups = nil
+				c.S3 = nil
+				c.BackupVolume.Size = resource.Quantity{}
+			},
+			errSubstr: "backupVolume.size is required",
+		},
+		{
+			name: "size required with mysql source only",
+			mutate: func(c *GenericBackupConfig) {
+				c.Postgres = nil
+				c.PostgresServers = nil
+				c.Redis = nil
+				c.Files = nil
+				c.FileGroups = nil
+				c.S3 = nil
+				c.BackupVolume.Size = resource.Quantity{}
+			},
+			errSubstr: "backupVolume.size is required",
+		},
+		{
+			name:      "postgresServer slot name shared with a postgres source",
+			mutate:    func(c *GenericBackupConfig) { c.PostgresServers[0].Name = c.Postgres[0].Name },
+			errSubstr: "duplicate postgres slot name",
+		},
+		{
+			name:      "missing postgresServer host",
+			mutate:    func(c *GenericBackupConfig) { c.PostgresServers[0].Host = "" },
+			errSubstr: "host is required",
+		},
+		{
+			name:      "postgresServer without a password or client cert",
+			mutate:    func(c *GenericBackupConfig) { c.PostgresServers[0].ClientCertSecret = "" },
+			errSubstr: "a password or a client certificate secret is required",
+		},
+		{
+			name: "postgresServer password secret with an unknown field",
+			mutate: func(c *GenericBackupConfig) {
+				c.PostgresServers[0].PasswordSecretRef = &SecretRef{Name: "db-password", Keys: map[string]string{"user": "USER"}}
+			},
+			errSubstr: "invalid passwordSecretRef",
+		},
+		{
+			name:      "invalid postgresServer SSL mode",
+			mutate:    func(c *GenericBackupConfig) { c.PostgresServers[0].SSLMode = "always" },
+			errSubstr: "invalid SSL mode",
+		},
+		{
+			name:      "postgresServer jobs with the plain format",
+			mutate:    func(c *GenericBackupConfig) { c.PostgresServers[0].Jobs = 4 },
+			errSubstr: "jobs are only supported",
+		},
+		{
+			name:      "duplicate mysql slot name",
+			mutate:    func(c *GenericBackupConfig) { c.MySQL = append(c.MySQL, c.MySQL[0]) },
+			errSubstr: "duplicate mysql slot name",
+		},
+		{
+			name:      "missing mysql host",
+			mutate:    func(c *GenericBackupConfig) { c.MySQL[0].Host = "" },
+			errSubstr: "host is required",
+		},
+		{
+			name:      "missing mysql credentials secret",
+			mutate:    func(c *GenericBackupConfig) { c.MySQL[0].CredentialsSecretRef = nil },
+			errSubstr: "credentialsSecretRef is required",
+		},
+		{
+			name: "mysql credentials secret with an unknown field",
+			mutate: func(c *GenericBackupConfig) {
+				c.MySQL[0].CredentialsSecretRef = &SecretRef{Name: "mariadb-credentials", Keys: map[string]string{"host": "HOST"}}
+			},
+			errSubstr: "invalid credentialsSecretRef",
+		},
+		{
+			name:      "invalid mysql SSL mode",
+			mutate:    func(c *GenericBackupConfig) { c.MySQL[0].SSLMode = "VERIFY_CA" },
+			errSubstr: "invalid SSL mode",
+		},
+		{
+			name:      "mysql serving CA with SSL disabled",
+			mutate:    func(c *GenericBackupConfig) { c.MySQL[0].SSLMode = mysql.SSLModeDisabled },
+			errSubstr: "CA certificate",
+		},
+		{
+			name:      "invalid mysql consistency",
+			mutate:    func(c *GenericBackupConfig) { c.MySQL[0].Consistency = "snapshot" },
+			errSubstr: "invalid consistency",
+		},
+		{
+			name:      "mysql table exclusion without a database",
+			mutate:    func(c *GenericBackupConfig) { c.MySQL[0].ExcludeTables = []string{"objectcache"} },
+			errSubstr: "database.table",
+		},
+		{
+			name: "size required with redis source only",
+			mutate: func(c *GenericBackupConfig) {
+				c.Postgres = nil
+				c.PostgresServers = nil
+				c.MySQL = nil
+				c.Files = nil
+				c.FileGroups = nil
+				c.S3 = nil
+				c.BackupVolume.Size = resource.Quantity{}
+			},
+			errSubstr: "backupVolume.size is required",
+		},
+		{
+			name:      "duplicate redis slot name",
+			mutate:    func(c *GenericBackupConfig) { c.Redis = append(c.Redis, c.Redis[0]) },
+			errSubstr: "duplicate redis slot name",
+		},
+		{
+			name:      "missing redis host",
+			mutate:    func(c *GenericBackupConfig) { c.Redis[0].Host = "" },
+			errSubstr: "host is required",
+		},
+		{
+			name:      "redis user without a credentials secret",
+			mutate:    func(c *GenericBackupConfig) { c.Redis[0].User = "backup" },
+			errSubstr: "credentialsSecretRef is required",
+		},
+		{
+			name: "redis credentials secret with an unknown field",
+			mutate: func(c *GenericBackupConfig) {
+				c.Redis[0].CredentialsSecretRef = &SecretRef{Name: "valkey-credentials", Keys: map[string]string{"host": "HOST"}}
+			},
+			errSubstr: "invalid credentialsSecretRef",
+		},
 		{
 			name:      "missing files pvc",
 			mutate:    func(c *GenericBackupConfig) { c.Files[0].PVC = "" },
@@ -173,6 +481,68 @@ func TestGenericBackupConfigValidate(t *testing.T) {
 			mutate:    func(c *GenericBackupConfig) { c.Files[0].Name = "Bad_Name" },
 			errSubstr: "not DNS/path-safe",
 		},
+		{
+			name:      "negative files rate limit",
+			mutate:    func(c *GenericBackupConfig) { c.Files[0].RateLimit.BytesPerSecond = -1 },
+			errSubstr: "invalid rateLimit",
+		},
+		{
+			name:      "negative fileGroup rate limit",
+			mutate:    func(c *GenericBackupConfig) { c.FileGroups[0].RateLimit.FilesPerSecond = -1 },
+			errSubstr: "invalid rateLimit",
+		},
+		{
+			name:      "negative files parallelism",
+			mutate:    func(c *GenericBackupConfig) { c.Files[0].Parallelism = -1 },
+			errSubstr: "parallelism must not be negative",
+		},
+		{
+			name:      "files sqlite database outside of the pvc",
+			mutate:    func(c *GenericBackupConfig) { c.Files[0].SQLiteDatabases = []string{"../db.sqlite3"} },
+			errSubstr: "invalid sqliteDatabases",
+		},
+		{
+			name: "files sqlite database excluded by the filter",
+			mutate: func(c *GenericBackupConfig) {
+				c.Files[0].FileFilter = files.FileFilter{Exclude: []files.FilePattern{{Glob: "*.sqlite3"}}}
+			},
+			errSubstr: "excluded by the file filter",
+		},
+		{
+			name:      "negative s3 rate limit",
+			mutate:    func(c *GenericBackupConfig) { c.S3[0].RateLimit.BytesPerSecond = -1 },
+			errSubstr: "invalid rateLimit",
+		},
+		{
+			name:      "s3 part size too small",
+			mutate:    func(c *GenericBackupConfig) { c.S3[0].Multipart.PartSize = 1 },
+			errSubstr: "invalid multipart",
+		},
+		{
+			name: "s3 invalid filter",
+			mutate: func(c *GenericBackupConfig) {
+				c.S3[0].FileFilter = files.FileFilter{Exclude: []files.FilePattern{{Glob: "[bad"}}}
+			},
+			errSubstr: "invalid include/exclude filter",
+		},
+		{
+			name:      "s3 inline keys with a credentials secret",
+			mutate:    func(c *GenericBackupConfig) { c.S3[0].CredentialsSecretRef = &SecretRef{Name: "creds"} },
+			errSubstr: "must not include keys",
+		},
+		{
+			name: "s3 credentials secret without a name",
+			mutate: func(c *GenericBackupConfig) {
+				c.S3[0].Credentials = s3.Credentials{}
+				c.S3[0].CredentialsSecretRef = &SecretRef{}
+			},
+			errSubstr: "secret name is required",
+		},
+		{
+			name:      "export without a path",
+			mutate:    func(c *GenericBackupConfig) { c.Export = &OffsiteExport{} },
+			errSubstr: "invalid export",
+		},
 	}
 
 	for _, tt := range tests {
@@ -191,21 +561,152 @@ func TestGenericRestoreConfigValidate(t *testing.T) {
 		require.NoError(t, validRestoreConfig().Validate())
 	})
 
+	t.Run("postgres directory format with jobs", func(t *testing.T) {
+		c := validRestoreConfig()
+		c.Postgres[0].Format = postgres.DumpFormatDirectory
+		c.Postgres[0].Jobs = 4
+		require.NoError(t, c.Validate())
+	})
+
+	t.Run("postgres physical mode", func(t *testing.T) {
+		c := validRestoreConfig()
+		c.Postgres[0] = GenericPostgresRestoreSource{
+			Name:           "main",
+			Cluster:        "vw-db-restored",
+			Mode:           cnpgbackup.BackupModePhysical,
+			ClusterCloning: clonedcluster.CloneClusterOptions{WaitForClusterTimeout: helpers.ShortWaitTime},
+		}
+		require.NoError(t, c.Validate())
+	})
+
+	t.Run("postgres table restore into a new database", func(t *testing.T) {
+		c := validRestoreConfig()
+		c.Postgres[0].Format = postgres.DumpFormatDirectory
+		c.Postgres[0].Databases = []string{"app"}
+		c.Postgres[0].Tables = []string{"public.users"}
+		c.Postgres[0].TargetDatabase = "app_recovered"
+		require.NoError(t, c.Validate())
+	})
+
 	tests := []struct {
 		name      string
 		mutate    func(c *GenericRestoreConfig)
 		errSubstr string
 	}{
 		{
-			name:      "no sources",
-			mutate:    func(c *GenericRestoreConfig) { c.Postgres = nil; c.Files = nil; c.FileGroups = nil; c.S3 = nil },
+			name: "no sources",
+			mutate: func(c *GenericRestoreConfig) {
+				c.Postgres = nil
+				c.PostgresServers = nil
+				c.MySQL = nil
+				c.Redis = nil
+				c.Files = nil
+				c.FileGroups = nil
+				c.S3 = nil
+			},
 			errSubstr: "at least one source",
 		},
+		{
+			name:      "invalid postgres format",
+			mutate:    func(c *GenericRestoreConfig) { c.Postgres[0].Format = "custom" },
+			errSubstr: "invalid format",
+		},
+		{
+			name:      "postgres table restore with the plain format",
+			mutate:    func(c *GenericRestoreConfig) { c.Postgres[0].Tables = []string{"public.users"} },
+			errSubstr: "invalid restore selection",
+		},
+		{
+			name: "postgres single transaction with jobs",
+			mutate: func(c *GenericRestoreConfig) {
+				c.Postgres[0].Format = postgres.DumpFormatDirectory
+				c.Postgres[0].Jobs = 4
+				c.Postgres[0].SingleTransaction = true
+			},
+			errSubstr: "single transaction",
+		},
 		{
 			name:      "missing postgres servingCert",
 			mutate:    func(c *GenericRestoreConfig) { c.Postgres[0].ServingCert = "" },
 			errSubstr: "servingCert is required",
 		},
+		{
+			name:      "invalid postgres mode",
+			mutate:    func(c *GenericRestoreConfig) { c.Postgres[0].Mode = "incremental" },
+			errSubstr: "invalid mode",
+		},
+		{
+			name: "postgres cluster cloning without the physical mode",
+			mutate: func(c *GenericRestoreConfig) {
+				c.Postgres[0].ClusterCloning = clonedcluster.CloneClusterOptions{WaitForClusterTimeout: helpers.ShortWaitTime}
+			},
+			errSubstr: "clusterCloning is only supported",
+		},
+		{
+			name: "postgres physical mode with certificates",
+			mutate: func(c *GenericRestoreConfig) {
+				c.Postgres[0].Mode = cnpgbackup.BackupModePhysical
+			},
+			errSubstr: "servingCert, clientCAIssuer and postgresUserCert are not supported",
+		},
+		{
+			name: "postgres physical mode with logical restore options",
+			mutate: func(c *GenericRestoreConfig) {
+				c.Postgres[0] = GenericPostgresRestoreSource{Name: "main", Cluster: "vw-db-restored", Mode: cnpgbackup.BackupModePhysical, Force: true}
+			},
+			errSubstr: "does not support the options of a logical restore",
+		},
+		{
+			name: "postgres physical mode with a long cluster name",
+			mutate: func(c *GenericRestoreConfig) {
+				c.Postgres[0] = GenericPostgresRestoreSource{Name: "main", Cluster: "vw-db-restored-with-a-very-long-name-41ch", Mode: cnpgbackup.BackupModePhysical}
+			},
+			errSubstr: "40 characters or less",
+		},
+		{
+			name:      "postgresServer slot name shared with a postgres source",
+			mutate:    func(c *GenericRestoreConfig) { c.PostgresServers[0].Name = c.Postgres[0].Name },
+			errSubstr: "duplicate postgres slot name",
+		},
+		{
+			name:      "missing postgresServer user",
+			mutate:    func(c *GenericRestoreConfig) { c.PostgresServers[0].User = "" },
+			errSubstr: "user is required",
+		},
+		{
+			name: "postgresServer single transaction with jobs",
+			mutate: func(c *GenericRestoreConfig) {
+				c.PostgresServers[0].Format = postgres.DumpFormatDirectory
+				c.PostgresServers[0].Jobs = 4
+				c.PostgresServers[0].SingleTransaction = true
+			},
+			errSubstr: "single transaction",
+		},
+		{
+			name:      "duplicate mysql slot name",
+			mutate:    func(c *GenericRestoreConfig) { c.MySQL = append(c.MySQL, c.MySQL[0]) },
+			errSubstr: "duplicate mysql slot name",
+		},
+		{
+			name:      "invalid mysql port",
+			mutate:    func(c *GenericRestoreConfig) { c.MySQL[0].Port = 70000 },
+			errSubstr: "port",
+		},
+		{
+			name:      "duplicate redis slot name",
+			mutate:    func(c *GenericRestoreConfig) { c.Redis = append(c.Redis, c.Redis[0]) },
+			errSubstr: "duplicate redis slot name",
+		},
+		{
+			name:      "missing redis pvc",
+			mutate:    func(c *GenericRestoreConfig) { c.Redis[0].PVC = "" },
+			errSubstr: "pvc is required",
+		},
+		{
+			name:      "redis rdb file path outside of the pvc",
+			mutate:    func(c *GenericRestoreConfig) { c.Redis[0].RDBFilePath = "../dump.rdb" },
+			errSubstr: "invalid rdbFilePath",
+		},
 		{
 			name:      "duplicate s3 slot name",
 			mutate:    func(c *GenericRestoreConfig) { c.S3 = append(c.S3, c.S3[0]) },
@@ -216,6 +717,21 @@ func TestGenericRestoreConfigValidate(t *testing.T) {
 			mutate:    func(c *GenericRestoreConfig) { c.FileGroups[0].Selector = metav1.LabelSelector{} },
 			errSubstr: "selector must match",
 		},
+		{
+			name:      "negative files rate limit",
+			mutate:    func(c *GenericRestoreConfig) { c.Files[0].RateLimit.FilesPerSecond = -1 },
+			errSubstr: "invalid rateLimit",
+		},
+		{
+			name:      "invalid s3 mode",
+			mutate:    func(c *GenericRestoreConfig) { c.S3[0].Mode = "invalid" },
+			errSubstr: "invalid mode",
+		},
+		{
+			name:      "s3 dry run without rewind",
+			mutate:    func(c *GenericRestoreConfig) { c.S3[0].Mode = s3sync.RestoreModeUpload },
+			errSubstr: "dryRun is only supported",
+		},
 	}
 
 	for _, tt := range tests {
@@ -255,6 +771,10 @@ postgres:
 files:
   - name: data
     pvc: vw-data
+    rateLimit:
+      bytesPerSecond: 52428800
+      filesPerSecond: 200
+    parallelism: 8
 fileGroups:
   - name: shards
     snapshotClass: ceph-block-group-snap
@@ -299,6 +819,9 @@ s3:
 		require.NoError(t, yaml.UnmarshalWithOptions([]byte(backupYAML), &c, yaml.Strict()))
 		require.NoError(t, c.Validate())
 		assert.Equal(t, "ceph-block-snap", c.BackupVolume.SnapshotClass)
+		require.Len(t, c.Files, 1)
+		assert.Equal(t, throttle.Limits{BytesPerSecond: 50 << 20, FilesPerSecond: 200}, c.Files[0].RateLimit)
+		assert.Equal(t, 8, c.Files[0].Parallelism)
 		require.Len(t, c.Postgres, 1)
 		assert.Equal(t, "vw-db", c.Postgres[0].Cluster)
 		require.Len(t, c.FileGroups, 1)
@@ -331,11 +854,13 @@ func TestNewGenericApp(t *testing.T) {
 	assert.Equal(t, mockClient, g.kubeClusterClient)
 	assert.NotNil(t, g.newCNPGBackup)
 	assert.NotNil(t, g.newCNPGRestore)
+	assert.NotNil(t, g.newCNPGPhysicalRestore)
 	assert.NotNil(t, g.newFilesBackup)
 	assert.NotNil(t, g.newFilesRestore)
 	assert.NotNil(t, g.newFilesGroupBackup)
 	assert.NotNil(t, g.newFilesGroupRestore)
 	assert.NotNil(t, g.newS3Sync)
+	assert.NotNil(t, g.newExport)
 	assert.NotNil(t, g.newRemoteStage)
 }
 
@@ -355,6 +880,41 @@ func TestResolveS3Credentials(t *testing.T) {
 	})
 }
 
+func TestGenericAppResolveS3SourceCredentials(t *testing.T) {
+	sources := []GenericS3Source{
+		{Name: "inline", Credentials: s3.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"}},
+		{Name: "secret", CredentialsSecretRef: &SecretRef{Name: "creds"}},
+	}
+
+	t.Run("each source is resolved in order", func(t *testing.T) {
+		mockClient := kubecluster.NewMockClientInterface(t)
+		mockCore := core.NewMockClientInterface(t)
+		mockClient.EXPECT().Core().Return(mockCore)
+		mockCore.EXPECT().GetSecret(mock.Anything, "ns", "creds").Return(&corev1.Secret{
+			Data: map[string][]byte{"accessKeyId": []byte("SECRET-AKIA"), "secretAccessKey": []byte("secret")},
+		}, nil)
+
+		g := &GenericApp{kubeClusterClient: mockClient}
+		resolved, err := g.resolveS3SourceCredentials(th.NewTestContext(), "ns", sources)
+		require.NoError(t, err)
+		require.Len(t, resolved, 2)
+		assert.Equal(t, "AKIA", resolved[0].GetAccessKeyID())
+		assert.Equal(t, "SECRET-AKIA", resolved[1].GetAccessKeyID())
+	})
+
+	t.Run("error reading a secret", func(t *testing.T) {
+		mockClient := kubecluster.NewMockClientInterface(t)
+		mockCore := core.NewMockClientInterface(t)
+		mockClient.EXPECT().Core().Return(mockCore)
+		mockCore.EXPECT().GetSecret(mock.Anything, "ns", "creds").Return(nil, assert.AnError)
+
+		g := &GenericApp{kubeClusterClient: mockClient}
+		_, err := g.resolveS3SourceCredentials(th.NewTestContext(), "ns", sources)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `s3 source "secret"`)
+	})
+}
+
 func pvcWithStorageRequest(t *testing.T, quantity string) *corev1.PersistentVolumeClaim {
 	t.Helper()
 	return &corev1.PersistentVolumeClaim{
@@ -412,32 +972,54 @@ func TestGenericAppBackup(t *testing.T) {
 		desc                          string
 		simulateNewDRVolumeError      bool
 		simulateConfigurePgErr        bool
+		simulateConfigurePgServerErr  bool
+		simulateConfigureMySQLErr     bool
+		simulateConfigureRedisErr     bool
 		simulateConfigureFilesErr     bool
 		simulateConfigureFileGroupErr bool
 		simulateConfigureS3Err        bool
 		simulateRunError              bool
 		simulateSnapshotError         bool
+		export                        bool
+		simulateExportErr             bool
 	}{
 		{desc: "success"},
 		{desc: "error creating DR volume", simulateNewDRVolumeError: true},
 		{desc: "error configuring postgres", simulateConfigurePgErr: true},
+		{desc: "error configuring postgresServer", simulateConfigurePgServerErr: true},
+		{desc: "error configuring mysql", simulateConfigureMySQLErr: true},
+		{desc: "error configuring redis", simulateConfigureRedisErr: true},
 		{desc: "error configuring files", simulateConfigureFilesErr: true},
 		{desc: "error configuring fileGroup", simulateConfigureFileGroupErr: true},
 		{desc: "error configuring s3", simulateConfigureS3Err: true},
 		{desc: "error running", simulateRunError: true},
 		{desc: "error snapshotting", simulateSnapshotError: true},
+		{desc: "success with export", export: true},
+		{desc: "error exporting", export: true, simulateExportErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
 			config := validBackupConfig()
+			if tt.export {
+				config.Export = &OffsiteExport{
+					OffsiteS3Location: OffsiteS3Location{
+						Path:        "s3://offsite/backups",
+						Credentials: s3.Credentials{AccessKeyID: "AKIA-OFFSITE", SecretAccessKey: "secret"},
+					},
+				}
+			}
 			namespace := config.Namespace
 			backupName := config.BackupName
 
 			mockClient := kubecluster.NewMockClientInterface(t)
 			mockDRVolume := drvolume.NewMockDRVolumeInterface(t)
+			mockExport := export.NewMockExportInterface(t)
 			mockStage := remote.NewMockRemoteStageInterface(t)
 			mockPg := cnpgbackup.NewMockCNPGBackupInterface(t)
+			mockPgServer := pgserverbackup.NewMockPGServerBackupInterface(t)
+			mockMySQL := mysqlbackup.NewMockMySQLBackupInterface(t)
+			mockRedis := redisbackup.NewMockRedisBackupInterface(t)
 			mockFiles := filesbackup.NewMockFilesBackupInterface(t)
 			mockFilesGroup := filesgroupbackup.NewMockFilesGroupBackupInterface(t)
 			mockS3 := s3sync.NewMockS3SyncInterface(t)
@@ -447,9 +1029,13 @@ func TestGenericAppBackup(t *testing.T) {
 			g := &GenericApp{
 				kubeClusterClient:   mockClient,
 				newCNPGBackup:       func() cnpgbackup.CNPGBackupInterface { return mockPg },
+				newPGServerBackup:   func() pgserverbackup.PGServerBackupInterface { return mockPgServer },
+				newMySQLBackup:      func() mysqlbackup.MySQLBackupInterface { return mockMySQL },
+				newRedisBackup:      func() redisbackup.RedisBackupInterface { return mockRedis },
 				newFilesBackup:      func() filesbackup.FilesBackupInterface { return mockFiles },
 				newFilesGroupBackup: func() filesgroupbackup.FilesGroupBackupInterface { return mockFilesGroup },
 				newS3Sync:           func() s3sync.S3SyncInterface { return mockS3 },
+				newExport:           func() export.ExportInterface { return mockExport },
 				newRemoteStage: func(c kubecluster.ClientInterface, ns, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface {
 					assert.Equal(t, mockClient, c)
 					assert.Equal(t, namespace, ns)
@@ -463,13 +1049,19 @@ func TestGenericAppBackup(t *testing.T) {
 			wantErr := th.ErrExpected(
 				tt.simulateNewDRVolumeError,
 				tt.simulateConfigurePgErr,
+				tt.simulateConfigurePgServerErr,
+				tt.simulateConfigureMySQLErr,
+				tt.simulateConfigureRedisErr,
 				tt.simulateConfigureFilesErr,
 				tt.simulateConfigureFileGroupErr,
 				tt.simulateConfigureS3Err,
 				tt.simulateRunError,
 				tt.simulateSnapshotError,
+				tt.simulateExportErr,
 			)
 
+			expectMySQLCredentialsSecret(t, mockClient, namespace)
+
 			mockStage.EXPECT().WithAction(mock.Anything, mock.Anything).RunAndReturn(
 				func(name string, action remote.RemoteAction) remote.RemoteStageInterface {
 					registered = append(registered, name)
@@ -496,9 +1088,33 @@ func TestGenericAppBackup(t *testing.T) {
 					return
 				}
 
-				mockFiles.EXPECT().Configure(mockClient, namespace, "vw-data", backupName, "data", filesbackup.FilesBackupOptions{
-					SnapshotClass:  config.Files[0].SnapshotClass,
+				mockPgServer.EXPECT().Configure(mockClient, namespace, config.PostgresServers[0].connection(""), backupName, "external.sql", pgserverbackup.PGServerBackupOptions{
 					CleanupTimeout: config.CleanupTimeout,
+				}).Return(th.ErrIfTrue(tt.simulateConfigurePgServerErr))
+				if tt.simulateConfigurePgServerErr {
+					return
+				}
+
+				mockMySQL.EXPECT().Configure(mockClient, namespace, resolvedMySQLCredentials(), backupName, "wiki.mysql.sql", mysqlbackup.MySQLBackupOptions{
+					ExcludeTables:   config.MySQL[0].ExcludeTables,
+					ServingCASecret: "mariadb-ca",
+				}).Return(th.ErrIfTrue(tt.simulateConfigureMySQLErr))
+				if tt.simulateConfigureMySQLErr {
+					return
+				}
+
+				mockRedis.EXPECT().Configure(mockClient, namespace, redis.Credentials{Host: "valkey.example.com"}, backupName, "sessions.rdb").
+					Return(th.ErrIfTrue(tt.simulateConfigureRedisErr))
+				if tt.simulateConfigureRedisErr {
+					return
+				}
+
+				mockFiles.EXPECT().Configure(mockClient, namespace, "vw-data", backupName, "data", filesbackup.FilesBackupOptions{
+					SnapshotClass:   config.Files[0].SnapshotClass,
+					RateLimit:       config.Files[0].RateLimit,
+					Parallelism:     config.Files[0].Parallelism,
+					CleanupTimeout:  config.CleanupTimeout,
+					SQLiteDatabases: config.Files[0].SQLiteDatabases,
 				}).Return(th.ErrIfTrue(tt.simulateConfigureFilesErr))
 				if tt.simulateConfigureFilesErr {
 					return
@@ -506,13 +1122,15 @@ func TestGenericAppBackup(t *testing.T) {
 
 				mockFilesGroup.EXPECT().Configure(mockClient, namespace, config.FileGroups[0].Selector, backupName, "shards", filesgroupbackup.FilesGroupBackupOptions{
 					SnapshotClass:  config.FileGroups[0].SnapshotClass,
+					RateLimit:      config.FileGroups[0].RateLimit,
+					Parallelism:    config.FileGroups[0].Parallelism,
 					CleanupTimeout: config.CleanupTimeout,
 				}).Return(th.ErrIfTrue(tt.simulateConfigureFileGroupErr))
 				if tt.simulateConfigureFileGroupErr {
 					return
 				}
 
-				mockS3.EXPECT().Configure(mockClient, namespace, backupName, "media", "s3://media-bucket/vw", mock.Anything, s3sync.DirectionDownload, s3sync.S3SyncOptions{}).
+				mockS3.EXPECT().Configure(mockClient, namespace, backupName, "media", "s3://media-bucket/vw", mock.Anything, s3sync.DirectionDownload, s3sync.S3SyncOptions{RateLimit: config.S3[0].RateLimit, Multipart: config.S3[0].Multipart, Rehash: config.S3[0].Rehash, Filter: config.S3[0].FileFilter}).
 					RunAndReturn(func(c kubecluster.ClientInterface, ns, drVolName, backupDirRelPath, s3Path string, creds s3.CredentialsInterface, direction s3sync.Direction, opts s3sync.S3SyncOptions) error {
 						assert.Equal(t, "AKIA", creds.GetAccessKeyID())
 						return th.ErrIfTrue(tt.simulateConfigureS3Err)
@@ -524,17 +1142,38 @@ func TestGenericAppBackup(t *testing.T) {
 				mockStage.EXPECT().Run(mock.Anything).RunAndReturn(func(ctx *contexts.Context) error {
 					assert.True(t, ctx.IsChildOf(rootCtx))
 					return th.ErrIfTrue(tt.simulateRunError)
-				})
+				}).Once()
 				if tt.simulateRunError {
 					return
 				}
 
+				var snapshotName string
 				mockDRVolume.EXPECT().SnapshotAndWaitReady(mock.Anything, mock.Anything, drvolume.DRVolumeSnapshotAndWaitOptions{}).
-					RunAndReturn(func(ctx *contexts.Context, snapshotName string, opts drvolume.DRVolumeSnapshotAndWaitOptions) error {
+					RunAndReturn(func(ctx *contexts.Context, name string, opts drvolume.DRVolumeSnapshotAndWaitOptions) error {
 						assert.True(t, ctx.IsChildOf(rootCtx))
-						assert.Contains(t, snapshotName, helpers.CleanName(backupName))
+						assert.Contains(t, name, helpers.CleanName(backupName))
+						snapshotName = name
 						return th.ErrIfTrue(tt.simulateSnapshotError)
 					})
+				if tt.simulateSnapshotError || !tt.export {
+					return
+				}
+
+				mockExport.EXPECT().Configure(mockClient, namespace, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(func(c kubecluster.ClientInterface, ns, name, s3Path string, creds s3.CredentialsInterface, opts export.ExportOptions) error {
+						assert.Equal(t, helpers.CleanName(snapshotName), name)
+						assert.Equal(t, "s3://offsite/backups/"+backupName+"/"+snapshotName+"/", s3Path)
+						assert.Equal(t, export.ExportOptions{
+							CleanupTimeout: config.CleanupTimeout,
+							ManifestPath:   "s3://offsite/backups/" + backupName + "/" + snapshotName + ".json",
+						}, opts)
+						assert.Equal(t, "AKIA-OFFSITE", creds.GetAccessKeyID())
+						return nil
+					})
+				mockStage.EXPECT().Run(mock.Anything).RunAndReturn(func(ctx *contexts.Context) error {
+					assert.True(t, ctx.IsChildOf(rootCtx))
+					return th.ErrIfTrue(tt.simulateExportErr)
+				}).Once()
 			}()
 
 			backup, err := g.Backup(rootCtx, config)
@@ -544,8 +1183,14 @@ func TestGenericAppBackup(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				// Fixed, consistency-correct registration order: postgres, then files, then fileGroups, then s3.
-				assert.Equal(t, []string{`postgres "main" backup`, `files "data" backup`, `fileGroup "shards" backup`, `s3 "media" sync`}, registered)
+				// Fixed, consistency-correct registration order: postgres, then postgresServers, then mysql, then
+				// redis, then files, then fileGroups, then s3.
+				expectedRegistered := []string{`postgres "main" backup`, `postgresServer "external" backup`, `mysql "wiki" backup`, `redis "sessions" backup`, `files "data" backup`, `fileGroup "shards" backup`, `s3 "media" sync`}
+				if tt.export {
+					// The export runs in a stage of its own, once the snapshot is taken.
+					expectedRegistered = append(expectedRegistered, "export")
+				}
+				assert.Equal(t, expectedRegistered, registered)
 			}
 		})
 	}
@@ -563,6 +1208,9 @@ func TestGenericAppRestore(t *testing.T) {
 	tests := []struct {
 		desc                          string
 		simulateConfigurePgErr        bool
+		simulateConfigurePgServerErr  bool
+		simulateConfigureMySQLErr     bool
+		simulateConfigureRedisErr     bool
 		simulateConfigureFilesErr     bool
 		simulateConfigureFileGroupErr bool
 		simulateConfigureS3Err        bool
@@ -570,6 +1218,9 @@ func TestGenericAppRestore(t *testing.T) {
 	}{
 		{desc: "success"},
 		{desc: "error configuring postgres", simulateConfigurePgErr: true},
+		{desc: "error configuring postgresServer", simulateConfigurePgServerErr: true},
+		{desc: "error configuring mysql", simulateConfigureMySQLErr: true},
+		{desc: "error configuring redis", simulateConfigureRedisErr: true},
 		{desc: "error configuring files", simulateConfigureFilesErr: true},
 		{desc: "error configuring fileGroup", simulateConfigureFileGroupErr: true},
 		{desc: "error configuring s3", simulateConfigureS3Err: true},
@@ -585,6 +1236,9 @@ func TestGenericAppRestore(t *testing.T) {
 			mockClient := kubecluster.NewMockClientInterface(t)
 			mockStage := remote.NewMockRemoteStageInterface(t)
 			mockPg := cnpgrestore.NewMockCNPGRestoreInterface(t)
+			mockPgServer := pgserverrestore.NewMockPGServerRestoreInterface(t)
+			mockMySQL := mysqlrestore.NewMockMySQLRestoreInterface(t)
+			mockRedis := redisrestore.NewMockRedisRestoreInterface(t)
 			mockFiles := filesrestore.NewMockFilesRestoreInterface(t)
 			mockFilesGroup := filesgrouprestore.NewMockFilesGroupRestoreInterface(t)
 			mockS3 := s3sync.NewMockS3SyncInterface(t)
@@ -594,6 +1248,9 @@ func TestGenericAppRestore(t *testing.T) {
 			g := &GenericApp{
 				kubeClusterClient:    mockClient,
 				newCNPGRestore:       func() cnpgrestore.CNPGRestoreInterface { return mockPg },
+				newPGServerRestore:   func() pgserverrestore.PGServerRestoreInterface { return mockPgServer },
+				newMySQLRestore:      func() mysqlrestore.MySQLRestoreInterface { return mockMySQL },
+				newRedisRestore:      func() redisrestore.RedisRestoreInterface { return mockRedis },
 				newFilesRestore:      func() filesrestore.FilesRestoreInterface { return mockFiles },
 				newFilesGroupRestore: func() filesgrouprestore.FilesGroupRestoreInterface { return mockFilesGroup },
 				newS3Sync:            func() s3sync.S3SyncInterface { return mockS3 },
@@ -608,12 +1265,17 @@ func TestGenericAppRestore(t *testing.T) {
 			rootCtx := th.NewTestContext()
 			wantErr := th.ErrExpected(
 				tt.simulateConfigurePgErr,
+				tt.simulateConfigurePgServerErr,
+				tt.simulateConfigureMySQLErr,
+				tt.simulateConfigureRedisErr,
 				tt.simulateConfigureFilesErr,
 				tt.simulateConfigureFileGroupErr,
 				tt.simulateConfigureS3Err,
 				tt.simulateRunError,
 			)
 
+			expectMySQLCredentialsSecret(t, mockClient, namespace)
+
 			mockStage.EXPECT().WithAction(mock.Anything, mock.Anything).RunAndReturn(
 				func(name string, action remote.RemoteAction) remote.RemoteStageInterface {
 					registered = append(registered, name)
@@ -629,19 +1291,44 @@ func TestGenericAppRestore(t *testing.T) {
 					return
 				}
 
-				mockFiles.EXPECT().Configure(mockClient, namespace, "vw-data", restoreName, "data", filesrestore.FilesRestoreOptions{}).
+				mockPgServer.EXPECT().Configure(mockClient, namespace, config.PostgresServers[0].connection(""), restoreName, "external.sql", pgserverrestore.PGServerRestoreOptions{
+					Force: true,
+				}).Return(th.ErrIfTrue(tt.simulateConfigurePgServerErr))
+				if tt.simulateConfigurePgServerErr {
+					return
+				}
+
+				mockMySQL.EXPECT().Configure(mockClient, namespace, resolvedMySQLCredentials(), restoreName, "wiki.mysql.sql", mysqlrestore.MySQLRestoreOptions{ServingCASecret: "mariadb-ca"}).
+					Return(th.ErrIfTrue(tt.simulateConfigureMySQLErr))
+				if tt.simulateConfigureMySQLErr {
+					return
+				}
+
+				mockRedis.EXPECT().Configure(mockClient, namespace, "valkey-data", restoreName, "sessions.rdb", redisrestore.RedisRestoreOptions{}).
+					Return(th.ErrIfTrue(tt.simulateConfigureRedisErr))
+				if tt.simulateConfigureRedisErr {
+					return
+				}
+
+				mockFiles.EXPECT().Configure(mockClient, namespace, "vw-data", restoreName, "data", filesrestore.FilesRestoreOptions{RateLimit: config.Files[0].RateLimit, Parallelism: config.Files[0].Parallelism}).
 					Return(th.ErrIfTrue(tt.simulateConfigureFilesErr))
 				if tt.simulateConfigureFilesErr {
 					return
 				}
 
-				mockFilesGroup.EXPECT().Configure(mockClient, namespace, config.FileGroups[0].Selector, restoreName, "shards", filesgrouprestore.FilesGroupRestoreOptions{}).
+				mockFilesGroup.EXPECT().Configure(mockClient, namespace, config.FileGroups[0].Selector, restoreName, "shards", filesgrouprestore.FilesGroupRestoreOptions{RateLimit: config.FileGroups[0].RateLimit, Parallelism: config.FileGroups[0].Parallelism}).
 					Return(th.ErrIfTrue(tt.simulateConfigureFileGroupErr))
 				if tt.simulateConfigureFileGroupErr {
 					return
 				}
 
-				mockS3.EXPECT().Configure(mockClient, namespace, restoreName, "media", "s3://media-bucket/vw", mock.Anything, s3sync.DirectionUpload, s3sync.S3SyncOptions{}).
+				mockS3.EXPECT().Configure(mockClient, namespace, restoreName, "media", "s3://media-bucket/vw", mock.Anything, s3sync.DirectionUpload, s3sync.S3SyncOptions{
+					RateLimit:   config.S3[0].RateLimit,
+					Multipart:   config.S3[0].Multipart,
+					RestoreMode: config.S3[0].Mode,
+					DryRun:      config.S3[0].DryRun,
+					Filter:      config.S3[0].FileFilter,
+				}).
 					RunAndReturn(func(c kubecluster.ClientInterface, ns, drVolName, backupDirRelPath, s3Path string, creds s3.CredentialsInterface, direction s3sync.Direction, opts s3sync.S3SyncOptions) error {
 						assert.Equal(t, "AKIA", creds.GetAccessKeyID())
 						return th.ErrIfTrue(tt.simulateConfigureS3Err)
@@ -663,8 +1350,165 @@ func TestGenericAppRestore(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, []string{`postgres "main" restore`, `files "data" restore`, `fileGroup "shards" restore`, `s3 "media" sync`}, registered)
+				assert.Equal(t, []string{`postgres "main" restore`, `postgresServer "external" restore`, `mysql "wiki" restore`, `redis "sessions" restore`, `files "data" restore`, `fileGroup "shards" restore`, `s3 "media" sync`}, registered)
 			}
 		})
 	}
 }
+
+func TestGenericAppResolvePostgresServerConnections(t *testing.T) {
+	mockClient := kubecluster.NewMockClientInterface(t)
+	mockCore := core.NewMockClientInterface(t)
+	mockClient.EXPECT().Core().Return(mockCore)
+	mockCore.EXPECT().GetSecret(mock.Anything, "ns", "db-password").Return(&corev1.Secret{Data: map[string][]byte{"password": []byte("secret")}}, nil)
+
+	withPassword := GenericPostgresServer{Host: "db.example.com", User: "app", PasswordSecretRef: &SecretRef{Name: "db-password"}}
+	withClientCert := validPostgresServer()
+
+	g := &GenericApp{kubeClusterClient: mockClient}
+	connections, err := g.resolvePostgresServerConnections(th.NewTestContext(), "ns", []GenericPostgresServer{withPassword, withClientCert})
+	require.NoError(t, err)
+	assert.Equal(t, []pgservercommon.Connection{withPassword.connection("secret"), withClientCert.connection("")}, connections)
+	assert.Equal(t, "secret", connections[0].Password)
+}
+
+func TestDumpFileName(t *testing.T) {
+	assert.Equal(t, "main.sql", dumpFileName("main", ""))
+	assert.Equal(t, "main.sql", dumpFileName("main", postgres.DumpFormatPlain))
+	assert.Equal(t, "main.dump", dumpFileName("main", postgres.DumpFormatDirectory))
+}
+
+func TestGenericPostgresBackupSourceBackupFileName(t *testing.T) {
+	assert.Equal(t, "main.sql", GenericPostgresBackupSource{Name: "main"}.backupFileName())
+	assert.Equal(t, "main.dump", GenericPostgresBackupSource{Name: "main", Format: postgres.DumpFormatDirectory}.backupFileName())
+	assert.Equal(t, "main.physical.json", GenericPostgresBackupSource{Name: "main", Mode: cnpgbackup.BackupModePhysical}.backupFileName())
+}
+
+func TestGenericPostgresBackupSourceBackupOptions(t *testing.T) {
+	src := GenericPostgresBackupSource{
+		ClusterCloning:    clonedcluster.CloneClusterOptions{WaitForClusterTimeout: helpers.ShortWaitTime},
+		Format:            postgres.DumpFormatDirectory,
+		Jobs:              4,
+		Roles:             postgres.RoleHandling{StripPasswords: true},
+		Mode:              cnpgbackup.BackupModeLogical,
+		DatabaseSelection: postgres.DatabaseSelection{ExcludeDatabases: []string{"analytics"}},
+		RestorePointName:  "before-migration",
+	}
+
+	assert.Equal(t, cnpgbackup.CNPGBackupOptions{
+		CloningOpts:      src.ClusterCloning,
+		CleanupTimeout:   helpers.MaxWaitTime(time.Minute),
+		Format:           src.Format,
+		Jobs:             src.Jobs,
+		Selection:        src.DatabaseSelection,
+		Roles:            src.Roles,
+		Mode:             src.Mode,
+		RestorePointName: src.RestorePointName,
+	}, src.backupOptions(helpers.MaxWaitTime(time.Minute)))
+}
+
+func TestGenericAppRestorePhysicalPostgres(t *testing.T) {
+	config := GenericRestoreConfig{
+		Namespace:  "vaultwarden",
+		BackupName: "vaultwarden",
+		Postgres: []GenericPostgresRestoreSource{{
+			Name:           "main",
+			Cluster:        "vw-db-restored",
+			Mode:           cnpgbackup.BackupModePhysical,
+			ClusterCloning: clonedcluster.CloneClusterOptions{WaitForClusterTimeout: helpers.ShortWaitTime},
+		}},
+	}
+
+	mockClient := kubecluster.NewMockClientInterface(t)
+	mockStage := remote.NewMockRemoteStageInterface(t)
+	mockPhysicalRestore := physicalrestore.NewMockCNPGPhysicalRestoreInterface(t)
+
+	g := &GenericApp{
+		kubeClusterClient: mockClient,
+		newCNPGRestore: func() cnpgrestore.CNPGRestoreInterface {
+			require.Fail(t, "a physical source should not be restored logically")
+			return nil
+		},
+		newCNPGPhysicalRestore: func() physicalrestore.CNPGPhysicalRestoreInterface { return mockPhysicalRestore },
+		newRemoteStage: func(c kubecluster.ClientInterface, ns, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface {
+			return mockStage
+		},
+	}
+
+	mockPhysicalRestore.EXPECT().Configure(mockClient, "vaultwarden", "vw-db-restored", "vaultwarden", "main.physical.json", physicalrestore.CNPGPhysicalRestoreOptions{
+		CloningOpts:    config.Postgres[0].ClusterCloning,
+		CleanupTimeout: config.CleanupTimeout,
+	}).Return(nil)
+	mockStage.EXPECT().WithAction(`postgres "main" physical restore`, mockPhysicalRestore).Return(mockStage)
+	mockStage.EXPECT().Run(mock.Anything).Return(nil)
+
+	restore, err := g.Restore(th.NewTestContext(), config)
+	require.NoError(t, err)
+	require.NotNil(t, restore)
+}
+
+func TestMySQLDumpFileName(t *testing.T) {
+	assert.Equal(t, "wiki.mysql.sql", mysqlDumpFileName("wiki"))
+}
+
+func TestGenericAppResolveMySQLCredentials(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockClient := kubecluster.NewMockClientInterface(t)
+		expectMySQLCredentialsSecret(t, mockClient, "ns")
+
+		g := &GenericApp{kubeClusterClient: mockClient}
+		credentials, err := g.resolveMySQLCredentials(th.NewTestContext(), "ns", []GenericMySQLServer{validMySQLServer()})
+		require.NoError(t, err)
+		assert.Equal(t, []mysql.Credentials{resolvedMySQLCredentials()}, credentials)
+	})
+
+	t.Run("error reading the secret", func(t *testing.T) {
+		mockClient := kubecluster.NewMockClientInterface(t)
+		mockCore := core.NewMockClientInterface(t)
+		mockClient.EXPECT().Core().Return(mockCore)
+		mockCore.EXPECT().GetSecret(mock.Anything, "ns", "mariadb-credentials").Return(nil, assert.AnError)
+
+		g := &GenericApp{kubeClusterClient: mockClient}
+		_, err := g.resolveMySQLCredentials(th.NewTestContext(), "ns", []GenericMySQLServer{validMySQLServer()})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mariadb.example.com")
+	})
+}
+
+func TestRedisSnapshotFileName(t *testing.T) {
+	assert.Equal(t, "sessions.rdb", redisSnapshotFileName("sessions"))
+}
+
+func TestGenericAppResolveRedisCredentials(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockClient := kubecluster.NewMockClientInterface(t)
+		mockCore := core.NewMockClientInterface(t)
+		mockClient.EXPECT().Core().Return(mockCore)
+		mockCore.EXPECT().GetSecret(mock.Anything, "ns", "valkey-credentials").Return(&corev1.Secret{
+			Data: map[string][]byte{"password": []byte("secret")},
+		}, nil)
+
+		withSecret := GenericRedisServer{Host: "valkey.example.com", User: "backup", CredentialsSecretRef: &SecretRef{Name: "valkey-credentials"}}
+		withoutAuth := GenericRedisServer{Host: "cache.example.com", Port: 6380}
+
+		g := &GenericApp{kubeClusterClient: mockClient}
+		credentials, err := g.resolveRedisCredentials(th.NewTestContext(), "ns", []GenericRedisServer{withSecret, withoutAuth})
+		require.NoError(t, err)
+		assert.Equal(t, []redis.Credentials{
+			{Host: "valkey.example.com", User: "backup", Password: "secret"},
+			{Host: "cache.example.com", Port: 6380},
+		}, credentials)
+	})
+
+	t.Run("error reading the secret", func(t *testing.T) {
+		mockClient := kubecluster.NewMockClientInterface(t)
+		mockCore := core.NewMockClientInterface(t)
+		mockClient.EXPECT().Core().Return(mockCore)
+		mockCore.EXPECT().GetSecret(mock.Anything, "ns", "valkey-credentials").Return(nil, assert.AnError)
+
+		g := &GenericApp{kubeClusterClient: mockClient}
+		_, err := g.resolveRedisCredentials(th.NewTestContext(), "ns", []GenericRedisServer{{Host: "valkey.example.com", CredentialsSecretRef: &SecretRef{Name: "valkey-credentials"}}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "valkey.example.com")
+	})
+}
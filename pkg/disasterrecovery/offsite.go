@@ -0,0 +1,360 @@
+package disasterrecovery
+
+import (
+	"strings"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/export"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/ingest"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/drvolume"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Offsite backups. Every backup ends as a snapshot of the DR volume, which lives in the same cluster (and
+// storage pool) as the data it protects. Exporting a backup copies the snapshot's contents to object storage
+// once it has been taken. Importing rebuilds the DR volume from an export, after which the backup can be
+// restored as usual.
+//
+// Exports are stored in one of two formats. The mirror format copies the files of each backup under
+// "<path>/<backupName>/<backup full name>/", so every export is a full copy, and records the Object Lock
+// retention that the files were given in a manifest at "<path>/<backupName>/<backup full name>.json". The
+// repository format adds each
+// backup to a content-addressed, deduplicating repository at "<path>", as a snapshot named after the backup
+// and tagged with its full name, so only the data that changed since earlier exports is uploaded.
+
+// OffsiteFormat selects how exports are stored.
+type OffsiteFormat string
+
+const (
+	// OffsiteFormatMirror copies the files of each exported backup to its own prefix. This is the default.
+	OffsiteFormatMirror OffsiteFormat = "mirror"
+	// OffsiteFormatRepository adds exported backups to a deduplicating repository.
+	OffsiteFormatRepository OffsiteFormat = "repository"
+)
+
+// OffsiteS3Location is where backups are exported to and imported from. Credentials are an optional inline
+// s3.Credentials; when omitted the AWS environment variables are used. CredentialsSecretRef instead reads
+// the keys from a Kubernetes Secret when the event starts.
+type OffsiteS3Location struct {
+	Path                 string              `yaml:"path" jsonschema:"required"` // s3://bucket/prefix
+	Credentials          s3.Credentials      `yaml:"credentials,omitempty"`
+	CredentialsSecretRef *SecretRef          `yaml:"credentialsSecretRef,omitempty"`
+	RateLimit            throttle.Limits     `yaml:"rateLimit,omitempty"`
+	Multipart            s3.MultipartOptions `yaml:"multipart,omitempty"`
+	Format               OffsiteFormat       `yaml:"format,omitempty"` // Empty means mirror.
+}
+
+func (l *OffsiteS3Location) validate() error {
+	if l.Path == "" {
+		return trace.BadParameter("path is required")
+	}
+
+	if !strings.HasPrefix(l.Path, "s3://") {
+		return trace.BadParameter("path %q is not an s3:// URL", l.Path)
+	}
+
+	if err := l.RateLimit.Validate(); err != nil {
+		return trace.Wrap(err, "invalid rateLimit")
+	}
+
+	if err := l.Multipart.Validate(); err != nil {
+		return trace.Wrap(err, "invalid multipart")
+	}
+
+	switch l.Format {
+	case "", OffsiteFormatMirror, OffsiteFormatRepository:
+	default:
+		return trace.BadParameter("invalid format %q (must be %q or %q)", l.Format, OffsiteFormatMirror, OffsiteFormatRepository)
+	}
+
+	return trace.Wrap(validateS3CredentialsSecretRef(l.Credentials, l.CredentialsSecretRef))
+}
+
+// credentials validates the location and resolves its credentials.
+func (l *OffsiteS3Location) credentials(ctx *contexts.Context, kubeClusterClient kubecluster.ClientInterface, namespace string) (s3.CredentialsInterface, error) {
+	if err := l.validate(); err != nil {
+		return nil, trace.Wrap(err)
+	}
+
+	creds := l.Credentials
+	if l.CredentialsSecretRef != nil {
+		var err error
+		creds, err = ResolveS3CredentialsSecretRef(ctx, kubeClusterClient.Core(), namespace, creds, l.CredentialsSecretRef)
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to resolve credentials")
+		}
+	}
+
+	return resolveS3Credentials(creds), nil
+}
+
+func (l *OffsiteS3Location) isRepository() bool {
+	return l.Format == OffsiteFormatRepository
+}
+
+// backupPath returns the path that the backup is exported to, and imported from. This is the repository
+// itself for the repository format.
+func (l *OffsiteS3Location) backupPath(backupName, fullName string) string {
+	if l.isRepository() {
+		return strings.TrimSuffix(l.Path, "/")
+	}
+
+	return strings.TrimSuffix(l.Path, "/") + "/" + backupName + "/" + fullName + "/"
+}
+
+// manifestPath returns the path of the manifest written beside a mirror export. It is empty for the
+// repository format, which records the same details in its snapshots.
+func (l *OffsiteS3Location) manifestPath(backupName, fullName string) string {
+	if l.isRepository() {
+		return ""
+	}
+
+	return strings.TrimSuffix(l.Path, "/") + "/" + backupName + "/" + fullName + ".json"
+}
+
+// OffsiteExport configures exporting a backup to object storage. The snapshot is restored to a temporary
+// volume to read it, which uses the snapshot's storage class unless StorageClassName is set. With the
+// repository format, KeepLast prunes the repository to that many exports of the backup after each export.
+//
+// ObjectLock protects the exported objects with S3 Object Lock, so that the credentials used to export a
+// backup can't be used to delete it, such as by ransomware that has taken over the cluster. The bucket must
+// have Object Lock enabled, which is checked before the backup starts. With the repository format, the
+// chunks that an export reuses from earlier exports have their retention extended to match, and pruning
+// keeps every export that is still retained.
+type OffsiteExport struct {
+	OffsiteS3Location      `yaml:",inline"`
+	StorageClassName       string              `yaml:"storageClassName,omitempty"`
+	WaitForSnapshotTimeout helpers.MaxWaitTime `yaml:"waitForSnapshotTimeout,omitempty"`
+	KeepLast               int                 `yaml:"keepLast,omitempty"`
+	ObjectLock             s3.ObjectLock       `yaml:"objectLock,omitempty"`
+}
+
+func (e *OffsiteExport) validate() error {
+	if err := e.OffsiteS3Location.validate(); err != nil {
+		return trace.Wrap(err)
+	}
+
+	if e.KeepLast < 0 {
+		return trace.BadParameter("keepLast must not be negative")
+	}
+
+	if e.KeepLast > 0 && !e.isRepository() {
+		return trace.BadParameter("keepLast is only supported by the %q format", OffsiteFormatRepository)
+	}
+
+	return trace.Wrap(e.ObjectLock.Validate(), "invalid objectLock")
+}
+
+// checkObjectLock verifies that the bucket at the path has Object Lock enabled. Testing injection.
+var checkObjectLock = func(ctx *contexts.Context, credentials s3.CredentialsInterface, path string) error {
+	return s3.NewLocalRuntime().CheckObjectLock(ctx, credentials, path)
+}
+
+// preparedExport is an export that is ready to run once the backup's snapshot is taken. Its credentials are
+// resolved when the backup starts, so that a missing credentials secret fails the backup before any
+// resource is created.
+type preparedExport struct {
+	opts        *OffsiteExport
+	credentials s3.CredentialsInterface
+}
+
+// prepareExport resolves the export's credentials, and checks that the bucket can lock the exported objects
+// when configured to. It returns nil when the backup is not exported.
+func prepareExport(ctx *contexts.Context, kubeClusterClient kubecluster.ClientInterface, namespace string, opts *OffsiteExport) (*preparedExport, error) {
+	if opts == nil {
+		return nil, nil
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, trace.Wrap(err, "invalid export configuration")
+	}
+
+	credentials, err := opts.credentials(ctx, kubeClusterClient, namespace)
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid export configuration")
+	}
+
+	if !opts.ObjectLock.IsZero() {
+		if err := checkObjectLock(ctx.Child(), credentials, opts.Path); err != nil {
+			return nil, trace.Wrap(err, "the export bucket can't lock the exported objects")
+		}
+	}
+
+	return &preparedExport{opts: opts, credentials: credentials}, nil
+}
+
+// run exports the backup's DR volume snapshot, in a stage of its own. It does nothing when the backup is
+// not exported.
+func (pe *preparedExport) run(ctx *contexts.Context, kubeClusterClient kubecluster.ClientInterface, namespace string, backup *DREvent,
+	newRemoteStage func(kubeClusterClient kubecluster.ClientInterface, namespace, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface,
+	newExport func() export.ExportInterface, cleanupTimeout helpers.MaxWaitTime) error {
+	if pe == nil {
+		return nil
+	}
+
+	s3Path := pe.opts.backupPath(backup.Name, backup.GetFullName())
+	ctx.Log.With("s3Path", s3Path).Info("Exporting the backup")
+
+	stage := newRemoteStage(kubeClusterClient, namespace, backup.GetFullName()+"-export", remote.RemoteStageOptions{
+		CleanupTimeout: cleanupTimeout,
+	})
+
+	exportOpts := export.ExportOptions{
+		RateLimit:              pe.opts.RateLimit,
+		Multipart:              pe.opts.Multipart,
+		StorageClassName:       pe.opts.StorageClassName,
+		WaitForSnapshotTimeout: pe.opts.WaitForSnapshotTimeout,
+		CleanupTimeout:         cleanupTimeout,
+		ObjectLock:             pe.opts.ObjectLock,
+		ManifestPath:           pe.opts.manifestPath(backup.Name, backup.GetFullName()),
+	}
+	if pe.opts.isRepository() {
+		exportOpts.Repository = &export.RepositoryOptions{
+			SnapshotName: backup.Name,
+			Tags:         []string{backup.GetFullName()},
+			KeepLast:     pe.opts.KeepLast,
+		}
+	}
+
+	// The DR volume snapshot is named after the cleaned full name (see DRVolume.SnapshotAndWaitReady).
+	action := newExport()
+	if err := action.Configure(kubeClusterClient, namespace, helpers.CleanName(backup.GetFullName()), s3Path, pe.credentials, exportOpts); err != nil {
+		return trace.Wrap(err, "failed to configure export")
+	}
+	stage.WithAction("export", action)
+
+	return trace.Wrap(stage.Run(ctx.Child()), "failed to export the backup to %q", s3Path)
+}
+
+// ImportBackupVolume configures the DR volume that an import rebuilds. The size of an exported backup is not
+// known until it is downloaded, so Size is required.
+type ImportBackupVolume struct {
+	StorageClass string            `yaml:"storageClass,omitempty"`
+	Size         resource.Quantity `yaml:"size" jsonschema:"required"`
+}
+
+// ImportConfig configures rebuilding a DR volume from an exported backup. The DR PVC named backupName is
+// created if it does not exist, and its contents are replaced by the export of the backup event named
+// eventName (the backup's full name, such as "mybackup-2006-01-02T15.04.05Z"). With the repository format,
+// eventName may be omitted to import the latest export of the backup. Once imported, the backup is restored
+// with the app's restore command, as it would be from a DR volume hydrated from a snapshot.
+type ImportConfig struct {
+	Namespace      string              `yaml:"namespace" jsonschema:"required"`
+	BackupName     string              `yaml:"backupName" jsonschema:"required"`
+	EventName      string              `yaml:"eventName,omitempty"`
+	Source         OffsiteS3Location   `yaml:"source" jsonschema:"required"`
+	BackupVolume   ImportBackupVolume  `yaml:"backupVolume" jsonschema:"required"`
+	CleanupTimeout helpers.MaxWaitTime `yaml:"cleanupTimeout,omitempty"`
+}
+
+// Validate checks the config before any resource is created.
+func (c ImportConfig) Validate() error {
+	if c.Namespace == "" {
+		return trace.BadParameter("namespace is required")
+	}
+
+	if c.BackupName == "" {
+		return trace.BadParameter("backupName is required")
+	}
+
+	if c.EventName == "" && !c.Source.isRepository() {
+		return trace.BadParameter("eventName is required")
+	}
+
+	if c.EventName != "" && !strings.HasPrefix(c.EventName, c.BackupName+"-") {
+		return trace.BadParameter("eventName %q is not an event of backup %q", c.EventName, c.BackupName)
+	}
+
+	if c.BackupVolume.Size.IsZero() {
+		return trace.BadParameter("backupVolume.size is required")
+	}
+
+	return trace.Wrap(c.Source.validate(), "invalid source")
+}
+
+// BackupImporter rebuilds DR volumes from exported backups. It works with any app's backups, as it only
+// depends on the DR volume's contents.
+type BackupImporter struct {
+	kubeClusterClient kubecluster.ClientInterface
+	// Testing injection
+	newIngest      func() ingest.IngestInterface
+	newRemoteStage func(kubeClusterClient kubecluster.ClientInterface, namespace, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface
+}
+
+func NewBackupImporter(client kubecluster.ClientInterface) *BackupImporter {
+	return &BackupImporter{
+		kubeClusterClient: client,
+		newIngest:         ingest.NewIngest,
+		newRemoteStage:    remote.NewRemoteStage,
+	}
+}
+
+// Import process:
+//  1. Create the DR PVC if not exists
+//  2. Download the exported backup (or restore the repository snapshot) into the DR PVC, removing any files
+//     that are not part of the export
+func (bi *BackupImporter) Import(ctx *contexts.Context, config ImportConfig) (importEvent *DREvent, err error) {
+	if err := config.Validate(); err != nil {
+		return nil, trace.Wrap(err, "invalid import configuration")
+	}
+
+	credentials, err := config.Source.credentials(ctx.Child(), bi.kubeClusterClient, config.Namespace)
+	if err != nil {
+		return nil, trace.Wrap(err, "invalid import configuration")
+	}
+
+	importEvent = NewDREventNow(config.BackupName)
+	s3Path := config.Source.backupPath(config.BackupName, config.EventName)
+	ctx.Log.With("eventName", config.EventName, "s3Path", s3Path, "namespace", config.Namespace).Info("Starting import process")
+	defer func() {
+		importEvent.Stop()
+		keyvals := []any{ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err)}
+		if err != nil {
+			ctx.Log.Warn("Import process failed", keyvals...)
+		} else {
+			ctx.Log.Info("Import process completed", keyvals...)
+		}
+	}()
+
+	ctx.Log.Step().Info("Ensuring DR volume exists")
+	if _, err := bi.kubeClusterClient.NewDRVolume(ctx.Child(), config.Namespace, config.BackupName, config.BackupVolume.Size, drvolume.DRVolumeCreateOptions{
+		VolumeStorageClass: config.BackupVolume.StorageClass,
+	}); err != nil {
+		return importEvent, trace.Wrap(err, "failed to create the DR volume")
+	}
+
+	ctx.Log.Step().Info("Configuring import actions")
+	stage := bi.newRemoteStage(bi.kubeClusterClient, config.Namespace, importEvent.GetFullName(), remote.RemoteStageOptions{
+		CleanupTimeout: config.CleanupTimeout,
+	})
+
+	ingestOpts := ingest.IngestOptions{
+		RateLimit: config.Source.RateLimit,
+		Multipart: config.Source.Multipart,
+	}
+	if config.Source.isRepository() {
+		ingestOpts.Repository = &ingest.RepositoryOptions{
+			SnapshotName: config.BackupName,
+			Tag:          config.EventName,
+		}
+	}
+
+	action := bi.newIngest()
+	if err := action.Configure(bi.kubeClusterClient, config.Namespace, config.BackupName, s3Path, credentials, ingestOpts); err != nil {
+		return importEvent, trace.Wrap(err, "failed to configure import")
+	}
+	stage.WithAction("import", action)
+
+	ctx.Log.Step().Info("Running import actions")
+	if err := stage.Run(ctx.Child()); err != nil {
+		return importEvent, trace.Wrap(err, "failed to import %q", s3Path)
+	}
+
+	return importEvent, nil
+}
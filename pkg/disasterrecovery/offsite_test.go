@@ -0,0 +1,430 @@
+package disasterrecovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/export"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/ingest"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/drvolume"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func validOffsiteS3Location() OffsiteS3Location {
+	return OffsiteS3Location{
+		Path:        "s3://offsite/backups",
+		Credentials: s3.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"},
+	}
+}
+
+func TestOffsiteS3LocationValidate(t *testing.T) {
+	tests := []struct {
+		desc      string
+		mutate    func(l *OffsiteS3Location)
+		errSubstr string
+	}{
+		{desc: "valid"},
+		{desc: "valid with a credentials secret", mutate: func(l *OffsiteS3Location) {
+			l.Credentials = s3.Credentials{}
+			l.CredentialsSecretRef = &SecretRef{Name: "creds"}
+		}},
+		{desc: "missing path", mutate: func(l *OffsiteS3Location) { l.Path = "" }, errSubstr: "path is required"},
+		{desc: "not an s3 path", mutate: func(l *OffsiteS3Location) { l.Path = "/backups" }, errSubstr: "not an s3:// URL"},
+		{desc: "invalid rate limit", mutate: func(l *OffsiteS3Location) { l.RateLimit = throttle.Limits{BytesPerSecond: -1} }, errSubstr: "rateLimit"},
+		{desc: "invalid multipart", mutate: func(l *OffsiteS3Location) { l.Multipart = s3.MultipartOptions{Threshold: -1} }, errSubstr: "multipart"},
+		{desc: "inline credentials and a secret", mutate: func(l *OffsiteS3Location) { l.CredentialsSecretRef = &SecretRef{Name: "creds"} }, errSubstr: "credentials"},
+		{desc: "mirror format", mutate: func(l *OffsiteS3Location) { l.Format = OffsiteFormatMirror }},
+		{desc: "repository format", mutate: func(l *OffsiteS3Location) { l.Format = OffsiteFormatRepository }},
+		{desc: "invalid format", mutate: func(l *OffsiteS3Location) { l.Format = "tarball" }, errSubstr: "invalid format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			location := validOffsiteS3Location()
+			if tt.mutate != nil {
+				tt.mutate(&location)
+			}
+
+			err := location.validate()
+			if tt.errSubstr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errSubstr)
+		})
+	}
+}
+
+func TestOffsiteS3LocationBackupPath(t *testing.T) {
+	for _, path := range []string{"s3://offsite/backups", "s3://offsite/backups/"} {
+		location := OffsiteS3Location{Path: path}
+		assert.Equal(t, "s3://offsite/backups/name/name-event/", location.backupPath("name", "name-event"))
+
+		location.Format = OffsiteFormatRepository
+		assert.Equal(t, "s3://offsite/backups", location.backupPath("name", "name-event"))
+	}
+}
+
+func TestOffsiteS3LocationManifestPath(t *testing.T) {
+	for _, path := range []string{"s3://offsite/backups", "s3://offsite/backups/"} {
+		location := OffsiteS3Location{Path: path}
+		assert.Equal(t, "s3://offsite/backups/name/name-event.json", location.manifestPath("name", "name-event"))
+
+		location.Format = OffsiteFormatRepository
+		assert.Empty(t, location.manifestPath("name", "name-event"))
+	}
+}
+
+func TestPrepareExport(t *testing.T) {
+	t.Run("not exported", func(t *testing.T) {
+		pe, err := prepareExport(th.NewTestContext(), kubecluster.NewMockClientInterface(t), "ns", nil)
+		require.NoError(t, err)
+		assert.Nil(t, pe)
+	})
+
+	t.Run("inline credentials", func(t *testing.T) {
+		opts := &OffsiteExport{OffsiteS3Location: validOffsiteS3Location()}
+		pe, err := prepareExport(th.NewTestContext(), kubecluster.NewMockClientInterface(t), "ns", opts)
+		require.NoError(t, err)
+		require.NotNil(t, pe)
+		assert.Equal(t, opts, pe.opts)
+		assert.Equal(t, "AKIA", pe.credentials.GetAccessKeyID())
+	})
+
+	t.Run("credentials secret", func(t *testing.T) {
+		mockClient := kubecluster.NewMockClientInterface(t)
+		mockCore := core.NewMockClientInterface(t)
+		mockClient.EXPECT().Core().Return(mockCore)
+		mockCore.EXPECT().GetSecret(mock.Anything, "ns", "creds").Return(&corev1.Secret{
+			Data: map[string][]byte{"accessKeyId": []byte("SECRET-AKIA"), "secretAccessKey": []byte("secret")},
+		}, nil)
+
+		opts := &OffsiteExport{OffsiteS3Location: OffsiteS3Location{Path: "s3://offsite", CredentialsSecretRef: &SecretRef{Name: "creds"}}}
+		pe, err := prepareExport(th.NewTestContext(), mockClient, "ns", opts)
+		require.NoError(t, err)
+		require.NotNil(t, pe)
+		assert.Equal(t, "SECRET-AKIA", pe.credentials.GetAccessKeyID())
+	})
+
+	t.Run("error reading the credentials secret", func(t *testing.T) {
+		mockClient := kubecluster.NewMockClientInterface(t)
+		mockCore := core.NewMockClientInterface(t)
+		mockClient.EXPECT().Core().Return(mockCore)
+		mockCore.EXPECT().GetSecret(mock.Anything, "ns", "creds").Return(nil, assert.AnError)
+
+		opts := &OffsiteExport{OffsiteS3Location: OffsiteS3Location{Path: "s3://offsite", CredentialsSecretRef: &SecretRef{Name: "creds"}}}
+		_, err := prepareExport(th.NewTestContext(), mockClient, "ns", opts)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := prepareExport(th.NewTestContext(), kubecluster.NewMockClientInterface(t), "ns", &OffsiteExport{})
+		assert.Error(t, err)
+	})
+
+	t.Run("repository format keeping the last exports", func(t *testing.T) {
+		opts := &OffsiteExport{OffsiteS3Location: validOffsiteS3Location(), KeepLast: 3}
+		opts.Format = OffsiteFormatRepository
+		pe, err := prepareExport(th.NewTestContext(), kubecluster.NewMockClientInterface(t), "ns", opts)
+		require.NoError(t, err)
+		assert.NotNil(t, pe)
+	})
+
+	t.Run("keeping the last exports of the mirror format", func(t *testing.T) {
+		opts := &OffsiteExport{OffsiteS3Location: validOffsiteS3Location(), KeepLast: 3}
+		_, err := prepareExport(th.NewTestContext(), kubecluster.NewMockClientInterface(t), "ns", opts)
+		assert.ErrorContains(t, err, "keepLast")
+	})
+
+	t.Run("keeping a negative number of exports", func(t *testing.T) {
+		opts := &OffsiteExport{OffsiteS3Location: validOffsiteS3Location(), KeepLast: -1}
+		opts.Format = OffsiteFormatRepository
+		_, err := prepareExport(th.NewTestContext(), kubecluster.NewMockClientInterface(t), "ns", opts)
+		assert.ErrorContains(t, err, "keepLast")
+	})
+
+	t.Run("object lock", func(t *testing.T) {
+		originalCheckObjectLock := checkObjectLock
+		t.Cleanup(func() { checkObjectLock = originalCheckObjectLock })
+
+		for _, checkErr := range []error{nil, assert.AnError} {
+			var checkedPath string
+			checkObjectLock = func(_ *contexts.Context, credentials s3.CredentialsInterface, path string) error {
+				assert.Equal(t, "AKIA", credentials.GetAccessKeyID())
+				checkedPath = path
+				return checkErr
+			}
+
+			opts := &OffsiteExport{OffsiteS3Location: validOffsiteS3Location(), ObjectLock: s3.ObjectLock{Mode: s3.ObjectLockModeGovernance, Retention: time.Hour}}
+			pe, err := prepareExport(th.NewTestContext(), kubecluster.NewMockClientInterface(t), "ns", opts)
+			assert.Equal(t, opts.Path, checkedPath)
+			if checkErr != nil {
+				assert.Error(t, err)
+				continue
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, pe)
+		}
+	})
+
+	t.Run("invalid object lock", func(t *testing.T) {
+		opts := &OffsiteExport{OffsiteS3Location: validOffsiteS3Location(), ObjectLock: s3.ObjectLock{Retention: time.Hour}}
+		_, err := prepareExport(th.NewTestContext(), kubecluster.NewMockClientInterface(t), "ns", opts)
+		assert.ErrorContains(t, err, "objectLock")
+	})
+}
+
+func TestPreparedExportRun(t *testing.T) {
+	namespace := "namespace"
+	cleanupTimeout := helpers.ShortWaitTime
+
+	t.Run("not exported", func(t *testing.T) {
+		var pe *preparedExport
+		assert.NoError(t, pe.run(th.NewTestContext(), nil, namespace, NewDREventNow("backup"), nil, nil, cleanupTimeout))
+	})
+
+	tests := []struct {
+		desc                 string
+		repository           bool
+		simulateConfigureErr bool
+		simulateRunErr       bool
+	}{
+		{desc: "success"},
+		{desc: "success with the repository format", repository: true},
+		{desc: "error configuring", simulateConfigureErr: true},
+		{desc: "error running", simulateRunErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockStage := remote.NewMockRemoteStageInterface(t)
+			mockExport := export.NewMockExportInterface(t)
+			mockCredentials := s3.NewMockCredentialsInterface(t)
+
+			backup := NewDREventNow("backup")
+			pe := &preparedExport{
+				opts: &OffsiteExport{
+					OffsiteS3Location:      OffsiteS3Location{Path: "s3://offsite/backups", RateLimit: throttle.Limits{BytesPerSecond: 1024}},
+					StorageClassName:       "storage-class",
+					WaitForSnapshotTimeout: helpers.ShortWaitTime,
+					ObjectLock:             s3.ObjectLock{LegalHold: true},
+				},
+				credentials: mockCredentials,
+			}
+			expectedS3Path := "s3://offsite/backups/backup/" + backup.GetFullName() + "/"
+			expectedOpts := export.ExportOptions{
+				RateLimit:              pe.opts.RateLimit,
+				StorageClassName:       pe.opts.StorageClassName,
+				WaitForSnapshotTimeout: pe.opts.WaitForSnapshotTimeout,
+				CleanupTimeout:         cleanupTimeout,
+				ObjectLock:             pe.opts.ObjectLock,
+				ManifestPath:           "s3://offsite/backups/backup/" + backup.GetFullName() + ".json",
+			}
+			if tt.repository {
+				pe.opts.Format = OffsiteFormatRepository
+				pe.opts.KeepLast = 3
+				expectedS3Path = "s3://offsite/backups"
+				expectedOpts.ManifestPath = ""
+				expectedOpts.Repository = &export.RepositoryOptions{SnapshotName: "backup", Tags: []string{backup.GetFullName()}, KeepLast: 3}
+			}
+			newRemoteStage := func(c kubecluster.ClientInterface, ns, eventName string, stageOpts remote.RemoteStageOptions) remote.RemoteStageInterface {
+				assert.Equal(t, mockClient, c)
+				assert.Equal(t, namespace, ns)
+				assert.Equal(t, backup.GetFullName()+"-export", eventName)
+				assert.Equal(t, cleanupTimeout, stageOpts.CleanupTimeout)
+				return mockStage
+			}
+
+			rootCtx := th.NewTestContext()
+			func() {
+				mockExport.EXPECT().Configure(mockClient, namespace, helpers.CleanName(backup.GetFullName()), expectedS3Path, mockCredentials, expectedOpts).
+					Return(th.ErrIfTrue(tt.simulateConfigureErr))
+				if tt.simulateConfigureErr {
+					return
+				}
+
+				mockStage.EXPECT().WithAction("export", mockExport).Return(mockStage)
+				mockStage.EXPECT().Run(mock.Anything).RunAndReturn(func(ctx *contexts.Context) error {
+					assert.True(t, ctx.IsChildOf(rootCtx))
+					return th.ErrIfTrue(tt.simulateRunErr)
+				})
+			}()
+
+			err := pe.run(rootCtx, mockClient, namespace, backup, newRemoteStage, func() export.ExportInterface { return mockExport }, cleanupTimeout)
+			if th.ErrExpected(tt.simulateConfigureErr, tt.simulateRunErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func validImportConfig() ImportConfig {
+	return ImportConfig{
+		Namespace:    "namespace",
+		BackupName:   "backup",
+		EventName:    "backup-2006-01-02T15.04.05Z",
+		Source:       validOffsiteS3Location(),
+		BackupVolume: ImportBackupVolume{StorageClass: "storage-class", Size: resource.MustParse("10Gi")},
+	}
+}
+
+func TestImportConfigValidate(t *testing.T) {
+	tests := []struct {
+		desc      string
+		mutate    func(c *ImportConfig)
+		errSubstr string
+	}{
+		{desc: "valid"},
+		{desc: "missing namespace", mutate: func(c *ImportConfig) { c.Namespace = "" }, errSubstr: "namespace"},
+		{desc: "missing backup name", mutate: func(c *ImportConfig) { c.BackupName = "" }, errSubstr: "backupName"},
+		{desc: "missing event name", mutate: func(c *ImportConfig) { c.EventName = "" }, errSubstr: "eventName"},
+		{desc: "event of another backup", mutate: func(c *ImportConfig) { c.EventName = "other-2006-01-02T15.04.05Z" }, errSubstr: "is not an event of backup"},
+		{desc: "missing volume size", mutate: func(c *ImportConfig) { c.BackupVolume.Size = resource.Quantity{} }, errSubstr: "backupVolume.size"},
+		{desc: "invalid source", mutate: func(c *ImportConfig) { c.Source.Path = "" }, errSubstr: "invalid source"},
+		{desc: "repository format without an event name", mutate: func(c *ImportConfig) {
+			c.Source.Format = OffsiteFormatRepository
+			c.EventName = ""
+		}},
+		{desc: "repository format with an event of another backup", mutate: func(c *ImportConfig) {
+			c.Source.Format = OffsiteFormatRepository
+			c.EventName = "other-2006-01-02T15.04.05Z"
+		}, errSubstr: "is not an event of backup"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			config := validImportConfig()
+			if tt.mutate != nil {
+				tt.mutate(&config)
+			}
+
+			err := config.Validate()
+			if tt.errSubstr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errSubstr)
+		})
+	}
+}
+
+func TestNewBackupImporter(t *testing.T) {
+	mockClient := kubecluster.NewMockClientInterface(t)
+	bi := NewBackupImporter(mockClient)
+
+	require.NotNil(t, bi)
+	assert.Equal(t, mockClient, bi.kubeClusterClient)
+	assert.NotNil(t, bi.newIngest)
+	assert.NotNil(t, bi.newRemoteStage)
+}
+
+func TestBackupImporterImport(t *testing.T) {
+	tests := []struct {
+		desc                     string
+		repository               bool
+		simulateNewDRVolumeError bool
+		simulateConfigureErr     bool
+		simulateRunErr           bool
+	}{
+		{desc: "success"},
+		{desc: "success with the repository format", repository: true},
+		{desc: "error creating DR volume", simulateNewDRVolumeError: true},
+		{desc: "error configuring", simulateConfigureErr: true},
+		{desc: "error running", simulateRunErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			config := validImportConfig()
+			config.CleanupTimeout = helpers.ShortWaitTime
+			expectedS3Path := "s3://offsite/backups/backup/" + config.EventName + "/"
+			var expectedOpts ingest.IngestOptions
+			if tt.repository {
+				config.Source.Format = OffsiteFormatRepository
+				expectedS3Path = "s3://offsite/backups"
+				expectedOpts.Repository = &ingest.RepositoryOptions{SnapshotName: config.BackupName, Tag: config.EventName}
+			}
+
+			mockClient := kubecluster.NewMockClientInterface(t)
+			mockStage := remote.NewMockRemoteStageInterface(t)
+			mockIngest := ingest.NewMockIngestInterface(t)
+
+			bi := &BackupImporter{
+				kubeClusterClient: mockClient,
+				newIngest:         func() ingest.IngestInterface { return mockIngest },
+				newRemoteStage: func(c kubecluster.ClientInterface, ns, eventName string, stageOpts remote.RemoteStageOptions) remote.RemoteStageInterface {
+					assert.Equal(t, mockClient, c)
+					assert.Equal(t, config.Namespace, ns)
+					assert.Contains(t, eventName, config.BackupName)
+					assert.Equal(t, config.CleanupTimeout, stageOpts.CleanupTimeout)
+					return mockStage
+				},
+			}
+
+			rootCtx := th.NewTestContext()
+			func() {
+				mockClient.EXPECT().NewDRVolume(mock.Anything, config.Namespace, config.BackupName, config.BackupVolume.Size, drvolume.DRVolumeCreateOptions{
+					VolumeStorageClass: config.BackupVolume.StorageClass,
+				}).RunAndReturn(func(ctx *contexts.Context, _, _ string, _ resource.Quantity, _ drvolume.DRVolumeCreateOptions) (drvolume.DRVolumeInterface, error) {
+					assert.True(t, ctx.IsChildOf(rootCtx))
+					return th.ErrOr1Val(drvolume.NewMockDRVolumeInterface(t), tt.simulateNewDRVolumeError)
+				})
+				if tt.simulateNewDRVolumeError {
+					return
+				}
+
+				mockIngest.EXPECT().Configure(mockClient, config.Namespace, config.BackupName, expectedS3Path, mock.Anything, expectedOpts).
+					RunAndReturn(func(_ kubecluster.ClientInterface, _, _, _ string, creds s3.CredentialsInterface, _ ingest.IngestOptions) error {
+						assert.Equal(t, "AKIA", creds.GetAccessKeyID())
+						return th.ErrIfTrue(tt.simulateConfigureErr)
+					})
+				if tt.simulateConfigureErr {
+					return
+				}
+
+				mockStage.EXPECT().WithAction("import", mockIngest).Return(mockStage)
+				mockStage.EXPECT().Run(mock.Anything).RunAndReturn(func(ctx *contexts.Context) error {
+					assert.True(t, ctx.IsChildOf(rootCtx))
+					return th.ErrIfTrue(tt.simulateRunErr)
+				})
+			}()
+
+			importEvent, err := bi.Import(rootCtx, config)
+			require.NotNil(t, importEvent)
+			assert.Equal(t, config.BackupName, importEvent.Name)
+			if th.ErrExpected(tt.simulateNewDRVolumeError, tt.simulateConfigureErr, tt.simulateRunErr) {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBackupImporterImportInvalidConfig(t *testing.T) {
+	bi := NewBackupImporter(kubecluster.NewMockClientInterface(t))
+	importEvent, err := bi.Import(th.NewTestContext(), ImportConfig{})
+	assert.Error(t, err)
+	assert.Nil(t, importEvent)
+}
@@ -0,0 +1,255 @@
+package disasterrecovery
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+)
+
+// SecretRef references a Kubernetes Secret that holds a source's credentials, so that they don't need to be
+// written into the config. The Secret must be in the event's namespace, which Namespace defaults to, so that
+// a config can't be used to read Secrets from other namespaces. Keys maps credential fields (named
+// as in the config) to the Secret keys that hold them; a field that isn't mapped is read from the key named
+// after the field, and a field that the Secret doesn't hold is left unset. The Secret is read when the event
+// starts, and its values are only passed on to the backup-tool instance that uses them.
+type SecretRef struct {
+	Name      string            `yaml:"name" jsonschema:"required"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Keys      map[string]string `yaml:"keys,omitempty"`
+}
+
+// validate checks the reference, given the credential fields that the Secret may hold.
+func (r *SecretRef) validate(fields ...string) error {
+	if r.Name == "" {
+		return trace.BadParameter("secret name is required")
+	}
+
+	for field, key := range r.Keys {
+		if !slices.Contains(fields, field) {
+			return trace.BadParameter("unknown credential field %q in keys (must be one of %s)", field, strings.Join(fields, ", "))
+		}
+		if key == "" {
+			return trace.BadParameter("credential field %q is mapped to an empty key", field)
+		}
+	}
+
+	return nil
+}
+
+// resolve reads the referenced Secret and returns the values that it holds for the given fields, keyed by
+// field. Surrounding whitespace (such as the trailing newline of a Secret created from a file) is trimmed.
+func (r *SecretRef) resolve(ctx *contexts.Context, coreClient core.ClientInterface, namespace string, fields ...string) (map[string]string, error) {
+	if r.Namespace != "" && r.Namespace != namespace {
+		return nil, trace.BadParameter("credentials secret %q must be in the event's namespace %q", helpers.FullNameStr(r.Namespace, r.Name), namespace)
+	}
+
+	secret, err := coreClient.GetSecret(ctx.Child(), namespace, r.Name)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to get credentials secret %q", helpers.FullNameStr(namespace, r.Name))
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		key := field
+		if mappedKey, ok := r.Keys[field]; ok {
+			key = mappedKey
+		}
+
+		if value, ok := secret.Data[key]; ok {
+			values[field] = strings.TrimSpace(string(value))
+		}
+	}
+
+	return values, nil
+}
+
+// The s3.Credentials fields that a credentials secret can hold, named as in the config.
+const (
+	s3CredentialFieldAccessKeyID      = "accessKeyId"
+	s3CredentialFieldSecretAccessKey  = "secretAccessKey"
+	s3CredentialFieldSessionToken     = "sessionToken"
+	s3CredentialFieldEndpoint         = "endpoint"
+	s3CredentialFieldRegion           = "region"
+	s3CredentialFieldS3ForcePathStyle = "s3ForcePathStyle"
+)
+
+var s3CredentialFields = []string{
+	s3CredentialFieldAccessKeyID,
+	s3CredentialFieldSecretAccessKey,
+	s3CredentialFieldSessionToken,
+	s3CredentialFieldEndpoint,
+	s3CredentialFieldRegion,
+	s3CredentialFieldS3ForcePathStyle,
+}
+
+// validateS3CredentialsSecretRef checks that inline credentials and a credentials secret can be combined.
+// The secret supplies the keys, so they can't also be inline. The inline endpoint, region and path-style
+// settings are still used when the secret doesn't hold them.
+func validateS3CredentialsSecretRef(creds s3.Credentials, ref *SecretRef) error {
+	if ref == nil {
+		return nil
+	}
+
+	if creds.AccessKeyID != "" || creds.SecretAccessKey != "" || creds.SessionToken != "" {
+		return trace.BadParameter("credentials must not include keys when credentialsSecretRef is set")
+	}
+
+	return trace.Wrap(ref.validate(s3CredentialFields...), "invalid credentialsSecretRef")
+}
+
+// ResolveS3CredentialsSecretRef returns the inline credentials with the values held by the referenced secret
+// applied over them. The credentials are returned unchanged when ref is nil.
+func ResolveS3CredentialsSecretRef(ctx *contexts.Context, coreClient core.ClientInterface, namespace string, creds s3.Credentials, ref *SecretRef) (s3.Credentials, error) {
+	if ref == nil {
+		return creds, nil
+	}
+
+	if err := validateS3CredentialsSecretRef(creds, ref); err != nil {
+		return s3.Credentials{}, trace.Wrap(err)
+	}
+
+	values, err := ref.resolve(ctx, coreClient, namespace, s3CredentialFields...)
+	if err != nil {
+		return s3.Credentials{}, trace.Wrap(err)
+	}
+
+	for _, field := range []string{s3CredentialFieldAccessKeyID, s3CredentialFieldSecretAccessKey} {
+		if values[field] == "" {
+			return s3.Credentials{}, trace.BadParameter("credentials secret %q does not hold a value for %q", ref.Name, field)
+		}
+	}
+
+	resolved := creds
+	resolved.AccessKeyID = values[s3CredentialFieldAccessKeyID]
+	resolved.SecretAccessKey = values[s3CredentialFieldSecretAccessKey]
+	resolved.SessionToken = values[s3CredentialFieldSessionToken]
+	if endpoint, ok := values[s3CredentialFieldEndpoint]; ok {
+		resolved.Endpoint = endpoint
+	}
+	if region, ok := values[s3CredentialFieldRegion]; ok {
+		resolved.Region = region
+	}
+	if rawForcePathStyle, ok := values[s3CredentialFieldS3ForcePathStyle]; ok {
+		if resolved.S3ForcePathStyle, err = strconv.ParseBool(rawForcePathStyle); err != nil {
+			return s3.Credentials{}, trace.Wrap(err, "credentials secret %q holds an invalid value for %q", ref.Name, s3CredentialFieldS3ForcePathStyle)
+		}
+	}
+
+	return resolved, nil
+}
+
+// The postgres credential fields that a password secret can hold, named as in the config.
+const postgresCredentialFieldPassword = "password"
+
+var postgresCredentialFields = []string{postgresCredentialFieldPassword}
+
+// ResolvePostgresPasswordSecretRef returns the password held by the referenced secret. An empty password is
+// returned when ref is nil.
+func ResolvePostgresPasswordSecretRef(ctx *contexts.Context, coreClient core.ClientInterface, namespace string, ref *SecretRef) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+
+	if err := ref.validate(postgresCredentialFields...); err != nil {
+		return "", trace.Wrap(err, "invalid passwordSecretRef")
+	}
+
+	values, err := ref.resolve(ctx, coreClient, namespace, postgresCredentialFields...)
+	if err != nil {
+		return "", trace.Wrap(err)
+	}
+
+	password := values[postgresCredentialFieldPassword]
+	if password == "" {
+		return "", trace.BadParameter("credentials secret %q does not hold a value for %q", ref.Name, postgresCredentialFieldPassword)
+	}
+
+	return password, nil
+}
+
+// The mysql.Credentials fields that a credentials secret can hold, named as in the config.
+const (
+	mysqlCredentialFieldUser     = "user"
+	mysqlCredentialFieldPassword = "password"
+)
+
+var mysqlCredentialFields = []string{mysqlCredentialFieldUser, mysqlCredentialFieldPassword}
+
+// ResolveMySQLCredentialsSecretRef returns the credentials with the user and password held by the referenced
+// secret applied over them. The secret must hold a password. The inline user is used when the secret doesn't
+// hold one.
+func ResolveMySQLCredentialsSecretRef(ctx *contexts.Context, coreClient core.ClientInterface, namespace string, creds mysql.Credentials, ref *SecretRef) (mysql.Credentials, error) {
+	if ref == nil {
+		return mysql.Credentials{}, trace.BadParameter("credentialsSecretRef is required")
+	}
+
+	if err := ref.validate(mysqlCredentialFields...); err != nil {
+		return mysql.Credentials{}, trace.Wrap(err, "invalid credentialsSecretRef")
+	}
+
+	values, err := ref.resolve(ctx, coreClient, namespace, mysqlCredentialFields...)
+	if err != nil {
+		return mysql.Credentials{}, trace.Wrap(err)
+	}
+
+	resolved := creds
+	resolved.Password = values[mysqlCredentialFieldPassword]
+	if resolved.Password == "" {
+		return mysql.Credentials{}, trace.BadParameter("credentials secret %q does not hold a value for %q", ref.Name, mysqlCredentialFieldPassword)
+	}
+
+	if user, ok := values[mysqlCredentialFieldUser]; ok && user != "" {
+		resolved.User = user
+	}
+	if resolved.User == "" {
+		return mysql.Credentials{}, trace.BadParameter("credentials secret %q does not hold a value for %q, and no user is configured", ref.Name, mysqlCredentialFieldUser)
+	}
+
+	return resolved, nil
+}
+
+// The redis.Credentials fields that a credentials secret can hold, named as in the config.
+const (
+	redisCredentialFieldUser     = "user"
+	redisCredentialFieldPassword = "password"
+)
+
+var redisCredentialFields = []string{redisCredentialFieldUser, redisCredentialFieldPassword}
+
+// ResolveRedisCredentialsSecretRef returns the credentials with the user and password held by the referenced
+// secret applied over them. A nil ref returns the credentials unchanged, for servers without authentication.
+// Otherwise the secret must hold a password. The inline user (if any) is used when the secret doesn't hold one.
+func ResolveRedisCredentialsSecretRef(ctx *contexts.Context, coreClient core.ClientInterface, namespace string, creds redis.Credentials, ref *SecretRef) (redis.Credentials, error) {
+	if ref == nil {
+		return creds, nil
+	}
+
+	if err := ref.validate(redisCredentialFields...); err != nil {
+		return redis.Credentials{}, trace.Wrap(err, "invalid credentialsSecretRef")
+	}
+
+	values, err := ref.resolve(ctx, coreClient, namespace, redisCredentialFields...)
+	if err != nil {
+		return redis.Credentials{}, trace.Wrap(err)
+	}
+
+	resolved := creds
+	resolved.Password = values[redisCredentialFieldPassword]
+	if resolved.Password == "" {
+		return redis.Credentials{}, trace.BadParameter("credentials secret %q does not hold a value for %q", ref.Name, redisCredentialFieldPassword)
+	}
+
+	if user, ok := values[redisCredentialFieldUser]; ok && user != "" {
+		resolved.User = user
+	}
+
+	return resolved, nil
+}
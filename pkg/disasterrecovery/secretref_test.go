@@ -0,0 +1,415 @@
+package disasterrecovery
+
+import (
+	"testing"
+
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSecretRefValidate(t *testing.T) {
+	tests := []struct {
+		desc      string
+		ref       SecretRef
+		errSubstr string
+	}{
+		{
+			desc: "valid",
+			ref:  SecretRef{Name: "creds", Keys: map[string]string{"accessKeyId": "AWS_ACCESS_KEY_ID"}},
+		},
+		{
+			desc:      "missing name",
+			ref:       SecretRef{},
+			errSubstr: "secret name is required",
+		},
+		{
+			desc:      "unknown field",
+			ref:       SecretRef{Name: "creds", Keys: map[string]string{"password": "pw"}},
+			errSubstr: "unknown credential field",
+		},
+		{
+			desc:      "empty key",
+			ref:       SecretRef{Name: "creds", Keys: map[string]string{"accessKeyId": ""}},
+			errSubstr: "empty key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.ref.validate(s3CredentialFields...)
+			if tt.errSubstr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errSubstr)
+		})
+	}
+}
+
+func TestResolveS3CredentialsSecretRef(t *testing.T) {
+	tests := []struct {
+		desc                string
+		creds               s3.Credentials
+		ref                 *SecretRef
+		secretData          map[string]string
+		expectedNamespace   string
+		simulateGetErr      bool
+		expectedCredentials s3.Credentials
+		errSubstr           string
+	}{
+		{
+			desc:                "no secret ref",
+			creds:               s3.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"},
+			expectedCredentials: s3.Credentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"},
+		},
+		{
+			desc:  "default keys",
+			creds: s3.Credentials{Endpoint: "https://s3.example.com", Region: "inline-region"},
+			ref:   &SecretRef{Name: "creds"},
+			secretData: map[string]string{
+				"accessKeyId":     "AKIA\n",
+				"secretAccessKey": "secret",
+				"sessionToken":    "token",
+				"region":          "secret-region",
+			},
+			expectedNamespace: "ns",
+			expectedCredentials: s3.Credentials{
+				AccessKeyID:     "AKIA",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+				Endpoint:        "https://s3.example.com",
+				Region:          "secret-region",
+			},
+		},
+		{
+			desc: "mapped keys in the event namespace",
+			ref: &SecretRef{
+				Name:      "creds",
+				Namespace: "ns",
+				Keys: map[string]string{
+					"accessKeyId":      "AWS_ACCESS_KEY_ID",
+					"secretAccessKey":  "AWS_SECRET_ACCESS_KEY",
+					"s3ForcePathStyle": "PATH_STYLE",
+				},
+			},
+			secretData: map[string]string{
+				"AWS_ACCESS_KEY_ID":     "AKIA",
+				"AWS_SECRET_ACCESS_KEY": "secret",
+				"PATH_STYLE":            "true",
+				// Mapped fields are not read from their default keys.
+				"accessKeyId": "ignored",
+			},
+			expectedNamespace: "ns",
+			expectedCredentials: s3.Credentials{
+				AccessKeyID:      "AKIA",
+				SecretAccessKey:  "secret",
+				S3ForcePathStyle: true,
+			},
+		},
+		{
+			desc:      "another namespace",
+			ref:       &SecretRef{Name: "creds", Namespace: "other-ns"},
+			errSubstr: "must be in the event's namespace",
+		},
+		{
+			desc:      "inline keys",
+			creds:     s3.Credentials{AccessKeyID: "AKIA"},
+			ref:       &SecretRef{Name: "creds"},
+			errSubstr: "must not include keys",
+		},
+		{
+			desc:              "error getting secret",
+			ref:               &SecretRef{Name: "creds"},
+			expectedNamespace: "ns",
+			simulateGetErr:    true,
+			errSubstr:         "failed to get credentials secret",
+		},
+		{
+			desc:              "missing secret access key",
+			ref:               &SecretRef{Name: "creds"},
+			secretData:        map[string]string{"accessKeyId": "AKIA"},
+			expectedNamespace: "ns",
+			errSubstr:         `does not hold a value for "secretAccessKey"`,
+		},
+		{
+			desc: "invalid path style",
+			ref:  &SecretRef{Name: "creds"},
+			secretData: map[string]string{
+				"accessKeyId":      "AKIA",
+				"secretAccessKey":  "secret",
+				"s3ForcePathStyle": "sometimes",
+			},
+			expectedNamespace: "ns",
+			errSubstr:         "invalid value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCore := core.NewMockClientInterface(t)
+			if tt.expectedNamespace != "" {
+				secret := &corev1.Secret{Data: make(map[string][]byte, len(tt.secretData))}
+				for key, value := range tt.secretData {
+					secret.Data[key] = []byte(value)
+				}
+				mockCore.EXPECT().GetSecret(mock.Anything, tt.expectedNamespace, "creds").Return(th.ErrOr1Val(secret, tt.simulateGetErr))
+			}
+
+			credentials, err := ResolveS3CredentialsSecretRef(th.NewTestContext(), mockCore, "ns", tt.creds, tt.ref)
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCredentials, credentials)
+		})
+	}
+}
+
+func TestResolvePostgresPasswordSecretRef(t *testing.T) {
+	tests := []struct {
+		desc              string
+		ref               *SecretRef
+		secretData        map[string]string
+		expectedNamespace string
+		simulateGetErr    bool
+		expectedPassword  string
+		errSubstr         string
+	}{
+		{
+			desc: "no secret ref",
+		},
+		{
+			desc:              "default key",
+			ref:               &SecretRef{Name: "creds"},
+			secretData:        map[string]string{"password": "secret\n"},
+			expectedNamespace: "ns",
+			expectedPassword:  "secret",
+		},
+		{
+			desc:              "mapped key in the event namespace",
+			ref:               &SecretRef{Name: "creds", Namespace: "ns", Keys: map[string]string{"password": "POSTGRES_PASSWORD"}},
+			secretData:        map[string]string{"POSTGRES_PASSWORD": "secret", "password": "ignored"},
+			expectedNamespace: "ns",
+			expectedPassword:  "secret",
+		},
+		{
+			desc:      "unknown field",
+			ref:       &SecretRef{Name: "creds", Keys: map[string]string{"username": "USER"}},
+			errSubstr: "unknown credential field",
+		},
+		{
+			desc:              "error getting secret",
+			ref:               &SecretRef{Name: "creds"},
+			expectedNamespace: "ns",
+			simulateGetErr:    true,
+			errSubstr:         "failed to get credentials secret",
+		},
+		{
+			desc:              "missing password",
+			ref:               &SecretRef{Name: "creds"},
+			secretData:        map[string]string{"username": "app"},
+			expectedNamespace: "ns",
+			errSubstr:         `does not hold a value for "password"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCore := core.NewMockClientInterface(t)
+			if tt.expectedNamespace != "" {
+				secret := &corev1.Secret{Data: make(map[string][]byte, len(tt.secretData))}
+				for key, value := range tt.secretData {
+					secret.Data[key] = []byte(value)
+				}
+				mockCore.EXPECT().GetSecret(mock.Anything, tt.expectedNamespace, "creds").Return(th.ErrOr1Val(secret, tt.simulateGetErr))
+			}
+
+			password, err := ResolvePostgresPasswordSecretRef(th.NewTestContext(), mockCore, "ns", tt.ref)
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPassword, password)
+		})
+	}
+}
+
+func TestResolveMySQLCredentialsSecretRef(t *testing.T) {
+	inline := mysql.Credentials{Host: "mariadb.example.com", User: "backup"}
+
+	tests := []struct {
+		desc                string
+		creds               mysql.Credentials
+		ref                 *SecretRef
+		secretData          map[string]string
+		expectedNamespace   string
+		simulateGetErr      bool
+		expectedCredentials mysql.Credentials
+		errSubstr           string
+	}{
+		{
+			desc:      "no secret ref",
+			creds:     inline,
+			errSubstr: "credentialsSecretRef is required",
+		},
+		{
+			desc:                "password only",
+			creds:               inline,
+			ref:                 &SecretRef{Name: "creds"},
+			secretData:          map[string]string{"password": "secret\n"},
+			expectedNamespace:   "ns",
+			expectedCredentials: mysql.Credentials{Host: "mariadb.example.com", User: "backup", Password: "secret"},
+		},
+		{
+			desc:                "mapped user overrides the inline user",
+			creds:               inline,
+			ref:                 &SecretRef{Name: "creds", Namespace: "ns", Keys: map[string]string{"user": "MARIADB_USER", "password": "MARIADB_PASSWORD"}},
+			secretData:          map[string]string{"MARIADB_USER": "wiki", "MARIADB_PASSWORD": "secret"},
+			expectedNamespace:   "ns",
+			expectedCredentials: mysql.Credentials{Host: "mariadb.example.com", User: "wiki", Password: "secret"},
+		},
+		{
+			desc:      "unknown field",
+			creds:     inline,
+			ref:       &SecretRef{Name: "creds", Keys: map[string]string{"host": "HOST"}},
+			errSubstr: "unknown credential field",
+		},
+		{
+			desc:              "error getting secret",
+			creds:             inline,
+			ref:               &SecretRef{Name: "creds"},
+			expectedNamespace: "ns",
+			simulateGetErr:    true,
+			errSubstr:         "failed to get credentials secret",
+		},
+		{
+			desc:              "missing password",
+			creds:             inline,
+			ref:               &SecretRef{Name: "creds"},
+			secretData:        map[string]string{"user": "wiki"},
+			expectedNamespace: "ns",
+			errSubstr:         `does not hold a value for "password"`,
+		},
+		{
+			desc:              "missing user",
+			creds:             mysql.Credentials{Host: "mariadb.example.com"},
+			ref:               &SecretRef{Name: "creds"},
+			secretData:        map[string]string{"password": "secret"},
+			expectedNamespace: "ns",
+			errSubstr:         `does not hold a value for "user"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCore := core.NewMockClientInterface(t)
+			if tt.expectedNamespace != "" {
+				secret := &corev1.Secret{Data: make(map[string][]byte, len(tt.secretData))}
+				for key, value := range tt.secretData {
+					secret.Data[key] = []byte(value)
+				}
+				mockCore.EXPECT().GetSecret(mock.Anything, tt.expectedNamespace, "creds").Return(th.ErrOr1Val(secret, tt.simulateGetErr))
+			}
+
+			credentials, err := ResolveMySQLCredentialsSecretRef(th.NewTestContext(), mockCore, "ns", tt.creds, tt.ref)
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCredentials, credentials)
+		})
+	}
+}
+
+func TestResolveRedisCredentialsSecretRef(t *testing.T) {
+	inline := redis.Credentials{Host: "valkey.example.com", User: "backup"}
+
+	tests := []struct {
+		desc                string
+		ref                 *SecretRef
+		secretData          map[string]string
+		expectedNamespace   string
+		simulateGetErr      bool
+		expectedCredentials redis.Credentials
+		errSubstr           string
+	}{
+		{
+			desc:                "no secret ref",
+			expectedCredentials: inline,
+		},
+		{
+			desc:                "password only",
+			ref:                 &SecretRef{Name: "creds"},
+			secretData:          map[string]string{"password": "secret\n"},
+			expectedNamespace:   "ns",
+			expectedCredentials: redis.Credentials{Host: "valkey.example.com", User: "backup", Password: "secret"},
+		},
+		{
+			desc:                "mapped user overrides the inline user",
+			ref:                 &SecretRef{Name: "creds", Namespace: "ns", Keys: map[string]string{"user": "VALKEY_USER", "password": "VALKEY_PASSWORD"}},
+			secretData:          map[string]string{"VALKEY_USER": "sessions", "VALKEY_PASSWORD": "secret"},
+			expectedNamespace:   "ns",
+			expectedCredentials: redis.Credentials{Host: "valkey.example.com", User: "sessions", Password: "secret"},
+		},
+		{
+			desc:      "unknown field",
+			ref:       &SecretRef{Name: "creds", Keys: map[string]string{"host": "HOST"}},
+			errSubstr: "unknown credential field",
+		},
+		{
+			desc:              "error getting secret",
+			ref:               &SecretRef{Name: "creds"},
+			expectedNamespace: "ns",
+			simulateGetErr:    true,
+			errSubstr:         "failed to get credentials secret",
+		},
+		{
+			desc:              "missing password",
+			ref:               &SecretRef{Name: "creds"},
+			secretData:        map[string]string{"user": "sessions"},
+			expectedNamespace: "ns",
+			errSubstr:         `does not hold a value for "password"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCore := core.NewMockClientInterface(t)
+			if tt.expectedNamespace != "" {
+				secret := &corev1.Secret{Data: make(map[string][]byte, len(tt.secretData))}
+				for key, value := range tt.secretData {
+					secret.Data[key] = []byte(value)
+				}
+				mockCore.EXPECT().GetSecret(mock.Anything, tt.expectedNamespace, "creds").Return(th.ErrOr1Val(secret, tt.simulateGetErr))
+			}
+
+			credentials, err := ResolveRedisCredentialsSecretRef(th.NewTestContext(), mockCore, "ns", inline, tt.ref)
+			if tt.errSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCredentials, credentials)
+		})
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote"
 	cnpgbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/backup"
 	cnpgrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/restore"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/export"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/s3sync"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
@@ -26,9 +28,28 @@ const (
 type TeleportOptionsS3Sync struct {
 	Enabled bool   `yaml:"enabled,omitempty"`
 	S3Path  string `yaml:"s3Path,omitempty"`
-	// TODO accept values from env, file, or k8s secret
 	// TODO if I switch to COSI, remove this and generate a BucketAccess resource instead
 	Credentials s3.Credentials `yaml:"credentials,omitempty"`
+	// CredentialsSecretRef reads the credentials from a Kubernetes Secret instead of the config.
+	CredentialsSecretRef *SecretRef `yaml:"credentialsSecretRef,omitempty"`
+	// Include/Exclude optionally whitelist/blacklist which session logs are synced, by their keys relative to
+	// the path.
+	files.FileFilter `yaml:",inline"`
+}
+
+// credentials returns the sync's credentials, with the values held by the credentials secret applied if one
+// is referenced. Nothing is resolved when the sync is disabled.
+func (o TeleportOptionsS3Sync) credentials(ctx *contexts.Context, kubeClusterClient kubecluster.ClientInterface, namespace string) (*s3.Credentials, error) {
+	if !o.Enabled || o.CredentialsSecretRef == nil {
+		return &o.Credentials, nil
+	}
+
+	credentials, err := ResolveS3CredentialsSecretRef(ctx, kubeClusterClient.Core(), namespace, o.Credentials, o.CredentialsSecretRef)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to resolve credentials")
+	}
+
+	return &credentials, nil
 }
 
 type TeleportOptionsAudit struct {
@@ -49,6 +70,7 @@ type TeleportBackupOptions struct {
 	RemoteBackupToolOptions backuptoolinstance.CreateBackupToolInstanceOptions `yaml:"remoteBackupToolOptions,omitempty"`
 	BackupSnapshot          OptionsBackupSnapshot                              `yaml:"backupSnapshot,omitempty"`
 	CleanupTimeout          helpers.MaxWaitTime                                `yaml:"cleanupTimeout,omitempty"`
+	Export                  *OffsiteExport                                     `yaml:"export,omitempty"`
 }
 
 type Teleport struct {
@@ -57,6 +79,7 @@ type Teleport struct {
 	newCNPGBackup  func() cnpgbackup.CNPGBackupInterface
 	newCNPGRestore func() cnpgrestore.CNPGRestoreInterface
 	newS3Sync      func() s3sync.S3SyncInterface
+	newExport      func() export.ExportInterface
 	newRemoteStage func(kubeClusterClient kubecluster.ClientInterface, namespace, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface
 }
 
@@ -66,6 +89,7 @@ func NewTeleport(kubeClusterClient kubecluster.ClientInterface) *Teleport {
 		newCNPGBackup:     cnpgbackup.NewCNPGBackup,
 		newCNPGRestore:    cnpgrestore.NewCNPGRestore,
 		newS3Sync:         s3sync.NewS3Sync,
+		newExport:         export.NewExport,
 		newRemoteStage:    remote.NewRemoteStage,
 	}
 }
@@ -79,6 +103,7 @@ func NewTeleport(kubeClusterClient kubecluster.ClientInterface) *Teleport {
 // 6. Perform a logical backup of the Audit cluster (if enabled)
 // 7. Sync the audit session logs from object storage (if enabled)
 // 8. Snapshot the backup PVC
+// 9. Export the snapshot to object storage (if configured)
 func (t *Teleport) Backup(ctx *contexts.Context, namespace, backupName, coreClusterName string, opts TeleportBackupOptions) (backup *DREvent, err error) {
 	backup = NewDREventNow(backupName)
 	ctx.Log.With("backupName", backup.GetFullName(), "namespace", namespace).Info("Starting backup process")
@@ -92,6 +117,18 @@ func (t *Teleport) Backup(ctx *contexts.Context, namespace, backupName, coreClus
 		}
 	}()
 
+	offsiteExport, err := prepareExport(ctx.Child(), t.kubeClusterClient, namespace, opts.Export)
+	if err != nil {
+		return backup, trace.Wrap(err, "invalid backup configuration")
+	}
+
+	// Resolved before any resource is created, so that a missing or incomplete credentials secret fails the
+	// backup early.
+	auditSessionLogsCredentials, err := opts.AuditSessionLogs.credentials(ctx.Child(), t.kubeClusterClient, namespace)
+	if err != nil {
+		return backup, trace.Wrap(err, "failed to get audit session logs credentials")
+	}
+
 	// Create the DR PVC if not exists
 	ctx.Log.Step()
 	clusterNames := []string{coreClusterName}
@@ -134,7 +171,7 @@ func (t *Teleport) Backup(ctx *contexts.Context, namespace, backupName, coreClus
 
 	auditSessionLogsBackup := t.newS3Sync()
 	if opts.AuditSessionLogs.Enabled {
-		if err := auditSessionLogsBackup.Configure(t.kubeClusterClient, namespace, backupName, teleportAuditSessionLogsDirectoryName, opts.AuditSessionLogs.S3Path, &opts.AuditSessionLogs.Credentials, s3sync.DirectionDownload, s3sync.S3SyncOptions{}); err != nil {
+		if err := auditSessionLogsBackup.Configure(t.kubeClusterClient, namespace, backupName, teleportAuditSessionLogsDirectoryName, opts.AuditSessionLogs.S3Path, auditSessionLogsCredentials, s3sync.DirectionDownload, s3sync.S3SyncOptions{Filter: opts.AuditSessionLogs.FileFilter}); err != nil {
 			return backup, trace.Wrap(err, "failed to configure audit session logs backup")
 		}
 		stage.WithAction("Teleport audit session logs S3 sync", auditSessionLogsBackup)
@@ -156,6 +193,10 @@ func (t *Teleport) Backup(ctx *contexts.Context, namespace, backupName, coreClus
 		return backup, trace.Wrap(err, "failed to snapshot the backup volume")
 	}
 
+	if err := offsiteExport.run(ctx.Child(), t.kubeClusterClient, namespace, backup, t.newRemoteStage, t.newExport, opts.CleanupTimeout); err != nil {
+		return backup, trace.Wrap(err, "failed to export the backup")
+	}
+
 	return backup, nil
 }
 
@@ -205,6 +246,11 @@ func (t *Teleport) Restore(ctx *contexts.Context, namespace, restoreName, coreCl
 		}
 	}()
 
+	auditSessionLogsCredentials, err := opts.AuditSessionLogs.credentials(ctx.Child(), t.kubeClusterClient, namespace)
+	if err != nil {
+		return restore, trace.Wrap(err, "failed to get audit session logs credentials")
+	}
+
 	// 1. Configuration
 	ctx.Log.Step().Info("Configuring restoration actions")
 	stage := t.newRemoteStage(t.kubeClusterClient, namespace, restore.GetFullName(), remote.RemoteStageOptions{
@@ -215,6 +261,7 @@ func (t *Teleport) Restore(ctx *contexts.Context, namespace, restoreName, coreCl
 	if err := coreRestore.Configure(t.kubeClusterClient, namespace, coreClusterName, coreServingCertName, coreClientCAIssuer, restoreName, teleportCoreSQLFileName, cnpgrestore.CNPGRestoreOptions{
 		PostgresUserCert: opts.PostgresUserCert,
 		CleanupTimeout:   opts.CleanupTimeout,
+		Force:            true, // The restore replaces Teleport's databases, which are expected to exist.
 	}); err != nil {
 		return restore, trace.Wrap(err, "failed to configure core cluster restoration")
 	}
@@ -225,6 +272,7 @@ func (t *Teleport) Restore(ctx *contexts.Context, namespace, restoreName, coreCl
 		if err := auditRestore.Configure(t.kubeClusterClient, namespace, opts.AuditCluster.Name, opts.AuditCluster.ServingCertName, opts.AuditCluster.ClientCAIssuer, restoreName, teleportAuditSQLFileName, cnpgrestore.CNPGRestoreOptions{
 			PostgresUserCert: opts.AuditCluster.PostgresUserCert,
 			CleanupTimeout:   opts.CleanupTimeout,
+			Force:            true,
 		}); err != nil {
 			return restore, trace.Wrap(err, "failed to configure audit cluster restoration")
 		}
@@ -233,7 +281,7 @@ func (t *Teleport) Restore(ctx *contexts.Context, namespace, restoreName, coreCl
 
 	auditSessionLogsRestore := t.newS3Sync()
 	if opts.AuditSessionLogs.Enabled {
-		if err := auditSessionLogsRestore.Configure(t.kubeClusterClient, namespace, restoreName, teleportAuditSessionLogsDirectoryName, opts.AuditSessionLogs.S3Path, &opts.AuditSessionLogs.Credentials, s3sync.DirectionUpload, s3sync.S3SyncOptions{}); err != nil {
+		if err := auditSessionLogsRestore.Configure(t.kubeClusterClient, namespace, restoreName, teleportAuditSessionLogsDirectoryName, opts.AuditSessionLogs.S3Path, auditSessionLogsCredentials, s3sync.DirectionUpload, s3sync.S3SyncOptions{Filter: opts.AuditSessionLogs.FileFilter}); err != nil {
 			return restore, trace.Wrap(err, "failed to configure audit session logs restoration")
 		}
 		stage.WithAction("Teleport audit session logs S3 sync", auditSessionLogsRestore)
@@ -11,6 +11,7 @@ import (
 	cnpgbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/backup"
 	cnpgrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/restore"
 	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/s3sync"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/drvolume"
@@ -50,6 +51,7 @@ func TestTeleportBackup(t *testing.T) {
 	tests := []struct {
 		desc                                         string
 		opts                                         TeleportBackupOptions
+		simulateGetCredentialsSecretError            bool
 		simulateEnsurePVCError                       bool
 		simulateConfigureCoreBackupError             bool
 		simulateConfigureAuditBackupError            bool
@@ -78,6 +80,7 @@ func TestTeleportBackup(t *testing.T) {
 					S3Path:      auditSessionLogsS3Path,
 					Credentials: *auditSessionLogsS3Credentials,
 					Enabled:     true,
+					FileFilter:  files.FileFilter{Include: []files.FilePattern{{Glob: "**/*.tar"}}},
 				},
 				BackupSnapshot: OptionsBackupSnapshot{
 					ReadyTimeout:  helpers.MaxWaitTime(2 * time.Second),
@@ -86,6 +89,15 @@ func TestTeleportBackup(t *testing.T) {
 				CleanupTimeout: helpers.MaxWaitTime(3 * time.Second),
 			},
 		},
+		{
+			desc: "error resolving audit session logs credentials",
+			opts: TeleportBackupOptions{AuditSessionLogs: TeleportOptionsS3Sync{
+				S3Path:               auditSessionLogsS3Path,
+				CredentialsSecretRef: &SecretRef{Name: "audit-session-logs-credentials"},
+				Enabled:              true,
+			}},
+			simulateGetCredentialsSecretError: true,
+		},
 		{
 			desc:                   "error ensuring backup volume exists",
 			opts:                   TeleportBackupOptions{AuditCluster: TeleportBackupOptionsAudit{TeleportOptionsAudit{Name: auditClusterName, Enabled: true}}},
@@ -164,6 +176,7 @@ func TestTeleportBackup(t *testing.T) {
 			rootCtx := th.NewTestContext()
 
 			wantErr := th.ErrExpected(
+				tt.simulateGetCredentialsSecretError,
 				tt.simulateEnsurePVCError,
 				tt.simulateConfigureCoreBackupError,
 				tt.simulateConfigureAuditBackupError,
@@ -174,6 +187,12 @@ func TestTeleportBackup(t *testing.T) {
 
 			// Setup mocks
 			func() {
+				// The credentials secret is read before any resource is created
+				if tt.simulateGetCredentialsSecretError {
+					mockCoreClient.EXPECT().GetSecret(mock.Anything, namespace, "audit-session-logs-credentials").Return(nil, assert.AnError)
+					return
+				}
+
 				// DR PVC
 				mockClient.EXPECT().NewDRVolume(mock.Anything, namespace, backupName, tt.opts.VolumeSize, mock.Anything).
 					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string, configuredSize resource.Quantity, opts drvolume.DRVolumeCreateOptions) (drvolume.DRVolumeInterface, error) {
@@ -223,7 +242,7 @@ func TestTeleportBackup(t *testing.T) {
 				}
 
 				if tt.opts.AuditSessionLogs.Enabled {
-					mockAuditSessionLogsS3Sync.EXPECT().Configure(mockClient, namespace, backupName, "audit-session-logs", auditSessionLogsS3Path, auditSessionLogsS3Credentials, s3sync.DirectionDownload, s3sync.S3SyncOptions{}).
+					mockAuditSessionLogsS3Sync.EXPECT().Configure(mockClient, namespace, backupName, "audit-session-logs", auditSessionLogsS3Path, auditSessionLogsS3Credentials, s3sync.DirectionDownload, s3sync.S3SyncOptions{Filter: tt.opts.AuditSessionLogs.FileFilter}).
 						Return(th.ErrIfTrue(tt.simulateConfigureAuditSessionLogsBackupError))
 					if tt.simulateConfigureAuditSessionLogsBackupError {
 						return
@@ -301,6 +320,7 @@ func TestTeleportRestore(t *testing.T) {
 		S3Path:      auditSessionLogsS3Path,
 		Credentials: *auditSessionLogsS3Credentials,
 		Enabled:     true,
+		FileFilter:  files.FileFilter{Include: []files.FilePattern{{Glob: "**/*.tar"}}},
 	}
 
 	tests := []struct {
@@ -375,6 +395,7 @@ func TestTeleportRestore(t *testing.T) {
 				mockCoreCNPGRestore.EXPECT().Configure(mockClient, namespace, coreClusterName, coreServingCertName, coreClientCAIssuer, restoreName, "backup-core.sql", cnpgrestore.CNPGRestoreOptions{
 					PostgresUserCert: tt.opts.PostgresUserCert,
 					CleanupTimeout:   tt.opts.CleanupTimeout,
+					Force:            true,
 				}).Return(th.ErrIfTrue(tt.simulateCoreConfigError))
 				if tt.simulateCoreConfigError {
 					return
@@ -385,6 +406,7 @@ func TestTeleportRestore(t *testing.T) {
 					mockAuditCNPGRestore.EXPECT().Configure(mockClient, namespace, auditClusterName, auditServingCertName, tt.opts.AuditCluster.ClientCAIssuer, restoreName, "backup-audit.sql", cnpgrestore.CNPGRestoreOptions{
 						PostgresUserCert: tt.opts.AuditCluster.PostgresUserCert,
 						CleanupTimeout:   tt.opts.CleanupTimeout,
+						Force:            true,
 					}).Return(th.ErrIfTrue(tt.simulateAuditConfigError))
 					if tt.simulateAuditConfigError {
 						return
@@ -393,7 +415,7 @@ func TestTeleportRestore(t *testing.T) {
 				}
 
 				if tt.opts.AuditSessionLogs.Enabled {
-					mockAuditSessionLogsS3Sync.EXPECT().Configure(mockClient, namespace, restoreName, "audit-session-logs", auditSessionLogsS3Path, auditSessionLogsS3Credentials, s3sync.DirectionUpload, s3sync.S3SyncOptions{}).
+					mockAuditSessionLogsS3Sync.EXPECT().Configure(mockClient, namespace, restoreName, "audit-session-logs", auditSessionLogsS3Path, auditSessionLogsS3Credentials, s3sync.DirectionUpload, s3sync.S3SyncOptions{Filter: tt.opts.AuditSessionLogs.FileFilter}).
 						Return(th.ErrIfTrue(tt.simulateAuditSessionLogsConfigError))
 					if tt.simulateAuditSessionLogsConfigError {
 						return
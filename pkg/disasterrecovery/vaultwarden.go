@@ -9,6 +9,7 @@ import (
 	cnpgrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/cnpg/restore"
 	filesbackup "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/backup"
 	filesrestore "github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/files/restore"
+	"github.com/solidDoWant/backup-tool/pkg/disasterrecovery/actions/remote/offsite/export"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/backuptoolinstance"
 	"github.com/solidDoWant/backup-tool/pkg/kubecluster/composite/clonedcluster"
@@ -31,6 +32,7 @@ type VaultWardenBackupOptions struct {
 	RemoteBackupToolOptions backuptoolinstance.CreateBackupToolInstanceOptions `yaml:"remoteBackupToolOptions,omitempty"`
 	BackupSnapshot          OptionsBackupSnapshot                              `yaml:"backupSnapshot,omitempty"`
 	CleanupTimeout          helpers.MaxWaitTime                                `yaml:"cleanupTimeout,omitempty"`
+	Export                  *OffsiteExport                                     `yaml:"export,omitempty"`
 }
 
 type VaultWarden struct {
@@ -40,6 +42,7 @@ type VaultWarden struct {
 	newCNPGRestore  func() cnpgrestore.CNPGRestoreInterface
 	newFilesBackup  func() filesbackup.FilesBackupInterface
 	newFilesRestore func() filesrestore.FilesRestoreInterface
+	newExport       func() export.ExportInterface
 	newRemoteStage  func(kubeClusterClient kubecluster.ClientInterface, namespace, eventName string, opts remote.RemoteStageOptions) remote.RemoteStageInterface
 }
 
@@ -50,6 +53,7 @@ func NewVaultWarden(client kubecluster.ClientInterface) *VaultWarden {
 		newCNPGRestore:    cnpgrestore.NewCNPGRestore,
 		newFilesBackup:    filesbackup.NewFilesBackup,
 		newFilesRestore:   filesrestore.NewFilesRestore,
+		newExport:         export.NewExport,
 		newRemoteStage:    remote.NewRemoteStage,
 	}
 }
@@ -65,6 +69,7 @@ func NewVaultWarden(client kubecluster.ClientInterface) *VaultWarden {
 //     freeze) and dumps it to the DR volume
 //     - the files action syncs the clone into the DR volume's data-vol subdirectory
 //  4. Snapshot the DR volume
+//  5. Export the snapshot to object storage (if configured)
 //
 // The CNPG action is registered before the files action because the database base backup must be taken
 // before the data-directory clone: the clone time is the consistency point, and the database can only
@@ -84,6 +89,11 @@ func (vw *VaultWarden) Backup(ctx *contexts.Context, namespace, backupName, data
 		}
 	}()
 
+	offsiteExport, err := prepareExport(ctx.Child(), vw.kubeClusterClient, namespace, opts.Export)
+	if err != nil {
+		return backup, trace.Wrap(err, "invalid backup configuration")
+	}
+
 	// Create the DR PVC if not exists. Vaultwarden's DR volume holds the synced data directory in addition
 	// to the SQL dump, so size it from the data PVC rather than the CNPG cluster. Default to roughly twice
 	// the data PVC size to fit both captures.
@@ -151,6 +161,10 @@ func (vw *VaultWarden) Backup(ctx *contexts.Context, namespace, backupName, data
 		return backup, trace.Wrap(err, "failed to snapshot the backup volume")
 	}
 
+	if err := offsiteExport.run(ctx.Child(), vw.kubeClusterClient, namespace, backup, vw.newRemoteStage, vw.newExport, opts.CleanupTimeout); err != nil {
+		return backup, trace.Wrap(err, "failed to export the backup")
+	}
+
 	return backup, nil
 }
 
@@ -200,6 +214,7 @@ func (vw *VaultWarden) Restore(ctx *contexts.Context, namespace, restoreName, da
 	if err := cnpgRestore.Configure(vw.kubeClusterClient, namespace, cnpgClusterName, servingCertName, clientCAIssuer, restoreName, vaultwardenSQLFileName, cnpgrestore.CNPGRestoreOptions{
 		PostgresUserCert: opts.PostgresUserCert,
 		CleanupTimeout:   opts.CleanupTimeout,
+		Force:            true, // The restore replaces the app's database, which is expected to exist.
 	}); err != nil {
 		return restore, trace.Wrap(err, "failed to configure CNPG cluster restoration")
 	}
@@ -36,6 +36,7 @@ func TestNewVaultWarden(t *testing.T) {
 	assert.NotNil(t, vw.newCNPGRestore)
 	assert.NotNil(t, vw.newFilesBackup)
 	assert.NotNil(t, vw.newFilesRestore)
+	assert.NotNil(t, vw.newExport)
 	assert.NotNil(t, vw.newRemoteStage)
 }
 
@@ -326,6 +327,7 @@ func TestVaultWardenRestore(t *testing.T) {
 				mockCNPGRestore.EXPECT().Configure(mockClient, namespace, clusterName, servingCertName, clientCAIssuer, restoreName, "dump.sql", cnpgrestore.CNPGRestoreOptions{
 					PostgresUserCert: tt.opts.PostgresUserCert,
 					CleanupTimeout:   tt.opts.CleanupTimeout,
+					Force:            true,
 				}).Return(th.ErrIfTrue(tt.simulateCNPGRestoreError))
 				if tt.simulateCNPGRestoreError {
 					return
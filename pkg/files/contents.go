@@ -0,0 +1,69 @@
+package files
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// The largest file that ReadFile will return. Whole files are sent in a single message, so this is meant
+// for small files such as manifests, and is kept well below the default gRPC message size limit.
+const maxReadFileSize = 1 << 20
+
+// Writes the contents to the file at the path, replacing any existing file. Parent directories are created
+// as needed. The file is written next to its final location and then renamed into place, so a failed write
+// never leaves a truncated file behind.
+func (*LocalRuntime) WriteFile(ctx *contexts.Context, path string, contents []byte) (err error) {
+	ctx.Log.With("path", path).Info("Writing file", "size", len(contents))
+	defer ctx.Log.Info("Finished writing file", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return trace.Errorf("no path provided")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return trace.Wrap(err, "failed to create parent directory for %q", path)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, contents, 0o644); err != nil {
+		return trace.Wrap(err, "failed to write %q", tempPath)
+	}
+
+	return trace.Wrap(os.Rename(tempPath, path), "failed to move %q to %q", tempPath, path)
+}
+
+// Reads the contents of the file at the path. Files larger than 1 MiB are rejected.
+func (*LocalRuntime) ReadFile(ctx *contexts.Context, path string) (contents []byte, err error) {
+	ctx.Log.With("path", path).Info("Reading file")
+	defer ctx.Log.Info("Finished reading file", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, trace.Errorf("no path provided")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to open %q", path)
+	}
+	defer file.Close()
+
+	// Read one byte past the limit, so that a file that is too large can be told apart from one that is
+	// exactly at the limit.
+	contents, err = io.ReadAll(io.LimitReader(file, maxReadFileSize+1))
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to read %q", path)
+	}
+
+	if len(contents) > maxReadFileSize {
+		return nil, trace.LimitExceeded("file %q is larger than %d bytes", path, maxReadFileSize)
+	}
+
+	return contents, nil
+}
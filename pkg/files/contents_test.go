@@ -0,0 +1,117 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	tests := []struct {
+		desc     string
+		path     func(dir string) string
+		existing bool
+		wantErr  bool
+	}{
+		{
+			desc: "new file",
+			path: func(dir string) string { return filepath.Join(dir, "manifest.json") },
+		},
+		{
+			desc: "new file in a missing directory",
+			path: func(dir string) string { return filepath.Join(dir, "a", "b", "manifest.json") },
+		},
+		{
+			desc:     "replaces an existing file",
+			path:     func(dir string) string { return filepath.Join(dir, "manifest.json") },
+			existing: true,
+		},
+		{
+			desc:    "no path",
+			path:    func(dir string) string { return " " },
+			wantErr: true,
+		},
+		{
+			desc:    "path is a directory",
+			path:    func(dir string) string { return dir },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			path := tt.path(t.TempDir())
+			if tt.existing {
+				require.NoError(t, os.WriteFile(path, []byte("old contents that are longer"), 0o600))
+			}
+
+			err := NewLocalRuntime().WriteFile(th.NewTestContext(), path, []byte("contents"))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			contents, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, "contents", string(contents))
+			verifyNotExist(t, path+".tmp")
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.json"), []byte("contents"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "limit"), make([]byte, maxReadFileSize), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "too-large"), make([]byte, maxReadFileSize+1), 0o644))
+
+	tests := []struct {
+		desc             string
+		path             string
+		expectedContents []byte
+		wantErr          bool
+	}{
+		{
+			desc:             "reads the file",
+			path:             filepath.Join(dir, "manifest.json"),
+			expectedContents: []byte("contents"),
+		},
+		{
+			desc:             "file at the size limit",
+			path:             filepath.Join(dir, "limit"),
+			expectedContents: make([]byte, maxReadFileSize),
+		},
+		{
+			desc:    "file over the size limit",
+			path:    filepath.Join(dir, "too-large"),
+			wantErr: true,
+		},
+		{
+			desc:    "missing file",
+			path:    filepath.Join(dir, "missing"),
+			wantErr: true,
+		},
+		{
+			desc:    "no path",
+			path:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			contents, err := NewLocalRuntime().ReadFile(th.NewTestContext(), tt.path)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedContents, contents)
+		})
+	}
+}
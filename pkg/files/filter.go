@@ -1,6 +1,7 @@
 package files
 
 import (
+	"path"
 	"path/filepath"
 	"slices"
 
@@ -17,7 +18,7 @@ type FilePattern struct {
 	// within a single path segment; "**" matches across segments (any depth, including none). So "logs"
 	// matches only a top-level "logs", "**/logs" matches a "logs" at any depth, "**/*.tmp" matches ".tmp"
 	// files anywhere, and "cache/**" matches everything under a top-level "cache".
-	Glob string `yaml:"glob,omitempty"`
+	Glob string `yaml:"glob,omitempty" json:"glob,omitempty"`
 }
 
 // Validate reports whether the pattern is well-formed: exactly one matcher must be set, and it must be
@@ -58,8 +59,8 @@ func (p FilePattern) matches(slashPath string) bool {
 // still reaches the nested files (intermediate directories may be left empty). A zero FileFilter (no
 // patterns) transfers everything.
 type FileFilter struct {
-	Include []FilePattern `yaml:"include,omitempty"`
-	Exclude []FilePattern `yaml:"exclude,omitempty"`
+	Include []FilePattern `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude []FilePattern `yaml:"exclude,omitempty" json:"exclude,omitempty"`
 }
 
 // IsZero reports whether the filter constrains nothing (transfers everything).
@@ -97,6 +98,20 @@ func (f FileFilter) shouldTransfer(relPath string, isDir bool) bool {
 	return matchesAnyPattern(f.Include, slashPath)
 }
 
+// ShouldTransferKey reports whether the file at key (a slash-separated path relative to the sync root, such
+// as an object key relative to a bucket prefix) should be transferred. Keys have no directory entries of
+// their own to prune, so the key is also excluded when any of its parent directories matches an Exclude
+// pattern, as the directory's subtree would be when walking a file tree.
+func (f FileFilter) ShouldTransferKey(key string) bool {
+	for dir := path.Dir(key); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if matchesAnyPattern(f.Exclude, dir) {
+			return false
+		}
+	}
+
+	return f.shouldTransfer(filepath.FromSlash(key), false)
+}
+
 // matchesAnyPattern reports whether slashPath matches any of the patterns.
 func matchesAnyPattern(patterns []FilePattern, slashPath string) bool {
 	return slices.ContainsFunc(patterns, func(pattern FilePattern) bool {
@@ -78,6 +78,31 @@ func TestFileFilterShouldTransfer(t *testing.T) {
 	}
 }
 
+func TestFileFilterShouldTransferKey(t *testing.T) {
+	tests := []struct {
+		desc   string
+		filter FileFilter
+		key    string
+		want   bool
+	}{
+		{desc: "empty filter transfers keys", filter: FileFilter{}, key: "a/b.txt", want: true},
+		{desc: "excluded key", filter: FileFilter{Exclude: globs("**/*.tmp")}, key: "a/b.tmp", want: false},
+		// A key under an excluded "directory" is excluded along with it.
+		{desc: "excluded top-level parent", filter: FileFilter{Exclude: globs("thumbnails")}, key: "thumbnails/a/b.jpg", want: false},
+		{desc: "excluded nested parent", filter: FileFilter{Exclude: globs("**/cache")}, key: "app/cache/x", want: false},
+		{desc: "anchored parent does not match nested", filter: FileFilter{Exclude: globs("cache")}, key: "app/cache/x", want: true},
+		{desc: "included key", filter: FileFilter{Include: globs("media/**")}, key: "media/a.png", want: true},
+		{desc: "not included key", filter: FileFilter{Include: globs("media/**")}, key: "other/a.png", want: false},
+		{desc: "exclude wins over include", filter: FileFilter{Include: globs("media/**"), Exclude: globs("media/thumbnails")}, key: "media/thumbnails/a.png", want: false},
+	}
+
+	for _, tC := range tests {
+		t.Run(tC.desc, func(t *testing.T) {
+			require.Equal(t, tC.want, tC.filter.ShouldTransferKey(tC.key))
+		})
+	}
+}
+
 // writeFile creates a file (and any parent directories) with the given relative path under root.
 func writeFile(t *testing.T, root, relPath string) {
 	t.Helper()
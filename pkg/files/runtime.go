@@ -1,23 +1,56 @@
 package files
 
-import "github.com/solidDoWant/backup-tool/pkg/contexts"
+import (
+	"os/exec"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+)
+
+// CopyFilesOptions are the optional parameters for a file copy.
+type CopyFilesOptions struct {
+	// Limits caps the rate that files are copied at. The zero value is unlimited.
+	Limits throttle.Limits
+}
 
 // SyncFilesOptions are the optional parameters for a file sync.
 type SyncFilesOptions struct {
 	// Filter selects which files are transferred (a whitelist/blacklist). The zero value transfers
 	// everything.
 	Filter FileFilter
+	// Limits caps the rate that files are transferred at. The zero value is unlimited. When files are
+	// copied in parallel, the limits apply to all of the workers combined.
+	Limits throttle.Limits
+	// Parallelism is the number of files that are copied concurrently. This hides per-file latency on
+	// network-backed volumes. Zero (or one) copies one file at a time. Only the file copies are bounded by it:
+	// the copy library starts a goroutine for every entry of a directory before waiting for a free worker, so
+	// copying a directory with a very large number of entries briefly holds that many goroutines. The library
+	// doesn't take a context either, so a cancellation is only noticed when a file's contents start being read.
+	Parallelism int
+	// SQLiteDatabases are the paths (relative to the source directory) of SQLite databases that are captured
+	// with the SQLite online backup API rather than copied, as a copy of a database that is being written to
+	// may be inconsistent. Each one is written to the destination as a single database file, and its
+	// "-wal", "-shm" and "-journal" files are not transferred. Syncing the copy back restores the database.
+	SQLiteDatabases []string
 }
 
 // Represents a place (i.e. local or remote) where commands can run.
 type Runtime interface {
-	CopyFiles(ctx *contexts.Context, src, dest string) error
+	CopyFiles(ctx *contexts.Context, src, dest string, opts CopyFilesOptions) error
 	SyncFiles(ctx *contexts.Context, src, dest string, opts SyncFilesOptions) error
 	ListDirectory(ctx *contexts.Context, path string) ([]string, error)
+	ListTree(ctx *contexts.Context, path string, opts ListTreeOptions) (ListTreeResult, error)
+	WriteFile(ctx *contexts.Context, path string, contents []byte) error
+	ReadFile(ctx *contexts.Context, path string) ([]byte, error)
 }
 
-type LocalRuntime struct{}
+type LocalRuntime struct {
+	// Used for tests to mock running commands
+	runCommand func(cmd *exec.Cmd) error
+}
 
 func NewLocalRuntime() *LocalRuntime {
-	return &LocalRuntime{}
+	return &LocalRuntime{
+		runCommand: (*exec.Cmd).Run,
+	}
 }
@@ -20,17 +20,17 @@ func (_m *MockRuntime) EXPECT() *MockRuntime_Expecter {
 	return &MockRuntime_Expecter{mock: &_m.Mock}
 }
 
-// CopyFiles provides a mock function with given fields: ctx, src, dest
-func (_m *MockRuntime) CopyFiles(ctx *contexts.Context, src string, dest string) error {
-	ret := _m.Called(ctx, src, dest)
+// CopyFiles provides a mock function with given fields: ctx, src, dest, opts
+func (_m *MockRuntime) CopyFiles(ctx *contexts.Context, src string, dest string, opts CopyFilesOptions) error {
+	ret := _m.Called(ctx, src, dest, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CopyFiles")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string) error); ok {
-		r0 = rf(ctx, src, dest)
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, CopyFilesOptions) error); ok {
+		r0 = rf(ctx, src, dest, opts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +47,14 @@ type MockRuntime_CopyFiles_Call struct {
 //   - ctx *contexts.Context
 //   - src string
 //   - dest string
-func (_e *MockRuntime_Expecter) CopyFiles(ctx interface{}, src interface{}, dest interface{}) *MockRuntime_CopyFiles_Call {
-	return &MockRuntime_CopyFiles_Call{Call: _e.mock.On("CopyFiles", ctx, src, dest)}
+//   - opts CopyFilesOptions
+func (_e *MockRuntime_Expecter) CopyFiles(ctx interface{}, src interface{}, dest interface{}, opts interface{}) *MockRuntime_CopyFiles_Call {
+	return &MockRuntime_CopyFiles_Call{Call: _e.mock.On("CopyFiles", ctx, src, dest, opts)}
 }
 
-func (_c *MockRuntime_CopyFiles_Call) Run(run func(ctx *contexts.Context, src string, dest string)) *MockRuntime_CopyFiles_Call {
+func (_c *MockRuntime_CopyFiles_Call) Run(run func(ctx *contexts.Context, src string, dest string, opts CopyFilesOptions)) *MockRuntime_CopyFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*contexts.Context), args[1].(string), args[2].(string))
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(string), args[3].(CopyFilesOptions))
 	})
 	return _c
 }
@@ -63,7 +64,7 @@ func (_c *MockRuntime_CopyFiles_Call) Return(_a0 error) *MockRuntime_CopyFiles_C
 	return _c
 }
 
-func (_c *MockRuntime_CopyFiles_Call) RunAndReturn(run func(*contexts.Context, string, string) error) *MockRuntime_CopyFiles_Call {
+func (_c *MockRuntime_CopyFiles_Call) RunAndReturn(run func(*contexts.Context, string, string, CopyFilesOptions) error) *MockRuntime_CopyFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -127,6 +128,123 @@ func (_c *MockRuntime_ListDirectory_Call) RunAndReturn(run func(*contexts.Contex
 	return _c
 }
 
+// ListTree provides a mock function with given fields: ctx, path, opts
+func (_m *MockRuntime) ListTree(ctx *contexts.Context, path string, opts ListTreeOptions) (ListTreeResult, error) {
+	ret := _m.Called(ctx, path, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTree")
+	}
+
+	var r0 ListTreeResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, ListTreeOptions) (ListTreeResult, error)); ok {
+		return rf(ctx, path, opts)
+	}
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, ListTreeOptions) ListTreeResult); ok {
+		r0 = rf(ctx, path, opts)
+	} else {
+		r0 = ret.Get(0).(ListTreeResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string, ListTreeOptions) error); ok {
+		r1 = rf(ctx, path, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRuntime_ListTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTree'
+type MockRuntime_ListTree_Call struct {
+	*mock.Call
+}
+
+// ListTree is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - path string
+//   - opts ListTreeOptions
+func (_e *MockRuntime_Expecter) ListTree(ctx interface{}, path interface{}, opts interface{}) *MockRuntime_ListTree_Call {
+	return &MockRuntime_ListTree_Call{Call: _e.mock.On("ListTree", ctx, path, opts)}
+}
+
+func (_c *MockRuntime_ListTree_Call) Run(run func(ctx *contexts.Context, path string, opts ListTreeOptions)) *MockRuntime_ListTree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(ListTreeOptions))
+	})
+	return _c
+}
+
+func (_c *MockRuntime_ListTree_Call) Return(_a0 ListTreeResult, _a1 error) *MockRuntime_ListTree_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRuntime_ListTree_Call) RunAndReturn(run func(*contexts.Context, string, ListTreeOptions) (ListTreeResult, error)) *MockRuntime_ListTree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadFile provides a mock function with given fields: ctx, path
+func (_m *MockRuntime) ReadFile(ctx *contexts.Context, path string) ([]byte, error) {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadFile")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string) ([]byte, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string) []byte); ok {
+		r0 = rf(ctx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRuntime_ReadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadFile'
+type MockRuntime_ReadFile_Call struct {
+	*mock.Call
+}
+
+// ReadFile is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - path string
+func (_e *MockRuntime_Expecter) ReadFile(ctx interface{}, path interface{}) *MockRuntime_ReadFile_Call {
+	return &MockRuntime_ReadFile_Call{Call: _e.mock.On("ReadFile", ctx, path)}
+}
+
+func (_c *MockRuntime_ReadFile_Call) Run(run func(ctx *contexts.Context, path string)) *MockRuntime_ReadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRuntime_ReadFile_Call) Return(_a0 []byte, _a1 error) *MockRuntime_ReadFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRuntime_ReadFile_Call) RunAndReturn(run func(*contexts.Context, string) ([]byte, error)) *MockRuntime_ReadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SyncFiles provides a mock function with given fields: ctx, src, dest, opts
 func (_m *MockRuntime) SyncFiles(ctx *contexts.Context, src string, dest string, opts SyncFilesOptions) error {
 	ret := _m.Called(ctx, src, dest, opts)
@@ -176,6 +294,54 @@ func (_c *MockRuntime_SyncFiles_Call) RunAndReturn(run func(*contexts.Context, s
 	return _c
 }
 
+// WriteFile provides a mock function with given fields: ctx, path, contents
+func (_m *MockRuntime) WriteFile(ctx *contexts.Context, path string, contents []byte) error {
+	ret := _m.Called(ctx, path, contents)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, []byte) error); ok {
+		r0 = rf(ctx, path, contents)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRuntime_WriteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteFile'
+type MockRuntime_WriteFile_Call struct {
+	*mock.Call
+}
+
+// WriteFile is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - path string
+//   - contents []byte
+func (_e *MockRuntime_Expecter) WriteFile(ctx interface{}, path interface{}, contents interface{}) *MockRuntime_WriteFile_Call {
+	return &MockRuntime_WriteFile_Call{Call: _e.mock.On("WriteFile", ctx, path, contents)}
+}
+
+func (_c *MockRuntime_WriteFile_Call) Run(run func(ctx *contexts.Context, path string, contents []byte)) *MockRuntime_WriteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockRuntime_WriteFile_Call) Return(_a0 error) *MockRuntime_WriteFile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRuntime_WriteFile_Call) RunAndReturn(run func(*contexts.Context, string, []byte) error) *MockRuntime_WriteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRuntime creates a new instance of MockRuntime. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRuntime(t interface {
@@ -0,0 +1,150 @@
+package files
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// Depending on CLI tools is unfortunate but there are no viable golang replacements for these.
+const sqliteCommandName = "sqlite3"
+
+// The name that a database backup is written to, in the directory of the database's destination, before it
+// is moved into place. A fixed name is used so that the destination path never needs to be quoted in the
+// backup command.
+const sqliteBackupTempFileName = ".backup-tool-sqlite-backup.tmp"
+
+// The suffixes of the files that SQLite keeps next to a database while it is in use. These are never
+// copied alongside a database that is captured with the backup API, as the backup already includes
+// everything that they hold.
+var sqliteSidecarSuffixes = []string{"-wal", "-shm", "-journal"}
+
+// ValidateSQLiteDatabasePaths checks that each SQLite database path is a file path relative to the sync
+// source, that no database is listed more than once, and that the filter doesn't exclude any of them.
+func ValidateSQLiteDatabasePaths(databases []string, filter FileFilter) error {
+	seen := make(map[string]struct{}, len(databases))
+	for _, database := range databases {
+		relPath := filepath.Clean(database)
+		if !filepath.IsLocal(relPath) || relPath == "." {
+			return trace.BadParameter("SQLite database path %q must be a file path relative to the source", database)
+		}
+
+		if _, ok := seen[relPath]; ok {
+			return trace.BadParameter("SQLite database %q is listed more than once", database)
+		}
+		seen[relPath] = struct{}{}
+
+		if !filter.ShouldTransferKey(filepath.ToSlash(relPath)) {
+			return trace.BadParameter("SQLite database %q is excluded by the file filter", database)
+		}
+	}
+
+	return nil
+}
+
+// validateSQLiteDatabases checks the SQLite databases of a sync, before anything is transferred. It
+// returns the paths (relative to the sync source) of the databases and their sidecar files, which must not
+// be copied as regular files.
+func validateSQLiteDatabases(src string, databases []string, filter FileFilter) (map[string]struct{}, error) {
+	if len(databases) == 0 {
+		return nil, nil
+	}
+
+	srcFileInfo, err := os.Stat(src)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to get file info for source path %q", src)
+	}
+
+	if !srcFileInfo.IsDir() {
+		return nil, trace.BadParameter("SQLite databases can only be captured when syncing a directory")
+	}
+
+	if err := ValidateSQLiteDatabasePaths(databases, filter); err != nil {
+		return nil, err
+	}
+
+	skippedPaths := make(map[string]struct{}, len(databases)*(len(sqliteSidecarSuffixes)+1))
+	for _, database := range databases {
+		relPath := filepath.Clean(database)
+		fileInfo, err := os.Lstat(filepath.Join(src, relPath))
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to get file info for SQLite database %q", database)
+		}
+
+		if !fileInfo.Mode().IsRegular() {
+			return nil, trace.BadParameter("SQLite database %q is not a regular file", database)
+		}
+
+		skippedPaths[relPath] = struct{}{}
+		for _, suffix := range sqliteSidecarSuffixes {
+			skippedPaths[relPath+suffix] = struct{}{}
+		}
+	}
+
+	return skippedPaths, nil
+}
+
+// backupSQLiteDatabase writes a consistent copy of the database at relPath within src to the same path
+// within dest, using the SQLite online backup API. The database can be written to while this runs. The
+// copy is a single, self-contained database file, with the mode and owner of the source database.
+func (lr *LocalRuntime) backupSQLiteDatabase(ctx *contexts.Context, src, dest, relPath string) (err error) {
+	srcPath := filepath.Join(src, relPath)
+	destPath := filepath.Join(dest, relPath)
+	ctx.Log.With("src", srcPath, "dest", destPath).Info("Backing up SQLite database")
+	defer ctx.Log.Info("Finished backing up SQLite database", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	destDir := filepath.Dir(destPath)
+	tempPath := filepath.Join(destDir, sqliteBackupTempFileName)
+	// Remove anything left behind by a previous backup that failed part way through
+	if err := os.Remove(tempPath); err != nil && !os.IsNotExist(err) {
+		return trace.Wrap(err, "failed to remove the leftover SQLite backup file %q", tempPath)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	cmd := exec.CommandContext(commandCtx, sqliteCommandName, "-bail", "-batch", srcPath, ".backup '"+sqliteBackupTempFileName+"'")
+	cmd.Dir = destDir
+
+	var output strings.Builder
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := lr.runCommand(cmd); err != nil {
+		return trace.Wrap(err, "process %q failed: %s", sqliteCommandName, output.String())
+	}
+
+	srcFileInfo, err := os.Lstat(srcPath)
+	if err != nil {
+		return trace.Wrap(err, "failed to get file info for SQLite database %q", srcPath)
+	}
+
+	if err := os.Chmod(tempPath, srcFileInfo.Mode().Perm()); err != nil {
+		return trace.Wrap(err, "failed to set the mode of the SQLite backup file %q", tempPath)
+	}
+
+	if stat, ok := srcFileInfo.Sys().(*syscall.Stat_t); ok {
+		if err := os.Lchown(tempPath, int(stat.Uid), int(stat.Gid)); err != nil {
+			return trace.Wrap(err, "failed to set the owner of the SQLite backup file %q", tempPath)
+		}
+	}
+
+	// The backup is only moved into place once it is complete, so a partially written database is never left behind
+	if err := os.Rename(tempPath, destPath); err != nil {
+		return trace.Wrap(err, "failed to move the SQLite backup file %q to %q", tempPath, destPath)
+	}
+
+	return nil
+}
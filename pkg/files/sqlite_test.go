@@ -0,0 +1,223 @@
+package files
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSQLiteDatabases(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "config"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "config", "app.db"), []byte("database"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "cache.db"), []byte("database"), 0644))
+	require.NoError(t, os.Symlink("cache.db", filepath.Join(src, "link.db")))
+
+	tests := []struct {
+		desc          string
+		src           string
+		databases     []string
+		filter        FileFilter
+		expectedPaths map[string]struct{}
+		wantErr       bool
+	}{
+		{
+			desc: "no databases",
+			src:  src,
+		},
+		{
+			desc:      "databases",
+			src:       src,
+			databases: []string{"config/app.db", "./cache.db"},
+			expectedPaths: map[string]struct{}{
+				filepath.Join("config", "app.db"):         {},
+				filepath.Join("config", "app.db-wal"):     {},
+				filepath.Join("config", "app.db-shm"):     {},
+				filepath.Join("config", "app.db-journal"): {},
+
+				"cache.db":         {},
+				"cache.db-wal":     {},
+				"cache.db-shm":     {},
+				"cache.db-journal": {},
+			},
+		},
+		{
+			desc:      "source is not a directory",
+			src:       filepath.Join(src, "cache.db"),
+			databases: []string{"cache.db"},
+			wantErr:   true,
+		},
+		{
+			desc:      "source does not exist",
+			src:       filepath.Join(src, "missing"),
+			databases: []string{"cache.db"},
+			wantErr:   true,
+		},
+		{
+			desc:      "absolute path",
+			src:       src,
+			databases: []string{filepath.Join(src, "cache.db")},
+			wantErr:   true,
+		},
+		{
+			desc:      "path outside of the source",
+			src:       src,
+			databases: []string{"../cache.db"},
+			wantErr:   true,
+		},
+		{
+			desc:      "source root",
+			src:       src,
+			databases: []string{"."},
+			wantErr:   true,
+		},
+		{
+			desc:      "listed more than once",
+			src:       src,
+			databases: []string{"cache.db", "./cache.db"},
+			wantErr:   true,
+		},
+		{
+			desc:      "excluded by the filter",
+			src:       src,
+			databases: []string{"cache.db"},
+			filter:    FileFilter{Exclude: []FilePattern{{Glob: "*.db"}}},
+			wantErr:   true,
+		},
+		{
+			desc:      "directory excluded by the filter",
+			src:       src,
+			databases: []string{"config/app.db"},
+			filter:    FileFilter{Exclude: []FilePattern{{Glob: "config"}}},
+			wantErr:   true,
+		},
+		{
+			desc:      "missing database",
+			src:       src,
+			databases: []string{"missing.db"},
+			wantErr:   true,
+		},
+		{
+			desc:      "directory",
+			src:       src,
+			databases: []string{"config"},
+			wantErr:   true,
+		},
+		{
+			desc:      "symlink",
+			src:       src,
+			databases: []string{"link.db"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			skippedPaths, err := validateSQLiteDatabases(tt.src, tt.databases, tt.filter)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedPaths, skippedPaths)
+		})
+	}
+}
+
+func TestSyncFilesSQLiteDatabases(t *testing.T) {
+	tests := []struct {
+		desc                string
+		simulateBackupError bool
+	}{
+		{
+			desc: "backs up the databases",
+		},
+		{
+			desc:                "backup fails",
+			simulateBackupError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			src := t.TempDir()
+			dest := t.TempDir()
+
+			require.NoError(t, os.MkdirAll(filepath.Join(src, "config"), 0755))
+			for _, name := range []string{"app.db", "app.db-wal", "app.db-shm", "settings.json"} {
+				require.NoError(t, os.WriteFile(filepath.Join(src, "config", name), []byte(name), 0640))
+			}
+
+			// Sidecar files left in the destination by a previous copy must be removed
+			require.NoError(t, os.MkdirAll(filepath.Join(dest, "config"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dest, "config", "app.db-wal"), []byte("stale"), 0644))
+
+			ran := false
+			lr := &LocalRuntime{
+				runCommand: func(cmd *exec.Cmd) error {
+					ran = true
+					assert.Equal(t, sqliteCommandName, filepath.Base(cmd.Path))
+					assert.Equal(t, []string{"-bail", "-batch", filepath.Join(src, "config", "app.db"), ".backup '" + sqliteBackupTempFileName + "'"}, cmd.Args[1:])
+					assert.Equal(t, filepath.Join(dest, "config"), cmd.Dir)
+
+					// The rest of the tree should already have been copied
+					assert.FileExists(t, filepath.Join(dest, "config", "settings.json"))
+
+					require.NoError(t, os.WriteFile(filepath.Join(cmd.Dir, sqliteBackupTempFileName), []byte("backup"), 0600))
+					if tt.simulateBackupError {
+						return assert.AnError
+					}
+
+					return nil
+				},
+			}
+
+			err := lr.SyncFiles(th.NewTestContext(), src, dest, SyncFilesOptions{SQLiteDatabases: []string{"config/app.db"}})
+			require.True(t, ran)
+			verifyNotExist(t, filepath.Join(dest, "config", "app.db-wal"))
+			verifyNotExist(t, filepath.Join(dest, "config", "app.db-shm"))
+			verifyNotExist(t, filepath.Join(dest, "config", sqliteBackupTempFileName))
+
+			if tt.simulateBackupError {
+				require.Error(t, err)
+				verifyNotExist(t, filepath.Join(dest, "config", "app.db"))
+				return
+			}
+
+			require.NoError(t, err)
+
+			contents, err := os.ReadFile(filepath.Join(dest, "config", "app.db"))
+			require.NoError(t, err)
+			require.Equal(t, "backup", string(contents))
+
+			fileInfo, err := os.Lstat(filepath.Join(dest, "config", "app.db"))
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0640), fileInfo.Mode())
+		})
+	}
+}
+
+func TestSyncFilesInvalidSQLiteDatabases(t *testing.T) {
+	src := t.TempDir()
+	dest := t.TempDir()
+	setupSrcTestFileWithContents(t, src, dest)
+
+	lr := &LocalRuntime{
+		runCommand: func(cmd *exec.Cmd) error {
+			require.Fail(t, "no command should be run")
+			return nil
+		},
+	}
+
+	err := lr.SyncFiles(th.NewTestContext(), src, dest, SyncFilesOptions{SQLiteDatabases: []string{"missing.db"}})
+	require.Error(t, err)
+
+	// Nothing should be transferred when the databases are invalid
+	verifyNotExist(t, testFilePath(dest))
+}
@@ -1,6 +1,7 @@
 package files
 
 import (
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -9,19 +10,27 @@ import (
 	"github.com/gravitational/trace"
 	cp "github.com/otiai10/copy"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 )
 
 // Copies the filesytem object (file, directory, etc.) to the destination path.
 // Special files (such as sockets or device files) are not included.
-func (lr *LocalRuntime) CopyFiles(ctx *contexts.Context, src, dest string) (err error) {
-	return lr.copyFiles(ctx, src, dest, FileFilter{})
+func (lr *LocalRuntime) CopyFiles(ctx *contexts.Context, src, dest string, opts CopyFilesOptions) (err error) {
+	if err := opts.Limits.Validate(); err != nil {
+		return trace.Wrap(err, "invalid rate limits")
+	}
+
+	return lr.copyFiles(ctx, src, dest, FileFilter{}, nil, throttle.NewLimiter(opts.Limits), 1)
 }
 
-// copyFiles copies the filesystem object at src to dest, omitting any entry the filter excludes.
+// copyFiles copies the filesystem object at src to dest, omitting any entry the filter excludes, and any
+// entry whose path (relative to src) is in skippedPaths.
+// File contents are read through the limiter, which paces (and counts) the copy. When parallelism is
+// greater than one, up to that many files are copied concurrently.
 // Special files (such as sockets or device files) are not included.
-func (*LocalRuntime) copyFiles(ctx *contexts.Context, src, dest string, filter FileFilter) (err error) {
-	ctx.Log.With("src", src, "dest", dest).Info("Copying files")
-	defer ctx.Log.Info("Finished copying files", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+func (*LocalRuntime) copyFiles(ctx *contexts.Context, src, dest string, filter FileFilter, skippedPaths map[string]struct{}, limiter *throttle.Limiter, parallelism int) (err error) {
+	ctx.Log.With("src", src, "dest", dest, "parallelism", parallelism).Info("Copying files")
+	defer ctx.Log.Info("Finished copying files", ctx.Stopwatch.Keyval(), limiter, contexts.ErrorKeyvals(&err))
 
 	if err := validateSrcDest(src, dest); err != nil {
 		return err
@@ -67,11 +76,22 @@ func (*LocalRuntime) copyFiles(ctx *contexts.Context, src, dest string, filter F
 		PermissionControl: cp.PerservePermission,
 		PreserveTimes:     true,
 		PreserveOwner:     true,
+		// Every regular file's contents are read through this, so it is also where the per-file limit is
+		// applied.
+		WrapReader: func(src io.Reader) io.Reader {
+			return &fileReader{ctx: ctx, limiter: limiter, reader: limiter.Reader(ctx, src)}
+		},
+		// The source tree is still walked once. When this is greater than one, the contents of each
+		// directory are copied concurrently, with at most this many files being copied at a time. A
+		// directory's permissions, owner and times are only applied after all of its children have been
+		// copied, so writing the children can't change the directory's times, and a read-only directory
+		// doesn't block its own children from being written.
+		NumOfWorkers: int64(parallelism),
 	}
 
 	// Only install a Skip callback when the filter actually constrains something, so an unfiltered copy
 	// behaves exactly as before (and skips the per-entry relative-path computation).
-	if !filter.IsZero() {
+	if !filter.IsZero() || len(skippedPaths) > 0 {
 		copyOpts.Skip = func(srcInfo os.FileInfo, itemSrc, _ string) (bool, error) {
 			relPath, err := filepath.Rel(src, itemSrc)
 			if err != nil {
@@ -83,6 +103,10 @@ func (*LocalRuntime) copyFiles(ctx *contexts.Context, src, dest string, filter F
 				return false, nil
 			}
 
+			if _, ok := skippedPaths[relPath]; ok {
+				return true, nil
+			}
+
 			return !filter.shouldTransfer(relPath, srcInfo.IsDir()), nil
 		}
 	}
@@ -95,21 +119,50 @@ func (*LocalRuntime) copyFiles(ctx *contexts.Context, src, dest string, filter F
 // Make the destination path contents match the input directory contents.
 // Special files (such as sockets or device files) are not included.
 func (lr *LocalRuntime) SyncFiles(ctx *contexts.Context, src, dest string, opts SyncFilesOptions) (err error) {
+	limiter := throttle.NewLimiter(opts.Limits)
 	ctx.Log.With("src", src, "dest", dest).Info("Syncing files")
-	defer ctx.Log.Info("Finished syncing files", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+	defer ctx.Log.Info("Finished syncing files", ctx.Stopwatch.Keyval(), limiter, contexts.ErrorKeyvals(&err))
 
 	if err := validateSrcDest(src, dest); err != nil {
 		return err
 	}
 
+	if err := opts.Limits.Validate(); err != nil {
+		return trace.Wrap(err, "invalid rate limits")
+	}
+
+	if opts.Parallelism < 0 {
+		return trace.BadParameter("parallelism must not be negative")
+	}
+
+	// SQLite databases (and the files that SQLite keeps next to them) are not copied as regular files, as a
+	// copy of a database that is being written to may be inconsistent.
+	sqlitePaths, err := validateSQLiteDatabases(src, opts.SQLiteDatabases, opts.Filter)
+	if err != nil {
+		return trace.Wrap(err, "invalid SQLite databases")
+	}
+
 	// Pass the filter so that destination entries which are filtered out (excluded, or not whitelisted)
 	// are removed even when they still exist in the source - the destination must match the filtered view.
-	if err := deleteMissingFiles(ctx.Child(), src, dest, opts.Filter); err != nil {
+	// Stale copies of the SQLite databases' sidecar files are removed the same way.
+	if err := deleteMissingFiles(ctx.Child(), src, dest, opts.Filter, sqlitePaths); err != nil {
 		return trace.Wrap(err, "failed to delete missing files from %q in %q", dest, src)
 	}
 
 	// Copy all (filter-permitted) files
-	return lr.copyFiles(ctx.Child(), src, dest, opts.Filter)
+	if err := lr.copyFiles(ctx.Child(), src, dest, opts.Filter, sqlitePaths, limiter, max(opts.Parallelism, 1)); err != nil {
+		return err
+	}
+
+	// The databases are backed up last so that their directories have already been copied. These are not
+	// subject to the rate limits.
+	for _, database := range opts.SQLiteDatabases {
+		if err := lr.backupSQLiteDatabase(ctx.Child(), src, dest, filepath.Clean(database)); err != nil {
+			return trace.Wrap(err, "failed to back up SQLite database %q", database)
+		}
+	}
+
+	return nil
 }
 
 // Lists the names of the immediate subdirectories of the provided path. Non-directory entries are
@@ -138,7 +191,7 @@ func (*LocalRuntime) ListDirectory(ctx *contexts.Context, path string) (entries
 	return entries, nil
 }
 
-func deleteMissingFiles(ctx *contexts.Context, src, dest string, filter FileFilter) error {
+func deleteMissingFiles(ctx *contexts.Context, src, dest string, filter FileFilter, skippedPaths map[string]struct{}) error {
 	ctx.Log.Info("Deleting files in the destination that are missing from the source")
 	defer ctx.Log.Info("Finished deleting files")
 
@@ -182,7 +235,8 @@ func deleteMissingFiles(ctx *contexts.Context, src, dest string, filter FileFilt
 			// excluded (or, under a whitelist, not included) must be removed from the destination so the
 			// destination matches the filtered view of the source. Directories are always permitted by the
 			// filter when a whitelist is set, so this only prunes excluded subtrees and filtered-out files.
-			if filter.shouldTransfer(relativePath, d.IsDir()) {
+			_, isSkipped := skippedPaths[relativePath]
+			if !isSkipped && filter.shouldTransfer(relativePath, d.IsDir()) {
 				walkerCtx.Log.Debug("File exists in source and passes the filter, skipping", "path", pathInSrc)
 				return nil
 			}
@@ -213,6 +267,26 @@ func deleteMissingFiles(ctx *contexts.Context, src, dest string, filter FileFilt
 	return trace.Wrap(err, "failed while walking over destination directory %q for files to delete", dest)
 }
 
+// fileReader waits for the limiter to permit another file before the first read of a file's contents.
+// The copy library doesn't provide a per-file hook that can fail, so a wait error surfaces as a read error.
+type fileReader struct {
+	ctx     *contexts.Context
+	limiter *throttle.Limiter
+	reader  io.Reader
+	started bool
+}
+
+func (fr *fileReader) Read(p []byte) (int, error) {
+	if !fr.started {
+		if err := fr.limiter.WaitFile(fr.ctx); err != nil {
+			return 0, err
+		}
+		fr.started = true
+	}
+
+	return fr.reader.Read(p)
+}
+
 func validateSrcDest(src, dest string) error {
 	src = strings.TrimSpace(src)
 	if src == "" {
@@ -2,13 +2,16 @@ package files
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"os/user"
 	"path/filepath"
 	"syscall"
 	"testing"
+	"time"
 
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/require"
 )
 
@@ -310,7 +313,7 @@ func TestCopyFiles(t *testing.T) {
 				tC.setup(t, tC.src, tC.dest)
 			}
 
-			err := runtime.CopyFiles(th.NewTestContext(), tC.src, tC.dest)
+			err := runtime.CopyFiles(th.NewTestContext(), tC.src, tC.dest, CopyFilesOptions{})
 			tC.errFunc(t, err)
 
 			if tC.verify != nil {
@@ -374,26 +377,103 @@ func TestSyncFiles(t *testing.T) {
 		},
 	}
 
-	for _, tC := range append(transferTestCases(t), extraTestCases...) {
-		t.Run(tC.desc, func(t *testing.T) {
-			if tC.errFunc == nil {
-				tC.errFunc = require.NoError
+	for _, parallelism := range []int{0, 8} {
+		t.Run(fmt.Sprintf("parallelism %d", parallelism), func(t *testing.T) {
+			for _, tC := range append(transferTestCases(t), extraTestCases...) {
+				t.Run(tC.desc, func(t *testing.T) {
+					if tC.errFunc == nil {
+						tC.errFunc = require.NoError
+					}
+
+					if tC.setup != nil {
+						tC.setup(t, tC.src, tC.dest)
+					}
+
+					err := runtime.SyncFiles(th.NewTestContext(), tC.src, tC.dest, SyncFilesOptions{Parallelism: parallelism})
+					tC.errFunc(t, err)
+
+					if tC.verify != nil {
+						tC.verify(t, tC.src, tC.dest)
+					}
+				})
 			}
+		})
+	}
+}
 
-			if tC.setup != nil {
-				tC.setup(t, tC.src, tC.dest)
-			}
+func TestSyncFilesParallelTree(t *testing.T) {
+	src := t.TempDir()
+	dest := t.TempDir()
+
+	// Build a tree with more files than workers, nested under directories that are read-only and have old
+	// times, so that applying directory metadata before the children were copied would either fail or leave
+	// the times changed.
+	dirTime := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	dirs := []string{"a", filepath.Join("a", "b"), "c"}
+	for _, dir := range dirs {
+		require.NoError(t, os.MkdirAll(filepath.Join(src, dir), 0755))
+		for i := range 10 {
+			require.NoError(t, os.WriteFile(filepath.Join(src, dir, fmt.Sprintf("file-%d", i)), []byte(dir), 0644))
+		}
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		dirPath := filepath.Join(src, dirs[i])
+		require.NoError(t, os.Chmod(dirPath, 0555))
+		require.NoError(t, os.Chtimes(dirPath, dirTime, dirTime))
+	}
+	t.Cleanup(func() {
+		// Allow the temp dir cleanup to remove the read-only trees
+		for _, root := range []string{src, dest} {
+			_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err == nil && d.IsDir() {
+					_ = os.Chmod(path, 0755)
+				}
+				return nil
+			})
+		}
+	})
 
-			err := runtime.SyncFiles(th.NewTestContext(), tC.src, tC.dest, SyncFilesOptions{})
-			tC.errFunc(t, err)
+	err := NewLocalRuntime().SyncFiles(th.NewTestContext(), src, dest, SyncFilesOptions{Parallelism: 4})
+	require.NoError(t, err)
 
-			if tC.verify != nil {
-				tC.verify(t, tC.src, tC.dest)
-			}
-		})
+	for _, dir := range dirs {
+		for i := range 10 {
+			contents, err := os.ReadFile(filepath.Join(dest, dir, fmt.Sprintf("file-%d", i)))
+			require.NoError(t, err)
+			require.Equal(t, dir, string(contents))
+		}
+
+		dirInfo, err := os.Lstat(filepath.Join(dest, dir))
+		require.NoError(t, err)
+		require.Equal(t, os.ModeDir|os.FileMode(0555), dirInfo.Mode())
+		require.True(t, dirTime.Equal(dirInfo.ModTime()), "directory %q has mod time %v", dir, dirInfo.ModTime())
 	}
 }
 
+func TestSyncFilesRejectsNegativeParallelism(t *testing.T) {
+	err := NewLocalRuntime().SyncFiles(th.NewTestContext(), t.TempDir(), t.TempDir(), SyncFilesOptions{Parallelism: -1})
+	require.Error(t, err)
+}
+
+func TestCopyFilesRejectsInvalidLimits(t *testing.T) {
+	err := NewLocalRuntime().CopyFiles(th.NewTestContext(), t.TempDir(), t.TempDir(), CopyFilesOptions{Limits: throttle.Limits{BytesPerSecond: -1}})
+	require.Error(t, err)
+}
+
+func TestSyncFilesWithLimits(t *testing.T) {
+	src := t.TempDir()
+	dest := t.TempDir()
+	setupSrcTestFileWithContents(t, src, dest)
+	setupSrcTestDir(t, src, dest)
+
+	// Limits that are loose enough to not slow the test down should still produce an identical copy
+	err := NewLocalRuntime().SyncFiles(th.NewTestContext(), src, dest, SyncFilesOptions{Limits: throttle.Limits{BytesPerSecond: 1 << 20, FilesPerSecond: 1000}})
+	require.NoError(t, err)
+
+	verifyTestFile(t, src, dest)
+	verityTestDir(t, src, dest)
+}
+
 func TestListDirectory(t *testing.T) {
 	runtime := NewLocalRuntime()
 
@@ -0,0 +1,213 @@
+package files
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// EntryType is the kind of filesystem object a TreeEntry describes.
+type EntryType string
+
+const (
+	EntryTypeFile      EntryType = "file"
+	EntryTypeDirectory EntryType = "directory"
+	EntryTypeSymlink   EntryType = "symlink"
+	// EntryTypeOther covers special files, such as sockets, pipes and device files.
+	EntryTypeOther EntryType = "other"
+)
+
+// TreeEntry describes a single filesystem object found while listing a tree.
+type TreeEntry struct {
+	Path       string // Slash-separated, relative to the listed root
+	Type       EntryType
+	Size       int64       // Size in bytes, as reported by lstat
+	Mode       fs.FileMode // Permission bits, plus the setuid, setgid and sticky bits. Type bits are reported via Type.
+	UID        uint32
+	GID        uint32
+	ModTime    time.Time
+	LinkTarget string // Target of the symlink, when Type is EntryTypeSymlink. Symlinks are never followed.
+}
+
+// ListTreeOptions are the optional parameters for a recursive tree listing.
+type ListTreeOptions struct {
+	// MaxDepth limits how deep the listing descends. Immediate children of the root are at depth 1.
+	// Zero means unlimited.
+	MaxDepth int
+	// Filter selects which entries are listed, with the same semantics as a file sync: an excluded
+	// directory prunes its whole subtree, and directories are always listed when only Include patterns
+	// are set.
+	Filter FileFilter
+	// PageSize limits the number of entries returned per call. Zero means unlimited.
+	PageSize int
+	// PageToken resumes a listing from the NextPageToken returned by a previous call.
+	PageToken string
+}
+
+// ListTreeResult is a single page of a recursive tree listing.
+type ListTreeResult struct {
+	Entries []TreeEntry
+	// NextPageToken is set when there are more entries to list. Pass it as ListTreeOptions.PageToken
+	// to retrieve the next page.
+	NextPageToken string
+}
+
+// Recursively lists the contents of the provided directory, in lexical (depth-first) order. The root
+// itself is not included in the results. Symlinks are reported, but not followed.
+func (*LocalRuntime) ListTree(ctx *contexts.Context, path string, opts ListTreeOptions) (result ListTreeResult, err error) {
+	ctx.Log.With("path", path, "maxDepth", opts.MaxDepth, "pageSize", opts.PageSize).Info("Listing tree")
+	defer ctx.Log.Info("Finished listing tree", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return ListTreeResult{}, trace.Errorf("no path provided")
+	}
+
+	if opts.MaxDepth < 0 {
+		return ListTreeResult{}, trace.BadParameter("max depth must not be negative")
+	}
+
+	if opts.PageSize < 0 {
+		return ListTreeResult{}, trace.BadParameter("page size must not be negative")
+	}
+
+	if err := opts.Filter.Validate(); err != nil {
+		return ListTreeResult{}, trace.Wrap(err, "invalid filter")
+	}
+
+	rootInfo, err := os.Stat(path)
+	if err != nil {
+		return ListTreeResult{}, trace.Wrap(err, "failed to get file info for %q", path)
+	}
+	if !rootInfo.IsDir() {
+		return ListTreeResult{}, trace.BadParameter("path %q is not a directory", path)
+	}
+
+	result.Entries = []TreeEntry{}
+	err = filepath.WalkDir(path, func(entryPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return trace.Wrap(err, "failed to walk over path %q", entryPath)
+		}
+
+		relPath, err := filepath.Rel(path, entryPath)
+		if err != nil {
+			return trace.Wrap(err, "failed to get file path %q relative to %q", entryPath, path)
+		}
+
+		// The root itself is not listed
+		if relPath == "." {
+			return nil
+		}
+
+		slashPath := filepath.ToSlash(relPath)
+		isDir := d.IsDir()
+		depth := strings.Count(slashPath, "/") + 1
+		atMaxDepth := opts.MaxDepth != 0 && depth >= opts.MaxDepth
+
+		// Skip everything that was returned by a previous page. Whole subtrees that precede the token
+		// are pruned rather than walked. The token entry itself is skipped, but if it is a directory
+		// then its contents have not been listed yet.
+		if opts.PageToken != "" {
+			if c := compareTreePaths(slashPath, opts.PageToken); c == 0 {
+				if isDir && atMaxDepth {
+					return filepath.SkipDir
+				}
+				return nil
+			} else if c < 0 {
+				if isDir && !strings.HasPrefix(opts.PageToken, slashPath+"/") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
+		if !opts.Filter.shouldTransfer(relPath, isDir) {
+			if isDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// There is at least one more entry, so the listing needs another page
+		if opts.PageSize != 0 && len(result.Entries) == opts.PageSize {
+			result.NextPageToken = result.Entries[len(result.Entries)-1].Path
+			return filepath.SkipAll
+		}
+
+		entry, err := newTreeEntry(entryPath, slashPath, d)
+		if err != nil {
+			return err
+		}
+		result.Entries = append(result.Entries, entry)
+
+		if isDir && atMaxDepth {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ListTreeResult{}, trace.Wrap(err, "failed while walking over directory %q", path)
+	}
+
+	return result, nil
+}
+
+// newTreeEntry builds the entry describing the filesystem object at entryPath.
+func newTreeEntry(entryPath, slashPath string, d fs.DirEntry) (TreeEntry, error) {
+	info, err := d.Info()
+	if err != nil {
+		return TreeEntry{}, trace.Wrap(err, "failed to get file info for %q", entryPath)
+	}
+
+	entry := TreeEntry{
+		Path:    slashPath,
+		Size:    info.Size(),
+		Mode:    info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky),
+		ModTime: info.ModTime(),
+	}
+
+	switch mode := info.Mode(); {
+	case mode.IsRegular():
+		entry.Type = EntryTypeFile
+	case mode.IsDir():
+		entry.Type = EntryTypeDirectory
+	case mode&fs.ModeSymlink != 0:
+		entry.Type = EntryTypeSymlink
+		entry.LinkTarget, err = os.Readlink(entryPath)
+		if err != nil {
+			return TreeEntry{}, trace.Wrap(err, "failed to read symlink %q", entryPath)
+		}
+	default:
+		entry.Type = EntryTypeOther
+	}
+
+	if linuxStat, ok := info.Sys().(*syscall.Stat_t); ok {
+		entry.UID = linuxStat.Uid
+		entry.GID = linuxStat.Gid
+	}
+
+	return entry, nil
+}
+
+// compareTreePaths orders two slash-separated relative paths the way a lexical depth-first walk visits
+// them: segment by segment, with a directory preceding its contents. It returns a negative number when a
+// is visited before b, zero when they are equal, and a positive number otherwise.
+func compareTreePaths(a, b string) int {
+	aSegments := strings.Split(a, "/")
+	bSegments := strings.Split(b, "/")
+
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		if c := strings.Compare(aSegments[i], bSegments[i]); c != 0 {
+			return c
+		}
+	}
+
+	return len(aSegments) - len(bSegments)
+}
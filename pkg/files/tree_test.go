@@ -0,0 +1,228 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestTree creates the following tree under a new temporary directory:
+//
+//	a/
+//	a/b/
+//	a/b/deep.txt
+//	a/file.log
+//	c.txt -> a/file.log
+//	z.txt
+func setupTestTree(t *testing.T) string {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "deep.txt"), []byte("deep"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "file.log"), []byte("log contents"), 0600))
+	require.NoError(t, os.Symlink(filepath.Join("a", "file.log"), filepath.Join(dir, "c.txt")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "z.txt"), nil, 0644))
+
+	return dir
+}
+
+func treeEntryPaths(entries []TreeEntry) []string {
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+func TestListTree(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          ListTreeOptions
+		expectedPaths []string
+	}{
+		{
+			desc:          "no options lists everything in walk order",
+			expectedPaths: []string{"a", "a/b", "a/b/deep.txt", "a/file.log", "c.txt", "z.txt"},
+		},
+		{
+			desc:          "max depth of one lists only the root's children",
+			opts:          ListTreeOptions{MaxDepth: 1},
+			expectedPaths: []string{"a", "c.txt", "z.txt"},
+		},
+		{
+			desc:          "max depth of two",
+			opts:          ListTreeOptions{MaxDepth: 2},
+			expectedPaths: []string{"a", "a/b", "a/file.log", "c.txt", "z.txt"},
+		},
+		{
+			desc:          "exclude prunes directories",
+			opts:          ListTreeOptions{Filter: FileFilter{Exclude: []FilePattern{{Glob: "a/b"}}}},
+			expectedPaths: []string{"a", "a/file.log", "c.txt", "z.txt"},
+		},
+		{
+			desc:          "include keeps directories",
+			opts:          ListTreeOptions{Filter: FileFilter{Include: []FilePattern{{Glob: "**/*.txt"}}}},
+			expectedPaths: []string{"a", "a/b", "a/b/deep.txt", "c.txt", "z.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir := setupTestTree(t)
+
+			result, err := NewLocalRuntime().ListTree(th.NewTestContext(), dir, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPaths, treeEntryPaths(result.Entries))
+			assert.Empty(t, result.NextPageToken)
+		})
+	}
+}
+
+func TestListTreeEntryMetadata(t *testing.T) {
+	dir := setupTestTree(t)
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "a", "file.log"), modTime, modTime))
+
+	result, err := NewLocalRuntime().ListTree(th.NewTestContext(), dir, ListTreeOptions{})
+	require.NoError(t, err)
+
+	entries := make(map[string]TreeEntry, len(result.Entries))
+	for _, entry := range result.Entries {
+		entries[entry.Path] = entry
+	}
+
+	assert.Equal(t, EntryTypeDirectory, entries["a"].Type)
+
+	file := entries["a/file.log"]
+	assert.Equal(t, EntryTypeFile, file.Type)
+	assert.Equal(t, int64(len("log contents")), file.Size)
+	assert.Equal(t, os.FileMode(0600), file.Mode)
+	assert.True(t, modTime.Equal(file.ModTime))
+	assert.Equal(t, uint32(os.Getuid()), file.UID)
+	assert.Equal(t, uint32(os.Getgid()), file.GID)
+	assert.Empty(t, file.LinkTarget)
+
+	link := entries["c.txt"]
+	assert.Equal(t, EntryTypeSymlink, link.Type)
+	assert.Equal(t, filepath.Join("a", "file.log"), link.LinkTarget)
+}
+
+func TestListTreePagination(t *testing.T) {
+	dir := setupTestTree(t)
+	runtime := NewLocalRuntime()
+
+	for _, pageSize := range []int{1, 2, 4, 6} {
+		var paths []string
+		pageToken := ""
+		pages := 0
+		for {
+			result, err := runtime.ListTree(th.NewTestContext(), dir, ListTreeOptions{PageSize: pageSize, PageToken: pageToken})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(result.Entries), pageSize)
+
+			paths = append(paths, treeEntryPaths(result.Entries)...)
+			pages++
+
+			if result.NextPageToken == "" {
+				break
+			}
+			pageToken = result.NextPageToken
+		}
+
+		assert.Equal(t, []string{"a", "a/b", "a/b/deep.txt", "a/file.log", "c.txt", "z.txt"}, paths, "page size %d", pageSize)
+		assert.Equal(t, (6+pageSize-1)/pageSize, pages, "page size %d", pageSize)
+	}
+}
+
+func TestListTreePaginationWithMaxDepth(t *testing.T) {
+	dir := setupTestTree(t)
+
+	// The page boundary falls on a directory at the maximum depth, which must not be descended into
+	// when the listing resumes
+	result, err := NewLocalRuntime().ListTree(th.NewTestContext(), dir, ListTreeOptions{MaxDepth: 1, PageSize: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, treeEntryPaths(result.Entries))
+	require.Equal(t, "a", result.NextPageToken)
+
+	result, err = NewLocalRuntime().ListTree(th.NewTestContext(), dir, ListTreeOptions{MaxDepth: 1, PageToken: result.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c.txt", "z.txt"}, treeEntryPaths(result.Entries))
+	assert.Empty(t, result.NextPageToken)
+}
+
+func TestListTreeErrors(t *testing.T) {
+	dir := setupTestTree(t)
+
+	tests := []struct {
+		desc string
+		path string
+		opts ListTreeOptions
+	}{
+		{
+			desc: "empty path",
+			path: "  ",
+		},
+		{
+			desc: "nonexistent path",
+			path: filepath.Join(dir, "does-not-exist"),
+		},
+		{
+			desc: "path is a file",
+			path: filepath.Join(dir, "z.txt"),
+		},
+		{
+			desc: "negative max depth",
+			path: dir,
+			opts: ListTreeOptions{MaxDepth: -1},
+		},
+		{
+			desc: "negative page size",
+			path: dir,
+			opts: ListTreeOptions{PageSize: -1},
+		},
+		{
+			desc: "invalid filter",
+			path: dir,
+			opts: ListTreeOptions{Filter: FileFilter{Include: []FilePattern{{Glob: "["}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := NewLocalRuntime().ListTree(th.NewTestContext(), tt.path, tt.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompareTreePaths(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "a", b: "a", expected: 0},
+		{a: "a", b: "a/b", expected: -1},
+		{a: "a/b", b: "a", expected: 1},
+		{a: "a/z", b: "a.txt", expected: -1}, // Lexical string order would put "a.txt" first
+		{a: "b", b: "a/z", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			actual := compareTreePaths(tt.a, tt.b)
+			switch {
+			case tt.expected < 0:
+				assert.Negative(t, actual)
+			case tt.expected > 0:
+				assert.Positive(t, actual)
+			default:
+				assert.Zero(t, actual)
+			}
+		})
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/constants"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
 	"github.com/solidDoWant/backup-tool/pkg/postgres"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -17,17 +20,23 @@ import (
 
 type ClientInterface interface {
 	Files() files.Runtime
+	MySQL() mysql.Runtime
 	Postgres() postgres.Runtime
+	Redis() redis.Runtime
+	Repository() repository.Runtime
 	S3() s3.Runtime
 	Close() error
 }
 
 type Client struct {
-	conn     *grpc.ClientConn
-	files    *FilesClient
-	postgres *PostgresClient
-	s3       *S3Client
-	health   grpc_health_v1.HealthClient
+	conn       *grpc.ClientConn
+	files      *FilesClient
+	mysql      *MySQLClient
+	postgres   *PostgresClient
+	redis      *RedisClient
+	repository *RepositoryClient
+	s3         *S3Client
+	health     grpc_health_v1.HealthClient
 }
 
 func NewClient(ctx *contexts.Context, serverAddress string) (*Client, error) {
@@ -45,11 +54,14 @@ func NewClient(ctx *contexts.Context, serverAddress string) (*Client, error) {
 	}
 
 	return &Client{
-		conn:     conn,
-		files:    NewFilesClient(conn),
-		postgres: NewPostgresClient(conn),
-		s3:       NewS3Client(conn),
-		health:   grpc_health_v1.NewHealthClient(conn),
+		conn:       conn,
+		files:      NewFilesClient(conn),
+		mysql:      NewMySQLClient(conn),
+		postgres:   NewPostgresClient(conn),
+		redis:      NewRedisClient(conn),
+		repository: NewRepositoryClient(conn),
+		s3:         NewS3Client(conn),
+		health:     grpc_health_v1.NewHealthClient(conn),
 	}, nil
 }
 
@@ -107,10 +119,22 @@ func (c *Client) Files() files.Runtime {
 	return c.files
 }
 
+func (c *Client) MySQL() mysql.Runtime {
+	return c.mysql
+}
+
 func (c *Client) Postgres() postgres.Runtime {
 	return c.postgres
 }
 
+func (c *Client) Redis() redis.Runtime {
+	return c.redis
+}
+
+func (c *Client) Repository() repository.Runtime {
+	return c.repository
+}
+
 func (c *Client) S3() s3.Runtime {
 	return c.s3
 }
@@ -88,7 +88,10 @@ func TestNewClient(t *testing.T) {
 			require.NoError(t, err)
 			assert.NotNil(t, client)
 			assert.NotNil(t, client.Files())
+			assert.NotNil(t, client.MySQL())
 			assert.NotNil(t, client.Postgres())
+			assert.NotNil(t, client.Redis())
+			assert.NotNil(t, client.Repository())
 			assert.NotNil(t, client.S3())
 			assert.NotNil(t, client.Health())
 		})
@@ -4,10 +4,17 @@ package clients
 
 import (
 	files "github.com/solidDoWant/backup-tool/pkg/files"
+
 	mock "github.com/stretchr/testify/mock"
 
+	mysql "github.com/solidDoWant/backup-tool/pkg/mysql"
+
 	postgres "github.com/solidDoWant/backup-tool/pkg/postgres"
 
+	redis "github.com/solidDoWant/backup-tool/pkg/redis"
+
+	repository "github.com/solidDoWant/backup-tool/pkg/repository"
+
 	s3 "github.com/solidDoWant/backup-tool/pkg/s3"
 )
 
@@ -116,6 +123,53 @@ func (_c *MockClientInterface_Files_Call) RunAndReturn(run func() files.Runtime)
 	return _c
 }
 
+// MySQL provides a mock function with no fields
+func (_m *MockClientInterface) MySQL() mysql.Runtime {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MySQL")
+	}
+
+	var r0 mysql.Runtime
+	if rf, ok := ret.Get(0).(func() mysql.Runtime); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mysql.Runtime)
+		}
+	}
+
+	return r0
+}
+
+// MockClientInterface_MySQL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MySQL'
+type MockClientInterface_MySQL_Call struct {
+	*mock.Call
+}
+
+// MySQL is a helper method to define mock.On call
+func (_e *MockClientInterface_Expecter) MySQL() *MockClientInterface_MySQL_Call {
+	return &MockClientInterface_MySQL_Call{Call: _e.mock.On("MySQL")}
+}
+
+func (_c *MockClientInterface_MySQL_Call) Run(run func()) *MockClientInterface_MySQL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClientInterface_MySQL_Call) Return(_a0 mysql.Runtime) *MockClientInterface_MySQL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientInterface_MySQL_Call) RunAndReturn(run func() mysql.Runtime) *MockClientInterface_MySQL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Postgres provides a mock function with no fields
 func (_m *MockClientInterface) Postgres() postgres.Runtime {
 	ret := _m.Called()
@@ -163,6 +217,100 @@ func (_c *MockClientInterface_Postgres_Call) RunAndReturn(run func() postgres.Ru
 	return _c
 }
 
+// Redis provides a mock function with no fields
+func (_m *MockClientInterface) Redis() redis.Runtime {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redis")
+	}
+
+	var r0 redis.Runtime
+	if rf, ok := ret.Get(0).(func() redis.Runtime); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(redis.Runtime)
+		}
+	}
+
+	return r0
+}
+
+// MockClientInterface_Redis_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redis'
+type MockClientInterface_Redis_Call struct {
+	*mock.Call
+}
+
+// Redis is a helper method to define mock.On call
+func (_e *MockClientInterface_Expecter) Redis() *MockClientInterface_Redis_Call {
+	return &MockClientInterface_Redis_Call{Call: _e.mock.On("Redis")}
+}
+
+func (_c *MockClientInterface_Redis_Call) Run(run func()) *MockClientInterface_Redis_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClientInterface_Redis_Call) Return(_a0 redis.Runtime) *MockClientInterface_Redis_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientInterface_Redis_Call) RunAndReturn(run func() redis.Runtime) *MockClientInterface_Redis_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Repository provides a mock function with no fields
+func (_m *MockClientInterface) Repository() repository.Runtime {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Repository")
+	}
+
+	var r0 repository.Runtime
+	if rf, ok := ret.Get(0).(func() repository.Runtime); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.Runtime)
+		}
+	}
+
+	return r0
+}
+
+// MockClientInterface_Repository_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Repository'
+type MockClientInterface_Repository_Call struct {
+	*mock.Call
+}
+
+// Repository is a helper method to define mock.On call
+func (_e *MockClientInterface_Expecter) Repository() *MockClientInterface_Repository_Call {
+	return &MockClientInterface_Repository_Call{Call: _e.mock.On("Repository")}
+}
+
+func (_c *MockClientInterface_Repository_Call) Run(run func()) *MockClientInterface_Repository_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockClientInterface_Repository_Call) Return(_a0 repository.Runtime) *MockClientInterface_Repository_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClientInterface_Repository_Call) RunAndReturn(run func() repository.Runtime) *MockClientInterface_Repository_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // S3 provides a mock function with no fields
 func (_m *MockClientInterface) S3() s3.Runtime {
 	ret := _m.Called()
@@ -1,6 +1,8 @@
 package clients
 
 import (
+	"io/fs"
+
 	"github.com/gravitational/trace/trail"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/files"
@@ -33,13 +35,15 @@ func filePatternsToProto(patterns []files.FilePattern) []*files_v1.FilePattern {
 	return protoPatterns
 }
 
-func (fc *FilesClient) CopyFiles(ctx *contexts.Context, src, dest string) error {
+func (fc *FilesClient) CopyFiles(ctx *contexts.Context, src, dest string, opts files.CopyFilesOptions) error {
 	ctx.Log.With("src", src, "dest", dest).Info("Copying files")
 	defer ctx.Log.Info("Finished copying files", ctx.Stopwatch.Keyval())
 
 	request := files_v1.CopyFilesRequest_builder{
-		Source: &src,
-		Dest:   &dest,
+		Source:         &src,
+		Dest:           &dest,
+		BytesPerSecond: &opts.Limits.BytesPerSecond,
+		FilesPerSecond: &opts.Limits.FilesPerSecond,
 	}.Build()
 
 	var header metadata.MD
@@ -52,10 +56,14 @@ func (fc *FilesClient) SyncFiles(ctx *contexts.Context, src, dest string, opts f
 	defer ctx.Log.Info("Finished syncing files", ctx.Stopwatch.Keyval())
 
 	request := files_v1.SyncFilesRequest_builder{
-		Source:  &src,
-		Dest:    &dest,
-		Include: filePatternsToProto(opts.Filter.Include),
-		Exclude: filePatternsToProto(opts.Filter.Exclude),
+		Source:          &src,
+		Dest:            &dest,
+		Include:         filePatternsToProto(opts.Filter.Include),
+		Exclude:         filePatternsToProto(opts.Filter.Exclude),
+		BytesPerSecond:  &opts.Limits.BytesPerSecond,
+		FilesPerSecond:  &opts.Limits.FilesPerSecond,
+		Parallelism:     new(int32(opts.Parallelism)),
+		SqliteDatabases: opts.SQLiteDatabases,
 	}.Build()
 
 	var header metadata.MD
@@ -79,3 +87,91 @@ func (fc *FilesClient) ListDirectory(ctx *contexts.Context, path string) ([]stri
 
 	return response.GetEntries(), nil
 }
+
+// entryTypeFromProto maps a wire tree entry type back onto the local representation.
+func entryTypeFromProto(protoEntryType files_v1.EntryType) files.EntryType {
+	switch protoEntryType {
+	case files_v1.EntryType_ENTRY_TYPE_FILE:
+		return files.EntryTypeFile
+	case files_v1.EntryType_ENTRY_TYPE_DIRECTORY:
+		return files.EntryTypeDirectory
+	case files_v1.EntryType_ENTRY_TYPE_SYMLINK:
+		return files.EntryTypeSymlink
+	default:
+		return files.EntryTypeOther
+	}
+}
+
+// treeEntriesFromProto maps wire tree entries back onto the local representation.
+func treeEntriesFromProto(protoEntries []*files_v1.TreeEntry) []files.TreeEntry {
+	entries := make([]files.TreeEntry, len(protoEntries))
+	for i, protoEntry := range protoEntries {
+		entries[i] = files.TreeEntry{
+			Path:       protoEntry.GetPath(),
+			Type:       entryTypeFromProto(protoEntry.GetType()),
+			Size:       protoEntry.GetSize(),
+			Mode:       fs.FileMode(protoEntry.GetMode()),
+			UID:        protoEntry.GetUid(),
+			GID:        protoEntry.GetGid(),
+			ModTime:    protoEntry.GetModTime().AsTime(),
+			LinkTarget: protoEntry.GetLinkTarget(),
+		}
+	}
+	return entries
+}
+
+func (fc *FilesClient) ListTree(ctx *contexts.Context, path string, opts files.ListTreeOptions) (files.ListTreeResult, error) {
+	ctx.Log.With("path", path, "maxDepth", opts.MaxDepth, "pageSize", opts.PageSize).Info("Listing tree")
+	defer ctx.Log.Info("Finished listing tree", ctx.Stopwatch.Keyval())
+
+	request := files_v1.ListTreeRequest_builder{
+		Path:      &path,
+		MaxDepth:  new(int32(opts.MaxDepth)),
+		Include:   filePatternsToProto(opts.Filter.Include),
+		Exclude:   filePatternsToProto(opts.Filter.Exclude),
+		PageSize:  new(int32(opts.PageSize)),
+		PageToken: &opts.PageToken,
+	}.Build()
+
+	var header metadata.MD
+	response, err := fc.client.ListTree(ctx.Child(), request, grpc.Header(&header))
+	if err != nil {
+		return files.ListTreeResult{}, trail.FromGRPC(err, header)
+	}
+
+	return files.ListTreeResult{
+		Entries:       treeEntriesFromProto(response.GetEntries()),
+		NextPageToken: response.GetNextPageToken(),
+	}, nil
+}
+
+func (fc *FilesClient) WriteFile(ctx *contexts.Context, path string, contents []byte) error {
+	ctx.Log.With("path", path).Info("Writing file")
+	defer ctx.Log.Info("Finished writing file", ctx.Stopwatch.Keyval())
+
+	request := files_v1.WriteFileRequest_builder{
+		Path:     &path,
+		Contents: contents,
+	}.Build()
+
+	var header metadata.MD
+	_, err := fc.client.WriteFile(ctx.Child(), request, grpc.Header(&header))
+	return trail.FromGRPC(err, header)
+}
+
+func (fc *FilesClient) ReadFile(ctx *contexts.Context, path string) ([]byte, error) {
+	ctx.Log.With("path", path).Info("Reading file")
+	defer ctx.Log.Info("Finished reading file", ctx.Stopwatch.Keyval())
+
+	request := files_v1.ReadFileRequest_builder{
+		Path: &path,
+	}.Build()
+
+	var header metadata.MD
+	response, err := fc.client.ReadFile(ctx.Child(), request, grpc.Header(&header))
+	if err != nil {
+		return nil, trail.FromGRPC(err, header)
+	}
+
+	return response.GetContents(), nil
+}
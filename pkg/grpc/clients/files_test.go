@@ -2,13 +2,16 @@ package clients
 
 import (
 	"testing"
+	"time"
 
 	"github.com/solidDoWant/backup-tool/pkg/files"
 	files_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/files/v1"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNewFilesClient(t *testing.T) {
@@ -60,13 +63,14 @@ func FilesTransferTest(t *testing.T, call func(*FilesClient) error, funcName str
 func TestFilesClient_CopyFiles(t *testing.T) {
 	src := "src"
 	dest := "dest"
+	limits := throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10}
 	FilesTransferTest(t,
 		func(fc *FilesClient) error {
 			ctx := th.NewTestContext()
-			return fc.CopyFiles(ctx, src, dest)
+			return fc.CopyFiles(ctx, src, dest, files.CopyFilesOptions{Limits: limits})
 		},
 		"CopyFiles",
-		files_v1.CopyFilesRequest_builder{Source: &src, Dest: &dest}.Build(),
+		files_v1.CopyFilesRequest_builder{Source: &src, Dest: &dest, BytesPerSecond: new(int64(1024)), FilesPerSecond: new(float64(10))}.Build(),
 		&files_v1.CopyFilesResponse{},
 	)
 }
@@ -77,10 +81,10 @@ func TestFilesClient_SyncFiles(t *testing.T) {
 	FilesTransferTest(t,
 		func(fc *FilesClient) error {
 			ctx := th.NewTestContext()
-			return fc.SyncFiles(ctx, src, dest, files.SyncFilesOptions{})
+			return fc.SyncFiles(ctx, src, dest, files.SyncFilesOptions{Parallelism: 8, SQLiteDatabases: []string{"app.db"}})
 		},
 		"SyncFiles",
-		files_v1.SyncFilesRequest_builder{Source: &src, Dest: &dest}.Build(),
+		files_v1.SyncFilesRequest_builder{Source: &src, Dest: &dest, BytesPerSecond: new(int64(0)), FilesPerSecond: new(float64(0)), Parallelism: new(int32(8)), SqliteDatabases: []string{"app.db"}}.Build(),
 		&files_v1.SyncFilesResponse{},
 	)
 }
@@ -114,3 +118,128 @@ func TestFilesClient_ListDirectory(t *testing.T) {
 		mockClient.AssertExpectations(t)
 	})
 }
+
+func TestFilesClient_ListTree(t *testing.T) {
+	path := "path"
+	opts := files.ListTreeOptions{
+		MaxDepth: 2,
+		Filter: files.FileFilter{
+			Include: []files.FilePattern{{Glob: "**/*.db"}},
+		},
+		PageSize:  10,
+		PageToken: "token",
+	}
+	request := files_v1.ListTreeRequest_builder{
+		Path:      &path,
+		MaxDepth:  new(int32(2)),
+		Include:   []*files_v1.FilePattern{files_v1.FilePattern_builder{Glob: new("**/*.db")}.Build()},
+		PageSize:  new(int32(10)),
+		PageToken: new("token"),
+	}.Build()
+
+	t.Run("successful", func(t *testing.T) {
+		modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		response := files_v1.ListTreeResponse_builder{
+			Entries: []*files_v1.TreeEntry{
+				files_v1.TreeEntry_builder{
+					Path:    new("file.db"),
+					Type:    new(files_v1.EntryType_ENTRY_TYPE_FILE),
+					Size:    new(int64(123)),
+					Mode:    new(uint32(0640)),
+					Uid:     new(uint32(1000)),
+					Gid:     new(uint32(2000)),
+					ModTime: timestamppb.New(modTime),
+				}.Build(),
+				files_v1.TreeEntry_builder{
+					Path:       new("link"),
+					Type:       new(files_v1.EntryType_ENTRY_TYPE_SYMLINK),
+					ModTime:    timestamppb.New(modTime),
+					LinkTarget: new("file.db"),
+				}.Build(),
+			},
+			NextPageToken: new("link"),
+		}.Build()
+
+		mockClient := files_v1.NewMockFilesClient()
+		mockClient.On("ListTree", mock.Anything, request, mock.Anything).Return(response, nil)
+
+		fc := &FilesClient{client: mockClient}
+		got, err := fc.ListTree(th.NewTestContext(), path, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, files.ListTreeResult{
+			Entries: []files.TreeEntry{
+				{
+					Path:    "file.db",
+					Type:    files.EntryTypeFile,
+					Size:    123,
+					Mode:    0640,
+					UID:     1000,
+					GID:     2000,
+					ModTime: modTime,
+				},
+				{
+					Path:       "link",
+					Type:       files.EntryTypeSymlink,
+					ModTime:    modTime,
+					LinkTarget: "file.db",
+				},
+			},
+			NextPageToken: "link",
+		}, got)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := files_v1.NewMockFilesClient()
+		mockClient.On("ListTree", mock.Anything, request, mock.Anything).Return(nil, assert.AnError)
+
+		fc := &FilesClient{client: mockClient}
+		got, err := fc.ListTree(th.NewTestContext(), path, opts)
+		assert.Error(t, err)
+		assert.Empty(t, got.Entries)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestFilesClient_WriteFile(t *testing.T) {
+	path := "path"
+	contents := []byte("contents")
+	FilesTransferTest(t,
+		func(fc *FilesClient) error {
+			return fc.WriteFile(th.NewTestContext(), path, contents)
+		},
+		"WriteFile",
+		files_v1.WriteFileRequest_builder{Path: &path, Contents: contents}.Build(),
+		&files_v1.WriteFileResponse{},
+	)
+}
+
+func TestFilesClient_ReadFile(t *testing.T) {
+	path := "path"
+	request := files_v1.ReadFileRequest_builder{Path: &path}.Build()
+
+	t.Run("successful", func(t *testing.T) {
+		contents := []byte("contents")
+		response := files_v1.ReadFileResponse_builder{Contents: contents}.Build()
+
+		mockClient := files_v1.NewMockFilesClient()
+		mockClient.On("ReadFile", mock.Anything, request, mock.Anything).Return(response, nil)
+
+		fc := &FilesClient{client: mockClient}
+		got, err := fc.ReadFile(th.NewTestContext(), path)
+		assert.NoError(t, err)
+		assert.Equal(t, contents, got)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := files_v1.NewMockFilesClient()
+		mockClient.On("ReadFile", mock.Anything, request, mock.Anything).Return(nil, assert.AnError)
+
+		fc := &FilesClient{client: mockClient}
+		got, err := fc.ReadFile(th.NewTestContext(), path)
+		assert.Error(t, err)
+		assert.Nil(t, got)
+		mockClient.AssertExpectations(t)
+	})
+}
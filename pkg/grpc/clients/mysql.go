@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"github.com/gravitational/trace/trail"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	mysql_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/mysql/v1"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type MySQLClient struct {
+	client mysql_v1.MySQLClient
+}
+
+func NewMySQLClient(grpcConnection grpc.ClientConnInterface) *MySQLClient {
+	return &MySQLClient{
+		client: mysql_v1.NewMySQLClient(grpcConnection),
+	}
+}
+
+func encodeMySQLCredentials(credentials mysql.Credentials) *mysql_v1.MySQLCredentials {
+	encodedCredentials := &mysql_v1.MySQLCredentials{}
+	encodedCredentials.SetHost(credentials.Host)
+	encodedCredentials.SetUser(credentials.User)
+
+	if credentials.Port != 0 {
+		encodedCredentials.SetPort(int32(credentials.Port))
+	}
+
+	if credentials.Password != "" {
+		encodedCredentials.SetPassword(credentials.Password)
+	}
+
+	if credentials.SSLMode != "" {
+		encodedCredentials.SetSslMode(string(credentials.SSLMode))
+	}
+
+	if credentials.SSLCAFilePath != "" {
+		encodedCredentials.SetSslCaFilePath(credentials.SSLCAFilePath)
+	}
+
+	return encodedCredentials
+}
+
+func encodeMySQLDumpOptions(opts mysql.DumpOptions) *mysql_v1.MySQLDumpOptions {
+	encodedOpts := &mysql_v1.MySQLDumpOptions{}
+
+	if opts.Consistency != "" {
+		encodedOpts.SetConsistency(string(opts.Consistency))
+	}
+
+	if opts.RecordBinlogPosition {
+		encodedOpts.SetRecordBinlogPosition(true)
+	}
+
+	if len(opts.IncludeDatabases) > 0 {
+		encodedOpts.SetIncludeDatabases(opts.IncludeDatabases)
+	}
+
+	if len(opts.ExcludeDatabases) > 0 {
+		encodedOpts.SetExcludeDatabases(opts.ExcludeDatabases)
+	}
+
+	if len(opts.ExcludeTables) > 0 {
+		encodedOpts.SetExcludeTables(opts.ExcludeTables)
+	}
+
+	return encodedOpts
+}
+
+func (mc *MySQLClient) Dump(ctx *contexts.Context, credentials mysql.Credentials, outputFilePath string, opts mysql.DumpOptions) error {
+	ctx.Log.With("outputFilePath", outputFilePath, "address", credentials.Address(), "username", credentials.User).Info("Dumping databases")
+	defer ctx.Log.Info("Finished dumping databases", ctx.Stopwatch.Keyval())
+
+	request := mysql_v1.MySQLDumpRequest_builder{
+		Credentials:    encodeMySQLCredentials(credentials),
+		OutputFilePath: &outputFilePath,
+		Options:        encodeMySQLDumpOptions(opts),
+	}.Build()
+
+	var header metadata.MD
+	_, err := mc.client.Dump(ctx.Child(), request, grpc.Header(&header))
+	return trail.FromGRPC(err, header)
+}
+
+func encodeMySQLRestoreOptions(opts mysql.RestoreOptions) *mysql_v1.MySQLRestoreOptions {
+	encodedOpts := &mysql_v1.MySQLRestoreOptions{}
+
+	if opts.Force {
+		encodedOpts.SetForce(true)
+	}
+
+	return encodedOpts
+}
+
+func (mc *MySQLClient) Restore(ctx *contexts.Context, credentials mysql.Credentials, inputFilePath string, opts mysql.RestoreOptions) error {
+	ctx.Log.With("inputFilePath", inputFilePath, "address", credentials.Address(), "username", credentials.User).Info("Restoring databases")
+	defer ctx.Log.Info("Finished restoring databases", ctx.Stopwatch.Keyval())
+
+	request := mysql_v1.MySQLRestoreRequest_builder{
+		Credentials:   encodeMySQLCredentials(credentials),
+		InputFilePath: &inputFilePath,
+		Options:       encodeMySQLRestoreOptions(opts),
+	}.Build()
+
+	var header metadata.MD
+	_, err := mc.client.Restore(ctx.Child(), request, grpc.Header(&header))
+	return trail.FromGRPC(err, header)
+}
@@ -0,0 +1,157 @@
+package clients
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	mysql_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/mysql/v1"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+func TestNewMySQLClient(t *testing.T) {
+	client := NewMySQLClient(&grpc.ClientConn{})
+
+	assert.NotNil(t, client)
+	assert.NotNil(t, client.client)
+	assert.Implements(t, (*mysql.Runtime)(nil), client)
+}
+
+func TestEncodeMySQLCredentials(t *testing.T) {
+	assert.Equal(t, mysql_v1.MySQLCredentials_builder{Host: new("db.example.com"), User: new("root")}.Build(),
+		encodeMySQLCredentials(mysql.Credentials{Host: "db.example.com", User: "root"}))
+	assert.Equal(t, mysql_v1.MySQLCredentials_builder{Host: new("db.example.com"), Port: new(int32(3307)), User: new("root"), Password: new("password")}.Build(),
+		encodeMySQLCredentials(mysql.Credentials{Host: "db.example.com", Port: 3307, User: "root", Password: "password"}))
+	assert.Equal(t, mysql_v1.MySQLCredentials_builder{Host: new("db.example.com"), User: new("root"), SslMode: new("VERIFY_IDENTITY"), SslCaFilePath: new("/ca.crt")}.Build(),
+		encodeMySQLCredentials(mysql.Credentials{Host: "db.example.com", User: "root", SSLMode: mysql.SSLModeVerifyIdentity, SSLCAFilePath: "/ca.crt"}))
+}
+
+func TestEncodeMySQLDumpOptions(t *testing.T) {
+	assert.Equal(t, &mysql_v1.MySQLDumpOptions{}, encodeMySQLDumpOptions(mysql.DumpOptions{}))
+	assert.Equal(t, mysql_v1.MySQLDumpOptions_builder{
+		Consistency:          new("lock-all-tables"),
+		RecordBinlogPosition: new(true),
+		IncludeDatabases:     []string{"wiki"},
+		ExcludeTables:        []string{"wiki.cache"},
+	}.Build(), encodeMySQLDumpOptions(mysql.DumpOptions{
+		Consistency:          mysql.ConsistencyLockAllTables,
+		RecordBinlogPosition: true,
+		IncludeDatabases:     []string{"wiki"},
+		ExcludeTables:        []string{"wiki.cache"},
+	}))
+	assert.Equal(t, mysql_v1.MySQLDumpOptions_builder{ExcludeDatabases: []string{"photos"}}.Build(),
+		encodeMySQLDumpOptions(mysql.DumpOptions{ExcludeDatabases: []string{"photos"}}))
+}
+
+func TestEncodeMySQLRestoreOptions(t *testing.T) {
+	assert.Equal(t, &mysql_v1.MySQLRestoreOptions{}, encodeMySQLRestoreOptions(mysql.RestoreOptions{}))
+	assert.Equal(t, mysql_v1.MySQLRestoreOptions_builder{Force: new(true)}.Build(), encodeMySQLRestoreOptions(mysql.RestoreOptions{Force: true}))
+}
+
+func TestMySQLDump(t *testing.T) {
+	credentials := mysql.Credentials{Host: "db.example.com", User: "root", Password: "password"}
+	opts := mysql.DumpOptions{IncludeDatabases: []string{"wiki"}}
+
+	tests := []struct {
+		name          string
+		mockResponse  *mysql_v1.MySQLDumpResponse
+		mockError     error
+		expectedError bool
+	}{
+		{
+			name:         "successful dump",
+			mockResponse: &mysql_v1.MySQLDumpResponse{},
+		},
+		{
+			name:          "grpc error",
+			mockError:     fmt.Errorf("grpc error"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mysql_v1.NewMockMySQLClient()
+			ctx := th.NewTestContext()
+			outputPath := "/tmp/dump.sql"
+
+			expectedRequest := mysql_v1.MySQLDumpRequest_builder{
+				Credentials:    encodeMySQLCredentials(credentials),
+				OutputFilePath: &outputPath,
+				Options:        encodeMySQLDumpOptions(opts),
+			}.Build()
+			mockClient.On("Dump", mock.Anything, expectedRequest, mock.Anything).
+				Run(func(args mock.Arguments) {
+					calledCtx := args.Get(0).(*contexts.Context)
+					assert.True(t, calledCtx.IsChildOf(ctx))
+				}).
+				Return(tt.mockResponse, tt.mockError)
+
+			mc := &MySQLClient{client: mockClient}
+			err := mc.Dump(ctx, credentials, outputPath, opts)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMySQLRestore(t *testing.T) {
+	credentials := mysql.Credentials{Host: "db.example.com", User: "root", Password: "password"}
+	opts := mysql.RestoreOptions{Force: true}
+
+	tests := []struct {
+		name          string
+		mockResponse  *mysql_v1.MySQLRestoreResponse
+		mockError     error
+		expectedError bool
+	}{
+		{
+			name:         "successful restore",
+			mockResponse: &mysql_v1.MySQLRestoreResponse{},
+		},
+		{
+			name:          "grpc error",
+			mockError:     fmt.Errorf("grpc error"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := mysql_v1.NewMockMySQLClient()
+			ctx := th.NewTestContext()
+			inputPath := "/tmp/dump.sql"
+
+			expectedRequest := mysql_v1.MySQLRestoreRequest_builder{
+				Credentials:   encodeMySQLCredentials(credentials),
+				InputFilePath: &inputPath,
+				Options:       encodeMySQLRestoreOptions(opts),
+			}.Build()
+			mockClient.On("Restore", mock.Anything, expectedRequest, mock.Anything).
+				Run(func(args mock.Arguments) {
+					calledCtx := args.Get(0).(*contexts.Context)
+					assert.True(t, calledCtx.IsChildOf(ctx))
+				}).
+				Return(tt.mockResponse, tt.mockError)
+
+			mc := &MySQLClient{client: mockClient}
+			err := mc.Restore(ctx, credentials, inputPath, opts)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -69,9 +69,74 @@ func encodePostgresDumpAllOptions(opts postgres.DumpAllOptions) *postgres_v1.Dum
 		encodedOpts.SetCleanupTimeout(durationpb.New(time.Duration(opts.CleanupTimeout)))
 	}
 
+	if opts.Format != "" {
+		encodedOpts.SetFormat(string(opts.Format))
+	}
+
+	if opts.Jobs != 0 {
+		encodedOpts.SetJobs(int32(opts.Jobs))
+	}
+
+	if len(opts.Selection.IncludeDatabases) > 0 {
+		encodedOpts.SetIncludeDatabases(opts.Selection.IncludeDatabases)
+	}
+
+	if len(opts.Selection.ExcludeDatabases) > 0 {
+		encodedOpts.SetExcludeDatabases(opts.Selection.ExcludeDatabases)
+	}
+
+	if len(opts.Selection.Databases) > 0 {
+		encodedFilters := make([]*postgres_v1.DatabaseFilter, 0, len(opts.Selection.Databases))
+		for _, filter := range opts.Selection.Databases {
+			encodedFilters = append(encodedFilters, encodePostgresDatabaseFilter(filter))
+		}
+		encodedOpts.SetDatabases(encodedFilters)
+	}
+
+	if !opts.Roles.IsZero() {
+		encodedOpts.SetRoles(encodePostgresRoleHandling(opts.Roles))
+	}
+
 	return encodedOpts
 }
 
+func encodePostgresRoleHandling(handling postgres.RoleHandling) *postgres_v1.RoleHandling {
+	encodedHandling := &postgres_v1.RoleHandling{}
+
+	if len(handling.SkipRoles) > 0 {
+		encodedHandling.SetSkipRoles(handling.SkipRoles)
+	}
+
+	if len(handling.RenameRoles) > 0 {
+		// Sorted so that the encoding is deterministic.
+		encodedRenames := make([]*postgres_v1.RoleHandling_RoleRename, 0, len(handling.RenameRoles))
+		for _, role := range slices.Sorted(maps.Keys(handling.RenameRoles)) {
+			encodedRenames = append(encodedRenames, postgres_v1.RoleHandling_RoleRename_builder{
+				Name:    &role,
+				NewName: new(handling.RenameRoles[role]),
+			}.Build())
+		}
+		encodedHandling.SetRenameRoles(encodedRenames)
+	}
+
+	if handling.StripPasswords {
+		encodedHandling.SetStripPasswords(true)
+	}
+
+	return encodedHandling
+}
+
+func encodePostgresDatabaseFilter(filter postgres.DatabaseFilter) *postgres_v1.DatabaseFilter {
+	return postgres_v1.DatabaseFilter_builder{
+		Name:             &filter.Name,
+		IncludeSchemas:   filter.IncludeSchemas,
+		ExcludeSchemas:   filter.ExcludeSchemas,
+		IncludeTables:    filter.IncludeTables,
+		ExcludeTables:    filter.ExcludeTables,
+		SchemaOnlyTables: filter.SchemaOnlyTables,
+	}.Build()
+}
+
 func (pc *PostgresClient) DumpAll(ctx *contexts.Context, credentials postgres.Credentials, outputFilePath string, opts postgres.DumpAllOptions) error {
 	ctx.Log.With("outputFilePath", outputFilePath, "address", postgres.GetServerAddress(credentials), "username", credentials.GetUsername()).Info("Dumping all databases")
 	defer ctx.Log.Info("Finished dumping databases", ctx.Stopwatch.Keyval())
@@ -92,8 +157,50 @@ func (pc *PostgresClient) DumpAll(ctx *contexts.Context, credentials postgres.Cr
 	return trail.FromGRPC(err, header)
 }
 
-func encodePostgresRestoreOptions(_ postgres.RestoreOptions) *postgres_v1.RestoreOptions {
-	return &postgres_v1.RestoreOptions{}
+func encodePostgresRestoreOptions(opts postgres.RestoreOptions) *postgres_v1.RestoreOptions {
+	encodedOpts := &postgres_v1.RestoreOptions{}
+
+	if opts.Format != "" {
+		encodedOpts.SetFormat(string(opts.Format))
+	}
+
+	if opts.Jobs != 0 {
+		encodedOpts.SetJobs(int32(opts.Jobs))
+	}
+
+	if len(opts.Selection.Databases) > 0 {
+		encodedOpts.SetDatabases(opts.Selection.Databases)
+	}
+
+	if len(opts.Selection.Schemas) > 0 {
+		encodedOpts.SetSchemas(opts.Selection.Schemas)
+	}
+
+	if len(opts.Selection.Tables) > 0 {
+		encodedOpts.SetTables(opts.Selection.Tables)
+	}
+
+	if opts.Selection.TargetDatabase != "" {
+		encodedOpts.SetTargetDatabase(opts.Selection.TargetDatabase)
+	}
+
+	if opts.StopOnError {
+		encodedOpts.SetStopOnError(true)
+	}
+
+	if opts.SingleTransaction {
+		encodedOpts.SetSingleTransaction(true)
+	}
+
+	if opts.DropAndRecreate {
+		encodedOpts.SetDropAndRecreate(true)
+	}
+
+	if opts.Force {
+		encodedOpts.SetForce(true)
+	}
+
+	return encodedOpts
 }
 
 func (pc *PostgresClient) Restore(ctx *contexts.Context, credentials postgres.Credentials, inputFilePath string, opts postgres.RestoreOptions) error {
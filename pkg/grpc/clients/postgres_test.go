@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
@@ -41,6 +42,11 @@ func TestEncodePostgresCredentialVariable(t *testing.T) {
 			variable: postgres.HostVarName,
 			want:     postgres_v1.VarName_PGHOST,
 		},
+		{
+			name:     "password variable",
+			variable: postgres.PasswordVarName,
+			want:     postgres_v1.VarName_PGPASSWORD,
+		},
 		{
 			name:     "invalid variable",
 			variable: "INVALID_VAR",
@@ -141,12 +147,65 @@ func TestEncodePostgresDumpAllOptions(t *testing.T) {
 				CleanupTimeout: durationpb.New(5 * time.Second),
 			}.Build(),
 		},
+		{
+			name: "directory format with jobs",
+			opts: postgres.DumpAllOptions{Format: postgres.DumpFormatDirectory, Jobs: 4},
+			want: postgres_v1.DumpAllOptions_builder{
+				Format: new("directory"),
+				Jobs:   new(int32(4)),
+			}.Build(),
+		},
+		{
+			name: "database selection",
+			opts: postgres.DumpAllOptions{
+				Format: postgres.DumpFormatDirectory,
+				Selection: postgres.DatabaseSelection{
+					IncludeDatabases: []string{"app", "analytics"},
+					ExcludeDatabases: []string{"analytics"},
+					Databases: []postgres.DatabaseFilter{
+						{Name: "app", ExcludeSchemas: []string{"reporting"}, SchemaOnlyTables: []string{"public.sessions"}},
+					},
+				},
+			},
+			want: postgres_v1.DumpAllOptions_builder{
+				Format:           new("directory"),
+				IncludeDatabases: []string{"app", "analytics"},
+				ExcludeDatabases: []string{"analytics"},
+				Databases: []*postgres_v1.DatabaseFilter{
+					postgres_v1.DatabaseFilter_builder{
+						Name:             new("app"),
+						ExcludeSchemas:   []string{"reporting"},
+						SchemaOnlyTables: []string{"public.sessions"},
+					}.Build(),
+				},
+			}.Build(),
+		},
+		{
+			name: "role handling",
+			opts: postgres.DumpAllOptions{
+				Roles: postgres.RoleHandling{
+					SkipRoles:      []string{"app"},
+					RenameRoles:    map[string]string{"reporting": "reporting_ro", "admin": "administrator"},
+					StripPasswords: true,
+				},
+			},
+			want: postgres_v1.DumpAllOptions_builder{
+				Roles: postgres_v1.RoleHandling_builder{
+					SkipRoles: []string{"app"},
+					RenameRoles: []*postgres_v1.RoleHandling_RoleRename{
+						postgres_v1.RoleHandling_RoleRename_builder{Name: new("admin"), NewName: new("administrator")}.Build(),
+						postgres_v1.RoleHandling_RoleRename_builder{Name: new("reporting"), NewName: new("reporting_ro")}.Build(),
+					},
+					StripPasswords: new(true),
+				}.Build(),
+			}.Build(),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := encodePostgresDumpAllOptions(tt.opts)
-			assert.Equal(t, tt.want, got)
+			assert.True(t, proto.Equal(tt.want, got))
 		})
 	}
 }
@@ -236,6 +295,23 @@ func TestDumpAll(t *testing.T) {
 
 func TestEncodePostgresRestoreOptions(t *testing.T) {
 	assert.Equal(t, &postgres_v1.RestoreOptions{}, encodePostgresRestoreOptions(postgres.RestoreOptions{}))
+	assert.Equal(t, postgres_v1.RestoreOptions_builder{Format: new("directory"), Jobs: new(int32(4))}.Build(),
+		encodePostgresRestoreOptions(postgres.RestoreOptions{Format: postgres.DumpFormatDirectory, Jobs: 4}))
+
+	selection := postgres.RestoreSelection{Databases: []string{"app"}, Schemas: []string{"audit"}, Tables: []string{"public.users"}, TargetDatabase: "app_recovered"}
+	assert.Equal(t, postgres_v1.RestoreOptions_builder{
+		Databases:      []string{"app"},
+		Schemas:        []string{"audit"},
+		Tables:         []string{"public.users"},
+		TargetDatabase: new("app_recovered"),
+	}.Build(), encodePostgresRestoreOptions(postgres.RestoreOptions{Selection: selection}))
+
+	assert.Equal(t, postgres_v1.RestoreOptions_builder{
+		StopOnError:       new(true),
+		SingleTransaction: new(true),
+		DropAndRecreate:   new(true),
+		Force:             new(true),
+	}.Build(), encodePostgresRestoreOptions(postgres.RestoreOptions{StopOnError: true, SingleTransaction: true, DropAndRecreate: true, Force: true}))
 }
 
 func TestRestore(t *testing.T) {
@@ -0,0 +1,53 @@
+package clients
+
+import (
+	"github.com/gravitational/trace/trail"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	redis_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/redis/v1"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type RedisClient struct {
+	client redis_v1.RedisClient
+}
+
+func NewRedisClient(grpcConnection grpc.ClientConnInterface) *RedisClient {
+	return &RedisClient{
+		client: redis_v1.NewRedisClient(grpcConnection),
+	}
+}
+
+func encodeRedisCredentials(credentials redis.Credentials) *redis_v1.RedisCredentials {
+	encodedCredentials := &redis_v1.RedisCredentials{}
+	encodedCredentials.SetHost(credentials.Host)
+
+	if credentials.Port != 0 {
+		encodedCredentials.SetPort(int32(credentials.Port))
+	}
+
+	if credentials.User != "" {
+		encodedCredentials.SetUser(credentials.User)
+	}
+
+	if credentials.Password != "" {
+		encodedCredentials.SetPassword(credentials.Password)
+	}
+
+	return encodedCredentials
+}
+
+func (rc *RedisClient) Snapshot(ctx *contexts.Context, credentials redis.Credentials, outputFilePath string) error {
+	ctx.Log.With("outputFilePath", outputFilePath, "address", credentials.Address(), "username", credentials.User).Info("Snapshotting dataset")
+	defer ctx.Log.Info("Finished snapshotting dataset", ctx.Stopwatch.Keyval())
+
+	request := redis_v1.RedisSnapshotRequest_builder{
+		Credentials:    encodeRedisCredentials(credentials),
+		OutputFilePath: &outputFilePath,
+	}.Build()
+
+	var header metadata.MD
+	_, err := rc.client.Snapshot(ctx.Child(), request, grpc.Header(&header))
+	return trail.FromGRPC(err, header)
+}
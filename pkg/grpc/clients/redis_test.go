@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	redis_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/redis/v1"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+func TestNewRedisClient(t *testing.T) {
+	client := NewRedisClient(&grpc.ClientConn{})
+
+	assert.NotNil(t, client)
+	assert.NotNil(t, client.client)
+	assert.Implements(t, (*redis.Runtime)(nil), client)
+}
+
+func TestEncodeRedisCredentials(t *testing.T) {
+	assert.Equal(t, redis_v1.RedisCredentials_builder{Host: new("valkey.example.com")}.Build(),
+		encodeRedisCredentials(redis.Credentials{Host: "valkey.example.com"}))
+	assert.Equal(t, redis_v1.RedisCredentials_builder{Host: new("valkey.example.com"), Port: new(int32(6380)), User: new("backup"), Password: new("password")}.Build(),
+		encodeRedisCredentials(redis.Credentials{Host: "valkey.example.com", Port: 6380, User: "backup", Password: "password"}))
+}
+
+func TestRedisSnapshot(t *testing.T) {
+	credentials := redis.Credentials{Host: "valkey.example.com", Password: "password"}
+
+	tests := []struct {
+		name          string
+		mockResponse  *redis_v1.RedisSnapshotResponse
+		mockError     error
+		expectedError bool
+	}{
+		{
+			name:         "successful snapshot",
+			mockResponse: &redis_v1.RedisSnapshotResponse{},
+		},
+		{
+			name:          "grpc error",
+			mockError:     fmt.Errorf("grpc error"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := redis_v1.NewMockRedisClient()
+			ctx := th.NewTestContext()
+			outputPath := "/tmp/cache.rdb"
+
+			expectedRequest := redis_v1.RedisSnapshotRequest_builder{
+				Credentials:    encodeRedisCredentials(credentials),
+				OutputFilePath: &outputPath,
+			}.Build()
+			mockClient.On("Snapshot", mock.Anything, expectedRequest, mock.Anything).
+				Run(func(args mock.Arguments) {
+					calledCtx := args.Get(0).(*contexts.Context)
+					assert.True(t, calledCtx.IsChildOf(ctx))
+				}).
+				Return(tt.mockResponse, tt.mockError)
+
+			rc := &RedisClient{client: mockClient}
+			err := rc.Snapshot(ctx, credentials, outputPath)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
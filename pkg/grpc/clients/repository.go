@@ -0,0 +1,173 @@
+package clients
+
+import (
+	"github.com/gravitational/trace"
+	"github.com/gravitational/trace/trail"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	repository_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/repository/v1"
+	s3_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type RepositoryClient struct {
+	client repository_v1.RepositoryClient
+}
+
+func NewRepositoryClient(grpcConnection grpc.ClientConnInterface) *RepositoryClient {
+	return &RepositoryClient{
+		client: repository_v1.NewRepositoryClient(grpcConnection),
+	}
+}
+
+// encodedRepositoryCredentials encodes the credentials for the server as the S3 client does, leaving them unset
+// for local repositories.
+func encodedRepositoryCredentials(credentials s3.CredentialsInterface) (*s3_v1.Credentials, error) {
+	if credentials == nil {
+		return nil, nil
+	}
+
+	return encodedS3Credentials(credentials)
+}
+
+func snapshotFromProto(protoSnapshot *repository_v1.Snapshot) repository.Snapshot {
+	return repository.Snapshot{
+		ID:         protoSnapshot.GetId(),
+		Name:       protoSnapshot.GetName(),
+		Tags:       protoSnapshot.GetTags(),
+		Time:       protoSnapshot.GetTime().AsTime(),
+		Parent:     protoSnapshot.GetParent(),
+		FileCount:  protoSnapshot.GetFileCount(),
+		Size:       protoSnapshot.GetSize(),
+		AddedBytes: protoSnapshot.GetAddedBytes(),
+		Retention:  retentionFromProto(protoSnapshot),
+	}
+}
+
+func retentionFromProto(protoSnapshot *repository_v1.Snapshot) s3.ObjectRetention {
+	retention := s3.ObjectRetention{
+		Mode:      s3.ObjectLockMode(protoSnapshot.GetRetentionMode()),
+		LegalHold: protoSnapshot.GetLegalHold(),
+	}
+	if protoSnapshot.HasRetainUntil() {
+		retention.RetainUntil = protoSnapshot.GetRetainUntil().AsTime()
+	}
+
+	return retention
+}
+
+func (rc *RepositoryClient) Backup(ctx *contexts.Context, credentials s3.CredentialsInterface, repositoryPath, src string, opts repository.BackupOptions) (repository.Snapshot, error) {
+	ctx.Log.With("repository", repositoryPath, "src", src, "name", opts.Name).Info("Backing up to repository")
+	defer ctx.Log.Info("Finished backing up to repository", ctx.Stopwatch.Keyval())
+
+	encodedCredentials, err := encodedRepositoryCredentials(credentials)
+	if err != nil {
+		return repository.Snapshot{}, trace.Wrap(err, "failed to encode credentials")
+	}
+
+	objectLockMode := string(opts.ObjectLock.Mode)
+	request := repository_v1.BackupRequest_builder{
+		Credentials:         encodedCredentials,
+		Repository:          &repositoryPath,
+		Source:              &src,
+		Name:                &opts.Name,
+		Tags:                opts.Tags,
+		Full:                &opts.Full,
+		BytesPerSecond:      &opts.Limits.BytesPerSecond,
+		FilesPerSecond:      &opts.Limits.FilesPerSecond,
+		ObjectLockMode:      &objectLockMode,
+		ObjectLockRetention: durationpb.New(opts.ObjectLock.Retention),
+		ObjectLockLegalHold: &opts.ObjectLock.LegalHold,
+	}.Build()
+
+	var header metadata.MD
+	response, err := rc.client.Backup(ctx.Child(), request, grpc.Header(&header))
+	if err != nil {
+		return repository.Snapshot{}, trail.FromGRPC(err, header)
+	}
+
+	return snapshotFromProto(response.GetSnapshot()), nil
+}
+
+func (rc *RepositoryClient) Restore(ctx *contexts.Context, credentials s3.CredentialsInterface, repositoryPath, snapshotID, dest string, opts repository.RestoreOptions) error {
+	ctx.Log.With("repository", repositoryPath, "snapshot", snapshotID, "dest", dest).Info("Restoring from repository")
+	defer ctx.Log.Info("Finished restoring from repository", ctx.Stopwatch.Keyval())
+
+	encodedCredentials, err := encodedRepositoryCredentials(credentials)
+	if err != nil {
+		return trace.Wrap(err, "failed to encode credentials")
+	}
+
+	request := repository_v1.RestoreSnapshotRequest_builder{
+		Credentials:    encodedCredentials,
+		Repository:     &repositoryPath,
+		SnapshotId:     &snapshotID,
+		Dest:           &dest,
+		BytesPerSecond: &opts.Limits.BytesPerSecond,
+		FilesPerSecond: &opts.Limits.FilesPerSecond,
+	}.Build()
+
+	var header metadata.MD
+	_, err = rc.client.RestoreSnapshot(ctx.Child(), request, grpc.Header(&header))
+	return trail.FromGRPC(err, header)
+}
+
+func (rc *RepositoryClient) ListSnapshots(ctx *contexts.Context, credentials s3.CredentialsInterface, repositoryPath string) ([]repository.Snapshot, error) {
+	ctx.Log.With("repository", repositoryPath).Info("Listing repository snapshots")
+	defer ctx.Log.Info("Finished listing repository snapshots", ctx.Stopwatch.Keyval())
+
+	encodedCredentials, err := encodedRepositoryCredentials(credentials)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to encode credentials")
+	}
+
+	request := repository_v1.ListSnapshotsRequest_builder{
+		Credentials: encodedCredentials,
+		Repository:  &repositoryPath,
+	}.Build()
+
+	var header metadata.MD
+	response, err := rc.client.ListSnapshots(ctx.Child(), request, grpc.Header(&header))
+	if err != nil {
+		return nil, trail.FromGRPC(err, header)
+	}
+
+	protoSnapshots := response.GetSnapshots()
+	snapshots := make([]repository.Snapshot, len(protoSnapshots))
+	for i, protoSnapshot := range protoSnapshots {
+		snapshots[i] = snapshotFromProto(protoSnapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (rc *RepositoryClient) Prune(ctx *contexts.Context, credentials s3.CredentialsInterface, repositoryPath string, opts repository.PruneOptions) (repository.PruneResult, error) {
+	ctx.Log.With("repository", repositoryPath, "keepLast", opts.KeepLast).Info("Pruning repository")
+	defer ctx.Log.Info("Finished pruning repository", ctx.Stopwatch.Keyval())
+
+	encodedCredentials, err := encodedRepositoryCredentials(credentials)
+	if err != nil {
+		return repository.PruneResult{}, trace.Wrap(err, "failed to encode credentials")
+	}
+
+	request := repository_v1.PruneRequest_builder{
+		Credentials: encodedCredentials,
+		Repository:  &repositoryPath,
+		KeepLast:    new(int64(opts.KeepLast)),
+	}.Build()
+
+	var header metadata.MD
+	response, err := rc.client.Prune(ctx.Child(), request, grpc.Header(&header))
+	if err != nil {
+		return repository.PruneResult{}, trail.FromGRPC(err, header)
+	}
+
+	return repository.PruneResult{
+		RemovedSnapshots: response.GetRemovedSnapshots(),
+		DeletedPacks:     int(response.GetDeletedPacks()),
+		WrittenPacks:     int(response.GetWrittenPacks()),
+	}, nil
+}
@@ -0,0 +1,315 @@
+package clients
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	repository_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/repository/v1"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewRepositoryClient(t *testing.T) {
+	mockConn := &grpc.ClientConn{}
+
+	client := NewRepositoryClient(mockConn)
+
+	assert.NotNil(t, client)
+	assert.NotNil(t, client.client)
+	assert.Implements(t, (*repository.Runtime)(nil), client)
+}
+
+func TestEncodedRepositoryCredentials(t *testing.T) {
+	t.Run("no credentials", func(t *testing.T) {
+		encodedCredentials, err := encodedRepositoryCredentials(nil)
+		require.NoError(t, err)
+		assert.Nil(t, encodedCredentials)
+	})
+
+	t.Run("access keys", func(t *testing.T) {
+		credentials := s3.NewCredentials("accessKeyID", "secretAccessKey").
+			WithSessionToken("sessionToken").
+			WithRegion("region").
+			WithEndpoint("endpoint").
+			WithS3ForcePathStyle(true)
+
+		encodedCredentials, err := encodedRepositoryCredentials(credentials)
+		require.NoError(t, err)
+		assert.Equal(t, "accessKeyID", encodedCredentials.GetAccessKeyId())
+		assert.Equal(t, "secretAccessKey", encodedCredentials.GetSecretAccessKey())
+		assert.Equal(t, "sessionToken", encodedCredentials.GetSessionToken())
+		assert.Equal(t, "region", encodedCredentials.GetRegion())
+		assert.Equal(t, "endpoint", encodedCredentials.GetEndpoint())
+		assert.True(t, encodedCredentials.GetS3ForcePathStyle())
+		assert.False(t, encodedCredentials.HasAssumeRole())
+	})
+
+	t.Run("web identity token file is read", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("web-identity-token\n"), 0o600))
+
+		credentials := s3.NewCredentials("", "").WithAssumeRole(&s3.AssumeRole{
+			RoleARN:              "arn:aws:iam::123456789012:role/backup",
+			SessionName:          "sessionName",
+			Duration:             time.Hour,
+			WebIdentityTokenFile: tokenFile,
+		})
+
+		encodedCredentials, err := encodedRepositoryCredentials(credentials)
+		require.NoError(t, err)
+		require.True(t, encodedCredentials.HasAssumeRole())
+		encodedAssumeRole := encodedCredentials.GetAssumeRole()
+		assert.Equal(t, "arn:aws:iam::123456789012:role/backup", encodedAssumeRole.GetRoleArn())
+		assert.Equal(t, "sessionName", encodedAssumeRole.GetSessionName())
+		assert.Equal(t, time.Hour, encodedAssumeRole.GetDuration().AsDuration())
+		assert.Equal(t, "web-identity-token", encodedAssumeRole.GetWebIdentityToken())
+	})
+
+	t.Run("missing web identity token file", func(t *testing.T) {
+		credentials := s3.NewCredentials("", "").WithAssumeRole(&s3.AssumeRole{
+			RoleARN:              "arn:aws:iam::123456789012:role/backup",
+			WebIdentityTokenFile: filepath.Join(t.TempDir(), "missing"),
+		})
+
+		_, err := encodedRepositoryCredentials(credentials)
+		assert.Error(t, err)
+	})
+}
+
+func TestRepositoryBackup(t *testing.T) {
+	credentials := s3.NewCredentials("accessKeyID", "secretAccessKey")
+	snapshotTime := time.Date(2026, time.June, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc             string
+		returnValues     []interface{}
+		expectedSnapshot repository.Snapshot
+		errFunc          assert.ErrorAssertionFunc
+	}{
+		{
+			desc: "successful",
+			returnValues: []interface{}{repository_v1.BackupResponse_builder{
+				Snapshot: repository_v1.Snapshot_builder{
+					Id:            new("snapshotID"),
+					Name:          new("app"),
+					Tags:          []string{"app-event"},
+					Time:          timestamppb.New(snapshotTime),
+					Parent:        new("parentID"),
+					FileCount:     new(int64(3)),
+					Size:          new(int64(1024)),
+					AddedBytes:    new(int64(512)),
+					RetentionMode: new("GOVERNANCE"),
+					RetainUntil:   timestamppb.New(snapshotTime.Add(time.Hour)),
+					LegalHold:     new(true),
+				}.Build(),
+			}.Build(), nil},
+			expectedSnapshot: repository.Snapshot{
+				ID:         "snapshotID",
+				Name:       "app",
+				Tags:       []string{"app-event"},
+				Time:       snapshotTime,
+				Parent:     "parentID",
+				FileCount:  3,
+				Size:       1024,
+				AddedBytes: 512,
+				Retention: s3.ObjectRetention{
+					Mode:        s3.ObjectLockModeGovernance,
+					RetainUntil: snapshotTime.Add(time.Hour),
+					LegalHold:   true,
+				},
+			},
+			errFunc: assert.NoError,
+		},
+		{
+			desc:         "failure",
+			returnValues: []interface{}{nil, assert.AnError},
+			errFunc:      assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			encodedCredentials, err := encodedRepositoryCredentials(credentials)
+			require.NoError(t, err)
+
+			request := repository_v1.BackupRequest_builder{
+				Credentials:         encodedCredentials,
+				Repository:          new("s3://bucket/repository"),
+				Source:              new("/src"),
+				Name:                new("app"),
+				Tags:                []string{"app-event"},
+				Full:                new(true),
+				BytesPerSecond:      new(int64(1024)),
+				FilesPerSecond:      new(float64(10)),
+				ObjectLockMode:      new("GOVERNANCE"),
+				ObjectLockRetention: durationpb.New(time.Hour),
+				ObjectLockLegalHold: new(true),
+			}.Build()
+
+			mockClient := repository_v1.NewMockRepositoryClient()
+			mockClient.OnBackup(mock.Anything, request, mock.Anything).
+				Return(tt.returnValues...)
+
+			rc := &RepositoryClient{client: mockClient}
+			snapshot, err := rc.Backup(th.NewTestContext(), credentials, "s3://bucket/repository", "/src", repository.BackupOptions{
+				Name:       "app",
+				Tags:       []string{"app-event"},
+				Full:       true,
+				Limits:     throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10},
+				ObjectLock: s3.ObjectLock{Mode: s3.ObjectLockModeGovernance, Retention: time.Hour, LegalHold: true},
+			})
+
+			tt.errFunc(t, err)
+			assert.Equal(t, tt.expectedSnapshot, snapshot)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepositoryRestore(t *testing.T) {
+	tests := []struct {
+		desc         string
+		returnValues []interface{}
+		errFunc      assert.ErrorAssertionFunc
+	}{
+		{
+			desc:         "successful",
+			returnValues: []interface{}{repository_v1.RestoreSnapshotResponse_builder{}.Build(), nil},
+			errFunc:      assert.NoError,
+		},
+		{
+			desc:         "failure",
+			returnValues: []interface{}{nil, assert.AnError},
+			errFunc:      assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			request := repository_v1.RestoreSnapshotRequest_builder{
+				Repository:     new("/repository"),
+				SnapshotId:     new("snapshotID"),
+				Dest:           new("/dest"),
+				BytesPerSecond: new(int64(1024)),
+				FilesPerSecond: new(float64(10)),
+			}.Build()
+
+			mockClient := repository_v1.NewMockRepositoryClient()
+			mockClient.OnRestoreSnapshot(mock.Anything, request, mock.Anything).
+				Return(tt.returnValues...)
+
+			rc := &RepositoryClient{client: mockClient}
+			err := rc.Restore(th.NewTestContext(), nil, "/repository", "snapshotID", "/dest", repository.RestoreOptions{
+				Limits: throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10},
+			})
+
+			tt.errFunc(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepositoryListSnapshots(t *testing.T) {
+	snapshotTime := time.Date(2026, time.June, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc              string
+		returnValues      []interface{}
+		expectedSnapshots []repository.Snapshot
+		errFunc           assert.ErrorAssertionFunc
+	}{
+		{
+			desc: "successful",
+			returnValues: []interface{}{repository_v1.ListSnapshotsResponse_builder{
+				Snapshots: []*repository_v1.Snapshot{
+					repository_v1.Snapshot_builder{Id: new("first"), Name: new("app"), Time: timestamppb.New(snapshotTime)}.Build(),
+					repository_v1.Snapshot_builder{Id: new("second"), Name: new("app"), Time: timestamppb.New(snapshotTime.Add(time.Hour))}.Build(),
+				},
+			}.Build(), nil},
+			expectedSnapshots: []repository.Snapshot{
+				{ID: "first", Name: "app", Time: snapshotTime},
+				{ID: "second", Name: "app", Time: snapshotTime.Add(time.Hour)},
+			},
+			errFunc: assert.NoError,
+		},
+		{
+			desc:         "failure",
+			returnValues: []interface{}{nil, assert.AnError},
+			errFunc:      assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			request := repository_v1.ListSnapshotsRequest_builder{
+				Repository: new("/repository"),
+			}.Build()
+
+			mockClient := repository_v1.NewMockRepositoryClient()
+			mockClient.OnListSnapshots(mock.Anything, request, mock.Anything).
+				Return(tt.returnValues...)
+
+			rc := &RepositoryClient{client: mockClient}
+			snapshots, err := rc.ListSnapshots(th.NewTestContext(), nil, "/repository")
+
+			tt.errFunc(t, err)
+			assert.Equal(t, tt.expectedSnapshots, snapshots)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepositoryPrune(t *testing.T) {
+	tests := []struct {
+		desc           string
+		returnValues   []interface{}
+		expectedResult repository.PruneResult
+		errFunc        assert.ErrorAssertionFunc
+	}{
+		{
+			desc: "successful",
+			returnValues: []interface{}{repository_v1.PruneResponse_builder{
+				RemovedSnapshots: []string{"first"},
+				DeletedPacks:     new(int64(2)),
+				WrittenPacks:     new(int64(1)),
+			}.Build(), nil},
+			expectedResult: repository.PruneResult{RemovedSnapshots: []string{"first"}, DeletedPacks: 2, WrittenPacks: 1},
+			errFunc:        assert.NoError,
+		},
+		{
+			desc:         "failure",
+			returnValues: []interface{}{nil, assert.AnError},
+			errFunc:      assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			request := repository_v1.PruneRequest_builder{
+				Repository: new("/repository"),
+				KeepLast:   new(int64(3)),
+			}.Build()
+
+			mockClient := repository_v1.NewMockRepositoryClient()
+			mockClient.OnPrune(mock.Anything, request, mock.Anything).
+				Return(tt.returnValues...)
+
+			rc := &RepositoryClient{client: mockClient}
+			result, err := rc.Prune(th.NewTestContext(), nil, "/repository", repository.PruneOptions{KeepLast: 3})
+
+			tt.errFunc(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -3,12 +3,15 @@ package clients
 import (
 	"time"
 
+	"github.com/gravitational/trace"
 	"github.com/gravitational/trace/trail"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	s3_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -22,18 +25,52 @@ func NewS3Client(grpcConnection grpc.ClientConnInterface) *S3Client {
 	}
 }
 
-func encodedS3Credentials(credentials s3.CredentialsInterface) *s3_v1.Credentials {
-	return s3_v1.Credentials_builder{
+// encodedS3Credentials encodes the credentials for the server. A web identity token file is read here, as the
+// server can't read it.
+func encodedS3Credentials(credentials s3.CredentialsInterface) (*s3_v1.Credentials, error) {
+	builder := s3_v1.Credentials_builder{
 		AccessKeyId:      new(credentials.GetAccessKeyID()),
 		SecretAccessKey:  new(credentials.GetSecretAccessKey()),
 		SessionToken:     new(credentials.GetSessionToken()),
 		Region:           new(credentials.GetRegion()),
 		Endpoint:         new(credentials.GetEndpoint()),
 		S3ForcePathStyle: new(credentials.GetS3ForcePathStyle()),
-	}.Build()
+	}
+
+	if configuredAssumeRole := credentials.GetAssumeRole(); configuredAssumeRole != nil {
+		assumeRole, err := configuredAssumeRole.WithWebIdentityToken()
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to get web identity token")
+		}
+
+		builder.AssumeRole = s3_v1.AssumeRole_builder{
+			RoleArn:          &assumeRole.RoleARN,
+			ExternalId:       &assumeRole.ExternalID,
+			SessionName:      &assumeRole.SessionName,
+			Duration:         durationpb.New(assumeRole.Duration),
+			WebIdentityToken: &assumeRole.WebIdentityToken,
+			StsEndpoint:      &assumeRole.STSEndpoint,
+		}.Build()
+	}
+
+	return builder.Build(), nil
+}
+
+// keyPatternsToProto maps the local filter patterns onto their wire representation.
+func keyPatternsToProto(patterns []files.FilePattern) []*s3_v1.KeyPattern {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	protoPatterns := make([]*s3_v1.KeyPattern, len(patterns))
+	for i, pattern := range patterns {
+		glob := pattern.Glob
+		protoPatterns[i] = s3_v1.KeyPattern_builder{Glob: &glob}.Build()
+	}
+	return protoPatterns
 }
 
-func (s3c *S3Client) Sync(ctx *contexts.Context, credentials s3.CredentialsInterface, src, dest string, asOf time.Time) error {
+func (s3c *S3Client) Sync(ctx *contexts.Context, credentials s3.CredentialsInterface, src, dest string, asOf time.Time, opts s3.SyncOptions) error {
 	ctx.Log.With("src", src, "dest", dest).Info("Syncing files")
 	defer ctx.Log.Info("Finished syncing files", ctx.Stopwatch.Keyval())
 
@@ -44,14 +81,86 @@ func (s3c *S3Client) Sync(ctx *contexts.Context, credentials s3.CredentialsInter
 		asOfTimestamp = timestamppb.New(asOf)
 	}
 
-	request := s3_v1.SyncRequest_builder{
-		Credentials: encodedS3Credentials(credentials),
-		Source:      &src,
-		Dest:        &dest,
-		AsOf:        asOfTimestamp,
-	}.Build()
+	encodedCredentials, err := encodedS3Credentials(credentials)
+	if err != nil {
+		return trace.Wrap(err, "failed to encode credentials")
+	}
+
+	objectLockMode := string(opts.ObjectLock.Mode)
+	requestBuilder := s3_v1.SyncRequest_builder{
+		Credentials:         encodedCredentials,
+		Source:              &src,
+		Dest:                &dest,
+		AsOf:                asOfTimestamp,
+		BytesPerSecond:      &opts.Limits.BytesPerSecond,
+		FilesPerSecond:      &opts.Limits.FilesPerSecond,
+		ManifestPath:        &opts.ManifestPath,
+		MultipartThreshold:  &opts.Multipart.Threshold,
+		PartSize:            &opts.Multipart.PartSize,
+		Rehash:              &opts.Rehash,
+		Include:             keyPatternsToProto(opts.Filter.Include),
+		Exclude:             keyPatternsToProto(opts.Filter.Exclude),
+		ObjectLockMode:      &objectLockMode,
+		ObjectLockRetention: durationpb.New(opts.ObjectLock.Retention),
+		ObjectLockLegalHold: &opts.ObjectLock.LegalHold,
+	}
+
+	if opts.DestCredentials != nil {
+		requestBuilder.DestCredentials, err = encodedS3Credentials(opts.DestCredentials)
+		if err != nil {
+			return trace.Wrap(err, "failed to encode destination credentials")
+		}
+	}
+	request := requestBuilder.Build()
 
 	var header metadata.MD
-	_, err := s3c.client.Sync(ctx.Child(), request, grpc.Header(&header))
+	_, err = s3c.client.Sync(ctx.Child(), request, grpc.Header(&header))
 	return trail.FromGRPC(err, header)
 }
+
+func rewindActionFromProto(protoAction s3_v1.RewindAction) s3.RewindAction {
+	switch protoAction {
+	case s3_v1.RewindAction_REWIND_ACTION_RESTORE:
+		return s3.RewindActionRestore
+	case s3_v1.RewindAction_REWIND_ACTION_DELETE:
+		return s3.RewindActionDelete
+	default:
+		return ""
+	}
+}
+
+func (s3c *S3Client) Rewind(ctx *contexts.Context, credentials s3.CredentialsInterface, path, manifestPath string, opts s3.RewindOptions) ([]s3.RewindChange, error) {
+	ctx.Log.With("path", path, "manifest", manifestPath, "dryRun", opts.DryRun).Info("Rewinding bucket")
+	defer ctx.Log.Info("Finished rewinding bucket", ctx.Stopwatch.Keyval())
+
+	encodedCredentials, err := encodedS3Credentials(credentials)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to encode credentials")
+	}
+
+	request := s3_v1.RewindRequest_builder{
+		Credentials:    encodedCredentials,
+		Path:           &path,
+		ManifestPath:   &manifestPath,
+		DryRun:         &opts.DryRun,
+		FilesPerSecond: &opts.Limits.FilesPerSecond,
+	}.Build()
+
+	var header metadata.MD
+	response, err := s3c.client.Rewind(ctx.Child(), request, grpc.Header(&header))
+	if err != nil {
+		return nil, trail.FromGRPC(err, header)
+	}
+
+	protoChanges := response.GetChanges()
+	changes := make([]s3.RewindChange, len(protoChanges))
+	for i, protoChange := range protoChanges {
+		changes[i] = s3.RewindChange{
+			Key:       protoChange.GetKey(),
+			Action:    rewindActionFromProto(protoChange.GetAction()),
+			VersionID: protoChange.GetVersionId(),
+		}
+	}
+
+	return changes, nil
+}
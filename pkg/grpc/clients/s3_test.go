@@ -1,15 +1,21 @@
 package clients
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	s3_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -36,7 +42,8 @@ func TestEncodedS3Credentials(t *testing.T) {
 		S3ForcePathStyle: true,
 	}
 
-	encodedCredentials := encodedS3Credentials(credentials)
+	encodedCredentials, err := encodedS3Credentials(credentials)
+	require.NoError(t, err)
 	assert.NotNil(t, encodedCredentials)
 	assert.Equal(t, credentials.AccessKeyID, encodedCredentials.GetAccessKeyId())
 	assert.Equal(t, credentials.SecretAccessKey, encodedCredentials.GetSecretAccessKey())
@@ -44,6 +51,56 @@ func TestEncodedS3Credentials(t *testing.T) {
 	assert.Equal(t, credentials.Region, encodedCredentials.GetRegion())
 	assert.Equal(t, credentials.Endpoint, encodedCredentials.GetEndpoint())
 	assert.Equal(t, credentials.S3ForcePathStyle, encodedCredentials.GetS3ForcePathStyle())
+	assert.False(t, encodedCredentials.HasAssumeRole())
+}
+
+func TestEncodedS3CredentialsAssumeRole(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("web-identity-token\n"), 0o600))
+
+	t.Run("access keys", func(t *testing.T) {
+		credentials := s3.NewCredentials("accessKeyID", "secretAccessKey").WithAssumeRole(&s3.AssumeRole{
+			RoleARN:     "arn:aws:iam::123456789012:role/backup",
+			ExternalID:  "externalID",
+			SessionName: "sessionName",
+			Duration:    time.Hour,
+			STSEndpoint: "https://sts.example",
+		})
+
+		encodedCredentials, err := encodedS3Credentials(credentials)
+		require.NoError(t, err)
+		require.True(t, encodedCredentials.HasAssumeRole())
+		encodedAssumeRole := encodedCredentials.GetAssumeRole()
+		assert.Equal(t, "arn:aws:iam::123456789012:role/backup", encodedAssumeRole.GetRoleArn())
+		assert.Equal(t, "externalID", encodedAssumeRole.GetExternalId())
+		assert.Equal(t, "sessionName", encodedAssumeRole.GetSessionName())
+		assert.Equal(t, time.Hour, encodedAssumeRole.GetDuration().AsDuration())
+		assert.Equal(t, "https://sts.example", encodedAssumeRole.GetStsEndpoint())
+		assert.Empty(t, encodedAssumeRole.GetWebIdentityToken())
+	})
+
+	t.Run("web identity token file is read", func(t *testing.T) {
+		credentials := s3.NewCredentials("", "").WithAssumeRole(&s3.AssumeRole{
+			RoleARN:              "arn:aws:iam::123456789012:role/backup",
+			WebIdentityTokenFile: tokenFile,
+		})
+
+		encodedCredentials, err := encodedS3Credentials(credentials)
+		require.NoError(t, err)
+		assert.Equal(t, "web-identity-token", encodedCredentials.GetAssumeRole().GetWebIdentityToken())
+		// The caller's credentials are left as configured.
+		assert.Empty(t, credentials.AssumeRole.WebIdentityToken)
+	})
+
+	t.Run("missing web identity token file", func(t *testing.T) {
+		credentials := s3.NewCredentials("", "").WithAssumeRole(&s3.AssumeRole{
+			RoleARN:              "arn:aws:iam::123456789012:role/backup",
+			WebIdentityTokenFile: filepath.Join(t.TempDir(), "missing"),
+		})
+
+		_, err := encodedS3Credentials(credentials)
+		assert.Error(t, err)
+	})
 }
 
 func TestS3Sync(t *testing.T) {
@@ -56,11 +113,12 @@ func TestS3Sync(t *testing.T) {
 	asOf := time.Date(2026, time.June, 2, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
-		desc         string
-		asOf         time.Time
-		expectedAsOf *timestamppb.Timestamp // expected as_of field on the request the client builds
-		returnValues []interface{}
-		errFunc      assert.ErrorAssertionFunc
+		desc            string
+		asOf            time.Time
+		destCredentials s3.CredentialsInterface
+		expectedAsOf    *timestamppb.Timestamp // expected as_of field on the request the client builds
+		returnValues    []interface{}
+		errFunc         assert.ErrorAssertionFunc
 	}{
 		{
 			desc:         "successful",
@@ -74,6 +132,12 @@ func TestS3Sync(t *testing.T) {
 			returnValues: []interface{}{s3_v1.SyncResponse_builder{}.Build(), nil},
 			errFunc:      assert.NoError,
 		},
+		{
+			desc:            "encodes the destination credentials when set",
+			destCredentials: s3.NewCredentials("destAccessKeyID", "destSecretAccessKey").WithEndpoint("https://dest.example"),
+			returnValues:    []interface{}{s3_v1.SyncResponse_builder{}.Build(), nil},
+			errFunc:         assert.NoError,
+		},
 		{
 			desc:         "failure",
 			returnValues: []interface{}{nil, assert.AnError},
@@ -83,21 +147,123 @@ func TestS3Sync(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			request := s3_v1.SyncRequest_builder{
-				Credentials: encodedS3Credentials(credentials),
-				Source:      new(src),
-				Dest:        new(dest),
-				AsOf:        tt.expectedAsOf,
-			}.Build()
+			encodedCredentials, err := encodedS3Credentials(credentials)
+			require.NoError(t, err)
+
+			requestBuilder := s3_v1.SyncRequest_builder{
+				Credentials:         encodedCredentials,
+				Source:              new(src),
+				Dest:                new(dest),
+				AsOf:                tt.expectedAsOf,
+				BytesPerSecond:      new(int64(1024)),
+				FilesPerSecond:      new(float64(10)),
+				ManifestPath:        new("manifest.json"),
+				MultipartThreshold:  new(int64(128 << 20)),
+				PartSize:            new(int64(32 << 20)),
+				Rehash:              new(true),
+				Include:             []*s3_v1.KeyPattern{s3_v1.KeyPattern_builder{Glob: new("media/**")}.Build()},
+				Exclude:             []*s3_v1.KeyPattern{s3_v1.KeyPattern_builder{Glob: new("media/thumbnails")}.Build()},
+				ObjectLockMode:      new("COMPLIANCE"),
+				ObjectLockRetention: durationpb.New(24 * time.Hour),
+				ObjectLockLegalHold: new(true),
+			}
+			if tt.destCredentials != nil {
+				requestBuilder.DestCredentials, err = encodedS3Credentials(tt.destCredentials)
+				require.NoError(t, err)
+			}
+			request := requestBuilder.Build()
 
 			mockClient := s3_v1.NewMockS3Client()
 			mockClient.OnSync(mock.Anything, request, mock.Anything).
 				Return(tt.returnValues...)
 
 			s3c := &S3Client{client: mockClient}
-			err := s3c.Sync(th.NewTestContext(), credentials, src, dest, tt.asOf)
+			err = s3c.Sync(th.NewTestContext(), credentials, src, dest, tt.asOf, s3.SyncOptions{
+				Limits:          throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10},
+				DestCredentials: tt.destCredentials,
+				Multipart:       s3.MultipartOptions{Threshold: 128 << 20, PartSize: 32 << 20},
+				ManifestPath:    "manifest.json",
+				Rehash:          true,
+				Filter: files.FileFilter{
+					Include: []files.FilePattern{{Glob: "media/**"}},
+					Exclude: []files.FilePattern{{Glob: "media/thumbnails"}},
+				},
+				ObjectLock: s3.ObjectLock{Mode: s3.ObjectLockModeCompliance, Retention: 24 * time.Hour, LegalHold: true},
+			})
+
+			tt.errFunc(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestS3Rewind(t *testing.T) {
+	path := "s3://bucket/prefix"
+	manifestPath := "manifest.json"
+	credentials := &s3.Credentials{
+		AccessKeyID:     "accessKeyID",
+		SecretAccessKey: "secretAccessKey",
+	}
+
+	tests := []struct {
+		desc            string
+		returnValues    []interface{}
+		expectedChanges []s3.RewindChange
+		errFunc         assert.ErrorAssertionFunc
+	}{
+		{
+			desc: "successful",
+			returnValues: []interface{}{s3_v1.RewindResponse_builder{
+				Changes: []*s3_v1.RewindChange{
+					s3_v1.RewindChange_builder{
+						Key:       new("prefix/a.txt"),
+						Action:    new(s3_v1.RewindAction_REWIND_ACTION_RESTORE),
+						VersionId: new("v1"),
+					}.Build(),
+					s3_v1.RewindChange_builder{
+						Key:    new("prefix/b.txt"),
+						Action: new(s3_v1.RewindAction_REWIND_ACTION_DELETE),
+					}.Build(),
+				},
+			}.Build(), nil},
+			expectedChanges: []s3.RewindChange{
+				{Key: "prefix/a.txt", Action: s3.RewindActionRestore, VersionID: "v1"},
+				{Key: "prefix/b.txt", Action: s3.RewindActionDelete},
+			},
+			errFunc: assert.NoError,
+		},
+		{
+			desc:         "failure",
+			returnValues: []interface{}{nil, assert.AnError},
+			errFunc:      assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			encodedCredentials, err := encodedS3Credentials(credentials)
+			require.NoError(t, err)
+
+			request := s3_v1.RewindRequest_builder{
+				Credentials:    encodedCredentials,
+				Path:           new(path),
+				ManifestPath:   new(manifestPath),
+				DryRun:         new(true),
+				FilesPerSecond: new(float64(10)),
+			}.Build()
+
+			mockClient := s3_v1.NewMockS3Client()
+			mockClient.OnRewind(mock.Anything, request, mock.Anything).
+				Return(tt.returnValues...)
+
+			s3c := &S3Client{client: mockClient}
+			changes, err := s3c.Rewind(th.NewTestContext(), credentials, path, manifestPath, s3.RewindOptions{
+				DryRun: true,
+				Limits: throttle.Limits{FilesPerSecond: 10},
+			})
 
 			tt.errFunc(t, err)
+			assert.Equal(t, tt.expectedChanges, changes)
 			mockClient.AssertExpectations(t)
 		})
 	}
@@ -24,32 +24,47 @@ var File_files_proto protoreflect.FileDescriptor
 
 const file_files_proto_rawDesc = "" +
 	"\n" +
-	"\vfiles.proto\x1a\x14files_transfer.proto2\xaf\x01\n" +
+	"\vfiles.proto\x1a\x14files_contents.proto\x1a\x14files_transfer.proto\x1a\x10files_tree.proto2\xc5\x02\n" +
 	"\x05Files\x122\n" +
 	"\tCopyFiles\x12\x11.CopyFilesRequest\x1a\x12.CopyFilesResponse\x122\n" +
 	"\tSyncFiles\x12\x11.SyncFilesRequest\x1a\x12.SyncFilesResponse\x12>\n" +
-	"\rListDirectory\x12\x15.ListDirectoryRequest\x1a\x16.ListDirectoryResponseBUZSgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/files/v1;files_v1b\beditionsp\xe8\a"
+	"\rListDirectory\x12\x15.ListDirectoryRequest\x1a\x16.ListDirectoryResponse\x12/\n" +
+	"\bListTree\x12\x10.ListTreeRequest\x1a\x11.ListTreeResponse\x122\n" +
+	"\tWriteFile\x12\x11.WriteFileRequest\x1a\x12.WriteFileResponse\x12/\n" +
+	"\bReadFile\x12\x10.ReadFileRequest\x1a\x11.ReadFileResponseBUZSgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/files/v1;files_v1b\beditionsp\xe8\a"
 
 var file_files_proto_goTypes = []any{
 	(*CopyFilesRequest)(nil),      // 0: CopyFilesRequest
 	(*SyncFilesRequest)(nil),      // 1: SyncFilesRequest
 	(*ListDirectoryRequest)(nil),  // 2: ListDirectoryRequest
-	(*CopyFilesResponse)(nil),     // 3: CopyFilesResponse
-	(*SyncFilesResponse)(nil),     // 4: SyncFilesResponse
-	(*ListDirectoryResponse)(nil), // 5: ListDirectoryResponse
+	(*ListTreeRequest)(nil),       // 3: ListTreeRequest
+	(*WriteFileRequest)(nil),      // 4: WriteFileRequest
+	(*ReadFileRequest)(nil),       // 5: ReadFileRequest
+	(*CopyFilesResponse)(nil),     // 6: CopyFilesResponse
+	(*SyncFilesResponse)(nil),     // 7: SyncFilesResponse
+	(*ListDirectoryResponse)(nil), // 8: ListDirectoryResponse
+	(*ListTreeResponse)(nil),      // 9: ListTreeResponse
+	(*WriteFileResponse)(nil),     // 10: WriteFileResponse
+	(*ReadFileResponse)(nil),      // 11: ReadFileResponse
 }
 var file_files_proto_depIdxs = []int32{
-	0, // 0: Files.CopyFiles:input_type -> CopyFilesRequest
-	1, // 1: Files.SyncFiles:input_type -> SyncFilesRequest
-	2, // 2: Files.ListDirectory:input_type -> ListDirectoryRequest
-	3, // 3: Files.CopyFiles:output_type -> CopyFilesResponse
-	4, // 4: Files.SyncFiles:output_type -> SyncFilesResponse
-	5, // 5: Files.ListDirectory:output_type -> ListDirectoryResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: Files.CopyFiles:input_type -> CopyFilesRequest
+	1,  // 1: Files.SyncFiles:input_type -> SyncFilesRequest
+	2,  // 2: Files.ListDirectory:input_type -> ListDirectoryRequest
+	3,  // 3: Files.ListTree:input_type -> ListTreeRequest
+	4,  // 4: Files.WriteFile:input_type -> WriteFileRequest
+	5,  // 5: Files.ReadFile:input_type -> ReadFileRequest
+	6,  // 6: Files.CopyFiles:output_type -> CopyFilesResponse
+	7,  // 7: Files.SyncFiles:output_type -> SyncFilesResponse
+	8,  // 8: Files.ListDirectory:output_type -> ListDirectoryResponse
+	9,  // 9: Files.ListTree:output_type -> ListTreeResponse
+	10, // 10: Files.WriteFile:output_type -> WriteFileResponse
+	11, // 11: Files.ReadFile:output_type -> ReadFileResponse
+	6,  // [6:12] is the sub-list for method output_type
+	0,  // [0:6] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_files_proto_init() }
@@ -57,7 +72,9 @@ func file_files_proto_init() {
 	if File_files_proto != nil {
 		return
 	}
+	file_files_contents_proto_init()
 	file_files_transfer_proto_init()
+	file_files_tree_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
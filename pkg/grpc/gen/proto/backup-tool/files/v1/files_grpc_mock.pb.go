@@ -72,6 +72,57 @@ func (c *MockFilesClient) OnListDirectory(ctx interface{}, in interface{}, opts
 	return c.On("ListDirectory", append([]interface{}{ctx, in}, opts...)...)
 }
 
+func (c *MockFilesClient) ListTree(ctx context.Context, in *ListTreeRequest, opts ...grpc.CallOption) (*ListTreeResponse, error) {
+	opts0 := []interface{}{ctx, in}
+	for _, opts1 := range opts {
+		opts0 = append(opts0, opts1)
+	}
+	args := c.Called(opts0...)
+	var ret0 *ListTreeResponse
+	if args.Get(0) != nil {
+		ret0 = args.Get(0).(*ListTreeResponse)
+	}
+	return ret0, args.Error(1)
+}
+
+func (c *MockFilesClient) OnListTree(ctx interface{}, in interface{}, opts ...interface{}) *mock.Call {
+	return c.On("ListTree", append([]interface{}{ctx, in}, opts...)...)
+}
+
+func (c *MockFilesClient) WriteFile(ctx context.Context, in *WriteFileRequest, opts ...grpc.CallOption) (*WriteFileResponse, error) {
+	opts0 := []interface{}{ctx, in}
+	for _, opts1 := range opts {
+		opts0 = append(opts0, opts1)
+	}
+	args := c.Called(opts0...)
+	var ret0 *WriteFileResponse
+	if args.Get(0) != nil {
+		ret0 = args.Get(0).(*WriteFileResponse)
+	}
+	return ret0, args.Error(1)
+}
+
+func (c *MockFilesClient) OnWriteFile(ctx interface{}, in interface{}, opts ...interface{}) *mock.Call {
+	return c.On("WriteFile", append([]interface{}{ctx, in}, opts...)...)
+}
+
+func (c *MockFilesClient) ReadFile(ctx context.Context, in *ReadFileRequest, opts ...grpc.CallOption) (*ReadFileResponse, error) {
+	opts0 := []interface{}{ctx, in}
+	for _, opts1 := range opts {
+		opts0 = append(opts0, opts1)
+	}
+	args := c.Called(opts0...)
+	var ret0 *ReadFileResponse
+	if args.Get(0) != nil {
+		ret0 = args.Get(0).(*ReadFileResponse)
+	}
+	return ret0, args.Error(1)
+}
+
+func (c *MockFilesClient) OnReadFile(ctx interface{}, in interface{}, opts ...interface{}) *mock.Call {
+	return c.On("ReadFile", append([]interface{}{ctx, in}, opts...)...)
+}
+
 type MockFilesServer struct {
 	mock.Mock
 }
@@ -118,3 +169,42 @@ func (s *MockFilesServer) ListDirectory(ctx context.Context, in *ListDirectoryRe
 func (s *MockFilesServer) OnListDirectory(ctx interface{}, in interface{}) *mock.Call {
 	return s.On("ListDirectory", ctx, in)
 }
+
+func (s *MockFilesServer) ListTree(ctx context.Context, in *ListTreeRequest) (*ListTreeResponse, error) {
+	args := s.Called(ctx, in)
+	var ret0 *ListTreeResponse
+	if args.Get(0) != nil {
+		ret0 = args.Get(0).(*ListTreeResponse)
+	}
+	return ret0, args.Error(1)
+}
+
+func (s *MockFilesServer) OnListTree(ctx interface{}, in interface{}) *mock.Call {
+	return s.On("ListTree", ctx, in)
+}
+
+func (s *MockFilesServer) WriteFile(ctx context.Context, in *WriteFileRequest) (*WriteFileResponse, error) {
+	args := s.Called(ctx, in)
+	var ret0 *WriteFileResponse
+	if args.Get(0) != nil {
+		ret0 = args.Get(0).(*WriteFileResponse)
+	}
+	return ret0, args.Error(1)
+}
+
+func (s *MockFilesServer) OnWriteFile(ctx interface{}, in interface{}) *mock.Call {
+	return s.On("WriteFile", ctx, in)
+}
+
+func (s *MockFilesServer) ReadFile(ctx context.Context, in *ReadFileRequest) (*ReadFileResponse, error) {
+	args := s.Called(ctx, in)
+	var ret0 *ReadFileResponse
+	if args.Get(0) != nil {
+		ret0 = args.Get(0).(*ReadFileResponse)
+	}
+	return ret0, args.Error(1)
+}
+
+func (s *MockFilesServer) OnReadFile(ctx interface{}, in interface{}) *mock.Call {
+	return s.On("ReadFile", ctx, in)
+}
@@ -21,13 +21,15 @@ const (
 )
 
 type CopyFilesRequest struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Source      *string                `protobuf:"bytes,1,opt,name=source"`
-	xxx_hidden_Dest        *string                `protobuf:"bytes,2,opt,name=dest"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Source         *string                `protobuf:"bytes,1,opt,name=source"`
+	xxx_hidden_Dest           *string                `protobuf:"bytes,2,opt,name=dest"`
+	xxx_hidden_BytesPerSecond int64                  `protobuf:"varint,3,opt,name=bytes_per_second,json=bytesPerSecond"`
+	xxx_hidden_FilesPerSecond float64                `protobuf:"fixed64,4,opt,name=files_per_second,json=filesPerSecond"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
 }
 
 func (x *CopyFilesRequest) Reset() {
@@ -75,14 +77,38 @@ func (x *CopyFilesRequest) GetDest() string {
 	return ""
 }
 
+func (x *CopyFilesRequest) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.xxx_hidden_BytesPerSecond
+	}
+	return 0
+}
+
+func (x *CopyFilesRequest) GetFilesPerSecond() float64 {
+	if x != nil {
+		return x.xxx_hidden_FilesPerSecond
+	}
+	return 0
+}
+
 func (x *CopyFilesRequest) SetSource(v string) {
 	x.xxx_hidden_Source = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
 }
 
 func (x *CopyFilesRequest) SetDest(v string) {
 	x.xxx_hidden_Dest = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *CopyFilesRequest) SetBytesPerSecond(v int64) {
+	x.xxx_hidden_BytesPerSecond = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *CopyFilesRequest) SetFilesPerSecond(v float64) {
+	x.xxx_hidden_FilesPerSecond = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
 }
 
 func (x *CopyFilesRequest) HasSource() bool {
@@ -99,6 +125,20 @@ func (x *CopyFilesRequest) HasDest() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *CopyFilesRequest) HasBytesPerSecond() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CopyFilesRequest) HasFilesPerSecond() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
 func (x *CopyFilesRequest) ClearSource() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Source = nil
@@ -109,11 +149,25 @@ func (x *CopyFilesRequest) ClearDest() {
 	x.xxx_hidden_Dest = nil
 }
 
+func (x *CopyFilesRequest) ClearBytesPerSecond() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_BytesPerSecond = 0
+}
+
+func (x *CopyFilesRequest) ClearFilesPerSecond() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_FilesPerSecond = 0
+}
+
 type CopyFilesRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Source *string
 	Dest   *string
+	// bytes_per_second caps the rate that file contents are read at. Zero means unlimited.
+	BytesPerSecond *int64
+	// files_per_second caps the rate that files are copied at. Zero means unlimited.
+	FilesPerSecond *float64
 }
 
 func (b0 CopyFilesRequest_builder) Build() *CopyFilesRequest {
@@ -121,13 +175,21 @@ func (b0 CopyFilesRequest_builder) Build() *CopyFilesRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Source != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
 		x.xxx_hidden_Source = b.Source
 	}
 	if b.Dest != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
 		x.xxx_hidden_Dest = b.Dest
 	}
+	if b.BytesPerSecond != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_BytesPerSecond = *b.BytesPerSecond
+	}
+	if b.FilesPerSecond != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_FilesPerSecond = *b.FilesPerSecond
+	}
 	return m0
 }
 
@@ -255,15 +317,19 @@ func (b0 FilePattern_builder) Build() *FilePattern {
 }
 
 type SyncFilesRequest struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Source      *string                `protobuf:"bytes,1,opt,name=source"`
-	xxx_hidden_Dest        *string                `protobuf:"bytes,2,opt,name=dest"`
-	xxx_hidden_Include     *[]*FilePattern        `protobuf:"bytes,3,rep,name=include"`
-	xxx_hidden_Exclude     *[]*FilePattern        `protobuf:"bytes,4,rep,name=exclude"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Source          *string                `protobuf:"bytes,1,opt,name=source"`
+	xxx_hidden_Dest            *string                `protobuf:"bytes,2,opt,name=dest"`
+	xxx_hidden_Include         *[]*FilePattern        `protobuf:"bytes,3,rep,name=include"`
+	xxx_hidden_Exclude         *[]*FilePattern        `protobuf:"bytes,4,rep,name=exclude"`
+	xxx_hidden_BytesPerSecond  int64                  `protobuf:"varint,5,opt,name=bytes_per_second,json=bytesPerSecond"`
+	xxx_hidden_FilesPerSecond  float64                `protobuf:"fixed64,6,opt,name=files_per_second,json=filesPerSecond"`
+	xxx_hidden_Parallelism     int32                  `protobuf:"varint,7,opt,name=parallelism"`
+	xxx_hidden_SqliteDatabases []string               `protobuf:"bytes,8,rep,name=sqlite_databases,json=sqliteDatabases"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *SyncFilesRequest) Reset() {
@@ -329,14 +395,42 @@ func (x *SyncFilesRequest) GetExclude() []*FilePattern {
 	return nil
 }
 
+func (x *SyncFilesRequest) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.xxx_hidden_BytesPerSecond
+	}
+	return 0
+}
+
+func (x *SyncFilesRequest) GetFilesPerSecond() float64 {
+	if x != nil {
+		return x.xxx_hidden_FilesPerSecond
+	}
+	return 0
+}
+
+func (x *SyncFilesRequest) GetParallelism() int32 {
+	if x != nil {
+		return x.xxx_hidden_Parallelism
+	}
+	return 0
+}
+
+func (x *SyncFilesRequest) GetSqliteDatabases() []string {
+	if x != nil {
+		return x.xxx_hidden_SqliteDatabases
+	}
+	return nil
+}
+
 func (x *SyncFilesRequest) SetSource(v string) {
 	x.xxx_hidden_Source = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
 }
 
 func (x *SyncFilesRequest) SetDest(v string) {
 	x.xxx_hidden_Dest = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
 }
 
 func (x *SyncFilesRequest) SetInclude(v []*FilePattern) {
@@ -347,6 +441,25 @@ func (x *SyncFilesRequest) SetExclude(v []*FilePattern) {
 	x.xxx_hidden_Exclude = &v
 }
 
+func (x *SyncFilesRequest) SetBytesPerSecond(v int64) {
+	x.xxx_hidden_BytesPerSecond = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *SyncFilesRequest) SetFilesPerSecond(v float64) {
+	x.xxx_hidden_FilesPerSecond = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+}
+
+func (x *SyncFilesRequest) SetParallelism(v int32) {
+	x.xxx_hidden_Parallelism = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+}
+
+func (x *SyncFilesRequest) SetSqliteDatabases(v []string) {
+	x.xxx_hidden_SqliteDatabases = v
+}
+
 func (x *SyncFilesRequest) HasSource() bool {
 	if x == nil {
 		return false
@@ -361,6 +474,27 @@ func (x *SyncFilesRequest) HasDest() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *SyncFilesRequest) HasBytesPerSecond() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *SyncFilesRequest) HasFilesPerSecond() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *SyncFilesRequest) HasParallelism() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
 func (x *SyncFilesRequest) ClearSource() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Source = nil
@@ -371,6 +505,21 @@ func (x *SyncFilesRequest) ClearDest() {
 	x.xxx_hidden_Dest = nil
 }
 
+func (x *SyncFilesRequest) ClearBytesPerSecond() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_BytesPerSecond = 0
+}
+
+func (x *SyncFilesRequest) ClearFilesPerSecond() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_FilesPerSecond = 0
+}
+
+func (x *SyncFilesRequest) ClearParallelism() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Parallelism = 0
+}
+
 type SyncFilesRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -380,6 +529,15 @@ type SyncFilesRequest_builder struct {
 	Include []*FilePattern
 	// exclude is a blacklist; any entry matching one of these patterns is omitted (exclude wins).
 	Exclude []*FilePattern
+	// bytes_per_second caps the rate that file contents are read at. Zero means unlimited.
+	BytesPerSecond *int64
+	// files_per_second caps the rate that files are copied at. Zero means unlimited.
+	FilesPerSecond *float64
+	// parallelism is the number of files that are copied concurrently. Zero copies one file at a time.
+	Parallelism *int32
+	// sqlite_databases are the paths (relative to the source) of SQLite databases that are captured with the
+	// SQLite online backup API rather than copied.
+	SqliteDatabases []string
 }
 
 func (b0 SyncFilesRequest_builder) Build() *SyncFilesRequest {
@@ -387,15 +545,28 @@ func (b0 SyncFilesRequest_builder) Build() *SyncFilesRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Source != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
 		x.xxx_hidden_Source = b.Source
 	}
 	if b.Dest != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
 		x.xxx_hidden_Dest = b.Dest
 	}
 	x.xxx_hidden_Include = &b.Include
 	x.xxx_hidden_Exclude = &b.Exclude
+	if b.BytesPerSecond != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_BytesPerSecond = *b.BytesPerSecond
+	}
+	if b.FilesPerSecond != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		x.xxx_hidden_FilesPerSecond = *b.FilesPerSecond
+	}
+	if b.Parallelism != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
+		x.xxx_hidden_Parallelism = *b.Parallelism
+	}
+	x.xxx_hidden_SqliteDatabases = b.SqliteDatabases
 	return m0
 }
 
@@ -581,18 +752,24 @@ var File_files_transfer_proto protoreflect.FileDescriptor
 
 const file_files_transfer_proto_rawDesc = "" +
 	"\n" +
-	"\x14files_transfer.proto\">\n" +
+	"\x14files_transfer.proto\"\x92\x01\n" +
 	"\x10CopyFilesRequest\x12\x16\n" +
 	"\x06source\x18\x01 \x01(\tR\x06source\x12\x12\n" +
-	"\x04dest\x18\x02 \x01(\tR\x04dest\"\x13\n" +
+	"\x04dest\x18\x02 \x01(\tR\x04dest\x12(\n" +
+	"\x10bytes_per_second\x18\x03 \x01(\x03R\x0ebytesPerSecond\x12(\n" +
+	"\x10files_per_second\x18\x04 \x01(\x01R\x0efilesPerSecond\"\x13\n" +
 	"\x11CopyFilesResponse\"!\n" +
 	"\vFilePattern\x12\x12\n" +
-	"\x04glob\x18\x01 \x01(\tR\x04glob\"\x8e\x01\n" +
+	"\x04glob\x18\x01 \x01(\tR\x04glob\"\xaf\x02\n" +
 	"\x10SyncFilesRequest\x12\x16\n" +
 	"\x06source\x18\x01 \x01(\tR\x06source\x12\x12\n" +
 	"\x04dest\x18\x02 \x01(\tR\x04dest\x12&\n" +
 	"\ainclude\x18\x03 \x03(\v2\f.FilePatternR\ainclude\x12&\n" +
-	"\aexclude\x18\x04 \x03(\v2\f.FilePatternR\aexclude\"\x13\n" +
+	"\aexclude\x18\x04 \x03(\v2\f.FilePatternR\aexclude\x12(\n" +
+	"\x10bytes_per_second\x18\x05 \x01(\x03R\x0ebytesPerSecond\x12(\n" +
+	"\x10files_per_second\x18\x06 \x01(\x01R\x0efilesPerSecond\x12 \n" +
+	"\vparallelism\x18\a \x01(\x05R\vparallelism\x12)\n" +
+	"\x10sqlite_databases\x18\b \x03(\tR\x0fsqliteDatabases\"\x13\n" +
 	"\x11SyncFilesResponse\"*\n" +
 	"\x14ListDirectoryRequest\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\"1\n" +
@@ -32,6 +32,7 @@ const (
 	VarName_PGSSLCERT     VarName = 6
 	VarName_PGSSLKEY      VarName = 7
 	VarName_PGSSLROOTCERT VarName = 8
+	VarName_PGPASSWORD    VarName = 9
 )
 
 // Enum value maps for VarName.
@@ -46,6 +47,7 @@ var (
 		6: "PGSSLCERT",
 		7: "PGSSLKEY",
 		8: "PGSSLROOTCERT",
+		9: "PGPASSWORD",
 	}
 	VarName_value = map[string]int32{
 		"PGHOST":        0,
@@ -57,6 +59,7 @@ var (
 		"PGSSLCERT":     6,
 		"PGSSLKEY":      7,
 		"PGSSLROOTCERT": 8,
+		"PGPASSWORD":    9,
 	}
 )
 
@@ -260,7 +263,7 @@ const file_postgres_credentials_proto_rawDesc = "" +
 	"\vcredentials\x18\x01 \x03(\v2+.EnvironmentCredentials.EnvironmentVariableR\vcredentials\x1aI\n" +
 	"\x13EnvironmentVariable\x12\x1c\n" +
 	"\x04name\x18\x01 \x01(\x0e2\b.VarNameR\x04name\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value*\x8f\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value*\x9f\x01\n" +
 	"\aVarName\x12\n" +
 	"\n" +
 	"\x06PGHOST\x10\x00\x12\n" +
@@ -274,7 +277,9 @@ const file_postgres_credentials_proto_rawDesc = "" +
 	"\tPGSSLMODE\x10\x05\x12\r\n" +
 	"\tPGSSLCERT\x10\x06\x12\f\n" +
 	"\bPGSSLKEY\x10\a\x12\x11\n" +
-	"\rPGSSLROOTCERT\x10\bB[ZYgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/postgres/v1;postgres_v1b\beditionsp\xe8\a"
+	"\rPGSSLROOTCERT\x10\b\x12\x0e\n" +
+	"\n" +
+	"PGPASSWORD\x10\tB[ZYgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/postgres/v1;postgres_v1b\beditionsp\xe8\a"
 
 var file_postgres_credentials_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_postgres_credentials_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
@@ -150,10 +150,18 @@ func (b0 DumpAllRequest_builder) Build() *DumpAllRequest {
 }
 
 type DumpAllOptions struct {
-	state                     protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_CleanupTimeout *durationpb.Duration   `protobuf:"bytes,1,opt,name=cleanup_timeout,json=cleanupTimeout"`
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_CleanupTimeout   *durationpb.Duration   `protobuf:"bytes,1,opt,name=cleanup_timeout,json=cleanupTimeout"`
+	xxx_hidden_Format           *string                `protobuf:"bytes,2,opt,name=format"`
+	xxx_hidden_Jobs             int32                  `protobuf:"varint,3,opt,name=jobs"`
+	xxx_hidden_IncludeDatabases []string               `protobuf:"bytes,4,rep,name=include_databases,json=includeDatabases"`
+	xxx_hidden_ExcludeDatabases []string               `protobuf:"bytes,5,rep,name=exclude_databases,json=excludeDatabases"`
+	xxx_hidden_Databases        *[]*DatabaseFilter     `protobuf:"bytes,6,rep,name=databases"`
+	xxx_hidden_Roles            *RoleHandling          `protobuf:"bytes,7,opt,name=roles"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
 }
 
 func (x *DumpAllOptions) Reset() {
@@ -188,10 +196,83 @@ func (x *DumpAllOptions) GetCleanupTimeout() *durationpb.Duration {
 	return nil
 }
 
+func (x *DumpAllOptions) GetFormat() string {
+	if x != nil {
+		if x.xxx_hidden_Format != nil {
+			return *x.xxx_hidden_Format
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DumpAllOptions) GetJobs() int32 {
+	if x != nil {
+		return x.xxx_hidden_Jobs
+	}
+	return 0
+}
+
+func (x *DumpAllOptions) GetIncludeDatabases() []string {
+	if x != nil {
+		return x.xxx_hidden_IncludeDatabases
+	}
+	return nil
+}
+
+func (x *DumpAllOptions) GetExcludeDatabases() []string {
+	if x != nil {
+		return x.xxx_hidden_ExcludeDatabases
+	}
+	return nil
+}
+
+func (x *DumpAllOptions) GetDatabases() []*DatabaseFilter {
+	if x != nil {
+		if x.xxx_hidden_Databases != nil {
+			return *x.xxx_hidden_Databases
+		}
+	}
+	return nil
+}
+
+func (x *DumpAllOptions) GetRoles() *RoleHandling {
+	if x != nil {
+		return x.xxx_hidden_Roles
+	}
+	return nil
+}
+
 func (x *DumpAllOptions) SetCleanupTimeout(v *durationpb.Duration) {
 	x.xxx_hidden_CleanupTimeout = v
 }
 
+func (x *DumpAllOptions) SetFormat(v string) {
+	x.xxx_hidden_Format = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *DumpAllOptions) SetJobs(v int32) {
+	x.xxx_hidden_Jobs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *DumpAllOptions) SetIncludeDatabases(v []string) {
+	x.xxx_hidden_IncludeDatabases = v
+}
+
+func (x *DumpAllOptions) SetExcludeDatabases(v []string) {
+	x.xxx_hidden_ExcludeDatabases = v
+}
+
+func (x *DumpAllOptions) SetDatabases(v []*DatabaseFilter) {
+	x.xxx_hidden_Databases = &v
+}
+
+func (x *DumpAllOptions) SetRoles(v *RoleHandling) {
+	x.xxx_hidden_Roles = v
+}
+
 func (x *DumpAllOptions) HasCleanupTimeout() bool {
 	if x == nil {
 		return false
@@ -199,14 +280,59 @@ func (x *DumpAllOptions) HasCleanupTimeout() bool {
 	return x.xxx_hidden_CleanupTimeout != nil
 }
 
+func (x *DumpAllOptions) HasFormat() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DumpAllOptions) HasJobs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *DumpAllOptions) HasRoles() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Roles != nil
+}
+
 func (x *DumpAllOptions) ClearCleanupTimeout() {
 	x.xxx_hidden_CleanupTimeout = nil
 }
 
+func (x *DumpAllOptions) ClearFormat() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Format = nil
+}
+
+func (x *DumpAllOptions) ClearJobs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Jobs = 0
+}
+
+func (x *DumpAllOptions) ClearRoles() {
+	x.xxx_hidden_Roles = nil
+}
+
 type DumpAllOptions_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	CleanupTimeout *durationpb.Duration
+	// format is "plain" (the default when empty) or "directory".
+	Format *string
+	// jobs is the number of tables of each database that are dumped concurrently, with the directory format.
+	Jobs *int32
+	// include_databases dumps only these databases. Empty dumps every database.
+	IncludeDatabases []string
+	ExcludeDatabases []string
+	// databases selects the schemas and tables that are dumped from individual databases, with the directory format.
+	Databases []*DatabaseFilter
+	Roles     *RoleHandling
 }
 
 func (b0 DumpAllOptions_builder) Build() *DumpAllOptions {
@@ -214,6 +340,273 @@ func (b0 DumpAllOptions_builder) Build() *DumpAllOptions {
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_CleanupTimeout = b.CleanupTimeout
+	if b.Format != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Format = b.Format
+	}
+	if b.Jobs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Jobs = *b.Jobs
+	}
+	x.xxx_hidden_IncludeDatabases = b.IncludeDatabases
+	x.xxx_hidden_ExcludeDatabases = b.ExcludeDatabases
+	x.xxx_hidden_Databases = &b.Databases
+	x.xxx_hidden_Roles = b.Roles
+	return m0
+}
+
+type RoleHandling struct {
+	state                     protoimpl.MessageState      `protogen:"opaque.v1"`
+	xxx_hidden_SkipRoles      []string                    `protobuf:"bytes,1,rep,name=skip_roles,json=skipRoles"`
+	xxx_hidden_RenameRoles    *[]*RoleHandling_RoleRename `protobuf:"bytes,2,rep,name=rename_roles,json=renameRoles"`
+	xxx_hidden_StripPasswords bool                        `protobuf:"varint,3,opt,name=strip_passwords,json=stripPasswords"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *RoleHandling) Reset() {
+	*x = RoleHandling{}
+	mi := &file_postgres_dump_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleHandling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleHandling) ProtoMessage() {}
+
+func (x *RoleHandling) ProtoReflect() protoreflect.Message {
+	mi := &file_postgres_dump_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RoleHandling) GetSkipRoles() []string {
+	if x != nil {
+		return x.xxx_hidden_SkipRoles
+	}
+	return nil
+}
+
+func (x *RoleHandling) GetRenameRoles() []*RoleHandling_RoleRename {
+	if x != nil {
+		if x.xxx_hidden_RenameRoles != nil {
+			return *x.xxx_hidden_RenameRoles
+		}
+	}
+	return nil
+}
+
+func (x *RoleHandling) GetStripPasswords() bool {
+	if x != nil {
+		return x.xxx_hidden_StripPasswords
+	}
+	return false
+}
+
+func (x *RoleHandling) SetSkipRoles(v []string) {
+	x.xxx_hidden_SkipRoles = v
+}
+
+func (x *RoleHandling) SetRenameRoles(v []*RoleHandling_RoleRename) {
+	x.xxx_hidden_RenameRoles = &v
+}
+
+func (x *RoleHandling) SetStripPasswords(v bool) {
+	x.xxx_hidden_StripPasswords = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *RoleHandling) HasStripPasswords() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RoleHandling) ClearStripPasswords() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_StripPasswords = false
+}
+
+type RoleHandling_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// skip_roles leaves the definitions of these roles out of the dump, along with those of the CNPG managed roles.
+	SkipRoles []string
+	// rename_roles renames dumped roles to the names that they're restored as.
+	RenameRoles    []*RoleHandling_RoleRename
+	StripPasswords *bool
+}
+
+func (b0 RoleHandling_builder) Build() *RoleHandling {
+	m0 := &RoleHandling{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_SkipRoles = b.SkipRoles
+	x.xxx_hidden_RenameRoles = &b.RenameRoles
+	if b.StripPasswords != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_StripPasswords = *b.StripPasswords
+	}
+	return m0
+}
+
+type DatabaseFilter struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name             *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_IncludeSchemas   []string               `protobuf:"bytes,2,rep,name=include_schemas,json=includeSchemas"`
+	xxx_hidden_ExcludeSchemas   []string               `protobuf:"bytes,3,rep,name=exclude_schemas,json=excludeSchemas"`
+	xxx_hidden_IncludeTables    []string               `protobuf:"bytes,4,rep,name=include_tables,json=includeTables"`
+	xxx_hidden_ExcludeTables    []string               `protobuf:"bytes,5,rep,name=exclude_tables,json=excludeTables"`
+	xxx_hidden_SchemaOnlyTables []string               `protobuf:"bytes,6,rep,name=schema_only_tables,json=schemaOnlyTables"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *DatabaseFilter) Reset() {
+	*x = DatabaseFilter{}
+	mi := &file_postgres_dump_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatabaseFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseFilter) ProtoMessage() {}
+
+func (x *DatabaseFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_postgres_dump_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DatabaseFilter) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DatabaseFilter) GetIncludeSchemas() []string {
+	if x != nil {
+		return x.xxx_hidden_IncludeSchemas
+	}
+	return nil
+}
+
+func (x *DatabaseFilter) GetExcludeSchemas() []string {
+	if x != nil {
+		return x.xxx_hidden_ExcludeSchemas
+	}
+	return nil
+}
+
+func (x *DatabaseFilter) GetIncludeTables() []string {
+	if x != nil {
+		return x.xxx_hidden_IncludeTables
+	}
+	return nil
+}
+
+func (x *DatabaseFilter) GetExcludeTables() []string {
+	if x != nil {
+		return x.xxx_hidden_ExcludeTables
+	}
+	return nil
+}
+
+func (x *DatabaseFilter) GetSchemaOnlyTables() []string {
+	if x != nil {
+		return x.xxx_hidden_SchemaOnlyTables
+	}
+	return nil
+}
+
+func (x *DatabaseFilter) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *DatabaseFilter) SetIncludeSchemas(v []string) {
+	x.xxx_hidden_IncludeSchemas = v
+}
+
+func (x *DatabaseFilter) SetExcludeSchemas(v []string) {
+	x.xxx_hidden_ExcludeSchemas = v
+}
+
+func (x *DatabaseFilter) SetIncludeTables(v []string) {
+	x.xxx_hidden_IncludeTables = v
+}
+
+func (x *DatabaseFilter) SetExcludeTables(v []string) {
+	x.xxx_hidden_ExcludeTables = v
+}
+
+func (x *DatabaseFilter) SetSchemaOnlyTables(v []string) {
+	x.xxx_hidden_SchemaOnlyTables = v
+}
+
+func (x *DatabaseFilter) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DatabaseFilter) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+type DatabaseFilter_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name             *string
+	IncludeSchemas   []string
+	ExcludeSchemas   []string
+	IncludeTables    []string
+	ExcludeTables    []string
+	SchemaOnlyTables []string
+}
+
+func (b0 DatabaseFilter_builder) Build() *DatabaseFilter {
+	m0 := &DatabaseFilter{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Name = b.Name
+	}
+	x.xxx_hidden_IncludeSchemas = b.IncludeSchemas
+	x.xxx_hidden_ExcludeSchemas = b.ExcludeSchemas
+	x.xxx_hidden_IncludeTables = b.IncludeTables
+	x.xxx_hidden_ExcludeTables = b.ExcludeTables
+	x.xxx_hidden_SchemaOnlyTables = b.SchemaOnlyTables
 	return m0
 }
 
@@ -225,7 +618,7 @@ type DumpAllResponse struct {
 
 func (x *DumpAllResponse) Reset() {
 	*x = DumpAllResponse{}
-	mi := &file_postgres_dump_proto_msgTypes[2]
+	mi := &file_postgres_dump_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -237,7 +630,7 @@ func (x *DumpAllResponse) String() string {
 func (*DumpAllResponse) ProtoMessage() {}
 
 func (x *DumpAllResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_postgres_dump_proto_msgTypes[2]
+	mi := &file_postgres_dump_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -260,6 +653,117 @@ func (b0 DumpAllResponse_builder) Build() *DumpAllResponse {
 	return m0
 }
 
+type RoleHandling_RoleRename struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_NewName     *string                `protobuf:"bytes,2,opt,name=new_name,json=newName"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RoleHandling_RoleRename) Reset() {
+	*x = RoleHandling_RoleRename{}
+	mi := &file_postgres_dump_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleHandling_RoleRename) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleHandling_RoleRename) ProtoMessage() {}
+
+func (x *RoleHandling_RoleRename) ProtoReflect() protoreflect.Message {
+	mi := &file_postgres_dump_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RoleHandling_RoleRename) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RoleHandling_RoleRename) GetNewName() string {
+	if x != nil {
+		if x.xxx_hidden_NewName != nil {
+			return *x.xxx_hidden_NewName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RoleHandling_RoleRename) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RoleHandling_RoleRename) SetNewName(v string) {
+	x.xxx_hidden_NewName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RoleHandling_RoleRename) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RoleHandling_RoleRename) HasNewName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RoleHandling_RoleRename) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *RoleHandling_RoleRename) ClearNewName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_NewName = nil
+}
+
+type RoleHandling_RoleRename_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name    *string
+	NewName *string
+}
+
+func (b0 RoleHandling_RoleRename_builder) Build() *RoleHandling_RoleRename {
+	m0 := &RoleHandling_RoleRename{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.NewName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_NewName = b.NewName
+	}
+	return m0
+}
+
 var File_postgres_dump_proto protoreflect.FileDescriptor
 
 const file_postgres_dump_proto_rawDesc = "" +
@@ -268,28 +772,56 @@ const file_postgres_dump_proto_rawDesc = "" +
 	"\x0eDumpAllRequest\x129\n" +
 	"\vcredentials\x18\x01 \x01(\v2\x17.EnvironmentCredentialsR\vcredentials\x12(\n" +
 	"\x10output_file_path\x18\x02 \x01(\tR\x0eoutputFilePath\x12)\n" +
-	"\aoptions\x18\x03 \x01(\v2\x0f.DumpAllOptionsR\aoptions\"T\n" +
+	"\aoptions\x18\x03 \x01(\v2\x0f.DumpAllOptionsR\aoptions\"\xae\x02\n" +
 	"\x0eDumpAllOptions\x12B\n" +
-	"\x0fcleanup_timeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x0ecleanupTimeout\"\x11\n" +
+	"\x0fcleanup_timeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x0ecleanupTimeout\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x12\n" +
+	"\x04jobs\x18\x03 \x01(\x05R\x04jobs\x12+\n" +
+	"\x11include_databases\x18\x04 \x03(\tR\x10includeDatabases\x12+\n" +
+	"\x11exclude_databases\x18\x05 \x03(\tR\x10excludeDatabases\x12-\n" +
+	"\tdatabases\x18\x06 \x03(\v2\x0f.DatabaseFilterR\tdatabases\x12#\n" +
+	"\x05roles\x18\a \x01(\v2\r.RoleHandlingR\x05roles\"\xd0\x01\n" +
+	"\fRoleHandling\x12\x1d\n" +
+	"\n" +
+	"skip_roles\x18\x01 \x03(\tR\tskipRoles\x12;\n" +
+	"\frename_roles\x18\x02 \x03(\v2\x18.RoleHandling.RoleRenameR\vrenameRoles\x12'\n" +
+	"\x0fstrip_passwords\x18\x03 \x01(\bR\x0estripPasswords\x1a;\n" +
+	"\n" +
+	"RoleRename\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\"\xf2\x01\n" +
+	"\x0eDatabaseFilter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12'\n" +
+	"\x0finclude_schemas\x18\x02 \x03(\tR\x0eincludeSchemas\x12'\n" +
+	"\x0fexclude_schemas\x18\x03 \x03(\tR\x0eexcludeSchemas\x12%\n" +
+	"\x0einclude_tables\x18\x04 \x03(\tR\rincludeTables\x12%\n" +
+	"\x0eexclude_tables\x18\x05 \x03(\tR\rexcludeTables\x12,\n" +
+	"\x12schema_only_tables\x18\x06 \x03(\tR\x10schemaOnlyTables\"\x11\n" +
 	"\x0fDumpAllResponseB[ZYgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/postgres/v1;postgres_v1b\beditionsp\xe8\a"
 
-var file_postgres_dump_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_postgres_dump_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_postgres_dump_proto_goTypes = []any{
-	(*DumpAllRequest)(nil),         // 0: DumpAllRequest
-	(*DumpAllOptions)(nil),         // 1: DumpAllOptions
-	(*DumpAllResponse)(nil),        // 2: DumpAllResponse
-	(*EnvironmentCredentials)(nil), // 3: EnvironmentCredentials
-	(*durationpb.Duration)(nil),    // 4: google.protobuf.Duration
+	(*DumpAllRequest)(nil),          // 0: DumpAllRequest
+	(*DumpAllOptions)(nil),          // 1: DumpAllOptions
+	(*RoleHandling)(nil),            // 2: RoleHandling
+	(*DatabaseFilter)(nil),          // 3: DatabaseFilter
+	(*DumpAllResponse)(nil),         // 4: DumpAllResponse
+	(*RoleHandling_RoleRename)(nil), // 5: RoleHandling.RoleRename
+	(*EnvironmentCredentials)(nil),  // 6: EnvironmentCredentials
+	(*durationpb.Duration)(nil),     // 7: google.protobuf.Duration
 }
 var file_postgres_dump_proto_depIdxs = []int32{
-	3, // 0: DumpAllRequest.credentials:type_name -> EnvironmentCredentials
+	6, // 0: DumpAllRequest.credentials:type_name -> EnvironmentCredentials
 	1, // 1: DumpAllRequest.options:type_name -> DumpAllOptions
-	4, // 2: DumpAllOptions.cleanup_timeout:type_name -> google.protobuf.Duration
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	7, // 2: DumpAllOptions.cleanup_timeout:type_name -> google.protobuf.Duration
+	3, // 3: DumpAllOptions.databases:type_name -> DatabaseFilter
+	2, // 4: DumpAllOptions.roles:type_name -> RoleHandling
+	5, // 5: RoleHandling.rename_roles:type_name -> RoleHandling.RoleRename
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_postgres_dump_proto_init() }
@@ -304,7 +836,7 @@ func file_postgres_dump_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_postgres_dump_proto_rawDesc), len(file_postgres_dump_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
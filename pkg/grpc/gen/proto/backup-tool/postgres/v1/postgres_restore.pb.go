@@ -149,9 +149,21 @@ func (b0 RestoreRequest_builder) Build() *RestoreRequest {
 }
 
 type RestoreOptions struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Format            *string                `protobuf:"bytes,1,opt,name=format"`
+	xxx_hidden_Jobs              int32                  `protobuf:"varint,2,opt,name=jobs"`
+	xxx_hidden_Databases         []string               `protobuf:"bytes,3,rep,name=databases"`
+	xxx_hidden_Schemas           []string               `protobuf:"bytes,4,rep,name=schemas"`
+	xxx_hidden_Tables            []string               `protobuf:"bytes,5,rep,name=tables"`
+	xxx_hidden_TargetDatabase    *string                `protobuf:"bytes,6,opt,name=target_database,json=targetDatabase"`
+	xxx_hidden_StopOnError       bool                   `protobuf:"varint,7,opt,name=stop_on_error,json=stopOnError"`
+	xxx_hidden_SingleTransaction bool                   `protobuf:"varint,8,opt,name=single_transaction,json=singleTransaction"`
+	xxx_hidden_DropAndRecreate   bool                   `protobuf:"varint,9,opt,name=drop_and_recreate,json=dropAndRecreate"`
+	xxx_hidden_Force             bool                   `protobuf:"varint,10,opt,name=force"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
 }
 
 func (x *RestoreOptions) Reset() {
@@ -179,15 +191,272 @@ func (x *RestoreOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+func (x *RestoreOptions) GetFormat() string {
+	if x != nil {
+		if x.xxx_hidden_Format != nil {
+			return *x.xxx_hidden_Format
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestoreOptions) GetJobs() int32 {
+	if x != nil {
+		return x.xxx_hidden_Jobs
+	}
+	return 0
+}
+
+func (x *RestoreOptions) GetDatabases() []string {
+	if x != nil {
+		return x.xxx_hidden_Databases
+	}
+	return nil
+}
+
+func (x *RestoreOptions) GetSchemas() []string {
+	if x != nil {
+		return x.xxx_hidden_Schemas
+	}
+	return nil
+}
+
+func (x *RestoreOptions) GetTables() []string {
+	if x != nil {
+		return x.xxx_hidden_Tables
+	}
+	return nil
+}
+
+func (x *RestoreOptions) GetTargetDatabase() string {
+	if x != nil {
+		if x.xxx_hidden_TargetDatabase != nil {
+			return *x.xxx_hidden_TargetDatabase
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestoreOptions) GetStopOnError() bool {
+	if x != nil {
+		return x.xxx_hidden_StopOnError
+	}
+	return false
+}
+
+func (x *RestoreOptions) GetSingleTransaction() bool {
+	if x != nil {
+		return x.xxx_hidden_SingleTransaction
+	}
+	return false
+}
+
+func (x *RestoreOptions) GetDropAndRecreate() bool {
+	if x != nil {
+		return x.xxx_hidden_DropAndRecreate
+	}
+	return false
+}
+
+func (x *RestoreOptions) GetForce() bool {
+	if x != nil {
+		return x.xxx_hidden_Force
+	}
+	return false
+}
+
+func (x *RestoreOptions) SetFormat(v string) {
+	x.xxx_hidden_Format = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+}
+
+func (x *RestoreOptions) SetJobs(v int32) {
+	x.xxx_hidden_Jobs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *RestoreOptions) SetDatabases(v []string) {
+	x.xxx_hidden_Databases = v
+}
+
+func (x *RestoreOptions) SetSchemas(v []string) {
+	x.xxx_hidden_Schemas = v
+}
+
+func (x *RestoreOptions) SetTables(v []string) {
+	x.xxx_hidden_Tables = v
+}
+
+func (x *RestoreOptions) SetTargetDatabase(v string) {
+	x.xxx_hidden_TargetDatabase = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+}
+
+func (x *RestoreOptions) SetStopOnError(v bool) {
+	x.xxx_hidden_StopOnError = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+}
+
+func (x *RestoreOptions) SetSingleTransaction(v bool) {
+	x.xxx_hidden_SingleTransaction = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
+}
+
+func (x *RestoreOptions) SetDropAndRecreate(v bool) {
+	x.xxx_hidden_DropAndRecreate = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *RestoreOptions) SetForce(v bool) {
+	x.xxx_hidden_Force = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
+}
+
+func (x *RestoreOptions) HasFormat() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RestoreOptions) HasJobs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RestoreOptions) HasTargetDatabase() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *RestoreOptions) HasStopOnError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *RestoreOptions) HasSingleTransaction() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *RestoreOptions) HasDropAndRecreate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *RestoreOptions) HasForce() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *RestoreOptions) ClearFormat() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Format = nil
+}
+
+func (x *RestoreOptions) ClearJobs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Jobs = 0
+}
+
+func (x *RestoreOptions) ClearTargetDatabase() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_TargetDatabase = nil
+}
+
+func (x *RestoreOptions) ClearStopOnError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_StopOnError = false
+}
+
+func (x *RestoreOptions) ClearSingleTransaction() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_SingleTransaction = false
+}
+
+func (x *RestoreOptions) ClearDropAndRecreate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_DropAndRecreate = false
+}
+
+func (x *RestoreOptions) ClearForce() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_Force = false
+}
+
 type RestoreOptions_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
+	// format is the format that the dump was written in: "plain" (the default when empty) or "directory".
+	Format *string
+	// jobs is the number of tables of each database that are restored concurrently, with the directory format.
+	Jobs *int32
+	// databases restores only these databases. Empty restores every database in the dump.
+	Databases []string
+	// schemas and tables restore only these objects, with the directory format.
+	Schemas []string
+	Tables  []string
+	// target_database restores the one selected database into a new database with this name.
+	TargetDatabase *string
+	// stop_on_error stops the restore at the first statement that fails.
+	StopOnError *bool
+	// single_transaction restores the contents of each database in a single transaction.
+	SingleTransaction *bool
+	// drop_and_recreate drops each database that the restore replaces before restoring it.
+	DropAndRecreate *bool
+	// force allows the restore to replace databases that are not empty.
+	Force *bool
 }
 
 func (b0 RestoreOptions_builder) Build() *RestoreOptions {
 	m0 := &RestoreOptions{}
 	b, x := &b0, m0
 	_, _ = b, x
+	if b.Format != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
+		x.xxx_hidden_Format = b.Format
+	}
+	if b.Jobs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Jobs = *b.Jobs
+	}
+	x.xxx_hidden_Databases = b.Databases
+	x.xxx_hidden_Schemas = b.Schemas
+	x.xxx_hidden_Tables = b.Tables
+	if b.TargetDatabase != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
+		x.xxx_hidden_TargetDatabase = b.TargetDatabase
+	}
+	if b.StopOnError != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
+		x.xxx_hidden_StopOnError = *b.StopOnError
+	}
+	if b.SingleTransaction != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
+		x.xxx_hidden_SingleTransaction = *b.SingleTransaction
+	}
+	if b.DropAndRecreate != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		x.xxx_hidden_DropAndRecreate = *b.DropAndRecreate
+	}
+	if b.Force != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		x.xxx_hidden_Force = *b.Force
+	}
 	return m0
 }
 
@@ -242,8 +511,19 @@ const file_postgres_restore_proto_rawDesc = "" +
 	"\x0eRestoreRequest\x129\n" +
 	"\vcredentials\x18\x01 \x01(\v2\x17.EnvironmentCredentialsR\vcredentials\x12&\n" +
 	"\x0finput_file_path\x18\x02 \x01(\tR\rinputFilePath\x12)\n" +
-	"\aoptions\x18\x03 \x01(\v2\x0f.RestoreOptionsR\aoptions\"\x10\n" +
-	"\x0eRestoreOptions\"\x11\n" +
+	"\aoptions\x18\x03 \x01(\v2\x0f.RestoreOptionsR\aoptions\"\xca\x02\n" +
+	"\x0eRestoreOptions\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04jobs\x18\x02 \x01(\x05R\x04jobs\x12\x1c\n" +
+	"\tdatabases\x18\x03 \x03(\tR\tdatabases\x12\x18\n" +
+	"\aschemas\x18\x04 \x03(\tR\aschemas\x12\x16\n" +
+	"\x06tables\x18\x05 \x03(\tR\x06tables\x12'\n" +
+	"\x0ftarget_database\x18\x06 \x01(\tR\x0etargetDatabase\x12\"\n" +
+	"\rstop_on_error\x18\a \x01(\bR\vstopOnError\x12-\n" +
+	"\x12single_transaction\x18\b \x01(\bR\x11singleTransaction\x12*\n" +
+	"\x11drop_and_recreate\x18\t \x01(\bR\x0fdropAndRecreate\x12\x14\n" +
+	"\x05force\x18\n" +
+	" \x01(\bR\x05force\"\x11\n" +
 	"\x0fRestoreResponseB[ZYgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/postgres/v1;postgres_v1b\beditionsp\xe8\a"
 
 var file_postgres_restore_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
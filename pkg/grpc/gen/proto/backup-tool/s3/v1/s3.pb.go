@@ -24,19 +24,24 @@ var File_s3_proto protoreflect.FileDescriptor
 
 const file_s3_proto_rawDesc = "" +
 	"\n" +
-	"\bs3.proto\x1a\x11s3_transfer.proto2)\n" +
+	"\bs3.proto\x1a\x0fs3_rewind.proto\x1a\x11s3_transfer.proto2T\n" +
 	"\x02S3\x12#\n" +
-	"\x04Sync\x12\f.SyncRequest\x1a\r.SyncResponseBOZMgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1;s3_v1b\beditionsp\xe8\a"
+	"\x04Sync\x12\f.SyncRequest\x1a\r.SyncResponse\x12)\n" +
+	"\x06Rewind\x12\x0e.RewindRequest\x1a\x0f.RewindResponseBOZMgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1;s3_v1b\beditionsp\xe8\a"
 
 var file_s3_proto_goTypes = []any{
-	(*SyncRequest)(nil),  // 0: SyncRequest
-	(*SyncResponse)(nil), // 1: SyncResponse
+	(*SyncRequest)(nil),    // 0: SyncRequest
+	(*RewindRequest)(nil),  // 1: RewindRequest
+	(*SyncResponse)(nil),   // 2: SyncResponse
+	(*RewindResponse)(nil), // 3: RewindResponse
 }
 var file_s3_proto_depIdxs = []int32{
 	0, // 0: S3.Sync:input_type -> SyncRequest
-	1, // 1: S3.Sync:output_type -> SyncResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+	1, // 1: S3.Rewind:input_type -> RewindRequest
+	2, // 2: S3.Sync:output_type -> SyncResponse
+	3, // 3: S3.Rewind:output_type -> RewindResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -47,6 +52,7 @@ func file_s3_proto_init() {
 	if File_s3_proto != nil {
 		return
 	}
+	file_s3_rewind_proto_init()
 	file_s3_transfer_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
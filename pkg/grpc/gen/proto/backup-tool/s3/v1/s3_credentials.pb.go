@@ -9,6 +9,7 @@ package s3_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -28,6 +29,7 @@ type Credentials struct {
 	xxx_hidden_Region           *string                `protobuf:"bytes,4,opt,name=region"`
 	xxx_hidden_Endpoint         *string                `protobuf:"bytes,5,opt,name=endpoint"`
 	xxx_hidden_S3ForcePathStyle bool                   `protobuf:"varint,6,opt,name=s3_force_path_style,json=s3ForcePathStyle"`
+	xxx_hidden_AssumeRole       *AssumeRole            `protobuf:"bytes,7,opt,name=assume_role,json=assumeRole"`
 	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
 	XXX_presence                [1]uint32
 	unknownFields               protoimpl.UnknownFields
@@ -116,34 +118,45 @@ func (x *Credentials) GetS3ForcePathStyle() bool {
 	return false
 }
 
+func (x *Credentials) GetAssumeRole() *AssumeRole {
+	if x != nil {
+		return x.xxx_hidden_AssumeRole
+	}
+	return nil
+}
+
 func (x *Credentials) SetAccessKeyId(v string) {
 	x.xxx_hidden_AccessKeyId = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
 }
 
 func (x *Credentials) SetSecretAccessKey(v string) {
 	x.xxx_hidden_SecretAccessKey = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
 }
 
 func (x *Credentials) SetSessionToken(v string) {
 	x.xxx_hidden_SessionToken = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
 }
 
 func (x *Credentials) SetRegion(v string) {
 	x.xxx_hidden_Region = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
 }
 
 func (x *Credentials) SetEndpoint(v string) {
 	x.xxx_hidden_Endpoint = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
 }
 
 func (x *Credentials) SetS3ForcePathStyle(v bool) {
 	x.xxx_hidden_S3ForcePathStyle = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *Credentials) SetAssumeRole(v *AssumeRole) {
+	x.xxx_hidden_AssumeRole = v
 }
 
 func (x *Credentials) HasAccessKeyId() bool {
@@ -188,6 +201,13 @@ func (x *Credentials) HasS3ForcePathStyle() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
+func (x *Credentials) HasAssumeRole() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_AssumeRole != nil
+}
+
 func (x *Credentials) ClearAccessKeyId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_AccessKeyId = nil
@@ -218,6 +238,10 @@ func (x *Credentials) ClearS3ForcePathStyle() {
 	x.xxx_hidden_S3ForcePathStyle = false
 }
 
+func (x *Credentials) ClearAssumeRole() {
+	x.xxx_hidden_AssumeRole = nil
+}
+
 type Credentials_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -227,6 +251,7 @@ type Credentials_builder struct {
 	Region           *string
 	Endpoint         *string
 	S3ForcePathStyle *bool
+	AssumeRole       *AssumeRole
 }
 
 func (b0 Credentials_builder) Build() *Credentials {
@@ -234,29 +259,266 @@ func (b0 Credentials_builder) Build() *Credentials {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.AccessKeyId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
 		x.xxx_hidden_AccessKeyId = b.AccessKeyId
 	}
 	if b.SecretAccessKey != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
 		x.xxx_hidden_SecretAccessKey = b.SecretAccessKey
 	}
 	if b.SessionToken != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
 		x.xxx_hidden_SessionToken = b.SessionToken
 	}
 	if b.Region != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
 		x.xxx_hidden_Region = b.Region
 	}
 	if b.Endpoint != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
 		x.xxx_hidden_Endpoint = b.Endpoint
 	}
 	if b.S3ForcePathStyle != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
 		x.xxx_hidden_S3ForcePathStyle = *b.S3ForcePathStyle
 	}
+	x.xxx_hidden_AssumeRole = b.AssumeRole
+	return m0
+}
+
+type AssumeRole struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_RoleArn          *string                `protobuf:"bytes,1,opt,name=role_arn,json=roleArn"`
+	xxx_hidden_ExternalId       *string                `protobuf:"bytes,2,opt,name=external_id,json=externalId"`
+	xxx_hidden_SessionName      *string                `protobuf:"bytes,3,opt,name=session_name,json=sessionName"`
+	xxx_hidden_Duration         *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration"`
+	xxx_hidden_WebIdentityToken *string                `protobuf:"bytes,5,opt,name=web_identity_token,json=webIdentityToken"`
+	xxx_hidden_StsEndpoint      *string                `protobuf:"bytes,6,opt,name=sts_endpoint,json=stsEndpoint"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *AssumeRole) Reset() {
+	*x = AssumeRole{}
+	mi := &file_s3_credentials_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssumeRole) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssumeRole) ProtoMessage() {}
+
+func (x *AssumeRole) ProtoReflect() protoreflect.Message {
+	mi := &file_s3_credentials_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AssumeRole) GetRoleArn() string {
+	if x != nil {
+		if x.xxx_hidden_RoleArn != nil {
+			return *x.xxx_hidden_RoleArn
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AssumeRole) GetExternalId() string {
+	if x != nil {
+		if x.xxx_hidden_ExternalId != nil {
+			return *x.xxx_hidden_ExternalId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AssumeRole) GetSessionName() string {
+	if x != nil {
+		if x.xxx_hidden_SessionName != nil {
+			return *x.xxx_hidden_SessionName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AssumeRole) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Duration
+	}
+	return nil
+}
+
+func (x *AssumeRole) GetWebIdentityToken() string {
+	if x != nil {
+		if x.xxx_hidden_WebIdentityToken != nil {
+			return *x.xxx_hidden_WebIdentityToken
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AssumeRole) GetStsEndpoint() string {
+	if x != nil {
+		if x.xxx_hidden_StsEndpoint != nil {
+			return *x.xxx_hidden_StsEndpoint
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AssumeRole) SetRoleArn(v string) {
+	x.xxx_hidden_RoleArn = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *AssumeRole) SetExternalId(v string) {
+	x.xxx_hidden_ExternalId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *AssumeRole) SetSessionName(v string) {
+	x.xxx_hidden_SessionName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *AssumeRole) SetDuration(v *durationpb.Duration) {
+	x.xxx_hidden_Duration = v
+}
+
+func (x *AssumeRole) SetWebIdentityToken(v string) {
+	x.xxx_hidden_WebIdentityToken = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *AssumeRole) SetStsEndpoint(v string) {
+	x.xxx_hidden_StsEndpoint = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *AssumeRole) HasRoleArn() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *AssumeRole) HasExternalId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *AssumeRole) HasSessionName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *AssumeRole) HasDuration() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Duration != nil
+}
+
+func (x *AssumeRole) HasWebIdentityToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *AssumeRole) HasStsEndpoint() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *AssumeRole) ClearRoleArn() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_RoleArn = nil
+}
+
+func (x *AssumeRole) ClearExternalId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ExternalId = nil
+}
+
+func (x *AssumeRole) ClearSessionName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_SessionName = nil
+}
+
+func (x *AssumeRole) ClearDuration() {
+	x.xxx_hidden_Duration = nil
+}
+
+func (x *AssumeRole) ClearWebIdentityToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_WebIdentityToken = nil
+}
+
+func (x *AssumeRole) ClearStsEndpoint() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_StsEndpoint = nil
+}
+
+type AssumeRole_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	RoleArn     *string
+	ExternalId  *string
+	SessionName *string
+	Duration    *durationpb.Duration
+	// The token itself rather than the file that holds it, as the file is only readable by the caller.
+	WebIdentityToken *string
+	StsEndpoint      *string
+}
+
+func (b0 AssumeRole_builder) Build() *AssumeRole {
+	m0 := &AssumeRole{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.RoleArn != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_RoleArn = b.RoleArn
+	}
+	if b.ExternalId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_ExternalId = b.ExternalId
+	}
+	if b.SessionName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_SessionName = b.SessionName
+	}
+	x.xxx_hidden_Duration = b.Duration
+	if b.WebIdentityToken != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_WebIdentityToken = b.WebIdentityToken
+	}
+	if b.StsEndpoint != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_StsEndpoint = b.StsEndpoint
+	}
 	return m0
 }
 
@@ -264,25 +526,40 @@ var File_s3_credentials_proto protoreflect.FileDescriptor
 
 const file_s3_credentials_proto_rawDesc = "" +
 	"\n" +
-	"\x14s3_credentials.proto\"\xe5\x01\n" +
+	"\x14s3_credentials.proto\x1a\x1egoogle/protobuf/duration.proto\"\x93\x02\n" +
 	"\vCredentials\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
 	"\x11secret_access_key\x18\x02 \x01(\tR\x0fsecretAccessKey\x12#\n" +
 	"\rsession_token\x18\x03 \x01(\tR\fsessionToken\x12\x16\n" +
 	"\x06region\x18\x04 \x01(\tR\x06region\x12\x1a\n" +
 	"\bendpoint\x18\x05 \x01(\tR\bendpoint\x12-\n" +
-	"\x13s3_force_path_style\x18\x06 \x01(\bR\x10s3ForcePathStyleBOZMgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1;s3_v1b\beditionsp\xe8\a"
-
-var file_s3_credentials_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+	"\x13s3_force_path_style\x18\x06 \x01(\bR\x10s3ForcePathStyle\x12,\n" +
+	"\vassume_role\x18\a \x01(\v2\v.AssumeRoleR\n" +
+	"assumeRole\"\xf3\x01\n" +
+	"\n" +
+	"AssumeRole\x12\x19\n" +
+	"\brole_arn\x18\x01 \x01(\tR\aroleArn\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12!\n" +
+	"\fsession_name\x18\x03 \x01(\tR\vsessionName\x125\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12,\n" +
+	"\x12web_identity_token\x18\x05 \x01(\tR\x10webIdentityToken\x12!\n" +
+	"\fsts_endpoint\x18\x06 \x01(\tR\vstsEndpointBOZMgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1;s3_v1b\beditionsp\xe8\a"
+
+var file_s3_credentials_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_s3_credentials_proto_goTypes = []any{
-	(*Credentials)(nil), // 0: Credentials
+	(*Credentials)(nil),         // 0: Credentials
+	(*AssumeRole)(nil),          // 1: AssumeRole
+	(*durationpb.Duration)(nil), // 2: google.protobuf.Duration
 }
 var file_s3_credentials_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: Credentials.assume_role:type_name -> AssumeRole
+	2, // 1: AssumeRole.duration:type_name -> google.protobuf.Duration
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_s3_credentials_proto_init() }
@@ -296,7 +573,7 @@ func file_s3_credentials_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_s3_credentials_proto_rawDesc), len(file_s3_credentials_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
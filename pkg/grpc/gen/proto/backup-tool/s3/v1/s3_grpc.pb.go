@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	S3_Sync_FullMethodName = "/S3/Sync"
+	S3_Sync_FullMethodName   = "/S3/Sync"
+	S3_Rewind_FullMethodName = "/S3/Rewind"
 )
 
 // S3Client is the client API for S3 service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type S3Client interface {
 	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	Rewind(ctx context.Context, in *RewindRequest, opts ...grpc.CallOption) (*RewindResponse, error)
 }
 
 type s3Client struct {
@@ -47,11 +49,22 @@ func (c *s3Client) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *s3Client) Rewind(ctx context.Context, in *RewindRequest, opts ...grpc.CallOption) (*RewindResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RewindResponse)
+	err := c.cc.Invoke(ctx, S3_Rewind_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // S3Server is the server API for S3 service.
 // All implementations must embed UnimplementedS3Server
 // for forward compatibility.
 type S3Server interface {
 	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	Rewind(context.Context, *RewindRequest) (*RewindResponse, error)
 	mustEmbedUnimplementedS3Server()
 }
 
@@ -65,6 +78,9 @@ type UnimplementedS3Server struct{}
 func (UnimplementedS3Server) Sync(context.Context, *SyncRequest) (*SyncResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Sync not implemented")
 }
+func (UnimplementedS3Server) Rewind(context.Context, *RewindRequest) (*RewindResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Rewind not implemented")
+}
 func (UnimplementedS3Server) mustEmbedUnimplementedS3Server() {}
 func (UnimplementedS3Server) testEmbeddedByValue()            {}
 
@@ -104,6 +120,24 @@ func _S3_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _S3_Rewind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(S3Server).Rewind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: S3_Rewind_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(S3Server).Rewind(ctx, req.(*RewindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // S3_ServiceDesc is the grpc.ServiceDesc for S3 service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +149,10 @@ var S3_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Sync",
 			Handler:    _S3_Sync_Handler,
 		},
+		{
+			MethodName: "Rewind",
+			Handler:    _S3_Rewind_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "s3.proto",
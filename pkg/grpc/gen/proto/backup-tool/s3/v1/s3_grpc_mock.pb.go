@@ -38,6 +38,23 @@ func (c *MockS3Client) OnSync(ctx interface{}, in interface{}, opts ...interface
 	return c.On("Sync", append([]interface{}{ctx, in}, opts...)...)
 }
 
+func (c *MockS3Client) Rewind(ctx context.Context, in *RewindRequest, opts ...grpc.CallOption) (*RewindResponse, error) {
+	opts0 := []interface{}{ctx, in}
+	for _, opts1 := range opts {
+		opts0 = append(opts0, opts1)
+	}
+	args := c.Called(opts0...)
+	var ret0 *RewindResponse
+	if args.Get(0) != nil {
+		ret0 = args.Get(0).(*RewindResponse)
+	}
+	return ret0, args.Error(1)
+}
+
+func (c *MockS3Client) OnRewind(ctx interface{}, in interface{}, opts ...interface{}) *mock.Call {
+	return c.On("Rewind", append([]interface{}{ctx, in}, opts...)...)
+}
+
 type MockS3Server struct {
 	mock.Mock
 }
@@ -58,3 +75,16 @@ func (s *MockS3Server) Sync(ctx context.Context, in *SyncRequest) (*SyncResponse
 func (s *MockS3Server) OnSync(ctx interface{}, in interface{}) *mock.Call {
 	return s.On("Sync", ctx, in)
 }
+
+func (s *MockS3Server) Rewind(ctx context.Context, in *RewindRequest) (*RewindResponse, error) {
+	args := s.Called(ctx, in)
+	var ret0 *RewindResponse
+	if args.Get(0) != nil {
+		ret0 = args.Get(0).(*RewindResponse)
+	}
+	return ret0, args.Error(1)
+}
+
+func (s *MockS3Server) OnRewind(ctx interface{}, in interface{}) *mock.Call {
+	return s.On("Rewind", ctx, in)
+}
@@ -9,6 +9,7 @@ package s3_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
@@ -22,15 +23,27 @@ const (
 )
 
 type SyncRequest struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Credentials *Credentials           `protobuf:"bytes,1,opt,name=credentials"`
-	xxx_hidden_Source      *string                `protobuf:"bytes,2,opt,name=source"`
-	xxx_hidden_Dest        *string                `protobuf:"bytes,3,opt,name=dest"`
-	xxx_hidden_AsOf        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Credentials         *Credentials           `protobuf:"bytes,1,opt,name=credentials"`
+	xxx_hidden_Source              *string                `protobuf:"bytes,2,opt,name=source"`
+	xxx_hidden_Dest                *string                `protobuf:"bytes,3,opt,name=dest"`
+	xxx_hidden_AsOf                *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=as_of,json=asOf"`
+	xxx_hidden_BytesPerSecond      int64                  `protobuf:"varint,5,opt,name=bytes_per_second,json=bytesPerSecond"`
+	xxx_hidden_FilesPerSecond      float64                `protobuf:"fixed64,6,opt,name=files_per_second,json=filesPerSecond"`
+	xxx_hidden_DestCredentials     *Credentials           `protobuf:"bytes,7,opt,name=dest_credentials,json=destCredentials"`
+	xxx_hidden_ManifestPath        *string                `protobuf:"bytes,8,opt,name=manifest_path,json=manifestPath"`
+	xxx_hidden_MultipartThreshold  int64                  `protobuf:"varint,9,opt,name=multipart_threshold,json=multipartThreshold"`
+	xxx_hidden_PartSize            int64                  `protobuf:"varint,10,opt,name=part_size,json=partSize"`
+	xxx_hidden_Rehash              bool                   `protobuf:"varint,11,opt,name=rehash"`
+	xxx_hidden_Include             *[]*KeyPattern         `protobuf:"bytes,12,rep,name=include"`
+	xxx_hidden_Exclude             *[]*KeyPattern         `protobuf:"bytes,13,rep,name=exclude"`
+	xxx_hidden_ObjectLockMode      *string                `protobuf:"bytes,14,opt,name=object_lock_mode,json=objectLockMode"`
+	xxx_hidden_ObjectLockRetention *durationpb.Duration   `protobuf:"bytes,15,opt,name=object_lock_retention,json=objectLockRetention"`
+	xxx_hidden_ObjectLockLegalHold bool                   `protobuf:"varint,16,opt,name=object_lock_legal_hold,json=objectLockLegalHold"`
+	XXX_raceDetectHookData         protoimpl.RaceDetectHookData
+	XXX_presence                   [1]uint32
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
 }
 
 func (x *SyncRequest) Reset() {
@@ -92,24 +105,174 @@ func (x *SyncRequest) GetAsOf() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *SyncRequest) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.xxx_hidden_BytesPerSecond
+	}
+	return 0
+}
+
+func (x *SyncRequest) GetFilesPerSecond() float64 {
+	if x != nil {
+		return x.xxx_hidden_FilesPerSecond
+	}
+	return 0
+}
+
+func (x *SyncRequest) GetDestCredentials() *Credentials {
+	if x != nil {
+		return x.xxx_hidden_DestCredentials
+	}
+	return nil
+}
+
+func (x *SyncRequest) GetManifestPath() string {
+	if x != nil {
+		if x.xxx_hidden_ManifestPath != nil {
+			return *x.xxx_hidden_ManifestPath
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SyncRequest) GetMultipartThreshold() int64 {
+	if x != nil {
+		return x.xxx_hidden_MultipartThreshold
+	}
+	return 0
+}
+
+func (x *SyncRequest) GetPartSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_PartSize
+	}
+	return 0
+}
+
+func (x *SyncRequest) GetRehash() bool {
+	if x != nil {
+		return x.xxx_hidden_Rehash
+	}
+	return false
+}
+
+func (x *SyncRequest) GetInclude() []*KeyPattern {
+	if x != nil {
+		if x.xxx_hidden_Include != nil {
+			return *x.xxx_hidden_Include
+		}
+	}
+	return nil
+}
+
+func (x *SyncRequest) GetExclude() []*KeyPattern {
+	if x != nil {
+		if x.xxx_hidden_Exclude != nil {
+			return *x.xxx_hidden_Exclude
+		}
+	}
+	return nil
+}
+
+func (x *SyncRequest) GetObjectLockMode() string {
+	if x != nil {
+		if x.xxx_hidden_ObjectLockMode != nil {
+			return *x.xxx_hidden_ObjectLockMode
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SyncRequest) GetObjectLockRetention() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_ObjectLockRetention
+	}
+	return nil
+}
+
+func (x *SyncRequest) GetObjectLockLegalHold() bool {
+	if x != nil {
+		return x.xxx_hidden_ObjectLockLegalHold
+	}
+	return false
+}
+
 func (x *SyncRequest) SetCredentials(v *Credentials) {
 	x.xxx_hidden_Credentials = v
 }
 
 func (x *SyncRequest) SetSource(v string) {
 	x.xxx_hidden_Source = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 16)
 }
 
 func (x *SyncRequest) SetDest(v string) {
 	x.xxx_hidden_Dest = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 16)
 }
 
 func (x *SyncRequest) SetAsOf(v *timestamppb.Timestamp) {
 	x.xxx_hidden_AsOf = v
 }
 
+func (x *SyncRequest) SetBytesPerSecond(v int64) {
+	x.xxx_hidden_BytesPerSecond = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 16)
+}
+
+func (x *SyncRequest) SetFilesPerSecond(v float64) {
+	x.xxx_hidden_FilesPerSecond = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 16)
+}
+
+func (x *SyncRequest) SetDestCredentials(v *Credentials) {
+	x.xxx_hidden_DestCredentials = v
+}
+
+func (x *SyncRequest) SetManifestPath(v string) {
+	x.xxx_hidden_ManifestPath = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 16)
+}
+
+func (x *SyncRequest) SetMultipartThreshold(v int64) {
+	x.xxx_hidden_MultipartThreshold = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 16)
+}
+
+func (x *SyncRequest) SetPartSize(v int64) {
+	x.xxx_hidden_PartSize = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 16)
+}
+
+func (x *SyncRequest) SetRehash(v bool) {
+	x.xxx_hidden_Rehash = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 16)
+}
+
+func (x *SyncRequest) SetInclude(v []*KeyPattern) {
+	x.xxx_hidden_Include = &v
+}
+
+func (x *SyncRequest) SetExclude(v []*KeyPattern) {
+	x.xxx_hidden_Exclude = &v
+}
+
+func (x *SyncRequest) SetObjectLockMode(v string) {
+	x.xxx_hidden_ObjectLockMode = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 16)
+}
+
+func (x *SyncRequest) SetObjectLockRetention(v *durationpb.Duration) {
+	x.xxx_hidden_ObjectLockRetention = v
+}
+
+func (x *SyncRequest) SetObjectLockLegalHold(v bool) {
+	x.xxx_hidden_ObjectLockLegalHold = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 16)
+}
+
 func (x *SyncRequest) HasCredentials() bool {
 	if x == nil {
 		return false
@@ -138,6 +301,76 @@ func (x *SyncRequest) HasAsOf() bool {
 	return x.xxx_hidden_AsOf != nil
 }
 
+func (x *SyncRequest) HasBytesPerSecond() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *SyncRequest) HasFilesPerSecond() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *SyncRequest) HasDestCredentials() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_DestCredentials != nil
+}
+
+func (x *SyncRequest) HasManifestPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *SyncRequest) HasMultipartThreshold() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *SyncRequest) HasPartSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *SyncRequest) HasRehash() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *SyncRequest) HasObjectLockMode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 13)
+}
+
+func (x *SyncRequest) HasObjectLockRetention() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ObjectLockRetention != nil
+}
+
+func (x *SyncRequest) HasObjectLockLegalHold() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 15)
+}
+
 func (x *SyncRequest) ClearCredentials() {
 	x.xxx_hidden_Credentials = nil
 }
@@ -156,6 +389,54 @@ func (x *SyncRequest) ClearAsOf() {
 	x.xxx_hidden_AsOf = nil
 }
 
+func (x *SyncRequest) ClearBytesPerSecond() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_BytesPerSecond = 0
+}
+
+func (x *SyncRequest) ClearFilesPerSecond() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_FilesPerSecond = 0
+}
+
+func (x *SyncRequest) ClearDestCredentials() {
+	x.xxx_hidden_DestCredentials = nil
+}
+
+func (x *SyncRequest) ClearManifestPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_ManifestPath = nil
+}
+
+func (x *SyncRequest) ClearMultipartThreshold() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_MultipartThreshold = 0
+}
+
+func (x *SyncRequest) ClearPartSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_PartSize = 0
+}
+
+func (x *SyncRequest) ClearRehash() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_Rehash = false
+}
+
+func (x *SyncRequest) ClearObjectLockMode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 13)
+	x.xxx_hidden_ObjectLockMode = nil
+}
+
+func (x *SyncRequest) ClearObjectLockRetention() {
+	x.xxx_hidden_ObjectLockRetention = nil
+}
+
+func (x *SyncRequest) ClearObjectLockLegalHold() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 15)
+	x.xxx_hidden_ObjectLockLegalHold = false
+}
+
 type SyncRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -165,6 +446,36 @@ type SyncRequest_builder struct {
 	// as_of is the event's shared consistency point: the instant the bucket should be captured as of.
 	// Unset means "no consistency point".
 	AsOf *timestamppb.Timestamp
+	// bytes_per_second caps the rate that object contents are read at. Zero means unlimited.
+	BytesPerSecond *int64
+	// files_per_second caps the rate that objects are transferred at. Zero means unlimited.
+	FilesPerSecond *float64
+	// dest_credentials authenticates to the destination bucket of a bucket-to-bucket sync. Unset means that
+	// credentials is used for both buckets.
+	DestCredentials *Credentials
+	// manifest_path is where a download writes a manifest recording how the bucket was captured, and the
+	// checksums that an upload verifies files against. Empty means no manifest is written or read.
+	ManifestPath *string
+	// multipart_threshold is the object size at and above which objects are transferred in parts. Zero means
+	// the default.
+	MultipartThreshold *int64
+	// part_size is the size of each part of a multipart transfer. Zero means the default.
+	PartSize *int64
+	// rehash revalidates the local copies that a download skips as up to date against their checksums.
+	Rehash *bool
+	// include is a whitelist; when non-empty only objects whose keys (relative to the prefix) match one of these
+	// patterns are synced.
+	Include []*KeyPattern
+	// exclude is a blacklist; objects matching one of these patterns, or under a matching "directory", are not
+	// synced (exclude wins).
+	Exclude []*KeyPattern
+	// object_lock_mode is the S3 Object Lock retention mode ("GOVERNANCE" or "COMPLIANCE") that uploaded objects
+	// are given. Empty means no retention.
+	ObjectLockMode *string
+	// object_lock_retention is how long uploaded objects are retained for. It is set along with object_lock_mode.
+	ObjectLockRetention *durationpb.Duration
+	// object_lock_legal_hold places a legal hold on uploaded objects.
+	ObjectLockLegalHold *bool
 }
 
 func (b0 SyncRequest_builder) Build() *SyncRequest {
@@ -173,14 +484,130 @@ func (b0 SyncRequest_builder) Build() *SyncRequest {
 	_, _ = b, x
 	x.xxx_hidden_Credentials = b.Credentials
 	if b.Source != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 16)
 		x.xxx_hidden_Source = b.Source
 	}
 	if b.Dest != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 16)
 		x.xxx_hidden_Dest = b.Dest
 	}
 	x.xxx_hidden_AsOf = b.AsOf
+	if b.BytesPerSecond != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 16)
+		x.xxx_hidden_BytesPerSecond = *b.BytesPerSecond
+	}
+	if b.FilesPerSecond != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 16)
+		x.xxx_hidden_FilesPerSecond = *b.FilesPerSecond
+	}
+	x.xxx_hidden_DestCredentials = b.DestCredentials
+	if b.ManifestPath != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 16)
+		x.xxx_hidden_ManifestPath = b.ManifestPath
+	}
+	if b.MultipartThreshold != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 16)
+		x.xxx_hidden_MultipartThreshold = *b.MultipartThreshold
+	}
+	if b.PartSize != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 16)
+		x.xxx_hidden_PartSize = *b.PartSize
+	}
+	if b.Rehash != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 16)
+		x.xxx_hidden_Rehash = *b.Rehash
+	}
+	x.xxx_hidden_Include = &b.Include
+	x.xxx_hidden_Exclude = &b.Exclude
+	if b.ObjectLockMode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 16)
+		x.xxx_hidden_ObjectLockMode = b.ObjectLockMode
+	}
+	x.xxx_hidden_ObjectLockRetention = b.ObjectLockRetention
+	if b.ObjectLockLegalHold != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 16)
+		x.xxx_hidden_ObjectLockLegalHold = *b.ObjectLockLegalHold
+	}
+	return m0
+}
+
+// KeyPattern matches object keys relative to the synced prefix. The matcher is selected by which field is set,
+// as with the files service's FilePattern.
+type KeyPattern struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Glob        *string                `protobuf:"bytes,1,opt,name=glob"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *KeyPattern) Reset() {
+	*x = KeyPattern{}
+	mi := &file_s3_transfer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyPattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPattern) ProtoMessage() {}
+
+func (x *KeyPattern) ProtoReflect() protoreflect.Message {
+	mi := &file_s3_transfer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *KeyPattern) GetGlob() string {
+	if x != nil {
+		if x.xxx_hidden_Glob != nil {
+			return *x.xxx_hidden_Glob
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *KeyPattern) SetGlob(v string) {
+	x.xxx_hidden_Glob = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *KeyPattern) HasGlob() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *KeyPattern) ClearGlob() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Glob = nil
+}
+
+type KeyPattern_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Glob *string
+}
+
+func (b0 KeyPattern_builder) Build() *KeyPattern {
+	m0 := &KeyPattern{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Glob != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Glob = b.Glob
+	}
 	return m0
 }
 
@@ -192,7 +619,7 @@ type SyncResponse struct {
 
 func (x *SyncResponse) Reset() {
 	*x = SyncResponse{}
-	mi := &file_s3_transfer_proto_msgTypes[1]
+	mi := &file_s3_transfer_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -204,7 +631,7 @@ func (x *SyncResponse) String() string {
 func (*SyncResponse) ProtoMessage() {}
 
 func (x *SyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_s3_transfer_proto_msgTypes[1]
+	mi := &file_s3_transfer_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,29 +658,51 @@ var File_s3_transfer_proto protoreflect.FileDescriptor
 
 const file_s3_transfer_proto_rawDesc = "" +
 	"\n" +
-	"\x11s3_transfer.proto\x1a\x14s3_credentials.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9a\x01\n" +
+	"\x11s3_transfer.proto\x1a\x14s3_credentials.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x05\n" +
 	"\vSyncRequest\x12.\n" +
 	"\vcredentials\x18\x01 \x01(\v2\f.CredentialsR\vcredentials\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x12\x12\n" +
 	"\x04dest\x18\x03 \x01(\tR\x04dest\x12/\n" +
-	"\x05as_of\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"\x0e\n" +
+	"\x05as_of\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12(\n" +
+	"\x10bytes_per_second\x18\x05 \x01(\x03R\x0ebytesPerSecond\x12(\n" +
+	"\x10files_per_second\x18\x06 \x01(\x01R\x0efilesPerSecond\x127\n" +
+	"\x10dest_credentials\x18\a \x01(\v2\f.CredentialsR\x0fdestCredentials\x12#\n" +
+	"\rmanifest_path\x18\b \x01(\tR\fmanifestPath\x12/\n" +
+	"\x13multipart_threshold\x18\t \x01(\x03R\x12multipartThreshold\x12\x1b\n" +
+	"\tpart_size\x18\n" +
+	" \x01(\x03R\bpartSize\x12\x16\n" +
+	"\x06rehash\x18\v \x01(\bR\x06rehash\x12%\n" +
+	"\ainclude\x18\f \x03(\v2\v.KeyPatternR\ainclude\x12%\n" +
+	"\aexclude\x18\r \x03(\v2\v.KeyPatternR\aexclude\x12(\n" +
+	"\x10object_lock_mode\x18\x0e \x01(\tR\x0eobjectLockMode\x12M\n" +
+	"\x15object_lock_retention\x18\x0f \x01(\v2\x19.google.protobuf.DurationR\x13objectLockRetention\x123\n" +
+	"\x16object_lock_legal_hold\x18\x10 \x01(\bR\x13objectLockLegalHold\" \n" +
+	"\n" +
+	"KeyPattern\x12\x12\n" +
+	"\x04glob\x18\x01 \x01(\tR\x04glob\"\x0e\n" +
 	"\fSyncResponseBOZMgithub.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1;s3_v1b\beditionsp\xe8\a"
 
-var file_s3_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_s3_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_s3_transfer_proto_goTypes = []any{
 	(*SyncRequest)(nil),           // 0: SyncRequest
-	(*SyncResponse)(nil),          // 1: SyncResponse
-	(*Credentials)(nil),           // 2: Credentials
-	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*KeyPattern)(nil),            // 1: KeyPattern
+	(*SyncResponse)(nil),          // 2: SyncResponse
+	(*Credentials)(nil),           // 3: Credentials
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 5: google.protobuf.Duration
 }
 var file_s3_transfer_proto_depIdxs = []int32{
-	2, // 0: SyncRequest.credentials:type_name -> Credentials
-	3, // 1: SyncRequest.as_of:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: SyncRequest.credentials:type_name -> Credentials
+	4, // 1: SyncRequest.as_of:type_name -> google.protobuf.Timestamp
+	3, // 2: SyncRequest.dest_credentials:type_name -> Credentials
+	1, // 3: SyncRequest.include:type_name -> KeyPattern
+	1, // 4: SyncRequest.exclude:type_name -> KeyPattern
+	5, // 5: SyncRequest.object_lock_retention:type_name -> google.protobuf.Duration
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_s3_transfer_proto_init() }
@@ -268,7 +717,7 @@ func file_s3_transfer_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_s3_transfer_proto_rawDesc), len(file_s3_transfer_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
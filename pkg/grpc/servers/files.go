@@ -7,6 +7,8 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/files"
 	files_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/files/v1"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // TODO figure out a way to auto generate this and protobufs
@@ -36,7 +38,12 @@ func filePatternsFromProto(protoPatterns []*files_v1.FilePattern) []files.FilePa
 
 func (fs *FilesServer) CopyFiles(ctx context.Context, req *files_v1.CopyFilesRequest) (*files_v1.CopyFilesResponse, error) {
 	grpcCtx := contexts.UnwrapHandlerContext(ctx)
-	err := fs.runtime.CopyFiles(grpcCtx, req.GetSource(), req.GetDest())
+	err := fs.runtime.CopyFiles(grpcCtx, req.GetSource(), req.GetDest(), files.CopyFilesOptions{
+		Limits: throttle.Limits{
+			BytesPerSecond: req.GetBytesPerSecond(),
+			FilesPerSecond: req.GetFilesPerSecond(),
+		},
+	})
 	if err != nil {
 		return nil, trail.Send(grpcCtx, err)
 	}
@@ -51,6 +58,12 @@ func (fs *FilesServer) SyncFiles(ctx context.Context, req *files_v1.SyncFilesReq
 			Include: filePatternsFromProto(req.GetInclude()),
 			Exclude: filePatternsFromProto(req.GetExclude()),
 		},
+		Limits: throttle.Limits{
+			BytesPerSecond: req.GetBytesPerSecond(),
+			FilesPerSecond: req.GetFilesPerSecond(),
+		},
+		Parallelism:     int(req.GetParallelism()),
+		SQLiteDatabases: req.GetSqliteDatabases(),
 	})
 	if err != nil {
 		return nil, trail.Send(grpcCtx, err)
@@ -70,3 +83,78 @@ func (fs *FilesServer) ListDirectory(ctx context.Context, req *files_v1.ListDire
 		Entries: entries,
 	}.Build(), nil
 }
+
+// entryTypeToProto maps a local tree entry type onto its wire representation.
+func entryTypeToProto(entryType files.EntryType) files_v1.EntryType {
+	switch entryType {
+	case files.EntryTypeFile:
+		return files_v1.EntryType_ENTRY_TYPE_FILE
+	case files.EntryTypeDirectory:
+		return files_v1.EntryType_ENTRY_TYPE_DIRECTORY
+	case files.EntryTypeSymlink:
+		return files_v1.EntryType_ENTRY_TYPE_SYMLINK
+	default:
+		return files_v1.EntryType_ENTRY_TYPE_OTHER
+	}
+}
+
+// treeEntriesToProto maps local tree entries onto their wire representation.
+func treeEntriesToProto(entries []files.TreeEntry) []*files_v1.TreeEntry {
+	protoEntries := make([]*files_v1.TreeEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = files_v1.TreeEntry_builder{
+			Path:       &entry.Path,
+			Type:       new(entryTypeToProto(entry.Type)),
+			Size:       &entry.Size,
+			Mode:       new(uint32(entry.Mode)),
+			Uid:        &entry.UID,
+			Gid:        &entry.GID,
+			ModTime:    timestamppb.New(entry.ModTime),
+			LinkTarget: &entry.LinkTarget,
+		}.Build()
+	}
+	return protoEntries
+}
+
+func (fs *FilesServer) ListTree(ctx context.Context, req *files_v1.ListTreeRequest) (*files_v1.ListTreeResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+	result, err := fs.runtime.ListTree(grpcCtx, req.GetPath(), files.ListTreeOptions{
+		MaxDepth: int(req.GetMaxDepth()),
+		Filter: files.FileFilter{
+			Include: filePatternsFromProto(req.GetInclude()),
+			Exclude: filePatternsFromProto(req.GetExclude()),
+		},
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return files_v1.ListTreeResponse_builder{
+		Entries:       treeEntriesToProto(result.Entries),
+		NextPageToken: &result.NextPageToken,
+	}.Build(), nil
+}
+
+func (fs *FilesServer) WriteFile(ctx context.Context, req *files_v1.WriteFileRequest) (*files_v1.WriteFileResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+	err := fs.runtime.WriteFile(grpcCtx, req.GetPath(), req.GetContents())
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return &files_v1.WriteFileResponse{}, nil
+}
+
+func (fs *FilesServer) ReadFile(ctx context.Context, req *files_v1.ReadFileRequest) (*files_v1.ReadFileResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+	contents, err := fs.runtime.ReadFile(grpcCtx, req.GetPath())
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return files_v1.ReadFileResponse_builder{
+		Contents: contents,
+	}.Build(), nil
+}
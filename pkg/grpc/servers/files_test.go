@@ -3,13 +3,16 @@ package servers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/files"
 	files_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/files/v1"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewFilesServer(t *testing.T) {
@@ -60,13 +63,15 @@ func transferTest(t *testing.T, onExpectCall func(expecter *files.MockRuntime_Ex
 
 func TestCopyFiles(t *testing.T) {
 	onExpectCall := func(expecter *files.MockRuntime_Expecter, ctx *contexts.Context, src, dest string) *mock.Call {
-		return expecter.CopyFiles(ctx, src, dest).Call
+		return expecter.CopyFiles(ctx, src, dest, files.CopyFilesOptions{Limits: throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10}}).Call
 	}
 
 	call := func(fs *FilesServer, ctx context.Context, src, dest string) (interface{}, error) {
 		req := files_v1.CopyFilesRequest_builder{
-			Source: &src,
-			Dest:   &dest,
+			Source:         &src,
+			Dest:           &dest,
+			BytesPerSecond: new(int64(1024)),
+			FilesPerSecond: new(float64(10)),
 		}.Build()
 
 		return fs.CopyFiles(ctx, req)
@@ -77,13 +82,15 @@ func TestCopyFiles(t *testing.T) {
 
 func TestSyncFiles(t *testing.T) {
 	onExpectCall := func(expecter *files.MockRuntime_Expecter, ctx *contexts.Context, src, dest string) *mock.Call {
-		return expecter.SyncFiles(ctx, src, dest, files.SyncFilesOptions{}).Call
+		return expecter.SyncFiles(ctx, src, dest, files.SyncFilesOptions{Parallelism: 8, SQLiteDatabases: []string{"app.db"}}).Call
 	}
 
 	call := func(fs *FilesServer, ctx context.Context, src, dest string) (interface{}, error) {
 		req := files_v1.SyncFilesRequest_builder{
-			Source: &src,
-			Dest:   &dest,
+			Source:          &src,
+			Dest:            &dest,
+			Parallelism:     new(int32(8)),
+			SqliteDatabases: []string{"app.db"},
 		}.Build()
 
 		return fs.SyncFiles(ctx, req)
@@ -134,3 +141,183 @@ func TestListDirectory(t *testing.T) {
 		})
 	}
 }
+
+func TestListTree(t *testing.T) {
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []files.TreeEntry{
+		{
+			Path:    "dir",
+			Type:    files.EntryTypeDirectory,
+			Size:    4096,
+			Mode:    0755,
+			UID:     1000,
+			GID:     1000,
+			ModTime: modTime,
+		},
+		{
+			Path:       "dir/link",
+			Type:       files.EntryTypeSymlink,
+			Mode:       0777,
+			ModTime:    modTime,
+			LinkTarget: "../file",
+		},
+	}
+
+	tests := []struct {
+		desc        string
+		returnValue error
+		shouldError bool
+	}{
+		{
+			desc: "successful",
+		},
+		{
+			desc:        "failure",
+			returnValue: assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runtime := files.NewMockRuntime(t)
+			server := NewFilesServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			path := "path"
+			opts := files.ListTreeOptions{
+				MaxDepth: 2,
+				Filter: files.FileFilter{
+					Include: []files.FilePattern{{Glob: "**/*.db"}},
+					Exclude: []files.FilePattern{{Glob: "cache"}},
+				},
+				PageSize:  10,
+				PageToken: "token",
+			}
+
+			var result files.ListTreeResult
+			if !tt.shouldError {
+				result = files.ListTreeResult{Entries: entries, NextPageToken: "dir/link"}
+			}
+			runtime.EXPECT().ListTree(contexts.UnwrapHandlerContext(ctx), path, opts).Return(result, tt.returnValue)
+
+			resp, err := server.ListTree(ctx, files_v1.ListTreeRequest_builder{
+				Path:      &path,
+				MaxDepth:  new(int32(2)),
+				Include:   []*files_v1.FilePattern{files_v1.FilePattern_builder{Glob: new("**/*.db")}.Build()},
+				Exclude:   []*files_v1.FilePattern{files_v1.FilePattern_builder{Glob: new("cache")}.Build()},
+				PageSize:  new(int32(10)),
+				PageToken: new("token"),
+			}.Build())
+			if tt.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			require.NotNil(t, resp)
+			assert.Equal(t, "dir/link", resp.GetNextPageToken())
+			require.Len(t, resp.GetEntries(), len(entries))
+
+			dir := resp.GetEntries()[0]
+			assert.Equal(t, "dir", dir.GetPath())
+			assert.Equal(t, files_v1.EntryType_ENTRY_TYPE_DIRECTORY, dir.GetType())
+			assert.Equal(t, int64(4096), dir.GetSize())
+			assert.Equal(t, uint32(0755), dir.GetMode())
+			assert.Equal(t, uint32(1000), dir.GetUid())
+			assert.Equal(t, uint32(1000), dir.GetGid())
+			assert.True(t, modTime.Equal(dir.GetModTime().AsTime()))
+
+			link := resp.GetEntries()[1]
+			assert.Equal(t, files_v1.EntryType_ENTRY_TYPE_SYMLINK, link.GetType())
+			assert.Equal(t, "../file", link.GetLinkTarget())
+		})
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	tests := []struct {
+		desc        string
+		returnValue error
+		shouldError bool
+	}{
+		{
+			desc: "successful",
+		},
+		{
+			desc:        "failure",
+			returnValue: assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runtime := files.NewMockRuntime(t)
+			server := NewFilesServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			path := "path"
+			contents := []byte("contents")
+			runtime.EXPECT().WriteFile(contexts.UnwrapHandlerContext(ctx), path, contents).Return(tt.returnValue)
+
+			resp, err := server.WriteFile(ctx, files_v1.WriteFileRequest_builder{
+				Path:     &path,
+				Contents: contents,
+			}.Build())
+			if tt.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+			}
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	tests := []struct {
+		desc        string
+		contents    []byte
+		returnValue error
+		shouldError bool
+	}{
+		{
+			desc:     "successful",
+			contents: []byte("contents"),
+		},
+		{
+			desc:        "failure",
+			returnValue: assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runtime := files.NewMockRuntime(t)
+			server := NewFilesServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			path := "path"
+			runtime.EXPECT().ReadFile(contexts.UnwrapHandlerContext(ctx), path).Return(tt.contents, tt.returnValue)
+
+			resp, err := server.ReadFile(ctx, files_v1.ReadFileRequest_builder{
+				Path: &path,
+			}.Build())
+			if tt.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+				assert.Equal(t, tt.contents, resp.GetContents())
+			}
+		})
+	}
+}
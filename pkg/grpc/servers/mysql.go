@@ -0,0 +1,68 @@
+package servers
+
+import (
+	"context"
+
+	"github.com/gravitational/trace/trail"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	mysql_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/mysql/v1"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+)
+
+type MySQLServer struct {
+	mysql_v1.UnimplementedMySQLServer
+	runtime mysql.Runtime
+}
+
+func NewMySQLServer() *MySQLServer {
+	return &MySQLServer{
+		runtime: mysql.NewLocalRuntime(),
+	}
+}
+
+func decodeMySQLCredentials(encodedCredentials *mysql_v1.MySQLCredentials) mysql.Credentials {
+	return mysql.Credentials{
+		Host:          encodedCredentials.GetHost(),
+		Port:          int(encodedCredentials.GetPort()),
+		User:          encodedCredentials.GetUser(),
+		Password:      encodedCredentials.GetPassword(),
+		SSLMode:       mysql.SSLMode(encodedCredentials.GetSslMode()),
+		SSLCAFilePath: encodedCredentials.GetSslCaFilePath(),
+	}
+}
+
+func decodeMySQLDumpOptions(encodedOptions *mysql_v1.MySQLDumpOptions) mysql.DumpOptions {
+	return mysql.DumpOptions{
+		Consistency:          mysql.Consistency(encodedOptions.GetConsistency()),
+		RecordBinlogPosition: encodedOptions.GetRecordBinlogPosition(),
+		IncludeDatabases:     encodedOptions.GetIncludeDatabases(),
+		ExcludeDatabases:     encodedOptions.GetExcludeDatabases(),
+		ExcludeTables:        encodedOptions.GetExcludeTables(),
+	}
+}
+
+func (ms *MySQLServer) Dump(ctx context.Context, req *mysql_v1.MySQLDumpRequest) (*mysql_v1.MySQLDumpResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+	err := ms.runtime.Dump(grpcCtx, decodeMySQLCredentials(req.GetCredentials()), req.GetOutputFilePath(), decodeMySQLDumpOptions(req.GetOptions()))
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return &mysql_v1.MySQLDumpResponse{}, nil
+}
+
+func decodeMySQLRestoreOptions(encodedOptions *mysql_v1.MySQLRestoreOptions) mysql.RestoreOptions {
+	return mysql.RestoreOptions{
+		Force: encodedOptions.GetForce(),
+	}
+}
+
+func (ms *MySQLServer) Restore(ctx context.Context, req *mysql_v1.MySQLRestoreRequest) (*mysql_v1.MySQLRestoreResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+	err := ms.runtime.Restore(grpcCtx, decodeMySQLCredentials(req.GetCredentials()), req.GetInputFilePath(), decodeMySQLRestoreOptions(req.GetOptions()))
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return &mysql_v1.MySQLRestoreResponse{}, nil
+}
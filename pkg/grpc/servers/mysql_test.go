@@ -0,0 +1,140 @@
+package servers
+
+import (
+	"testing"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	mysql_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/mysql/v1"
+	"github.com/solidDoWant/backup-tool/pkg/mysql"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMySQLServer(t *testing.T) {
+	server := NewMySQLServer()
+
+	assert.NotNil(t, server)
+	assert.NotNil(t, server.runtime)
+}
+
+func TestDecodeMySQLCredentials(t *testing.T) {
+	assert.Equal(t, mysql.Credentials{}, decodeMySQLCredentials(&mysql_v1.MySQLCredentials{}))
+	assert.Equal(t, mysql.Credentials{Host: "db.example.com", Port: 3307, User: "root", Password: "password"},
+		decodeMySQLCredentials(mysql_v1.MySQLCredentials_builder{Host: new("db.example.com"), Port: new(int32(3307)), User: new("root"), Password: new("password")}.Build()))
+	assert.Equal(t, mysql.Credentials{Host: "db.example.com", User: "root", SSLMode: mysql.SSLModeVerifyIdentity, SSLCAFilePath: "/ca.crt"},
+		decodeMySQLCredentials(mysql_v1.MySQLCredentials_builder{Host: new("db.example.com"), User: new("root"), SslMode: new("VERIFY_IDENTITY"), SslCaFilePath: new("/ca.crt")}.Build()))
+}
+
+func TestDecodeMySQLDumpOptions(t *testing.T) {
+	assert.Equal(t, mysql.DumpOptions{}, decodeMySQLDumpOptions(&mysql_v1.MySQLDumpOptions{}))
+	assert.Equal(t, mysql.DumpOptions{
+		Consistency:          mysql.ConsistencyNone,
+		RecordBinlogPosition: true,
+		ExcludeDatabases:     []string{"photos"},
+		ExcludeTables:        []string{"wiki.cache"},
+	}, decodeMySQLDumpOptions(mysql_v1.MySQLDumpOptions_builder{
+		Consistency:          new("none"),
+		RecordBinlogPosition: new(true),
+		ExcludeDatabases:     []string{"photos"},
+		ExcludeTables:        []string{"wiki.cache"},
+	}.Build()))
+	assert.Equal(t, mysql.DumpOptions{IncludeDatabases: []string{"wiki"}},
+		decodeMySQLDumpOptions(mysql_v1.MySQLDumpOptions_builder{IncludeDatabases: []string{"wiki"}}.Build()))
+}
+
+func TestDecodeMySQLRestoreOptions(t *testing.T) {
+	assert.Equal(t, mysql.RestoreOptions{}, decodeMySQLRestoreOptions(&mysql_v1.MySQLRestoreOptions{}))
+	assert.Equal(t, mysql.RestoreOptions{Force: true}, decodeMySQLRestoreOptions(mysql_v1.MySQLRestoreOptions_builder{Force: new(true)}.Build()))
+}
+
+func TestMySQLDump(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		runtimeErr  error
+		shouldError bool
+	}{
+		{
+			desc: "successful dump",
+		},
+		{
+			desc:        "runtime error",
+			runtimeErr:  assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			runtime := mysql.NewMockRuntime(t)
+			server := NewMySQLServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			credentials := mysql_v1.MySQLCredentials_builder{Host: new("db.example.com"), User: new("root")}.Build()
+			opts := mysql_v1.MySQLDumpOptions_builder{IncludeDatabases: []string{"wiki"}}.Build()
+			outputPath := "/tmp/dump.sql"
+			runtime.EXPECT().Dump(contexts.UnwrapHandlerContext(ctx), decodeMySQLCredentials(credentials), outputPath, decodeMySQLDumpOptions(opts)).Return(tc.runtimeErr)
+
+			req := mysql_v1.MySQLDumpRequest_builder{
+				Credentials:    credentials,
+				OutputFilePath: &outputPath,
+				Options:        opts,
+			}.Build()
+
+			result, err := server.Dump(ctx, req)
+			if tc.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
+
+func TestMySQLRestore(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		runtimeErr  error
+		shouldError bool
+	}{
+		{
+			desc: "successful restore",
+		},
+		{
+			desc:        "runtime error",
+			runtimeErr:  assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			runtime := mysql.NewMockRuntime(t)
+			server := NewMySQLServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			credentials := mysql_v1.MySQLCredentials_builder{Host: new("db.example.com"), User: new("root")}.Build()
+			opts := mysql_v1.MySQLRestoreOptions_builder{Force: new(true)}.Build()
+			inputPath := "/tmp/dump.sql"
+			runtime.EXPECT().Restore(contexts.UnwrapHandlerContext(ctx), decodeMySQLCredentials(credentials), inputPath, decodeMySQLRestoreOptions(opts)).Return(tc.runtimeErr)
+
+			req := mysql_v1.MySQLRestoreRequest_builder{
+				Credentials:   credentials,
+				InputFilePath: &inputPath,
+				Options:       opts,
+			}.Build()
+
+			result, err := server.Restore(ctx, req)
+			if tc.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
@@ -42,9 +42,49 @@ func decodePostgresDumpAllOptions(encodedOptions *postgres_v1.DumpAllOptions) po
 		opts.CleanupTimeout = helpers.MaxWaitTime(timeout.AsDuration())
 	}
 
+	opts.Format = postgres.DumpFormat(encodedOptions.GetFormat())
+	opts.Jobs = int(encodedOptions.GetJobs())
+	opts.Selection.IncludeDatabases = encodedOptions.GetIncludeDatabases()
+	opts.Selection.ExcludeDatabases = encodedOptions.GetExcludeDatabases()
+
+	for _, encodedFilter := range encodedOptions.GetDatabases() {
+		opts.Selection.Databases = append(opts.Selection.Databases, decodePostgresDatabaseFilter(encodedFilter))
+	}
+
+	if encodedOptions.HasRoles() {
+		opts.Roles = decodePostgresRoleHandling(encodedOptions.GetRoles())
+	}
+
 	return opts
 }
 
+func decodePostgresRoleHandling(encodedHandling *postgres_v1.RoleHandling) postgres.RoleHandling {
+	handling := postgres.RoleHandling{
+		SkipRoles:      encodedHandling.GetSkipRoles(),
+		StripPasswords: encodedHandling.GetStripPasswords(),
+	}
+
+	if encodedRenames := encodedHandling.GetRenameRoles(); len(encodedRenames) > 0 {
+		handling.RenameRoles = make(map[string]string, len(encodedRenames))
+		for _, encodedRename := range encodedRenames {
+			handling.RenameRoles[encodedRename.GetName()] = encodedRename.GetNewName()
+		}
+	}
+
+	return handling
+}
+
+func decodePostgresDatabaseFilter(encodedFilter *postgres_v1.DatabaseFilter) postgres.DatabaseFilter {
+	return postgres.DatabaseFilter{
+		Name:             encodedFilter.GetName(),
+		IncludeSchemas:   encodedFilter.GetIncludeSchemas(),
+		ExcludeSchemas:   encodedFilter.GetExcludeSchemas(),
+		IncludeTables:    encodedFilter.GetIncludeTables(),
+		ExcludeTables:    encodedFilter.GetExcludeTables(),
+		SchemaOnlyTables: encodedFilter.GetSchemaOnlyTables(),
+	}
+}
+
 func (ps *PostgresServer) DumpAll(ctx context.Context, req *postgres_v1.DumpAllRequest) (*postgres_v1.DumpAllResponse, error) {
 	grpcCtx := contexts.UnwrapHandlerContext(ctx)
 	err := ps.runtime.DumpAll(grpcCtx, decodePostgresCredentials(req.GetCredentials()), req.GetOutputFilePath(), decodePostgresDumpAllOptions(req.GetOptions()))
@@ -55,8 +95,21 @@ func (ps *PostgresServer) DumpAll(ctx context.Context, req *postgres_v1.DumpAllR
 	return &postgres_v1.DumpAllResponse{}, nil
 }
 
-func decodePostgresRestoreOptions(_ *postgres_v1.RestoreOptions) postgres.RestoreOptions {
-	return postgres.RestoreOptions{}
+func decodePostgresRestoreOptions(encodedOptions *postgres_v1.RestoreOptions) postgres.RestoreOptions {
+	return postgres.RestoreOptions{
+		Format: postgres.DumpFormat(encodedOptions.GetFormat()),
+		Jobs:   int(encodedOptions.GetJobs()),
+		Selection: postgres.RestoreSelection{
+			Databases:      encodedOptions.GetDatabases(),
+			Schemas:        encodedOptions.GetSchemas(),
+			Tables:         encodedOptions.GetTables(),
+			TargetDatabase: encodedOptions.GetTargetDatabase(),
+		},
+		StopOnError:       encodedOptions.GetStopOnError(),
+		SingleTransaction: encodedOptions.GetSingleTransaction(),
+		DropAndRecreate:   encodedOptions.GetDropAndRecreate(),
+		Force:             encodedOptions.GetForce(),
+	}
 }
 
 func (ps *PostgresServer) Restore(ctx context.Context, req *postgres_v1.RestoreRequest) (*postgres_v1.RestoreResponse, error) {
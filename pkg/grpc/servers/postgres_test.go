@@ -91,9 +91,60 @@ func TestDecodePostgresDumpAllOptions(t *testing.T) {
 		{
 			name: "All options",
 			input: postgres_v1.DumpAllOptions_builder{
-				CleanupTimeout: durationpb.New(5 * time.Second),
+				CleanupTimeout:   durationpb.New(5 * time.Second),
+				Format:           new("directory"),
+				Jobs:             new(int32(4)),
+				IncludeDatabases: []string{"app", "analytics"},
+				ExcludeDatabases: []string{"analytics"},
+				Databases: []*postgres_v1.DatabaseFilter{
+					postgres_v1.DatabaseFilter_builder{
+						Name:             new("app"),
+						IncludeSchemas:   []string{"public"},
+						ExcludeSchemas:   []string{"reporting"},
+						IncludeTables:    []string{"public.*"},
+						ExcludeTables:    []string{"public.audit_*"},
+						SchemaOnlyTables: []string{"public.sessions"},
+					}.Build(),
+				},
+			}.Build(),
+			want: postgres.DumpAllOptions{
+				CleanupTimeout: helpers.MaxWaitTime(5 * time.Second),
+				Format:         postgres.DumpFormatDirectory,
+				Jobs:           4,
+				Selection: postgres.DatabaseSelection{
+					IncludeDatabases: []string{"app", "analytics"},
+					ExcludeDatabases: []string{"analytics"},
+					Databases: []postgres.DatabaseFilter{
+						{
+							Name:             "app",
+							IncludeSchemas:   []string{"public"},
+							ExcludeSchemas:   []string{"reporting"},
+							IncludeTables:    []string{"public.*"},
+							ExcludeTables:    []string{"public.audit_*"},
+							SchemaOnlyTables: []string{"public.sessions"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "role handling",
+			input: postgres_v1.DumpAllOptions_builder{
+				Roles: postgres_v1.RoleHandling_builder{
+					SkipRoles: []string{"app"},
+					RenameRoles: []*postgres_v1.RoleHandling_RoleRename{
+						postgres_v1.RoleHandling_RoleRename_builder{Name: new("reporting"), NewName: new("reporting_ro")}.Build(),
+					},
+					StripPasswords: new(true),
+				}.Build(),
 			}.Build(),
-			want: postgres.DumpAllOptions{CleanupTimeout: helpers.MaxWaitTime(5 * time.Second)},
+			want: postgres.DumpAllOptions{
+				Roles: postgres.RoleHandling{
+					SkipRoles:      []string{"app"},
+					RenameRoles:    map[string]string{"reporting": "reporting_ro"},
+					StripPasswords: true,
+				},
+			},
 		},
 	}
 
@@ -176,6 +227,25 @@ func TestDumpAll(t *testing.T) {
 
 func TestDecodePostgresRestoreOptions(t *testing.T) {
 	assert.Equal(t, postgres.RestoreOptions{}, decodePostgresRestoreOptions(&postgres_v1.RestoreOptions{}))
+	assert.Equal(t, postgres.RestoreOptions{Format: postgres.DumpFormatDirectory, Jobs: 4},
+		decodePostgresRestoreOptions(postgres_v1.RestoreOptions_builder{Format: new("directory"), Jobs: new(int32(4))}.Build()))
+
+	selection := postgres.RestoreSelection{Databases: []string{"app"}, Schemas: []string{"audit"}, Tables: []string{"public.users"}, TargetDatabase: "app_recovered"}
+	assert.Equal(t, postgres.RestoreOptions{Selection: selection},
+		decodePostgresRestoreOptions(postgres_v1.RestoreOptions_builder{
+			Databases:      []string{"app"},
+			Schemas:        []string{"audit"},
+			Tables:         []string{"public.users"},
+			TargetDatabase: new("app_recovered"),
+		}.Build()))
+
+	assert.Equal(t, postgres.RestoreOptions{StopOnError: true, SingleTransaction: true, DropAndRecreate: true, Force: true},
+		decodePostgresRestoreOptions(postgres_v1.RestoreOptions_builder{
+			StopOnError:       new(true),
+			SingleTransaction: new(true),
+			DropAndRecreate:   new(true),
+			Force:             new(true),
+		}.Build()))
 }
 
 func TestRestore(t *testing.T) {
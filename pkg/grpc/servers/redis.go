@@ -0,0 +1,40 @@
+package servers
+
+import (
+	"context"
+
+	"github.com/gravitational/trace/trail"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	redis_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/redis/v1"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+)
+
+type RedisServer struct {
+	redis_v1.UnimplementedRedisServer
+	runtime redis.Runtime
+}
+
+func NewRedisServer() *RedisServer {
+	return &RedisServer{
+		runtime: redis.NewLocalRuntime(),
+	}
+}
+
+func decodeRedisCredentials(encodedCredentials *redis_v1.RedisCredentials) redis.Credentials {
+	return redis.Credentials{
+		Host:     encodedCredentials.GetHost(),
+		Port:     int(encodedCredentials.GetPort()),
+		User:     encodedCredentials.GetUser(),
+		Password: encodedCredentials.GetPassword(),
+	}
+}
+
+func (rs *RedisServer) Snapshot(ctx context.Context, req *redis_v1.RedisSnapshotRequest) (*redis_v1.RedisSnapshotResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+	err := rs.runtime.Snapshot(grpcCtx, decodeRedisCredentials(req.GetCredentials()), req.GetOutputFilePath())
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return &redis_v1.RedisSnapshotResponse{}, nil
+}
@@ -0,0 +1,68 @@
+package servers
+
+import (
+	"testing"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	redis_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/redis/v1"
+	"github.com/solidDoWant/backup-tool/pkg/redis"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRedisServer(t *testing.T) {
+	server := NewRedisServer()
+
+	assert.NotNil(t, server)
+	assert.NotNil(t, server.runtime)
+}
+
+func TestDecodeRedisCredentials(t *testing.T) {
+	assert.Equal(t, redis.Credentials{}, decodeRedisCredentials(&redis_v1.RedisCredentials{}))
+	assert.Equal(t, redis.Credentials{Host: "valkey.example.com", Port: 6380, User: "backup", Password: "password"},
+		decodeRedisCredentials(redis_v1.RedisCredentials_builder{Host: new("valkey.example.com"), Port: new(int32(6380)), User: new("backup"), Password: new("password")}.Build()))
+}
+
+func TestRedisSnapshot(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		runtimeErr  error
+		shouldError bool
+	}{
+		{
+			desc: "successful snapshot",
+		},
+		{
+			desc:        "runtime error",
+			runtimeErr:  assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			runtime := redis.NewMockRuntime(t)
+			server := NewRedisServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			credentials := redis_v1.RedisCredentials_builder{Host: new("valkey.example.com")}.Build()
+			outputPath := "/tmp/cache.rdb"
+			runtime.EXPECT().Snapshot(contexts.UnwrapHandlerContext(ctx), decodeRedisCredentials(credentials), outputPath).Return(tc.runtimeErr)
+
+			req := redis_v1.RedisSnapshotRequest_builder{
+				Credentials:    credentials,
+				OutputFilePath: &outputPath,
+			}.Build()
+
+			result, err := server.Snapshot(ctx, req)
+			if tc.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package servers
+
+import (
+	"context"
+
+	"github.com/gravitational/trace/trail"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	repository_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/repository/v1"
+	s3_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type RepositoryServer struct {
+	repository_v1.UnimplementedRepositoryServer
+	runtime repository.Runtime
+}
+
+func NewRepositoryServer() *RepositoryServer {
+	return &RepositoryServer{
+		runtime: repository.NewLocalRuntime(),
+	}
+}
+
+// decodeRepositoryCredentials decodes the credentials as the S3 server does, returning nil when the request has
+// no credentials, as is the case for local repositories.
+func decodeRepositoryCredentials(encodedCredentials *s3_v1.Credentials) s3.CredentialsInterface {
+	if encodedCredentials == nil {
+		return nil
+	}
+
+	return decodeS3Credentials(encodedCredentials)
+}
+
+func snapshotToProto(snapshot repository.Snapshot) *repository_v1.Snapshot {
+	retentionMode := string(snapshot.Retention.Mode)
+	builder := repository_v1.Snapshot_builder{
+		Id:            &snapshot.ID,
+		Name:          &snapshot.Name,
+		Tags:          snapshot.Tags,
+		Time:          timestamppb.New(snapshot.Time),
+		Parent:        &snapshot.Parent,
+		FileCount:     &snapshot.FileCount,
+		Size:          &snapshot.Size,
+		AddedBytes:    &snapshot.AddedBytes,
+		RetentionMode: &retentionMode,
+		LegalHold:     &snapshot.Retention.LegalHold,
+	}
+	if !snapshot.Retention.RetainUntil.IsZero() {
+		builder.RetainUntil = timestamppb.New(snapshot.Retention.RetainUntil)
+	}
+
+	return builder.Build()
+}
+
+func (rs *RepositoryServer) Backup(ctx context.Context, req *repository_v1.BackupRequest) (*repository_v1.BackupResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+
+	opts := repository.BackupOptions{
+		Name: req.GetName(),
+		Tags: req.GetTags(),
+		Full: req.GetFull(),
+		Limits: throttle.Limits{
+			BytesPerSecond: req.GetBytesPerSecond(),
+			FilesPerSecond: req.GetFilesPerSecond(),
+		},
+		ObjectLock: s3.ObjectLock{
+			Mode:      s3.ObjectLockMode(req.GetObjectLockMode()),
+			Retention: req.GetObjectLockRetention().AsDuration(),
+			LegalHold: req.GetObjectLockLegalHold(),
+		},
+	}
+
+	snapshot, err := rs.runtime.Backup(grpcCtx, decodeRepositoryCredentials(req.GetCredentials()), req.GetRepository(), req.GetSource(), opts)
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return repository_v1.BackupResponse_builder{Snapshot: snapshotToProto(snapshot)}.Build(), nil
+}
+
+func (rs *RepositoryServer) RestoreSnapshot(ctx context.Context, req *repository_v1.RestoreSnapshotRequest) (*repository_v1.RestoreSnapshotResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+
+	opts := repository.RestoreOptions{
+		Limits: throttle.Limits{
+			BytesPerSecond: req.GetBytesPerSecond(),
+			FilesPerSecond: req.GetFilesPerSecond(),
+		},
+	}
+
+	err := rs.runtime.Restore(grpcCtx, decodeRepositoryCredentials(req.GetCredentials()), req.GetRepository(), req.GetSnapshotId(), req.GetDest(), opts)
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return &repository_v1.RestoreSnapshotResponse{}, nil
+}
+
+func (rs *RepositoryServer) ListSnapshots(ctx context.Context, req *repository_v1.ListSnapshotsRequest) (*repository_v1.ListSnapshotsResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+
+	snapshots, err := rs.runtime.ListSnapshots(grpcCtx, decodeRepositoryCredentials(req.GetCredentials()), req.GetRepository())
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	protoSnapshots := make([]*repository_v1.Snapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		protoSnapshots[i] = snapshotToProto(snapshot)
+	}
+
+	return repository_v1.ListSnapshotsResponse_builder{Snapshots: protoSnapshots}.Build(), nil
+}
+
+func (rs *RepositoryServer) Prune(ctx context.Context, req *repository_v1.PruneRequest) (*repository_v1.PruneResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+
+	opts := repository.PruneOptions{KeepLast: int(req.GetKeepLast())}
+
+	result, err := rs.runtime.Prune(grpcCtx, decodeRepositoryCredentials(req.GetCredentials()), req.GetRepository(), opts)
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	return repository_v1.PruneResponse_builder{
+		RemovedSnapshots: result.RemovedSnapshots,
+		DeletedPacks:     new(int64(result.DeletedPacks)),
+		WrittenPacks:     new(int64(result.WrittenPacks)),
+	}.Build(), nil
+}
@@ -0,0 +1,322 @@
+package servers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	repository_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/repository/v1"
+	s3_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1"
+	"github.com/solidDoWant/backup-tool/pkg/repository"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewRepositoryServer(t *testing.T) {
+	server := NewRepositoryServer()
+
+	assert.NotNil(t, server)
+	assert.NotNil(t, server.runtime)
+}
+
+func TestDecodeRepositoryCredentials(t *testing.T) {
+	t.Run("no credentials", func(t *testing.T) {
+		assert.Nil(t, decodeRepositoryCredentials(nil))
+	})
+
+	t.Run("credentials", func(t *testing.T) {
+		credentials := s3_v1.Credentials_builder{
+			AccessKeyId:      new("accessKeyID"),
+			SecretAccessKey:  new("secretAccessKey"),
+			SessionToken:     new("sessionToken"),
+			Region:           new("region"),
+			Endpoint:         new("endpoint"),
+			S3ForcePathStyle: new(true),
+			AssumeRole: s3_v1.AssumeRole_builder{
+				RoleArn:          new("arn:aws:iam::123456789012:role/backup"),
+				ExternalId:       new("externalID"),
+				SessionName:      new("sessionName"),
+				Duration:         durationpb.New(time.Hour),
+				WebIdentityToken: new("webIdentityToken"),
+				StsEndpoint:      new("https://sts.example"),
+			}.Build(),
+		}.Build()
+
+		decodedCredentials := decodeRepositoryCredentials(credentials)
+		require.NotNil(t, decodedCredentials)
+		assert.Equal(t, "accessKeyID", decodedCredentials.GetAccessKeyID())
+		assert.Equal(t, "secretAccessKey", decodedCredentials.GetSecretAccessKey())
+		assert.Equal(t, "sessionToken", decodedCredentials.GetSessionToken())
+		assert.Equal(t, "region", decodedCredentials.GetRegion())
+		assert.Equal(t, "endpoint", decodedCredentials.GetEndpoint())
+		assert.True(t, decodedCredentials.GetS3ForcePathStyle())
+		assert.Equal(t, &s3.AssumeRole{
+			RoleARN:          "arn:aws:iam::123456789012:role/backup",
+			ExternalID:       "externalID",
+			SessionName:      "sessionName",
+			Duration:         time.Hour,
+			WebIdentityToken: "webIdentityToken",
+			STSEndpoint:      "https://sts.example",
+		}, decodedCredentials.GetAssumeRole())
+	})
+}
+
+func TestRepositoryBackup(t *testing.T) {
+	snapshot := repository.Snapshot{
+		ID:         "snapshotID",
+		Name:       "app",
+		Tags:       []string{"app-event"},
+		Time:       time.Date(2026, time.June, 2, 12, 0, 0, 0, time.UTC),
+		Parent:     "parentID",
+		FileCount:  3,
+		Size:       1024,
+		AddedBytes: 512,
+		Retention: s3.ObjectRetention{
+			Mode:        s3.ObjectLockModeGovernance,
+			RetainUntil: time.Date(2026, time.June, 3, 12, 0, 0, 0, time.UTC),
+			LegalHold:   true,
+		},
+	}
+
+	tests := []struct {
+		desc        string
+		returnErr   error
+		shouldError bool
+	}{
+		{
+			desc: "successful",
+		},
+		{
+			desc:        "failure",
+			returnErr:   assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runtime := repository.NewMockRuntime(t)
+			server := NewRepositoryServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			credentials := s3_v1.Credentials_builder{
+				AccessKeyId:     new("accessKeyID"),
+				SecretAccessKey: new("secretAccessKey"),
+			}.Build()
+
+			expectedOpts := repository.BackupOptions{
+				Name:       "app",
+				Tags:       []string{"app-event"},
+				Full:       true,
+				Limits:     throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10},
+				ObjectLock: s3.ObjectLock{Mode: s3.ObjectLockModeGovernance, Retention: 24 * time.Hour, LegalHold: true},
+			}
+			runtime.EXPECT().Backup(contexts.UnwrapHandlerContext(ctx), decodeRepositoryCredentials(credentials), "s3://bucket/repository", "/src", expectedOpts).
+				Return(snapshot, tt.returnErr)
+
+			resp, err := server.Backup(ctx, repository_v1.BackupRequest_builder{
+				Credentials:         credentials,
+				Repository:          new("s3://bucket/repository"),
+				Source:              new("/src"),
+				Name:                new("app"),
+				Tags:                []string{"app-event"},
+				Full:                new(true),
+				BytesPerSecond:      new(int64(1024)),
+				FilesPerSecond:      new(float64(10)),
+				ObjectLockMode:      new("GOVERNANCE"),
+				ObjectLockRetention: durationpb.New(24 * time.Hour),
+				ObjectLockLegalHold: new(true),
+			}.Build())
+			if tt.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(repository_v1.Snapshot_builder{
+				Id:            new("snapshotID"),
+				Name:          new("app"),
+				Tags:          []string{"app-event"},
+				Time:          timestamppb.New(snapshot.Time),
+				Parent:        new("parentID"),
+				FileCount:     new(int64(3)),
+				Size:          new(int64(1024)),
+				AddedBytes:    new(int64(512)),
+				RetentionMode: new("GOVERNANCE"),
+				RetainUntil:   timestamppb.New(snapshot.Retention.RetainUntil),
+				LegalHold:     new(true),
+			}.Build(), resp.GetSnapshot()))
+		})
+	}
+}
+
+func TestRepositoryRestoreSnapshot(t *testing.T) {
+	tests := []struct {
+		desc        string
+		returnErr   error
+		shouldError bool
+	}{
+		{
+			desc: "successful",
+		},
+		{
+			desc:        "failure",
+			returnErr:   assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runtime := repository.NewMockRuntime(t)
+			server := NewRepositoryServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			expectedOpts := repository.RestoreOptions{Limits: throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10}}
+			runtime.EXPECT().Restore(contexts.UnwrapHandlerContext(ctx), nil, "/repository", "snapshotID", "/dest", expectedOpts).Return(tt.returnErr)
+
+			resp, err := server.RestoreSnapshot(ctx, repository_v1.RestoreSnapshotRequest_builder{
+				Repository:     new("/repository"),
+				SnapshotId:     new("snapshotID"),
+				Dest:           new("/dest"),
+				BytesPerSecond: new(int64(1024)),
+				FilesPerSecond: new(float64(10)),
+			}.Build())
+			if tt.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+			}
+		})
+	}
+}
+
+func TestRepositoryListSnapshots(t *testing.T) {
+	snapshotTime := time.Date(2026, time.June, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc         string
+		snapshots    []repository.Snapshot
+		returnErr    error
+		shouldError  bool
+		expectedResp *repository_v1.ListSnapshotsResponse
+	}{
+		{
+			desc: "successful",
+			snapshots: []repository.Snapshot{
+				{ID: "first", Name: "app", Time: snapshotTime},
+				{ID: "second", Name: "app", Time: snapshotTime.Add(time.Hour), Parent: "first"},
+			},
+			expectedResp: repository_v1.ListSnapshotsResponse_builder{
+				Snapshots: []*repository_v1.Snapshot{
+					repository_v1.Snapshot_builder{
+						Id:            new("first"),
+						Name:          new("app"),
+						Time:          timestamppb.New(snapshotTime),
+						Parent:        new(""),
+						FileCount:     new(int64(0)),
+						Size:          new(int64(0)),
+						AddedBytes:    new(int64(0)),
+						RetentionMode: new(""),
+						LegalHold:     new(false),
+					}.Build(),
+					repository_v1.Snapshot_builder{
+						Id:            new("second"),
+						Name:          new("app"),
+						Time:          timestamppb.New(snapshotTime.Add(time.Hour)),
+						Parent:        new("first"),
+						FileCount:     new(int64(0)),
+						Size:          new(int64(0)),
+						AddedBytes:    new(int64(0)),
+						RetentionMode: new(""),
+						LegalHold:     new(false),
+					}.Build(),
+				},
+			}.Build(),
+		},
+		{
+			desc:        "failure",
+			returnErr:   assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runtime := repository.NewMockRuntime(t)
+			server := NewRepositoryServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			runtime.EXPECT().ListSnapshots(contexts.UnwrapHandlerContext(ctx), nil, "/repository").Return(tt.snapshots, tt.returnErr)
+
+			resp, err := server.ListSnapshots(ctx, repository_v1.ListSnapshotsRequest_builder{
+				Repository: new("/repository"),
+			}.Build())
+			if tt.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, proto.Equal(tt.expectedResp, resp))
+			}
+		})
+	}
+}
+
+func TestRepositoryPrune(t *testing.T) {
+	tests := []struct {
+		desc        string
+		returnErr   error
+		shouldError bool
+	}{
+		{
+			desc: "successful",
+		},
+		{
+			desc:        "failure",
+			returnErr:   assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runtime := repository.NewMockRuntime(t)
+			server := NewRepositoryServer()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			result := repository.PruneResult{RemovedSnapshots: []string{"first"}, DeletedPacks: 2, WrittenPacks: 1}
+			runtime.EXPECT().Prune(contexts.UnwrapHandlerContext(ctx), nil, "/repository", repository.PruneOptions{KeepLast: 3}).Return(result, tt.returnErr)
+
+			resp, err := server.Prune(ctx, repository_v1.PruneRequest_builder{
+				Repository: new("/repository"),
+				KeepLast:   new(int64(3)),
+			}.Build())
+			if tt.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(repository_v1.PruneResponse_builder{
+				RemovedSnapshots: []string{"first"},
+				DeletedPacks:     new(int64(2)),
+				WrittenPacks:     new(int64(1)),
+			}.Build(), resp))
+		})
+	}
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/gravitational/trace/trail"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	s3_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 )
 
 type S3Server struct {
@@ -22,11 +24,38 @@ func NewS3Server() *S3Server {
 }
 
 func decodeS3Credentials(encodedCredentials *s3_v1.Credentials) *s3.Credentials {
-	return s3.NewCredentials(encodedCredentials.GetAccessKeyId(), encodedCredentials.GetSecretAccessKey()).
+	credentials := s3.NewCredentials(encodedCredentials.GetAccessKeyId(), encodedCredentials.GetSecretAccessKey()).
 		WithSessionToken(encodedCredentials.GetSessionToken()).
 		WithRegion(encodedCredentials.GetRegion()).
 		WithEndpoint(encodedCredentials.GetEndpoint()).
 		WithS3ForcePathStyle(encodedCredentials.GetS3ForcePathStyle())
+
+	if encodedCredentials.HasAssumeRole() {
+		encodedAssumeRole := encodedCredentials.GetAssumeRole()
+		credentials.WithAssumeRole(&s3.AssumeRole{
+			RoleARN:          encodedAssumeRole.GetRoleArn(),
+			ExternalID:       encodedAssumeRole.GetExternalId(),
+			SessionName:      encodedAssumeRole.GetSessionName(),
+			Duration:         encodedAssumeRole.GetDuration().AsDuration(),
+			WebIdentityToken: encodedAssumeRole.GetWebIdentityToken(),
+			STSEndpoint:      encodedAssumeRole.GetStsEndpoint(),
+		})
+	}
+
+	return credentials
+}
+
+// keyPatternsFromProto maps wire filter patterns back onto the local representation.
+func keyPatternsFromProto(protoPatterns []*s3_v1.KeyPattern) []files.FilePattern {
+	if len(protoPatterns) == 0 {
+		return nil
+	}
+
+	patterns := make([]files.FilePattern, len(protoPatterns))
+	for i, protoPattern := range protoPatterns {
+		patterns[i] = files.FilePattern{Glob: protoPattern.GetGlob()}
+	}
+	return patterns
 }
 
 func (s3s *S3Server) Sync(ctx context.Context, req *s3_v1.SyncRequest) (*s3_v1.SyncResponse, error) {
@@ -38,10 +67,74 @@ func (s3s *S3Server) Sync(ctx context.Context, req *s3_v1.SyncRequest) (*s3_v1.S
 		asOf = ts.AsTime()
 	}
 
-	err := s3s.runtime.Sync(grpcCtx, decodeS3Credentials(req.GetCredentials()), req.GetSource(), req.GetDest(), asOf)
+	opts := s3.SyncOptions{
+		Limits: throttle.Limits{
+			BytesPerSecond: req.GetBytesPerSecond(),
+			FilesPerSecond: req.GetFilesPerSecond(),
+		},
+		Multipart: s3.MultipartOptions{
+			Threshold: req.GetMultipartThreshold(),
+			PartSize:  req.GetPartSize(),
+		},
+		ManifestPath: req.GetManifestPath(),
+		Rehash:       req.GetRehash(),
+		Filter: files.FileFilter{
+			Include: keyPatternsFromProto(req.GetInclude()),
+			Exclude: keyPatternsFromProto(req.GetExclude()),
+		},
+		ObjectLock: s3.ObjectLock{
+			Mode:      s3.ObjectLockMode(req.GetObjectLockMode()),
+			Retention: req.GetObjectLockRetention().AsDuration(),
+			LegalHold: req.GetObjectLockLegalHold(),
+		},
+	}
+
+	// Unset destination credentials mean "use credentials for both buckets", which the runtime reads from a
+	// nil DestCredentials.
+	if req.HasDestCredentials() {
+		opts.DestCredentials = decodeS3Credentials(req.GetDestCredentials())
+	}
+
+	err := s3s.runtime.Sync(grpcCtx, decodeS3Credentials(req.GetCredentials()), req.GetSource(), req.GetDest(), asOf, opts)
 	if err != nil {
 		return nil, trail.Send(grpcCtx, err)
 	}
 
 	return &s3_v1.SyncResponse{}, nil
 }
+
+func rewindActionToProto(action s3.RewindAction) s3_v1.RewindAction {
+	switch action {
+	case s3.RewindActionRestore:
+		return s3_v1.RewindAction_REWIND_ACTION_RESTORE
+	case s3.RewindActionDelete:
+		return s3_v1.RewindAction_REWIND_ACTION_DELETE
+	default:
+		return s3_v1.RewindAction_REWIND_ACTION_UNSPECIFIED
+	}
+}
+
+func (s3s *S3Server) Rewind(ctx context.Context, req *s3_v1.RewindRequest) (*s3_v1.RewindResponse, error) {
+	grpcCtx := contexts.UnwrapHandlerContext(ctx)
+
+	opts := s3.RewindOptions{
+		DryRun: req.GetDryRun(),
+		Limits: throttle.Limits{FilesPerSecond: req.GetFilesPerSecond()},
+	}
+
+	changes, err := s3s.runtime.Rewind(grpcCtx, decodeS3Credentials(req.GetCredentials()), req.GetPath(), req.GetManifestPath(), opts)
+	if err != nil {
+		return nil, trail.Send(grpcCtx, err)
+	}
+
+	protoChanges := make([]*s3_v1.RewindChange, len(changes))
+	for i, change := range changes {
+		protoChanges[i] = s3_v1.RewindChange_builder{
+			Key:       &change.Key,
+			Action:    new(rewindActionToProto(change.Action)),
+			VersionId: &change.VersionID,
+		}.Build()
+	}
+
+	return s3_v1.RewindResponse_builder{Changes: protoChanges}.Build(), nil
+}
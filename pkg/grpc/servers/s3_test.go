@@ -5,11 +5,15 @@ import (
 	"time"
 
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	s3_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1"
 	"github.com/solidDoWant/backup-tool/pkg/s3"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -38,17 +42,43 @@ func TestDecodeS3Credentials(t *testing.T) {
 	assert.Equal(t, credentials.GetRegion(), decodedCredentials.Region)
 	assert.Equal(t, credentials.GetEndpoint(), decodedCredentials.Endpoint)
 	assert.Equal(t, credentials.GetS3ForcePathStyle(), decodedCredentials.S3ForcePathStyle)
+	assert.Nil(t, decodedCredentials.AssumeRole)
+}
+
+func TestDecodeS3CredentialsAssumeRole(t *testing.T) {
+	credentials := s3_v1.Credentials_builder{
+		AssumeRole: s3_v1.AssumeRole_builder{
+			RoleArn:          new("arn:aws:iam::123456789012:role/backup"),
+			ExternalId:       new("externalID"),
+			SessionName:      new("sessionName"),
+			Duration:         durationpb.New(time.Hour),
+			WebIdentityToken: new("webIdentityToken"),
+			StsEndpoint:      new("https://sts.example"),
+		}.Build(),
+	}.Build()
+
+	decodedCredentials := decodeS3Credentials(credentials)
+	require.NotNil(t, decodedCredentials)
+	assert.Equal(t, &s3.AssumeRole{
+		RoleARN:          "arn:aws:iam::123456789012:role/backup",
+		ExternalID:       "externalID",
+		SessionName:      "sessionName",
+		Duration:         time.Hour,
+		WebIdentityToken: "webIdentityToken",
+		STSEndpoint:      "https://sts.example",
+	}, decodedCredentials.AssumeRole)
 }
 
 func TestS3Sync(t *testing.T) {
 	asOf := time.Date(2026, time.June, 2, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
-		desc         string
-		asOf         *timestamppb.Timestamp // as_of carried on the request
-		expectedAsOf time.Time              // what the handler should decode and pass to the runtime
-		returnValue  error
-		shouldError  bool
+		desc            string
+		asOf            *timestamppb.Timestamp // as_of carried on the request
+		expectedAsOf    time.Time              // what the handler should decode and pass to the runtime
+		destCredentials *s3_v1.Credentials     // dest_credentials carried on the request
+		returnValue     error
+		shouldError     bool
 	}{
 		{
 			desc: "successful without a consistency point",
@@ -58,6 +88,14 @@ func TestS3Sync(t *testing.T) {
 			asOf:         timestamppb.New(asOf),
 			expectedAsOf: asOf,
 		},
+		{
+			desc: "decodes the destination credentials when set",
+			destCredentials: s3_v1.Credentials_builder{
+				AccessKeyId:     new("destAccessKeyID"),
+				SecretAccessKey: new("destSecretAccessKey"),
+				Endpoint:        new("https://dest.example"),
+			}.Build(),
+		},
 		{
 			desc:        "failure",
 			returnValue: assert.AnError,
@@ -79,13 +117,40 @@ func TestS3Sync(t *testing.T) {
 				SecretAccessKey: new("secretAccessKey"),
 			}.Build()
 
-			runtime.EXPECT().Sync(contexts.UnwrapHandlerContext(ctx), decodeS3Credentials(credentials), src, dest, tt.expectedAsOf).Return(tt.returnValue)
+			expectedOpts := s3.SyncOptions{
+				Limits:       throttle.Limits{BytesPerSecond: 1024, FilesPerSecond: 10},
+				Multipart:    s3.MultipartOptions{Threshold: 128 << 20, PartSize: 32 << 20},
+				ManifestPath: "manifest.json",
+				Rehash:       true,
+				Filter: files.FileFilter{
+					Include: []files.FilePattern{{Glob: "media/**"}},
+					Exclude: []files.FilePattern{{Glob: "media/thumbnails"}},
+				},
+				ObjectLock: s3.ObjectLock{Mode: s3.ObjectLockModeCompliance, Retention: 24 * time.Hour, LegalHold: true},
+			}
+			if tt.destCredentials != nil {
+				expectedOpts.DestCredentials = decodeS3Credentials(tt.destCredentials)
+			}
+
+			runtime.EXPECT().Sync(contexts.UnwrapHandlerContext(ctx), decodeS3Credentials(credentials), src, dest, tt.expectedAsOf, expectedOpts).Return(tt.returnValue)
 
 			resp, err := server.Sync(ctx, s3_v1.SyncRequest_builder{
-				Credentials: credentials,
-				Source:      &src,
-				Dest:        &dest,
-				AsOf:        tt.asOf,
+				Credentials:         credentials,
+				Source:              &src,
+				Dest:                &dest,
+				AsOf:                tt.asOf,
+				BytesPerSecond:      new(int64(1024)),
+				FilesPerSecond:      new(float64(10)),
+				DestCredentials:     tt.destCredentials,
+				ManifestPath:        new("manifest.json"),
+				MultipartThreshold:  new(int64(128 << 20)),
+				PartSize:            new(int64(32 << 20)),
+				Rehash:              new(true),
+				Include:             []*s3_v1.KeyPattern{s3_v1.KeyPattern_builder{Glob: new("media/**")}.Build()},
+				Exclude:             []*s3_v1.KeyPattern{s3_v1.KeyPattern_builder{Glob: new("media/thumbnails")}.Build()},
+				ObjectLockMode:      new("COMPLIANCE"),
+				ObjectLockRetention: durationpb.New(24 * time.Hour),
+				ObjectLockLegalHold: new(true),
 			}.Build())
 			if tt.shouldError {
 				assert.Error(t, err)
@@ -97,3 +162,75 @@ func TestS3Sync(t *testing.T) {
 		})
 	}
 }
+
+func TestS3Rewind(t *testing.T) {
+	tests := []struct {
+		desc         string
+		changes      []s3.RewindChange
+		returnErr    error
+		shouldError  bool
+		expectedResp *s3_v1.RewindResponse
+	}{
+		{
+			desc: "successful",
+			changes: []s3.RewindChange{
+				{Key: "prefix/a.txt", Action: s3.RewindActionRestore, VersionID: "v1"},
+				{Key: "prefix/b.txt", Action: s3.RewindActionDelete},
+			},
+			expectedResp: s3_v1.RewindResponse_builder{
+				Changes: []*s3_v1.RewindChange{
+					s3_v1.RewindChange_builder{
+						Key:       new("prefix/a.txt"),
+						Action:    new(s3_v1.RewindAction_REWIND_ACTION_RESTORE),
+						VersionId: new("v1"),
+					}.Build(),
+					s3_v1.RewindChange_builder{
+						Key:       new("prefix/b.txt"),
+						Action:    new(s3_v1.RewindAction_REWIND_ACTION_DELETE),
+						VersionId: new(""),
+					}.Build(),
+				},
+			}.Build(),
+		},
+		{
+			desc:        "failure",
+			returnErr:   assert.AnError,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runtime := s3.NewMockRuntime(t)
+			server := NewS3Server()
+			server.runtime = runtime
+
+			ctx := th.NewTestContext()
+			path := "s3://bucket/prefix"
+			manifestPath := "manifest.json"
+			credentials := s3_v1.Credentials_builder{
+				AccessKeyId:     new("accessKeyID"),
+				SecretAccessKey: new("secretAccessKey"),
+			}.Build()
+
+			expectedOpts := s3.RewindOptions{DryRun: true, Limits: throttle.Limits{FilesPerSecond: 10}}
+			runtime.EXPECT().Rewind(contexts.UnwrapHandlerContext(ctx), decodeS3Credentials(credentials), path, manifestPath, expectedOpts).
+				Return(tt.changes, tt.returnErr)
+
+			resp, err := server.Rewind(ctx, s3_v1.RewindRequest_builder{
+				Credentials:    credentials,
+				Path:           &path,
+				ManifestPath:   &manifestPath,
+				DryRun:         new(true),
+				FilesPerSecond: new(float64(10)),
+			}.Build())
+			if tt.shouldError {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, proto.Equal(tt.expectedResp, resp))
+			}
+		})
+	}
+}
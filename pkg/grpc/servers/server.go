@@ -9,7 +9,10 @@ import (
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
 	"github.com/solidDoWant/backup-tool/pkg/grpc"
 	files_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/files/v1"
+	mysql_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/mysql/v1"
 	postgres_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/postgres/v1"
+	redis_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/redis/v1"
+	repository_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/repository/v1"
 	s3_v1 "github.com/solidDoWant/backup-tool/pkg/grpc/gen/proto/backup-tool/s3/v1"
 	gogrpc "google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -36,7 +39,10 @@ var registerServers = func(registrar gogrpc.ServiceRegistrar) {
 	healthcheckService := health.NewServer()
 
 	files_v1.RegisterFilesServer(registrar, NewFilesServer())
+	mysql_v1.RegisterMySQLServer(registrar, NewMySQLServer())
 	postgres_v1.RegisterPostgresServer(registrar, NewPostgresServer())
+	redis_v1.RegisterRedisServer(registrar, NewRedisServer())
+	repository_v1.RegisterRepositoryServer(registrar, NewRepositoryServer())
 	s3_v1.RegisterS3Server(registrar, NewS3Server())
 	grpchealth_v1.RegisterHealthServer(registrar, healthcheckService)
 
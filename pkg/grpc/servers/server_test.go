@@ -75,7 +75,10 @@ func TestRegisterServers(t *testing.T) {
 
 	// Verify all services are registered
 	assert.Contains(t, serviceInfo, "Files")
+	assert.Contains(t, serviceInfo, "MySQL")
 	assert.Contains(t, serviceInfo, "Postgres")
+	assert.Contains(t, serviceInfo, "Redis")
+	assert.Contains(t, serviceInfo, "Repository")
 	assert.Contains(t, serviceInfo, "S3")
 	assert.Contains(t, serviceInfo, "grpc.health.v1.Health")
 }
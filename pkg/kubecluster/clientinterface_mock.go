@@ -196,9 +196,9 @@ func (_c *MockClientInterface_CNPG_Call) RunAndReturn(run func() cnpg.ClientInte
 	return _c
 }
 
-// CloneClusterFromBackup provides a mock function with given fields: ctx, namespace, existingClusterName, newClusterName, backup, opts
-func (_m *MockClientInterface) CloneClusterFromBackup(ctx *contexts.Context, namespace string, existingClusterName string, newClusterName string, backup *v1.Backup, opts clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error) {
-	ret := _m.Called(ctx, namespace, existingClusterName, newClusterName, backup, opts)
+// CloneClusterFromBackup provides a mock function with given fields: ctx, namespace, source, newClusterName, backup, opts
+func (_m *MockClientInterface) CloneClusterFromBackup(ctx *contexts.Context, namespace string, source clonedcluster.SourceCluster, newClusterName string, backup *v1.Backup, opts clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error) {
+	ret := _m.Called(ctx, namespace, source, newClusterName, backup, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CloneClusterFromBackup")
@@ -206,19 +206,19 @@ func (_m *MockClientInterface) CloneClusterFromBackup(ctx *contexts.Context, nam
 
 	var r0 clonedcluster.ClonedClusterInterface
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, string, *v1.Backup, clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error)); ok {
-		return rf(ctx, namespace, existingClusterName, newClusterName, backup, opts)
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, clonedcluster.SourceCluster, string, *v1.Backup, clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error)); ok {
+		return rf(ctx, namespace, source, newClusterName, backup, opts)
 	}
-	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, string, *v1.Backup, clonedcluster.CloneClusterOptions) clonedcluster.ClonedClusterInterface); ok {
-		r0 = rf(ctx, namespace, existingClusterName, newClusterName, backup, opts)
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, clonedcluster.SourceCluster, string, *v1.Backup, clonedcluster.CloneClusterOptions) clonedcluster.ClonedClusterInterface); ok {
+		r0 = rf(ctx, namespace, source, newClusterName, backup, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(clonedcluster.ClonedClusterInterface)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*contexts.Context, string, string, string, *v1.Backup, clonedcluster.CloneClusterOptions) error); ok {
-		r1 = rf(ctx, namespace, existingClusterName, newClusterName, backup, opts)
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string, clonedcluster.SourceCluster, string, *v1.Backup, clonedcluster.CloneClusterOptions) error); ok {
+		r1 = rf(ctx, namespace, source, newClusterName, backup, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -234,17 +234,17 @@ type MockClientInterface_CloneClusterFromBackup_Call struct {
 // CloneClusterFromBackup is a helper method to define mock.On call
 //   - ctx *contexts.Context
 //   - namespace string
-//   - existingClusterName string
+//   - source clonedcluster.SourceCluster
 //   - newClusterName string
 //   - backup *v1.Backup
 //   - opts clonedcluster.CloneClusterOptions
-func (_e *MockClientInterface_Expecter) CloneClusterFromBackup(ctx interface{}, namespace interface{}, existingClusterName interface{}, newClusterName interface{}, backup interface{}, opts interface{}) *MockClientInterface_CloneClusterFromBackup_Call {
-	return &MockClientInterface_CloneClusterFromBackup_Call{Call: _e.mock.On("CloneClusterFromBackup", ctx, namespace, existingClusterName, newClusterName, backup, opts)}
+func (_e *MockClientInterface_Expecter) CloneClusterFromBackup(ctx interface{}, namespace interface{}, source interface{}, newClusterName interface{}, backup interface{}, opts interface{}) *MockClientInterface_CloneClusterFromBackup_Call {
+	return &MockClientInterface_CloneClusterFromBackup_Call{Call: _e.mock.On("CloneClusterFromBackup", ctx, namespace, source, newClusterName, backup, opts)}
 }
 
-func (_c *MockClientInterface_CloneClusterFromBackup_Call) Run(run func(ctx *contexts.Context, namespace string, existingClusterName string, newClusterName string, backup *v1.Backup, opts clonedcluster.CloneClusterOptions)) *MockClientInterface_CloneClusterFromBackup_Call {
+func (_c *MockClientInterface_CloneClusterFromBackup_Call) Run(run func(ctx *contexts.Context, namespace string, source clonedcluster.SourceCluster, newClusterName string, backup *v1.Backup, opts clonedcluster.CloneClusterOptions)) *MockClientInterface_CloneClusterFromBackup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*contexts.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*v1.Backup), args[5].(clonedcluster.CloneClusterOptions))
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(clonedcluster.SourceCluster), args[3].(string), args[4].(*v1.Backup), args[5].(clonedcluster.CloneClusterOptions))
 	})
 	return _c
 }
@@ -254,7 +254,7 @@ func (_c *MockClientInterface_CloneClusterFromBackup_Call) Return(cluster cloned
 	return _c
 }
 
-func (_c *MockClientInterface_CloneClusterFromBackup_Call) RunAndReturn(run func(*contexts.Context, string, string, string, *v1.Backup, clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error)) *MockClientInterface_CloneClusterFromBackup_Call {
+func (_c *MockClientInterface_CloneClusterFromBackup_Call) RunAndReturn(run func(*contexts.Context, string, clonedcluster.SourceCluster, string, *v1.Backup, clonedcluster.CloneClusterOptions) (clonedcluster.ClonedClusterInterface, error)) *MockClientInterface_CloneClusterFromBackup_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -610,6 +610,64 @@ func (_c *MockClientInterface_CreateClusterBackup_Call) RunAndReturn(run func(*c
 	return _c
 }
 
+// DescribeCluster provides a mock function with given fields: ctx, namespace, existingClusterName
+func (_m *MockClientInterface) DescribeCluster(ctx *contexts.Context, namespace string, existingClusterName string) (clonedcluster.SourceCluster, error) {
+	ret := _m.Called(ctx, namespace, existingClusterName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeCluster")
+	}
+
+	var r0 clonedcluster.SourceCluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string) (clonedcluster.SourceCluster, error)); ok {
+		return rf(ctx, namespace, existingClusterName)
+	}
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string) clonedcluster.SourceCluster); ok {
+		r0 = rf(ctx, namespace, existingClusterName)
+	} else {
+		r0 = ret.Get(0).(clonedcluster.SourceCluster)
+	}
+
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, existingClusterName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClientInterface_DescribeCluster_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeCluster'
+type MockClientInterface_DescribeCluster_Call struct {
+	*mock.Call
+}
+
+// DescribeCluster is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - namespace string
+//   - existingClusterName string
+func (_e *MockClientInterface_Expecter) DescribeCluster(ctx interface{}, namespace interface{}, existingClusterName interface{}) *MockClientInterface_DescribeCluster_Call {
+	return &MockClientInterface_DescribeCluster_Call{Call: _e.mock.On("DescribeCluster", ctx, namespace, existingClusterName)}
+}
+
+func (_c *MockClientInterface_DescribeCluster_Call) Run(run func(ctx *contexts.Context, namespace string, existingClusterName string)) *MockClientInterface_DescribeCluster_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClientInterface_DescribeCluster_Call) Return(_a0 clonedcluster.SourceCluster, _a1 error) *MockClientInterface_DescribeCluster_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClientInterface_DescribeCluster_Call) RunAndReturn(run func(*contexts.Context, string, string) (clonedcluster.SourceCluster, error)) *MockClientInterface_DescribeCluster_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ES provides a mock function with no fields
 func (_m *MockClientInterface) ES() externalsnapshotter.ClientInterface {
 	ret := _m.Called()
@@ -782,6 +840,67 @@ func (_c *MockClientInterface_NewDRVolume_Call) RunAndReturn(run func(*contexts.
 	return _c
 }
 
+// RestoreSnapshot provides a mock function with given fields: ctx, namespace, snapshotName, opts
+func (_m *MockClientInterface) RestoreSnapshot(ctx *contexts.Context, namespace string, snapshotName string, opts clonepvc.RestoreSnapshotOptions) (*corev1.PersistentVolumeClaim, error) {
+	ret := _m.Called(ctx, namespace, snapshotName, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSnapshot")
+	}
+
+	var r0 *corev1.PersistentVolumeClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, clonepvc.RestoreSnapshotOptions) (*corev1.PersistentVolumeClaim, error)); ok {
+		return rf(ctx, namespace, snapshotName, opts)
+	}
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, clonepvc.RestoreSnapshotOptions) *corev1.PersistentVolumeClaim); ok {
+		r0 = rf(ctx, namespace, snapshotName, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.PersistentVolumeClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string, string, clonepvc.RestoreSnapshotOptions) error); ok {
+		r1 = rf(ctx, namespace, snapshotName, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClientInterface_RestoreSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSnapshot'
+type MockClientInterface_RestoreSnapshot_Call struct {
+	*mock.Call
+}
+
+// RestoreSnapshot is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - namespace string
+//   - snapshotName string
+//   - opts clonepvc.RestoreSnapshotOptions
+func (_e *MockClientInterface_Expecter) RestoreSnapshot(ctx interface{}, namespace interface{}, snapshotName interface{}, opts interface{}) *MockClientInterface_RestoreSnapshot_Call {
+	return &MockClientInterface_RestoreSnapshot_Call{Call: _e.mock.On("RestoreSnapshot", ctx, namespace, snapshotName, opts)}
+}
+
+func (_c *MockClientInterface_RestoreSnapshot_Call) Run(run func(ctx *contexts.Context, namespace string, snapshotName string, opts clonepvc.RestoreSnapshotOptions)) *MockClientInterface_RestoreSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(string), args[3].(clonepvc.RestoreSnapshotOptions))
+	})
+	return _c
+}
+
+func (_c *MockClientInterface_RestoreSnapshot_Call) Return(pvc *corev1.PersistentVolumeClaim, err error) *MockClientInterface_RestoreSnapshot_Call {
+	_c.Call.Return(pvc, err)
+	return _c
+}
+
+func (_c *MockClientInterface_RestoreSnapshot_Call) RunAndReturn(run func(*contexts.Context, string, string, clonepvc.RestoreSnapshotOptions) (*corev1.PersistentVolumeClaim, error)) *MockClientInterface_RestoreSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockClientInterface creates a new instance of MockClientInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockClientInterface(t interface {
@@ -85,6 +85,19 @@ type CloneClusterOptionsCAIssuer struct {
 	WaitForReadyTimeout helpers.MaxWaitTime `yaml:"waitForReadyTimeout,omitempty"`
 }
 
+// CloneClusterOptionsRecoveryTarget recovers the clone to a point in the source's write-ahead log rather than
+// to a wall-clock time, such as just before a known-bad transaction. At most one target may be set.
+type CloneClusterOptionsRecoveryTarget struct {
+	TargetLSN  string `yaml:"targetLSN,omitempty" jsonschema:"description=The write-ahead log location (LSN) to recover to"`
+	TargetXID  string `yaml:"targetXID,omitempty" jsonschema:"description=The transaction ID to recover to"`
+	TargetName string `yaml:"targetName,omitempty" jsonschema:"description=The named restore point to recover to"`
+	Exclusive  bool   `yaml:"exclusive,omitempty" jsonschema:"description=Stop just before the recovery target instead of just after it. Not supported for named restore points"`
+}
+
+func (rt CloneClusterOptionsRecoveryTarget) IsZero() bool {
+	return rt == CloneClusterOptionsRecoveryTarget{}
+}
+
 type CloneClusterOptions struct {
 	WaitForBackupTimeout  helpers.MaxWaitTime             `yaml:"waitForBackupTimeout,omitempty"`
 	SelfSignedIssuer      CloneClusterOptionsCAIssuer     `yaml:"selfSignedIssuer,omitempty"`
@@ -94,6 +107,50 @@ type CloneClusterOptions struct {
 	WaitForClusterTimeout helpers.MaxWaitTime             `yaml:"waitForClusterTimeout,omitempty"`
 	CleanupTimeout        helpers.MaxWaitTime             `yaml:"cleanupTimeout,omitempty"`
 	// TODO maybe provide an option for additional client auth CAs?
+	RecoveryTarget CloneClusterOptionsRecoveryTarget `yaml:"recoveryTarget,omitempty" jsonschema:"description=The LSN\\, transaction or named restore point to roll back to\\, instead of a time"`
+}
+
+// validateRecoveryTarget checks that at most one recovery target is set, and that exclusive is only set for
+// targets that honor it.
+func (opts CloneClusterOptions) validateRecoveryTarget() error {
+	setTargets := 0
+	for _, target := range []string{opts.RecoveryTargetTime, opts.RecoveryTarget.TargetLSN, opts.RecoveryTarget.TargetXID, opts.RecoveryTarget.TargetName} {
+		if target != "" {
+			setTargets++
+		}
+	}
+
+	if setTargets > 1 {
+		return trace.BadParameter("only one of the recovery target time, LSN, transaction ID or name may be set")
+	}
+
+	if opts.RecoveryTarget.Exclusive && (setTargets == 0 || opts.RecoveryTarget.TargetName != "") {
+		return trace.BadParameter("an exclusive recovery requires a recovery target time, LSN or transaction ID")
+	}
+
+	return nil
+}
+
+// SourceCluster is everything that a clone copies from the cluster that it recovers from. It is read from the
+// cluster with DescribeCluster, and can be recorded alongside a backup so that the clone can be created after
+// the source cluster is gone.
+type SourceCluster struct {
+	Name         string `json:"name"`
+	ImageName    string `json:"imageName,omitempty"`
+	StorageClass string `json:"storageClass,omitempty"`
+	StorageSize  string `json:"storageSize"`
+	DatabaseName string `json:"databaseName,omitempty"`
+	OwnerName    string `json:"ownerName,omitempty"`
+	// WALArchive is where the source cluster archives write-ahead logs, which the clone recovers forward from.
+	WALArchive SourceClusterWALArchive `json:"walArchive"`
+}
+
+// SourceClusterWALArchive is the barman-cloud plugin object store that a cluster archives write-ahead logs to.
+type SourceClusterWALArchive struct {
+	// ObjectStoreName is the name of the barman-cloud ObjectStore resource, in the cluster's namespace.
+	ObjectStoreName string `json:"objectStoreName"`
+	// ServerName is the folder in the object store that the cluster's write-ahead logs are stored under.
+	ServerName string `json:"serverName"`
 }
 
 func newClonedCluster(p providerInterfaceInternal) ClonedClusterInterface {
@@ -134,17 +191,70 @@ func (p *Provider) CreateClusterBackup(ctx *contexts.Context, namespace, existin
 	return readyBackup, nil
 }
 
+// DescribeCluster reads what a clone of an existing cluster needs to know about it. It is an error for the
+// cluster not to archive WAL via the barman-cloud plugin, as a clone could not recover forward from its backups.
+func (p *Provider) DescribeCluster(ctx *contexts.Context, namespace, existingClusterName string) (source SourceCluster, err error) {
+	ctx.Log.With("existingCluster", existingClusterName).Info("Collecting information about the existing cluster")
+	defer ctx.Log.Info("Finished collecting information about the existing cluster", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	existingCluster, err := p.cnpgClient.GetCluster(ctx.Child(), namespace, existingClusterName)
+	if err != nil {
+		return SourceCluster{}, trace.Wrap(err, "failed to get existing cluster %q", helpers.FullNameStr(namespace, existingClusterName))
+	}
+
+	if _, err := resource.ParseQuantity(existingCluster.Spec.StorageConfiguration.Size); err != nil {
+		return SourceCluster{}, trace.Wrap(err, "failed to parse the existing cluster %q storage volume size %q", helpers.FullName(existingCluster), existingCluster.Spec.StorageConfiguration.Size)
+	}
+
+	source = SourceCluster{
+		Name:        existingCluster.Name,
+		ImageName:   existingCluster.Spec.ImageName,
+		StorageSize: existingCluster.Spec.StorageConfiguration.Size,
+	}
+
+	if existingCluster.Spec.StorageConfiguration.StorageClass != nil {
+		source.StorageClass = *existingCluster.Spec.StorageConfiguration.StorageClass
+	}
+
+	if existingCluster.Spec.Bootstrap != nil {
+		if existingCluster.Spec.Bootstrap.InitDB != nil {
+			source.DatabaseName = existingCluster.Spec.Bootstrap.InitDB.Database
+			source.OwnerName = existingCluster.Spec.Bootstrap.InitDB.Owner
+		} else if existingCluster.Spec.Bootstrap.PgBaseBackup != nil {
+			source.DatabaseName = existingCluster.Spec.Bootstrap.PgBaseBackup.Database
+			source.OwnerName = existingCluster.Spec.Bootstrap.PgBaseBackup.Owner
+		} else if existingCluster.Spec.Bootstrap.Recovery != nil {
+			source.DatabaseName = existingCluster.Spec.Bootstrap.Recovery.Database
+			source.OwnerName = existingCluster.Spec.Bootstrap.Recovery.Owner
+		}
+	}
+
+	walArchive, err := p.describeWALArchive(ctx.Child(), namespace, existingCluster)
+	if err != nil {
+		return SourceCluster{}, trace.Wrap(err, "failed to describe the WAL archive of the existing cluster %q", helpers.FullName(existingCluster))
+	}
+	source.WALArchive = walArchive
+
+	return source, nil
+}
+
 // CloneClusterFromBackup creates a new cluster that recovers from a previously-created (ready) backup,
-// with its own short-lived certificates. When a RecoveryTargetTime is supplied, the clone recovers
-// forward to that wall-clock instant (recoveryTarget.targetTime); if the source had no WAL at/after the
-// target (an idle database), it falls back to the backup's consistency point. Otherwise it recovers to
-// the consistency point directly.
-func (p *Provider) CloneClusterFromBackup(ctx *contexts.Context, namespace, existingClusterName, newClusterName string, readyBackup *apiv1.Backup, opts CloneClusterOptions) (cluster ClonedClusterInterface, err error) {
+// with its own short-lived certificates. The new cluster's image, storage and WAL archive are taken from
+// the source, so the source cluster itself doesn't need to exist. When a RecoveryTargetTime is supplied,
+// the clone recovers forward to that wall-clock instant (recoveryTarget.targetTime); if the source had no
+// WAL at/after the target (an idle database), it falls back to the backup's consistency point. When a
+// RecoveryTarget is supplied, the clone recovers to that LSN, transaction or named restore point, and fails
+// if it can't. Otherwise it recovers to the consistency point directly.
+func (p *Provider) CloneClusterFromBackup(ctx *contexts.Context, namespace string, source SourceCluster, newClusterName string, readyBackup *apiv1.Backup, opts CloneClusterOptions) (cluster ClonedClusterInterface, err error) {
 	if len(newClusterName) > 40 { // Max length that CNPG allows for cloned cluster names, see https://github.com/cloudnative-pg/cloudnative-pg/pull/6755
 		return nil, trace.Errorf("newClusterName must be 40 characters or less")
 	}
 
-	ctx.Log.With("existingCluster", existingClusterName, "newCluster", newClusterName).Info("Creating cloned cluster from backup")
+	if err := opts.validateRecoveryTarget(); err != nil {
+		return nil, trace.Wrap(err, "invalid recovery target")
+	}
+
+	ctx.Log.With("existingCluster", source.Name, "newCluster", newClusterName).Info("Creating cloned cluster from backup")
 	defer ctx.Log.Info("Finished creating cloned cluster from backup", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
 
 	cluster = p.newClonedCluster()
@@ -160,15 +270,9 @@ func (p *Provider) CloneClusterFromBackup(ctx *contexts.Context, namespace, exis
 			RunError()
 	}
 
-	ctx.Log.Info("Collecting information about the existing cluster")
-	existingCluster, err := p.cnpgClient.GetCluster(ctx.Child(), namespace, existingClusterName)
-	if err != nil {
-		return errHandler(err, "failed to get existing cluster %q", helpers.FullNameStr(namespace, existingClusterName))
-	}
-
-	clusterVolumeSize, err := resource.ParseQuantity(existingCluster.Spec.StorageConfiguration.Size)
+	clusterVolumeSize, err := resource.ParseQuantity(source.StorageSize)
 	if err != nil {
-		return errHandler(err, "failed to parse the existing cluster %q storage volume size %q", helpers.FullName(existingCluster), existingCluster.Spec.StorageConfiguration.Size)
+		return errHandler(err, "failed to parse the existing cluster %q storage volume size %q", helpers.FullNameStr(namespace, source.Name), source.StorageSize)
 	}
 
 	// 1. Create the self-signed issuer that mints the clone's short-lived root certificates: its serving
@@ -283,34 +387,24 @@ func (p *Provider) CloneClusterFromBackup(ctx *contexts.Context, namespace, exis
 	// 5. Create a new cluster from the backup
 	ctx.Log.Step().Info("Creating new cluster from backup")
 	clusterOpts := cnpg.CreateClusterOptions{
-		ImageName: existingCluster.Spec.ImageName,
-	}
-
-	if existingCluster.Spec.StorageConfiguration.StorageClass != nil {
-		clusterOpts.StorageClass = *existingCluster.Spec.StorageConfiguration.StorageClass
-	}
-
-	if existingCluster.Spec.Bootstrap != nil {
-		if existingCluster.Spec.Bootstrap.InitDB != nil {
-			clusterOpts.DatabaseName = existingCluster.Spec.Bootstrap.InitDB.Database
-			clusterOpts.OwnerName = existingCluster.Spec.Bootstrap.InitDB.Owner
-		} else if existingCluster.Spec.Bootstrap.PgBaseBackup != nil {
-			clusterOpts.DatabaseName = existingCluster.Spec.Bootstrap.PgBaseBackup.Database
-			clusterOpts.OwnerName = existingCluster.Spec.Bootstrap.PgBaseBackup.Owner
-		} else if existingCluster.Spec.Bootstrap.Recovery != nil {
-			clusterOpts.DatabaseName = existingCluster.Spec.Bootstrap.Recovery.Database
-			clusterOpts.OwnerName = existingCluster.Spec.Bootstrap.Recovery.Owner
-		}
+		ImageName:    source.ImageName,
+		StorageClass: source.StorageClass,
+		DatabaseName: source.DatabaseName,
+		OwnerName:    source.OwnerName,
 	}
 
 	// Configure where the new cluster sources WAL during recovery from the source cluster's
 	// barman-cloud plugin object store.
-	if err := p.configureWALRecovery(ctx.Child(), namespace, existingCluster, readyBackup, &clusterOpts); err != nil {
+	if err := configureWALRecovery(ctx.Child(), source, readyBackup, &clusterOpts); err != nil {
 		return errHandler(err, "failed to configure WAL recovery for new cluster %q", helpers.FullNameStr(namespace, newClusterName))
 	}
 
 	// configureWALRecovery has already populated VolumeSnapshotRecovery, so this deref is safe.
 	clusterOpts.VolumeSnapshotRecovery.RecoveryTargetTime = opts.RecoveryTargetTime
+	clusterOpts.VolumeSnapshotRecovery.RecoveryTargetLSN = opts.RecoveryTarget.TargetLSN
+	clusterOpts.VolumeSnapshotRecovery.RecoveryTargetXID = opts.RecoveryTarget.TargetXID
+	clusterOpts.VolumeSnapshotRecovery.RecoveryTargetName = opts.RecoveryTarget.TargetName
+	clusterOpts.VolumeSnapshotRecovery.RecoveryTargetExclusive = opts.RecoveryTarget.Exclusive
 
 	createClone := func(ctx *contexts.Context) error {
 		newCluster, createErr := p.cnpgClient.CreateCluster(ctx.Child(), namespace, newClusterName, clusterVolumeSize, readyServingCert.Name, readyClientCACert.Name, replicationUserCert.GetCertificate().Name, clusterOpts)
@@ -333,6 +427,13 @@ func (p *Provider) CloneClusterFromBackup(ctx *contexts.Context, namespace, exis
 			return errHandler(err, "failed to wait for new PITR cluster %q to become ready", helpers.FullNameStr(namespace, newClusterName))
 		}
 
+		// Only a wall-clock target can be unreachable because nothing happened after it. An LSN, transaction or
+		// restore point that recovery never reached was asked for explicitly, so recovering anywhere else would
+		// silently give the caller different data.
+		if !opts.RecoveryTarget.IsZero() {
+			return errHandler(err, "new PITR cluster %q did not reach its recovery target", helpers.FullNameStr(namespace, newClusterName))
+		}
+
 		// The source had no WAL at or after the target (an idle database), so recovering forward to
 		// the wall-clock target is impossible. Recovering to the consistency point is data-identical
 		// (nothing changed after it), so tear the clone down and recreate it with targetImmediate.
@@ -407,21 +508,22 @@ func (p *Provider) createClonedClusterCertificate(ctx *contexts.Context, namespa
 }
 
 // waitForCloneRecovery waits for the cloned cluster to become ready, watching the API server rather
-// than polling. When recovering to a wall-clock target, recovery may not reach it (an idle source),
-// so it watches the recovery Job to its terminal outcome:
+// than polling. When recovering to a target, recovery may not reach it (an idle source, or an LSN,
+// transaction or restore point that was never archived), so it watches the recovery Job to its
+// terminal outcome:
 //   - The Job retries (its backoffLimit window) long enough to wait out the source's archive_timeout,
 //     so it completes once an attempt reaches the target; the instance is then promoted and the
 //     cluster becomes ready.
 //   - It fails once it has exhausted its retries without reaching the target, meaning the target is
-//     unreachable from archived WAL (an idle source), so this returns ErrRecoveryTargetNotReached and
-//     the caller falls back to the consistency point.
+//     unreachable from archived WAL, so this returns ErrRecoveryTargetNotReached and the caller falls
+//     back to the consistency point (for a wall-clock target) or fails.
 //
 // Watching the Job's terminal condition is unambiguous — a completed Job promoted, a failed Job gave
 // up — unlike the cluster status (which doesn't surface the reason) or a one-shot pod check (which
 // can't tell an idle retry from a slow replay). The terminal state is observed even if CNPG garbage
 // collects the Job afterwards, since the delete watch event still carries the Job's final state.
 func (p *Provider) waitForCloneRecovery(ctx *contexts.Context, namespace, newClusterName string, opts CloneClusterOptions) (*apiv1.Cluster, error) {
-	if opts.RecoveryTargetTime != "" {
+	if opts.RecoveryTargetTime != "" || !opts.RecoveryTarget.IsZero() {
 		// The recovery Job's name isn't known ahead of time, so select it by the cluster + jobRole labels.
 		jobSelector := fmt.Sprintf("%s=%s,%s", utils.ClusterLabelName, newClusterName, utils.JobRoleLabelName)
 		_, err := p.coreClient.WaitForJobCompletion(ctx.Child(), namespace, "", core.WaitForJobCompletionOpts{MaxWaitTime: opts.WaitForClusterTimeout, LabelSelector: jobSelector})
@@ -438,27 +540,36 @@ func (p *Provider) waitForCloneRecovery(ctx *contexts.Context, namespace, newClu
 	return p.cnpgClient.WaitForReadyCluster(ctx.Child(), namespace, newClusterName, cnpg.WaitForReadyClusterOpts{MaxWaitTime: opts.WaitForClusterTimeout})
 }
 
-// configureWALRecovery sets the recovery source on clusterOpts for a source cluster that archives
-// write-ahead logs with the barman-cloud plugin: the new cluster recovers from the backup's volume
-// snapshots, fetching WAL via an external cluster that references the same object store. This honors a
-// wall-clock recoveryTarget.targetTime. The recovery target itself is chosen by the caller; this only
-// configures the source. It is an error for the source cluster not to use the barman-cloud plugin.
-func (p *Provider) configureWALRecovery(ctx *contexts.Context, namespace string, sourceCluster *apiv1.Cluster, backup *apiv1.Backup, clusterOpts *cnpg.CreateClusterOptions) (err error) {
-	walArchiverPlugin := findBarmanCloudWALArchiver(sourceCluster)
+// describeWALArchive finds the barman-cloud object store that a cluster archives write-ahead logs to, and the
+// server name that its WAL is stored under. It is an error for the cluster not to use the barman-cloud plugin.
+func (p *Provider) describeWALArchive(ctx *contexts.Context, namespace string, cluster *apiv1.Cluster) (SourceClusterWALArchive, error) {
+	walArchiverPlugin := findBarmanCloudWALArchiver(cluster)
 	if walArchiverPlugin == nil {
-		return trace.Errorf("source cluster %q does not archive WAL via the barman-cloud plugin", helpers.FullName(sourceCluster))
+		return SourceClusterWALArchive{}, trace.Errorf("cluster %q does not archive WAL via the barman-cloud plugin", helpers.FullName(cluster))
 	}
 
-	ctx.Log.Debug("Source cluster uses the barman-cloud plugin; recovering from volume snapshots", "plugin", walArchiverPlugin.Name)
+	ctx.Log.Debug("Cluster uses the barman-cloud plugin", "plugin", walArchiverPlugin.Name)
 
 	objectStoreName := walArchiverPlugin.Parameters["barmanObjectName"]
 	if objectStoreName == "" {
-		return trace.Errorf("barman-cloud plugin on cluster %q is missing the %q parameter", helpers.FullName(sourceCluster), "barmanObjectName")
+		return SourceClusterWALArchive{}, trace.Errorf("barman-cloud plugin on cluster %q is missing the %q parameter", helpers.FullName(cluster), "barmanObjectName")
 	}
 
-	serverName, err := p.resolveBarmanServerName(ctx, namespace, sourceCluster.Name, walArchiverPlugin, objectStoreName)
+	serverName, err := p.resolveBarmanServerName(ctx, namespace, cluster.Name, walArchiverPlugin, objectStoreName)
 	if err != nil {
-		return trace.Wrap(err, "failed to resolve barman server name for cluster %q", helpers.FullName(sourceCluster))
+		return SourceClusterWALArchive{}, trace.Wrap(err, "failed to resolve barman server name for cluster %q", helpers.FullName(cluster))
+	}
+
+	return SourceClusterWALArchive{ObjectStoreName: objectStoreName, ServerName: serverName}, nil
+}
+
+// configureWALRecovery sets the recovery source on clusterOpts: the new cluster recovers from the backup's
+// volume snapshots, fetching WAL via an external cluster that references the source cluster's barman-cloud
+// object store. This honors a wall-clock recoveryTarget.targetTime. The recovery target itself is chosen by
+// the caller; this only configures the source.
+func configureWALRecovery(ctx *contexts.Context, source SourceCluster, backup *apiv1.Backup, clusterOpts *cnpg.CreateClusterOptions) error {
+	if source.WALArchive.ObjectStoreName == "" || source.WALArchive.ServerName == "" {
+		return trace.BadParameter("source cluster %q has no barman-cloud WAL archive", source.Name)
 	}
 
 	dataSnapshotName, walSnapshotName := getBackupSnapshotNames(backup)
@@ -466,16 +577,18 @@ func (p *Provider) configureWALRecovery(ctx *contexts.Context, namespace string,
 		return trace.Errorf("backup %q did not report a PG_DATA volume snapshot to recover from", helpers.FullName(backup))
 	}
 
+	ctx.Log.Debug("Recovering from volume snapshots", "dataSnapshot", dataSnapshotName, "walSnapshot", walSnapshotName)
+
 	clusterOpts.VolumeSnapshotRecovery = &cnpg.VolumeSnapshotRecovery{
 		DataSnapshotName: dataSnapshotName,
 		WALSnapshotName:  walSnapshotName,
 		WALSource: apiv1.ExternalCluster{
-			Name: serverName,
+			Name: source.WALArchive.ServerName,
 			PluginConfiguration: &apiv1.PluginConfiguration{
 				Name: cnpg.BarmanCloudPluginName,
 				Parameters: map[string]string{
-					"barmanObjectName": objectStoreName,
-					"serverName":       serverName,
+					"barmanObjectName": source.WALArchive.ObjectStoreName,
+					"serverName":       source.WALArchive.ServerName,
 				},
 			},
 		},
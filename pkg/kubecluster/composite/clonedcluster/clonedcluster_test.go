@@ -160,6 +160,70 @@ func TestCloneClusterOptions(t *testing.T) {
 	th.OptStructTest[CloneClusterOptions](t)
 }
 
+func TestCloneClusterOptionsValidateRecoveryTarget(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    CloneClusterOptions
+		wantErr bool
+	}{
+		{
+			desc: "no target",
+		},
+		{
+			desc: "target time",
+			opts: CloneClusterOptions{RecoveryTargetTime: "2026-01-01T10:00:00Z"},
+		},
+		{
+			desc: "exclusive target LSN",
+			opts: CloneClusterOptions{RecoveryTarget: CloneClusterOptionsRecoveryTarget{TargetLSN: "0/3000060", Exclusive: true}},
+		},
+		{
+			desc: "exclusive target XID",
+			opts: CloneClusterOptions{RecoveryTarget: CloneClusterOptionsRecoveryTarget{TargetXID: "1234", Exclusive: true}},
+		},
+		{
+			desc: "exclusive target time",
+			opts: CloneClusterOptions{RecoveryTargetTime: "2026-01-01T10:00:00Z", RecoveryTarget: CloneClusterOptionsRecoveryTarget{Exclusive: true}},
+		},
+		{
+			desc: "target name",
+			opts: CloneClusterOptions{RecoveryTarget: CloneClusterOptionsRecoveryTarget{TargetName: "before-migration"}},
+		},
+		{
+			desc:    "target time and LSN",
+			opts:    CloneClusterOptions{RecoveryTargetTime: "2026-01-01T10:00:00Z", RecoveryTarget: CloneClusterOptionsRecoveryTarget{TargetLSN: "0/3000060"}},
+			wantErr: true,
+		},
+		{
+			desc:    "target XID and name",
+			opts:    CloneClusterOptions{RecoveryTarget: CloneClusterOptionsRecoveryTarget{TargetXID: "1234", TargetName: "before-migration"}},
+			wantErr: true,
+		},
+		{
+			desc:    "exclusive without a target",
+			opts:    CloneClusterOptions{RecoveryTarget: CloneClusterOptionsRecoveryTarget{Exclusive: true}},
+			wantErr: true,
+		},
+		{
+			desc:    "exclusive target name",
+			opts:    CloneClusterOptions{RecoveryTarget: CloneClusterOptionsRecoveryTarget{TargetName: "before-migration", Exclusive: true}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.opts.validateRecoveryTarget()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
 func barmanCloudPlugin(params map[string]string, isWALArchiver bool) apiv1.PluginConfiguration {
 	return apiv1.PluginConfiguration{
 		Name:          cnpg.BarmanCloudPluginName,
@@ -177,117 +241,130 @@ func snapshotBackup(elements ...apiv1.BackupSnapshotElementStatus) *apiv1.Backup
 	}
 }
 
-func TestConfigureWALRecovery(t *testing.T) {
+func TestDescribeCluster(t *testing.T) {
 	namespace := "test-ns"
 	clusterName := "source-cluster"
-	dataElement := apiv1.BackupSnapshotElementStatus{Name: "snap-data", Type: string(utils.PVCRolePgData)}
-	walElement := apiv1.BackupSnapshotElementStatus{Name: "snap-wal", Type: string(utils.PVCRolePgWal)}
-
-	pluginWALSource := func(serverName string) apiv1.ExternalCluster {
-		return apiv1.ExternalCluster{
-			Name: serverName,
-			PluginConfiguration: &apiv1.PluginConfiguration{
-				Name: cnpg.BarmanCloudPluginName,
-				Parameters: map[string]string{
-					"barmanObjectName": "store",
-					"serverName":       serverName,
-				},
-			},
-		}
-	}
 
 	tests := []struct {
-		desc            string
-		plugins         []apiv1.PluginConfiguration
-		backup          *apiv1.Backup
-		objectStore     *barmancloudv1.ObjectStore
-		objectStoreErr  bool
-		expectGetStore  bool
-		expectErr       bool
-		expectedOptions cnpg.CreateClusterOptions
+		desc           string
+		cluster        *apiv1.Cluster
+		getClusterErr  bool
+		objectStore    *barmancloudv1.ObjectStore
+		objectStoreErr bool
+		expectGetStore bool
+		expectErr      bool
+		expected       SourceCluster
 	}{
 		{
-			desc:      "source without the barman-cloud plugin is an error",
-			backup:    snapshotBackup(dataElement),
+			desc:          "cluster lookup failure is an error",
+			getClusterErr: true,
+			expectErr:     true,
+		},
+		{
+			desc: "invalid storage size is an error",
+			cluster: &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					StorageConfiguration: apiv1.StorageConfiguration{Size: "not-a-size"},
+					Plugins:              []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store", "serverName": "custom-server"}, true)},
+				},
+			},
 			expectErr: true,
 		},
 		{
-			desc:      "non-barman plugin does not count as WAL archiving and is an error",
-			plugins:   []apiv1.PluginConfiguration{{Name: "some-other.plugin", IsWALArchiver: new(true)}},
-			backup:    snapshotBackup(dataElement),
+			desc:      "source without the barman-cloud plugin is an error",
+			cluster:   &apiv1.Cluster{Spec: apiv1.ClusterSpec{StorageConfiguration: apiv1.StorageConfiguration{Size: "1Gi"}}},
 			expectErr: true,
 		},
 		{
-			desc:    "plugin with explicit serverName parameter",
-			plugins: []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store", "serverName": "custom-server"}, true)},
-			backup:  snapshotBackup(dataElement),
-			expectedOptions: cnpg.CreateClusterOptions{
-				VolumeSnapshotRecovery: &cnpg.VolumeSnapshotRecovery{
-					DataSnapshotName: "snap-data",
-					WALSource:        pluginWALSource("custom-server"),
+			desc: "non-barman plugin does not count as WAL archiving and is an error",
+			cluster: &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					StorageConfiguration: apiv1.StorageConfiguration{Size: "1Gi"},
+					Plugins:              []apiv1.PluginConfiguration{{Name: "some-other.plugin", IsWALArchiver: new(true)}},
 				},
 			},
+			expectErr: true,
 		},
 		{
-			desc:    "plugin with separate WAL volume snapshot",
-			plugins: []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store", "serverName": "custom-server"}, true)},
-			backup:  snapshotBackup(dataElement, walElement),
-			expectedOptions: cnpg.CreateClusterOptions{
-				VolumeSnapshotRecovery: &cnpg.VolumeSnapshotRecovery{
-					DataSnapshotName: "snap-data",
-					WALSnapshotName:  "snap-wal",
-					WALSource:        pluginWALSource("custom-server"),
+			desc: "plugin missing barmanObjectName parameter is an error",
+			cluster: &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					StorageConfiguration: apiv1.StorageConfiguration{Size: "1Gi"},
+					Plugins:              []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"serverName": "custom-server"}, true)},
 				},
 			},
+			expectErr: true,
 		},
 		{
-			desc:           "plugin without serverName falls back to the object store's server name",
-			plugins:        []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store"}, true)},
-			backup:         snapshotBackup(dataElement),
-			objectStore:    &barmancloudv1.ObjectStore{Spec: barmancloudv1.ObjectStoreSpec{Configuration: barmanapi.BarmanObjectStoreConfiguration{ServerName: "store-server"}}},
-			expectGetStore: true,
-			expectedOptions: cnpg.CreateClusterOptions{
-				VolumeSnapshotRecovery: &cnpg.VolumeSnapshotRecovery{
-					DataSnapshotName: "snap-data",
-					WALSource: apiv1.ExternalCluster{
-						Name: "store-server",
-						PluginConfiguration: &apiv1.PluginConfiguration{
-							Name:       cnpg.BarmanCloudPluginName,
-							Parameters: map[string]string{"barmanObjectName": "store", "serverName": "store-server"},
-						},
+			desc: "plugin with explicit serverName parameter",
+			cluster: &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					ImageName: "postgres:17",
+					StorageConfiguration: apiv1.StorageConfiguration{
+						Size:         "10Gi",
+						StorageClass: new("fast"),
 					},
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						InitDB: &apiv1.BootstrapInitDB{Database: "app", Owner: "app-owner"},
+					},
+					Plugins: []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store", "serverName": "custom-server"}, true)},
 				},
 			},
+			expected: SourceCluster{
+				Name:         clusterName,
+				ImageName:    "postgres:17",
+				StorageClass: "fast",
+				StorageSize:  "10Gi",
+				DatabaseName: "app",
+				OwnerName:    "app-owner",
+				WALArchive:   SourceClusterWALArchive{ObjectStoreName: "store", ServerName: "custom-server"},
+			},
 		},
 		{
-			desc:           "plugin without serverName and empty object store server name defaults to cluster name",
-			plugins:        []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store"}, true)},
-			backup:         snapshotBackup(dataElement),
-			objectStore:    &barmancloudv1.ObjectStore{Spec: barmancloudv1.ObjectStoreSpec{Configuration: barmanapi.BarmanObjectStoreConfiguration{}}},
-			expectGetStore: true,
-			expectedOptions: cnpg.CreateClusterOptions{
-				VolumeSnapshotRecovery: &cnpg.VolumeSnapshotRecovery{
-					DataSnapshotName: "snap-data",
-					WALSource:        pluginWALSource(clusterName),
+			desc: "plugin without serverName falls back to the object store's server name",
+			cluster: &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					StorageConfiguration: apiv1.StorageConfiguration{Size: "1Gi"},
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						Recovery: &apiv1.BootstrapRecovery{Database: "recovered", Owner: "recovered-owner"},
+					},
+					Plugins: []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store"}, true)},
 				},
 			},
+			objectStore:    &barmancloudv1.ObjectStore{Spec: barmancloudv1.ObjectStoreSpec{Configuration: barmanapi.BarmanObjectStoreConfiguration{ServerName: "store-server"}}},
+			expectGetStore: true,
+			expected: SourceCluster{
+				Name:         clusterName,
+				StorageSize:  "1Gi",
+				DatabaseName: "recovered",
+				OwnerName:    "recovered-owner",
+				WALArchive:   SourceClusterWALArchive{ObjectStoreName: "store", ServerName: "store-server"},
+			},
 		},
 		{
-			desc:      "plugin missing barmanObjectName parameter is an error",
-			plugins:   []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"serverName": "custom-server"}, true)},
-			backup:    snapshotBackup(dataElement),
-			expectErr: true,
-		},
-		{
-			desc:      "plugin backup without a PG_DATA snapshot is an error",
-			plugins:   []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store", "serverName": "custom-server"}, true)},
-			backup:    snapshotBackup(walElement),
-			expectErr: true,
+			desc: "plugin without serverName and empty object store server name defaults to cluster name",
+			cluster: &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					StorageConfiguration: apiv1.StorageConfiguration{Size: "1Gi"},
+					Plugins:              []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store"}, true)},
+				},
+			},
+			objectStore:    &barmancloudv1.ObjectStore{Spec: barmancloudv1.ObjectStoreSpec{Configuration: barmanapi.BarmanObjectStoreConfiguration{}}},
+			expectGetStore: true,
+			expected: SourceCluster{
+				Name:        clusterName,
+				StorageSize: "1Gi",
+				WALArchive:  SourceClusterWALArchive{ObjectStoreName: "store", ServerName: clusterName},
+			},
 		},
 		{
-			desc:           "object store lookup failure is an error",
-			plugins:        []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store"}, true)},
-			backup:         snapshotBackup(dataElement),
+			desc: "object store lookup failure is an error",
+			cluster: &apiv1.Cluster{
+				Spec: apiv1.ClusterSpec{
+					StorageConfiguration: apiv1.StorageConfiguration{Size: "1Gi"},
+					Plugins:              []apiv1.PluginConfiguration{barmanCloudPlugin(map[string]string{"barmanObjectName": "store"}, true)},
+				},
+			},
 			objectStoreErr: true,
 			expectGetStore: true,
 			expectErr:      true,
@@ -299,11 +376,16 @@ func TestConfigureWALRecovery(t *testing.T) {
 			ctx := th.NewTestContext()
 			p := newMockProvider(t)
 
-			sourceCluster := &apiv1.Cluster{
-				ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: namespace},
-				Spec:       apiv1.ClusterSpec{Plugins: tt.plugins},
+			if tt.cluster != nil {
+				tt.cluster.ObjectMeta = metav1.ObjectMeta{Name: clusterName, Namespace: namespace}
 			}
 
+			p.cnpgClient.EXPECT().GetCluster(mock.Anything, namespace, clusterName).
+				RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*apiv1.Cluster, error) {
+					assert.True(t, calledCtx.IsChildOf(ctx))
+					return th.ErrOr1Val(tt.cluster, tt.getClusterErr)
+				})
+
 			if tt.expectGetStore {
 				p.barmanCloudClient.EXPECT().GetObjectStore(mock.Anything, namespace, "store").
 					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*barmancloudv1.ObjectStore, error) {
@@ -311,8 +393,84 @@ func TestConfigureWALRecovery(t *testing.T) {
 					})
 			}
 
+			source, err := p.DescribeCluster(ctx, namespace, clusterName)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, source)
+		})
+	}
+}
+
+func TestConfigureWALRecovery(t *testing.T) {
+	dataElement := apiv1.BackupSnapshotElementStatus{Name: "snap-data", Type: string(utils.PVCRolePgData)}
+	walElement := apiv1.BackupSnapshotElementStatus{Name: "snap-wal", Type: string(utils.PVCRolePgWal)}
+	source := SourceCluster{
+		Name:        "source-cluster",
+		StorageSize: "1Gi",
+		WALArchive:  SourceClusterWALArchive{ObjectStoreName: "store", ServerName: "custom-server"},
+	}
+	walSource := apiv1.ExternalCluster{
+		Name: "custom-server",
+		PluginConfiguration: &apiv1.PluginConfiguration{
+			Name: cnpg.BarmanCloudPluginName,
+			Parameters: map[string]string{
+				"barmanObjectName": "store",
+				"serverName":       "custom-server",
+			},
+		},
+	}
+
+	tests := []struct {
+		desc            string
+		source          SourceCluster
+		backup          *apiv1.Backup
+		expectErr       bool
+		expectedOptions cnpg.CreateClusterOptions
+	}{
+		{
+			desc:   "data volume snapshot",
+			source: source,
+			backup: snapshotBackup(dataElement),
+			expectedOptions: cnpg.CreateClusterOptions{
+				VolumeSnapshotRecovery: &cnpg.VolumeSnapshotRecovery{
+					DataSnapshotName: "snap-data",
+					WALSource:        walSource,
+				},
+			},
+		},
+		{
+			desc:   "separate WAL volume snapshot",
+			source: source,
+			backup: snapshotBackup(dataElement, walElement),
+			expectedOptions: cnpg.CreateClusterOptions{
+				VolumeSnapshotRecovery: &cnpg.VolumeSnapshotRecovery{
+					DataSnapshotName: "snap-data",
+					WALSnapshotName:  "snap-wal",
+					WALSource:        walSource,
+				},
+			},
+		},
+		{
+			desc:      "backup without a PG_DATA snapshot is an error",
+			source:    source,
+			backup:    snapshotBackup(walElement),
+			expectErr: true,
+		},
+		{
+			desc:      "source without a WAL archive is an error",
+			source:    SourceCluster{Name: "source-cluster", StorageSize: "1Gi"},
+			backup:    snapshotBackup(dataElement),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
 			clusterOpts := cnpg.CreateClusterOptions{}
-			err := p.configureWALRecovery(ctx, namespace, sourceCluster, tt.backup, &clusterOpts)
+			err := configureWALRecovery(th.NewTestContext(), tt.source, tt.backup, &clusterOpts)
 
 			if tt.expectErr {
 				require.Error(t, err)
@@ -328,22 +486,20 @@ func TestClonedClusterWhenFailToParseExistingClusterStorageSize(t *testing.T) {
 	p := newMockProvider(t)
 	ctx := th.NewTestContext()
 
-	p.cnpgClient.EXPECT().GetCluster(mock.Anything, "test-ns", "existing-cluster").
-		RunAndReturn(func(calledCtx *contexts.Context, namespace, name string) (*apiv1.Cluster, error) {
-			assert.True(t, calledCtx.IsChildOf(ctx))
-			return &apiv1.Cluster{
-				Spec: apiv1.ClusterSpec{
-					StorageConfiguration: apiv1.StorageConfiguration{
-						Size: "not-a-size",
-					},
-				},
-			}, nil
-		})
 	p.clonedCluster.EXPECT().Delete(mock.Anything).Return(nil)
 
-	// Call CloneClusterFromBackup directly so the test focuses on the storage-size parse failure
-	// without needing to drive the base backup creation that CloneCluster performs first.
-	clonedCluster, err := p.CloneClusterFromBackup(ctx, "test-ns", "existing-cluster", "new-cluster", &apiv1.Backup{}, CloneClusterOptions{})
+	source := SourceCluster{Name: "existing-cluster", StorageSize: "not-a-size"}
+	clonedCluster, err := p.CloneClusterFromBackup(ctx, "test-ns", source, "new-cluster", &apiv1.Backup{}, CloneClusterOptions{})
+	require.Error(t, err)
+	require.Nil(t, clonedCluster)
+}
+
+func TestClonedClusterWhenRecoveryTargetIsInvalid(t *testing.T) {
+	p := newMockProvider(t)
+	ctx := th.NewTestContext()
+
+	opts := CloneClusterOptions{RecoveryTarget: CloneClusterOptionsRecoveryTarget{TargetLSN: "0/3000060", TargetName: "before-migration"}}
+	clonedCluster, err := p.CloneClusterFromBackup(ctx, "test-ns", SourceCluster{Name: "existing-cluster"}, "new-cluster", &apiv1.Backup{}, opts)
 	require.Error(t, err)
 	require.Nil(t, clonedCluster)
 }
@@ -997,6 +1153,16 @@ func TestWaitForCloneRecovery(t *testing.T) {
 		assert.ErrorIs(t, err, ErrRecoveryTargetNotReached)
 	})
 
+	t.Run("target LSN, recovery job fails returns ErrRecoveryTargetNotReached", func(t *testing.T) {
+		p := newMockProvider(t)
+		ctx := th.NewTestContext()
+		p.coreClient.EXPECT().WaitForJobCompletion(mock.Anything, namespace, mock.Anything, mock.Anything).Return(nil, core.ErrJobFailed)
+
+		opts := CloneClusterOptions{RecoveryTarget: CloneClusterOptionsRecoveryTarget{TargetLSN: "0/3000060"}}
+		_, err := p.waitForCloneRecovery(ctx, namespace, clusterName, opts)
+		assert.ErrorIs(t, err, ErrRecoveryTargetNotReached)
+	})
+
 	t.Run("target time, recovery job wait error propagates", func(t *testing.T) {
 		p := newMockProvider(t)
 		ctx := th.NewTestContext()
@@ -17,9 +17,12 @@ type ProviderInterface interface {
 	// callers that need a wall-clock recovery target can take the base backup early (before the other
 	// captures, which fixes the consistency point) and create the recovering clone later (once the
 	// target time is known). The caller owns the returned backup's lifecycle and must DeleteBackup it
-	// after the clone is created, since the volume snapshots are owned by the backup.
+	// after the clone is created, since the volume snapshots are owned by the backup. The clone is created
+	// from a description of the source cluster (see DescribeCluster) rather than the cluster itself, so
+	// that it can be created from a kept backup after the source cluster is gone.
 	CreateClusterBackup(ctx *contexts.Context, namespace, existingClusterName string, opts CloneClusterOptions) (*apiv1.Backup, error)
-	CloneClusterFromBackup(ctx *contexts.Context, namespace, existingClusterName, newClusterName string, backup *apiv1.Backup, opts CloneClusterOptions) (cluster ClonedClusterInterface, err error)
+	DescribeCluster(ctx *contexts.Context, namespace, existingClusterName string) (SourceCluster, error)
+	CloneClusterFromBackup(ctx *contexts.Context, namespace string, source SourceCluster, newClusterName string, backup *apiv1.Backup, opts CloneClusterOptions) (cluster ClonedClusterInterface, err error)
 }
 
 type providerInterfaceInternal interface {
@@ -22,9 +22,9 @@ func (_m *MockProviderInterface) EXPECT() *MockProviderInterface_Expecter {
 	return &MockProviderInterface_Expecter{mock: &_m.Mock}
 }
 
-// CloneClusterFromBackup provides a mock function with given fields: ctx, namespace, existingClusterName, newClusterName, backup, opts
-func (_m *MockProviderInterface) CloneClusterFromBackup(ctx *contexts.Context, namespace string, existingClusterName string, newClusterName string, backup *v1.Backup, opts CloneClusterOptions) (ClonedClusterInterface, error) {
-	ret := _m.Called(ctx, namespace, existingClusterName, newClusterName, backup, opts)
+// CloneClusterFromBackup provides a mock function with given fields: ctx, namespace, source, newClusterName, backup, opts
+func (_m *MockProviderInterface) CloneClusterFromBackup(ctx *contexts.Context, namespace string, source SourceCluster, newClusterName string, backup *v1.Backup, opts CloneClusterOptions) (ClonedClusterInterface, error) {
+	ret := _m.Called(ctx, namespace, source, newClusterName, backup, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CloneClusterFromBackup")
@@ -32,19 +32,19 @@ func (_m *MockProviderInterface) CloneClusterFromBackup(ctx *contexts.Context, n
 
 	var r0 ClonedClusterInterface
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, string, *v1.Backup, CloneClusterOptions) (ClonedClusterInterface, error)); ok {
-		return rf(ctx, namespace, existingClusterName, newClusterName, backup, opts)
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, SourceCluster, string, *v1.Backup, CloneClusterOptions) (ClonedClusterInterface, error)); ok {
+		return rf(ctx, namespace, source, newClusterName, backup, opts)
 	}
-	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, string, *v1.Backup, CloneClusterOptions) ClonedClusterInterface); ok {
-		r0 = rf(ctx, namespace, existingClusterName, newClusterName, backup, opts)
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, SourceCluster, string, *v1.Backup, CloneClusterOptions) ClonedClusterInterface); ok {
+		r0 = rf(ctx, namespace, source, newClusterName, backup, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(ClonedClusterInterface)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*contexts.Context, string, string, string, *v1.Backup, CloneClusterOptions) error); ok {
-		r1 = rf(ctx, namespace, existingClusterName, newClusterName, backup, opts)
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string, SourceCluster, string, *v1.Backup, CloneClusterOptions) error); ok {
+		r1 = rf(ctx, namespace, source, newClusterName, backup, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,17 +60,17 @@ type MockProviderInterface_CloneClusterFromBackup_Call struct {
 // CloneClusterFromBackup is a helper method to define mock.On call
 //   - ctx *contexts.Context
 //   - namespace string
-//   - existingClusterName string
+//   - source SourceCluster
 //   - newClusterName string
 //   - backup *v1.Backup
 //   - opts CloneClusterOptions
-func (_e *MockProviderInterface_Expecter) CloneClusterFromBackup(ctx interface{}, namespace interface{}, existingClusterName interface{}, newClusterName interface{}, backup interface{}, opts interface{}) *MockProviderInterface_CloneClusterFromBackup_Call {
-	return &MockProviderInterface_CloneClusterFromBackup_Call{Call: _e.mock.On("CloneClusterFromBackup", ctx, namespace, existingClusterName, newClusterName, backup, opts)}
+func (_e *MockProviderInterface_Expecter) CloneClusterFromBackup(ctx interface{}, namespace interface{}, source interface{}, newClusterName interface{}, backup interface{}, opts interface{}) *MockProviderInterface_CloneClusterFromBackup_Call {
+	return &MockProviderInterface_CloneClusterFromBackup_Call{Call: _e.mock.On("CloneClusterFromBackup", ctx, namespace, source, newClusterName, backup, opts)}
 }
 
-func (_c *MockProviderInterface_CloneClusterFromBackup_Call) Run(run func(ctx *contexts.Context, namespace string, existingClusterName string, newClusterName string, backup *v1.Backup, opts CloneClusterOptions)) *MockProviderInterface_CloneClusterFromBackup_Call {
+func (_c *MockProviderInterface_CloneClusterFromBackup_Call) Run(run func(ctx *contexts.Context, namespace string, source SourceCluster, newClusterName string, backup *v1.Backup, opts CloneClusterOptions)) *MockProviderInterface_CloneClusterFromBackup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*contexts.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(*v1.Backup), args[5].(CloneClusterOptions))
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(SourceCluster), args[3].(string), args[4].(*v1.Backup), args[5].(CloneClusterOptions))
 	})
 	return _c
 }
@@ -80,7 +80,7 @@ func (_c *MockProviderInterface_CloneClusterFromBackup_Call) Return(cluster Clon
 	return _c
 }
 
-func (_c *MockProviderInterface_CloneClusterFromBackup_Call) RunAndReturn(run func(*contexts.Context, string, string, string, *v1.Backup, CloneClusterOptions) (ClonedClusterInterface, error)) *MockProviderInterface_CloneClusterFromBackup_Call {
+func (_c *MockProviderInterface_CloneClusterFromBackup_Call) RunAndReturn(run func(*contexts.Context, string, SourceCluster, string, *v1.Backup, CloneClusterOptions) (ClonedClusterInterface, error)) *MockProviderInterface_CloneClusterFromBackup_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -146,6 +146,64 @@ func (_c *MockProviderInterface_CreateClusterBackup_Call) RunAndReturn(run func(
 	return _c
 }
 
+// DescribeCluster provides a mock function with given fields: ctx, namespace, existingClusterName
+func (_m *MockProviderInterface) DescribeCluster(ctx *contexts.Context, namespace string, existingClusterName string) (SourceCluster, error) {
+	ret := _m.Called(ctx, namespace, existingClusterName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DescribeCluster")
+	}
+
+	var r0 SourceCluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string) (SourceCluster, error)); ok {
+		return rf(ctx, namespace, existingClusterName)
+	}
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string) SourceCluster); ok {
+		r0 = rf(ctx, namespace, existingClusterName)
+	} else {
+		r0 = ret.Get(0).(SourceCluster)
+	}
+
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, existingClusterName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProviderInterface_DescribeCluster_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DescribeCluster'
+type MockProviderInterface_DescribeCluster_Call struct {
+	*mock.Call
+}
+
+// DescribeCluster is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - namespace string
+//   - existingClusterName string
+func (_e *MockProviderInterface_Expecter) DescribeCluster(ctx interface{}, namespace interface{}, existingClusterName interface{}) *MockProviderInterface_DescribeCluster_Call {
+	return &MockProviderInterface_DescribeCluster_Call{Call: _e.mock.On("DescribeCluster", ctx, namespace, existingClusterName)}
+}
+
+func (_c *MockProviderInterface_DescribeCluster_Call) Run(run func(ctx *contexts.Context, namespace string, existingClusterName string)) *MockProviderInterface_DescribeCluster_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockProviderInterface_DescribeCluster_Call) Return(_a0 SourceCluster, _a1 error) *MockProviderInterface_DescribeCluster_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockProviderInterface_DescribeCluster_Call) RunAndReturn(run func(*contexts.Context, string, string) (SourceCluster, error)) *MockProviderInterface_DescribeCluster_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockProviderInterface creates a new instance of MockProviderInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockProviderInterface(t interface {
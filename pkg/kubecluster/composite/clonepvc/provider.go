@@ -11,10 +11,12 @@ import (
 // ProviderInterface clones PVCs from CSI snapshots. ClonePVC clones a single volume (via an
 // individual VolumeSnapshot); ClonePVCGroup clones a label-selected set atomically (via a
 // VolumeGroupSnapshot). Both share the same dependencies and the same per-snapshot
-// create-and-force-bind machinery, so they live on one provider.
+// create-and-force-bind machinery, so they live on one provider. RestoreSnapshot reuses the same
+// machinery to create a PVC from a snapshot that already exists.
 type ProviderInterface interface {
 	ClonePVC(ctx *contexts.Context, namespace, pvcName string, opts ClonePVCOptions) (clonedPvc *corev1.PersistentVolumeClaim, err error)
 	ClonePVCGroup(ctx *contexts.Context, namespace string, selector metav1.LabelSelector, opts ClonePVCGroupOptions) (result *ClonePVCGroupResult, err error)
+	RestoreSnapshot(ctx *contexts.Context, namespace, snapshotName string, opts RestoreSnapshotOptions) (pvc *corev1.PersistentVolumeClaim, err error)
 }
 
 type Provider struct {
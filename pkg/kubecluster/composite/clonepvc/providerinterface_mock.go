@@ -146,6 +146,67 @@ func (_c *MockProviderInterface_ClonePVCGroup_Call) RunAndReturn(run func(*conte
 	return _c
 }
 
+// RestoreSnapshot provides a mock function with given fields: ctx, namespace, snapshotName, opts
+func (_m *MockProviderInterface) RestoreSnapshot(ctx *contexts.Context, namespace string, snapshotName string, opts RestoreSnapshotOptions) (*v1.PersistentVolumeClaim, error) {
+	ret := _m.Called(ctx, namespace, snapshotName, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSnapshot")
+	}
+
+	var r0 *v1.PersistentVolumeClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, RestoreSnapshotOptions) (*v1.PersistentVolumeClaim, error)); ok {
+		return rf(ctx, namespace, snapshotName, opts)
+	}
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string, RestoreSnapshotOptions) *v1.PersistentVolumeClaim); ok {
+		r0 = rf(ctx, namespace, snapshotName, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.PersistentVolumeClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string, string, RestoreSnapshotOptions) error); ok {
+		r1 = rf(ctx, namespace, snapshotName, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockProviderInterface_RestoreSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSnapshot'
+type MockProviderInterface_RestoreSnapshot_Call struct {
+	*mock.Call
+}
+
+// RestoreSnapshot is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - namespace string
+//   - snapshotName string
+//   - opts RestoreSnapshotOptions
+func (_e *MockProviderInterface_Expecter) RestoreSnapshot(ctx interface{}, namespace interface{}, snapshotName interface{}, opts interface{}) *MockProviderInterface_RestoreSnapshot_Call {
+	return &MockProviderInterface_RestoreSnapshot_Call{Call: _e.mock.On("RestoreSnapshot", ctx, namespace, snapshotName, opts)}
+}
+
+func (_c *MockProviderInterface_RestoreSnapshot_Call) Run(run func(ctx *contexts.Context, namespace string, snapshotName string, opts RestoreSnapshotOptions)) *MockProviderInterface_RestoreSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(string), args[3].(RestoreSnapshotOptions))
+	})
+	return _c
+}
+
+func (_c *MockProviderInterface_RestoreSnapshot_Call) Return(pvc *v1.PersistentVolumeClaim, err error) *MockProviderInterface_RestoreSnapshot_Call {
+	_c.Call.Return(pvc, err)
+	return _c
+}
+
+func (_c *MockProviderInterface_RestoreSnapshot_Call) RunAndReturn(run func(*contexts.Context, string, string, RestoreSnapshotOptions) (*v1.PersistentVolumeClaim, error)) *MockProviderInterface_RestoreSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockProviderInterface creates a new instance of MockProviderInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockProviderInterface(t interface {
@@ -0,0 +1,44 @@
+package clonepvc
+
+import (
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/externalsnapshotter"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type RestoreSnapshotOptions struct {
+	WaitForSnapshotTimeout helpers.MaxWaitTime
+	DestStorageClassName   string // Override the storage class used for the created volume. Must be compatible with the snapshot. Required when the snapshot's source PVC no longer exists.
+	DestPvcNamePrefix      string // Override the prefix used for the created volume name. Defaults to the snapshot name.
+}
+
+// Creates a new PVC from an existing VolumeSnapshot, such as a DR volume snapshot. Unlike ClonePVC, the
+// snapshot is not owned (or deleted) by this function. Callers own the created PVC.
+func (p *Provider) RestoreSnapshot(ctx *contexts.Context, namespace, snapshotName string, opts RestoreSnapshotOptions) (pvc *corev1.PersistentVolumeClaim, err error) {
+	ctx.Log.With("snapshot", snapshotName).Info("Restoring snapshot to new PVC")
+	defer ctx.Log.Info("Finished restoring snapshot to new PVC", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	snapshot, err := p.esClient.WaitForReadySnapshot(ctx.Child(), namespace, snapshotName, externalsnapshotter.WaitForReadySnapshotOpts{MaxWaitTime: opts.WaitForSnapshotTimeout})
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to wait for snapshot %q to become ready", helpers.FullNameStr(namespace, snapshotName))
+	}
+
+	sourcePVCName := ""
+	if snapshot.Spec.Source.PersistentVolumeClaimName != nil {
+		sourcePVCName = *snapshot.Spec.Source.PersistentVolumeClaimName
+	}
+
+	if sourcePVCName == "" && opts.DestStorageClassName == "" {
+		return nil, trace.BadParameter("snapshot %q was not taken from a PVC, so a destination storage class must be specified", helpers.FullName(snapshot))
+	}
+
+	pvcNamePrefix := snapshotName
+	if opts.DestPvcNamePrefix != "" {
+		pvcNamePrefix = opts.DestPvcNamePrefix
+	}
+
+	pvc, err = p.createPVCFromSnapshot(ctx.Child(), namespace, pvcNamePrefix, sourcePVCName, snapshot, opts.DestStorageClassName)
+	return pvc, trace.Wrap(err, "failed to create volume from snapshot %q", helpers.FullName(snapshot))
+}
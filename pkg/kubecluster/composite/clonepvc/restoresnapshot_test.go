@@ -0,0 +1,146 @@
+package clonepvc
+
+import (
+	"testing"
+
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/core"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/primatives/externalsnapshotter"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRestoreSnapshot(t *testing.T) {
+	namespace := "test-ns"
+	snapshotName := "test-snapshot"
+	sourcePVCName := "source-pvc"
+	size := resource.MustParse("5Gi")
+
+	readySnapshot := &volumesnapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshotName,
+			Namespace: namespace,
+		},
+		Spec: volumesnapshotv1.VolumeSnapshotSpec{
+			Source: volumesnapshotv1.VolumeSnapshotSource{
+				PersistentVolumeClaimName: &sourcePVCName,
+			},
+		},
+		Status: &volumesnapshotv1.VolumeSnapshotStatus{
+			RestoreSize: &size,
+		},
+	}
+
+	preProvisionedSnapshot := readySnapshot.DeepCopy()
+	preProvisionedSnapshot.Spec.Source = volumesnapshotv1.VolumeSnapshotSource{
+		VolumeSnapshotContentName: new("content"),
+	}
+
+	tests := []struct {
+		desc                       string
+		opts                       RestoreSnapshotOptions
+		snapshot                   *volumesnapshotv1.VolumeSnapshot
+		simulateWaitForSnapshotErr bool
+		simulateQuerySourceErr     bool
+		simulateCreateErr          bool
+		expectMissingClassErr      bool
+	}{
+		{
+			desc: "successful restore with default options",
+		},
+		{
+			desc: "successful restore with custom options",
+			opts: RestoreSnapshotOptions{
+				DestStorageClassName: "custom-class",
+				DestPvcNamePrefix:    "custom-prefix",
+			},
+		},
+		{
+			desc:     "successful restore of a pre-provisioned snapshot with a storage class",
+			opts:     RestoreSnapshotOptions{DestStorageClassName: "custom-class"},
+			snapshot: preProvisionedSnapshot,
+		},
+		{
+			desc:                  "pre-provisioned snapshot without a storage class",
+			snapshot:              preProvisionedSnapshot,
+			expectMissingClassErr: true,
+		},
+		{
+			desc:                       "wait for snapshot error",
+			simulateWaitForSnapshotErr: true,
+		},
+		{
+			desc:                   "error while querying source PVC",
+			simulateQuerySourceErr: true,
+		},
+		{
+			desc:              "creation error",
+			simulateCreateErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := newMockProvider(t)
+			ctx := th.NewTestContext()
+			snapshot := th.ValOrDefault(tt.snapshot, readySnapshot)
+			createdPVC := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "created-pvc", Namespace: namespace}}
+
+			func() {
+				p.esClient.EXPECT().WaitForReadySnapshot(mock.Anything, namespace, snapshotName, externalsnapshotter.WaitForReadySnapshotOpts{MaxWaitTime: tt.opts.WaitForSnapshotTimeout}).
+					RunAndReturn(func(calledCtx *contexts.Context, namespace, name string, opts externalsnapshotter.WaitForReadySnapshotOpts) (*volumesnapshotv1.VolumeSnapshot, error) {
+						assert.True(t, calledCtx.IsChildOf(ctx))
+						return th.ErrOr1Val(snapshot, tt.simulateWaitForSnapshotErr)
+					})
+				if tt.simulateWaitForSnapshotErr || tt.expectMissingClassErr {
+					return
+				}
+
+				storageClassName := tt.opts.DestStorageClassName
+				if storageClassName == "" {
+					p.coreClient.EXPECT().GetPVC(mock.Anything, namespace, sourcePVCName).
+						Return(th.ErrOr1Val(&corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: new("standard")}}, tt.simulateQuerySourceErr))
+					storageClassName = "standard"
+				}
+				if tt.simulateQuerySourceErr {
+					return
+				}
+
+				pvcNamePrefix := snapshotName
+				if tt.opts.DestPvcNamePrefix != "" {
+					pvcNamePrefix = tt.opts.DestPvcNamePrefix
+				}
+
+				p.coreClient.EXPECT().CreatePVC(mock.Anything, namespace, pvcNamePrefix, size, core.CreatePVCOptions{
+					GenerateName:     true,
+					StorageClassName: storageClassName,
+					Source: &corev1.TypedObjectReference{
+						APIGroup: new(volumesnapshotv1.SchemeGroupVersion.Group),
+						Kind:     externalsnapshotter.VolumeSnapshotKind,
+						Name:     snapshotName,
+					},
+				}).Return(th.ErrOr1Val(createdPVC, tt.simulateCreateErr))
+			}()
+
+			pvc, err := p.RestoreSnapshot(ctx, namespace, snapshotName, tt.opts)
+			if th.ErrExpected(
+				tt.simulateWaitForSnapshotErr,
+				tt.simulateQuerySourceErr,
+				tt.simulateCreateErr,
+				tt.expectMissingClassErr,
+			) {
+				assert.Error(t, err)
+				assert.Nil(t, pvc)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, createdPVC, pvc)
+		})
+	}
+}
@@ -121,6 +121,36 @@ type VolumeSnapshotRecovery struct {
 	// forward to that wall-clock instant (recoveryTarget.targetTime); when empty it stops at the
 	// backup's consistency point (recoveryTarget.targetImmediate).
 	RecoveryTargetTime string
+	// RecoveryTargetLSN, RecoveryTargetXID and RecoveryTargetName recover the clone to a write-ahead log
+	// location, a transaction or a named restore point (recoveryTarget.targetLSN, targetXID and targetName)
+	// instead. At most one target, including RecoveryTargetTime, may be set.
+	RecoveryTargetLSN  string
+	RecoveryTargetXID  string
+	RecoveryTargetName string
+	// RecoveryTargetExclusive stops recovery just before the target instead of just after it
+	// (recoveryTarget.exclusive). Postgres ignores it for named restore points.
+	RecoveryTargetExclusive bool
+}
+
+// recoveryTarget builds the bootstrap recovery target. When no target is set, recovery stops at the
+// backup's consistency point.
+func (vsr *VolumeSnapshotRecovery) recoveryTarget() *apiv1.RecoveryTarget {
+	recoveryTarget := &apiv1.RecoveryTarget{
+		TargetTime: vsr.RecoveryTargetTime,
+		TargetLSN:  vsr.RecoveryTargetLSN,
+		TargetXID:  vsr.RecoveryTargetXID,
+		TargetName: vsr.RecoveryTargetName,
+	}
+
+	if *recoveryTarget == (apiv1.RecoveryTarget{}) {
+		return &apiv1.RecoveryTarget{TargetImmediate: new(true)}
+	}
+
+	if vsr.RecoveryTargetExclusive {
+		recoveryTarget.Exclusive = new(true)
+	}
+
+	return recoveryTarget
 }
 
 // This doesn't need to support every option - just the ones that may be relavent to backups.
@@ -171,10 +201,6 @@ func (cnpgc *Client) CreateCluster(ctx *contexts.Context, namespace, clusterName
 	opts.SetName(&cluster.ObjectMeta, clusterName)
 
 	if opts.VolumeSnapshotRecovery != nil {
-		recoveryTarget := &apiv1.RecoveryTarget{TargetImmediate: new(true)}
-		if opts.VolumeSnapshotRecovery.RecoveryTargetTime != "" {
-			recoveryTarget = &apiv1.RecoveryTarget{TargetTime: opts.VolumeSnapshotRecovery.RecoveryTargetTime}
-		}
 		recovery := &apiv1.BootstrapRecovery{
 			Source: opts.VolumeSnapshotRecovery.WALSource.Name,
 			VolumeSnapshots: &apiv1.DataSource{
@@ -184,7 +210,7 @@ func (cnpgc *Client) CreateCluster(ctx *contexts.Context, namespace, clusterName
 					Name:     opts.VolumeSnapshotRecovery.DataSnapshotName,
 				},
 			},
-			RecoveryTarget: recoveryTarget,
+			RecoveryTarget: opts.VolumeSnapshotRecovery.recoveryTarget(),
 			Database:       opts.DatabaseName,
 			Owner:          opts.OwnerName,
 		}
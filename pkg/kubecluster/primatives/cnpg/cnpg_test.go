@@ -448,6 +448,105 @@ func TestCreateCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "cluster with volume snapshot recovery to an exclusive LSN target",
+			opts: CreateClusterOptions{
+				VolumeSnapshotRecovery: &VolumeSnapshotRecovery{
+					DataSnapshotName: "snap-data",
+					WALSource: apiv1.ExternalCluster{
+						Name: "source-server",
+						PluginConfiguration: &apiv1.PluginConfiguration{
+							Name:       BarmanCloudPluginName,
+							Parameters: map[string]string{"barmanObjectName": "store", "serverName": "source-server"},
+						},
+					},
+					RecoveryTargetLSN:       "0/3000060",
+					RecoveryTargetExclusive: true,
+				},
+				DatabaseName: "testdb",
+				OwnerName:    "testowner",
+			},
+			expected: &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Spec: apiv1.ClusterSpec{
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						Recovery: &apiv1.BootstrapRecovery{
+							Source: "source-server",
+							VolumeSnapshots: &apiv1.DataSource{
+								Storage: corev1.TypedLocalObjectReference{
+									APIGroup: new("snapshot.storage.k8s.io"),
+									Kind:     "VolumeSnapshot",
+									Name:     "snap-data",
+								},
+							},
+							RecoveryTarget: &apiv1.RecoveryTarget{TargetLSN: "0/3000060", Exclusive: new(true)},
+							Database:       "testdb",
+							Owner:          "testowner",
+						},
+					},
+					ExternalClusters: []apiv1.ExternalCluster{
+						{
+							Name: "source-server",
+							PluginConfiguration: &apiv1.PluginConfiguration{
+								Name:       BarmanCloudPluginName,
+								Parameters: map[string]string{"barmanObjectName": "store", "serverName": "source-server"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "cluster with volume snapshot recovery to a named restore point",
+			opts: CreateClusterOptions{
+				VolumeSnapshotRecovery: &VolumeSnapshotRecovery{
+					DataSnapshotName: "snap-data",
+					WALSource: apiv1.ExternalCluster{
+						Name: "source-server",
+						PluginConfiguration: &apiv1.PluginConfiguration{
+							Name:       BarmanCloudPluginName,
+							Parameters: map[string]string{"barmanObjectName": "store", "serverName": "source-server"},
+						},
+					},
+					RecoveryTargetName: "before-migration",
+				},
+				DatabaseName: "testdb",
+				OwnerName:    "testowner",
+			},
+			expected: &apiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Spec: apiv1.ClusterSpec{
+					Bootstrap: &apiv1.BootstrapConfiguration{
+						Recovery: &apiv1.BootstrapRecovery{
+							Source: "source-server",
+							VolumeSnapshots: &apiv1.DataSource{
+								Storage: corev1.TypedLocalObjectReference{
+									APIGroup: new("snapshot.storage.k8s.io"),
+									Kind:     "VolumeSnapshot",
+									Name:     "snap-data",
+								},
+							},
+							RecoveryTarget: &apiv1.RecoveryTarget{TargetName: "before-migration"},
+							Database:       "testdb",
+							Owner:          "testowner",
+						},
+					},
+					ExternalClusters: []apiv1.ExternalCluster{
+						{
+							Name: "source-server",
+							PluginConfiguration: &apiv1.PluginConfiguration{
+								Name:       BarmanCloudPluginName,
+								Parameters: map[string]string{"barmanObjectName": "store", "serverName": "source-server"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			desc: "cluster with initdb",
 			opts: CreateClusterOptions{
@@ -37,6 +37,8 @@ type ClientInterface interface {
 	// Endpoints
 	GetEndpoint(ctx *contexts.Context, namespace, name string) (*discoveryv1.EndpointSlice, error)
 	WaitForReadyEndpoint(ctx *contexts.Context, namespace, name string, opts WaitForReadyEndpointOpts) (*discoveryv1.EndpointSlice, error)
+	// Secrets
+	GetSecret(ctx *contexts.Context, namespace, name string) (*corev1.Secret, error)
 }
 
 type Client struct {
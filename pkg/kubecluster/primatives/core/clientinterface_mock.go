@@ -663,6 +663,66 @@ func (_c *MockClientInterface_GetPVC_Call) RunAndReturn(run func(*contexts.Conte
 	return _c
 }
 
+// GetSecret provides a mock function with given fields: ctx, namespace, name
+func (_m *MockClientInterface) GetSecret(ctx *contexts.Context, namespace string, name string) (*v1.Secret, error) {
+	ret := _m.Called(ctx, namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecret")
+	}
+
+	var r0 *v1.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string) (*v1.Secret, error)); ok {
+		return rf(ctx, namespace, name)
+	}
+	if rf, ok := ret.Get(0).(func(*contexts.Context, string, string) *v1.Secret); ok {
+		r0 = rf(ctx, namespace, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*contexts.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClientInterface_GetSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecret'
+type MockClientInterface_GetSecret_Call struct {
+	*mock.Call
+}
+
+// GetSecret is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - namespace string
+//   - name string
+func (_e *MockClientInterface_Expecter) GetSecret(ctx interface{}, namespace interface{}, name interface{}) *MockClientInterface_GetSecret_Call {
+	return &MockClientInterface_GetSecret_Call{Call: _e.mock.On("GetSecret", ctx, namespace, name)}
+}
+
+func (_c *MockClientInterface_GetSecret_Call) Run(run func(ctx *contexts.Context, namespace string, name string)) *MockClientInterface_GetSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClientInterface_GetSecret_Call) Return(_a0 *v1.Secret, _a1 error) *MockClientInterface_GetSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClientInterface_GetSecret_Call) RunAndReturn(run func(*contexts.Context, string, string) (*v1.Secret, error)) *MockClientInterface_GetSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListPVCs provides a mock function with given fields: ctx, namespace, opts
 func (_m *MockClientInterface) ListPVCs(ctx *contexts.Context, namespace string, opts ListPVCsOptions) ([]v1.PersistentVolumeClaim, error) {
 	ret := _m.Called(ctx, namespace, opts)
@@ -0,0 +1,21 @@
+package core
+
+import (
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (c *Client) GetSecret(ctx *contexts.Context, namespace, name string) (*corev1.Secret, error) {
+	ctx.Log.With("name", name).Info("Getting secret")
+
+	secret, err := c.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to get secret %q", helpers.FullNameStr(namespace, name))
+	}
+
+	// The secret's data is deliberately not logged.
+	return secret, nil
+}
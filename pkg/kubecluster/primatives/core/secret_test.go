@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetSecret(t *testing.T) {
+	namespace := "test-ns"
+	secretName := "test-secret"
+
+	tests := []struct {
+		desc          string
+		initialSecret *corev1.Secret
+		expectedErr   bool
+	}{
+		{
+			desc: "secret exists",
+			initialSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"key": []byte("value"),
+				},
+			},
+		},
+		{
+			desc:        "secret does not exist",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c, mockK8s := createTestClient()
+			ctx := th.NewTestContext()
+
+			if tt.initialSecret != nil {
+				_, err := mockK8s.CoreV1().Secrets(namespace).Create(ctx, tt.initialSecret, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			secret, err := c.GetSecret(ctx, namespace, secretName)
+			if tt.expectedErr {
+				require.Error(t, err)
+				require.Nil(t, secret)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.initialSecret, secret)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package mysql
+
+import (
+	"net"
+	"slices"
+	"strconv"
+
+	"github.com/gravitational/trace"
+)
+
+const DefaultPort = 3306
+
+// The client reads the password from this variable, which keeps it out of the process list.
+const passwordVarName = "MYSQL_PWD"
+
+// SSLMode selects whether the connection to the server is encrypted, and whether the server's certificate is
+// verified. The modes are named after the MySQL client's --ssl-mode values, and are mapped onto the MariaDB
+// client's options. The MariaDB client can't verify the CA without also verifying the host name, so there is
+// no VERIFY_CA mode.
+type SSLMode string
+
+const (
+	// SSLModeDisabled connects without encryption.
+	SSLModeDisabled SSLMode = "DISABLED"
+	// SSLModeRequired requires an encrypted connection, without verifying the server's certificate.
+	SSLModeRequired SSLMode = "REQUIRED"
+	// SSLModeVerifyIdentity requires an encrypted connection, and verifies that the server's certificate is
+	// signed by the CA and issued for the host.
+	SSLModeVerifyIdentity SSLMode = "VERIFY_IDENTITY"
+)
+
+var sslModes = []SSLMode{SSLModeDisabled, SSLModeRequired, SSLModeVerifyIdentity}
+
+// Credentials locate a MySQL or MariaDB server, and authenticate with it.
+type Credentials struct {
+	Host     string
+	Port     int // Optional. Defaults to 3306.
+	User     string
+	Password string
+	SSLMode  SSLMode // Optional. Defaults to the client's default.
+	// SSLCAFilePath optionally points to the CA certificate that the server's certificate is verified against,
+	// rather than the system's CA certificates.
+	SSLCAFilePath string
+}
+
+// Validate checks the credentials' fields, without contacting the server.
+func (c Credentials) Validate() error {
+	if c.Host == "" {
+		return trace.BadParameter("host is required")
+	}
+
+	if c.Port < 0 || c.Port > 65535 {
+		return trace.BadParameter("port %d is out of range", c.Port)
+	}
+
+	if c.User == "" {
+		return trace.BadParameter("user is required")
+	}
+
+	if c.SSLMode != "" && !slices.Contains(sslModes, c.SSLMode) {
+		return trace.BadParameter("invalid SSL mode %q", c.SSLMode)
+	}
+
+	if c.SSLMode == SSLModeDisabled && c.SSLCAFilePath != "" {
+		return trace.BadParameter("a CA certificate cannot be used with SSL mode %q", c.SSLMode)
+	}
+
+	return nil
+}
+
+func (c Credentials) GetPort() int {
+	if c.Port != 0 {
+		return c.Port
+	}
+
+	return DefaultPort
+}
+
+func (c Credentials) Address() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.GetPort()))
+}
+
+// args returns the args that connect a client to the server. Option files are not read, so that the
+// configuration of the host that the client runs on can't change what is connected to.
+func (c Credentials) args() []string {
+	args := []string{
+		"--no-defaults", // Must be the first arg
+		"--protocol=TCP",
+		"--host=" + c.Host,
+		"--port=" + strconv.Itoa(c.GetPort()),
+		"--user=" + c.User,
+	}
+
+	switch c.SSLMode {
+	case SSLModeDisabled:
+		args = append(args, "--skip-ssl")
+	case SSLModeRequired:
+		args = append(args, "--ssl", "--skip-ssl-verify-server-cert")
+	case SSLModeVerifyIdentity:
+		args = append(args, "--ssl", "--ssl-verify-server-cert")
+	}
+
+	if c.SSLCAFilePath != "" {
+		args = append(args, "--ssl-ca="+c.SSLCAFilePath)
+	}
+
+	return args
+}
+
+func (c Credentials) env() []string {
+	if c.Password == "" {
+		return []string{}
+	}
+
+	return []string{passwordVarName + "=" + c.Password}
+}
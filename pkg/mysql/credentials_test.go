@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialsValidate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		credentials Credentials
+		wantErr     bool
+	}{
+		{
+			desc:        "valid",
+			credentials: Credentials{Host: "db.example.com", User: "root", Password: "password"},
+		},
+		{
+			desc:        "no password",
+			credentials: Credentials{Host: "db.example.com", Port: 3307, User: "root"},
+		},
+		{
+			desc:        "no host",
+			credentials: Credentials{User: "root"},
+			wantErr:     true,
+		},
+		{
+			desc:        "negative port",
+			credentials: Credentials{Host: "db.example.com", Port: -1, User: "root"},
+			wantErr:     true,
+		},
+		{
+			desc:        "port out of range",
+			credentials: Credentials{Host: "db.example.com", Port: 65536, User: "root"},
+			wantErr:     true,
+		},
+		{
+			desc:        "no user",
+			credentials: Credentials{Host: "db.example.com"},
+			wantErr:     true,
+		},
+		{
+			desc:        "verified SSL",
+			credentials: Credentials{Host: "db.example.com", User: "root", SSLMode: SSLModeVerifyIdentity, SSLCAFilePath: "/ca.crt"},
+		},
+		{
+			desc:        "invalid SSL mode",
+			credentials: Credentials{Host: "db.example.com", User: "root", SSLMode: "VERIFY_CA"},
+			wantErr:     true,
+		},
+		{
+			desc:        "CA with SSL disabled",
+			credentials: Credentials{Host: "db.example.com", User: "root", SSLMode: SSLModeDisabled, SSLCAFilePath: "/ca.crt"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.credentials.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCredentialsAddress(t *testing.T) {
+	assert.Equal(t, "db.example.com:3306", Credentials{Host: "db.example.com"}.Address())
+	assert.Equal(t, "db.example.com:3307", Credentials{Host: "db.example.com", Port: 3307}.Address())
+	assert.Equal(t, "[::1]:3306", Credentials{Host: "::1"}.Address())
+}
+
+func TestCredentialsArgs(t *testing.T) {
+	credentials := Credentials{Host: "db.example.com", User: "root", Password: "password"}
+	assert.Equal(t, []string{"--no-defaults", "--protocol=TCP", "--host=db.example.com", "--port=3306", "--user=root"}, credentials.args())
+	assert.NotContains(t, credentials.args(), "password")
+
+	sslArgs := map[SSLMode][]string{
+		SSLModeDisabled:       {"--skip-ssl"},
+		SSLModeRequired:       {"--ssl", "--skip-ssl-verify-server-cert"},
+		SSLModeVerifyIdentity: {"--ssl", "--ssl-verify-server-cert"},
+	}
+	for sslMode, expectedArgs := range sslArgs {
+		credentials := Credentials{Host: "db.example.com", User: "root", SSLMode: sslMode}
+		assert.Equal(t, expectedArgs, credentials.args()[5:], "SSL mode %q", sslMode)
+	}
+
+	credentials = Credentials{Host: "db.example.com", User: "root", SSLMode: SSLModeVerifyIdentity, SSLCAFilePath: "/ca.crt"}
+	assert.Equal(t, []string{"--ssl", "--ssl-verify-server-cert", "--ssl-ca=/ca.crt"}, credentials.args()[5:])
+}
+
+func TestCredentialsEnv(t *testing.T) {
+	assert.Equal(t, []string{"MYSQL_PWD=password"}, Credentials{Password: "password"}.env())
+	assert.Empty(t, Credentials{}.env())
+}
@@ -0,0 +1,205 @@
+package mysql
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// Consistency selects how a dump is kept consistent while the server is written to.
+type Consistency string
+
+const (
+	// ConsistencySingleTransaction dumps every table from a single transaction, without locking them. Only
+	// transactional tables (such as InnoDB tables) are consistent with each other.
+	ConsistencySingleTransaction Consistency = "single-transaction"
+	// ConsistencyLockAllTables locks every table of the server while the dump is taken, which blocks writes
+	// to the server until the dump completes. Use it when the databases hold non-transactional (such as
+	// MyISAM) tables.
+	ConsistencyLockAllTables Consistency = "lock-all-tables"
+	// ConsistencyNone neither locks tables nor uses a transaction, so the dump may be inconsistent if the
+	// server is written to while it is taken.
+	ConsistencyNone Consistency = "none"
+)
+
+var consistencies = []Consistency{ConsistencySingleTransaction, ConsistencyLockAllTables, ConsistencyNone}
+
+// The databases that hold the server's own state. They are never dumped, as restoring them would replace the
+// users, grants and statistics of the server that the dump is restored to.
+var systemDatabases = []string{"information_schema", "mysql", "performance_schema", "sys"}
+
+const listDatabasesQuery = "SHOW DATABASES"
+
+// The client's batch output escapes these characters in values, so that each row is on its own line.
+var batchValueUnescaper = strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n", `\0`, "\x00")
+
+type DumpOptions struct {
+	// Consistency selects how the dump is kept consistent. Empty means ConsistencySingleTransaction.
+	Consistency Consistency
+	// RecordBinlogPosition records the server's binary log position in the dump, as a comment. This requires
+	// the RELOAD privilege and the binary log to be enabled, and briefly locks every table of the server.
+	RecordBinlogPosition bool
+	// IncludeDatabases dumps only these databases. Empty dumps every database other than the system ones.
+	IncludeDatabases []string
+	// ExcludeDatabases leaves these databases out of the dump. It can't be combined with IncludeDatabases.
+	ExcludeDatabases []string
+	// ExcludeTables leaves these tables out of the dump. Each one is named as "database.table".
+	ExcludeTables []string
+}
+
+// Validate checks the options before anything is dumped.
+func (opts DumpOptions) Validate() error {
+	if opts.Consistency != "" && !slices.Contains(consistencies, opts.Consistency) {
+		return trace.BadParameter("invalid consistency %q", opts.Consistency)
+	}
+
+	if len(opts.IncludeDatabases) > 0 && len(opts.ExcludeDatabases) > 0 {
+		return trace.BadParameter("databases can't be both included and excluded")
+	}
+
+	for _, database := range opts.IncludeDatabases {
+		if slices.Contains(systemDatabases, database) {
+			return trace.BadParameter("system database %q can't be dumped", database)
+		}
+	}
+
+	for _, table := range opts.ExcludeTables {
+		database, name, ok := strings.Cut(table, ".")
+		if !ok || database == "" || name == "" {
+			return trace.BadParameter("excluded table %q must be named as \"database.table\"", table)
+		}
+	}
+
+	return nil
+}
+
+func (opts DumpOptions) args() []string {
+	// Routines, events and triggers are dumped so that the databases can be fully restored from the dump.
+	args := []string{"--routines", "--events", "--triggers", "--hex-blob", "--quick", "--add-drop-database"}
+
+	switch opts.Consistency {
+	case ConsistencyLockAllTables:
+		args = append(args, "--lock-all-tables")
+	case ConsistencyNone:
+		args = append(args, "--skip-lock-tables")
+	default:
+		args = append(args, "--single-transaction")
+	}
+
+	if opts.RecordBinlogPosition {
+		args = append(args, "--master-data=2")
+	}
+
+	for _, table := range opts.ExcludeTables {
+		args = append(args, "--ignore-table="+table)
+	}
+
+	return args
+}
+
+// selectDatabases returns the databases that are dumped, out of the ones that the server holds.
+func (opts DumpOptions) selectDatabases(databases []string) ([]string, error) {
+	if len(opts.IncludeDatabases) > 0 {
+		for _, database := range opts.IncludeDatabases {
+			if !slices.Contains(databases, database) {
+				return nil, trace.NotFound("included database %q does not exist", database)
+			}
+		}
+
+		return opts.IncludeDatabases, nil
+	}
+
+	selected := slices.DeleteFunc(slices.Clone(databases), func(database string) bool {
+		return slices.Contains(systemDatabases, database) || slices.Contains(opts.ExcludeDatabases, database)
+	})
+	if len(selected) == 0 {
+		return nil, trace.NotFound("no databases are selected")
+	}
+
+	return selected, nil
+}
+
+func (lr *LocalRuntime) Dump(ctx *contexts.Context, credentials Credentials, outputFilePath string, opts DumpOptions) (err error) {
+	ctx.Log.With("serverAddress", credentials.Address(), "username", credentials.User).Info("Dumping databases", "outputFilePath", outputFilePath, "consistency", opts.Consistency)
+	defer ctx.Log.Info("Database dump complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if err := credentials.Validate(); err != nil {
+		return trace.Wrap(err, "invalid credentials")
+	}
+
+	if err := opts.Validate(); err != nil {
+		return trace.Wrap(err, "invalid dump options")
+	}
+
+	databases, err := lr.listDatabases(ctx.Child(), credentials)
+	if err != nil {
+		return trace.Wrap(err, "failed to list databases")
+	}
+
+	selected, err := opts.selectDatabases(databases)
+	if err != nil {
+		return trace.Wrap(err, "failed to select databases")
+	}
+	ctx.Log.Debug("Selected databases", "databases", selected)
+
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	outputFile, err := os.OpenFile(outputFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return trace.Wrap(err, "failed to open SQL dump output file %q for writing", outputFilePath)
+	}
+	defer func() {
+		err = trace.NewAggregate(err, trace.Wrap(outputFile.Close(), "failed to close output file %q", outputFilePath))
+	}()
+	outputFileWriter := bufio.NewWriter(outputFile) // This is used to avoid writing to the file one (potentially small) line at a time.
+
+	args := slices.Concat(opts.args(), []string{"--databases"}, selected)
+	cmd := command(commandCtx, dumpCommandName, credentials, args...)
+	cmd.Stdout = outputFileWriter
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := lr.runCommand(cmd); err != nil {
+		return trace.Wrap(err, "process %q failed: %s", dumpCommandName, stderr.String())
+	}
+
+	return trace.Wrap(outputFileWriter.Flush(), "failed to flush all output data to output file at %q", outputFilePath)
+}
+
+// listDatabases returns the names of the databases that the server holds.
+func (lr *LocalRuntime) listDatabases(ctx *contexts.Context, credentials Credentials) ([]string, error) {
+	cmd := command(ctx, clientCommandName, credentials, "--batch", "--skip-column-names", "--execute="+listDatabasesQuery)
+
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := lr.runCommand(cmd); err != nil {
+		return nil, trace.Wrap(err, "process %q failed: %s", clientCommandName, stderr.String())
+	}
+
+	return parseDatabaseNames(stdout.String()), nil
+}
+
+// parseDatabaseNames splits the client's batch output into the names of the databases, one per line. Names can
+// hold spaces, and the tabs, newlines and backslashes in them are escaped.
+func parseDatabaseNames(output string) []string {
+	databases := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+
+		databases = append(databases, batchValueUnescaper.Replace(line))
+	}
+
+	return databases
+}
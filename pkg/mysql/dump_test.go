@@ -0,0 +1,276 @@
+package mysql
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpOptionsValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    DumpOptions
+		wantErr bool
+	}{
+		{
+			desc: "zero value",
+		},
+		{
+			desc: "all options",
+			opts: DumpOptions{
+				Consistency:          ConsistencyLockAllTables,
+				RecordBinlogPosition: true,
+				IncludeDatabases:     []string{"wiki"},
+				ExcludeTables:        []string{"wiki.cache"},
+			},
+		},
+		{
+			desc:    "invalid consistency",
+			opts:    DumpOptions{Consistency: "snapshot"},
+			wantErr: true,
+		},
+		{
+			desc:    "included and excluded databases",
+			opts:    DumpOptions{IncludeDatabases: []string{"wiki"}, ExcludeDatabases: []string{"photos"}},
+			wantErr: true,
+		},
+		{
+			desc:    "included system database",
+			opts:    DumpOptions{IncludeDatabases: []string{"mysql"}},
+			wantErr: true,
+		},
+		{
+			desc:    "excluded table without a database",
+			opts:    DumpOptions{ExcludeTables: []string{"cache"}},
+			wantErr: true,
+		},
+		{
+			desc:    "excluded table without a name",
+			opts:    DumpOptions{ExcludeTables: []string{"wiki."}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDumpOptionsArgs(t *testing.T) {
+	baseArgs := []string{"--routines", "--events", "--triggers", "--hex-blob", "--quick", "--add-drop-database"}
+
+	assert.Equal(t, append(slices.Clone(baseArgs), "--single-transaction"), DumpOptions{}.args())
+	assert.Equal(t, append(slices.Clone(baseArgs), "--skip-lock-tables"), DumpOptions{Consistency: ConsistencyNone}.args())
+	assert.Equal(t,
+		append(slices.Clone(baseArgs), "--lock-all-tables", "--master-data=2", "--ignore-table=wiki.cache", "--ignore-table=wiki.sessions"),
+		DumpOptions{Consistency: ConsistencyLockAllTables, RecordBinlogPosition: true, ExcludeTables: []string{"wiki.cache", "wiki.sessions"}}.args(),
+	)
+}
+
+func TestDumpOptionsSelectDatabases(t *testing.T) {
+	databases := []string{"information_schema", "mysql", "performance_schema", "photos", "sys", "wiki"}
+
+	tests := []struct {
+		desc     string
+		opts     DumpOptions
+		expected []string
+		wantErr  bool
+	}{
+		{
+			desc:     "all databases",
+			expected: []string{"photos", "wiki"},
+		},
+		{
+			desc:     "included databases",
+			opts:     DumpOptions{IncludeDatabases: []string{"wiki"}},
+			expected: []string{"wiki"},
+		},
+		{
+			desc:     "excluded databases",
+			opts:     DumpOptions{ExcludeDatabases: []string{"photos", "missing"}},
+			expected: []string{"wiki"},
+		},
+		{
+			desc:    "missing included database",
+			opts:    DumpOptions{IncludeDatabases: []string{"missing"}},
+			wantErr: true,
+		},
+		{
+			desc:    "every database excluded",
+			opts:    DumpOptions{ExcludeDatabases: []string{"photos", "wiki"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			selected, err := tt.opts.selectDatabases(databases)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+}
+
+func TestParseDatabaseNames(t *testing.T) {
+	tests := []struct {
+		desc     string
+		output   string
+		expected []string
+	}{
+		{
+			desc:     "no databases",
+			expected: []string{},
+		},
+		{
+			desc:     "one database per line",
+			output:   "photos\nwiki\n",
+			expected: []string{"photos", "wiki"},
+		},
+		{
+			desc:     "names with spaces",
+			output:   "my photos\nthe wiki\n",
+			expected: []string{"my photos", "the wiki"},
+		},
+		{
+			desc:     "escaped characters",
+			output:   `tab\tname` + "\n" + `new\nline` + "\n" + `back\\slash` + "\n",
+			expected: []string{"tab\tname", "new\nline", `back\slash`},
+		},
+		{
+			desc:     "without a trailing newline",
+			output:   "photos\nwiki",
+			expected: []string{"photos", "wiki"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseDatabaseNames(tt.output))
+		})
+	}
+}
+
+func TestDump(t *testing.T) {
+	credentials := Credentials{Host: "db.example.com", User: "root", Password: "password"}
+
+	tests := []struct {
+		desc                 string
+		credentials          Credentials
+		opts                 DumpOptions
+		simulateListError    bool
+		simulateDumpError    bool
+		expectedDatabaseArgs []string
+		wantErr              bool
+	}{
+		{
+			desc:                 "dumps the selected databases",
+			credentials:          credentials,
+			expectedDatabaseArgs: []string{"--databases", "my photos", "photos", "wiki"},
+		},
+		{
+			desc:                 "dumps the included databases",
+			credentials:          credentials,
+			opts:                 DumpOptions{IncludeDatabases: []string{"wiki"}},
+			expectedDatabaseArgs: []string{"--databases", "wiki"},
+		},
+		{
+			desc:        "invalid credentials",
+			credentials: Credentials{Host: "db.example.com"},
+			wantErr:     true,
+		},
+		{
+			desc:        "invalid options",
+			credentials: credentials,
+			opts:        DumpOptions{Consistency: "snapshot"},
+			wantErr:     true,
+		},
+		{
+			desc:              "fails to list databases",
+			credentials:       credentials,
+			simulateListError: true,
+			wantErr:           true,
+		},
+		{
+			desc:        "no databases selected",
+			credentials: credentials,
+			opts:        DumpOptions{ExcludeDatabases: []string{"my photos", "photos", "wiki"}},
+			wantErr:     true,
+		},
+		{
+			desc:                 "dump fails",
+			credentials:          credentials,
+			simulateDumpError:    true,
+			expectedDatabaseArgs: []string{"--databases", "my photos", "photos", "wiki"},
+			wantErr:              true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			outputFilePath := filepath.Join(t.TempDir(), "dump.sql")
+
+			var commands []*exec.Cmd
+			lr := &LocalRuntime{
+				runCommand: func(cmd *exec.Cmd) error {
+					commands = append(commands, cmd)
+					assert.Equal(t, []string{"MYSQL_PWD=password"}, cmd.Env)
+
+					switch filepath.Base(cmd.Path) {
+					case clientCommandName:
+						assert.Equal(t, append(tt.credentials.args(), "--batch", "--skip-column-names", "--execute=SHOW DATABASES"), cmd.Args[1:])
+						if tt.simulateListError {
+							_, err := io.WriteString(cmd.Stderr, "access denied")
+							require.NoError(t, err)
+							return assert.AnError
+						}
+
+						_, err := io.WriteString(cmd.Stdout, "information_schema\nmy photos\nmysql\nperformance_schema\nphotos\nsys\nwiki\n")
+						require.NoError(t, err)
+						return nil
+					case dumpCommandName:
+						assert.Equal(t, slices.Concat(tt.credentials.args(), tt.opts.args(), tt.expectedDatabaseArgs), cmd.Args[1:])
+						_, err := io.WriteString(cmd.Stdout, "some dump data")
+						require.NoError(t, err)
+						return th.ErrIfTrue(tt.simulateDumpError)
+					}
+
+					assert.Fail(t, "unexpected command", cmd.Path)
+					return nil
+				},
+			}
+
+			err := lr.Dump(th.NewTestContext(), tt.credentials, outputFilePath, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, commands, 2)
+
+			contents, err := os.ReadFile(outputFilePath)
+			require.NoError(t, err)
+			assert.Equal(t, "some dump data", string(contents))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package mysql
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+type RestoreOptions struct {
+	// Force carries on with the restore when a statement fails. Otherwise the restore stops at the first
+	// statement that fails.
+	Force bool
+}
+
+func (opts RestoreOptions) args() []string {
+	args := []string{"--batch"}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+
+	return args
+}
+
+// Restore replays a dump against the server. The dump drops and recreates each of the databases that it holds.
+func (lr *LocalRuntime) Restore(ctx *contexts.Context, credentials Credentials, inputFilePath string, opts RestoreOptions) (err error) {
+	ctx.Log.With("serverAddress", credentials.Address(), "username", credentials.User).Info("Restoring databases", "inputFilePath", inputFilePath)
+	defer ctx.Log.Info("Database restore complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if err := credentials.Validate(); err != nil {
+		return trace.Wrap(err, "invalid credentials")
+	}
+
+	inputFile, err := os.Open(inputFilePath)
+	if err != nil {
+		return trace.Wrap(err, "failed to open SQL dump file %q for reading", inputFilePath)
+	}
+	defer inputFile.Close()
+
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	cmd := command(commandCtx, clientCommandName, credentials, opts.args()...)
+	cmd.Stdin = inputFile
+
+	var output strings.Builder
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err = lr.runCommand(cmd)
+	return trace.Wrap(err, "process %q failed: %s", clientCommandName, output.String())
+}
@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreOptionsArgs(t *testing.T) {
+	assert.Equal(t, []string{"--batch"}, RestoreOptions{}.args())
+	assert.Equal(t, []string{"--batch", "--force"}, RestoreOptions{Force: true}.args())
+}
+
+func TestRestore(t *testing.T) {
+	credentials := Credentials{Host: "db.example.com", User: "root", Password: "password"}
+
+	tests := []struct {
+		desc                 string
+		credentials          Credentials
+		opts                 RestoreOptions
+		missingInputFile     bool
+		simulateRestoreError bool
+		wantErr              bool
+	}{
+		{
+			desc:        "restores the dump",
+			credentials: credentials,
+		},
+		{
+			desc:        "restores the dump with options",
+			credentials: credentials,
+			opts:        RestoreOptions{Force: true},
+		},
+		{
+			desc:        "invalid credentials",
+			credentials: Credentials{User: "root"},
+			wantErr:     true,
+		},
+		{
+			desc:             "missing input file",
+			credentials:      credentials,
+			missingInputFile: true,
+			wantErr:          true,
+		},
+		{
+			desc:                 "restore fails",
+			credentials:          credentials,
+			simulateRestoreError: true,
+			wantErr:              true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inputFilePath := filepath.Join(t.TempDir(), "dump.sql")
+			if !tt.missingInputFile {
+				require.NoError(t, os.WriteFile(inputFilePath, []byte("some dump data"), 0600))
+			}
+
+			ran := false
+			lr := &LocalRuntime{
+				runCommand: func(cmd *exec.Cmd) error {
+					ran = true
+					assert.Equal(t, clientCommandName, filepath.Base(cmd.Path))
+					assert.Equal(t, append(tt.credentials.args(), tt.opts.args()...), cmd.Args[1:])
+					assert.Equal(t, []string{"MYSQL_PWD=password"}, cmd.Env)
+
+					input, err := io.ReadAll(cmd.Stdin)
+					require.NoError(t, err)
+					assert.Equal(t, "some dump data", string(input))
+
+					return th.ErrIfTrue(tt.simulateRestoreError)
+				},
+			}
+
+			err := lr.Restore(th.NewTestContext(), tt.credentials, inputFilePath, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, ran)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// Depending on CLI tools is unfortunate but there are no viable golang replacements for these.
+const (
+	dumpCommandName   = "mariadb-dump"
+	clientCommandName = "mariadb"
+)
+
+// Represents a place (i.e. local or remote) where commands can run.
+type Runtime interface {
+	Dump(*contexts.Context, Credentials, string, DumpOptions) error
+	Restore(*contexts.Context, Credentials, string, RestoreOptions) error
+}
+
+type LocalRuntime struct {
+	// Used for tests to mock running commands
+	runCommand func(cmd *exec.Cmd) error
+}
+
+func NewLocalRuntime() *LocalRuntime {
+	return &LocalRuntime{
+		runCommand: (*exec.Cmd).Run,
+	}
+}
+
+// command returns a command that runs the named client tool against the server.
+func command(ctx context.Context, name string, credentials Credentials, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, append(credentials.args(), args...)...)
+	cmd.Env = credentials.env()
+	return cmd
+}
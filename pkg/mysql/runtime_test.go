@@ -0,0 +1,18 @@
+package mysql
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocalRuntime(t *testing.T) {
+	runtime := NewLocalRuntime()
+	require.NotNil(t, runtime)
+
+	// Verify that commands will be run directly when not under test
+	// For details, see https://github.com/stretchr/testify/issues/182#issuecomment-495359313
+	require.Equal(t, reflect.ValueOf((*exec.Cmd).Run), reflect.ValueOf(runtime.runCommand))
+}
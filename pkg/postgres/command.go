@@ -15,6 +15,7 @@ type cmdWrapper struct {
 	startCallback          func(*cmdWrapper) error
 	waitCallback           func(*cmdWrapper) error
 	combinedOutputCallback func(*cmdWrapper) ([]byte, error)
+	outputCallback         func(*cmdWrapper) ([]byte, error)
 }
 
 func NewCmdWrapper(cmd *exec.Cmd) *cmdWrapper {
@@ -48,3 +49,10 @@ func (cw *cmdWrapper) CombinedOutput() ([]byte, error) {
 	}
 	return cw.Cmd.CombinedOutput()
 }
+
+func (cw *cmdWrapper) Output() ([]byte, error) {
+	if cw.outputCallback != nil {
+		return cw.outputCallback(cw)
+	}
+	return cw.Cmd.Output()
+}
@@ -213,3 +213,49 @@ func TestCmdWrapperCombinedOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestCmdWrapperOutput(t *testing.T) {
+	tests := []struct {
+		desc           string
+		outputCallback func(*cmdWrapper) ([]byte, error)
+		expectedOutput []byte
+		errFunc        require.ErrorAssertionFunc
+	}{
+		{
+			desc: "callback is nil",
+		},
+		{
+			desc: "callback is not nil",
+			outputCallback: func(cw *cmdWrapper) ([]byte, error) {
+				return []byte("test output"), nil
+			},
+			expectedOutput: []byte("test output"),
+		},
+		{
+			desc: "callback returns error",
+			outputCallback: func(cw *cmdWrapper) ([]byte, error) {
+				return nil, exec.ErrNotFound
+			},
+			errFunc: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if tt.errFunc == nil {
+				tt.errFunc = require.NoError
+			}
+
+			cmd := instantReturnCommand()
+			wrapper := NewCmdWrapper(cmd)
+			wrapper.outputCallback = tt.outputCallback
+
+			output, err := wrapper.Output()
+
+			tt.errFunc(t, err)
+			if tt.expectedOutput != nil {
+				require.Equal(t, tt.expectedOutput, output)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ const (
 	SSLCertVarName     CredentialVariable = "PGSSLCERT"
 	SSLKeyVarName      CredentialVariable = "PGSSLKEY"
 	SSLRootCertVarName CredentialVariable = "PGSSLROOTCERT"
+	PasswordVarName    CredentialVariable = "PGPASSWORD"
 )
 
 type CredentialVariables map[CredentialVariable]string
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// DumpFormat is how a dump of all databases is written.
+type DumpFormat string
+
+const (
+	// DumpFormatPlain writes every database to a single SQL file with pg_dumpall. This is the default.
+	DumpFormatPlain DumpFormat = "plain"
+	// DumpFormatDirectory writes a directory holding the cluster's globals (roles and tablespaces) as a SQL
+	// file, and each database as a pg_dump directory format archive. Databases are dumped and restored one at
+	// a time, but the tables of each are dumped and restored in parallel. Template databases are not dumped.
+	DumpFormatDirectory DumpFormat = "directory"
+)
+
+// Layout of a directory format dump.
+const (
+	globalsFileName  = "globals.sql"
+	databasesDirName = "databases" // Holds an archive per database, named after the escaped database name.
+)
+
+const (
+	databaseDumpCommandName    = "pg_dump"
+	databaseRestoreCommandName = "pg_restore"
+)
+
+// Matches the databases that pg_dumpall dumps, less the template databases, which can't be dropped and
+// recreated by pg_restore.
+const listDatabasesQuery = "SELECT datname FROM pg_database WHERE datallowconn AND NOT datistemplate AND datname <> 'postgres' ORDER BY datname"
+
+// validateFormat checks the format, and that jobs are only used with a format that supports them.
+func validateFormat(format DumpFormat, jobs int) error {
+	switch format {
+	case "", DumpFormatPlain, DumpFormatDirectory:
+	default:
+		return trace.BadParameter("invalid format %q (must be %q or %q)", format, DumpFormatPlain, DumpFormatDirectory)
+	}
+
+	if jobs < 0 {
+		return trace.BadParameter("jobs must not be negative")
+	}
+
+	if jobs > 0 && format != DumpFormatDirectory {
+		return trace.BadParameter("jobs are only supported by the %q format", DumpFormatDirectory)
+	}
+
+	return nil
+}
+
+// databaseVariables returns the credential variables for connecting to the database, without changing the
+// credentials.
+func databaseVariables(credentials Credentials, database string) CredentialVariables {
+	return maps.Clone(credentials.GetVariables()).SetDatabaseName(database)
+}
+
+// jobsArgs returns the args that set the number of parallel jobs, if any.
+func jobsArgs(jobs int) []string {
+	if jobs == 0 {
+		return nil
+	}
+
+	return []string{"--jobs=" + strconv.Itoa(jobs)}
+}
+
+// dumpDirectory writes a directory format dump to the output path, replacing anything already there.
+func (lr *LocalRuntime) dumpDirectory(ctx *contexts.Context, credentials Credentials, outputDirPath string, opts DumpAllOptions) error {
+	// pg_dump requires that its output directory doesn't exist, so an earlier dump is removed first.
+	if err := os.RemoveAll(outputDirPath); err != nil {
+		return trace.Wrap(err, "failed to remove the existing dump at %q", outputDirPath)
+	}
+
+	databasesDirPath := filepath.Join(outputDirPath, databasesDirName)
+	if err := os.MkdirAll(databasesDirPath, 0700); err != nil {
+		return trace.Wrap(err, "failed to create dump directory %q", databasesDirPath)
+	}
+
+	if err := lr.dumpSQL(ctx, credentials, filepath.Join(outputDirPath, globalsFileName), opts, "--globals-only", "--clean", "--if-exists"); err != nil {
+		return trace.Wrap(err, "failed to dump globals")
+	}
+
+	databases, err := lr.listDatabases(ctx, credentials)
+	if err != nil {
+		return trace.Wrap(err, "failed to list databases")
+	}
+
+	databases, err = opts.Selection.selectDatabases(databases)
+	if err != nil {
+		return trace.Wrap(err, "failed to select databases")
+	}
+
+	for _, database := range databases {
+		outputDirPath := filepath.Join(databasesDirPath, url.PathEscape(database))
+		if err := lr.dumpDatabase(ctx.Child(), credentials, database, outputDirPath, opts.Jobs, opts.Selection.filterArgs(database)); err != nil {
+			return trace.Wrap(err, "failed to dump database %q", database)
+		}
+	}
+
+	return nil
+}
+
+// listDatabases returns the names of the databases to dump.
+func (lr *LocalRuntime) listDatabases(ctx *contexts.Context, credentials Credentials) ([]string, error) {
+	return lr.queryRows(ctx, credentials, "postgres", listDatabasesQuery)
+}
+
+// dumpDatabase writes a directory format archive of the database to the output path. The filter args select
+// the schemas and tables that are dumped.
+func (lr *LocalRuntime) dumpDatabase(ctx *contexts.Context, credentials Credentials, database, outputDirPath string, jobs int, filterArgs []string) (err error) {
+	ctx.Log.With("database", database).Info("Dumping database", "outputDirPath", outputDirPath)
+	defer ctx.Log.Info("Finished dumping database", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	args := slices.Concat([]string{"--format=directory", "--file=" + outputDirPath}, jobsArgs(jobs), filterArgs)
+	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, databaseDumpCommandName, args...))
+	cmd.Env = databaseVariables(credentials, database).ToEnvSlice()
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return trace.Wrap(err, "process %q failed: %s", databaseDumpCommandName, string(output))
+	}
+	return nil
+}
+
+// listArchivedDatabases returns the databases in a directory format dump, in the order of their names.
+func listArchivedDatabases(inputDirPath string) ([]string, error) {
+	databasesDirPath := filepath.Join(inputDirPath, databasesDirName)
+	entries, err := os.ReadDir(databasesDirPath)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to read dump directory %q", databasesDirPath)
+	}
+
+	var databases []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		database, err := url.PathUnescape(entry.Name())
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to get the database name of archive %q", entry.Name())
+		}
+		databases = append(databases, database)
+	}
+
+	return databases, nil
+}
+
+// restoreDirectory restores the globals and then the given databases of a directory format dump. The globals
+// are only restored along with the whole dump.
+func (lr *LocalRuntime) restoreDirectory(ctx *contexts.Context, credentials Credentials, inputDirPath string, databases []string, opts RestoreOptions) error {
+	if opts.Selection.IsZero() {
+		if err := lr.restoreSQL(ctx, credentials, filepath.Join(inputDirPath, globalsFileName), opts); err != nil {
+			return trace.Wrap(err, "failed to restore globals")
+		}
+	}
+
+	databasesDirPath := filepath.Join(inputDirPath, databasesDirName)
+	for _, database := range databases {
+		if err := lr.restoreDatabase(ctx.Child(), credentials, database, filepath.Join(databasesDirPath, url.PathEscape(database)), opts); err != nil {
+			return trace.Wrap(err, "failed to restore database %q", database)
+		}
+	}
+
+	return nil
+}
+
+// restoreDatabase restores the archive at the input path, which was dumped from the database. Without a
+// selection, the database is dropped and recreated. With a target database, the target is created and the
+// archive is restored into it. Otherwise the selected objects are dropped and recreated in the database.
+func (lr *LocalRuntime) restoreDatabase(ctx *contexts.Context, credentials Credentials, database, inputDirPath string, opts RestoreOptions) (err error) {
+	ctx.Log.With("database", database).Info("Restoring database", "inputDirPath", inputDirPath, "targetDatabase", opts.Selection.TargetDatabase)
+	defer ctx.Log.Info("Finished restoring database", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	// With --create, pg_restore connects to the given database only to drop and create the archived one.
+	args := []string{"--create", "--clean", "--if-exists", "--dbname=postgres"}
+	connectDatabase := "postgres"
+	switch {
+	case opts.Selection.TargetDatabase != "":
+		if err := lr.createDatabase(ctx, credentials, opts.Selection.TargetDatabase); err != nil {
+			return trace.Wrap(err, "failed to create target database %q", opts.Selection.TargetDatabase)
+		}
+		connectDatabase = opts.Selection.TargetDatabase
+		args = []string{"--dbname=" + connectDatabase}
+	case opts.Selection.selectsObjects():
+		connectDatabase = database
+		args = []string{"--clean", "--if-exists", "--dbname=" + connectDatabase}
+	case opts.SingleTransaction:
+		// pg_restore can't create the database within a transaction, so it is created from the archive first.
+		if err := lr.runPGRestore(ctx, credentials, connectDatabase, inputDirPath, slices.Concat(args, opts.pgRestoreArgs(false)), isDatabaseEntry); err != nil {
+			return trace.Wrap(err, "failed to create database %q", database)
+		}
+		connectDatabase = database
+		args = []string{"--dbname=" + connectDatabase}
+	}
+	args = slices.Concat(args, jobsArgs(opts.Jobs), opts.pgRestoreArgs(opts.SingleTransaction))
+
+	var selectEntry func(listEntry) bool
+	if opts.Selection.selectsObjects() {
+		selectEntry = opts.Selection.selectsEntry
+	}
+
+	return trace.Wrap(lr.runPGRestore(ctx, credentials, connectDatabase, inputDirPath, args, selectEntry))
+}
+
+// runPGRestore restores the archive at the input path with pg_restore, connected to the database. If
+// selectEntry is given, only the entries of the archive that it selects are restored.
+func (lr *LocalRuntime) runPGRestore(ctx *contexts.Context, credentials Credentials, database, inputDirPath string, args []string, selectEntry func(listEntry) bool) error {
+	if selectEntry != nil {
+		listFilePath, err := lr.writeRestoreList(ctx, inputDirPath, selectEntry)
+		if err != nil {
+			return trace.Wrap(err, "failed to select the objects to restore")
+		}
+		defer os.Remove(listFilePath)
+
+		args = append(args, "--use-list="+listFilePath)
+	}
+
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, databaseRestoreCommandName, append(args, inputDirPath)...))
+	cmd.Env = databaseVariables(credentials, database).ToEnvSlice()
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return trace.Wrap(err, "process %q failed: %s", databaseRestoreCommandName, string(output))
+	}
+	return nil
+}
+
+// writeRestoreList writes a pg_restore list of the selected entries of the archive at the input path to a
+// temporary file, and returns its path.
+func (lr *LocalRuntime) writeRestoreList(ctx *contexts.Context, inputDirPath string, selectEntry func(listEntry) bool) (string, error) {
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	// Listing an archive doesn't connect to a server.
+	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, databaseRestoreCommandName, "--list", inputDirPath))
+	list, err := cmd.Output()
+	if err != nil {
+		return "", trace.Wrap(err, "process %q failed", databaseRestoreCommandName)
+	}
+
+	listFile, err := os.CreateTemp("", "pg_restore-*.list")
+	if err != nil {
+		return "", trace.Wrap(err, "failed to create list file")
+	}
+	defer listFile.Close()
+
+	if _, err := listFile.WriteString(selectListEntries(string(list), selectEntry)); err != nil {
+		return "", trace.NewAggregate(trace.Wrap(err, "failed to write list file %q", listFile.Name()), os.Remove(listFile.Name()))
+	}
+
+	return listFile.Name(), nil
+}
@@ -0,0 +1,318 @@
+package postgres
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFormat(t *testing.T) {
+	tests := []struct {
+		desc        string
+		format      DumpFormat
+		jobs        int
+		expectedErr bool
+	}{
+		{desc: "default"},
+		{desc: "plain", format: DumpFormatPlain},
+		{desc: "directory", format: DumpFormatDirectory},
+		{desc: "directory with jobs", format: DumpFormatDirectory, jobs: 4},
+		{desc: "invalid format", format: "custom", expectedErr: true},
+		{desc: "negative jobs", format: DumpFormatDirectory, jobs: -1, expectedErr: true},
+		{desc: "jobs with the plain format", format: DumpFormatPlain, jobs: 4, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := validateFormat(tt.format, tt.jobs)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJobsArgs(t *testing.T) {
+	assert.Nil(t, jobsArgs(0))
+	assert.Equal(t, []string{"--jobs=4"}, jobsArgs(4))
+}
+
+func TestDatabaseVariables(t *testing.T) {
+	creds := EnvironmentCredentials{HostVarName: "fakehost"}
+	assert.Equal(t, CredentialVariables{HostVarName: "fakehost", DatabaseVarName: "app"}, databaseVariables(creds, "app"))
+	assert.Equal(t, EnvironmentCredentials{HostVarName: "fakehost"}, creds)
+}
+
+// recordedCommand is a command run by a LocalRuntime under test.
+type recordedCommand struct {
+	args []string
+	env  []string
+}
+
+func TestDumpAllDirectory(t *testing.T) {
+	tests := []struct {
+		desc            string
+		simulateListErr bool
+		simulateDumpErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:            "fails to list databases",
+			simulateListErr: true,
+		},
+		{
+			desc:            "fails to dump a database",
+			simulateDumpErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var commands []recordedCommand
+			lr := &LocalRuntime{
+				errOutputWriter: &nopWriterCloser{io.Discard},
+				wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+					commands = append(commands, recordedCommand{args: cmd.Args})
+
+					cw := NewCmdWrapper(cmd)
+					switch cmd.Args[0] {
+					case dumpCommandName:
+						cw.stdoutPipeCallback = func(cw *cmdWrapper) (io.ReadCloser, error) {
+							commands[len(commands)-1].env = cw.Env
+							return io.NopCloser(strings.NewReader("CREATE ROLE postgres;\nCREATE ROLE app;\n")), nil
+						}
+						cw.startCallback = func(*cmdWrapper) error { return nil }
+						cw.waitCallback = func(*cmdWrapper) error { return nil }
+					case restoreCommandName:
+						cw.outputCallback = func(cw *cmdWrapper) ([]byte, error) {
+							commands[len(commands)-1].env = cw.Env
+							return th.ErrOr1Val([]byte("app\nmy db\n"), tt.simulateListErr)
+						}
+					case databaseDumpCommandName:
+						cw.combinedOutputCallback = func(cw *cmdWrapper) ([]byte, error) {
+							commands[len(commands)-1].env = cw.Env
+							if tt.simulateDumpErr {
+								return []byte("pg_dump: error"), assert.AnError
+							}
+
+							// Simulate pg_dump creating the archive directory.
+							outputDirPath := strings.TrimPrefix(cw.Args[2], "--file=")
+							return nil, os.Mkdir(outputDirPath, 0700)
+						}
+					default:
+						assert.Failf(t, "unexpected command", "%v", cmd.Args)
+					}
+					return cw
+				},
+			}
+
+			// Anything left from an earlier dump is removed.
+			outputDirPath := filepath.Join(t.TempDir(), "dump")
+			require.NoError(t, os.MkdirAll(filepath.Join(outputDirPath, databasesDirName, "dropped"), 0700))
+
+			creds := EnvironmentCredentials{HostVarName: "fakehost"}
+			err := lr.DumpAll(th.NewTestContext(), creds, outputDirPath, DumpAllOptions{Format: DumpFormatDirectory, Jobs: 4})
+			if th.ErrExpected(tt.simulateListErr, tt.simulateDumpErr) {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, commands, 4)
+			assert.Equal(t, []string{dumpCommandName, "--globals-only", "--clean", "--if-exists"}, commands[0].args)
+			assert.Contains(t, commands[1].env, "PGDATABASE=postgres")
+			assert.Equal(t, []string{databaseDumpCommandName, "--format=directory", "--file=" + filepath.Join(outputDirPath, databasesDirName, "app"), "--jobs=4"}, commands[2].args)
+			assert.Subset(t, commands[2].env, []string{"PGHOST=fakehost", "PGDATABASE=app"})
+			assert.Equal(t, []string{databaseDumpCommandName, "--format=directory", "--file=" + filepath.Join(outputDirPath, databasesDirName, "my%20db"), "--jobs=4"}, commands[3].args)
+			assert.Subset(t, commands[3].env, []string{"PGHOST=fakehost", "PGDATABASE=my db"})
+
+			globals, err := os.ReadFile(filepath.Join(outputDirPath, globalsFileName))
+			require.NoError(t, err)
+			assert.Equal(t, "-- Ignored statement: CREATE ROLE postgres;\nCREATE ROLE app;\n", string(globals))
+
+			entries, err := os.ReadDir(filepath.Join(outputDirPath, databasesDirName))
+			require.NoError(t, err)
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			assert.Equal(t, []string{"app", "my%20db"}, names)
+		})
+	}
+}
+
+func TestDumpAllInvalidOptions(t *testing.T) {
+	lr := &LocalRuntime{}
+	err := lr.DumpAll(th.NewTestContext(), EnvironmentCredentials{}, t.TempDir(), DumpAllOptions{Jobs: 4})
+	assert.Error(t, err)
+}
+
+func TestRestoreDirectory(t *testing.T) {
+	tests := []struct {
+		desc               string
+		noDatabasesDir     bool
+		simulateGlobalsErr bool
+		simulateRestoreErr bool
+	}{
+		{
+			desc: "succeeds",
+		},
+		{
+			desc:           "fails without a databases directory",
+			noDatabasesDir: true,
+		},
+		{
+			desc:               "fails to restore globals",
+			simulateGlobalsErr: true,
+		},
+		{
+			desc:               "fails to restore a database",
+			simulateRestoreErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inputDirPath := t.TempDir()
+			databasesDirPath := filepath.Join(inputDirPath, databasesDirName)
+			if !tt.noDatabasesDir {
+				require.NoError(t, os.MkdirAll(filepath.Join(databasesDirPath, "app"), 0700))
+				require.NoError(t, os.MkdirAll(filepath.Join(databasesDirPath, "other"), 0700))
+				require.NoError(t, os.WriteFile(filepath.Join(databasesDirPath, "stray-file"), nil, 0600))
+			}
+
+			var commands []recordedCommand
+			lr := &LocalRuntime{
+				wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+					commands = append(commands, recordedCommand{args: cmd.Args})
+
+					cw := NewCmdWrapper(cmd)
+					cw.combinedOutputCallback = func(cw *cmdWrapper) ([]byte, error) {
+						commands[len(commands)-1].env = cw.Env
+						if cw.Args[0] == restoreCommandName {
+							return nil, th.ErrIfTrue(tt.simulateGlobalsErr)
+						}
+						return nil, th.ErrIfTrue(tt.simulateRestoreErr)
+					}
+					return cw
+				},
+			}
+
+			creds := EnvironmentCredentials{HostVarName: "fakehost"}
+			err := lr.Restore(th.NewTestContext(), creds, inputDirPath, RestoreOptions{Format: DumpFormatDirectory, Jobs: 2, Force: true})
+			if th.ErrExpected(tt.noDatabasesDir, tt.simulateGlobalsErr, tt.simulateRestoreErr) {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, commands, 3)
+			assert.Equal(t, []string{restoreCommandName, "-X", "-f", filepath.Join(inputDirPath, globalsFileName)}, commands[0].args)
+			assert.Equal(t, []string{databaseRestoreCommandName, "--create", "--clean", "--if-exists", "--dbname=postgres", "--jobs=2", filepath.Join(databasesDirPath, "app")}, commands[1].args)
+			assert.Equal(t, []string{databaseRestoreCommandName, "--create", "--clean", "--if-exists", "--dbname=postgres", "--jobs=2", filepath.Join(databasesDirPath, "other")}, commands[2].args)
+			for _, command := range commands {
+				assert.Subset(t, command.env, []string{"PGHOST=fakehost", "PGDATABASE=postgres"})
+			}
+		})
+	}
+}
+
+func TestRestoreDirectorySingleTransaction(t *testing.T) {
+	inputDirPath := t.TempDir()
+	databaseDirPath := filepath.Join(inputDirPath, databasesDirName, "app")
+	require.NoError(t, os.MkdirAll(databaseDirPath, 0700))
+
+	var commands []recordedCommand
+	var lists []string
+	lr := &LocalRuntime{
+		wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+			commands = append(commands, recordedCommand{args: cmd.Args})
+
+			cw := NewCmdWrapper(cmd)
+			cw.outputCallback = func(*cmdWrapper) ([]byte, error) { return []byte(testRestoreList), nil }
+			cw.combinedOutputCallback = func(cw *cmdWrapper) ([]byte, error) {
+				commands[len(commands)-1].env = cw.Env
+				for _, arg := range cw.Args {
+					if listFilePath, ok := strings.CutPrefix(arg, "--use-list="); ok {
+						contents, err := os.ReadFile(listFilePath)
+						require.NoError(t, err)
+						lists = append(lists, string(contents))
+					}
+				}
+				return nil, nil
+			}
+			return cw
+		},
+	}
+
+	creds := EnvironmentCredentials{HostVarName: "fakehost"}
+	require.NoError(t, lr.Restore(th.NewTestContext(), creds, inputDirPath, RestoreOptions{Format: DumpFormatDirectory, SingleTransaction: true, Force: true}))
+
+	require.Len(t, commands, 4)
+	assert.Equal(t, []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "-f", filepath.Join(inputDirPath, globalsFileName)}, commands[0].args)
+	assert.Equal(t, []string{databaseRestoreCommandName, "--list", databaseDirPath}, commands[1].args)
+	assert.Equal(t, databaseRestoreCommandName, commands[2].args[0])
+	assert.Equal(t, []string{"--create", "--clean", "--if-exists", "--dbname=postgres", "--exit-on-error"}, commands[2].args[1:6])
+	assert.Subset(t, commands[2].env, []string{"PGDATABASE=postgres"})
+	assert.Equal(t, []string{databaseRestoreCommandName, "--dbname=app", "--exit-on-error", "--single-transaction", databaseDirPath}, commands[3].args)
+	assert.Subset(t, commands[3].env, []string{"PGDATABASE=app"})
+
+	// Only the database's own entries are restored when it is created.
+	assert.Equal(t, []string{"3340; 1262 16384 DATABASE - app postgres\n3341; 0 0 DATABASE PROPERTIES - app postgres\n3342; 0 0 COMMENT - DATABASE app postgres\n"}, lists)
+}
+
+func TestRestoreInvalidOptions(t *testing.T) {
+	lr := &LocalRuntime{}
+	err := lr.Restore(th.NewTestContext(), EnvironmentCredentials{}, t.TempDir(), RestoreOptions{Format: "custom"})
+	assert.Error(t, err)
+}
+
+func TestDumpAllDirectorySelection(t *testing.T) {
+	var dumpArgs [][]string
+	lr := &LocalRuntime{
+		errOutputWriter: &nopWriterCloser{io.Discard},
+		wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+			cw := NewCmdWrapper(cmd)
+			switch cmd.Args[0] {
+			case dumpCommandName:
+				cw.stdoutPipeCallback = func(*cmdWrapper) (io.ReadCloser, error) { return io.NopCloser(strings.NewReader("")), nil }
+				cw.startCallback = func(*cmdWrapper) error { return nil }
+				cw.waitCallback = func(*cmdWrapper) error { return nil }
+			case restoreCommandName:
+				cw.outputCallback = func(*cmdWrapper) ([]byte, error) { return []byte("analytics\napp\nother\n"), nil }
+			case databaseDumpCommandName:
+				cw.combinedOutputCallback = func(cw *cmdWrapper) ([]byte, error) {
+					dumpArgs = append(dumpArgs, cw.Args)
+					return nil, nil
+				}
+			}
+			return cw
+		},
+	}
+
+	outputDirPath := t.TempDir()
+	opts := DumpAllOptions{
+		Format: DumpFormatDirectory,
+		Selection: DatabaseSelection{
+			ExcludeDatabases: []string{"analytics"},
+			Databases:        []DatabaseFilter{{Name: "app", SchemaOnlyTables: []string{"public.sessions"}}},
+		},
+	}
+	require.NoError(t, lr.DumpAll(th.NewTestContext(), EnvironmentCredentials{}, outputDirPath, opts))
+
+	assert.Equal(t, [][]string{
+		{databaseDumpCommandName, "--format=directory", "--file=" + filepath.Join(outputDirPath, databasesDirName, "app"), "--exclude-table-data=public.sessions"},
+		{databaseDumpCommandName, "--format=directory", "--file=" + filepath.Join(outputDirPath, databasesDirName, "other")},
+	}, dumpArgs)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,20 +19,6 @@ import (
 // Depending on a CLI tool is unfortunate but there are no viable golang replacements for this.
 const dumpCommandName = "pg_dumpall"
 
-// Tracks the _prefixes_ of lines that should be commented out in the produced file.
-// This is used to drop problematic queries for DB resources that should be entirely managed by CNPG, such as the postgres role.
-// Entries will only be matched once.
-var ignoreLines = []string{
-	"DROP ROLE IF EXISTS postgres",
-	"DROP ROLE IF EXISTS streaming_replica",
-	"CREATE ROLE postgres",
-	"ALTER ROLE postgres",
-	"COMMENT ON ROLE postgres",
-	"CREATE ROLE streaming_replica",
-	"ALTER ROLE streaming_replica",
-	"COMMENT ON ROLE streaming_replica",
-}
-
 type writerCloserCallback struct {
 	io.Writer
 	callback func() error
@@ -43,17 +30,90 @@ func (wcc *writerCloserCallback) Close() error {
 
 type DumpAllOptions struct {
 	CleanupTimeout helpers.MaxWaitTime
+	// Format selects how the dump is written to the output path. Empty means DumpFormatPlain.
+	Format DumpFormat
+	// Jobs is the number of tables of each database that are dumped concurrently. It requires the directory
+	// format. Zero dumps one table at a time.
+	Jobs int
+	// Selection selects the databases, schemas and tables that are dumped. The zero value dumps everything.
+	Selection DatabaseSelection
+	// Roles configures how the statements that involve roles are rewritten. The zero value only leaves out
+	// the definitions of the roles managed by CNPG.
+	Roles RoleHandling
+}
+
+// Validate checks the options before anything is dumped.
+func (opts DumpAllOptions) Validate() error {
+	if err := validateFormat(opts.Format, opts.Jobs); err != nil {
+		return trace.Wrap(err)
+	}
+
+	if err := opts.Selection.validate(opts.Format); err != nil {
+		return trace.Wrap(err, "invalid database selection")
+	}
+
+	return trace.Wrap(opts.Roles.validate(opts.Format), "invalid role handling")
 }
 
 func (lr *LocalRuntime) DumpAll(ctx *contexts.Context, credentials Credentials, outputFilePath string, opts DumpAllOptions) (err error) {
-	ctx.Log.With("serverAddress", GetServerAddress(credentials), "username", credentials.GetUsername()).Info("Dumping all databases", "outputFilePath", outputFilePath)
+	ctx.Log.With("serverAddress", GetServerAddress(credentials), "username", credentials.GetUsername()).Info("Dumping all databases", "outputFilePath", outputFilePath, "format", opts.Format)
 	defer ctx.Log.Info("Database dump complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
 
+	if err := opts.Validate(); err != nil {
+		return trace.Wrap(err, "invalid dump options")
+	}
+
+	if opts.Format == DumpFormatDirectory {
+		return trace.Wrap(lr.dumpDirectory(ctx, credentials, outputFilePath, opts))
+	}
+
+	excludeArgs, err := lr.excludeDatabaseArgs(ctx, credentials, opts.Selection)
+	if err != nil {
+		return trace.Wrap(err, "failed to select databases")
+	}
+
+	args := append([]string{"--clean", "--if-exists", "--exclude-database=postgres"}, excludeArgs...)
+	return trace.Wrap(lr.dumpSQL(ctx, credentials, outputFilePath, opts, args...))
+}
+
+// excludeDatabaseArgs returns the pg_dumpall args that leave the databases that aren't selected out of the
+// dump. pg_dumpall can't include databases, so when databases are included, every other one is excluded.
+func (lr *LocalRuntime) excludeDatabaseArgs(ctx *contexts.Context, credentials Credentials, selection DatabaseSelection) ([]string, error) {
+	excluded := selection.ExcludeDatabases
+	if len(selection.IncludeDatabases) > 0 {
+		databases, err := lr.listDatabases(ctx, credentials)
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to list databases")
+		}
+
+		selected, err := selection.selectDatabases(databases)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+
+		excluded = slices.DeleteFunc(databases, func(database string) bool {
+			return slices.Contains(selected, database)
+		})
+		// Template databases aren't listed, but pg_dumpall dumps the contents of template1.
+		excluded = append(excluded, "template1")
+	}
+
+	args := make([]string, 0, len(excluded))
+	for _, database := range excluded {
+		args = append(args, "--exclude-database="+literalPattern(database))
+	}
+
+	return args, nil
+}
+
+// dumpSQL runs pg_dumpall with the given args, writing its output to the file at the output path with the
+// statements that involve roles rewritten according to the role handling options.
+func (lr *LocalRuntime) dumpSQL(ctx *contexts.Context, credentials Credentials, outputFilePath string, opts DumpAllOptions, args ...string) (err error) {
 	// This will cause the process to be terminated if the function returns before the process is done.
 	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
 	defer ctxCancel()
 
-	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, dumpCommandName, "--clean", "--if-exists", "--exclude-database=postgres"))
+	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, dumpCommandName, append(args, opts.Roles.dumpArgs()...)...))
 	cmd.Env = credentials.GetVariables().SetDatabaseName("postgres").ToEnvSlice()
 
 	// Capture stderr and also write it to the standard error output stream.
@@ -89,12 +149,8 @@ func (lr *LocalRuntime) DumpAll(ctx *contexts.Context, credentials Credentials,
 		return trace.Wrap(err, "failed to start %q process", dumpCommandName)
 	}
 
-	// The map value indicates if the item has been found yet.
-	ignoreLineTracker := make(map[string]bool, len(ignoreLines))
-	for _, ignoreLine := range ignoreLines {
-		ignoreLineTracker[ignoreLine] = false
-	}
-	foundIgnoreLineCount := 0 // Used to stop checking every line a little early
+	// Statements that involve roles are rewritten as they're read.
+	rewriter := newRoleRewriter(opts.Roles)
 
 	// Track the number of lines processed for logging purposes.
 	linesProcessed := 0
@@ -126,44 +182,14 @@ func (lr *LocalRuntime) DumpAll(ctx *contexts.Context, credentials Credentials,
 			return trace.Wrap(err, "failed to read from stdout")
 		}
 
-		// Find lines that should be ignored, and comment them out.
-		// Assumptions made:
-		// * All lines end in '\n' (so have a min length of 1)
-		// * No leading whitespace
-		// * One "entry" per line, where an entry is one of:
-		//   * Blank (no chars, just another new line)
-		//   * Comment (starts with --)
-		//   * A psql command (starts with \)
-		//   * A SQL statement (ends with ;)
-		// If not all lines to ignore have been found yet, and
-		if foundIgnoreLineCount < len(ignoreLineTracker) &&
-			// the current line is not blank, and
-			len(sqlLine) >= 2 &&
-			// the current line is not a comment, and
-			(sqlLine[0] != '-' || sqlLine[1] != '-') &&
-			// the current line is not a psql command, and
-			sqlLine[0] != '\\' &&
-			// the current line is a statement line
-			sqlLine[len(sqlLine)-2] == ';' {
-			for ignoreLinePrefix, alreadyFound := range ignoreLineTracker {
-				if alreadyFound {
-					continue
-				}
-
-				if strings.HasPrefix(sqlLine, ignoreLinePrefix) {
-					ctx.Log.Debug("Ignoring line", "line", sqlLine)
-					ignoreLineTracker[ignoreLinePrefix] = true
-					foundIgnoreLineCount++
-
-					sqlLine = "-- Ignored statement: " + sqlLine
-					break
-				}
-			}
+		sqlText := rewriter.rewriteLine(sqlLine)
+		if hitEOF {
+			sqlText += rewriter.flush()
 		}
 
-		_, err = outputFileWriter.WriteString(sqlLine)
+		_, err = outputFileWriter.WriteString(sqlText)
 		if err != nil {
-			return trace.Wrap(err, "failed to write SQL line %q to output file at %q", outputFilePath)
+			return trace.Wrap(err, "failed to write SQL to output file at %q", outputFilePath)
 		}
 
 		if hitEOF {
@@ -17,9 +17,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestIgnoreLines(t *testing.T) {
+func TestCNPGManagedRoles(t *testing.T) {
 	// Require no duplicates
-	require.Equal(t, lo.Uniq(ignoreLines), ignoreLines)
+	require.Equal(t, lo.Uniq(cnpgManagedRoles), cnpgManagedRoles)
 }
 
 // Why doesn't Go include this in the io package?
@@ -2,7 +2,11 @@ package postgres
 
 import (
 	"context"
+	"io"
+	"os"
 	"os/exec"
+	"slices"
+	"strings"
 
 	"github.com/gravitational/trace"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
@@ -11,22 +15,330 @@ import (
 // The dumps (currently) rely on psql local commands (i.e. `\c`)
 const restoreCommandName = "psql"
 
-type RestoreOptions struct{}
+// Lists the databases that a restore may replace. Template databases are left to the dump, which restores
+// them in place.
+const listReplaceableDatabasesQuery = "SELECT datname FROM pg_database WHERE NOT datistemplate ORDER BY datname"
+
+// Counts the relations (tables, views, sequences and so on) of a database, outside of the system schemas.
+const countRelationsQuery = `SELECT count(*) FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname <> 'information_schema' AND n.nspname NOT LIKE 'pg\_%'`
+
+type RestoreOptions struct {
+	// Format is the format that the dump was written in. Empty means DumpFormatPlain.
+	Format DumpFormat
+	// Jobs is the number of tables of each database that are restored concurrently. It requires the directory
+	// format. Zero restores one table at a time.
+	Jobs int
+	// Selection selects the part of the dump that is restored. The zero value restores the whole dump.
+	Selection RestoreSelection
+	// StopOnError stops the restore at the first statement that fails. Otherwise the failed statements are
+	// logged, and the restore carries on and succeeds.
+	StopOnError bool
+	// SingleTransaction restores the contents of each database in a single transaction, so that a failed
+	// restore leaves none of the database's objects behind. It implies StopOnError, and can't be combined
+	// with jobs. Databases can't be created within a transaction, so they are created beforehand.
+	SingleTransaction bool
+	// DropAndRecreate drops each database that the restore replaces before restoring it, disconnecting any
+	// sessions. Otherwise the dump's own statements drop the databases, which fails while they are in use.
+	DropAndRecreate bool
+	// Force allows the restore to replace databases that hold tables, views or other relations. Without it,
+	// the restore fails before changing anything if a database that it would replace is not empty. Restores
+	// of selected schemas and tables only replace those objects, so they are not checked.
+	Force bool
+}
+
+// Validate checks the options before anything is restored.
+func (opts RestoreOptions) Validate() error {
+	if err := validateFormat(opts.Format, opts.Jobs); err != nil {
+		return trace.Wrap(err)
+	}
+
+	if err := opts.Selection.validate(opts.Format); err != nil {
+		return trace.Wrap(err, "invalid restore selection")
+	}
+
+	if opts.SingleTransaction && opts.Jobs > 0 {
+		return trace.BadParameter("a single transaction can't be combined with jobs")
+	}
+
+	if opts.DropAndRecreate && opts.Selection.selectsObjects() && opts.Selection.TargetDatabase == "" {
+		return trace.BadParameter("dropping and recreating databases requires a target database when schemas or tables are selected")
+	}
+
+	return nil
+}
+
+// replacedDatabases returns the databases that the restore drops and recreates, given the databases that
+// are restored.
+func (opts RestoreOptions) replacedDatabases(databases []string) []string {
+	switch {
+	case opts.Selection.TargetDatabase != "":
+		return []string{opts.Selection.TargetDatabase}
+	case opts.Selection.selectsObjects():
+		return nil
+	}
+
+	return databases
+}
+
+// psqlArgs returns the psql args that apply the options. As some statements can't be run in a transaction,
+// a single transaction is only used when inTransaction is set.
+func (opts RestoreOptions) psqlArgs(inTransaction bool) []string {
+	var args []string
+	if opts.StopOnError || opts.SingleTransaction {
+		args = append(args, "--set=ON_ERROR_STOP=1")
+	}
+
+	if inTransaction {
+		args = append(args, "--single-transaction")
+	}
+
+	return args
+}
+
+// pgRestoreArgs returns the pg_restore args that apply the options. As some statements can't be run in a
+// transaction, a single transaction is only used when inTransaction is set.
+func (opts RestoreOptions) pgRestoreArgs(inTransaction bool) []string {
+	var args []string
+	if opts.StopOnError || opts.SingleTransaction {
+		args = append(args, "--exit-on-error")
+	}
+
+	if inTransaction {
+		args = append(args, "--single-transaction")
+	}
+
+	return args
+}
 
 func (lr *LocalRuntime) Restore(ctx *contexts.Context, credentials Credentials, inputFilePath string, opts RestoreOptions) (err error) {
-	ctx.Log.With("serverAddress", GetServerAddress(credentials), "username", credentials.GetUsername()).Info("Restoring all databases", "inputFilePath", inputFilePath)
+	ctx.Log.With("serverAddress", GetServerAddress(credentials), "username", credentials.GetUsername()).Info("Restoring all databases", "inputFilePath", inputFilePath, "format", opts.Format)
 	defer ctx.Log.Info("Database restoration complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
 
+	if err := opts.Validate(); err != nil {
+		return trace.Wrap(err, "invalid restore options")
+	}
+
+	var dumped []string
+	if opts.Format == DumpFormatDirectory {
+		dumped, err = listArchivedDatabases(inputFilePath)
+	} else {
+		dumped, err = listSQLDatabases(inputFilePath)
+	}
+	if err != nil {
+		return trace.Wrap(err, "failed to list the databases in the dump")
+	}
+
+	databases, err := opts.Selection.selectDatabases(dumped)
+	if err != nil {
+		return trace.Wrap(err, "failed to select databases")
+	}
+
+	if err := lr.prepareDatabases(ctx, credentials, opts.replacedDatabases(databases), opts); err != nil {
+		return trace.Wrap(err, "failed to prepare the databases for the restore")
+	}
+
+	if opts.Format == DumpFormatDirectory {
+		return trace.Wrap(lr.restoreDirectory(ctx, credentials, inputFilePath, databases, opts))
+	}
+
+	// Databases are created by the dump, so the contents of each can only be restored in a transaction when
+	// they are restored one at a time.
+	if opts.Selection.IsZero() && !opts.SingleTransaction {
+		return trace.Wrap(lr.restoreSQL(ctx, credentials, inputFilePath, opts))
+	}
+
+	return trace.Wrap(lr.restoreSQLDatabases(ctx, credentials, inputFilePath, databases, opts))
+}
+
+// prepareDatabases checks that the databases that the restore replaces are empty, unless the restore is
+// forced, and then drops them if they are to be dropped and recreated. Databases that don't exist are skipped.
+func (lr *LocalRuntime) prepareDatabases(ctx *contexts.Context, credentials Credentials, databases []string, opts RestoreOptions) error {
+	if len(databases) == 0 || (opts.Force && !opts.DropAndRecreate) {
+		return nil
+	}
+
+	existing, err := lr.queryRows(ctx, credentials, "postgres", listReplaceableDatabasesQuery)
+	if err != nil {
+		return trace.Wrap(err, "failed to list the existing databases")
+	}
+
+	databases = slices.DeleteFunc(slices.Clone(databases), func(database string) bool {
+		return !slices.Contains(existing, database)
+	})
+
+	// Every database is checked before any is dropped.
+	if !opts.Force {
+		for _, database := range databases {
+			relations, err := lr.queryRows(ctx, credentials, database, countRelationsQuery)
+			if err != nil {
+				return trace.Wrap(err, "failed to check whether database %q is empty", database)
+			}
+
+			if !slices.Equal(relations, []string{"0"}) {
+				return trace.AlreadyExists("database %q is not empty, and the restore is not forced", database)
+			}
+		}
+	}
+
+	if !opts.DropAndRecreate {
+		return nil
+	}
+
+	for _, database := range databases {
+		if err := lr.dropDatabase(ctx, credentials, database); err != nil {
+			return trace.Wrap(err, "failed to drop database %q", database)
+		}
+	}
+
+	return nil
+}
+
+// restoreSQL runs the SQL file at the input path with psql.
+func (lr *LocalRuntime) restoreSQL(ctx *contexts.Context, credentials Credentials, inputFilePath string, opts RestoreOptions) error {
+	return trace.Wrap(lr.runSQL(ctx, credentials, "postgres", inputFilePath, nil, opts.psqlArgs(false)))
+}
+
+// restoreSQLDatabases restores the given databases of a plain format dump, one at a time. The globals are
+// restored first, when the whole dump is restored.
+func (lr *LocalRuntime) restoreSQLDatabases(ctx *contexts.Context, credentials Credentials, inputFilePath string, databases []string, opts RestoreOptions) error {
+	if opts.Selection.IsZero() {
+		if err := lr.runSQLPart(ctx, credentials, "postgres", inputFilePath, writeSQLGlobals, opts.psqlArgs(false)); err != nil {
+			return trace.Wrap(err, "failed to restore globals")
+		}
+	}
+
+	for _, database := range databases {
+		if err := lr.restoreSQLDatabase(ctx.Child(), credentials, inputFilePath, database, opts); err != nil {
+			return trace.Wrap(err, "failed to restore database %q", database)
+		}
+	}
+
+	return nil
+}
+
+// restoreSQLDatabase runs the part of a plain format dump that restores the database with psql. If a target
+// database is given, it is created and the database is restored into it instead.
+func (lr *LocalRuntime) restoreSQLDatabase(ctx *contexts.Context, credentials Credentials, inputFilePath, database string, opts RestoreOptions) (err error) {
+	targetDatabase := opts.Selection.TargetDatabase
+	ctx.Log.With("database", database).Info("Restoring database", "inputFilePath", inputFilePath, "targetDatabase", targetDatabase)
+	defer ctx.Log.Info("Finished restoring database", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	connectDatabase := "postgres"
+	part := sqlDatabaseAll
+	switch {
+	case targetDatabase != "":
+		if err := lr.createDatabase(ctx, credentials, targetDatabase); err != nil {
+			return trace.Wrap(err, "failed to create target database %q", targetDatabase)
+		}
+		connectDatabase, part = targetDatabase, sqlDatabaseContents
+	case opts.SingleTransaction:
+		if err := lr.runSQLPart(ctx, credentials, connectDatabase, inputFilePath, sqlDatabaseWriter(database, sqlDatabaseCreation), opts.psqlArgs(false)); err != nil {
+			return trace.Wrap(err, "failed to create database %q", database)
+		}
+		connectDatabase, part = database, sqlDatabaseContents
+	}
+
+	return trace.Wrap(lr.runSQLPart(ctx, credentials, connectDatabase, inputFilePath, sqlDatabaseWriter(database, part), opts.psqlArgs(opts.SingleTransaction)))
+}
+
+// runSQLPart runs the part of the SQL file at the input path that the function writes with psql, against the
+// database. The part is streamed to psql, rather than copied to a file first.
+func (lr *LocalRuntime) runSQLPart(ctx *contexts.Context, credentials Credentials, database, inputFilePath string, writePart func(io.Writer, io.Reader) error, args []string) error {
+	inputFile, err := os.Open(inputFilePath)
+	if err != nil {
+		return trace.Wrap(err, "failed to open SQL dump %q", inputFilePath)
+	}
+	defer inputFile.Close()
+
+	sqlReader, sqlWriter := io.Pipe()
+	defer sqlReader.Close() // Unblocks the writer if psql exits early.
+	go func() {
+		sqlWriter.CloseWithError(writePart(sqlWriter, inputFile))
+	}()
+
+	return trace.Wrap(lr.runSQL(ctx, credentials, database, "-", sqlReader, args))
+}
+
+// runSQL runs the SQL file at the input path with psql, against the database. The input path "-" reads the
+// SQL from stdin instead. The statements that fail are returned as StatementErrors if psql fails, and are
+// logged otherwise.
+func (lr *LocalRuntime) runSQL(ctx *contexts.Context, credentials Credentials, database, inputFilePath string, stdin io.Reader, args []string) error {
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, restoreCommandName, slices.Concat([]string{"-X"}, args, []string{"-f", inputFilePath})...))
+	cmd.Env = databaseVariables(credentials, database).ToEnvSlice()
+	cmd.Stdin = stdin
+
+	output, err := cmd.CombinedOutput()
+	statementErrs := parseStatementErrors(string(output))
+	if err != nil {
+		if len(statementErrs) == 0 {
+			return trace.Wrap(err, "database restoration command failed: %s", string(output))
+		}
+
+		errs := make([]error, 0, len(statementErrs))
+		for _, statementErr := range statementErrs {
+			errs = append(errs, statementErr)
+		}
+		return trace.Wrap(trace.NewAggregate(errs...), "database restoration command failed")
+	}
+
+	for _, statementErr := range statementErrs {
+		ctx.Log.Warn("Restored statement failed", "error", statementErr)
+	}
+	return nil
+}
+
+// queryRows runs the query against the database with psql, and returns the rows of its single column result.
+func (lr *LocalRuntime) queryRows(ctx *contexts.Context, credentials Credentials, database, query string) ([]string, error) {
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, restoreCommandName, "-X", "--no-align", "--tuples-only", "--command="+query))
+	cmd.Env = databaseVariables(credentials, database).ToEnvSlice()
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, trace.Wrap(err, "process %q failed", restoreCommandName)
+	}
+
+	// Each row is on its own line. Values may contain spaces, so lines are not split any further.
+	var rows []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line != "" {
+			rows = append(rows, line)
+		}
+	}
+
+	return rows, nil
+}
+
+// createDatabase creates an empty database. It fails if the database already exists, so that a restore into
+// another database never overwrites one.
+func (lr *LocalRuntime) createDatabase(ctx *contexts.Context, credentials Credentials, database string) error {
+	return trace.Wrap(lr.execSQL(ctx, credentials, "CREATE DATABASE "+quoteIdentifier(database)))
+}
+
+// dropDatabase drops the database, if it exists, disconnecting its sessions.
+func (lr *LocalRuntime) dropDatabase(ctx *contexts.Context, credentials Credentials, database string) error {
+	return trace.Wrap(lr.execSQL(ctx, credentials, "DROP DATABASE IF EXISTS "+quoteIdentifier(database)+" WITH (FORCE)"))
+}
+
+// execSQL runs a single command with psql, connected to the postgres database.
+func (lr *LocalRuntime) execSQL(ctx *contexts.Context, credentials Credentials, command string) error {
 	// This will cause the process to be terminated if the function returns before the process is done.
 	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
 	defer ctxCancel()
 
-	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, restoreCommandName, "-X", "-f", inputFilePath))
-	cmd.Env = credentials.GetVariables().SetDatabaseName("postgres").ToEnvSlice()
+	cmd := lr.wrapCommand(exec.CommandContext(commandCtx, restoreCommandName, "-X", "--command="+command))
+	cmd.Env = databaseVariables(credentials, "postgres").ToEnvSlice()
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return trace.Wrap(err, "database restoration command failed: %s", string(output))
+		return trace.Wrap(err, "process %q failed: %s", restoreCommandName, string(output))
 	}
 	return nil
 }
@@ -1,11 +1,19 @@
 package postgres
 
 import (
+	"io"
+	"maps"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/gravitational/trace"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRestore(t *testing.T) {
@@ -49,7 +57,9 @@ func TestRestore(t *testing.T) {
 				HostVarName: "fakehost",
 				UserVarName: "fakeuser",
 			}
-			inputFilePath := "test/dump.sql"
+			// The dump holds no databases, so there are none to check before restoring.
+			inputFilePath := filepath.Join(t.TempDir(), "dump.sql")
+			require.NoError(t, os.WriteFile(inputFilePath, nil, 0600))
 			opts := RestoreOptions{}
 
 			err := lr.Restore(ctx, creds, inputFilePath, opts)
@@ -66,3 +76,277 @@ func TestRestore(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreOptionsValidate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		opts        RestoreOptions
+		expectedErr bool
+	}{
+		{
+			desc: "zero value",
+		},
+		{
+			desc: "every safety option",
+			opts: RestoreOptions{StopOnError: true, SingleTransaction: true, DropAndRecreate: true, Force: true},
+		},
+		{
+			desc: "dropping and recreating a target database with selected tables",
+			opts: RestoreOptions{Format: DumpFormatDirectory, DropAndRecreate: true, Selection: RestoreSelection{Databases: []string{"app"}, Tables: []string{"users"}, TargetDatabase: "app_recovered"}},
+		},
+		{
+			desc:        "single transaction with jobs",
+			opts:        RestoreOptions{Format: DumpFormatDirectory, Jobs: 4, SingleTransaction: true},
+			expectedErr: true,
+		},
+		{
+			desc:        "dropping and recreating databases with selected tables",
+			opts:        RestoreOptions{Format: DumpFormatDirectory, DropAndRecreate: true, Selection: RestoreSelection{Tables: []string{"users"}}},
+			expectedErr: true,
+		},
+		{
+			desc:        "invalid selection",
+			opts:        RestoreOptions{Selection: RestoreSelection{Tables: []string{"users"}}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRestoreOptionsReplacedDatabases(t *testing.T) {
+	databases := []string{"app", "other"}
+	assert.Equal(t, databases, RestoreOptions{}.replacedDatabases(databases))
+	assert.Equal(t, []string{"app_recovered"}, RestoreOptions{Selection: RestoreSelection{Databases: []string{"app"}, TargetDatabase: "app_recovered"}}.replacedDatabases(databases))
+	assert.Nil(t, RestoreOptions{Selection: RestoreSelection{Schemas: []string{"audit"}}}.replacedDatabases(databases))
+}
+
+func TestRestoreOptionsArgs(t *testing.T) {
+	assert.Nil(t, RestoreOptions{}.psqlArgs(false))
+	assert.Equal(t, []string{"--set=ON_ERROR_STOP=1"}, RestoreOptions{StopOnError: true}.psqlArgs(false))
+	assert.Equal(t, []string{"--set=ON_ERROR_STOP=1", "--single-transaction"}, RestoreOptions{SingleTransaction: true}.psqlArgs(true))
+
+	assert.Nil(t, RestoreOptions{}.pgRestoreArgs(false))
+	assert.Equal(t, []string{"--exit-on-error"}, RestoreOptions{SingleTransaction: true}.pgRestoreArgs(false))
+	assert.Equal(t, []string{"--exit-on-error", "--single-transaction"}, RestoreOptions{StopOnError: true}.pgRestoreArgs(true))
+}
+
+func TestRestoreSafety(t *testing.T) {
+	tests := []struct {
+		desc             string
+		opts             RestoreOptions
+		relations        map[string]string // Relations counted in each existing database.
+		simulateQueryErr bool
+		simulateDropErr  bool
+		expectedErr      bool
+		expectedCommands []recordedCommand
+	}{
+		{
+			desc:      "empty databases",
+			relations: map[string]string{"app": "0"},
+			expectedCommands: []recordedCommand{
+				{args: []string{restoreCommandName, "-X", "--no-align", "--tuples-only", "--command=" + listReplaceableDatabasesQuery}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{restoreCommandName, "-X", "--no-align", "--tuples-only", "--command=" + countRelationsQuery}, env: []string{"PGDATABASE=app"}},
+				{args: []string{restoreCommandName, "-X", "-f", "dump.sql"}, env: []string{"PGDATABASE=postgres"}},
+			},
+		},
+		{
+			desc:        "database that is not empty",
+			relations:   map[string]string{"app": "0", "other": "3"},
+			expectedErr: true,
+		},
+		{
+			desc:      "forced restore",
+			opts:      RestoreOptions{Force: true, StopOnError: true},
+			relations: map[string]string{"app": "3"},
+			expectedCommands: []recordedCommand{
+				{args: []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "-f", "dump.sql"}, env: []string{"PGDATABASE=postgres"}},
+			},
+		},
+		{
+			desc:      "drop and recreate",
+			opts:      RestoreOptions{DropAndRecreate: true},
+			relations: map[string]string{"other": "0"},
+			expectedCommands: []recordedCommand{
+				{args: []string{restoreCommandName, "-X", "--no-align", "--tuples-only", "--command=" + listReplaceableDatabasesQuery}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{restoreCommandName, "-X", "--no-align", "--tuples-only", "--command=" + countRelationsQuery}, env: []string{"PGDATABASE=other"}},
+				{args: []string{restoreCommandName, "-X", "--command=DROP DATABASE IF EXISTS \"other\" WITH (FORCE)"}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{restoreCommandName, "-X", "-f", "dump.sql"}, env: []string{"PGDATABASE=postgres"}},
+			},
+		},
+		{
+			desc:      "single transaction",
+			opts:      RestoreOptions{SingleTransaction: true, Force: true, Selection: RestoreSelection{Databases: []string{"app"}}},
+			relations: map[string]string{"app": "3"},
+			expectedCommands: []recordedCommand{
+				{args: []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "-f", "-"}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "--single-transaction", "-f", "-"}, env: []string{"PGDATABASE=app"}},
+			},
+		},
+		{
+			desc:      "single transaction for the whole dump",
+			opts:      RestoreOptions{SingleTransaction: true, Force: true},
+			relations: map[string]string{},
+			expectedCommands: []recordedCommand{
+				{args: []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "-f", "-"}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "-f", "-"}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "--single-transaction", "-f", "-"}, env: []string{"PGDATABASE=app"}},
+				{args: []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "-f", "-"}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{restoreCommandName, "-X", "--set=ON_ERROR_STOP=1", "--single-transaction", "-f", "-"}, env: []string{"PGDATABASE=other"}},
+			},
+		},
+		{
+			desc:             "fails to check the databases",
+			relations:        map[string]string{"app": "0"},
+			simulateQueryErr: true,
+			expectedErr:      true,
+		},
+		{
+			desc:            "fails to drop a database",
+			opts:            RestoreOptions{DropAndRecreate: true, Force: true},
+			relations:       map[string]string{"app": "3"},
+			simulateDropErr: true,
+			expectedErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inputDirPath := t.TempDir()
+			inputFilePath := filepath.Join(inputDirPath, "dump.sql")
+			require.NoError(t, os.WriteFile(inputFilePath, []byte(testSQLDump), 0600))
+
+			var commands []recordedCommand
+			lr := &LocalRuntime{
+				wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+					commands = append(commands, recordedCommand{args: cmd.Args})
+
+					cw := NewCmdWrapper(cmd)
+					cw.outputCallback = func(cw *cmdWrapper) ([]byte, error) {
+						commands[len(commands)-1].env = cw.Env
+						if tt.simulateQueryErr {
+							return nil, assert.AnError
+						}
+
+						if cw.Args[len(cw.Args)-1] == "--command="+listReplaceableDatabasesQuery {
+							return []byte(strings.Join(slices.Sorted(maps.Keys(tt.relations)), "\n") + "\n"), nil
+						}
+
+						for _, env := range cw.Env {
+							if database, ok := strings.CutPrefix(env, "PGDATABASE="); ok {
+								return []byte(tt.relations[database] + "\n"), nil
+							}
+						}
+						return nil, nil
+					}
+					cw.combinedOutputCallback = func(cw *cmdWrapper) ([]byte, error) {
+						commands[len(commands)-1].env = cw.Env
+						if cw.Stdin != nil {
+							_, err := io.Copy(io.Discard, cw.Stdin)
+							require.NoError(t, err)
+						}
+
+						if strings.HasPrefix(cw.Args[2], "--command=DROP") {
+							return nil, th.ErrIfTrue(tt.simulateDropErr)
+						}
+						return nil, nil
+					}
+					return cw
+				},
+			}
+
+			creds := EnvironmentCredentials{HostVarName: "fakehost"}
+			err := lr.Restore(th.NewTestContext(), creds, inputFilePath, tt.opts)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				for _, command := range commands {
+					assert.NotContains(t, command.args, "-f", "nothing should be restored")
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, commands, len(tt.expectedCommands))
+			for i, expectedCommand := range tt.expectedCommands {
+				args := commands[i].args
+				args[len(args)-1] = strings.TrimPrefix(args[len(args)-1], inputDirPath+string(filepath.Separator))
+
+				assert.Equal(t, expectedCommand.args, args)
+				assert.Subset(t, commands[i].env, append(expectedCommand.env, "PGHOST=fakehost"))
+			}
+		})
+	}
+}
+
+func TestRestoreNotEmptyError(t *testing.T) {
+	inputFilePath := filepath.Join(t.TempDir(), "dump.sql")
+	require.NoError(t, os.WriteFile(inputFilePath, []byte(testSQLDump), 0600))
+
+	lr := &LocalRuntime{
+		wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+			cw := NewCmdWrapper(cmd)
+			cw.outputCallback = func(cw *cmdWrapper) ([]byte, error) {
+				if cw.Args[len(cw.Args)-1] == "--command="+listReplaceableDatabasesQuery {
+					return []byte("app\n"), nil
+				}
+				return []byte("12\n"), nil
+			}
+			return cw
+		},
+	}
+
+	err := lr.Restore(th.NewTestContext(), EnvironmentCredentials{}, inputFilePath, RestoreOptions{})
+	assert.True(t, trace.IsAlreadyExists(err))
+}
+
+func TestRestoreStatementErrors(t *testing.T) {
+	tests := []struct {
+		desc               string
+		simulateRestoreErr bool
+	}{
+		{
+			desc: "failed statements are logged",
+		},
+		{
+			desc:               "failed statements are returned",
+			simulateRestoreErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inputFilePath := filepath.Join(t.TempDir(), "dump.sql")
+			require.NoError(t, os.WriteFile(inputFilePath, nil, 0600))
+
+			lr := &LocalRuntime{
+				wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+					cw := NewCmdWrapper(cmd)
+					cw.combinedOutputCallback = func(*cmdWrapper) ([]byte, error) {
+						output := []byte("SET\npsql:" + inputFilePath + ":12: ERROR:  role \"app\" does not exist\n")
+						return output, th.ErrIfTrue(tt.simulateRestoreErr)
+					}
+					return cw
+				},
+			}
+
+			err := lr.Restore(th.NewTestContext(), EnvironmentCredentials{}, inputFilePath, RestoreOptions{StopOnError: true})
+			if !tt.simulateRestoreErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var statementErr *StatementError
+			require.ErrorAs(t, err, &statementErr)
+			assert.Equal(t, &StatementError{Input: inputFilePath, Line: 12, Severity: "ERROR", Message: `role "app" does not exist`}, statementErr)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/kubecluster/helpers"
+)
+
+type CreateRestorePointOptions struct {
+	WaitForArchiveTimeout helpers.MaxWaitTime
+}
+
+// CreateRestorePoint creates a named restore point on the server, that a clone can later recover to with a
+// recoveryTarget.targetName. The restore point's WAL segment is switched out and archived before this returns,
+// so recovery can find it. Returns the restore point's write-ahead log location (LSN).
+//
+// Unlike a recovery fence (see ForceWALArchive), a restore point is not a transaction commit, so it can only be
+// targeted by name, not by time.
+func CreateRestorePoint(ctx *contexts.Context, run PSQLRunner, name string, opts CreateRestorePointOptions) (lsn string, err error) {
+	ctx.Log.With("restorePoint", name).Info("Creating restore point")
+	defer ctx.Log.Info("Finished creating restore point", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if name == "" {
+		return "", trace.BadParameter("no restore point name provided")
+	}
+
+	lsnOutput, err := run(ctx.Child(), "SELECT pg_create_restore_point("+quoteLiteral(name)+")")
+	if err != nil {
+		return "", trace.Wrap(err, "failed to create restore point %q", name)
+	}
+
+	lsn = lastNonEmptyLine(lsnOutput)
+	if lsn == "" {
+		return "", trace.Errorf("could not determine the location of restore point %q from psql output %q", name, lsnOutput)
+	}
+
+	segmentOutput, err := run(ctx.Child(), "SELECT pg_walfile_name("+quoteLiteral(lsn)+"::pg_lsn)")
+	if err != nil {
+		return "", trace.Wrap(err, "failed to determine the WAL segment for restore point %q", name)
+	}
+
+	targetSegment := lastNonEmptyLine(segmentOutput)
+	if targetSegment == "" {
+		return "", trace.Errorf("could not determine the WAL segment for restore point %q from psql output %q", name, segmentOutput)
+	}
+	ctx.Log.Debug("Restore point created", "lsn", lsn, "walSegment", targetSegment)
+
+	if _, err := run(ctx.Child(), "SELECT pg_switch_wal()"); err != nil {
+		return "", trace.Wrap(err, "failed to switch WAL after creating restore point %q", name)
+	}
+
+	if err := waitForWALArchive(ctx, run, targetSegment, opts.WaitForArchiveTimeout.MaxWait(2*time.Minute)); err != nil {
+		return "", err
+	}
+
+	return lsn, nil
+}
+
+// quoteLiteral quotes a value as a SQL string literal. Assumes standard_conforming_strings is on (the
+// default), so only single quotes need escaping.
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
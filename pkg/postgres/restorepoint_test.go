@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteLiteral(t *testing.T) {
+	assert.Equal(t, "'before-migration'", quoteLiteral("before-migration"))
+	assert.Equal(t, "'it''s'", quoteLiteral("it's"))
+}
+
+func TestCreateRestorePoint(t *testing.T) {
+	const targetSegment = "000000010000002B00000000"
+
+	t.Run("creates the restore point, switches WAL, and waits for archive", func(t *testing.T) {
+		run, statements := scriptedRunner(targetSegment, targetSegment+"||f\n", nil)
+
+		lsn, err := CreateRestorePoint(th.NewTestContext(), run, "before-migration", CreateRestorePointOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "0/0", lsn)
+
+		joined := strings.Join(*statements, "\n")
+		assert.Contains(t, joined, "pg_create_restore_point('before-migration')")
+		assert.Contains(t, joined, "pg_walfile_name('0/0'::pg_lsn)")
+		assert.Contains(t, joined, "pg_switch_wal()")
+		assert.Contains(t, joined, "pg_stat_archiver")
+		assert.Less(t, strings.Index(joined, "pg_create_restore_point"), strings.Index(joined, "pg_switch_wal()"))
+	})
+
+	t.Run("requires a name", func(t *testing.T) {
+		run, statements := scriptedRunner(targetSegment, targetSegment+"||f\n", nil)
+
+		_, err := CreateRestorePoint(th.NewTestContext(), run, "", CreateRestorePointOptions{})
+		require.Error(t, err)
+		assert.Empty(t, *statements)
+	})
+
+	t.Run("fails when archiving is actively failing at the restore point", func(t *testing.T) {
+		run, _ := scriptedRunner(targetSegment, "000000010000002A000000FF|"+targetSegment+"|t\n", nil)
+
+		_, err := CreateRestorePoint(th.NewTestContext(), run, "before-migration", CreateRestorePointOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "WAL archiving is failing")
+	})
+
+	t.Run("propagates a psql error", func(t *testing.T) {
+		run := func(_ *contexts.Context, _ string) (string, error) { return "", trace.Errorf("boom") }
+
+		_, err := CreateRestorePoint(th.NewTestContext(), run, "before-migration", CreateRestorePointOptions{})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,341 @@
+package postgres
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gravitational/trace"
+)
+
+// RestoreSelection selects the part of a dump that is restored. The globals (roles and tablespaces) are only
+// restored along with the whole dump.
+//
+// Selected databases are dropped and recreated, unless schemas or tables are also selected, in which case
+// only the selected objects are dropped and recreated, in the existing databases. Selecting schemas or
+// tables requires the directory format.
+type RestoreSelection struct {
+	// Databases restores only these databases. Empty restores every database in the dump.
+	Databases []string `yaml:"databases,omitempty"`
+	// Schemas restores only the objects in these schemas.
+	Schemas []string `yaml:"schemas,omitempty"`
+	// Tables restores only these relations (tables, views, sequences and indexes), named "schema.name" or
+	// just "name" for any schema. The constraints, triggers, policies, rules, column defaults, comments and
+	// privileges of a selected table are restored with it, but its indexes and sequences must be listed too.
+	Tables []string `yaml:"tables,omitempty"`
+	// TargetDatabase restores the selected database into a new database with this name, rather than into
+	// the database that was dumped, so that it can be recovered side by side. It requires that exactly one
+	// database is selected, and fails if the target database already exists.
+	TargetDatabase string `yaml:"targetDatabase,omitempty"`
+}
+
+// IsZero reports whether the whole dump is selected.
+func (s RestoreSelection) IsZero() bool {
+	return len(s.Databases) == 0 && !s.selectsObjects() && s.TargetDatabase == ""
+}
+
+// selectsObjects reports whether only some of the objects of each database are selected.
+func (s RestoreSelection) selectsObjects() bool {
+	return len(s.Schemas) > 0 || len(s.Tables) > 0
+}
+
+// validate checks the selection for a dump in the given format.
+func (s RestoreSelection) validate(format DumpFormat) error {
+	if slices.Contains(slices.Concat(s.Databases, s.Schemas, s.Tables), "") {
+		return trace.BadParameter("selected names must not be empty")
+	}
+
+	if s.selectsObjects() && format != DumpFormatDirectory {
+		return trace.BadParameter("selecting schemas and tables requires the %q format", DumpFormatDirectory)
+	}
+
+	if s.TargetDatabase != "" && len(s.Databases) != 1 {
+		return trace.BadParameter("a target database requires exactly one selected database")
+	}
+
+	return nil
+}
+
+// selectDatabases returns the databases that are restored, out of the databases in the dump, in the order
+// that they were dumped. A selected database that isn't in the dump is an error.
+func (s RestoreSelection) selectDatabases(dumped []string) ([]string, error) {
+	if len(s.Databases) == 0 {
+		return dumped, nil
+	}
+
+	for _, database := range s.Databases {
+		if !slices.Contains(dumped, database) {
+			return nil, trace.NotFound("database %q is not in the dump", database)
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(dumped), func(database string) bool {
+		return !slices.Contains(s.Databases, database)
+	}), nil
+}
+
+// Kinds of pg_restore list entries with names made of more than one word, longest first.
+var multiWordEntryKinds = [][]string{
+	{"MATERIALIZED", "VIEW", "DATA"},
+	{"SEQUENCE", "OWNED", "BY"},
+	{"DATABASE", "PROPERTIES"},
+	{"MATERIALIZED", "VIEW"},
+	{"FK", "CONSTRAINT"},
+	{"CHECK", "CONSTRAINT"},
+	{"SECURITY", "LABEL"},
+	{"TABLE", "DATA"},
+	{"SEQUENCE", "SET"},
+	{"DEFAULT", "ACL"},
+	{"FOREIGN", "TABLE"},
+	{"TABLE", "ATTACH"},
+	{"INDEX", "ATTACH"},
+	{"EVENT", "TRIGGER"},
+	{"ROW", "SECURITY"},
+}
+
+// Kinds of pg_restore list entries that are named after their table, followed by their own name.
+var tableObjectEntryKinds = []string{"CONSTRAINT", "FK CONSTRAINT", "CHECK CONSTRAINT", "TRIGGER", "POLICY", "RULE", "DEFAULT"}
+
+// Kinds of pg_restore list entries that are named after the type of the object they belong to, followed by
+// its name.
+var attachedEntryKinds = []string{"ACL", "COMMENT", "SECURITY LABEL"}
+
+// listEntry is an entry of a pg_restore list, less its IDs and owner.
+type listEntry struct {
+	kind   string
+	schema string // "-" for objects that don't belong to a schema.
+	name   string
+}
+
+// parseListEntry parses a line of a pg_restore list, in the form "<dump ID>; <catalog OID> <OID> <kind>
+// <schema> <name> <owner>". Names may contain spaces, so the owner is taken to be the last field.
+func parseListEntry(line string) (listEntry, bool) {
+	if strings.HasPrefix(line, ";") {
+		return listEntry{}, false
+	}
+
+	_, fieldsText, ok := strings.Cut(line, "; ")
+	if !ok {
+		return listEntry{}, false
+	}
+
+	fields := strings.Fields(fieldsText)
+	if len(fields) < 5 {
+		return listEntry{}, false
+	}
+	fields = fields[2:]
+
+	kindLength := 1
+	for _, kindWords := range multiWordEntryKinds {
+		if len(fields) > len(kindWords) && slices.Equal(fields[:len(kindWords)], kindWords) {
+			kindLength = len(kindWords)
+			break
+		}
+	}
+
+	entry := listEntry{kind: strings.Join(fields[:kindLength], " ")}
+	fields = fields[kindLength:]
+	switch len(fields) {
+	case 0:
+		return listEntry{}, false
+	case 1:
+		entry.schema = fields[0]
+	case 2:
+		entry.schema, entry.name = fields[0], fields[1]
+	default:
+		entry.schema, entry.name = fields[0], strings.Join(fields[1:len(fields)-1], " ")
+	}
+
+	return entry, true
+}
+
+// selectsEntry reports whether the objects of the pg_restore list entry are restored.
+func (s RestoreSelection) selectsEntry(entry listEntry) bool {
+	if slices.Contains(s.Schemas, entry.schema) {
+		return true
+	}
+
+	if slices.Contains(s.Schemas, entry.name) && (entry.kind == "SCHEMA" || slices.Contains(attachedEntryKinds, entry.kind)) {
+		return true
+	}
+
+	for _, table := range s.Tables {
+		schema, name, qualified := strings.Cut(table, ".")
+		if !qualified {
+			schema, name = "", table
+		}
+
+		if schema != "" && schema != entry.schema {
+			continue
+		}
+
+		switch {
+		case entry.name == name:
+			return true
+		case slices.Contains(tableObjectEntryKinds, entry.kind) && strings.HasPrefix(entry.name, name+" "):
+			return true
+		case slices.Contains(attachedEntryKinds, entry.kind) && strings.HasSuffix(entry.name, " "+name):
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDatabaseEntry reports whether the pg_restore list entry creates the database, or sets its properties.
+// pg_restore only restores these entries when it creates the database.
+func isDatabaseEntry(entry listEntry) bool {
+	switch {
+	case entry.kind == "DATABASE", entry.kind == "DATABASE PROPERTIES":
+		return true
+	case slices.Contains(attachedEntryKinds, entry.kind):
+		return strings.HasPrefix(entry.name, "DATABASE ")
+	}
+
+	return false
+}
+
+// selectListEntries returns the entries of a pg_restore list that are selected, as a pg_restore list.
+func selectListEntries(list string, selectEntry func(listEntry) bool) string {
+	var selected strings.Builder
+	for _, line := range strings.Split(list, "\n") {
+		if entry, ok := parseListEntry(line); ok && selectEntry(entry) {
+			selected.WriteString(line + "\n")
+		}
+	}
+
+	return selected.String()
+}
+
+// pg_dumpall writes this comment before the dump of each database, which starts by dropping, creating and
+// then connecting (with `\connect`) to the database.
+const (
+	sqlDatabaseHeaderPrefix = `-- Database "`
+	sqlDatabaseHeaderSuffix = `" dump`
+)
+
+// sqlDatabaseHeader returns the name of the database whose dump starts with the line, if any.
+func sqlDatabaseHeader(line string) (string, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	if len(line) < len(sqlDatabaseHeaderPrefix)+len(sqlDatabaseHeaderSuffix) ||
+		!strings.HasPrefix(line, sqlDatabaseHeaderPrefix) || !strings.HasSuffix(line, sqlDatabaseHeaderSuffix) {
+		return "", false
+	}
+
+	return line[len(sqlDatabaseHeaderPrefix) : len(line)-len(sqlDatabaseHeaderSuffix)], true
+}
+
+// listSQLDatabases returns the databases in a plain format dump, in the order that they were dumped.
+func listSQLDatabases(inputFilePath string) ([]string, error) {
+	inputFile, err := os.Open(inputFilePath)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to open SQL dump %q", inputFilePath)
+	}
+	defer inputFile.Close()
+
+	var databases []string
+	sqlReader := bufio.NewReader(inputFile)
+	for {
+		sqlLine, err := sqlReader.ReadString('\n')
+		if database, ok := sqlDatabaseHeader(sqlLine); ok {
+			databases = append(databases, database)
+		}
+
+		if err == io.EOF {
+			return databases, nil
+		}
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to read SQL dump %q", inputFilePath)
+		}
+	}
+}
+
+// writeSQLGlobals writes the part of a plain format dump before the dump of the first database, which drops
+// the databases and restores the globals.
+func writeSQLGlobals(w io.Writer, r io.Reader) error {
+	sqlReader := bufio.NewReader(r)
+	for {
+		sqlLine, readErr := sqlReader.ReadString('\n')
+		if _, ok := sqlDatabaseHeader(sqlLine); ok {
+			return nil
+		}
+
+		if _, err := io.WriteString(w, sqlLine); err != nil {
+			return trace.Wrap(err, "failed to write the dump of the globals")
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return trace.Wrap(readErr, "failed to read SQL dump")
+		}
+	}
+}
+
+// sqlDatabasePart is a part of the dump of a database in a plain format dump.
+type sqlDatabasePart int
+
+const (
+	// sqlDatabaseAll is the whole dump of the database.
+	sqlDatabaseAll sqlDatabasePart = iota
+	// sqlDatabaseCreation is the statements before the `\connect` to the database, which (drop and) create it.
+	sqlDatabaseCreation
+	// sqlDatabaseContents is the statements after the `\connect` to the database, which can be run against
+	// another database.
+	sqlDatabaseContents
+)
+
+// sqlDatabaseWriter returns a function that writes the part of the dump of the database.
+func sqlDatabaseWriter(database string, part sqlDatabasePart) func(io.Writer, io.Reader) error {
+	return func(w io.Writer, r io.Reader) error {
+		return writeSQLDatabase(w, r, database, part)
+	}
+}
+
+// writeSQLDatabase writes the part of a plain format dump that restores the database, or part of it.
+func writeSQLDatabase(w io.Writer, r io.Reader, database string, part sqlDatabasePart) error {
+	inDatabase := false
+	connected := false
+	sqlReader := bufio.NewReader(r)
+	for {
+		sqlLine, readErr := sqlReader.ReadString('\n')
+		if headerDatabase, ok := sqlDatabaseHeader(sqlLine); ok {
+			if inDatabase {
+				return nil
+			}
+			inDatabase = headerDatabase == database
+		}
+
+		connecting := inDatabase && !connected && strings.HasPrefix(sqlLine, `\connect `)
+		write := false
+		switch part {
+		case sqlDatabaseAll:
+			write = inDatabase
+		case sqlDatabaseCreation:
+			write = inDatabase && !connected && !connecting
+		case sqlDatabaseContents:
+			// The restrict key must be set for the `\unrestrict` at the end of the database's dump to succeed.
+			write = inDatabase && (connected || strings.HasPrefix(sqlLine, `\restrict `))
+		}
+
+		if write {
+			if _, err := io.WriteString(w, sqlLine); err != nil {
+				return trace.Wrap(err, "failed to write the dump of database %q", database)
+			}
+		}
+
+		if connecting {
+			connected = true
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return trace.Wrap(readErr, "failed to read SQL dump")
+		}
+	}
+}
@@ -0,0 +1,494 @@
+package postgres
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gravitational/trace"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreSelectionValidate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		selection   RestoreSelection
+		format      DumpFormat
+		expectedErr bool
+	}{
+		{
+			desc: "zero value",
+		},
+		{
+			desc:      "databases with the plain format",
+			selection: RestoreSelection{Databases: []string{"app", "other"}},
+		},
+		{
+			desc:      "target database",
+			selection: RestoreSelection{Databases: []string{"app"}, TargetDatabase: "app_recovered"},
+		},
+		{
+			desc:      "tables with the directory format",
+			selection: RestoreSelection{Databases: []string{"app"}, Schemas: []string{"audit"}, Tables: []string{"public.users"}},
+			format:    DumpFormatDirectory,
+		},
+		{
+			desc:        "empty name",
+			selection:   RestoreSelection{Tables: []string{""}},
+			format:      DumpFormatDirectory,
+			expectedErr: true,
+		},
+		{
+			desc:        "tables with the plain format",
+			selection:   RestoreSelection{Tables: []string{"public.users"}},
+			format:      DumpFormatPlain,
+			expectedErr: true,
+		},
+		{
+			desc:        "target database without a selected database",
+			selection:   RestoreSelection{TargetDatabase: "app_recovered"},
+			expectedErr: true,
+		},
+		{
+			desc:        "target database with multiple selected databases",
+			selection:   RestoreSelection{Databases: []string{"app", "other"}, TargetDatabase: "app_recovered"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.selection.validate(tt.format)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRestoreSelectionIsZero(t *testing.T) {
+	assert.True(t, RestoreSelection{}.IsZero())
+	assert.False(t, RestoreSelection{Databases: []string{"app"}}.IsZero())
+	assert.False(t, RestoreSelection{Tables: []string{"users"}}.IsZero())
+}
+
+func TestRestoreSelectionSelectDatabases(t *testing.T) {
+	dumped := []string{"analytics", "app", "other"}
+
+	selected, err := RestoreSelection{}.selectDatabases(dumped)
+	require.NoError(t, err)
+	assert.Equal(t, dumped, selected)
+
+	selected, err = RestoreSelection{Databases: []string{"other", "app"}}.selectDatabases(dumped)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app", "other"}, selected)
+	assert.Equal(t, []string{"analytics", "app", "other"}, dumped)
+
+	_, err = RestoreSelection{Databases: []string{"missing"}}.selectDatabases(dumped)
+	assert.True(t, trace.IsNotFound(err))
+}
+
+func TestParseListEntry(t *testing.T) {
+	tests := []struct {
+		line          string
+		expectedEntry listEntry
+		expectedOk    bool
+	}{
+		{line: ";"},
+		{line: "; Archive created at 2026-01-01 00:00:00 UTC"},
+		{line: ""},
+		{line: "215; 1259 16386 TABLE public users app", expectedEntry: listEntry{"TABLE", "public", "users"}, expectedOk: true},
+		{line: "3345; 0 16386 TABLE DATA public users app", expectedEntry: listEntry{"TABLE DATA", "public", "users"}, expectedOk: true},
+		{line: "3205; 2606 16395 CONSTRAINT public users users_pkey app", expectedEntry: listEntry{"CONSTRAINT", "public", "users users_pkey"}, expectedOk: true},
+		{line: "3210; 2606 16400 FK CONSTRAINT public posts posts_user_id_fkey app", expectedEntry: listEntry{"FK CONSTRAINT", "public", "posts posts_user_id_fkey"}, expectedOk: true},
+		{line: "6; 2615 2200 SCHEMA - audit app", expectedEntry: listEntry{"SCHEMA", "-", "audit"}, expectedOk: true},
+		{line: "3400; 0 0 ACL public TABLE users app", expectedEntry: listEntry{"ACL", "public", "TABLE users"}, expectedOk: true},
+		{line: "3401; 0 0 SEQUENCE SET public users_id_seq app", expectedEntry: listEntry{"SEQUENCE SET", "public", "users_id_seq"}, expectedOk: true},
+		{line: "3402; 0 0 COMMENT - EXTENSION pgcrypto", expectedEntry: listEntry{"COMMENT", "-", "EXTENSION"}, expectedOk: true},
+		{line: "3341; 0 0 DATABASE PROPERTIES - app postgres", expectedEntry: listEntry{"DATABASE PROPERTIES", "-", "app"}, expectedOk: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			entry, ok := parseListEntry(tt.line)
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedEntry, entry)
+		})
+	}
+}
+
+const testRestoreList = `;
+; Archive created at 2026-01-01 00:00:00 UTC
+;
+3340; 1262 16384 DATABASE - app postgres
+3341; 0 0 DATABASE PROPERTIES - app postgres
+3342; 0 0 COMMENT - DATABASE app postgres
+6; 2615 16385 SCHEMA - audit app
+215; 1259 16386 TABLE public users app
+216; 1259 16390 SEQUENCE public users_id_seq app
+217; 1259 16392 TABLE public posts app
+218; 1259 16398 TABLE audit events app
+3200; 2604 16394 DEFAULT public users id app
+3345; 0 16386 TABLE DATA public users app
+3346; 0 16392 TABLE DATA public posts app
+3347; 0 16398 TABLE DATA audit events app
+3205; 2606 16395 CONSTRAINT public users users_pkey app
+3206; 1259 16396 INDEX public users_email_idx app
+3210; 2606 16400 FK CONSTRAINT public posts posts_user_id_fkey app
+3400; 0 0 ACL public TABLE users app
+`
+
+func TestRestoreSelectionSelectListEntries(t *testing.T) {
+	assert.Equal(t, `215; 1259 16386 TABLE public users app
+3200; 2604 16394 DEFAULT public users id app
+3345; 0 16386 TABLE DATA public users app
+3205; 2606 16395 CONSTRAINT public users users_pkey app
+3206; 1259 16396 INDEX public users_email_idx app
+3400; 0 0 ACL public TABLE users app
+`, selectListEntries(testRestoreList, RestoreSelection{Tables: []string{"public.users", "users_email_idx"}}.selectsEntry))
+
+	assert.Equal(t, `6; 2615 16385 SCHEMA - audit app
+218; 1259 16398 TABLE audit events app
+3347; 0 16398 TABLE DATA audit events app
+`, selectListEntries(testRestoreList, RestoreSelection{Schemas: []string{"audit"}}.selectsEntry))
+
+	assert.Empty(t, selectListEntries(testRestoreList, RestoreSelection{Tables: []string{"audit.users"}}.selectsEntry))
+}
+
+func TestSelectDatabaseListEntries(t *testing.T) {
+	assert.Equal(t, `3340; 1262 16384 DATABASE - app postgres
+3341; 0 0 DATABASE PROPERTIES - app postgres
+3342; 0 0 COMMENT - DATABASE app postgres
+`, selectListEntries(testRestoreList, isDatabaseEntry))
+}
+
+func TestSQLDatabaseHeader(t *testing.T) {
+	database, ok := sqlDatabaseHeader("-- Database \"my db\" dump\n")
+	assert.True(t, ok)
+	assert.Equal(t, "my db", database)
+
+	_, ok = sqlDatabaseHeader("-- PostgreSQL database dump\n")
+	assert.False(t, ok)
+}
+
+const testSQLDump = `--
+-- PostgreSQL database cluster dump
+--
+
+CREATE ROLE app;
+
+--
+-- Database "app" dump
+--
+
+\restrict key1
+SET statement_timeout = 0;
+DROP DATABASE IF EXISTS app;
+CREATE DATABASE app;
+\connect app
+
+SET statement_timeout = 0;
+CREATE TABLE public.users (id integer);
+\unrestrict key1
+
+--
+-- Database "other" dump
+--
+
+CREATE DATABASE other;
+\connect other
+
+CREATE TABLE public.posts (id integer);
+
+--
+-- PostgreSQL database cluster dump complete
+--
+`
+
+func TestListSQLDatabases(t *testing.T) {
+	inputFilePath := filepath.Join(t.TempDir(), "dump.sql")
+	require.NoError(t, os.WriteFile(inputFilePath, []byte(testSQLDump), 0600))
+
+	databases, err := listSQLDatabases(inputFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app", "other"}, databases)
+
+	_, err = listSQLDatabases(filepath.Join(t.TempDir(), "missing.sql"))
+	assert.Error(t, err)
+}
+
+func TestWriteSQLDatabase(t *testing.T) {
+	var section strings.Builder
+	require.NoError(t, writeSQLDatabase(&section, strings.NewReader(testSQLDump), "app", sqlDatabaseAll))
+	assert.Equal(t, `-- Database "app" dump
+--
+
+\restrict key1
+SET statement_timeout = 0;
+DROP DATABASE IF EXISTS app;
+CREATE DATABASE app;
+\connect app
+
+SET statement_timeout = 0;
+CREATE TABLE public.users (id integer);
+\unrestrict key1
+
+--
+`, section.String())
+
+	section.Reset()
+	require.NoError(t, writeSQLDatabase(&section, strings.NewReader(testSQLDump), "app", sqlDatabaseContents))
+	assert.Equal(t, `\restrict key1
+
+SET statement_timeout = 0;
+CREATE TABLE public.users (id integer);
+\unrestrict key1
+
+--
+`, section.String())
+
+	section.Reset()
+	require.NoError(t, writeSQLDatabase(&section, strings.NewReader(testSQLDump), "other", sqlDatabaseContents))
+	assert.Equal(t, `
+CREATE TABLE public.posts (id integer);
+
+--
+-- PostgreSQL database cluster dump complete
+--
+`, section.String())
+
+	section.Reset()
+	require.NoError(t, writeSQLDatabase(&section, strings.NewReader(testSQLDump), "app", sqlDatabaseCreation))
+	assert.Equal(t, `-- Database "app" dump
+--
+
+\restrict key1
+SET statement_timeout = 0;
+DROP DATABASE IF EXISTS app;
+CREATE DATABASE app;
+`, section.String())
+}
+
+func TestWriteSQLGlobals(t *testing.T) {
+	var globals strings.Builder
+	require.NoError(t, writeSQLGlobals(&globals, strings.NewReader(testSQLDump)))
+	assert.Equal(t, "--\n-- PostgreSQL database cluster dump\n--\n\nCREATE ROLE app;\n\n--\n", globals.String())
+
+	globals.Reset()
+	require.NoError(t, writeSQLGlobals(&globals, strings.NewReader("CREATE ROLE app;")))
+	assert.Equal(t, "CREATE ROLE app;", globals.String())
+}
+
+func TestRestoreSQLSelection(t *testing.T) {
+	tests := []struct {
+		desc               string
+		selection          RestoreSelection
+		simulateCreateErr  bool
+		simulateRestoreErr bool
+		expectedErr        bool
+		expectedCommands   []recordedCommand
+		expectedInput      []string
+	}{
+		{
+			desc:      "selected database",
+			selection: RestoreSelection{Databases: []string{"other"}},
+			expectedCommands: []recordedCommand{
+				{args: []string{restoreCommandName, "-X", "-f", "-"}, env: []string{"PGDATABASE=postgres"}},
+			},
+			expectedInput: []string{"-- Database \"other\" dump\n--\n\nCREATE DATABASE other;\n\\connect other\n\nCREATE TABLE public.posts (id integer);\n\n--\n-- PostgreSQL database cluster dump complete\n--\n"},
+		},
+		{
+			desc:      "target database",
+			selection: RestoreSelection{Databases: []string{"other"}, TargetDatabase: `other "recovered"`},
+			expectedCommands: []recordedCommand{
+				{args: []string{restoreCommandName, "-X", `--command=CREATE DATABASE "other ""recovered"""`}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{restoreCommandName, "-X", "-f", "-"}, env: []string{`PGDATABASE=other "recovered"`}},
+			},
+			expectedInput: []string{"", "\nCREATE TABLE public.posts (id integer);\n\n--\n-- PostgreSQL database cluster dump complete\n--\n"},
+		},
+		{
+			desc:        "missing database",
+			selection:   RestoreSelection{Databases: []string{"missing"}},
+			expectedErr: true,
+		},
+		{
+			desc:              "fails to create the target database",
+			selection:         RestoreSelection{Databases: []string{"other"}, TargetDatabase: "other_recovered"},
+			simulateCreateErr: true,
+			expectedErr:       true,
+		},
+		{
+			desc:               "fails to restore",
+			selection:          RestoreSelection{Databases: []string{"other"}},
+			simulateRestoreErr: true,
+			expectedErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inputFilePath := filepath.Join(t.TempDir(), "dump.sql")
+			require.NoError(t, os.WriteFile(inputFilePath, []byte(testSQLDump), 0600))
+
+			var commands []recordedCommand
+			var inputs []string
+			lr := &LocalRuntime{
+				wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+					commands = append(commands, recordedCommand{args: cmd.Args})
+
+					cw := NewCmdWrapper(cmd)
+					cw.combinedOutputCallback = func(cw *cmdWrapper) ([]byte, error) {
+						commands[len(commands)-1].env = cw.Env
+
+						var input []byte
+						if cw.Stdin != nil {
+							var err error
+							input, err = io.ReadAll(cw.Stdin)
+							require.NoError(t, err)
+						}
+						inputs = append(inputs, string(input))
+
+						if strings.HasPrefix(cw.Args[2], "--command=") {
+							return nil, th.ErrIfTrue(tt.simulateCreateErr)
+						}
+						return nil, th.ErrIfTrue(tt.simulateRestoreErr)
+					}
+					return cw
+				},
+			}
+
+			creds := EnvironmentCredentials{HostVarName: "fakehost"}
+			err := lr.Restore(th.NewTestContext(), creds, inputFilePath, RestoreOptions{Selection: tt.selection, Force: true})
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, commands, len(tt.expectedCommands))
+			for i, expectedCommand := range tt.expectedCommands {
+				assert.Equal(t, expectedCommand.args, commands[i].args)
+				assert.Subset(t, commands[i].env, append(expectedCommand.env, "PGHOST=fakehost"))
+			}
+			assert.Equal(t, tt.expectedInput, inputs)
+		})
+	}
+}
+
+func TestRestoreDirectorySelection(t *testing.T) {
+	tests := []struct {
+		desc             string
+		selection        RestoreSelection
+		simulateListErr  bool
+		expectedErr      bool
+		expectedCommands []recordedCommand
+		expectedList     string
+	}{
+		{
+			desc:      "selected database",
+			selection: RestoreSelection{Databases: []string{"my db"}},
+			expectedCommands: []recordedCommand{
+				{args: []string{databaseRestoreCommandName, "--create", "--clean", "--if-exists", "--dbname=postgres", "--jobs=2", "my%20db"}, env: []string{"PGDATABASE=postgres"}},
+			},
+		},
+		{
+			desc:      "selected tables",
+			selection: RestoreSelection{Databases: []string{"app"}, Tables: []string{"public.users"}},
+			expectedCommands: []recordedCommand{
+				{args: []string{databaseRestoreCommandName, "--list", "app"}},
+				{args: []string{databaseRestoreCommandName, "--clean", "--if-exists", "--dbname=app", "--jobs=2", "--use-list=", "app"}, env: []string{"PGDATABASE=app"}},
+			},
+			expectedList: "215; 1259 16386 TABLE public users app\n3200; 2604 16394 DEFAULT public users id app\n3345; 0 16386 TABLE DATA public users app\n" +
+				"3205; 2606 16395 CONSTRAINT public users users_pkey app\n3400; 0 0 ACL public TABLE users app\n",
+		},
+		{
+			desc:      "selected schema in a target database",
+			selection: RestoreSelection{Databases: []string{"app"}, Schemas: []string{"audit"}, TargetDatabase: "app_recovered"},
+			expectedCommands: []recordedCommand{
+				{args: []string{restoreCommandName, "-X", `--command=CREATE DATABASE "app_recovered"`}, env: []string{"PGDATABASE=postgres"}},
+				{args: []string{databaseRestoreCommandName, "--list", "app"}},
+				{args: []string{databaseRestoreCommandName, "--dbname=app_recovered", "--jobs=2", "--use-list=", "app"}, env: []string{"PGDATABASE=app_recovered"}},
+			},
+			expectedList: "6; 2615 16385 SCHEMA - audit app\n218; 1259 16398 TABLE audit events app\n3347; 0 16398 TABLE DATA audit events app\n",
+		},
+		{
+			desc:        "missing database",
+			selection:   RestoreSelection{Databases: []string{"missing"}},
+			expectedErr: true,
+		},
+		{
+			desc:            "fails to list the archive",
+			selection:       RestoreSelection{Databases: []string{"app"}, Tables: []string{"public.users"}},
+			simulateListErr: true,
+			expectedErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inputDirPath := t.TempDir()
+			databasesDirPath := filepath.Join(inputDirPath, databasesDirName)
+			require.NoError(t, os.MkdirAll(filepath.Join(databasesDirPath, "app"), 0700))
+			require.NoError(t, os.MkdirAll(filepath.Join(databasesDirPath, "my%20db"), 0700))
+
+			var commands []recordedCommand
+			var list string
+			lr := &LocalRuntime{
+				wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+					commands = append(commands, recordedCommand{args: cmd.Args})
+
+					cw := NewCmdWrapper(cmd)
+					cw.outputCallback = func(*cmdWrapper) ([]byte, error) {
+						return th.ErrOr1Val([]byte(testRestoreList), tt.simulateListErr)
+					}
+					cw.combinedOutputCallback = func(cw *cmdWrapper) ([]byte, error) {
+						commands[len(commands)-1].env = cw.Env
+						for _, arg := range cw.Args {
+							if listFilePath, ok := strings.CutPrefix(arg, "--use-list="); ok {
+								contents, err := os.ReadFile(listFilePath)
+								require.NoError(t, err)
+								list = string(contents)
+							}
+						}
+						return nil, nil
+					}
+					return cw
+				},
+			}
+
+			creds := EnvironmentCredentials{HostVarName: "fakehost"}
+			err := lr.Restore(th.NewTestContext(), creds, inputDirPath, RestoreOptions{Format: DumpFormatDirectory, Jobs: 2, Selection: tt.selection, Force: true})
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// The globals are not restored, and the list file is removed afterwards.
+			require.Len(t, commands, len(tt.expectedCommands))
+			for i, expectedCommand := range tt.expectedCommands {
+				args := commands[i].args
+				args[len(args)-1] = strings.TrimPrefix(args[len(args)-1], databasesDirPath+string(filepath.Separator))
+				for j, arg := range args {
+					if listFilePath, ok := strings.CutPrefix(arg, "--use-list="); ok {
+						assert.NoFileExists(t, listFilePath)
+						args[j] = "--use-list="
+					}
+				}
+
+				assert.Equal(t, expectedCommand.args, args)
+				if expectedCommand.env != nil {
+					assert.Subset(t, commands[i].env, append(expectedCommand.env, "PGHOST=fakehost"))
+				}
+			}
+			assert.Equal(t, tt.expectedList, list)
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package postgres
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gravitational/trace"
+)
+
+// Roles that are managed by CNPG, and whose definitions are always left out of dumps.
+var cnpgManagedRoles = []string{"postgres", "streaming_replica"}
+
+// Prefix of the statements of a dump that are commented out.
+const ignoredStatementPrefix = "-- Ignored statement: "
+
+// RoleHandling configures how the statements of a dump that involve roles are rewritten, so that roles
+// that are managed outside of the dumped cluster don't collide with the restored ones. The definitions of
+// the roles managed by CNPG ("postgres" and "streaming_replica") are always left out.
+type RoleHandling struct {
+	// SkipRoles leaves the definitions of these roles (such as those from CNPG's managed roles) out of the
+	// dump, so that restoring it doesn't recreate or alter them. Their grants and owned objects are kept.
+	SkipRoles []string `yaml:"skipRoles,omitempty"`
+	// RenameRoles maps the names of dumped roles to the names that they're restored as. Role definitions,
+	// memberships, privileges and ownership are all remapped. It requires the plain format, as the dumps of
+	// directory format databases can't be rewritten.
+	RenameRoles map[string]string `yaml:"renameRoles,omitempty"`
+	// StripPasswords leaves the passwords of roles out of the dump, for roles whose passwords are managed
+	// elsewhere. Restoring the dump then doesn't change the passwords of existing roles.
+	StripPasswords bool `yaml:"stripPasswords,omitempty"`
+}
+
+// IsZero reports whether the role handling only leaves out the definitions of the roles managed by CNPG.
+func (rh RoleHandling) IsZero() bool {
+	return len(rh.SkipRoles) == 0 && len(rh.RenameRoles) == 0 && !rh.StripPasswords
+}
+
+// validate checks the role handling for a dump in the given format.
+func (rh RoleHandling) validate(format DumpFormat) error {
+	if slices.Contains(rh.SkipRoles, "") {
+		return trace.BadParameter("skipped role names must not be empty")
+	}
+
+	if len(rh.RenameRoles) > 0 && format == DumpFormatDirectory {
+		return trace.BadParameter("renaming roles is not supported by the %q format", DumpFormatDirectory)
+	}
+
+	for role, newName := range rh.RenameRoles {
+		if role == "" || newName == "" {
+			return trace.BadParameter("renamed role names must not be empty")
+		}
+
+		if rh.skips(role) {
+			return trace.BadParameter("role %q is both skipped and renamed", role)
+		}
+	}
+
+	return nil
+}
+
+// skips reports whether the definition of the role is left out of the dump.
+func (rh RoleHandling) skips(role string) bool {
+	return slices.Contains(cnpgManagedRoles, role) || slices.Contains(rh.SkipRoles, role)
+}
+
+// dumpArgs returns the args of the pg_dumpall command that are needed for the role handling.
+func (rh RoleHandling) dumpArgs() []string {
+	if rh.StripPasswords {
+		return []string{"--no-role-passwords"}
+	}
+
+	return nil
+}
+
+// roleRewriter rewrites the statements of a SQL dump that involve roles. Lines are fed to it in order, and
+// it returns the rewritten text as each statement is completed.
+type roleRewriter struct {
+	handling   RoleHandling
+	statement  strings.Builder // Lines of a statement that hasn't been completed yet.
+	inCopyData bool
+}
+
+func newRoleRewriter(handling RoleHandling) *roleRewriter {
+	return &roleRewriter{handling: handling}
+}
+
+// rewriteLine returns the rewritten text of the statements that the line completes, if any.
+func (rr *roleRewriter) rewriteLine(line string) string {
+	// The data of a COPY statement is passed through as-is, up to the line that ends it.
+	if rr.inCopyData {
+		if strings.TrimRight(line, "\r\n") == `\.` {
+			rr.inCopyData = false
+		}
+		return line
+	}
+
+	// Blank lines, comments and psql commands between statements are passed through as-is.
+	if rr.statement.Len() == 0 {
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "--") || strings.HasPrefix(trimmedLine, `\`) {
+			return line
+		}
+	}
+
+	rr.statement.WriteString(line)
+	if !strings.Contains(line, ";") {
+		return ""
+	}
+
+	tokens, complete := tokenizeSQL(rr.statement.String())
+	if !complete {
+		return ""
+	}
+	rr.statement.Reset()
+
+	var rewritten strings.Builder
+	for _, statement := range splitSQLStatements(tokens) {
+		rewritten.WriteString(rr.rewriteStatement(statement))
+	}
+	return rewritten.String()
+}
+
+// flush returns the text of a statement that was never completed, as-is.
+func (rr *roleRewriter) flush() string {
+	remainder := rr.statement.String()
+	rr.statement.Reset()
+	return remainder
+}
+
+// rewriteStatement returns the rewritten text of the statement.
+func (rr *roleRewriter) rewriteStatement(statement []sqlToken) string {
+	significant := significantTokens(statement)
+	if isCopyFromStdin(significant) {
+		rr.inCopyData = true
+	}
+
+	if role, ok := definedRole(significant); ok && rr.handling.skips(role) {
+		return commentOut(joinSQLTokens(statement))
+	}
+
+	for _, i := range roleTokenIndexes(significant) {
+		if newName, ok := rr.handling.RenameRoles[significant[i].token.name()]; ok {
+			statement[significant[i].index].text = quoteIdentifier(newName)
+		}
+	}
+
+	return joinSQLTokens(statement)
+}
+
+// commentOut comments out each line of the statement text. The commented text always ends the line, so
+// that it doesn't comment out any statement that follows it on the same line.
+func commentOut(text string) string {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		prefix := "-- "
+		if i == 0 {
+			prefix = ignoredStatementPrefix
+		}
+		lines[i] = prefix + line
+	}
+
+	return strings.Join(lines, "")
+}
+
+// splitSQLStatements splits tokens into statements, each ending with its separator. The tokens after the
+// last separator (such as the rest of the line) are kept with the last statement.
+func splitSQLStatements(tokens []sqlToken) [][]sqlToken {
+	var statements [][]sqlToken
+	start, lastStart := 0, 0
+	for i, token := range tokens {
+		if token.isStatementEnd() {
+			statements = append(statements, tokens[start:i+1])
+			lastStart, start = start, i+1
+		}
+	}
+
+	if start < len(tokens) {
+		if len(statements) == 0 {
+			return [][]sqlToken{tokens}
+		}
+		statements[len(statements)-1] = tokens[lastStart:]
+	}
+
+	return statements
+}
+
+func joinSQLTokens(tokens []sqlToken) string {
+	var text strings.Builder
+	for _, token := range tokens {
+		text.WriteString(token.text)
+	}
+	return text.String()
+}
+
+// indexedToken is a significant token of a statement, along with its index in all of the statement's tokens.
+type indexedToken struct {
+	token sqlToken
+	index int
+}
+
+func significantTokens(statement []sqlToken) []indexedToken {
+	var significant []indexedToken
+	for i, token := range statement {
+		if token.isSignificant() {
+			significant = append(significant, indexedToken{token: token, index: i})
+		}
+	}
+	return significant
+}
+
+// hasKeywords reports whether the tokens starting at the index are the keywords.
+func hasKeywords(tokens []indexedToken, start int, keywords ...string) bool {
+	if start < 0 || start+len(keywords) > len(tokens) {
+		return false
+	}
+
+	for i, keyword := range keywords {
+		if !tokens[start+i].token.isKeyword(keyword) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCopyFromStdin reports whether the statement is followed by the data that it copies.
+func isCopyFromStdin(tokens []indexedToken) bool {
+	if !hasKeywords(tokens, 0, "COPY") {
+		return false
+	}
+
+	for i := range tokens {
+		if hasKeywords(tokens, i, "FROM", "stdin") {
+			return true
+		}
+	}
+	return false
+}
+
+// definedRole returns the role that the statement defines (creates, alters, drops or comments on), if any.
+func definedRole(tokens []indexedToken) (string, bool) {
+	roleIndex := definedRoleIndex(tokens)
+	if roleIndex == -1 {
+		return "", false
+	}
+	return tokens[roleIndex].token.name(), true
+}
+
+// definedRoleIndex returns the index of the role that the statement defines, or -1.
+func definedRoleIndex(tokens []indexedToken) int {
+	// CREATE USER MAPPING FOR role SERVER server
+	if hasKeywords(tokens, 1, "USER", "MAPPING") {
+		return -1
+	}
+
+	roleIndex := -1
+	for _, roleKeyword := range []string{"ROLE", "USER", "GROUP"} {
+		switch {
+		case hasKeywords(tokens, 0, "CREATE", roleKeyword), hasKeywords(tokens, 0, "ALTER", roleKeyword):
+			roleIndex = 2
+		case hasKeywords(tokens, 0, "DROP", roleKeyword, "IF", "EXISTS"):
+			roleIndex = 4
+		case hasKeywords(tokens, 0, "DROP", roleKeyword):
+			roleIndex = 2
+		case hasKeywords(tokens, 0, "COMMENT", "ON", roleKeyword):
+			roleIndex = 3
+		}
+	}
+
+	// SECURITY LABEL [FOR provider] ON ROLE name
+	if hasKeywords(tokens, 0, "SECURITY", "LABEL") {
+		for i := range tokens {
+			if hasKeywords(tokens, i, "ON", "ROLE") {
+				roleIndex = i + 2
+				break
+			}
+		}
+	}
+
+	if roleIndex >= len(tokens) || roleIndex != -1 && !tokens[roleIndex].token.isName() {
+		return -1
+	}
+	return roleIndex
+}
+
+// roleTokenIndexes returns the indexes of the tokens of the statement that name roles.
+func roleTokenIndexes(tokens []indexedToken) []int {
+	var indexes []int
+	if roleIndex := definedRoleIndex(tokens); roleIndex != -1 {
+		indexes = append(indexes, roleIndex)
+	}
+
+	if hasKeywords(tokens, 0, "SET", "ROLE") {
+		indexes = append(indexes, nameIndexes(tokens, 2)...)
+	}
+
+	isPolicy := hasKeywords(tokens, 0, "CREATE", "POLICY") || hasKeywords(tokens, 0, "ALTER", "POLICY")
+	for i := range tokens {
+		switch {
+		case hasKeywords(tokens, i, "OWNER", "TO"), hasKeywords(tokens, i, "GRANTED", "BY"), hasKeywords(tokens, i, "MAPPING", "FOR"):
+			indexes = append(indexes, nameIndexes(tokens, i+2)...)
+		case hasKeywords(tokens, i, "OWNER") && i+1 < len(tokens) && tokens[i+1].token.text == "=":
+			// CREATE DATABASE name WITH OWNER = role
+			indexes = append(indexes, nameIndexes(tokens, i+2)...)
+		case hasKeywords(tokens, i, "AUTHORIZATION"):
+			indexes = append(indexes, nameIndexes(tokens, i+1)...)
+		case hasKeywords(tokens, i, "FOR", "ROLE"), hasKeywords(tokens, i, "FOR", "USER"):
+			// ALTER DEFAULT PRIVILEGES FOR ROLE role, ...
+			indexes = append(indexes, nameListIndexes(tokens, i+2)...)
+		case isPolicy && hasKeywords(tokens, i, "TO"):
+			indexes = append(indexes, nameListIndexes(tokens, i+1)...)
+		case hasKeywords(tokens, i, "GRANT"), hasKeywords(tokens, i, "REVOKE"):
+			indexes = append(indexes, grantRoleIndexes(tokens, i)...)
+		}
+	}
+
+	return indexes
+}
+
+// grantRoleIndexes returns the indexes of the roles of the GRANT or REVOKE at the index.
+func grantRoleIndexes(tokens []indexedToken, start int) []int {
+	granteeKeyword := "TO"
+	if tokens[start].token.isKeyword("REVOKE") {
+		granteeKeyword = "FROM"
+	}
+
+	isPrivilegeGrant := false
+	for i := start + 1; i < len(tokens); i++ {
+		switch {
+		case tokens[i].token.isKeyword("ON"):
+			isPrivilegeGrant = true
+		case tokens[i].token.isKeyword(granteeKeyword):
+			indexes := nameListIndexes(tokens, i+1)
+			if !isPrivilegeGrant {
+				// GRANT role, ... TO role, ...
+				indexes = append(indexes, nameListIndexes(tokens, start+1)...)
+			}
+			return indexes
+		}
+	}
+
+	return nil
+}
+
+// Keywords that end a list of roles.
+var roleListEndKeywords = []string{"TO", "FROM", "WITH", "GRANTED", "CASCADE", "RESTRICT", "USING", "IN", "GRANT", "REVOKE"}
+
+// nameListIndexes returns the indexes of the names of a comma separated list starting at the index. The
+// name of each item is its last token, so that items such as "GROUP name" and "ADMIN OPTION FOR name" are
+// handled.
+func nameListIndexes(tokens []indexedToken, start int) []int {
+	var indexes []int
+	lastName := -1
+	for i := start; i < len(tokens); i++ {
+		token := tokens[i].token
+		isListEnd := slices.ContainsFunc(roleListEndKeywords, token.isKeyword)
+		if token.text == "," || isListEnd || !token.isName() {
+			if lastName != -1 {
+				indexes = append(indexes, lastName)
+				lastName = -1
+			}
+			if token.text != "," {
+				break
+			}
+			continue
+		}
+		lastName = i
+	}
+
+	if lastName != -1 {
+		indexes = append(indexes, lastName)
+	}
+	return indexes
+}
+
+// nameIndexes returns the index of the name at the index, if there is one.
+func nameIndexes(tokens []indexedToken, start int) []int {
+	if start >= len(tokens) || !tokens[start].token.isName() {
+		return nil
+	}
+	return []int{start}
+}
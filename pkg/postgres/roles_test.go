@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleHandlingValidate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		handling    RoleHandling
+		format      DumpFormat
+		expectedErr bool
+	}{
+		{
+			desc: "zero value",
+		},
+		{
+			desc:     "all options",
+			handling: RoleHandling{SkipRoles: []string{"app"}, RenameRoles: map[string]string{"reporting": "reporting_ro"}, StripPasswords: true},
+		},
+		{
+			desc:     "skipped roles with the directory format",
+			handling: RoleHandling{SkipRoles: []string{"app"}, StripPasswords: true},
+			format:   DumpFormatDirectory,
+		},
+		{
+			desc:        "empty skipped role",
+			handling:    RoleHandling{SkipRoles: []string{""}},
+			expectedErr: true,
+		},
+		{
+			desc:        "renamed roles with the directory format",
+			handling:    RoleHandling{RenameRoles: map[string]string{"app": "application"}},
+			format:      DumpFormatDirectory,
+			expectedErr: true,
+		},
+		{
+			desc:        "empty new role name",
+			handling:    RoleHandling{RenameRoles: map[string]string{"app": ""}},
+			expectedErr: true,
+		},
+		{
+			desc:        "skipped and renamed role",
+			handling:    RoleHandling{SkipRoles: []string{"app"}, RenameRoles: map[string]string{"app": "application"}},
+			expectedErr: true,
+		},
+		{
+			desc:        "renamed CNPG managed role",
+			handling:    RoleHandling{RenameRoles: map[string]string{"postgres": "admin"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.handling.validate(tt.format)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRoleHandlingDumpArgs(t *testing.T) {
+	assert.Nil(t, RoleHandling{}.dumpArgs())
+	assert.Equal(t, []string{"--no-role-passwords"}, RoleHandling{StripPasswords: true}.dumpArgs())
+}
+
+// rewriteRoles runs the SQL through a role rewriter, a line at a time.
+func rewriteRoles(handling RoleHandling, sql string) string {
+	rewriter := newRoleRewriter(handling)
+
+	var rewritten strings.Builder
+	for _, line := range strings.SplitAfter(sql, "\n") {
+		rewritten.WriteString(rewriter.rewriteLine(line))
+	}
+	rewritten.WriteString(rewriter.flush())
+
+	return rewritten.String()
+}
+
+func TestRoleRewriterFixtures(t *testing.T) {
+	tests := []struct {
+		desc             string
+		handling         RoleHandling
+		expectedFileName string
+	}{
+		{
+			desc:             "CNPG managed roles are always skipped",
+			expectedFileName: "roles_dump_default.sql",
+		},
+		{
+			desc:             "skipped roles",
+			handling:         RoleHandling{SkipRoles: []string{"app"}},
+			expectedFileName: "roles_dump_skip.sql",
+		},
+		{
+			desc:             "renamed roles",
+			handling:         RoleHandling{RenameRoles: map[string]string{"app": "application", "Reporting": "reporting_ro"}},
+			expectedFileName: "roles_dump_rename.sql",
+		},
+	}
+
+	dump, err := os.ReadFile(filepath.Join("testdata", "roles_dump.sql"))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			expected, err := os.ReadFile(filepath.Join("testdata", tt.expectedFileName))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expected), rewriteRoles(tt.handling, string(dump)))
+		})
+	}
+}
+
+func TestRoleRewriterStatements(t *testing.T) {
+	handling := RoleHandling{SkipRoles: []string{"skipped"}, RenameRoles: map[string]string{"old": "new"}}
+
+	tests := []struct {
+		desc     string
+		sql      string
+		expected string
+	}{
+		{
+			desc:     "multi-line skipped statement",
+			sql:      "ALTER ROLE skipped\n    WITH LOGIN;\n",
+			expected: "-- Ignored statement: ALTER ROLE skipped\n--     WITH LOGIN;\n",
+		},
+		{
+			desc:     "quoted and upper case names",
+			sql:      "DROP ROLE IF EXISTS \"skipped\";\nCREATE ROLE OLD;\nCREATE ROLE \"Old\";\n",
+			expected: "-- Ignored statement: DROP ROLE IF EXISTS \"skipped\";\nCREATE ROLE \"new\";\nCREATE ROLE \"Old\";\n",
+		},
+		{
+			desc:     "new names are quoted",
+			sql:      "GRANT old TO skipped;\n",
+			expected: "GRANT \"new\" TO skipped;\n",
+		},
+		{
+			desc:     "role grant lists",
+			sql:      "REVOKE ADMIN OPTION FOR old, other FROM GROUP old CASCADE;\n",
+			expected: "REVOKE ADMIN OPTION FOR \"new\", other FROM GROUP \"new\" CASCADE;\n",
+		},
+		{
+			desc:     "database owner",
+			sql:      "CREATE DATABASE old WITH OWNER = old;\n",
+			expected: "CREATE DATABASE old WITH OWNER = \"new\";\n",
+		},
+		{
+			desc:     "session role",
+			sql:      "SET ROLE old;\nSET SESSION AUTHORIZATION old;\n",
+			expected: "SET ROLE \"new\";\nSET SESSION AUTHORIZATION \"new\";\n",
+		},
+		{
+			desc:     "user mapping",
+			sql:      "CREATE USER MAPPING FOR old SERVER remote;\n",
+			expected: "CREATE USER MAPPING FOR \"new\" SERVER remote;\n",
+		},
+		{
+			desc:     "names in strings, comments and other positions are kept",
+			sql:      "COMMENT ON TABLE old IS E'owner to old\\' TO old'; /* OWNER TO old */\nSELECT old FROM old;\n",
+			expected: "COMMENT ON TABLE old IS E'owner to old\\' TO old'; /* OWNER TO old */\nSELECT old FROM old;\n",
+		},
+		{
+			desc:     "multiple statements on a line",
+			sql:      "CREATE ROLE skipped; CREATE ROLE old;\n",
+			expected: "-- Ignored statement: CREATE ROLE skipped;\n CREATE ROLE \"new\";\n",
+		},
+		{
+			desc:     "incomplete statement",
+			sql:      "ALTER TABLE t OWNER TO old",
+			expected: "ALTER TABLE t OWNER TO old",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rewriteRoles(handling, tt.sql))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gravitational/trace"
+)
+
+// DatabaseSelection selects what a dump of all databases captures. The globals (roles and tablespaces) are
+// always dumped. Database names are matched exactly. The patterns of each DatabaseFilter are pg_dump
+// patterns, such as "public.*" or "cache.sessions".
+//
+// Restoring a dump drops and recreates each database that it holds, so the tables and schemas left out of
+// a database's dump are not kept by a restore.
+type DatabaseSelection struct {
+	// IncludeDatabases dumps only these databases. Empty dumps every database other than "postgres", which
+	// is managed by CNPG.
+	IncludeDatabases []string `yaml:"includeDatabases,omitempty"`
+	// ExcludeDatabases leaves these databases out of the dump.
+	ExcludeDatabases []string `yaml:"excludeDatabases,omitempty"`
+	// Databases selects the schemas and tables that are dumped from individual databases. It requires the
+	// directory format.
+	Databases []DatabaseFilter `yaml:"databases,omitempty"`
+}
+
+// DatabaseFilter selects the schemas and tables that are dumped from a database.
+type DatabaseFilter struct {
+	Name           string   `yaml:"name" jsonschema:"required"`
+	IncludeSchemas []string `yaml:"includeSchemas,omitempty"` // Dumps only the schemas matching these patterns.
+	ExcludeSchemas []string `yaml:"excludeSchemas,omitempty"`
+	IncludeTables  []string `yaml:"includeTables,omitempty"` // Dumps only the tables matching these patterns.
+	ExcludeTables  []string `yaml:"excludeTables,omitempty"`
+	// SchemaOnlyTables dumps the definitions but not the contents of the tables matching these patterns, such
+	// as caches and sessions that are rebuilt when they're missing.
+	SchemaOnlyTables []string `yaml:"schemaOnlyTables,omitempty"`
+}
+
+// IsZero reports whether everything is selected.
+func (s DatabaseSelection) IsZero() bool {
+	return len(s.IncludeDatabases) == 0 && len(s.ExcludeDatabases) == 0 && len(s.Databases) == 0
+}
+
+// validate checks the selection for a dump in the given format.
+func (s DatabaseSelection) validate(format DumpFormat) error {
+	for _, name := range slices.Concat(s.IncludeDatabases, s.ExcludeDatabases) {
+		if name == "" {
+			return trace.BadParameter("database names must not be empty")
+		}
+	}
+
+	if len(s.Databases) > 0 && format != DumpFormatDirectory {
+		return trace.BadParameter("selecting the schemas and tables of databases requires the %q format", DumpFormatDirectory)
+	}
+
+	filtered := make(map[string]struct{}, len(s.Databases))
+	for _, filter := range s.Databases {
+		if filter.Name == "" {
+			return trace.BadParameter("a database filter has an empty name")
+		}
+
+		if _, ok := filtered[filter.Name]; ok {
+			return trace.BadParameter("database %q has multiple filters", filter.Name)
+		}
+		filtered[filter.Name] = struct{}{}
+
+		if !s.selects(filter.Name) {
+			return trace.BadParameter("database %q has a filter but is not dumped", filter.Name)
+		}
+
+		if slices.Contains(filter.patterns(), "") {
+			return trace.BadParameter("database %q has an empty pattern", filter.Name)
+		}
+	}
+
+	return nil
+}
+
+// selects reports whether the database is dumped.
+func (s DatabaseSelection) selects(database string) bool {
+	if len(s.IncludeDatabases) > 0 && !slices.Contains(s.IncludeDatabases, database) {
+		return false
+	}
+
+	return !slices.Contains(s.ExcludeDatabases, database)
+}
+
+// selectDatabases returns the databases that are dumped, out of all of the server's databases. An included
+// database that doesn't exist is an error, so that a typo doesn't leave it out of the backup unnoticed.
+func (s DatabaseSelection) selectDatabases(databases []string) ([]string, error) {
+	for _, included := range s.IncludeDatabases {
+		if !slices.Contains(databases, included) {
+			return nil, trace.NotFound("included database %q does not exist", included)
+		}
+	}
+
+	var selected []string
+	for _, database := range databases {
+		if s.selects(database) {
+			selected = append(selected, database)
+		}
+	}
+
+	return selected, nil
+}
+
+// filterArgs returns the pg_dump args that select the schemas and tables of the database.
+func (s DatabaseSelection) filterArgs(database string) []string {
+	for _, filter := range s.Databases {
+		if filter.Name == database {
+			return filter.args()
+		}
+	}
+
+	return nil
+}
+
+func (f DatabaseFilter) patterns() []string {
+	return slices.Concat(f.IncludeSchemas, f.ExcludeSchemas, f.IncludeTables, f.ExcludeTables, f.SchemaOnlyTables)
+}
+
+func (f DatabaseFilter) args() []string {
+	var args []string
+	for _, flagPatterns := range []struct {
+		flag     string
+		patterns []string
+	}{
+		{"--schema=", f.IncludeSchemas},
+		{"--exclude-schema=", f.ExcludeSchemas},
+		{"--table=", f.IncludeTables},
+		{"--exclude-table=", f.ExcludeTables},
+		{"--exclude-table-data=", f.SchemaOnlyTables},
+	} {
+		for _, pattern := range flagPatterns.patterns {
+			args = append(args, flagPatterns.flag+pattern)
+		}
+	}
+
+	return args
+}
+
+// quoteIdentifier returns the name quoted for use as an SQL identifier.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// literalPattern returns a pattern that only matches the name. Patterns quote names like SQL identifiers.
+func literalPattern(name string) string {
+	return quoteIdentifier(name)
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/gravitational/trace"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseSelectionValidate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		selection   DatabaseSelection
+		format      DumpFormat
+		expectedErr bool
+	}{
+		{
+			desc: "zero value",
+		},
+		{
+			desc:      "included and excluded databases",
+			selection: DatabaseSelection{IncludeDatabases: []string{"app", "analytics"}, ExcludeDatabases: []string{"analytics"}},
+		},
+		{
+			desc: "database filters",
+			selection: DatabaseSelection{
+				IncludeDatabases: []string{"app"},
+				Databases:        []DatabaseFilter{{Name: "app", ExcludeSchemas: []string{"reporting"}, SchemaOnlyTables: []string{"public.sessions"}}},
+			},
+			format: DumpFormatDirectory,
+		},
+		{
+			desc:        "empty database name",
+			selection:   DatabaseSelection{ExcludeDatabases: []string{""}},
+			expectedErr: true,
+		},
+		{
+			desc:        "database filters with the plain format",
+			selection:   DatabaseSelection{Databases: []DatabaseFilter{{Name: "app", ExcludeTables: []string{"cache"}}}},
+			format:      DumpFormatPlain,
+			expectedErr: true,
+		},
+		{
+			desc:        "database filter without a name",
+			selection:   DatabaseSelection{Databases: []DatabaseFilter{{ExcludeTables: []string{"cache"}}}},
+			format:      DumpFormatDirectory,
+			expectedErr: true,
+		},
+		{
+			desc:        "duplicate database filters",
+			selection:   DatabaseSelection{Databases: []DatabaseFilter{{Name: "app"}, {Name: "app"}}},
+			format:      DumpFormatDirectory,
+			expectedErr: true,
+		},
+		{
+			desc: "filter of an excluded database",
+			selection: DatabaseSelection{
+				ExcludeDatabases: []string{"analytics"},
+				Databases:        []DatabaseFilter{{Name: "analytics"}},
+			},
+			format:      DumpFormatDirectory,
+			expectedErr: true,
+		},
+		{
+			desc: "filter of a database that isn't included",
+			selection: DatabaseSelection{
+				IncludeDatabases: []string{"app"},
+				Databases:        []DatabaseFilter{{Name: "analytics"}},
+			},
+			format:      DumpFormatDirectory,
+			expectedErr: true,
+		},
+		{
+			desc:        "empty pattern",
+			selection:   DatabaseSelection{Databases: []DatabaseFilter{{Name: "app", IncludeTables: []string{""}}}},
+			format:      DumpFormatDirectory,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.selection.validate(tt.format)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDatabaseSelectionIsZero(t *testing.T) {
+	assert.True(t, DatabaseSelection{}.IsZero())
+	assert.False(t, DatabaseSelection{ExcludeDatabases: []string{"analytics"}}.IsZero())
+}
+
+func TestDatabaseSelectionSelectDatabases(t *testing.T) {
+	databases := []string{"analytics", "app", "other"}
+
+	selected, err := DatabaseSelection{}.selectDatabases(databases)
+	require.NoError(t, err)
+	assert.Equal(t, databases, selected)
+
+	selected, err = DatabaseSelection{ExcludeDatabases: []string{"analytics"}}.selectDatabases(databases)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app", "other"}, selected)
+
+	selected, err = DatabaseSelection{IncludeDatabases: []string{"other", "app"}, ExcludeDatabases: []string{"other"}}.selectDatabases(databases)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app"}, selected)
+
+	_, err = DatabaseSelection{IncludeDatabases: []string{"missing"}}.selectDatabases(databases)
+	assert.True(t, trace.IsNotFound(err))
+}
+
+func TestDatabaseSelectionFilterArgs(t *testing.T) {
+	selection := DatabaseSelection{
+		Databases: []DatabaseFilter{
+			{
+				Name:             "app",
+				IncludeSchemas:   []string{"public"},
+				ExcludeSchemas:   []string{"reporting"},
+				IncludeTables:    []string{"public.*"},
+				ExcludeTables:    []string{"public.audit_*"},
+				SchemaOnlyTables: []string{"public.sessions", "public.cache"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"--schema=public",
+		"--exclude-schema=reporting",
+		"--table=public.*",
+		"--exclude-table=public.audit_*",
+		"--exclude-table-data=public.sessions",
+		"--exclude-table-data=public.cache",
+	}, selection.filterArgs("app"))
+	assert.Nil(t, selection.filterArgs("other"))
+}
+
+func TestLiteralPattern(t *testing.T) {
+	assert.Equal(t, `"app"`, literalPattern("app"))
+	assert.Equal(t, `"my ""quoted"" db*"`, literalPattern(`my "quoted" db*`))
+}
+
+func TestExcludeDatabaseArgs(t *testing.T) {
+	tests := []struct {
+		desc            string
+		selection       DatabaseSelection
+		simulateListErr bool
+		expectedArgs    []string
+		expectedErr     bool
+	}{
+		{
+			desc:         "everything selected",
+			expectedArgs: []string{},
+		},
+		{
+			desc:         "excluded databases",
+			selection:    DatabaseSelection{ExcludeDatabases: []string{"analytics", `my "db"`}},
+			expectedArgs: []string{`--exclude-database="analytics"`, `--exclude-database="my ""db"""`},
+		},
+		{
+			desc:         "included databases",
+			selection:    DatabaseSelection{IncludeDatabases: []string{"app"}},
+			expectedArgs: []string{`--exclude-database="analytics"`, `--exclude-database="other"`, `--exclude-database="template1"`},
+		},
+		{
+			desc:        "missing included database",
+			selection:   DatabaseSelection{IncludeDatabases: []string{"missing"}},
+			expectedErr: true,
+		},
+		{
+			desc:            "fails to list databases",
+			selection:       DatabaseSelection{IncludeDatabases: []string{"app"}},
+			simulateListErr: true,
+			expectedErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			lr := &LocalRuntime{
+				wrapCommand: func(cmd *exec.Cmd) *cmdWrapper {
+					cw := NewCmdWrapper(cmd)
+					cw.outputCallback = func(*cmdWrapper) ([]byte, error) {
+						return th.ErrOr1Val([]byte("analytics\napp\nother\n"), tt.simulateListErr)
+					}
+					return cw
+				},
+			}
+
+			args, err := lr.excludeDatabaseArgs(th.NewTestContext(), EnvironmentCredentials{}, tt.selection)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
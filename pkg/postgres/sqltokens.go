@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"strings"
+)
+
+type sqlTokenKind int
+
+const (
+	sqlTokenWhitespace sqlTokenKind = iota
+	sqlTokenComment
+	sqlTokenIdentifier       // Keywords and unquoted identifiers.
+	sqlTokenQuotedIdentifier // "Name"
+	sqlTokenString           // 'value', E'value', and $tag$value$tag$
+	sqlTokenOther            // Numbers, operators and punctuation, including statement separators.
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+}
+
+// isSignificant reports whether the token affects the meaning of a statement.
+func (t sqlToken) isSignificant() bool {
+	return t.kind != sqlTokenWhitespace && t.kind != sqlTokenComment
+}
+
+// isKeyword reports whether the token is the (unquoted, case insensitive) keyword.
+func (t sqlToken) isKeyword(keyword string) bool {
+	return t.kind == sqlTokenIdentifier && strings.EqualFold(t.text, keyword)
+}
+
+// isName reports whether the token is an identifier, quoted or not.
+func (t sqlToken) isName() bool {
+	return t.kind == sqlTokenIdentifier || t.kind == sqlTokenQuotedIdentifier
+}
+
+// name returns the name that an identifier token refers to. Unquoted identifiers are folded to lower case.
+func (t sqlToken) name() string {
+	if t.kind == sqlTokenQuotedIdentifier {
+		return strings.ReplaceAll(t.text[1:len(t.text)-1], `""`, `"`)
+	}
+
+	return strings.ToLower(t.text)
+}
+
+// isStatementEnd reports whether the token separates statements.
+func (t sqlToken) isStatementEnd() bool {
+	return t.kind == sqlTokenOther && t.text == ";"
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c >= 0x80 || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || c == '$' || ('0' <= c && c <= '9')
+}
+
+// tokenizeSQL splits SQL text into tokens. The text of the tokens always adds up to the given text. The
+// text is complete when it ends outside of any string, quoted identifier or comment, and the last
+// significant token is a statement separator.
+func tokenizeSQL(text string) (tokens []sqlToken, complete bool) {
+	lastSignificant := sqlToken{}
+	for i := 0; i < len(text); {
+		token, ok := nextSQLToken(text[i:])
+		if !ok {
+			// The rest of the text is an unterminated string, quoted identifier or comment.
+			return append(tokens, sqlToken{kind: sqlTokenOther, text: text[i:]}), false
+		}
+
+		tokens = append(tokens, token)
+		if token.isSignificant() {
+			lastSignificant = token
+		}
+		i += len(token.text)
+	}
+
+	return tokens, lastSignificant.isStatementEnd()
+}
+
+// nextSQLToken returns the token at the start of the text, or false if the text starts with an
+// unterminated string, quoted identifier or comment.
+func nextSQLToken(text string) (sqlToken, bool) {
+	c := text[0]
+	switch {
+	case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f':
+		end := 1
+		for end < len(text) && strings.IndexByte(" \t\r\n\f", text[end]) != -1 {
+			end++
+		}
+		return sqlToken{kind: sqlTokenWhitespace, text: text[:end]}, true
+	case strings.HasPrefix(text, "--"):
+		end := strings.IndexByte(text, '\n')
+		if end == -1 {
+			end = len(text)
+		}
+		return sqlToken{kind: sqlTokenComment, text: text[:end]}, true
+	case strings.HasPrefix(text, "/*"):
+		end, ok := blockCommentEnd(text)
+		return sqlToken{kind: sqlTokenComment, text: text[:end]}, ok
+	case c == '\'':
+		end, ok := quotedEnd(text, '\'', false)
+		return sqlToken{kind: sqlTokenString, text: text[:end]}, ok
+	case (c == 'E' || c == 'e') && len(text) > 1 && text[1] == '\'':
+		end, ok := quotedEnd(text[1:], '\'', true)
+		return sqlToken{kind: sqlTokenString, text: text[:end+1]}, ok
+	case c == '"':
+		end, ok := quotedEnd(text, '"', false)
+		return sqlToken{kind: sqlTokenQuotedIdentifier, text: text[:end]}, ok
+	case c == '$':
+		if tag, ok := dollarQuoteTag(text); ok {
+			end := strings.Index(text[len(tag):], tag)
+			if end == -1 {
+				return sqlToken{}, false
+			}
+			return sqlToken{kind: sqlTokenString, text: text[:len(tag)+end+len(tag)]}, true
+		}
+	case isIdentifierStart(c):
+		end := 1
+		for end < len(text) && isIdentifierPart(text[end]) {
+			end++
+		}
+		return sqlToken{kind: sqlTokenIdentifier, text: text[:end]}, true
+	}
+
+	return sqlToken{kind: sqlTokenOther, text: text[:1]}, true
+}
+
+// blockCommentEnd returns the length of the (possibly nested) block comment at the start of the text.
+func blockCommentEnd(text string) (int, bool) {
+	depth := 0
+	for i := 0; i < len(text)-1; i++ {
+		switch text[i : i+2] {
+		case "/*":
+			depth++
+			i++
+		case "*/":
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1, true
+			}
+		}
+	}
+
+	return len(text), false
+}
+
+// quotedEnd returns the length of the quoted text at the start of the text. Doubled quotes are escaped, as
+// are backslash escaped characters when backslashEscapes is set.
+func quotedEnd(text string, quote byte, backslashEscapes bool) (int, bool) {
+	for i := 1; i < len(text); i++ {
+		switch {
+		case backslashEscapes && text[i] == '\\':
+			i++
+		case text[i] == quote:
+			if i+1 < len(text) && text[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1, true
+		}
+	}
+
+	return len(text), false
+}
+
+// dollarQuoteTag returns the tag (such as "$$" or "$body$") that opens the dollar quoted string at the start
+// of the text, if any.
+func dollarQuoteTag(text string) (string, bool) {
+	for i := 1; i < len(text); i++ {
+		if text[i] == '$' {
+			return text[:i+1], true
+		}
+
+		if i == 1 && !isIdentifierStart(text[i]) || !isIdentifierPart(text[i]) {
+			return "", false
+		}
+	}
+
+	return "", false
+}
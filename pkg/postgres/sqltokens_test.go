@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizeSQL(t *testing.T) {
+	tests := []struct {
+		desc             string
+		sql              string
+		expectedTokens   []sqlToken
+		expectedComplete bool
+	}{
+		{
+			desc: "statement",
+			sql:  "ALTER TABLE \"My \"\"Table\"\" \" OWNER TO app;\n",
+			expectedTokens: []sqlToken{
+				{sqlTokenIdentifier, "ALTER"}, {sqlTokenWhitespace, " "}, {sqlTokenIdentifier, "TABLE"}, {sqlTokenWhitespace, " "},
+				{sqlTokenQuotedIdentifier, `"My ""Table"" "`}, {sqlTokenWhitespace, " "}, {sqlTokenIdentifier, "OWNER"}, {sqlTokenWhitespace, " "},
+				{sqlTokenIdentifier, "TO"}, {sqlTokenWhitespace, " "}, {sqlTokenIdentifier, "app"}, {sqlTokenOther, ";"}, {sqlTokenWhitespace, "\n"},
+			},
+			expectedComplete: true,
+		},
+		{
+			desc: "strings and comments",
+			sql:  "SELECT 'a;''b', E'c\\';d', $1 -- e;\n/* f /* g; */ h; */;",
+			expectedTokens: []sqlToken{
+				{sqlTokenIdentifier, "SELECT"}, {sqlTokenWhitespace, " "}, {sqlTokenString, "'a;''b'"}, {sqlTokenOther, ","}, {sqlTokenWhitespace, " "},
+				{sqlTokenString, "E'c\\';d'"}, {sqlTokenOther, ","}, {sqlTokenWhitespace, " "}, {sqlTokenOther, "$"}, {sqlTokenOther, "1"},
+				{sqlTokenWhitespace, " "}, {sqlTokenComment, "-- e;"}, {sqlTokenWhitespace, "\n"}, {sqlTokenComment, "/* f /* g; */ h; */"}, {sqlTokenOther, ";"},
+			},
+			expectedComplete: true,
+		},
+		{
+			desc: "dollar quoted string",
+			sql:  "AS $body$ BEGIN; $$ END; $body$;",
+			expectedTokens: []sqlToken{
+				{sqlTokenIdentifier, "AS"}, {sqlTokenWhitespace, " "}, {sqlTokenString, "$body$ BEGIN; $$ END; $body$"}, {sqlTokenOther, ";"},
+			},
+			expectedComplete: true,
+		},
+		{
+			desc:             "separator inside an unterminated dollar quoted string",
+			sql:              "AS $$\nBEGIN;\n",
+			expectedTokens:   []sqlToken{{sqlTokenIdentifier, "AS"}, {sqlTokenWhitespace, " "}, {sqlTokenOther, "$$\nBEGIN;\n"}},
+			expectedComplete: false,
+		},
+		{
+			desc:             "separator inside an unterminated string",
+			sql:              "SELECT 'a;",
+			expectedTokens:   []sqlToken{{sqlTokenIdentifier, "SELECT"}, {sqlTokenWhitespace, " "}, {sqlTokenOther, "'a;"}},
+			expectedComplete: false,
+		},
+		{
+			desc:             "text after the last separator",
+			sql:              "SELECT 1; SELECT",
+			expectedTokens:   []sqlToken{{sqlTokenIdentifier, "SELECT"}, {sqlTokenWhitespace, " "}, {sqlTokenOther, "1"}, {sqlTokenOther, ";"}, {sqlTokenWhitespace, " "}, {sqlTokenIdentifier, "SELECT"}},
+			expectedComplete: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tokens, complete := tokenizeSQL(tt.sql)
+			assert.Equal(t, tt.expectedTokens, tokens)
+			assert.Equal(t, tt.expectedComplete, complete)
+		})
+	}
+}
+
+func TestSQLTokenName(t *testing.T) {
+	assert.Equal(t, "app", sqlToken{sqlTokenIdentifier, "App"}.name())
+	assert.Equal(t, `My "App"`, sqlToken{sqlTokenQuotedIdentifier, `"My ""App"""`}.name())
+}
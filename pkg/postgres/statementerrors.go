@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StatementError is an error that psql reported while running a statement of a dump.
+type StatementError struct {
+	Input    string // The file that psql ran, or "<stdin>" when the dump was streamed to it.
+	Line     int    // The line of the input that psql had read up to when the statement failed.
+	Severity string // ERROR, FATAL or PANIC.
+	Message  string
+	Detail   string // Optional.
+	Hint     string // Optional.
+}
+
+func (e *StatementError) Error() string {
+	message := fmt.Sprintf("%s line %d: %s: %s", e.Input, e.Line, e.Severity, e.Message)
+	if e.Detail != "" {
+		message += " (detail: " + e.Detail + ")"
+	}
+	if e.Hint != "" {
+		message += " (hint: " + e.Hint + ")"
+	}
+	return message
+}
+
+// Matches the first line of an error reported by psql, such as
+// `psql:dump.sql:12: ERROR:  role "app" does not exist`. The input may contain colons, so the line number is
+// taken to be the last number between colons.
+var statementErrorPattern = regexp.MustCompile(`^psql:(.*):(\d+): (ERROR|FATAL|PANIC):  (.*)$`)
+
+// parseStatementErrors returns the errors in the output of psql. Notices and warnings are left out.
+func parseStatementErrors(output string) []*StatementError {
+	var statementErrs []*StatementError
+	var last *StatementError
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if match := statementErrorPattern.FindStringSubmatch(line); match != nil {
+			lineNumber, _ := strconv.Atoi(match[2])
+			last = &StatementError{Input: match[1], Line: lineNumber, Severity: match[3], Message: match[4]}
+			statementErrs = append(statementErrs, last)
+			continue
+		}
+
+		// Details and hints follow the error that they belong to, along with the text of the statement.
+		if last == nil {
+			continue
+		}
+		if detail, ok := strings.CutPrefix(line, "DETAIL:  "); ok {
+			last.Detail = detail
+			continue
+		}
+		if hint, ok := strings.CutPrefix(line, "HINT:  "); ok {
+			last.Hint = hint
+			continue
+		}
+		if !strings.HasPrefix(line, "LINE ") && !strings.HasPrefix(line, " ") {
+			last = nil
+		}
+	}
+
+	return statementErrs
+}
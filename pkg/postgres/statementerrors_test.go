@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatementErrors(t *testing.T) {
+	output := `SET
+psql:/dr/my:dump.sql:12: ERROR:  role "app" does not exist
+CREATE TABLE
+psql:/dr/my:dump.sql:40: WARNING:  no privileges were granted for "users"
+psql:<stdin>:57: ERROR:  duplicate key value violates unique constraint "users_pkey"
+DETAIL:  Key (id)=(1) already exists.
+CONTEXT:  COPY users, line 1
+psql:<stdin>:60: ERROR:  syntax error at or near "CREATE"
+LINE 1: CREATE TABLE CREATE
+                     ^
+HINT:  Check the statement.
+CREATE INDEX
+DETAIL:  Not part of any error.
+psql:<stdin>:61: FATAL:  terminating connection due to administrator command
+`
+
+	assert.Equal(t, []*StatementError{
+		{Input: "/dr/my:dump.sql", Line: 12, Severity: "ERROR", Message: `role "app" does not exist`},
+		{Input: "<stdin>", Line: 57, Severity: "ERROR", Message: `duplicate key value violates unique constraint "users_pkey"`, Detail: "Key (id)=(1) already exists."},
+		{Input: "<stdin>", Line: 60, Severity: "ERROR", Message: `syntax error at or near "CREATE"`, Hint: "Check the statement."},
+		{Input: "<stdin>", Line: 61, Severity: "FATAL", Message: "terminating connection due to administrator command"},
+	}, parseStatementErrors(output))
+
+	assert.Nil(t, parseStatementErrors("SET\nCREATE TABLE\n"))
+}
+
+func TestStatementErrorError(t *testing.T) {
+	err := &StatementError{Input: "<stdin>", Line: 57, Severity: "ERROR", Message: "duplicate key", Detail: "Key (id)=(1) already exists.", Hint: "Drop it."}
+	assert.Equal(t, "<stdin> line 57: ERROR: duplicate key (detail: Key (id)=(1) already exists.) (hint: Drop it.)", err.Error())
+
+	err = &StatementError{Input: "dump.sql", Line: 3, Severity: "FATAL", Message: "terminating connection"}
+	assert.Equal(t, "dump.sql line 3: FATAL: terminating connection", err.Error())
+}
@@ -0,0 +1,67 @@
+--
+-- PostgreSQL database cluster dump
+--
+
+SET default_transaction_read_only = off;
+
+--
+-- Roles
+--
+
+DROP ROLE IF EXISTS app;
+-- Ignored statement: DROP ROLE IF EXISTS postgres;
+DROP ROLE IF EXISTS "Reporting";
+CREATE ROLE app;
+ALTER ROLE app WITH NOSUPERUSER INHERIT NOCREATEROLE NOCREATEDB LOGIN NOREPLICATION NOBYPASSRLS PASSWORD 'SCRAM-SHA-256$4096:c2FsdA==$a2V5';
+COMMENT ON ROLE app IS 'Application role; managed by the operator';
+-- Ignored statement: CREATE ROLE postgres;
+-- Ignored statement: ALTER ROLE postgres WITH SUPERUSER INHERIT CREATEROLE CREATEDB LOGIN REPLICATION BYPASSRLS;
+CREATE ROLE "Reporting";
+ALTER ROLE "Reporting" WITH NOSUPERUSER INHERIT NOCREATEROLE NOCREATEDB NOLOGIN NOREPLICATION NOBYPASSRLS;
+ALTER ROLE app SET search_path TO app, public;
+
+--
+-- Role memberships
+--
+
+GRANT "Reporting" TO app WITH INHERIT TRUE GRANTED BY postgres;
+
+--
+-- Database "appdb" dump
+--
+
+CREATE DATABASE appdb WITH TEMPLATE = template0 ENCODING = 'UTF8';
+ALTER DATABASE appdb OWNER TO app;
+\connect appdb
+
+CREATE SCHEMA reporting AUTHORIZATION "Reporting";
+
+CREATE FUNCTION public.grant_app() RETURNS void
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+  -- GRANT app TO app;
+  EXECUTE 'ALTER TABLE public.users OWNER TO app';
+END;
+$$;
+ALTER FUNCTION public.grant_app() OWNER TO app;
+
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    name text DEFAULT 'app;'::text
+);
+ALTER TABLE public.users OWNER TO app;
+
+COPY public.users (id, name) FROM stdin;
+1	CREATE ROLE app;
+2	ALTER TABLE public.users OWNER TO app;
+\.
+
+CREATE POLICY users_app ON public.users FOR SELECT TO app, "Reporting" USING ((name <> 'app'::text));
+GRANT SELECT ON TABLE public.users TO "Reporting" WITH GRANT OPTION;
+REVOKE ALL ON SCHEMA public FROM app;
+ALTER DEFAULT PRIVILEGES FOR ROLE app IN SCHEMA public GRANT SELECT ON TABLES TO "Reporting";
+
+--
+-- PostgreSQL database cluster dump complete
+--
@@ -0,0 +1,67 @@
+--
+-- PostgreSQL database cluster dump
+--
+
+SET default_transaction_read_only = off;
+
+--
+-- Roles
+--
+
+DROP ROLE IF EXISTS "application";
+-- Ignored statement: DROP ROLE IF EXISTS postgres;
+DROP ROLE IF EXISTS "reporting_ro";
+CREATE ROLE "application";
+ALTER ROLE "application" WITH NOSUPERUSER INHERIT NOCREATEROLE NOCREATEDB LOGIN NOREPLICATION NOBYPASSRLS PASSWORD 'SCRAM-SHA-256$4096:c2FsdA==$a2V5';
+COMMENT ON ROLE "application" IS 'Application role; managed by the operator';
+-- Ignored statement: CREATE ROLE postgres;
+-- Ignored statement: ALTER ROLE postgres WITH SUPERUSER INHERIT CREATEROLE CREATEDB LOGIN REPLICATION BYPASSRLS;
+CREATE ROLE "reporting_ro";
+ALTER ROLE "reporting_ro" WITH NOSUPERUSER INHERIT NOCREATEROLE NOCREATEDB NOLOGIN NOREPLICATION NOBYPASSRLS;
+ALTER ROLE "application" SET search_path TO app, public;
+
+--
+-- Role memberships
+--
+
+GRANT "reporting_ro" TO "application" WITH INHERIT TRUE GRANTED BY postgres;
+
+--
+-- Database "appdb" dump
+--
+
+CREATE DATABASE appdb WITH TEMPLATE = template0 ENCODING = 'UTF8';
+ALTER DATABASE appdb OWNER TO "application";
+\connect appdb
+
+CREATE SCHEMA reporting AUTHORIZATION "reporting_ro";
+
+CREATE FUNCTION public.grant_app() RETURNS void
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+  -- GRANT app TO app;
+  EXECUTE 'ALTER TABLE public.users OWNER TO app';
+END;
+$$;
+ALTER FUNCTION public.grant_app() OWNER TO "application";
+
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    name text DEFAULT 'app;'::text
+);
+ALTER TABLE public.users OWNER TO "application";
+
+COPY public.users (id, name) FROM stdin;
+1	CREATE ROLE app;
+2	ALTER TABLE public.users OWNER TO app;
+\.
+
+CREATE POLICY users_app ON public.users FOR SELECT TO "application", "reporting_ro" USING ((name <> 'app'::text));
+GRANT SELECT ON TABLE public.users TO "reporting_ro" WITH GRANT OPTION;
+REVOKE ALL ON SCHEMA public FROM "application";
+ALTER DEFAULT PRIVILEGES FOR ROLE "application" IN SCHEMA public GRANT SELECT ON TABLES TO "reporting_ro";
+
+--
+-- PostgreSQL database cluster dump complete
+--
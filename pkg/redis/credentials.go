@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/gravitational/trace"
+)
+
+const DefaultPort = 6379
+
+// The client reads the password from this variable, which keeps it out of the process list.
+const passwordVarName = "REDISCLI_AUTH"
+
+// Credentials locate a Redis or Valkey server, and authenticate with it. Servers without authentication
+// enabled need neither a user nor a password. A password without a user authenticates as the default user.
+type Credentials struct {
+	Host     string
+	Port     int    // Optional. Defaults to 6379.
+	User     string // Optional. Requires an ACL user on the server.
+	Password string // Optional.
+}
+
+// Validate checks the credentials' fields, without contacting the server.
+func (c Credentials) Validate() error {
+	if c.Host == "" {
+		return trace.BadParameter("host is required")
+	}
+
+	if c.Port < 0 || c.Port > 65535 {
+		return trace.BadParameter("port %d is out of range", c.Port)
+	}
+
+	if c.User != "" && c.Password == "" {
+		return trace.BadParameter("a password is required when a user is set")
+	}
+
+	return nil
+}
+
+func (c Credentials) GetPort() int {
+	if c.Port != 0 {
+		return c.Port
+	}
+
+	return DefaultPort
+}
+
+func (c Credentials) Address() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.GetPort()))
+}
+
+// args returns the args that connect the client to the server.
+func (c Credentials) args() []string {
+	args := []string{
+		"-h", c.Host,
+		"-p", strconv.Itoa(c.GetPort()),
+	}
+
+	if c.User != "" {
+		args = append(args, "--user", c.User)
+	}
+
+	return args
+}
+
+func (c Credentials) env() []string {
+	if c.Password == "" {
+		return []string{}
+	}
+
+	return []string{passwordVarName + "=" + c.Password}
+}
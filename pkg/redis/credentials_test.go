@@ -0,0 +1,79 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialsValidate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		credentials Credentials
+		wantErr     bool
+	}{
+		{
+			desc:        "valid",
+			credentials: Credentials{Host: "valkey.example.com", User: "backup", Password: "password"},
+		},
+		{
+			desc:        "no authentication",
+			credentials: Credentials{Host: "valkey.example.com", Port: 6380},
+		},
+		{
+			desc:        "default user",
+			credentials: Credentials{Host: "valkey.example.com", Password: "password"},
+		},
+		{
+			desc:        "no host",
+			credentials: Credentials{Password: "password"},
+			wantErr:     true,
+		},
+		{
+			desc:        "negative port",
+			credentials: Credentials{Host: "valkey.example.com", Port: -1},
+			wantErr:     true,
+		},
+		{
+			desc:        "port out of range",
+			credentials: Credentials{Host: "valkey.example.com", Port: 65536},
+			wantErr:     true,
+		},
+		{
+			desc:        "user without a password",
+			credentials: Credentials{Host: "valkey.example.com", User: "backup"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.credentials.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCredentialsAddress(t *testing.T) {
+	assert.Equal(t, "valkey.example.com:6379", Credentials{Host: "valkey.example.com"}.Address())
+	assert.Equal(t, "valkey.example.com:6380", Credentials{Host: "valkey.example.com", Port: 6380}.Address())
+	assert.Equal(t, "[::1]:6379", Credentials{Host: "::1"}.Address())
+}
+
+func TestCredentialsArgs(t *testing.T) {
+	assert.Equal(t, []string{"-h", "valkey.example.com", "-p", "6379"}, Credentials{Host: "valkey.example.com"}.args())
+
+	credentials := Credentials{Host: "valkey.example.com", Port: 6380, User: "backup", Password: "password"}
+	assert.Equal(t, []string{"-h", "valkey.example.com", "-p", "6380", "--user", "backup"}, credentials.args())
+	assert.NotContains(t, credentials.args(), "password")
+}
+
+func TestCredentialsEnv(t *testing.T) {
+	assert.Equal(t, []string{"REDISCLI_AUTH=password"}, Credentials{Password: "password"}.env())
+	assert.Empty(t, Credentials{}.env())
+}
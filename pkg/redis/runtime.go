@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// Depending on CLI tools is unfortunate but there are no viable golang replacements for these.
+// redis-cli also works with Valkey servers.
+const cliCommandName = "redis-cli"
+
+// Represents a place (i.e. local or remote) where commands can run.
+type Runtime interface {
+	Snapshot(*contexts.Context, Credentials, string) error
+}
+
+type LocalRuntime struct {
+	// Used for tests to mock running commands
+	runCommand func(cmd *exec.Cmd) error
+}
+
+func NewLocalRuntime() *LocalRuntime {
+	return &LocalRuntime{
+		runCommand: (*exec.Cmd).Run,
+	}
+}
+
+// command returns a command that runs the client tool against the server.
+func command(ctx context.Context, credentials Credentials, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, cliCommandName, append(credentials.args(), args...)...)
+	cmd.Env = credentials.env()
+	return cmd
+}
@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// Snapshot writes an RDB snapshot of the server's dataset to the output file. The snapshot is streamed
+// from the server the same way that a replica's initial sync is, so the server forks to take a point in
+// time copy of the dataset, and nothing is read from (or written to) the server's own data directory.
+// The user must be allowed to run the SYNC and REPLCONF commands.
+func (lr *LocalRuntime) Snapshot(ctx *contexts.Context, credentials Credentials, outputFilePath string) (err error) {
+	ctx.Log.With("serverAddress", credentials.Address(), "username", credentials.User).Info("Snapshotting dataset", "outputFilePath", outputFilePath)
+	defer ctx.Log.Info("Dataset snapshot complete", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if err := credentials.Validate(); err != nil {
+		return trace.Wrap(err, "invalid credentials")
+	}
+
+	// This will cause the process to be terminated if the function returns before the process is done.
+	commandCtx, ctxCancel := context.WithCancel(ctx.Child())
+	defer ctxCancel()
+
+	cmd := command(commandCtx, credentials, "--rdb", outputFilePath)
+
+	var output strings.Builder
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := lr.runCommand(cmd); err != nil {
+		return trace.Wrap(err, "process %q failed: %s", cliCommandName, output.String())
+	}
+
+	// Check the snapshot now, rather than only finding that it is missing or empty when it is restored.
+	fileInfo, err := os.Stat(outputFilePath)
+	if err != nil {
+		return trace.Wrap(err, "failed to get file info for the snapshot at %q: %s", outputFilePath, output.String())
+	}
+
+	if fileInfo.Size() == 0 {
+		return trace.Errorf("the snapshot at %q is empty: %s", outputFilePath, output.String())
+	}
+
+	return nil
+}
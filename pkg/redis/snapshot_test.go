@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	credentials := Credentials{Host: "valkey.example.com", User: "backup", Password: "password"}
+
+	tests := []struct {
+		desc                  string
+		credentials           Credentials
+		simulateSnapshotError bool
+		skipWritingSnapshot   bool
+		snapshotContents      string
+		expectedEnv           []string
+		wantErr               bool
+	}{
+		{
+			desc:             "writes the snapshot",
+			credentials:      credentials,
+			snapshotContents: "REDIS0011",
+			expectedEnv:      []string{"REDISCLI_AUTH=password"},
+		},
+		{
+			desc:             "writes the snapshot without authentication",
+			credentials:      Credentials{Host: "valkey.example.com"},
+			snapshotContents: "REDIS0011",
+			expectedEnv:      []string{},
+		},
+		{
+			desc:        "invalid credentials",
+			credentials: Credentials{User: "backup"},
+			wantErr:     true,
+		},
+		{
+			desc:                  "snapshot fails",
+			credentials:           credentials,
+			simulateSnapshotError: true,
+			expectedEnv:           []string{"REDISCLI_AUTH=password"},
+			wantErr:               true,
+		},
+		{
+			desc:                "no snapshot written",
+			credentials:         credentials,
+			skipWritingSnapshot: true,
+			expectedEnv:         []string{"REDISCLI_AUTH=password"},
+			wantErr:             true,
+		},
+		{
+			desc:        "empty snapshot",
+			credentials: credentials,
+			expectedEnv: []string{"REDISCLI_AUTH=password"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			outputFilePath := filepath.Join(t.TempDir(), "cache.rdb")
+
+			ran := false
+			lr := &LocalRuntime{
+				runCommand: func(cmd *exec.Cmd) error {
+					ran = true
+					assert.Equal(t, cliCommandName, filepath.Base(cmd.Path))
+					assert.Equal(t, append(tt.credentials.args(), "--rdb", outputFilePath), cmd.Args[1:])
+					assert.Equal(t, tt.expectedEnv, cmd.Env)
+
+					if tt.simulateSnapshotError {
+						return assert.AnError
+					}
+
+					if !tt.skipWritingSnapshot {
+						require.NoError(t, os.WriteFile(outputFilePath, []byte(tt.snapshotContents), 0600))
+					}
+					return nil
+				},
+			}
+
+			err := lr.Snapshot(th.NewTestContext(), tt.credentials, outputFilePath)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, ran)
+
+			contents, err := os.ReadFile(outputFilePath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.snapshotContents, string(contents))
+		})
+	}
+}
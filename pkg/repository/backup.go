@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/cleanup"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+)
+
+// backup stores the src directory as a new snapshot. Files that are unchanged since the parent snapshot (the
+// latest with the same name) reuse its chunks without being read. Every other file is read and chunked, and
+// only the chunks that the repository doesn't have yet are stored. The snapshot records its retention, which
+// the store protects the objects that it writes with.
+func (r *repository) backup(ctx *contexts.Context, src string, opts BackupOptions, retention s3.ObjectRetention, limiter *throttle.Limiter) (Snapshot, error) {
+	snapshot := &snapshotObject{
+		Name:      opts.Name,
+		Tags:      opts.Tags,
+		Time:      time.Now().UTC(),
+		Retention: retention,
+	}
+
+	parentTree, err := r.parentTree(ctx.Child(), snapshot, opts)
+	if err != nil {
+		return Snapshot{}, trace.Wrap(err)
+	}
+
+	listing, err := files.NewLocalRuntime().ListTree(ctx.Child(), src, files.ListTreeOptions{})
+	if err != nil {
+		return Snapshot{}, trace.Wrap(err, "failed to list %q", src)
+	}
+
+	packer := newPacker(r)
+	chunker := newChunker(nil, r.config.Chunker)
+	snapshot.Tree = make([]node, 0, len(listing.Entries))
+	for _, entry := range listing.Entries {
+		n := node{
+			Path:       entry.Path,
+			Type:       entry.Type,
+			Mode:       entry.Mode,
+			UID:        entry.UID,
+			GID:        entry.GID,
+			ModTime:    entry.ModTime,
+			LinkTarget: entry.LinkTarget,
+		}
+
+		if entry.Type == files.EntryTypeFile {
+			if parent, ok := parentTree[entry.Path]; ok && r.isUnchanged(parent, entry) {
+				n.Size = parent.Size
+				n.Chunks = parent.Chunks
+			} else if err := r.backupFile(ctx, filepath.Join(src, filepath.FromSlash(entry.Path)), &n, chunker, packer, limiter); err != nil {
+				return Snapshot{}, trace.Wrap(err)
+			}
+
+			snapshot.FileCount++
+			snapshot.Size += n.Size
+		}
+
+		snapshot.Tree = append(snapshot.Tree, n)
+	}
+
+	if err := packer.flush(ctx); err != nil {
+		return Snapshot{}, trace.Wrap(err)
+	}
+	snapshot.AddedBytes = packer.addedBytes
+
+	if !retention.IsZero() {
+		if err := r.retain(ctx, snapshot, packer.written); err != nil {
+			return Snapshot{}, trace.Wrap(err, "failed to protect the existing objects that the snapshot depends on")
+		}
+	}
+
+	id, err := r.saveSnapshot(ctx, snapshot)
+	if err != nil {
+		return Snapshot{}, trace.Wrap(err)
+	}
+
+	ctx.Log.With("snapshot", id, "files", snapshot.FileCount, "size", snapshot.Size, "addedBytes", snapshot.AddedBytes).Info("Created snapshot")
+	return snapshot.summary(id), nil
+}
+
+// retain protects the objects that the snapshot depends on, but that the backup didn't write, with the
+// store's retention: the config, and the packs (and indexes) of the chunks that were already stored. They may
+// have been written without protection, or with a retention that expires before the snapshot's.
+func (r *repository) retain(ctx *contexts.Context, snapshot *snapshotObject, written map[string]struct{}) error {
+	if err := r.store.Retain(ctx, configKey); err != nil {
+		return trace.Wrap(err, "failed to protect the repository config")
+	}
+
+	packs := make(map[string]struct{})
+	for _, n := range snapshot.Tree {
+		for _, id := range n.Chunks {
+			packs[r.chunks[id].pack] = struct{}{}
+		}
+	}
+
+	for _, packID := range slices.Sorted(maps.Keys(packs)) {
+		if _, ok := written[packID]; ok {
+			continue
+		}
+
+		if err := r.store.Retain(ctx, packKey(packID)); err != nil {
+			return trace.Wrap(err, "failed to protect pack %q", packID)
+		}
+
+		if err := r.store.Retain(ctx, indexPrefix+packID); err != nil {
+			return trace.Wrap(err, "failed to protect the index of pack %q", packID)
+		}
+	}
+
+	return nil
+}
+
+// parentTree returns the tree of the parent snapshot by path, and records the parent on the snapshot. There
+// is no parent for a full backup, or for the first snapshot with a name.
+func (r *repository) parentTree(ctx *contexts.Context, snapshot *snapshotObject, opts BackupOptions) (map[string]node, error) {
+	if opts.Full {
+		return nil, nil
+	}
+
+	snapshots, err := r.loadSnapshots(ctx)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to load the existing snapshots")
+	}
+
+	parent, ok := LatestSnapshot(sortedSnapshots(snapshots), opts.Name, "")
+	if !ok {
+		return nil, nil
+	}
+
+	snapshot.Parent = parent.ID
+	tree := make(map[string]node, len(snapshots[parent.ID].Tree))
+	for _, n := range snapshots[parent.ID].Tree {
+		tree[n.Path] = n
+	}
+
+	return tree, nil
+}
+
+// isUnchanged reports whether the file can reuse the chunks of its node in the parent snapshot. A prune
+// may have removed the chunks since, if the parent was removed with them.
+func (r *repository) isUnchanged(parent node, entry files.TreeEntry) bool {
+	if parent.Type != files.EntryTypeFile || parent.Size != entry.Size || !parent.ModTime.Equal(entry.ModTime) {
+		return false
+	}
+
+	for _, id := range parent.Chunks {
+		if _, ok := r.chunks[id]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// backupFile chunks the file, storing each chunk that the repository doesn't have.
+func (r *repository) backupFile(ctx *contexts.Context, filePath string, n *node, chunker *chunker, packer *packer, limiter *throttle.Limiter) error {
+	if err := limiter.WaitFile(ctx); err != nil {
+		return trace.Wrap(err)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return trace.Wrap(err, "failed to open %q", filePath)
+	}
+	defer cleanup.To(func(_ *contexts.Context) error { return f.Close() }).
+		WithErrMessage("failed to close %q", filePath).
+		Run()
+
+	chunker.reset(limiter.Reader(ctx, f))
+	for {
+		chunk, err := chunker.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return trace.Wrap(err, "failed to read %q", filePath)
+		}
+
+		id := hashID(chunk)
+		if err := packer.add(ctx, id, chunk); err != nil {
+			return trace.Wrap(err)
+		}
+
+		// The size is counted from what was read, as the file may have changed since it was listed.
+		n.Size += int64(len(chunk))
+		n.Chunks = append(n.Chunks, id)
+	}
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"io"
+	"math/bits"
+
+	"github.com/gravitational/trace"
+)
+
+// ChunkerParams bound the size of the chunks that file contents are split into. Chunk boundaries are chosen
+// by the contents (content-defined chunking), so that an insertion or deletion only changes the chunks
+// around it, and the rest of the file still deduplicates against earlier snapshots. The params are fixed
+// when a repository is created, as chunks cut with different params rarely match.
+type ChunkerParams struct {
+	MinSize int `json:"minSize"`
+	// AvgSize is the typical chunk size. It must be a power of two.
+	AvgSize int `json:"avgSize"`
+	MaxSize int `json:"maxSize"`
+}
+
+// DefaultChunkerParams are used for new repositories.
+var DefaultChunkerParams = ChunkerParams{
+	MinSize: 512 * 1024,
+	AvgSize: 1024 * 1024,
+	MaxSize: 8 * 1024 * 1024,
+}
+
+// Validate reports whether the params are well-formed.
+func (p ChunkerParams) Validate() error {
+	if p.MinSize <= 0 {
+		return trace.BadParameter("minimum chunk size must be positive")
+	}
+
+	if p.AvgSize&(p.AvgSize-1) != 0 {
+		return trace.BadParameter("average chunk size %d is not a power of two", p.AvgSize)
+	}
+
+	if p.MinSize >= p.AvgSize || p.AvgSize >= p.MaxSize {
+		return trace.BadParameter("chunk sizes must satisfy min < avg < max")
+	}
+
+	return nil
+}
+
+// gearTable maps each byte to a pseudo-random value for the rolling hash. It must never change, as the
+// chunk boundaries of every existing repository depend on it. The values are generated from a fixed seed
+// with splitmix64.
+var gearTable = func() (table [256]uint64) {
+	state := uint64(0x6261636b75702d74) // "backup-t"
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+// chunker splits a stream into content-defined chunks, using a gear rolling hash with normalized chunking
+// (as in FastCDC): below the average size a stricter mask is used, and above it a looser one, which keeps
+// chunk sizes close to the average.
+type chunker struct {
+	reader      io.Reader
+	params      ChunkerParams
+	strictMask  uint64
+	relaxedMask uint64
+
+	buf        []byte
+	start, end int
+	eof        bool
+}
+
+func newChunker(reader io.Reader, params ChunkerParams) *chunker {
+	// The masks select the high bits of the hash, which depend on the last 64 bytes read.
+	avgBits := bits.Len(uint(params.AvgSize)) - 1
+	return &chunker{
+		reader:      reader,
+		params:      params,
+		strictMask:  ^uint64(0) << (64 - (avgBits + 1)),
+		relaxedMask: ^uint64(0) << (64 - (avgBits - 1)),
+		buf:         make([]byte, 2*params.MaxSize),
+	}
+}
+
+// reset starts chunking another stream, reusing the buffer.
+func (c *chunker) reset(reader io.Reader) {
+	c.reader = reader
+	c.start, c.end = 0, 0
+	c.eof = false
+}
+
+// Next returns the next chunk, or io.EOF once the stream is exhausted. The chunk is only valid until the
+// next call.
+func (c *chunker) Next() ([]byte, error) {
+	if err := c.fill(); err != nil {
+		return nil, trace.Wrap(err)
+	}
+
+	if c.start == c.end {
+		return nil, io.EOF
+	}
+
+	data := c.buf[c.start:c.end]
+	chunk := data[:c.cutPoint(data)]
+	c.start += len(chunk)
+	return chunk, nil
+}
+
+// fill buffers at least MaxSize bytes, unless the stream ends first.
+func (c *chunker) fill() error {
+	if c.eof || c.end-c.start >= c.params.MaxSize {
+		return nil
+	}
+
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+	for c.end < len(c.buf) {
+		n, err := c.reader.Read(c.buf[c.end:])
+		c.end += n
+		if err == io.EOF {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return trace.Wrap(err, "failed to read chunk data")
+		}
+	}
+
+	return nil
+}
+
+// cutPoint returns the length of the chunk at the start of data.
+func (c *chunker) cutPoint(data []byte) int {
+	length := min(len(data), c.params.MaxSize)
+	if length <= c.params.MinSize {
+		return length
+	}
+
+	normal := min(c.params.AvgSize, length)
+	var hash uint64
+	i := c.params.MinSize
+	for ; i < normal; i++ {
+		hash = (hash << 1) + gearTable[data[i]]
+		if hash&c.strictMask == 0 {
+			return i + 1
+		}
+	}
+
+	for ; i < length; i++ {
+		hash = (hash << 1) + gearTable[data[i]]
+		if hash&c.relaxedMask == 0 {
+			return i + 1
+		}
+	}
+
+	return length
+}
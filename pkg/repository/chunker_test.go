@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChunkerParams keep chunks small, so that tests can exercise content-defined boundaries with little data.
+var testChunkerParams = ChunkerParams{MinSize: 256, AvgSize: 1024, MaxSize: 4096}
+
+func randomData(t *testing.T, seed uint64, size int) []byte {
+	t.Helper()
+
+	data := make([]byte, size)
+	rng := rand.New(rand.NewPCG(seed, seed))
+	for i := range data {
+		data[i] = byte(rng.UintN(256))
+	}
+	return data
+}
+
+func chunkAll(t *testing.T, data []byte, params ChunkerParams) [][]byte {
+	t.Helper()
+
+	var chunks [][]byte
+	c := newChunker(bytes.NewReader(data), params)
+	for {
+		chunk, err := c.Next()
+		if err == io.EOF {
+			return chunks
+		}
+		require.NoError(t, err)
+		chunks = append(chunks, bytes.Clone(chunk))
+	}
+}
+
+func TestChunkerParamsValidate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		params      ChunkerParams
+		expectedErr bool
+	}{
+		{desc: "defaults", params: DefaultChunkerParams},
+		{desc: "test params", params: testChunkerParams},
+		{desc: "zero minimum", params: ChunkerParams{MinSize: 0, AvgSize: 1024, MaxSize: 4096}, expectedErr: true},
+		{desc: "average not a power of two", params: ChunkerParams{MinSize: 256, AvgSize: 1000, MaxSize: 4096}, expectedErr: true},
+		{desc: "minimum above average", params: ChunkerParams{MinSize: 2048, AvgSize: 1024, MaxSize: 4096}, expectedErr: true},
+		{desc: "maximum below average", params: ChunkerParams{MinSize: 256, AvgSize: 1024, MaxSize: 512}, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestChunker(t *testing.T) {
+	t.Run("empty stream", func(t *testing.T) {
+		assert.Empty(t, chunkAll(t, nil, testChunkerParams))
+	})
+
+	t.Run("stream smaller than the minimum chunk size", func(t *testing.T) {
+		data := randomData(t, 1, 100)
+		assert.Equal(t, [][]byte{data}, chunkAll(t, data, testChunkerParams))
+	})
+
+	t.Run("chunks reassemble the stream, within the size bounds", func(t *testing.T) {
+		data := randomData(t, 2, 256*1024)
+		chunks := chunkAll(t, data, testChunkerParams)
+
+		assert.Equal(t, data, bytes.Join(chunks, nil))
+		for i, chunk := range chunks {
+			assert.LessOrEqual(t, len(chunk), testChunkerParams.MaxSize)
+			if i < len(chunks)-1 {
+				assert.Greater(t, len(chunk), testChunkerParams.MinSize)
+			}
+		}
+
+		// Normalized chunking keeps the average close to the configured average.
+		average := len(data) / len(chunks)
+		assert.InDelta(t, testChunkerParams.AvgSize, average, float64(testChunkerParams.AvgSize)/2)
+	})
+
+	t.Run("boundaries are found again after an insertion", func(t *testing.T) {
+		data := randomData(t, 3, 256*1024)
+		edited := append(append(bytes.Clone(data[:1000]), []byte("inserted bytes")...), data[1000:]...)
+
+		original := make(map[string]struct{})
+		for _, chunk := range chunkAll(t, data, testChunkerParams) {
+			original[hashID(chunk)] = struct{}{}
+		}
+
+		editedChunks := chunkAll(t, edited, testChunkerParams)
+		shared := 0
+		for _, chunk := range editedChunks {
+			if _, ok := original[hashID(chunk)]; ok {
+				shared++
+			}
+		}
+
+		// Only the chunks around the insertion differ.
+		assert.GreaterOrEqual(t, shared, len(editedChunks)-3)
+	})
+
+	t.Run("reset reuses the chunker for another stream", func(t *testing.T) {
+		first := randomData(t, 4, 10*1024)
+		second := randomData(t, 5, 10*1024)
+
+		c := newChunker(bytes.NewReader(first), testChunkerParams)
+		for {
+			if _, err := c.Next(); err == io.EOF {
+				break
+			}
+		}
+
+		c.reset(bytes.NewReader(second))
+		var chunks [][]byte
+		for {
+			chunk, err := c.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			chunks = append(chunks, bytes.Clone(chunk))
+		}
+
+		assert.Equal(t, chunkAll(t, second, testChunkerParams), chunks)
+	})
+}
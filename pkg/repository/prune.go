@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// prune removes the snapshots beyond the newest opts.KeepLast of each name, and then the chunks that no
+// remaining snapshot references. Packs where every chunk is still referenced are kept as they are. The
+// referenced chunks of every other pack are written to new packs, and then the old packs are deleted (index
+// first, so that the repository is never left with an index entry for data that is gone). Packs that have no
+// index at all, left behind by an interrupted backup, are deleted too.
+//
+// Snapshots that are still protected by Object Lock at now are kept however old they are, as their objects
+// can't be deleted yet. The packs that hold their chunks were protected along with them, so those are kept as
+// they are too, rather than being repacked.
+func (r *repository) prune(ctx *contexts.Context, opts PruneOptions, now time.Time) (PruneResult, error) {
+	var result PruneResult
+
+	snapshots, err := r.loadSnapshots(ctx)
+	if err != nil {
+		return PruneResult{}, trace.Wrap(err, "failed to load snapshots")
+	}
+
+	if opts.KeepLast > 0 {
+		kept := make(map[string]int)
+		for _, snapshot := range slices.Backward(sortedSnapshots(snapshots)) {
+			if kept[snapshot.Name] < opts.KeepLast {
+				kept[snapshot.Name]++
+				continue
+			}
+
+			if snapshot.Retention.ProtectsAt(now) {
+				ctx.Log.With("snapshot", snapshot.ID, "name", snapshot.Name, "retainUntil", snapshot.Retention.RetainUntil,
+					"legalHold", snapshot.Retention.LegalHold).Info("Keeping snapshot protected by Object Lock")
+				continue
+			}
+
+			ctx.Log.With("snapshot", snapshot.ID, "name", snapshot.Name, "time", snapshot.Time).Info("Removing snapshot")
+			if err := r.store.Delete(ctx, snapshotsPrefix+snapshot.ID); err != nil {
+				return result, trace.Wrap(err, "failed to remove snapshot %q", snapshot.ID)
+			}
+			delete(snapshots, snapshot.ID)
+			result.RemovedSnapshots = append(result.RemovedSnapshots, snapshot.ID)
+		}
+	}
+
+	referenced := make(map[string]struct{})
+	protected := make(map[string]struct{})
+	for _, snapshot := range snapshots {
+		isProtected := snapshot.Retention.ProtectsAt(now)
+		for _, n := range snapshot.Tree {
+			for _, id := range n.Chunks {
+				referenced[id] = struct{}{}
+				if isProtected {
+					protected[id] = struct{}{}
+				}
+			}
+		}
+	}
+
+	// Chunks may be in more than one pack (such as when a prune was interrupted after repacking). Chunks in
+	// a pack that is kept don't need to be repacked from another.
+	packIDs := slices.Sorted(maps.Keys(r.packs))
+	covered := make(map[string]struct{})
+	var obsoletePacks []string
+	for _, packID := range packIDs {
+		entries := r.packs[packID]
+		isProtected := slices.ContainsFunc(entries, func(entry indexEntry) bool { _, ok := protected[entry.ID]; return ok })
+		if !isProtected && slices.ContainsFunc(entries, func(entry indexEntry) bool { _, ok := referenced[entry.ID]; return !ok }) {
+			obsoletePacks = append(obsoletePacks, packID)
+			continue
+		}
+
+		for _, entry := range entries {
+			covered[entry.ID] = struct{}{}
+		}
+	}
+
+	packer := newPacker(r)
+	for _, packID := range obsoletePacks {
+		for _, entry := range r.packs[packID] {
+			if _, ok := referenced[entry.ID]; !ok {
+				continue
+			}
+
+			if _, ok := covered[entry.ID]; ok {
+				continue
+			}
+
+			data, err := r.store.GetRange(ctx, packKey(packID), entry.Offset, entry.Length)
+			if err != nil {
+				return result, trace.Wrap(err, "failed to read chunk %q from pack %q", entry.ID, packID)
+			}
+
+			if hashID(data) != entry.ID {
+				return result, trace.Errorf("chunk %q in pack %q is corrupt", entry.ID, packID)
+			}
+
+			if err := packer.write(ctx, entry.ID, data); err != nil {
+				return result, trace.Wrap(err)
+			}
+			covered[entry.ID] = struct{}{}
+		}
+	}
+
+	if err := packer.flush(ctx); err != nil {
+		return result, trace.Wrap(err)
+	}
+	result.WrittenPacks = len(packer.written)
+
+	for _, packID := range obsoletePacks {
+		if err := r.deletePack(ctx, packID); err != nil {
+			return result, trace.Wrap(err)
+		}
+		result.DeletedPacks++
+	}
+
+	// Packs without an index were never referenced by anything.
+	packKeys, err := r.store.List(ctx, packsPrefix)
+	if err != nil {
+		return result, trace.Wrap(err, "failed to list packs")
+	}
+
+	for _, key := range packKeys {
+		packID := path.Base(key)
+		if _, ok := r.packs[packID]; ok || strings.HasPrefix(packID, ".") {
+			continue
+		}
+
+		if err := r.store.Delete(ctx, key); err != nil {
+			return result, trace.Wrap(err, "failed to delete unindexed pack %q", packID)
+		}
+		result.DeletedPacks++
+	}
+
+	return result, nil
+}
+
+func (r *repository) deletePack(ctx *contexts.Context, packID string) error {
+	if err := r.store.Delete(ctx, indexPrefix+packID); err != nil {
+		return trace.Wrap(err, "failed to delete the index of pack %q", packID)
+	}
+
+	if err := r.store.Delete(ctx, packKey(packID)); err != nil {
+		return trace.Wrap(err, "failed to delete pack %q", packID)
+	}
+
+	delete(r.packs, packID)
+	return nil
+}
@@ -0,0 +1,362 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+)
+
+// Repository layout. A repository is a set of objects in a Store:
+//
+//	config                 The format version and chunker params, written when the repository is created.
+//	packs/<id[:2]>/<id>    Chunk data. A pack is its chunks concatenated, and its ID is the SHA-256 of its
+//	                       contents.
+//	index/<pack id>        The chunks in each pack, and where they are.
+//	snapshots/<id>         The file tree of each snapshot, with the chunks of each file. The ID is the SHA-256
+//	                       of the object.
+//
+// Chunks are identified by the SHA-256 of their contents, so a chunk is only stored once, however many files
+// and snapshots contain it. Objects are written in dependency order (a pack before its index, and all of a
+// snapshot's packs before the snapshot), so an interrupted backup only leaves behind unreferenced data, which
+// a prune removes. A repository has a single writer: backups and prunes of the same repository must not run
+// concurrently.
+const (
+	formatVersion   = 1
+	configKey       = "config"
+	packsPrefix     = "packs/"
+	indexPrefix     = "index/"
+	snapshotsPrefix = "snapshots/"
+	// packTargetSize is the size that packs are filled to before they are written. Larger packs mean fewer
+	// objects, but more data buffered in memory.
+	packTargetSize = 16 * 1024 * 1024
+)
+
+type repositoryConfig struct {
+	Version int           `json:"version"`
+	Chunker ChunkerParams `json:"chunker"`
+}
+
+type packIndex struct {
+	Chunks []indexEntry `json:"chunks"`
+}
+
+type indexEntry struct {
+	ID     string `json:"id"`
+	Offset int64  `json:"offset"`
+	Length int64  `json:"length"`
+}
+
+type chunkLocation struct {
+	pack   string
+	offset int64
+	length int64
+}
+
+// node is a single filesystem object in a snapshot's tree.
+type node struct {
+	Path       string          `json:"path"` // Slash-separated, relative to the snapshot root
+	Type       files.EntryType `json:"type"`
+	Size       int64           `json:"size,omitempty"`
+	Mode       fs.FileMode     `json:"mode"`
+	UID        uint32          `json:"uid"`
+	GID        uint32          `json:"gid"`
+	ModTime    time.Time       `json:"modTime"`
+	LinkTarget string          `json:"linkTarget,omitempty"`
+	Chunks     []string        `json:"chunks,omitempty"`
+}
+
+type snapshotObject struct {
+	Name       string    `json:"name"`
+	Tags       []string  `json:"tags,omitempty"`
+	Time       time.Time `json:"time"`
+	Parent     string    `json:"parent,omitempty"`
+	FileCount  int64     `json:"fileCount"`
+	Size       int64     `json:"size"`
+	AddedBytes int64     `json:"addedBytes"`
+	// Retention is recorded so that a prune can tell whether the snapshot's objects can be deleted yet,
+	// without reading each object's retention.
+	Retention s3.ObjectRetention `json:"retention,omitzero"`
+	Tree      []node             `json:"tree"`
+}
+
+func (so *snapshotObject) summary(id string) Snapshot {
+	return Snapshot{
+		ID:         id,
+		Name:       so.Name,
+		Tags:       so.Tags,
+		Time:       so.Time,
+		Parent:     so.Parent,
+		FileCount:  so.FileCount,
+		Size:       so.Size,
+		AddedBytes: so.AddedBytes,
+		Retention:  so.Retention,
+	}
+}
+
+type repository struct {
+	store  Store
+	config repositoryConfig
+	chunks map[string]chunkLocation
+	packs  map[string][]indexEntry
+}
+
+func hashID(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func packKey(id string) string {
+	return path.Join(packsPrefix, id[:2], id)
+}
+
+// openRepository reads the repository's config and index. When create is set, a repository that does not
+// exist yet is created with the given chunker params.
+func openRepository(ctx *contexts.Context, store Store, create bool, params ChunkerParams) (*repository, error) {
+	repo := &repository{
+		store:  store,
+		chunks: make(map[string]chunkLocation),
+		packs:  make(map[string][]indexEntry),
+	}
+
+	rawConfig, err := store.Get(ctx, configKey)
+	switch {
+	case trace.IsNotFound(err) && create:
+		if err := params.Validate(); err != nil {
+			return nil, trace.Wrap(err, "invalid chunker params")
+		}
+
+		repo.config = repositoryConfig{Version: formatVersion, Chunker: params}
+		rawConfig, err := json.Marshal(repo.config)
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to encode the repository config")
+		}
+
+		ctx.Log.Info("Creating repository")
+		if err := store.Put(ctx, configKey, rawConfig); err != nil {
+			return nil, trace.Wrap(err, "failed to write the repository config")
+		}
+		return repo, nil
+	case trace.IsNotFound(err):
+		return nil, trace.NotFound("repository does not exist")
+	case err != nil:
+		return nil, trace.Wrap(err, "failed to read the repository config")
+	}
+
+	if err := json.Unmarshal(rawConfig, &repo.config); err != nil {
+		return nil, trace.Wrap(err, "failed to decode the repository config")
+	}
+
+	if repo.config.Version != formatVersion {
+		return nil, trace.BadParameter("unsupported repository format version %d", repo.config.Version)
+	}
+
+	if err := repo.config.Chunker.Validate(); err != nil {
+		return nil, trace.Wrap(err, "invalid repository chunker params")
+	}
+
+	if err := repo.loadIndex(ctx); err != nil {
+		return nil, trace.Wrap(err, "failed to load the repository index")
+	}
+
+	return repo, nil
+}
+
+func (r *repository) loadIndex(ctx *contexts.Context) error {
+	keys, err := r.store.List(ctx, indexPrefix)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	for _, key := range keys {
+		rawIndex, err := r.store.Get(ctx, key)
+		if err != nil {
+			return trace.Wrap(err)
+		}
+
+		var index packIndex
+		if err := json.Unmarshal(rawIndex, &index); err != nil {
+			return trace.Wrap(err, "failed to decode index %q", key)
+		}
+
+		r.addPack(strings.TrimPrefix(key, indexPrefix), index.Chunks)
+	}
+
+	return nil
+}
+
+func (r *repository) addPack(id string, entries []indexEntry) {
+	r.packs[id] = entries
+	for _, entry := range entries {
+		r.chunks[entry.ID] = chunkLocation{pack: id, offset: entry.Offset, length: entry.Length}
+	}
+}
+
+// readChunk reads the chunk, and verifies it against its ID.
+func (r *repository) readChunk(ctx *contexts.Context, id string) ([]byte, error) {
+	location, ok := r.chunks[id]
+	if !ok {
+		return nil, trace.NotFound("chunk %q is not in the repository", id)
+	}
+
+	data, err := r.store.GetRange(ctx, packKey(location.pack), location.offset, location.length)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to read chunk %q from pack %q", id, location.pack)
+	}
+
+	if hashID(data) != id {
+		return nil, trace.Errorf("chunk %q in pack %q is corrupt", id, location.pack)
+	}
+
+	return data, nil
+}
+
+func (r *repository) saveSnapshot(ctx *contexts.Context, snapshot *snapshotObject) (string, error) {
+	rawSnapshot, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", trace.Wrap(err, "failed to encode the snapshot")
+	}
+
+	id := hashID(rawSnapshot)
+	if err := r.store.Put(ctx, snapshotsPrefix+id, rawSnapshot); err != nil {
+		return "", trace.Wrap(err, "failed to write snapshot %q", id)
+	}
+
+	return id, nil
+}
+
+func (r *repository) loadSnapshot(ctx *contexts.Context, id string) (*snapshotObject, error) {
+	rawSnapshot, err := r.store.Get(ctx, snapshotsPrefix+id)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to read snapshot %q", id)
+	}
+
+	if hashID(rawSnapshot) != id {
+		return nil, trace.Errorf("snapshot %q is corrupt", id)
+	}
+
+	var snapshot snapshotObject
+	if err := json.Unmarshal(rawSnapshot, &snapshot); err != nil {
+		return nil, trace.Wrap(err, "failed to decode snapshot %q", id)
+	}
+
+	return &snapshot, nil
+}
+
+// loadSnapshots loads every snapshot in the repository, keyed by ID.
+func (r *repository) loadSnapshots(ctx *contexts.Context) (map[string]*snapshotObject, error) {
+	keys, err := r.store.List(ctx, snapshotsPrefix)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to list snapshots")
+	}
+
+	snapshots := make(map[string]*snapshotObject, len(keys))
+	for _, key := range keys {
+		id := strings.TrimPrefix(key, snapshotsPrefix)
+		snapshot, err := r.loadSnapshot(ctx, id)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+		snapshots[id] = snapshot
+	}
+
+	return snapshots, nil
+}
+
+// sortedSnapshots returns summaries of the snapshots, oldest first.
+func sortedSnapshots(snapshots map[string]*snapshotObject) []Snapshot {
+	summaries := make([]Snapshot, 0, len(snapshots))
+	for id, snapshot := range snapshots {
+		summaries = append(summaries, snapshot.summary(id))
+	}
+
+	slices.SortFunc(summaries, func(a, b Snapshot) int {
+		if c := a.Time.Compare(b.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return summaries
+}
+
+// packer accumulates chunks into packs, and writes each pack (and its index) once it is full.
+type packer struct {
+	repo    *repository
+	buf     bytes.Buffer
+	entries []indexEntry
+	pending map[string]struct{}
+
+	// written holds the IDs of the packs that have been written.
+	written    map[string]struct{}
+	addedBytes int64
+}
+
+func newPacker(repo *repository) *packer {
+	return &packer{repo: repo, pending: make(map[string]struct{}), written: make(map[string]struct{})}
+}
+
+// add stores the chunk, unless the repository (or a pack being filled) already has it.
+func (p *packer) add(ctx *contexts.Context, id string, data []byte) error {
+	if _, ok := p.repo.chunks[id]; ok {
+		return nil
+	}
+
+	return trace.Wrap(p.write(ctx, id, data))
+}
+
+// write stores the chunk, even when the repository has it elsewhere. Prunes use this to move chunks out of
+// packs that are being removed.
+func (p *packer) write(ctx *contexts.Context, id string, data []byte) error {
+	if _, ok := p.pending[id]; ok {
+		return nil
+	}
+
+	p.entries = append(p.entries, indexEntry{ID: id, Offset: int64(p.buf.Len()), Length: int64(len(data))})
+	p.pending[id] = struct{}{}
+	p.buf.Write(data)
+	p.addedBytes += int64(len(data))
+
+	if p.buf.Len() < packTargetSize {
+		return nil
+	}
+
+	return trace.Wrap(p.flush(ctx))
+}
+
+// flush writes the pack being filled, if it has any chunks.
+func (p *packer) flush(ctx *contexts.Context) error {
+	if len(p.entries) == 0 {
+		return nil
+	}
+
+	id := hashID(p.buf.Bytes())
+	if err := p.repo.store.Put(ctx, packKey(id), p.buf.Bytes()); err != nil {
+		return trace.Wrap(err, "failed to write pack %q", id)
+	}
+
+	rawIndex, err := json.Marshal(packIndex{Chunks: p.entries})
+	if err != nil {
+		return trace.Wrap(err, "failed to encode the index of pack %q", id)
+	}
+
+	if err := p.repo.store.Put(ctx, indexPrefix+id, rawIndex); err != nil {
+		return trace.Wrap(err, "failed to write the index of pack %q", id)
+	}
+
+	p.repo.addPack(id, p.entries)
+	p.written[id] = struct{}{}
+	p.buf.Reset()
+	p.entries = nil
+	clear(p.pending)
+	return nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+)
+
+// restore makes dest an exact copy of the snapshot. Everything under dest that is not part of the snapshot
+// is removed first, then the tree is written in order (parents before their children), and finally the
+// metadata is applied in reverse order, so that writing a directory's contents doesn't change its
+// modification time afterwards. Ownership is only restored when running as root. Special files (such as
+// sockets and device files) are not restored.
+func (r *repository) restore(ctx *contexts.Context, snapshotID, dest string, limiter *throttle.Limiter) error {
+	snapshot, err := r.loadSnapshot(ctx, snapshotID)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	// Fail before anything is changed if the snapshot can't be restored in full.
+	for _, n := range snapshot.Tree {
+		for _, id := range n.Chunks {
+			if _, ok := r.chunks[id]; !ok {
+				return trace.NotFound("chunk %q of %q is missing from the repository", id, n.Path)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return trace.Wrap(err, "failed to create %q", dest)
+	}
+
+	tree := make(map[string]node, len(snapshot.Tree))
+	for _, n := range snapshot.Tree {
+		tree[n.Path] = n
+	}
+
+	if err := removeExtraneousEntries(dest, tree); err != nil {
+		return trace.Wrap(err, "failed to remove entries that are not part of the snapshot")
+	}
+
+	for _, n := range snapshot.Tree {
+		if err := r.restoreNode(ctx, dest, n, limiter); err != nil {
+			return trace.Wrap(err, "failed to restore %q", n.Path)
+		}
+	}
+
+	for _, n := range slices.Backward(snapshot.Tree) {
+		if err := restoreMetadata(dest, n); err != nil {
+			return trace.Wrap(err, "failed to restore the metadata of %q", n.Path)
+		}
+	}
+
+	return nil
+}
+
+// removeExtraneousEntries deletes everything under dest that the tree doesn't have, or has as a different
+// type of entry.
+func removeExtraneousEntries(dest string, tree map[string]node) error {
+	return filepath.WalkDir(dest, func(entryPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return trace.Wrap(err, "failed to walk over path %q", entryPath)
+		}
+
+		relPath, err := filepath.Rel(dest, entryPath)
+		if err != nil {
+			return trace.Wrap(err, "failed to get file path %q relative to %q", entryPath, dest)
+		}
+
+		if relPath == "." {
+			return nil
+		}
+
+		if n, ok := tree[filepath.ToSlash(relPath)]; ok && n.Type == entryType(d.Type()) {
+			return nil
+		}
+
+		if err := os.RemoveAll(entryPath); err != nil {
+			return trace.Wrap(err, "failed to remove %q", entryPath)
+		}
+
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+func entryType(mode fs.FileMode) files.EntryType {
+	switch {
+	case mode.IsRegular():
+		return files.EntryTypeFile
+	case mode.IsDir():
+		return files.EntryTypeDirectory
+	case mode&fs.ModeSymlink != 0:
+		return files.EntryTypeSymlink
+	default:
+		return files.EntryTypeOther
+	}
+}
+
+func (r *repository) restoreNode(ctx *contexts.Context, dest string, n node, limiter *throttle.Limiter) error {
+	nodePath := filepath.Join(dest, filepath.FromSlash(n.Path))
+	switch n.Type {
+	case files.EntryTypeDirectory:
+		return trace.Wrap(os.MkdirAll(nodePath, 0700), "failed to create directory")
+	case files.EntryTypeSymlink:
+		if err := os.Remove(nodePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return trace.Wrap(err, "failed to remove the existing symlink")
+		}
+		return trace.Wrap(os.Symlink(n.LinkTarget, nodePath), "failed to create symlink")
+	case files.EntryTypeFile:
+		return trace.Wrap(r.restoreFile(ctx, nodePath, n, limiter))
+	default:
+		ctx.Log.With("path", n.Path).Debug("Skipping special file")
+		return nil
+	}
+}
+
+func (r *repository) restoreFile(ctx *contexts.Context, filePath string, n node, limiter *throttle.Limiter) (err error) {
+	if err := limiter.WaitFile(ctx); err != nil {
+		return trace.Wrap(err)
+	}
+
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return trace.Wrap(err, "failed to open file for writing")
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = trace.Wrap(closeErr, "failed to close file")
+		}
+	}()
+
+	for _, id := range n.Chunks {
+		chunk, err := r.readChunk(ctx, id)
+		if err != nil {
+			return trace.Wrap(err)
+		}
+
+		if _, err := io.Copy(f, limiter.Reader(ctx, bytes.NewReader(chunk))); err != nil {
+			return trace.Wrap(err, "failed to write file")
+		}
+	}
+
+	return nil
+}
+
+func restoreMetadata(dest string, n node) error {
+	if n.Type == files.EntryTypeOther {
+		return nil
+	}
+
+	nodePath := filepath.Join(dest, filepath.FromSlash(n.Path))
+	if os.Geteuid() == 0 {
+		if err := os.Lchown(nodePath, int(n.UID), int(n.GID)); err != nil {
+			return trace.Wrap(err, "failed to set ownership")
+		}
+	}
+
+	// Symlinks have no permissions of their own, and Go can't set their times without following them.
+	if n.Type == files.EntryTypeSymlink {
+		return nil
+	}
+
+	if err := os.Chmod(nodePath, n.Mode); err != nil {
+		return trace.Wrap(err, "failed to set permissions")
+	}
+
+	return trace.Wrap(os.Chtimes(nodePath, n.ModTime, n.ModTime), "failed to set modification time")
+}
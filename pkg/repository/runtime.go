@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+)
+
+// Snapshot describes a single backup of a directory tree into a repository.
+type Snapshot struct {
+	ID   string
+	Name string
+	Tags []string
+	Time time.Time
+	// Parent is the snapshot that unchanged files were taken from without being read again, if any.
+	Parent    string
+	FileCount int64
+	// Size is the total size of the snapshot's files.
+	Size int64
+	// AddedBytes is the amount of chunk data that the snapshot added to the repository. The rest of its
+	// contents were already stored.
+	AddedBytes int64
+	// Retention is the Object Lock protection that the snapshot's objects were given when it was created.
+	Retention s3.ObjectRetention
+}
+
+// HasTag reports whether the snapshot is tagged with tag.
+func (s Snapshot) HasTag(tag string) bool {
+	return slices.Contains(s.Tags, tag)
+}
+
+// LatestSnapshot returns the most recent of the snapshots (as returned by ListSnapshots) with the given name,
+// and with the given tag unless it is empty.
+func LatestSnapshot(snapshots []Snapshot, name, tag string) (Snapshot, bool) {
+	for _, snapshot := range slices.Backward(snapshots) {
+		if snapshot.Name == name && (tag == "" || snapshot.HasTag(tag)) {
+			return snapshot, true
+		}
+	}
+
+	return Snapshot{}, false
+}
+
+// BackupOptions are the parameters for a backup into a repository.
+type BackupOptions struct {
+	// Name groups the snapshots of the same source, such as the backups of one app. It is required.
+	Name string
+	// Tags label the snapshot, such as with the backup event that it was taken for.
+	Tags []string
+	// Full reads every file, rather than reusing the chunks of files that are unchanged (by size and
+	// modification time) since the latest snapshot with the same name.
+	Full bool
+	// Limits caps the rate that files are read at. The zero value is unlimited.
+	Limits throttle.Limits
+	// ObjectLock protects the snapshot from deletion with S3 Object Lock. Every object that the snapshot
+	// depends on is protected, including those written by earlier backups, so it is only supported by s3://
+	// repositories. The zero value protects nothing.
+	ObjectLock s3.ObjectLock
+}
+
+// RestoreOptions are the optional parameters for a restore from a repository.
+type RestoreOptions struct {
+	// Limits caps the rate that files are written at. The zero value is unlimited.
+	Limits throttle.Limits
+}
+
+// PruneOptions are the optional parameters for pruning a repository.
+type PruneOptions struct {
+	// KeepLast is the number of snapshots of each name to keep. Older snapshots are removed, unless they are
+	// still protected by Object Lock. Zero keeps every snapshot, and only removes data that no snapshot
+	// references (such as that of an interrupted backup).
+	KeepLast int
+}
+
+// PruneResult reports what a prune removed.
+type PruneResult struct {
+	// RemovedSnapshots are the IDs of the snapshots that were removed.
+	RemovedSnapshots []string
+	// DeletedPacks is the number of packs that were deleted. The chunks in them that are still referenced
+	// were first written to new packs.
+	DeletedPacks int
+	WrittenPacks int
+}
+
+// Runtime backs directory trees up into content-addressed, deduplicating repositories, and restores them.
+// Repositories are located by either an s3://bucket/prefix URL (which requires credentials) or a local
+// directory path. Represents a place (i.e. local or remote) where commands can run.
+type Runtime interface {
+	// Backup stores the contents of the src directory as a new snapshot. The repository is created if it does
+	// not exist yet.
+	Backup(ctx *contexts.Context, credentials s3.CredentialsInterface, repository, src string, opts BackupOptions) (Snapshot, error)
+	// Restore makes the dest directory an exact copy of the snapshot. Anything in the directory that is not
+	// part of the snapshot is removed.
+	Restore(ctx *contexts.Context, credentials s3.CredentialsInterface, repository, snapshotID, dest string, opts RestoreOptions) error
+	// ListSnapshots returns the repository's snapshots, oldest first.
+	ListSnapshots(ctx *contexts.Context, credentials s3.CredentialsInterface, repository string) ([]Snapshot, error)
+	// Prune removes old snapshots, and then the chunks that no remaining snapshot references. Snapshots still
+	// protected by Object Lock, and the objects that they depend on, are never removed.
+	Prune(ctx *contexts.Context, credentials s3.CredentialsInterface, repository string, opts PruneOptions) (PruneResult, error)
+}
+
+type LocalRuntime struct {
+	// Testing injection
+	openStore     func(credentials s3.CredentialsInterface, location string, retention s3.ObjectRetention) (Store, error)
+	chunkerParams ChunkerParams
+	now           func() time.Time
+}
+
+func NewLocalRuntime() *LocalRuntime {
+	return &LocalRuntime{
+		openStore:     openStore,
+		chunkerParams: DefaultChunkerParams,
+		now:           time.Now,
+	}
+}
+
+// open opens the repository at location. Objects written to it are protected with the retention.
+func (lr *LocalRuntime) open(ctx *contexts.Context, credentials s3.CredentialsInterface, location string, create bool, retention s3.ObjectRetention) (*repository, error) {
+	store, err := lr.openStore(credentials, location, retention)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to open the store for repository %q", location)
+	}
+
+	repo, err := openRepository(ctx, store, create, lr.chunkerParams)
+	return repo, trace.Wrap(err, "failed to open repository %q", location)
+}
+
+func (lr *LocalRuntime) Backup(ctx *contexts.Context, credentials s3.CredentialsInterface, repository, src string, opts BackupOptions) (snapshot Snapshot, err error) {
+	limiter := throttle.NewLimiter(opts.Limits)
+	ctx.Log.With("repository", repository, "src", src, "name", opts.Name).Info("Backing up to repository")
+	defer ctx.Log.Info("Finished backing up to repository", ctx.Stopwatch.Keyval(), limiter, contexts.ErrorKeyvals(&err))
+
+	if opts.Name == "" {
+		return Snapshot{}, trace.BadParameter("snapshot name is required")
+	}
+
+	if err := opts.Limits.Validate(); err != nil {
+		return Snapshot{}, trace.Wrap(err, "invalid rate limits")
+	}
+
+	if err := opts.ObjectLock.Validate(); err != nil {
+		return Snapshot{}, trace.Wrap(err, "invalid object lock")
+	}
+	retention := opts.ObjectLock.At(lr.now())
+
+	repo, err := lr.open(ctx.Child(), credentials, repository, true, retention)
+	if err != nil {
+		return Snapshot{}, trace.Wrap(err)
+	}
+
+	snapshot, err = repo.backup(ctx.Child(), src, opts, retention, limiter)
+	return snapshot, trace.Wrap(err, "failed to back up %q to repository %q", src, repository)
+}
+
+func (lr *LocalRuntime) Restore(ctx *contexts.Context, credentials s3.CredentialsInterface, repository, snapshotID, dest string, opts RestoreOptions) (err error) {
+	limiter := throttle.NewLimiter(opts.Limits)
+	ctx.Log.With("repository", repository, "snapshot", snapshotID, "dest", dest).Info("Restoring from repository")
+	defer ctx.Log.Info("Finished restoring from repository", ctx.Stopwatch.Keyval(), limiter, contexts.ErrorKeyvals(&err))
+
+	if snapshotID == "" {
+		return trace.BadParameter("snapshot ID is required")
+	}
+
+	if err := opts.Limits.Validate(); err != nil {
+		return trace.Wrap(err, "invalid rate limits")
+	}
+
+	repo, err := lr.open(ctx.Child(), credentials, repository, false, s3.ObjectRetention{})
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	err = repo.restore(ctx.Child(), snapshotID, dest, limiter)
+	return trace.Wrap(err, "failed to restore snapshot %q from repository %q to %q", snapshotID, repository, dest)
+}
+
+func (lr *LocalRuntime) ListSnapshots(ctx *contexts.Context, credentials s3.CredentialsInterface, repository string) (snapshots []Snapshot, err error) {
+	ctx.Log.With("repository", repository).Info("Listing repository snapshots")
+	defer ctx.Log.Info("Finished listing repository snapshots", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	repo, err := lr.open(ctx.Child(), credentials, repository, false, s3.ObjectRetention{})
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+
+	loaded, err := repo.loadSnapshots(ctx.Child())
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to load the snapshots of repository %q", repository)
+	}
+
+	return sortedSnapshots(loaded), nil
+}
+
+func (lr *LocalRuntime) Prune(ctx *contexts.Context, credentials s3.CredentialsInterface, repository string, opts PruneOptions) (result PruneResult, err error) {
+	ctx.Log.With("repository", repository, "keepLast", opts.KeepLast).Info("Pruning repository")
+	defer ctx.Log.Info("Finished pruning repository", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	if opts.KeepLast < 0 {
+		return PruneResult{}, trace.BadParameter("the number of snapshots to keep must not be negative")
+	}
+
+	repo, err := lr.open(ctx.Child(), credentials, repository, false, s3.ObjectRetention{})
+	if err != nil {
+		return PruneResult{}, trace.Wrap(err)
+	}
+
+	result, err = repo.prune(ctx.Child(), opts, lr.now())
+	return result, trace.Wrap(err, "failed to prune repository %q", repository)
+}
@@ -0,0 +1,466 @@
+package repository
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRuntime() *LocalRuntime {
+	lr := NewLocalRuntime()
+	lr.chunkerParams = testChunkerParams
+	return lr
+}
+
+// writeSourceTree creates a tree with each kind of entry that a snapshot records.
+func writeSourceTree(t *testing.T, dir string) {
+	t.Helper()
+
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "data", "nested"), 0750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "empty"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data", "large.bin"), randomData(t, 10, 64*1024), 0640))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data", "nested", "small.txt"), []byte("small file"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data", "nested", "copy.bin"), randomData(t, 10, 64*1024), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.txt"), nil, 0644))
+	require.NoError(t, os.Symlink("data/nested/small.txt", filepath.Join(dir, "link")))
+
+	for _, p := range []string{"data/large.bin", "data/nested/small.txt", "data/nested/copy.bin", "empty.txt", "data/nested", "data", "empty"} {
+		require.NoError(t, os.Chtimes(filepath.Join(dir, p), modTime, modTime))
+	}
+}
+
+// assertTreesEqual compares the listings and file contents of two directories.
+func assertTreesEqual(t *testing.T, expectedDir, actualDir string) {
+	t.Helper()
+
+	ctx := th.NewTestContext()
+	expected, err := files.NewLocalRuntime().ListTree(ctx, expectedDir, files.ListTreeOptions{})
+	require.NoError(t, err)
+	actual, err := files.NewLocalRuntime().ListTree(ctx, actualDir, files.ListTreeOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, actual.Entries, len(expected.Entries))
+	for i, expectedEntry := range expected.Entries {
+		actualEntry := actual.Entries[i]
+		assert.Equal(t, expectedEntry.Path, actualEntry.Path)
+		assert.Equal(t, expectedEntry.Type, actualEntry.Type, expectedEntry.Path)
+		assert.Equal(t, expectedEntry.LinkTarget, actualEntry.LinkTarget, expectedEntry.Path)
+		if expectedEntry.Type == files.EntryTypeSymlink {
+			continue
+		}
+
+		assert.Equal(t, expectedEntry.Mode, actualEntry.Mode, expectedEntry.Path)
+		assert.True(t, expectedEntry.ModTime.Equal(actualEntry.ModTime), expectedEntry.Path)
+		if expectedEntry.Type == files.EntryTypeFile {
+			expectedContents, err := os.ReadFile(filepath.Join(expectedDir, expectedEntry.Path))
+			require.NoError(t, err)
+			actualContents, err := os.ReadFile(filepath.Join(actualDir, actualEntry.Path))
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(expectedContents, actualContents), expectedEntry.Path)
+		}
+	}
+}
+
+func TestLatestSnapshot(t *testing.T) {
+	snapshots := []Snapshot{
+		{ID: "1", Name: "app", Tags: []string{"app-event-1"}},
+		{ID: "2", Name: "other", Tags: []string{"other-event-1"}},
+		{ID: "3", Name: "app", Tags: []string{"app-event-2"}},
+	}
+
+	snapshot, ok := LatestSnapshot(snapshots, "app", "")
+	require.True(t, ok)
+	assert.Equal(t, "3", snapshot.ID)
+
+	snapshot, ok = LatestSnapshot(snapshots, "app", "app-event-1")
+	require.True(t, ok)
+	assert.Equal(t, "1", snapshot.ID)
+
+	_, ok = LatestSnapshot(snapshots, "app", "other-event-1")
+	assert.False(t, ok)
+
+	_, ok = LatestSnapshot(snapshots, "missing", "")
+	assert.False(t, ok)
+}
+
+func TestLocalRuntimeBackupAndRestore(t *testing.T) {
+	src := t.TempDir()
+	writeSourceTree(t, src)
+	repository := filepath.Join(t.TempDir(), "repository")
+	lr := newTestRuntime()
+	ctx := th.NewTestContext()
+
+	snapshot, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app", Tags: []string{"app-event-1"}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, snapshot.ID)
+	assert.Equal(t, "app", snapshot.Name)
+	assert.Equal(t, []string{"app-event-1"}, snapshot.Tags)
+	assert.Empty(t, snapshot.Parent)
+	assert.Equal(t, int64(4), snapshot.FileCount)
+	assert.Equal(t, int64(2*64*1024+10), snapshot.Size)
+	// The two identical files are only stored once.
+	assert.Equal(t, int64(64*1024+10), snapshot.AddedBytes)
+
+	t.Run("restore to a new directory", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "dest")
+		require.NoError(t, lr.Restore(ctx, nil, repository, snapshot.ID, dest, RestoreOptions{}))
+		assertTreesEqual(t, src, dest)
+	})
+
+	t.Run("restore over an existing directory", func(t *testing.T) {
+		dest := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dest, "extra", "dir"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dest, "extra", "dir", "file"), []byte("extra"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dest, "data"), []byte("a file where a directory belongs"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dest, "empty.txt"), []byte("stale contents"), 0644))
+
+		require.NoError(t, lr.Restore(ctx, nil, repository, snapshot.ID, dest, RestoreOptions{Limits: throttle.Limits{FilesPerSecond: 1000}}))
+		assertTreesEqual(t, src, dest)
+	})
+
+	t.Run("list snapshots", func(t *testing.T) {
+		snapshots, err := lr.ListSnapshots(ctx, nil, repository)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, snapshot.ID, snapshots[0].ID)
+		assert.True(t, snapshot.Time.Equal(snapshots[0].Time))
+		assert.Equal(t, snapshot.AddedBytes, snapshots[0].AddedBytes)
+	})
+
+	t.Run("restore a snapshot that does not exist", func(t *testing.T) {
+		assert.Error(t, lr.Restore(ctx, nil, repository, "missing", t.TempDir(), RestoreOptions{}))
+	})
+}
+
+func TestLocalRuntimeIncrementalBackup(t *testing.T) {
+	src := t.TempDir()
+	writeSourceTree(t, src)
+	repository := filepath.Join(t.TempDir(), "repository")
+	lr := newTestRuntime()
+	ctx := th.NewTestContext()
+
+	first, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app"})
+	require.NoError(t, err)
+
+	t.Run("unchanged tree adds nothing", func(t *testing.T) {
+		second, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app"})
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.Parent)
+		assert.Equal(t, first.Size, second.Size)
+		assert.Zero(t, second.AddedBytes)
+	})
+
+	t.Run("a full backup has no parent", func(t *testing.T) {
+		full, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app", Full: true})
+		require.NoError(t, err)
+		assert.Empty(t, full.Parent)
+		assert.Zero(t, full.AddedBytes)
+	})
+
+	t.Run("snapshots of another name are not parents", func(t *testing.T) {
+		other, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "other"})
+		require.NoError(t, err)
+		assert.Empty(t, other.Parent)
+	})
+
+	t.Run("an edit only adds the chunks around it", func(t *testing.T) {
+		largePath := filepath.Join(src, "data", "large.bin")
+		contents, err := os.ReadFile(largePath)
+		require.NoError(t, err)
+		edited := append(append(bytes.Clone(contents[:30000]), []byte("inserted bytes")...), contents[30000:]...)
+		require.NoError(t, os.WriteFile(largePath, edited, 0640))
+
+		edit, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app"})
+		require.NoError(t, err)
+		assert.NotZero(t, edit.AddedBytes)
+		assert.Less(t, edit.AddedBytes, int64(4*testChunkerParams.MaxSize))
+
+		dest := t.TempDir()
+		require.NoError(t, lr.Restore(ctx, nil, repository, edit.ID, dest, RestoreOptions{}))
+		assertTreesEqual(t, src, dest)
+	})
+}
+
+func TestLocalRuntimePrune(t *testing.T) {
+	src := t.TempDir()
+	writeSourceTree(t, src)
+	repository := filepath.Join(t.TempDir(), "repository")
+	lr := newTestRuntime()
+	ctx := th.NewTestContext()
+	store := newLocalStore(repository)
+
+	first, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app"})
+	require.NoError(t, err)
+
+	// The second snapshot only has the first's chunks once a file is removed, and a new one is added.
+	require.NoError(t, os.Remove(filepath.Join(src, "data", "large.bin")))
+	require.NoError(t, os.Remove(filepath.Join(src, "data", "nested", "copy.bin")))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "new.bin"), randomData(t, 20, 32*1024), 0644))
+	second, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app"})
+	require.NoError(t, err)
+
+	// Snapshots of other names are kept separately.
+	otherSrc := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(otherSrc, "other.bin"), randomData(t, 30, 8*1024), 0644))
+	other, err := lr.Backup(ctx, nil, repository, otherSrc, BackupOptions{Name: "other"})
+	require.NoError(t, err)
+
+	t.Run("keeping every snapshot removes nothing", func(t *testing.T) {
+		result, err := lr.Prune(ctx, nil, repository, PruneOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, PruneResult{}, result)
+	})
+
+	t.Run("unindexed packs are deleted", func(t *testing.T) {
+		orphanID := hashID([]byte("orphan"))
+		require.NoError(t, store.Put(ctx, packKey(orphanID), []byte("orphan")))
+
+		result, err := lr.Prune(ctx, nil, repository, PruneOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.DeletedPacks)
+
+		_, err = store.Get(ctx, packKey(orphanID))
+		assert.True(t, trace.IsNotFound(err))
+	})
+
+	t.Run("old snapshots and their chunks are removed", func(t *testing.T) {
+		result, err := lr.Prune(ctx, nil, repository, PruneOptions{KeepLast: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.ID}, result.RemovedSnapshots)
+		assert.NotZero(t, result.DeletedPacks)
+
+		snapshots, err := lr.ListSnapshots(ctx, nil, repository)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, second.ID, snapshots[0].ID)
+		assert.Equal(t, other.ID, snapshots[1].ID)
+
+		// Only the chunks of the remaining snapshots are left.
+		repo, err := openRepository(ctx, store, false, testChunkerParams)
+		require.NoError(t, err)
+		var storedBytes int64
+		for _, entries := range repo.packs {
+			for _, entry := range entries {
+				storedBytes += entry.Length
+			}
+		}
+		assert.Equal(t, second.Size+other.Size, storedBytes)
+
+		dest := t.TempDir()
+		require.NoError(t, lr.Restore(ctx, nil, repository, second.ID, dest, RestoreOptions{}))
+		assertTreesEqual(t, src, dest)
+
+		otherDest := t.TempDir()
+		require.NoError(t, lr.Restore(ctx, nil, repository, other.ID, otherDest, RestoreOptions{}))
+		assertTreesEqual(t, otherSrc, otherDest)
+	})
+
+	t.Run("a later backup stores the pruned data again", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(src, "link")))
+		writeSourceTree(t, src)
+		snapshot, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(64*1024), snapshot.AddedBytes)
+
+		dest := t.TempDir()
+		require.NoError(t, lr.Restore(ctx, nil, repository, snapshot.ID, dest, RestoreOptions{}))
+		assertTreesEqual(t, src, dest)
+	})
+}
+
+// retainingStore is a local store that records the objects that it is asked to protect.
+type retainingStore struct {
+	*localStore
+	retained []string
+}
+
+func (rs *retainingStore) Retain(_ *contexts.Context, key string) error {
+	rs.retained = append(rs.retained, key)
+	return nil
+}
+
+func TestLocalRuntimeObjectLock(t *testing.T) {
+	src := t.TempDir()
+	writeSourceTree(t, src)
+	repository := filepath.Join(t.TempDir(), "repository")
+	ctx := th.NewTestContext()
+
+	now := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	lr := newTestRuntime()
+	lr.now = func() time.Time { return now }
+
+	var store *retainingStore
+	var openedWith s3.ObjectRetention
+	lr.openStore = func(_ s3.CredentialsInterface, location string, retention s3.ObjectRetention) (Store, error) {
+		store = &retainingStore{localStore: newLocalStore(location)}
+		openedWith = retention
+		return store, nil
+	}
+
+	first, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app"})
+	require.NoError(t, err)
+	assert.Zero(t, first.Retention)
+	assert.Zero(t, openedWith)
+	assert.Empty(t, store.retained)
+
+	lock := s3.ObjectLock{Mode: s3.ObjectLockModeGovernance, Retention: 24 * time.Hour}
+	protected, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app", ObjectLock: lock})
+	require.NoError(t, err)
+	expectedRetention := s3.ObjectRetention{Mode: s3.ObjectLockModeGovernance, RetainUntil: now.Add(24 * time.Hour)}
+	assert.Equal(t, expectedRetention, protected.Retention)
+	assert.Equal(t, expectedRetention, openedWith)
+
+	// Every chunk was already stored by the unprotected backup, so the objects holding them are protected too.
+	repo, err := openRepository(ctx, store.localStore, false, testChunkerParams)
+	require.NoError(t, err)
+	require.NotEmpty(t, repo.packs)
+	expectedRetained := []string{configKey}
+	for _, packID := range slices.Sorted(maps.Keys(repo.packs)) {
+		expectedRetained = append(expectedRetained, packKey(packID), indexPrefix+packID)
+	}
+	assert.Equal(t, expectedRetained, store.retained)
+
+	latest, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app", Full: true})
+	require.NoError(t, err)
+
+	t.Run("protected snapshots are kept", func(t *testing.T) {
+		result, err := lr.Prune(ctx, nil, repository, PruneOptions{KeepLast: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.ID}, result.RemovedSnapshots)
+
+		snapshots, err := lr.ListSnapshots(ctx, nil, repository)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, protected.ID, snapshots[0].ID)
+		assert.Equal(t, expectedRetention, snapshots[0].Retention)
+		assert.Equal(t, latest.ID, snapshots[1].ID)
+
+		dest := t.TempDir()
+		require.NoError(t, lr.Restore(ctx, nil, repository, protected.ID, dest, RestoreOptions{}))
+		assertTreesEqual(t, src, dest)
+	})
+
+	t.Run("snapshots are removed once their retention expires", func(t *testing.T) {
+		now = expectedRetention.RetainUntil
+
+		result, err := lr.Prune(ctx, nil, repository, PruneOptions{KeepLast: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{protected.ID}, result.RemovedSnapshots)
+
+		dest := t.TempDir()
+		require.NoError(t, lr.Restore(ctx, nil, repository, latest.ID, dest, RestoreOptions{}))
+		assertTreesEqual(t, src, dest)
+	})
+}
+
+func TestLocalRuntimeCorruption(t *testing.T) {
+	src := t.TempDir()
+	writeSourceTree(t, src)
+	repository := filepath.Join(t.TempDir(), "repository")
+	lr := newTestRuntime()
+	ctx := th.NewTestContext()
+
+	snapshot, err := lr.Backup(ctx, nil, repository, src, BackupOptions{Name: "app"})
+	require.NoError(t, err)
+
+	packs, err := newLocalStore(repository).List(ctx, packsPrefix)
+	require.NoError(t, err)
+	for _, key := range packs {
+		packPath := filepath.Join(repository, filepath.FromSlash(key))
+		contents, err := os.ReadFile(packPath)
+		require.NoError(t, err)
+		contents[len(contents)/2] ^= 0xff
+		require.NoError(t, os.WriteFile(packPath, contents, 0644))
+	}
+
+	err = lr.Restore(ctx, nil, repository, snapshot.ID, t.TempDir(), RestoreOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "corrupt")
+}
+
+func TestLocalRuntimeErrors(t *testing.T) {
+	ctx := th.NewTestContext()
+	lr := newTestRuntime()
+	// Failed backups may still create the repository, so each case gets its own path.
+	missingRepository := func() string { return filepath.Join(t.TempDir(), "missing") }
+
+	t.Run("backup without a name", func(t *testing.T) {
+		_, err := lr.Backup(ctx, nil, missingRepository(), t.TempDir(), BackupOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("backup with invalid limits", func(t *testing.T) {
+		_, err := lr.Backup(ctx, nil, missingRepository(), t.TempDir(), BackupOptions{Name: "app", Limits: throttle.Limits{BytesPerSecond: -1}})
+		assert.Error(t, err)
+	})
+
+	t.Run("backup of a missing directory", func(t *testing.T) {
+		_, err := lr.Backup(ctx, nil, missingRepository(), filepath.Join(t.TempDir(), "missing"), BackupOptions{Name: "app"})
+		assert.Error(t, err)
+	})
+
+	t.Run("backup to s3 without credentials", func(t *testing.T) {
+		_, err := lr.Backup(ctx, nil, "s3://bucket/repository", t.TempDir(), BackupOptions{Name: "app"})
+		assert.Error(t, err)
+	})
+
+	t.Run("backup with an unusable store", func(t *testing.T) {
+		lr := newTestRuntime()
+		lr.openStore = func(s3.CredentialsInterface, string, s3.ObjectRetention) (Store, error) { return nil, assert.AnError }
+		_, err := lr.Backup(ctx, nil, missingRepository(), t.TempDir(), BackupOptions{Name: "app"})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("backup with an invalid object lock", func(t *testing.T) {
+		_, err := lr.Backup(ctx, nil, missingRepository(), t.TempDir(), BackupOptions{Name: "app", ObjectLock: s3.ObjectLock{Retention: time.Hour}})
+		assert.Error(t, err)
+	})
+
+	t.Run("restore without a snapshot ID", func(t *testing.T) {
+		assert.Error(t, lr.Restore(ctx, nil, missingRepository(), "", t.TempDir(), RestoreOptions{}))
+	})
+
+	t.Run("restore from a repository that does not exist", func(t *testing.T) {
+		err := lr.Restore(ctx, nil, missingRepository(), "snapshot", t.TempDir(), RestoreOptions{})
+		assert.True(t, trace.IsNotFound(err))
+	})
+
+	t.Run("list the snapshots of a repository that does not exist", func(t *testing.T) {
+		_, err := lr.ListSnapshots(ctx, nil, missingRepository())
+		assert.True(t, trace.IsNotFound(err))
+	})
+
+	t.Run("prune with a negative number of snapshots to keep", func(t *testing.T) {
+		_, err := lr.Prune(ctx, nil, missingRepository(), PruneOptions{KeepLast: -1})
+		assert.Error(t, err)
+	})
+
+	t.Run("open a repository of another format version", func(t *testing.T) {
+		repository := t.TempDir()
+		require.NoError(t, newLocalStore(repository).Put(ctx, configKey, []byte(`{"version":2}`)))
+		_, err := lr.ListSnapshots(ctx, nil, repository)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewLocalRuntime(t *testing.T) {
+	lr := NewLocalRuntime()
+	assert.NotNil(t, lr.openStore)
+	assert.Equal(t, DefaultChunkerParams, lr.chunkerParams)
+	assert.NotNil(t, lr.now)
+	assert.Implements(t, (*Runtime)(nil), lr)
+}
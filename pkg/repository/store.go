@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/cleanup"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+)
+
+// Store holds the objects of a repository, by slash-separated keys. It is satisfied by *s3.ObjectStore.
+type Store interface {
+	// Get reads the whole object. It returns a trace.NotFound error when the object does not exist.
+	Get(ctx *contexts.Context, key string) ([]byte, error)
+	// GetRange reads length bytes of the object, starting at offset.
+	GetRange(ctx *contexts.Context, key string, offset, length int64) ([]byte, error)
+	// Put writes the object, replacing it if it exists. The object is protected with the retention that the
+	// store was opened with, if any.
+	Put(ctx *contexts.Context, key string, data []byte) error
+	// List returns the keys of the objects under prefix, in lexical order.
+	List(ctx *contexts.Context, prefix string) ([]string, error)
+	// Delete removes the object. Deleting an object that does not exist is not an error.
+	Delete(ctx *contexts.Context, key string) error
+	// Retain protects an existing object with the retention that the store was opened with (see Put).
+	Retain(ctx *contexts.Context, key string) error
+}
+
+// openStore opens the store at location: an s3://bucket/prefix URL, or a local directory path. Objects that
+// the store writes are protected with the retention, which only S3 stores support.
+func openStore(credentials s3.CredentialsInterface, location string, retention s3.ObjectRetention) (Store, error) {
+	if strings.HasPrefix(location, "s3://") {
+		if credentials == nil {
+			return nil, trace.BadParameter("credentials are required for repository %q", location)
+		}
+
+		store, err := s3.NewObjectStore(credentials, location)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+		return store.WithRetention(retention), nil
+	}
+
+	if location == "" {
+		return nil, trace.BadParameter("no repository location provided")
+	}
+
+	if !retention.IsZero() {
+		return nil, trace.BadParameter("object lock is only supported by s3:// repositories")
+	}
+
+	return newLocalStore(location), nil
+}
+
+// localStore keeps objects as files under a directory. Objects are written to a temporary file and renamed
+// into place, so that a reader never sees a partial object.
+type localStore struct {
+	root string
+}
+
+func newLocalStore(root string) *localStore {
+	return &localStore{root: root}
+}
+
+func (ls *localStore) path(key string) string {
+	return filepath.Join(ls.root, filepath.FromSlash(key))
+}
+
+func (ls *localStore) Get(_ *contexts.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(ls.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, trace.NotFound("object %q does not exist", key)
+	}
+	return data, trace.Wrap(err, "failed to read object %q", key)
+}
+
+func (ls *localStore) GetRange(_ *contexts.Context, key string, offset, length int64) ([]byte, error) {
+	if length <= 0 {
+		return nil, trace.BadParameter("range length must be positive")
+	}
+
+	f, err := os.Open(ls.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, trace.NotFound("object %q does not exist", key)
+		}
+		return nil, trace.Wrap(err, "failed to open object %q", key)
+	}
+	defer cleanup.To(func(_ *contexts.Context) error { return f.Close() }).
+		WithErrMessage("failed to close object %q", key).
+		Run()
+
+	data := make([]byte, length)
+	if _, err := f.ReadAt(data, offset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, trace.Errorf("object %q is shorter than %d bytes", key, offset+length)
+		}
+		return nil, trace.Wrap(err, "failed to read object %q", key)
+	}
+
+	return data, nil
+}
+
+func (ls *localStore) Put(_ *contexts.Context, key string, data []byte) error {
+	objectPath := ls.path(key)
+	if err := os.MkdirAll(filepath.Dir(objectPath), 0755); err != nil {
+		return trace.Wrap(err, "failed to create the directory for object %q", key)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(objectPath), ".tmp-"+filepath.Base(objectPath)+"-")
+	if err != nil {
+		return trace.Wrap(err, "failed to create a temporary file for object %q", key)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return trace.Wrap(err, "failed to write object %q", key)
+	}
+
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return trace.Wrap(err, "failed to sync object %q", key)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return trace.Wrap(err, "failed to close object %q", key)
+	}
+
+	return trace.Wrap(os.Rename(tempFile.Name(), objectPath), "failed to move object %q into place", key)
+}
+
+func (ls *localStore) List(_ *contexts.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(ls.root, func(entryPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return trace.Wrap(err, "failed to walk over path %q", entryPath)
+		}
+
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(ls.root, entryPath)
+		if err != nil {
+			return trace.Wrap(err, "failed to get file path %q relative to %q", entryPath, ls.root)
+		}
+
+		if key := filepath.ToSlash(relPath); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to list objects under %q", prefix)
+	}
+
+	slices.Sort(keys)
+	return keys, nil
+}
+
+func (ls *localStore) Delete(_ *contexts.Context, key string) error {
+	err := os.Remove(ls.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return trace.Wrap(err, "failed to delete object %q", key)
+}
+
+// Retain does nothing, as local stores never protect their objects.
+func (ls *localStore) Retain(_ *contexts.Context, _ string) error {
+	return nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/s3"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenStore(t *testing.T) {
+	tests := []struct {
+		desc          string
+		location      string
+		credentials   s3.CredentialsInterface
+		retention     s3.ObjectRetention
+		expectedType  Store
+		expectedError bool
+	}{
+		{desc: "local directory", location: "/repository", expectedType: &localStore{}},
+		{desc: "s3 path", location: "s3://bucket/repository", credentials: s3.NewCredentials("id", "secret"), expectedType: &s3.ObjectStore{}},
+		{desc: "s3 path with a retention", location: "s3://bucket/repository", credentials: s3.NewCredentials("id", "secret"), retention: s3.ObjectRetention{LegalHold: true}, expectedType: &s3.ObjectStore{}},
+		{desc: "s3 path without credentials", location: "s3://bucket/repository", expectedError: true},
+		{desc: "local directory with a retention", location: "/repository", retention: s3.ObjectRetention{LegalHold: true}, expectedError: true},
+		{desc: "no location", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			store, err := openStore(tt.credentials, tt.location, tt.retention)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.IsType(t, tt.expectedType, store)
+		})
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "repository")
+	store := newLocalStore(root)
+	ctx := th.NewTestContext()
+
+	t.Run("list a store that does not exist yet", func(t *testing.T) {
+		keys, err := store.List(ctx, "")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("get a missing object", func(t *testing.T) {
+		_, err := store.Get(ctx, "config")
+		assert.True(t, trace.IsNotFound(err))
+
+		_, err = store.GetRange(ctx, "config", 0, 1)
+		assert.True(t, trace.IsNotFound(err))
+	})
+
+	t.Run("put and get", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "config", []byte("config contents")))
+		require.NoError(t, store.Put(ctx, "packs/ab/abcd", []byte("0123456789")))
+		require.NoError(t, store.Put(ctx, "packs/cd/cdef", []byte("abcdefghij")))
+
+		contents, err := os.ReadFile(filepath.Join(root, "packs", "ab", "abcd"))
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", string(contents))
+
+		data, err := store.Get(ctx, "config")
+		require.NoError(t, err)
+		assert.Equal(t, "config contents", string(data))
+	})
+
+	t.Run("replace an object", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "config", []byte("new contents")))
+
+		data, err := store.Get(ctx, "config")
+		require.NoError(t, err)
+		assert.Equal(t, "new contents", string(data))
+	})
+
+	t.Run("get a range", func(t *testing.T) {
+		data, err := store.GetRange(ctx, "packs/ab/abcd", 2, 5)
+		require.NoError(t, err)
+		assert.Equal(t, "23456", string(data))
+
+		_, err = store.GetRange(ctx, "packs/ab/abcd", 2, 0)
+		assert.Error(t, err)
+
+		_, err = store.GetRange(ctx, "packs/ab/abcd", 8, 5)
+		assert.Error(t, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		// Temporary files of interrupted writes are not objects.
+		require.NoError(t, os.WriteFile(filepath.Join(root, "packs", "ab", ".tmp-abcd-123"), nil, 0644))
+
+		keys, err := store.List(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"config", "packs/ab/abcd", "packs/cd/cdef"}, keys)
+
+		keys, err = store.List(ctx, "packs/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"packs/ab/abcd", "packs/cd/cdef"}, keys)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, "packs/ab/abcd"))
+		require.NoError(t, store.Delete(ctx, "packs/ab/abcd"))
+
+		_, err := store.Get(ctx, "packs/ab/abcd")
+		assert.True(t, trace.IsNotFound(err))
+	})
+}
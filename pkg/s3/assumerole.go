@@ -0,0 +1,120 @@
+package s3
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/constants"
+)
+
+// defaultSTSRegion is used to sign STS requests when the credentials don't set a region. STS is available in
+// every region, and us-east-1 hosts the global endpoint.
+const defaultSTSRegion = "us-east-1"
+
+// assumedCredentialsExpiryWindow is how long before they expire that temporary credentials are replaced, so
+// that a request started just before expiry doesn't fail.
+const assumedCredentialsExpiryWindow = 5 * time.Minute
+
+// AssumeRole exchanges credentials with STS for temporary credentials for a role. When a web identity token is
+// configured, the token is exchanged (with AssumeRoleWithWebIdentity), and no access keys are needed.
+// Otherwise the access keys of the credentials that the role is assumed with are exchanged (with AssumeRole).
+//
+// Temporary credentials are minted when a client is first used, and are replaced shortly before they expire,
+// so that long syncs outlive them.
+type AssumeRole struct {
+	RoleARN    string `yaml:"roleArn" jsonschema:"required"`
+	ExternalID string `yaml:"externalId,omitempty"`
+	// SessionName defaults to the tool name.
+	SessionName string `yaml:"sessionName,omitempty"`
+	// Duration is how long each set of temporary credentials is valid for. Zero means 15 minutes for access
+	// keys, and one hour for web identities.
+	Duration time.Duration `yaml:"duration,omitempty"`
+	// WebIdentityTokenFile is the path of a file holding a web identity token, such as a projected
+	// ServiceAccount token. The file is read by the process that the credentials are configured in, and the
+	// token is passed on to the backup-tool instance that uses them, so it must stay valid for the duration of
+	// the sync.
+	WebIdentityTokenFile string `yaml:"webIdentityTokenFile,omitempty"`
+	// WebIdentityToken is the token read from WebIdentityTokenFile.
+	WebIdentityToken string `yaml:"-"`
+	// STSEndpoint overrides the STS endpoint, for STS-compatible services.
+	STSEndpoint string `yaml:"stsEndpoint,omitempty"`
+}
+
+// IsWebIdentity reports whether the role is assumed with a web identity token rather than access keys.
+func (ar *AssumeRole) IsWebIdentity() bool {
+	return ar.WebIdentityTokenFile != "" || ar.WebIdentityToken != ""
+}
+
+// WithWebIdentityToken returns a copy of the role with the web identity token read from WebIdentityTokenFile,
+// so that the token can be passed to a process that can't read the file. The role is returned unchanged when
+// it isn't assumed with a web identity token, or the token has already been read.
+func (ar *AssumeRole) WithWebIdentityToken() (*AssumeRole, error) {
+	if ar.WebIdentityToken != "" || ar.WebIdentityTokenFile == "" {
+		return ar, nil
+	}
+
+	token, err := os.ReadFile(ar.WebIdentityTokenFile)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to read web identity token file %q", ar.WebIdentityTokenFile)
+	}
+
+	resolved := *ar
+	resolved.WebIdentityToken = strings.TrimSpace(string(token))
+	return &resolved, nil
+}
+
+// webIdentityToken is an already-read web identity token.
+type webIdentityToken string
+
+func (t webIdentityToken) GetIdentityToken() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// credentialsProvider returns a provider of temporary credentials for the role. sourceConfig carries the
+// credentials that the role is assumed with (unused for web identities) and the region.
+func (ar *AssumeRole) credentialsProvider(sourceConfig aws.Config) aws.CredentialsProvider {
+	if sourceConfig.Region == "" {
+		sourceConfig.Region = defaultSTSRegion
+	}
+
+	client := sts.NewFromConfig(sourceConfig, func(o *sts.Options) {
+		if ar.STSEndpoint != "" {
+			o.BaseEndpoint = aws.String(ar.STSEndpoint)
+		}
+	})
+
+	sessionName := ar.SessionName
+	if sessionName == "" {
+		sessionName = constants.ToolName
+	}
+
+	var provider aws.CredentialsProvider
+	if ar.IsWebIdentity() {
+		var tokenRetriever stscreds.IdentityTokenRetriever = stscreds.IdentityTokenFile(ar.WebIdentityTokenFile)
+		if ar.WebIdentityToken != "" {
+			tokenRetriever = webIdentityToken(ar.WebIdentityToken)
+		}
+
+		provider = stscreds.NewWebIdentityRoleProvider(client, ar.RoleARN, tokenRetriever, func(o *stscreds.WebIdentityRoleOptions) {
+			o.RoleSessionName = sessionName
+			o.Duration = ar.Duration
+		})
+	} else {
+		provider = stscreds.NewAssumeRoleProvider(client, ar.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = sessionName
+			if ar.ExternalID != "" {
+				o.ExternalID = aws.String(ar.ExternalID)
+			}
+			o.Duration = ar.Duration
+		})
+	}
+
+	return aws.NewCredentialsCache(provider, func(o *aws.CredentialsCacheOptions) {
+		o.ExpiryWindow = assumedCredentialsExpiryWindow
+	})
+}
@@ -0,0 +1,304 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSTS is a minimal STS-compatible server for tests, which issues temporary credentials for AssumeRole and
+// AssumeRoleWithWebIdentity requests.
+type fakeSTS struct {
+	server *httptest.Server
+
+	lock sync.Mutex
+	// requests records the parameters of each request, along with the access key ID that it was signed with
+	// (as "SigningAccessKeyId").
+	requests []url.Values
+	// lifetime is how long issued credentials are valid for.
+	lifetime time.Duration
+	// fail, when set, rejects every request.
+	fail bool
+}
+
+// newFakeSTS starts a fake STS server, which is stopped when the test completes.
+func newFakeSTS(t *testing.T) *fakeSTS {
+	fake := &fakeSTS{lifetime: time.Hour}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+func (f *fakeSTS) takeRequests() []url.Values {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	requests := f.requests
+	f.requests = nil
+	return requests
+}
+
+type fakeSTSCredentials struct {
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time
+}
+
+type fakeSTSAssumedRoleUser struct {
+	Arn           string
+	AssumedRoleId string
+}
+
+func (f *fakeSTS) handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.lock.Lock()
+	params := r.PostForm
+	params.Set("SigningAccessKeyId", signingAccessKeyID(r))
+	f.requests = append(f.requests, params)
+	issued := len(f.requests)
+	lifetime := f.lifetime
+	fail := f.fail
+	f.lock.Unlock()
+
+	if fail {
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>AccessDenied</Code><Message>denied</Message></Error><RequestId>1</RequestId></ErrorResponse>`)
+		return
+	}
+
+	credentials := fakeSTSCredentials{
+		AccessKeyId:     fmt.Sprintf("ASIA-ASSUMED-%d", issued),
+		SecretAccessKey: "assumed-secret",
+		SessionToken:    "assumed-token",
+		Expiration:      time.Now().Add(lifetime).UTC(),
+	}
+	user := fakeSTSAssumedRoleUser{
+		Arn:           params.Get("RoleArn") + "/" + params.Get("RoleSessionName"),
+		AssumedRoleId: "AROA:" + params.Get("RoleSessionName"),
+	}
+
+	action := params.Get("Action")
+	var response any
+	switch action {
+	case "AssumeRole":
+		response = struct {
+			XMLName xml.Name `xml:"AssumeRoleResponse"`
+			Result  struct {
+				Credentials     fakeSTSCredentials
+				AssumedRoleUser fakeSTSAssumedRoleUser
+			} `xml:"AssumeRoleResult"`
+		}{Result: struct {
+			Credentials     fakeSTSCredentials
+			AssumedRoleUser fakeSTSAssumedRoleUser
+		}{credentials, user}}
+	case "AssumeRoleWithWebIdentity":
+		response = struct {
+			XMLName xml.Name `xml:"AssumeRoleWithWebIdentityResponse"`
+			Result  struct {
+				Credentials     fakeSTSCredentials
+				AssumedRoleUser fakeSTSAssumedRoleUser
+			} `xml:"AssumeRoleWithWebIdentityResult"`
+		}{Result: struct {
+			Credentials     fakeSTSCredentials
+			AssumedRoleUser fakeSTSAssumedRoleUser
+		}{credentials, user}}
+	default:
+		http.Error(w, "unsupported action "+action, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml")
+	_ = xml.NewEncoder(w).Encode(response)
+}
+
+func TestAssumeRoleIsWebIdentity(t *testing.T) {
+	assert.False(t, (&AssumeRole{RoleARN: "role"}).IsWebIdentity())
+	assert.True(t, (&AssumeRole{RoleARN: "role", WebIdentityTokenFile: "/token"}).IsWebIdentity())
+	assert.True(t, (&AssumeRole{RoleARN: "role", WebIdentityToken: "token"}).IsWebIdentity())
+}
+
+func TestAssumeRoleWithWebIdentityToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0o600))
+
+	t.Run("reads the token file", func(t *testing.T) {
+		assumeRole := &AssumeRole{RoleARN: "role", WebIdentityTokenFile: tokenFile}
+		resolved, err := assumeRole.WithWebIdentityToken()
+		require.NoError(t, err)
+		assert.Equal(t, "token", resolved.WebIdentityToken)
+		assert.Empty(t, assumeRole.WebIdentityToken)
+	})
+
+	t.Run("access keys are unchanged", func(t *testing.T) {
+		assumeRole := &AssumeRole{RoleARN: "role"}
+		resolved, err := assumeRole.WithWebIdentityToken()
+		require.NoError(t, err)
+		assert.Same(t, assumeRole, resolved)
+	})
+
+	t.Run("missing token file", func(t *testing.T) {
+		_, err := (&AssumeRole{RoleARN: "role", WebIdentityTokenFile: filepath.Join(t.TempDir(), "missing")}).WithWebIdentityToken()
+		assert.Error(t, err)
+	})
+}
+
+func TestSyncWithAssumedRole(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("file-token"), 0o600))
+
+	tests := []struct {
+		desc           string
+		accessKeyID    string
+		assumeRole     AssumeRole
+		expectedParams url.Values
+	}{
+		{
+			desc:        "access keys with an external ID",
+			accessKeyID: "AKIA-SOURCE",
+			assumeRole: AssumeRole{
+				RoleARN:    "arn:aws:iam::123456789012:role/backup",
+				ExternalID: "external-id",
+				Duration:   30 * time.Minute,
+			},
+			expectedParams: url.Values{
+				"Action":             {"AssumeRole"},
+				"Version":            {"2011-06-15"},
+				"RoleArn":            {"arn:aws:iam::123456789012:role/backup"},
+				"RoleSessionName":    {"backup-tool"},
+				"ExternalId":         {"external-id"},
+				"DurationSeconds":    {"1800"},
+				"SigningAccessKeyId": {"AKIA-SOURCE"},
+			},
+		},
+		{
+			desc: "web identity token file",
+			assumeRole: AssumeRole{
+				RoleARN:              "arn:aws:iam::123456789012:role/backup",
+				SessionName:          "session",
+				WebIdentityTokenFile: tokenFile,
+			},
+			expectedParams: url.Values{
+				"Action":           {"AssumeRoleWithWebIdentity"},
+				"Version":          {"2011-06-15"},
+				"RoleArn":          {"arn:aws:iam::123456789012:role/backup"},
+				"RoleSessionName":  {"session"},
+				"WebIdentityToken": {"file-token"},
+				// Web identity requests are not signed.
+				"SigningAccessKeyId": {""},
+			},
+		},
+		{
+			desc: "web identity token passed from the driver",
+			assumeRole: AssumeRole{
+				RoleARN:          "arn:aws:iam::123456789012:role/backup",
+				WebIdentityToken: "passed-token",
+			},
+			expectedParams: url.Values{
+				"Action":             {"AssumeRoleWithWebIdentity"},
+				"Version":            {"2011-06-15"},
+				"RoleArn":            {"arn:aws:iam::123456789012:role/backup"},
+				"RoleSessionName":    {"backup-tool"},
+				"WebIdentityToken":   {"passed-token"},
+				"SigningAccessKeyId": {""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sts := newFakeSTS(t)
+			fake := newFakeS3(t)
+			// Only the temporary credentials issued by the fake STS are accepted.
+			fake.accessKeyID = "ASIA-ASSUMED-1"
+			fake.putObject("bucket", "prefix/a.txt", []byte("a"))
+
+			assumeRole := tt.assumeRole
+			assumeRole.STSEndpoint = sts.server.URL
+			credentials := fake.credentials().WithAccessKeyID(tt.accessKeyID).WithAssumeRole(&assumeRole)
+
+			destDir := t.TempDir()
+			require.NoError(t, NewLocalRuntime().Sync(th.NewTestContext(), credentials, "s3://bucket/prefix", destDir, time.Time{}, SyncOptions{}))
+
+			contents, err := os.ReadFile(filepath.Join(destDir, "a.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "a", string(contents))
+
+			// The role is assumed once for the whole sync.
+			assert.Equal(t, []url.Values{tt.expectedParams}, sts.takeRequests())
+		})
+	}
+}
+
+func TestSyncWithAssumedRoleFailure(t *testing.T) {
+	sts := newFakeSTS(t)
+	sts.fail = true
+	fake := newFakeS3(t)
+	fake.putObject("bucket", "prefix/a.txt", []byte("a"))
+
+	credentials := fake.credentials().WithAssumeRole(&AssumeRole{RoleARN: "role", STSEndpoint: sts.server.URL})
+	err := NewLocalRuntime().Sync(th.NewTestContext(), credentials, "s3://bucket/prefix", t.TempDir(), time.Time{}, SyncOptions{})
+	assert.Error(t, err)
+}
+
+func TestAssumedRoleCredentialsRefresh(t *testing.T) {
+	tests := []struct {
+		desc             string
+		lifetime         time.Duration
+		expectedRequests int
+	}{
+		{
+			desc:             "valid credentials are reused",
+			lifetime:         time.Hour,
+			expectedRequests: 1,
+		},
+		{
+			desc: "credentials close to expiry are replaced",
+			// Within the expiry window, so every retrieval mints new credentials.
+			lifetime:         assumedCredentialsExpiryWindow / 2,
+			expectedRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sts := newFakeSTS(t)
+			sts.lifetime = tt.lifetime
+
+			credentials := NewCredentials("AKIA-SOURCE", "secret").WithAssumeRole(&AssumeRole{
+				RoleARN:     "role",
+				STSEndpoint: sts.server.URL,
+			})
+			provider := credentials.AWSConfig().Credentials
+
+			var retrieved aws.Credentials
+			for range 3 {
+				var err error
+				retrieved, err = provider.Retrieve(context.Background())
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, fmt.Sprintf("ASIA-ASSUMED-%d", tt.expectedRequests), retrieved.AccessKeyID)
+			assert.Len(t, sts.takeRequests(), tt.expectedRequests)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package s3
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/cleanup"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// ChecksumAlgorithm identifies the digest that a Checksum holds.
+type ChecksumAlgorithm string
+
+const (
+	ChecksumAlgorithmSHA256 ChecksumAlgorithm = "SHA256"
+	ChecksumAlgorithmCRC32C ChecksumAlgorithm = "CRC32C"
+	ChecksumAlgorithmCRC32  ChecksumAlgorithm = "CRC32"
+	ChecksumAlgorithmMD5    ChecksumAlgorithm = "MD5"
+)
+
+// Checksum is the expected digest of an object's contents. Value is encoded the way that S3 reports it:
+// base64 for SHA256, CRC32C and CRC32, and hex (as in an ETag) for MD5.
+type Checksum struct {
+	Algorithm ChecksumAlgorithm `json:"algorithm"`
+	Value     string            `json:"value"`
+	// Computed is set when the object had no usable checksum of its own, so the value was computed from the
+	// downloaded copy. It detects later changes to the copy, but not corruption while downloading it.
+	Computed bool `json:"computed,omitempty"`
+}
+
+// IsZero reports whether the checksum is unset.
+func (c Checksum) IsZero() bool {
+	return c.Value == ""
+}
+
+// applyToPutObject sets the checksum on an upload, so that the bucket rejects the upload if the contents that
+// it receives don't match.
+func (c Checksum) applyToPutObject(input *s3.PutObjectInput) {
+	switch c.Algorithm {
+	case ChecksumAlgorithmSHA256:
+		input.ChecksumSHA256 = aws.String(c.Value)
+	case ChecksumAlgorithmCRC32C:
+		input.ChecksumCRC32C = aws.String(c.Value)
+	case ChecksumAlgorithmCRC32:
+		input.ChecksumCRC32 = aws.String(c.Value)
+	case ChecksumAlgorithmMD5:
+		if digest, err := hex.DecodeString(c.Value); err == nil {
+			input.ContentMD5 = aws.String(base64.StdEncoding.EncodeToString(digest))
+		}
+	}
+}
+
+// objectChecksumHeaders are the GetObject and HeadObject response fields that an object's checksum is derived
+// from.
+type objectChecksumHeaders struct {
+	etag                 *string
+	checksumSHA256       *string
+	checksumCRC32C       *string
+	checksumCRC32        *string
+	checksumType         types.ChecksumType
+	serverSideEncryption types.ServerSideEncryption
+	sseCustomerAlgorithm *string
+}
+
+// checksum returns the digest of the whole object that the headers describe, preferring the object's own
+// SHA256, CRC32C or CRC32 checksum (in that order) over its ETag. The result is zero if the object has no
+// usable checksum.
+func (h objectChecksumHeaders) checksum() Checksum {
+	// A composite checksum is a digest of the checksums of a multipart upload's parts rather than of the
+	// contents, and is suffixed with the part count.
+	if h.checksumType != types.ChecksumTypeComposite {
+		for _, candidate := range []Checksum{
+			{Algorithm: ChecksumAlgorithmSHA256, Value: aws.ToString(h.checksumSHA256)},
+			{Algorithm: ChecksumAlgorithmCRC32C, Value: aws.ToString(h.checksumCRC32C)},
+			{Algorithm: ChecksumAlgorithmCRC32, Value: aws.ToString(h.checksumCRC32)},
+		} {
+			if !candidate.IsZero() && !strings.Contains(candidate.Value, "-") {
+				return candidate
+			}
+		}
+	}
+
+	// The ETag of an object uploaded in a single part is the MD5 of its contents, unless it is encrypted with
+	// KMS or a customer-provided key. The ETag of a multipart upload contains a "-", so it isn't valid hex.
+	if aws.ToString(h.sseCustomerAlgorithm) != "" ||
+		h.serverSideEncryption == types.ServerSideEncryptionAwsKms ||
+		h.serverSideEncryption == types.ServerSideEncryptionAwsKmsDsse {
+		return Checksum{}
+	}
+
+	etag := strings.ToLower(strings.Trim(aws.ToString(h.etag), `"`))
+	if digest, err := hex.DecodeString(etag); err != nil || len(digest) != md5.Size {
+		return Checksum{}
+	}
+
+	return Checksum{Algorithm: ChecksumAlgorithmMD5, Value: etag}
+}
+
+// getObjectChecksum returns the checksum of the object fetched by a (non-ranged) GetObject.
+func getObjectChecksum(out *s3.GetObjectOutput) Checksum {
+	return objectChecksumHeaders{
+		etag:                 out.ETag,
+		checksumSHA256:       out.ChecksumSHA256,
+		checksumCRC32C:       out.ChecksumCRC32C,
+		checksumCRC32:        out.ChecksumCRC32,
+		checksumType:         out.ChecksumType,
+		serverSideEncryption: out.ServerSideEncryption,
+		sseCustomerAlgorithm: out.SSECustomerAlgorithm,
+	}.checksum()
+}
+
+// computeChecksum reads r to the end and returns its digest with the given algorithm.
+func computeChecksum(r io.Reader, algorithm ChecksumAlgorithm) (Checksum, error) {
+	var h hash.Hash
+	switch algorithm {
+	case ChecksumAlgorithmSHA256:
+		h = sha256.New()
+	case ChecksumAlgorithmCRC32C:
+		h = crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case ChecksumAlgorithmCRC32:
+		h = crc32.NewIEEE()
+	case ChecksumAlgorithmMD5:
+		h = md5.New()
+	default:
+		return Checksum{}, trace.BadParameter("unsupported checksum algorithm %q", algorithm)
+	}
+
+	if _, err := io.Copy(h, r); err != nil {
+		return Checksum{}, trace.Wrap(err, "failed to read contents")
+	}
+
+	if algorithm == ChecksumAlgorithmMD5 {
+		return Checksum{Algorithm: algorithm, Value: hex.EncodeToString(h.Sum(nil))}, nil
+	}
+
+	return Checksum{Algorithm: algorithm, Value: base64.StdEncoding.EncodeToString(h.Sum(nil))}, nil
+}
+
+// computeFileChecksum returns the digest of the file at filePath with the given algorithm.
+func computeFileChecksum(filePath string, algorithm ChecksumAlgorithm) (Checksum, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return Checksum{}, trace.Wrap(err, "failed to open %q", filePath)
+	}
+	defer cleanup.To(func(_ *contexts.Context) error { return f.Close() }).
+		WithErrMessage("failed to close %q", filePath).
+		Run()
+
+	checksum, err := computeChecksum(f, algorithm)
+	return checksum, trace.Wrap(err, "failed to compute checksum of %q", filePath)
+}
+
+// verifyFileChecksum checks that the contents of the file at filePath, a copy of the object at key, match the
+// expected checksum.
+func verifyFileChecksum(filePath, key string, expected Checksum) error {
+	actual, err := computeFileChecksum(filePath, expected.Algorithm)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	if actual.Value != expected.Value {
+		return trace.Errorf("checksum mismatch for object %q: expected %s %s, but %q has %s", key, expected.Algorithm, expected.Value, filePath, actual.Value)
+	}
+
+	return nil
+}
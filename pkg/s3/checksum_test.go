@@ -0,0 +1,280 @@
+package s3
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectChecksumHeadersChecksum(t *testing.T) {
+	md5ETag := `"5d41402abc4b2a76b9719d911017c592"`
+
+	tests := []struct {
+		desc     string
+		headers  objectChecksumHeaders
+		expected Checksum
+	}{
+		{
+			desc:     "no checksum",
+			expected: Checksum{},
+		},
+		{
+			desc: "SHA256 preferred",
+			headers: objectChecksumHeaders{
+				etag:           aws.String(md5ETag),
+				checksumSHA256: aws.String("sha"),
+				checksumCRC32C: aws.String("crc32c"),
+				checksumCRC32:  aws.String("crc32"),
+			},
+			expected: Checksum{Algorithm: ChecksumAlgorithmSHA256, Value: "sha"},
+		},
+		{
+			desc:     "CRC32C",
+			headers:  objectChecksumHeaders{checksumCRC32C: aws.String("crc32c"), checksumCRC32: aws.String("crc32")},
+			expected: Checksum{Algorithm: ChecksumAlgorithmCRC32C, Value: "crc32c"},
+		},
+		{
+			desc:     "CRC32",
+			headers:  objectChecksumHeaders{checksumCRC32: aws.String("crc32")},
+			expected: Checksum{Algorithm: ChecksumAlgorithmCRC32, Value: "crc32"},
+		},
+		{
+			desc: "composite checksum falls back to ETag",
+			headers: objectChecksumHeaders{
+				etag:           aws.String(md5ETag),
+				checksumSHA256: aws.String("sha-2"),
+				checksumType:   types.ChecksumTypeComposite,
+			},
+			expected: Checksum{Algorithm: ChecksumAlgorithmMD5, Value: "5d41402abc4b2a76b9719d911017c592"},
+		},
+		{
+			desc:     "single-part ETag",
+			headers:  objectChecksumHeaders{etag: aws.String(md5ETag), serverSideEncryption: types.ServerSideEncryptionAes256},
+			expected: Checksum{Algorithm: ChecksumAlgorithmMD5, Value: "5d41402abc4b2a76b9719d911017c592"},
+		},
+		{
+			desc:    "multipart ETag",
+			headers: objectChecksumHeaders{etag: aws.String(`"5d41402abc4b2a76b9719d911017c592-3"`)},
+		},
+		{
+			desc:    "KMS encrypted",
+			headers: objectChecksumHeaders{etag: aws.String(md5ETag), serverSideEncryption: types.ServerSideEncryptionAwsKms},
+		},
+		{
+			desc:    "customer key encrypted",
+			headers: objectChecksumHeaders{etag: aws.String(md5ETag), sseCustomerAlgorithm: aws.String("AES256")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.headers.checksum())
+		})
+	}
+}
+
+func TestComputeChecksum(t *testing.T) {
+	tests := []struct {
+		algorithm ChecksumAlgorithm
+		expected  string
+	}{
+		{algorithm: ChecksumAlgorithmSHA256, expected: "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="},
+		{algorithm: ChecksumAlgorithmCRC32C, expected: "mnG7TA=="},
+		{algorithm: ChecksumAlgorithmCRC32, expected: "NhCmhg=="},
+		{algorithm: ChecksumAlgorithmMD5, expected: "5d41402abc4b2a76b9719d911017c592"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.algorithm), func(t *testing.T) {
+			checksum, err := computeChecksum(strings.NewReader("hello"), tt.algorithm)
+			require.NoError(t, err)
+			assert.Equal(t, Checksum{Algorithm: tt.algorithm, Value: tt.expected}, checksum)
+		})
+	}
+
+	_, err := computeChecksum(strings.NewReader("hello"), "SHA1")
+	assert.Error(t, err)
+}
+
+// downloadWithManifest syncs the fake's prefix to a new directory, returning the directory and manifest.
+func downloadWithManifest(t *testing.T, fake *fakeS3, opts SyncOptions) (string, Manifest, error) {
+	destDir := t.TempDir()
+	opts.ManifestPath = filepath.Join(t.TempDir(), "manifest.json")
+
+	err := NewLocalRuntime().Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts)
+	if err != nil {
+		return destDir, Manifest{}, err
+	}
+
+	manifest, err := readManifest(opts.ManifestPath)
+	require.NoError(t, err)
+	return destDir, manifest, nil
+}
+
+func TestSyncDownloadVerifiesChecksums(t *testing.T) {
+	fake := newFakeS3(t)
+
+	// Uploaded with a checksum, which the bucket stores.
+	sha256Checksum, err := computeChecksum(strings.NewReader("checksummed"), ChecksumAlgorithmSHA256)
+	require.NoError(t, err)
+	fake.setObject("bucket", "prefix/checksummed.txt", fakeS3Object{
+		contents:  []byte("checksummed"),
+		checksums: map[string]string{"x-amz-checksum-sha256": sha256Checksum.Value},
+	})
+	// Only verifiable by its ETag.
+	fake.putObject("bucket", "prefix/etag.txt", []byte("hello"))
+	// Uploaded in parts without a checksum, so it can't be verified at all.
+	fake.setObject("bucket", "prefix/unverifiable.txt", fakeS3Object{contents: []byte("parts"), etag: `"0123456789abcdef0123456789abcdef-2"`})
+	// Large enough to be downloaded in parts.
+	large := randomContents(minPartSize)
+	fake.putObject("bucket", "prefix/large.bin", large)
+	fake.takeRequests()
+
+	destDir, manifest, err := downloadWithManifest(t, fake, SyncOptions{Multipart: testMultipart})
+	require.NoError(t, err)
+
+	assert.Equal(t, sha256Checksum, manifest.Objects["checksummed.txt"].Checksum)
+	assert.Equal(t, Checksum{Algorithm: ChecksumAlgorithmMD5, Value: "5d41402abc4b2a76b9719d911017c592"}, manifest.Objects["etag.txt"].Checksum)
+	computed, err := computeChecksum(strings.NewReader("parts"), ChecksumAlgorithmSHA256)
+	require.NoError(t, err)
+	computed.Computed = true
+	assert.Equal(t, computed, manifest.Objects["unverifiable.txt"].Checksum)
+	assert.Equal(t, ChecksumAlgorithmMD5, manifest.Objects["large.bin"].Checksum.Algorithm)
+
+	downloaded, err := os.ReadFile(filepath.Join(destDir, "large.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, large, downloaded)
+}
+
+func TestSyncDownloadFailsOnChecksumMismatch(t *testing.T) {
+	for _, multipart := range []bool{false, true} {
+		t.Run(map[bool]string{false: "whole object", true: "in parts"}[multipart], func(t *testing.T) {
+			fake := newFakeS3(t)
+			fake.putObject("bucket", "prefix/intact.txt", []byte("intact"))
+			fake.putObject("bucket", "prefix/corrupt.bin", randomContents(minPartSize))
+			fake.corruptObject("bucket", "prefix/corrupt.bin")
+
+			opts := SyncOptions{}
+			if multipart {
+				opts.Multipart = testMultipart
+			}
+
+			destDir, _, err := downloadWithManifest(t, fake, opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `checksum mismatch for object "prefix/corrupt.bin"`)
+
+			// The corrupt copy is removed, so that it isn't skipped as up to date by the next download.
+			assert.NoFileExists(t, filepath.Join(destDir, "corrupt.bin"))
+		})
+	}
+}
+
+func TestSyncDownloadRehash(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.putObject("bucket", "prefix/a.txt", []byte("hello"))
+	fake.putObject("bucket", "prefix/b.txt", []byte("world"))
+
+	destDir := t.TempDir()
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	opts := SyncOptions{ManifestPath: manifestPath}
+	rt := NewLocalRuntime()
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+	fake.takeRequests()
+
+	// Corrupt a local copy without changing its size or modification time, as bit rot would.
+	corruptPath := filepath.Join(destDir, "a.txt")
+	info, err := os.Stat(corruptPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(corruptPath, []byte("jello"), 0o644))
+	require.NoError(t, os.Chtimes(corruptPath, info.ModTime(), info.ModTime()))
+
+	// Without rehashing, the copy is skipped as up to date, and its record is carried over.
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+	assert.Empty(t, fake.takeRequests())
+	manifest, err := readManifest(manifestPath)
+	require.NoError(t, err)
+	assert.Len(t, manifest.Objects, 2)
+
+	// Rehashing finds the corrupt copy, and downloads it again.
+	opts.Rehash = true
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+	assert.Equal(t, []string{"GET prefix/a.txt"}, fake.takeRequests())
+
+	contents, err := os.ReadFile(corruptPath)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(contents))
+}
+
+func TestSyncDownloadRehashWithoutRecord(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.putObject("bucket", "prefix/a.txt", []byte("hello"))
+
+	destDir := t.TempDir()
+	rt := NewLocalRuntime()
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, SyncOptions{}))
+	fake.takeRequests()
+
+	// Without a manifest, the copy is revalidated against the object's own checksum.
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, SyncOptions{Rehash: true}))
+	assert.Equal(t, []string{"HEAD prefix/a.txt"}, fake.takeRequests())
+}
+
+func TestSyncUploadVerifiesRecordedChecksums(t *testing.T) {
+	for _, multipart := range []bool{false, true} {
+		t.Run(map[bool]string{false: "whole object", true: "in parts"}[multipart], func(t *testing.T) {
+			fake := newFakeS3(t)
+			contents := randomContents(minPartSize)
+			fake.putObject("bucket", "prefix/data.bin", contents)
+
+			opts := SyncOptions{}
+			if multipart {
+				opts.Multipart = testMultipart
+			}
+
+			srcDir := t.TempDir()
+			opts.ManifestPath = filepath.Join(t.TempDir(), "manifest.json")
+			rt := NewLocalRuntime()
+			require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", srcDir, time.Time{}, opts))
+			fake.takeRequests()
+
+			// An intact copy is restored, with the recorded checksum sent for the bucket to verify.
+			require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://restored/prefix", time.Time{}, opts))
+			restored, ok := fake.getObject("restored", "prefix/data.bin")
+			require.True(t, ok)
+			assert.Equal(t, contents, restored)
+
+			// A copy that has changed since it was downloaded is not.
+			require.NoError(t, os.WriteFile(filepath.Join(srcDir, "data.bin"), randomContents(minPartSize+1), 0o644))
+			fake.takeRequests()
+			err := rt.Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://other/prefix", time.Time{}, opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `checksum mismatch for object "prefix/data.bin"`)
+			assert.Empty(t, fake.takeRequests())
+		})
+	}
+}
+
+func TestSyncDownloadReplacesChangedObject(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.setObject("bucket", "prefix/a.txt", fakeS3Object{contents: []byte("hello"), lastModified: time.Now().Add(-time.Hour).Truncate(time.Second)})
+
+	destDir := t.TempDir()
+	rt := NewLocalRuntime()
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, SyncOptions{}))
+
+	// The object is replaced with one of the same size, so only its modification time shows the change.
+	fake.putObject("bucket", "prefix/a.txt", []byte("world"))
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, SyncOptions{}))
+
+	contents, err := os.ReadFile(filepath.Join(destDir, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(contents))
+}
@@ -14,19 +14,25 @@ type CredentialsInterface interface {
 	GetRegion() string
 	GetEndpoint() string
 	GetS3ForcePathStyle() bool
-	// AWSConfig returns a v2 SDK config carrying the static credentials and region. The endpoint and
-	// path-style settings are not part of aws.Config in the v2 SDK; they are applied as s3.Options when
-	// the client is constructed (see LocalRuntime.Sync).
+	// GetAssumeRole returns the role that the credentials are exchanged for, or nil when they are used
+	// directly.
+	GetAssumeRole() *AssumeRole
+	// AWSConfig returns a v2 SDK config carrying the credentials and region. The endpoint and path-style
+	// settings are not part of aws.Config in the v2 SDK; they are applied as s3.Options when the client is
+	// constructed (see LocalRuntime.newClient).
 	AWSConfig() aws.Config
 }
 
+// Credentials are static access keys, optionally exchanged for temporary credentials for a role. The keys
+// are not needed when the role is assumed with a web identity token.
 type Credentials struct {
-	AccessKeyID      string `yaml:"accessKeyId" jsonschema:"required"`
-	SecretAccessKey  string `yaml:"secretAccessKey" jsonschema:"required"`
-	SessionToken     string `yaml:"sessionToken,omitempty"`
-	Endpoint         string `yaml:"endpoint,omitempty"`
-	Region           string `yaml:"region,omitempty"`
-	S3ForcePathStyle bool   `yaml:"s3ForcePathStyle,omitempty"`
+	AccessKeyID      string      `yaml:"accessKeyId,omitempty"`
+	SecretAccessKey  string      `yaml:"secretAccessKey,omitempty"`
+	SessionToken     string      `yaml:"sessionToken,omitempty"`
+	Endpoint         string      `yaml:"endpoint,omitempty"`
+	Region           string      `yaml:"region,omitempty"`
+	S3ForcePathStyle bool        `yaml:"s3ForcePathStyle,omitempty"`
+	AssumeRole       *AssumeRole `yaml:"assumeRole,omitempty"`
 }
 
 func NewCredentials(accessKeyID, secretAccessKey string) *Credentials {
@@ -36,15 +42,28 @@ func NewCredentials(accessKeyID, secretAccessKey string) *Credentials {
 	}
 }
 
-// Create new credentials from the standard AWS environment variables.
+// Create new credentials from the standard AWS environment variables. When AWS_ROLE_ARN is set (as it is for
+// pods using IAM roles for service accounts), the role is assumed with the web identity token in
+// AWS_WEB_IDENTITY_TOKEN_FILE if set, or otherwise with the access keys.
 func NewCredentialsFromEnv() *Credentials {
 	accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
 	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
-	return NewCredentials(accessKeyID, secretAccessKey).
+	credentials := NewCredentials(accessKeyID, secretAccessKey).
 		WithSessionToken(os.Getenv("AWS_SESSION_TOKEN")).
 		WithEndpoint(os.Getenv("AWS_ENDPOINT")).
 		WithRegion(os.Getenv("AWS_REGION")).
 		WithS3ForcePathStyle(os.Getenv("AWS_S3_FORCE_PATH_STYLE") == "true")
+
+	if roleARN := os.Getenv("AWS_ROLE_ARN"); roleARN != "" {
+		credentials.WithAssumeRole(&AssumeRole{
+			RoleARN:              roleARN,
+			SessionName:          os.Getenv("AWS_ROLE_SESSION_NAME"),
+			WebIdentityTokenFile: os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"),
+			STSEndpoint:          os.Getenv("AWS_ENDPOINT_URL_STS"),
+		})
+	}
+
+	return credentials
 }
 
 func (c *Credentials) WithAccessKeyID(id string) *Credentials {
@@ -77,6 +96,11 @@ func (c *Credentials) WithS3ForcePathStyle(forcePathStyle bool) *Credentials {
 	return c
 }
 
+func (c *Credentials) WithAssumeRole(assumeRole *AssumeRole) *Credentials {
+	c.AssumeRole = assumeRole
+	return c
+}
+
 func (c *Credentials) GetAccessKeyID() string {
 	return c.AccessKeyID
 }
@@ -101,6 +125,10 @@ func (c *Credentials) GetS3ForcePathStyle() bool {
 	return c.S3ForcePathStyle
 }
 
+func (c *Credentials) GetAssumeRole() *AssumeRole {
+	return c.AssumeRole
+}
+
 func (c *Credentials) AWSConfig() aws.Config {
 	region := c.Region
 	if region == "" && c.Endpoint != "" {
@@ -109,7 +137,7 @@ func (c *Credentials) AWSConfig() aws.Config {
 		region = "us-east-1"
 	}
 
-	return aws.Config{
+	config := aws.Config{
 		Region: region,
 		Credentials: credentials.NewStaticCredentialsProvider(
 			c.AccessKeyID,
@@ -117,4 +145,10 @@ func (c *Credentials) AWSConfig() aws.Config {
 			c.SessionToken,
 		),
 	}
+
+	if c.AssumeRole != nil {
+		config.Credentials = c.AssumeRole.credentialsProvider(config)
+	}
+
+	return config
 }
@@ -46,6 +46,22 @@ func TestNewCredentialsFromEnv(t *testing.T) {
 	assert.Equal(t, credentials.Endpoint, endpoint)
 	assert.Equal(t, credentials.Region, region)
 	assert.Equal(t, credentials.S3ForcePathStyle, s3ForcePathStyle)
+	assert.Nil(t, credentials.AssumeRole)
+}
+
+func TestNewCredentialsFromEnvAssumeRole(t *testing.T) {
+	t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/backup")
+	t.Setenv("AWS_ROLE_SESSION_NAME", "session")
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/token")
+	t.Setenv("AWS_ENDPOINT_URL_STS", "https://sts.example.com")
+
+	credentials := NewCredentialsFromEnv()
+	assert.Equal(t, &AssumeRole{
+		RoleARN:              "arn:aws:iam::123456789012:role/backup",
+		SessionName:          "session",
+		WebIdentityTokenFile: "/var/run/secrets/token",
+		STSEndpoint:          "https://sts.example.com",
+	}, credentials.AssumeRole)
 }
 
 func TestWithAccessKeyID(t *testing.T) {
@@ -84,6 +100,12 @@ func TestWithS3ForcePathStyle(t *testing.T) {
 	assert.Equal(t, credentials.S3ForcePathStyle, s3ForcePathStyle)
 }
 
+func TestWithAssumeRole(t *testing.T) {
+	assumeRole := &AssumeRole{RoleARN: "role"}
+	credentials := NewCredentials("", "").WithAssumeRole(assumeRole)
+	assert.Equal(t, credentials.AssumeRole, assumeRole)
+}
+
 func TestGetAccessKeyID(t *testing.T) {
 	accessKeyID := "accessKeyID"
 	credentials := NewCredentials(accessKeyID, "")
@@ -120,6 +142,12 @@ func TestGetS3ForcePathStyle(t *testing.T) {
 	assert.Equal(t, credentials.GetS3ForcePathStyle(), s3ForcePathStyle)
 }
 
+func TestGetAssumeRole(t *testing.T) {
+	assumeRole := &AssumeRole{RoleARN: "role"}
+	credentials := NewCredentials("", "").WithAssumeRole(assumeRole)
+	assert.Equal(t, credentials.GetAssumeRole(), assumeRole)
+}
+
 func TestAWSConfig(t *testing.T) {
 	accesKeyId := "accessKeyId"
 	secretAccessKey := "secretAccessKey"
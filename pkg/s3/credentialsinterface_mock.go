@@ -110,6 +110,53 @@ func (_c *MockCredentialsInterface_GetAccessKeyID_Call) RunAndReturn(run func()
 	return _c
 }
 
+// GetAssumeRole provides a mock function with no fields
+func (_m *MockCredentialsInterface) GetAssumeRole() *AssumeRole {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssumeRole")
+	}
+
+	var r0 *AssumeRole
+	if rf, ok := ret.Get(0).(func() *AssumeRole); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*AssumeRole)
+		}
+	}
+
+	return r0
+}
+
+// MockCredentialsInterface_GetAssumeRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssumeRole'
+type MockCredentialsInterface_GetAssumeRole_Call struct {
+	*mock.Call
+}
+
+// GetAssumeRole is a helper method to define mock.On call
+func (_e *MockCredentialsInterface_Expecter) GetAssumeRole() *MockCredentialsInterface_GetAssumeRole_Call {
+	return &MockCredentialsInterface_GetAssumeRole_Call{Call: _e.mock.On("GetAssumeRole")}
+}
+
+func (_c *MockCredentialsInterface_GetAssumeRole_Call) Run(run func()) *MockCredentialsInterface_GetAssumeRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCredentialsInterface_GetAssumeRole_Call) Return(_a0 *AssumeRole) *MockCredentialsInterface_GetAssumeRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCredentialsInterface_GetAssumeRole_Call) RunAndReturn(run func() *AssumeRole) *MockCredentialsInterface_GetAssumeRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEndpoint provides a mock function with no fields
 func (_m *MockCredentialsInterface) GetEndpoint() string {
 	ret := _m.Called()
@@ -0,0 +1,675 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeS3 is a minimal, in-memory S3-compatible server for exercising the runtime through the real SDK client.
+// It supports path-style requests for the operations that a latest-state sync uses, including multipart
+// uploads, server-side copies and ranged GETs. Signatures are not verified, but the SHA256, CRC32C, CRC32 and
+// MD5 checksums sent with a single-part upload are, and are returned by GETs and HEADs that request
+// checksums, as S3 does.
+// Metadata headers and tags sent with an upload are stored and returned with the object.
+type fakeS3 struct {
+	server *httptest.Server
+
+	lock    sync.Mutex
+	objects map[string]fakeS3Object // keyed by "bucket/key"
+	uploads map[string]*fakeS3Upload
+	// requests records each object request as "METHOD key [range|partNumber]", for assertions.
+	requests []string
+
+	// failGet, when set, is called for each object GET. Returning true cuts the response body off part way
+	// through, as a connection reset would.
+	failGet func(key, byteRange string) bool
+	// failUploadPart, when set, is called for each part upload. Returning true rejects the part.
+	failUploadPart func(key string, partNumber int32) bool
+	// accessKeyID, when set, is the only access key ID that requests may be signed with. Requests signed with
+	// another are denied.
+	accessKeyID string
+	// objectLockEnabled reports Object Lock as enabled on every bucket. The fake doesn't enforce locks.
+	objectLockEnabled bool
+}
+
+type fakeS3Object struct {
+	contents     []byte
+	etag         string
+	lastModified time.Time
+	checksums    map[string]string // keyed by header name, e.g. "x-amz-checksum-sha256"
+	headers      http.Header       // metadata headers, e.g. Cache-Control and x-amz-meta-*
+	tags         url.Values
+	partSizes    []int64 // the size of each part, for an object uploaded in parts
+}
+
+type fakeS3Upload struct {
+	parts   map[int32][]byte
+	headers http.Header
+	tags    url.Values
+}
+
+// fakeS3MetadataHeaders are the headers that the fake stores with an object, in addition to x-amz-meta-*.
+var fakeS3MetadataHeaders = []string{
+	"Content-Type",
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Expires",
+	"X-Amz-Website-Redirect-Location",
+	"X-Amz-Storage-Class",
+	"X-Amz-Object-Lock-Mode",
+	"X-Amz-Object-Lock-Retain-Until-Date",
+	"X-Amz-Object-Lock-Legal-Hold",
+}
+
+// fakeS3ObjectMetadata returns the metadata headers and tags sent with an upload.
+func fakeS3ObjectMetadata(r *http.Request) (http.Header, url.Values) {
+	headers := make(http.Header)
+	for name, values := range r.Header {
+		if slices.Contains(fakeS3MetadataHeaders, name) || strings.HasPrefix(name, "X-Amz-Meta-") {
+			headers[name] = values
+		}
+	}
+
+	tags, _ := url.ParseQuery(r.Header.Get("X-Amz-Tagging"))
+	return headers, tags
+}
+
+// fakeS3ChecksumHeaders maps the checksum headers that the fake verifies and stores to their algorithms.
+var fakeS3ChecksumHeaders = map[string]ChecksumAlgorithm{
+	"x-amz-checksum-sha256": ChecksumAlgorithmSHA256,
+	"x-amz-checksum-crc32c": ChecksumAlgorithmCRC32C,
+	"x-amz-checksum-crc32":  ChecksumAlgorithmCRC32,
+}
+
+// newFakeS3 starts a fake S3 server, which is stopped when the test completes.
+func newFakeS3(t *testing.T) *fakeS3 {
+	fake := &fakeS3{
+		objects: make(map[string]fakeS3Object),
+		uploads: make(map[string]*fakeS3Upload),
+	}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.handle))
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+// credentials returns credentials that direct the runtime's client to the fake.
+func (f *fakeS3) credentials() *Credentials {
+	return NewCredentials("id", "secret").WithEndpoint(f.server.URL).WithS3ForcePathStyle(true)
+}
+
+func (f *fakeS3) putObject(bucket, key string, contents []byte) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sum := md5.Sum(contents)
+	f.objects[bucket+"/"+key] = fakeS3Object{
+		contents:     contents,
+		etag:         `"` + hex.EncodeToString(sum[:]) + `"`,
+		lastModified: time.Now().Truncate(time.Second),
+	}
+}
+
+// setObject stores an object as is, for tests that need control over its ETag or checksums.
+func (f *fakeS3) setObject(bucket, key string, obj fakeS3Object) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if obj.lastModified.IsZero() {
+		obj.lastModified = time.Now().Truncate(time.Second)
+	}
+	f.objects[bucket+"/"+key] = obj
+}
+
+// corruptObject flips a bit of an object's contents without changing its ETag or checksums, as bit rot in the
+// bucket or corruption in transit would.
+func (f *fakeS3) corruptObject(bucket, key string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	obj := f.objects[bucket+"/"+key]
+	obj.contents = bytes.Clone(obj.contents)
+	obj.contents[0] ^= 1
+	f.objects[bucket+"/"+key] = obj
+}
+
+func (f *fakeS3) getObject(bucket, key string) ([]byte, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	obj, ok := f.objects[bucket+"/"+key]
+	return obj.contents, ok
+}
+
+// getETag returns the ETag of an object.
+func (f *fakeS3) getETag(bucket, key string) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.objects[bucket+"/"+key].etag
+}
+
+// getHeaders returns the metadata headers stored with an object.
+func (f *fakeS3) getHeaders(bucket, key string) http.Header {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.objects[bucket+"/"+key].headers
+}
+
+// takeRequests returns the requests recorded so far, and resets the record.
+func (f *fakeS3) takeRequests() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	requests := f.requests
+	f.requests = nil
+	return requests
+}
+
+func (f *fakeS3) record(request string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.requests = append(f.requests, request)
+}
+
+// signingAccessKeyID returns the access key ID that a SigV4-signed request was signed with.
+func signingAccessKeyID(r *http.Request) string {
+	_, credential, _ := strings.Cut(r.Header.Get("Authorization"), "Credential=")
+	accessKeyID, _, _ := strings.Cut(credential, "/")
+	return accessKeyID
+}
+
+func (f *fakeS3) handle(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+
+	if f.accessKeyID != "" && signingAccessKeyID(r) != f.accessKeyID {
+		writeFakeS3Error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	switch {
+	case key == "" && r.Method == http.MethodGet && query.Has("object-lock"):
+		f.serveObjectLockConfiguration(w)
+	case key == "" && r.Method == http.MethodGet:
+		f.listObjects(w, bucket, query.Get("prefix"))
+	case r.Method == http.MethodGet && query.Has("tagging"):
+		f.record("TAGGING " + key)
+		f.serveTags(w, bucket, key)
+	case r.Method == http.MethodGet:
+		f.record(strings.TrimSpace(fmt.Sprintf("GET %s %s", key, r.Header.Get("Range"))))
+		f.serveObject(w, r, bucket, key)
+	case r.Method == http.MethodHead:
+		f.record("HEAD " + key)
+		f.serveObject(w, r, bucket, key)
+	case r.Method == http.MethodPut && (query.Has("retention") || query.Has("legal-hold")):
+		f.putObjectLock(w, r, bucket, key)
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		f.copyObject(w, r, bucket, key, query.Get("uploadId"), query.Get("partNumber"))
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		f.uploadPart(w, r, key, query.Get("uploadId"), query.Get("partNumber"))
+	case r.Method == http.MethodPut:
+		f.record("PUT " + key)
+		contents, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeFakeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		checksums, ok := verifyFakeS3Checksums(r, contents)
+		if !ok {
+			writeFakeS3Error(w, http.StatusBadRequest, "BadDigest")
+			return
+		}
+		headers, tags := fakeS3ObjectMetadata(r)
+		sum := md5.Sum(contents)
+		f.setObject(bucket, key, fakeS3Object{
+			contents:  contents,
+			etag:      `"` + hex.EncodeToString(sum[:]) + `"`,
+			checksums: checksums,
+			headers:   headers,
+			tags:      tags,
+		})
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.record("CREATE " + key)
+		uploadID := uuid.NewString()
+		headers, tags := fakeS3ObjectMetadata(r)
+		f.lock.Lock()
+		f.uploads[uploadID] = &fakeS3Upload{parts: make(map[int32][]byte), headers: headers, tags: tags}
+		f.lock.Unlock()
+		writeFakeS3XML(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			Bucket   string
+			Key      string
+			UploadId string
+		}{Bucket: bucket, Key: key, UploadId: uploadID})
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		f.completeUpload(w, r, bucket, key, query.Get("uploadId"))
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		f.record("ABORT " + key)
+		f.lock.Lock()
+		delete(f.uploads, query.Get("uploadId"))
+		f.lock.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		f.record("DELETE " + key)
+		f.lock.Lock()
+		delete(f.objects, bucket+"/"+key)
+		f.lock.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3) listObjects(w http.ResponseWriter, bucket, prefix string) {
+	type content struct {
+		Key          string
+		LastModified string
+		ETag         string
+		Size         int64
+	}
+
+	f.lock.Lock()
+	var contents []content
+	for name, obj := range f.objects {
+		objectBucket, key, _ := strings.Cut(name, "/")
+		if objectBucket != bucket || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		contents = append(contents, content{
+			Key:          key,
+			LastModified: obj.lastModified.UTC().Format(time.RFC3339),
+			ETag:         obj.etag,
+			Size:         int64(len(obj.contents)),
+		})
+	}
+	f.lock.Unlock()
+
+	slices.SortFunc(contents, func(a, b content) int { return strings.Compare(a.Key, b.Key) })
+	writeFakeS3XML(w, struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Name        string
+		Prefix      string
+		KeyCount    int
+		IsTruncated bool
+		Contents    []content
+	}{Name: bucket, Prefix: prefix, KeyCount: len(contents), Contents: contents})
+}
+
+func (f *fakeS3) serveObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	f.lock.Lock()
+	obj, ok := f.objects[bucket+"/"+key]
+	f.lock.Unlock()
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != obj.etag {
+		writeFakeS3Error(w, http.StatusPreconditionFailed, "PreconditionFailed")
+		return
+	}
+
+	body := obj.contents
+	status := http.StatusOK
+	byteRange := r.Header.Get("Range")
+	// As with S3, requesting a part of an object uploaded in parts returns that part's byte range.
+	if rawPartNumber := r.URL.Query().Get("partNumber"); rawPartNumber != "" && len(obj.partSizes) > 0 {
+		partNumber, err := strconv.Atoi(rawPartNumber)
+		if err != nil || partNumber < 1 || partNumber > len(obj.partSizes) {
+			writeFakeS3Error(w, http.StatusRequestedRangeNotSatisfiable, "InvalidPartNumber")
+			return
+		}
+
+		var start int64
+		for _, partSize := range obj.partSizes[:partNumber-1] {
+			start += partSize
+		}
+		byteRange = fmt.Sprintf("bytes=%d-%d", start, start+obj.partSizes[partNumber-1]-1)
+		w.Header().Set("X-Amz-Mp-Parts-Count", strconv.Itoa(len(obj.partSizes)))
+	}
+	if byteRange != "" {
+		var start, end int64
+		if _, err := fmt.Sscanf(byteRange, "bytes=%d-%d", &start, &end); err != nil || end >= int64(len(body)) || start > end {
+			writeFakeS3Error(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange")
+			return
+		}
+
+		body = body[start : end+1]
+		status = http.StatusPartialContent
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(obj.contents)))
+	}
+
+	// As with S3, the checksum of the whole object is only returned when the whole object is requested.
+	if byteRange == "" && r.Header.Get("x-amz-checksum-mode") == "ENABLED" {
+		for header, value := range obj.checksums {
+			w.Header().Set(header, value)
+		}
+	}
+
+	for name, values := range obj.headers {
+		w.Header()[name] = values
+	}
+	if len(obj.tags) > 0 {
+		w.Header().Set("X-Amz-Tagging-Count", strconv.Itoa(len(obj.tags)))
+	}
+
+	w.Header().Set("ETag", obj.etag)
+	w.Header().Set("Last-Modified", obj.lastModified.UTC().Format(http.TimeFormat))
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if f.failGet != nil && f.failGet(key, byteRange) {
+		_, _ = w.Write(body[:len(body)/2])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler) // drops the connection
+	}
+
+	_, _ = w.Write(body)
+}
+
+func (f *fakeS3) serveTags(w http.ResponseWriter, bucket, key string) {
+	f.lock.Lock()
+	obj, ok := f.objects[bucket+"/"+key]
+	f.lock.Unlock()
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	type tag struct {
+		Key   string
+		Value string
+	}
+
+	var tagSet []tag
+	for key := range obj.tags {
+		tagSet = append(tagSet, tag{Key: key, Value: obj.tags.Get(key)})
+	}
+
+	writeFakeS3XML(w, struct {
+		XMLName xml.Name `xml:"Tagging"`
+		TagSet  []tag    `xml:"TagSet>Tag"`
+	}{TagSet: tagSet})
+}
+
+func (f *fakeS3) serveObjectLockConfiguration(w http.ResponseWriter) {
+	if !f.objectLockEnabled {
+		writeFakeS3Error(w, http.StatusNotFound, "ObjectLockConfigurationNotFoundError")
+		return
+	}
+
+	writeFakeS3XML(w, struct {
+		XMLName           xml.Name `xml:"ObjectLockConfiguration"`
+		ObjectLockEnabled string
+	}{ObjectLockEnabled: "Enabled"})
+}
+
+// putObjectLock stores the retention or legal hold of an existing object, as the headers that an upload would
+// set it with.
+func (f *fakeS3) putObjectLock(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	var request struct {
+		Mode            string
+		RetainUntilDate string
+		Status          string
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeFakeS3Error(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	obj, ok := f.objects[bucket+"/"+key]
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	headers := obj.headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	if r.URL.Query().Has("retention") {
+		f.requests = append(f.requests, "RETENTION "+key)
+		headers.Set("X-Amz-Object-Lock-Mode", request.Mode)
+		headers.Set("X-Amz-Object-Lock-Retain-Until-Date", request.RetainUntilDate)
+	} else {
+		f.requests = append(f.requests, "LEGALHOLD "+key)
+		headers.Set("X-Amz-Object-Lock-Legal-Hold", request.Status)
+	}
+	obj.headers = headers
+	f.objects[bucket+"/"+key] = obj
+}
+
+func (f *fakeS3) uploadPart(w http.ResponseWriter, r *http.Request, key, uploadID, rawPartNumber string) {
+	partNumber, err := strconv.ParseInt(rawPartNumber, 10, 32)
+	if err != nil {
+		writeFakeS3Error(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+	f.record(fmt.Sprintf("PART %s %d", key, partNumber))
+
+	contents, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeFakeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+
+	if f.failUploadPart != nil && f.failUploadPart(key, int32(partNumber)) {
+		writeFakeS3Error(w, http.StatusBadRequest, "InvalidPart")
+		return
+	}
+
+	f.lock.Lock()
+	upload, ok := f.uploads[uploadID]
+	if ok {
+		upload.parts[int32(partNumber)] = contents
+	}
+	f.lock.Unlock()
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+
+	sum := md5.Sum(contents)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+}
+
+func (f *fakeS3) completeUpload(w http.ResponseWriter, r *http.Request, bucket, key, uploadID string) {
+	f.record("COMPLETE " + key)
+
+	var request struct {
+		Parts []struct {
+			PartNumber int32
+		} `xml:"Part"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeFakeS3Error(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+
+	f.lock.Lock()
+	upload, ok := f.uploads[uploadID]
+	delete(f.uploads, uploadID)
+	f.lock.Unlock()
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+
+	// The ETag of a multipart upload is the MD5 of its parts' MD5s, suffixed with the part count.
+	var contents, partSums []byte
+	var partSizes []int64
+	for i, requestPart := range request.Parts {
+		partContents, ok := upload.parts[requestPart.PartNumber]
+		if !ok || requestPart.PartNumber != int32(i+1) {
+			writeFakeS3Error(w, http.StatusBadRequest, "InvalidPartOrder")
+			return
+		}
+		contents = append(contents, partContents...)
+		partSizes = append(partSizes, int64(len(partContents)))
+		partSum := md5.Sum(partContents)
+		partSums = append(partSums, partSum[:]...)
+	}
+	sum := md5.Sum(partSums)
+	f.setObject(bucket, key, fakeS3Object{
+		contents:  contents,
+		etag:      fmt.Sprintf(`"%s-%d"`, hex.EncodeToString(sum[:]), len(request.Parts)),
+		headers:   upload.headers,
+		tags:      upload.tags,
+		partSizes: partSizes,
+	})
+
+	writeFakeS3XML(w, struct {
+		XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		Bucket  string
+		Key     string
+	}{Bucket: bucket, Key: key})
+}
+
+// copyObject copies an object server-side, either whole (CopyObject) or a byte range of it as a part of a
+// multipart upload (UploadPartCopy). Version IDs in the copy source are ignored, as the fake doesn't keep versions.
+func (f *fakeS3) copyObject(w http.ResponseWriter, r *http.Request, bucket, key, uploadID, rawPartNumber string) {
+	copySource, _, _ := strings.Cut(r.Header.Get("X-Amz-Copy-Source"), "?")
+	copySource, err := url.PathUnescape(strings.TrimPrefix(copySource, "/"))
+	if err != nil {
+		writeFakeS3Error(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+
+	f.lock.Lock()
+	src, ok := f.objects[copySource]
+	f.lock.Unlock()
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	if uploadID == "" {
+		f.record("COPY " + key)
+		// As with S3, a copy keeps the source's contents, ETag and metadata, but not its object lock.
+		headers, _ := fakeS3ObjectMetadata(r)
+		obj := src
+		obj.lastModified = time.Time{}
+		obj.headers = src.headers.Clone()
+		for _, name := range []string{"X-Amz-Object-Lock-Mode", "X-Amz-Object-Lock-Retain-Until-Date", "X-Amz-Object-Lock-Legal-Hold"} {
+			if obj.headers != nil {
+				obj.headers.Del(name)
+			}
+			if value := headers.Get(name); value != "" {
+				if obj.headers == nil {
+					obj.headers = make(http.Header)
+				}
+				obj.headers.Set(name, value)
+			}
+		}
+		f.setObject(bucket, key, obj)
+		writeFakeS3XML(w, struct {
+			XMLName xml.Name `xml:"CopyObjectResult"`
+			ETag    string
+		}{ETag: src.etag})
+		return
+	}
+
+	partNumber, err := strconv.ParseInt(rawPartNumber, 10, 32)
+	if err != nil {
+		writeFakeS3Error(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+	f.record(fmt.Sprintf("COPYPART %s %d", key, partNumber))
+
+	contents := src.contents
+	if byteRange := r.Header.Get("X-Amz-Copy-Source-Range"); byteRange != "" {
+		var start, end int64
+		if _, err := fmt.Sscanf(byteRange, "bytes=%d-%d", &start, &end); err != nil || end >= int64(len(contents)) || start > end {
+			writeFakeS3Error(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		contents = contents[start : end+1]
+	}
+
+	f.lock.Lock()
+	upload, ok := f.uploads[uploadID]
+	if ok {
+		upload.parts[int32(partNumber)] = contents
+	}
+	f.lock.Unlock()
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+
+	sum := md5.Sum(contents)
+	writeFakeS3XML(w, struct {
+		XMLName xml.Name `xml:"CopyPartResult"`
+		ETag    string
+	}{ETag: `"` + hex.EncodeToString(sum[:]) + `"`})
+}
+
+// verifyFakeS3Checksums checks the contents of an upload against the checksums sent with it, returning the
+// checksums to store with the object.
+func verifyFakeS3Checksums(r *http.Request, contents []byte) (map[string]string, bool) {
+	if contentMD5 := r.Header.Get("Content-MD5"); contentMD5 != "" {
+		sum := md5.Sum(contents)
+		if contentMD5 != base64.StdEncoding.EncodeToString(sum[:]) {
+			return nil, false
+		}
+	}
+
+	checksums := make(map[string]string)
+	for header, algorithm := range fakeS3ChecksumHeaders {
+		value := r.Header.Get(header)
+		if value == "" {
+			continue
+		}
+
+		actual, err := computeChecksum(bytes.NewReader(contents), algorithm)
+		if err != nil || actual.Value != value {
+			return nil, false
+		}
+		checksums[header] = value
+	}
+
+	return checksums, true
+}
+
+func writeFakeS3XML(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(body)
+}
+
+func writeFakeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: code})
+}
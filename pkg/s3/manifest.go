@@ -0,0 +1,83 @@
+package s3
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+)
+
+// Manifest records how a bucket was captured by a download, so that a restore can later tell whether (and to
+// which instant) the bucket can be rewound in place.
+type Manifest struct {
+	// Source is the s3://bucket/prefix URL that was captured.
+	Source string `json:"source"`
+	// PointInTime is set when the capture reconstructed the prefix from its object versions as of AsOf,
+	// rather than capturing its latest state.
+	PointInTime bool `json:"pointInTime"`
+	// AsOf is the instant that the prefix was captured as of. It is only set for point-in-time captures.
+	AsOf time.Time `json:"asOf,omitzero"`
+	// Filter selects the objects that were captured. Objects that it excludes were not captured, and are
+	// left alone by a rewind.
+	Filter files.FileFilter `json:"filter,omitzero"`
+	// Objects records each captured object, keyed by its slash-separated path relative to the captured
+	// prefix (and so to the capture's directory).
+	Objects map[string]ObjectRecord `json:"objects,omitempty"`
+}
+
+// ObjectRecord describes a single captured object.
+type ObjectRecord struct {
+	// Checksum is the expected digest of the object's contents, which the local copy was verified against
+	// when it was downloaded, and is verified against again before it is uploaded.
+	Checksum Checksum `json:"checksum,omitzero"`
+	// ObjectMetadata is re-applied to the object when it is uploaded.
+	ObjectMetadata
+}
+
+// writeManifest writes the manifest to manifestPath, replacing any existing manifest.
+func writeManifest(manifestPath string, manifest Manifest) error {
+	return trace.Wrap(writeJSONFile(manifestPath, manifest), "failed to write manifest")
+}
+
+// readManifest reads the manifest written by a previous download.
+func readManifest(manifestPath string) (Manifest, error) {
+	var manifest Manifest
+	if err := readJSONFile(manifestPath, &manifest); err != nil {
+		return Manifest{}, trace.Wrap(err, "failed to read manifest")
+	}
+
+	return manifest, nil
+}
+
+// writeJSONFile encodes value as JSON to filePath, replacing any existing file. The file is written next to
+// its final location and then renamed into place, so a failed write never leaves a truncated file behind.
+func writeJSONFile(filePath string, value any) error {
+	contents, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return trace.Wrap(err, "failed to encode %q", filePath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return trace.Wrap(err, "failed to create parent directory for %q", filePath)
+	}
+
+	tempPath := filePath + ".tmp"
+	if err := os.WriteFile(tempPath, contents, 0o644); err != nil {
+		return trace.Wrap(err, "failed to write %q", tempPath)
+	}
+
+	return trace.Wrap(os.Rename(tempPath, filePath), "failed to move %q to %q", tempPath, filePath)
+}
+
+// readJSONFile decodes the JSON file at filePath into value.
+func readJSONFile(filePath string, value any) error {
+	contents, err := os.ReadFile(filePath)
+	if err != nil {
+		return trace.Wrap(err, "failed to read %q", filePath)
+	}
+
+	return trace.Wrap(json.Unmarshal(contents, value), "failed to decode %q", filePath)
+}
@@ -0,0 +1,219 @@
+package s3
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// ObjectMetadata is everything about an object other than its contents that a restore re-applies: its
+// content headers, user-defined metadata, tags and storage class.
+type ObjectMetadata struct {
+	ContentType             string    `json:"contentType,omitempty"`
+	CacheControl            string    `json:"cacheControl,omitempty"`
+	ContentDisposition      string    `json:"contentDisposition,omitempty"`
+	ContentEncoding         string    `json:"contentEncoding,omitempty"`
+	ContentLanguage         string    `json:"contentLanguage,omitempty"`
+	Expires                 time.Time `json:"expires,omitzero"`
+	WebsiteRedirectLocation string    `json:"websiteRedirectLocation,omitempty"`
+	// UserMetadata is the object's user-defined (x-amz-meta-*) metadata, keyed by name without the prefix.
+	UserMetadata map[string]string `json:"userMetadata,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	// StorageClass is empty for the standard storage class.
+	StorageClass types.StorageClass `json:"storageClass,omitempty"`
+}
+
+// tagging returns the tags encoded as a URL query string, as uploads take them.
+func (m ObjectMetadata) tagging() *string {
+	if len(m.Tags) == 0 {
+		return nil
+	}
+
+	tags := make(url.Values, len(m.Tags))
+	for key, value := range m.Tags {
+		tags.Set(key, value)
+	}
+
+	return aws.String(tags.Encode())
+}
+
+// applyToPutObject sets the metadata on a single-part upload.
+func (m ObjectMetadata) applyToPutObject(input *s3.PutObjectInput) {
+	input.ContentType = optionalString(m.ContentType)
+	input.CacheControl = optionalString(m.CacheControl)
+	input.ContentDisposition = optionalString(m.ContentDisposition)
+	input.ContentEncoding = optionalString(m.ContentEncoding)
+	input.ContentLanguage = optionalString(m.ContentLanguage)
+	input.Expires = optionalTime(m.Expires)
+	input.WebsiteRedirectLocation = optionalString(m.WebsiteRedirectLocation)
+	input.Metadata = m.UserMetadata
+	input.Tagging = m.tagging()
+	input.StorageClass = m.StorageClass
+}
+
+// applyToCreateMultipartUpload sets the metadata on a multipart upload.
+func (m ObjectMetadata) applyToCreateMultipartUpload(input *s3.CreateMultipartUploadInput) {
+	input.ContentType = optionalString(m.ContentType)
+	input.CacheControl = optionalString(m.CacheControl)
+	input.ContentDisposition = optionalString(m.ContentDisposition)
+	input.ContentEncoding = optionalString(m.ContentEncoding)
+	input.ContentLanguage = optionalString(m.ContentLanguage)
+	input.Expires = optionalTime(m.Expires)
+	input.WebsiteRedirectLocation = optionalString(m.WebsiteRedirectLocation)
+	input.Metadata = m.UserMetadata
+	input.Tagging = m.tagging()
+	input.StorageClass = m.StorageClass
+}
+
+// objectMetadataHeaders are the GetObject and HeadObject response fields that an object's metadata is read
+// from. Tags are not returned with the object, only their count.
+type objectMetadataHeaders struct {
+	contentType             *string
+	cacheControl            *string
+	contentDisposition      *string
+	contentEncoding         *string
+	contentLanguage         *string
+	expires                 *time.Time
+	websiteRedirectLocation *string
+	metadata                map[string]string
+	storageClass            types.StorageClass
+	tagCount                *int32
+}
+
+// objectMetadata returns the metadata that the headers describe, and whether the object has tags to fetch.
+func (h objectMetadataHeaders) objectMetadata() (ObjectMetadata, bool) {
+	metadata := ObjectMetadata{
+		ContentType:             aws.ToString(h.contentType),
+		CacheControl:            aws.ToString(h.cacheControl),
+		ContentDisposition:      aws.ToString(h.contentDisposition),
+		ContentEncoding:         aws.ToString(h.contentEncoding),
+		ContentLanguage:         aws.ToString(h.contentLanguage),
+		Expires:                 aws.ToTime(h.expires),
+		WebsiteRedirectLocation: aws.ToString(h.websiteRedirectLocation),
+		StorageClass:            h.storageClass,
+	}
+	if len(h.metadata) > 0 {
+		metadata.UserMetadata = h.metadata
+	}
+	if metadata.StorageClass == types.StorageClassStandard {
+		metadata.StorageClass = ""
+	}
+
+	return metadata, aws.ToInt32(h.tagCount) > 0
+}
+
+// getObjectRecord returns the record of the object fetched by a (non-ranged) GetObject, and whether the object
+// has tags to fetch.
+func getObjectRecord(out *s3.GetObjectOutput) (ObjectRecord, bool) {
+	metadata, hasTags := objectMetadataHeaders{
+		contentType:             out.ContentType,
+		cacheControl:            out.CacheControl,
+		contentDisposition:      out.ContentDisposition,
+		contentEncoding:         out.ContentEncoding,
+		contentLanguage:         out.ContentLanguage,
+		expires:                 out.Expires,
+		websiteRedirectLocation: out.WebsiteRedirectLocation,
+		metadata:                out.Metadata,
+		storageClass:            out.StorageClass,
+		tagCount:                out.TagCount,
+	}.objectMetadata()
+
+	return ObjectRecord{Checksum: getObjectChecksum(out), ObjectMetadata: metadata}, hasTags
+}
+
+// fetchObjectRecord fetches the checksum and metadata (including tags) of the object's listed version (or
+// ETag), without fetching its contents.
+func fetchObjectRecord(ctx *contexts.Context, client s3API, bucket string, obj remoteObject) (ObjectRecord, error) {
+	input := &s3.HeadObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(obj.key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	}
+	if obj.versionID != nil {
+		input.VersionId = obj.versionID
+	} else if obj.etag != "" {
+		input.IfMatch = aws.String(obj.etag)
+	}
+
+	out, err := client.HeadObject(ctx, input)
+	if err != nil {
+		return ObjectRecord{}, trace.Wrap(err, "failed to get attributes of object %q", obj.key)
+	}
+
+	metadata, hasTags := objectMetadataHeaders{
+		contentType:             out.ContentType,
+		cacheControl:            out.CacheControl,
+		contentDisposition:      out.ContentDisposition,
+		contentEncoding:         out.ContentEncoding,
+		contentLanguage:         out.ContentLanguage,
+		expires:                 out.Expires,
+		websiteRedirectLocation: out.WebsiteRedirectLocation,
+		metadata:                out.Metadata,
+		storageClass:            out.StorageClass,
+		tagCount:                out.TagCount,
+	}.objectMetadata()
+
+	record := ObjectRecord{
+		Checksum: objectChecksumHeaders{
+			etag:                 out.ETag,
+			checksumSHA256:       out.ChecksumSHA256,
+			checksumCRC32C:       out.ChecksumCRC32C,
+			checksumCRC32:        out.ChecksumCRC32,
+			checksumType:         out.ChecksumType,
+			serverSideEncryption: out.ServerSideEncryption,
+			sseCustomerAlgorithm: out.SSECustomerAlgorithm,
+		}.checksum(),
+		ObjectMetadata: metadata,
+	}
+
+	if hasTags {
+		if record.Tags, err = getObjectTags(ctx, client, bucket, obj); err != nil {
+			return ObjectRecord{}, trace.Wrap(err, "failed to get tags of object %q", obj.key)
+		}
+	}
+
+	return record, nil
+}
+
+// getObjectTags fetches the tags of the object's listed version.
+func getObjectTags(ctx *contexts.Context, client s3API, bucket string, obj remoteObject) (map[string]string, error) {
+	out, err := client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(obj.key),
+		VersionId: obj.versionID,
+	})
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to get tags of object %q", obj.key)
+	}
+
+	tags := make(map[string]string, len(out.TagSet))
+	for _, tag := range out.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	return tags, nil
+}
+
+// optionalString returns nil for an empty string, so that unset metadata is left unset rather than sent
+// empty.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return aws.String(value)
+}
+
+// optionalTime returns nil for a zero time.
+func optionalTime(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+
+	return aws.Time(value)
+}
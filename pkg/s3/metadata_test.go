@@ -0,0 +1,130 @@
+package s3
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectMetadataApply(t *testing.T) {
+	expires := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	metadata := ObjectMetadata{
+		ContentType:        "text/plain",
+		CacheControl:       "max-age=60",
+		ContentDisposition: "attachment",
+		Expires:            expires,
+		UserMetadata:       map[string]string{"owner": "teleport"},
+		Tags:               map[string]string{"b": "2", "a": "1 2"},
+		StorageClass:       types.StorageClassStandardIa,
+	}
+
+	putInput := &s3.PutObjectInput{}
+	metadata.applyToPutObject(putInput)
+	assert.Equal(t, &s3.PutObjectInput{
+		ContentType:        aws.String("text/plain"),
+		CacheControl:       aws.String("max-age=60"),
+		ContentDisposition: aws.String("attachment"),
+		Expires:            &expires,
+		Metadata:           map[string]string{"owner": "teleport"},
+		Tagging:            aws.String("a=1+2&b=2"),
+		StorageClass:       types.StorageClassStandardIa,
+	}, putInput)
+
+	createInput := &s3.CreateMultipartUploadInput{}
+	metadata.applyToCreateMultipartUpload(createInput)
+	assert.Equal(t, &s3.CreateMultipartUploadInput{
+		ContentType:        aws.String("text/plain"),
+		CacheControl:       aws.String("max-age=60"),
+		ContentDisposition: aws.String("attachment"),
+		Expires:            &expires,
+		Metadata:           map[string]string{"owner": "teleport"},
+		Tagging:            aws.String("a=1+2&b=2"),
+		StorageClass:       types.StorageClassStandardIa,
+	}, createInput)
+
+	// Unset metadata is left unset.
+	emptyInput := &s3.PutObjectInput{}
+	ObjectMetadata{}.applyToPutObject(emptyInput)
+	assert.Equal(t, &s3.PutObjectInput{}, emptyInput)
+}
+
+func TestSyncPreservesObjectMetadata(t *testing.T) {
+	headers := http.Header{
+		"Content-Type":                    {"application/x-teleport"},
+		"Cache-Control":                   {"no-cache"},
+		"Content-Disposition":             {`attachment; filename="session.tar"`},
+		"Content-Language":                {"en"},
+		"X-Amz-Website-Redirect-Location": {"/elsewhere"},
+		"X-Amz-Storage-Class":             {"STANDARD_IA"},
+		"X-Amz-Meta-Session-Id":           {"abc123"},
+	}
+	tags := url.Values{"retention": {"long"}, "app": {"teleport"}}
+	expectedMetadata := ObjectMetadata{
+		ContentType:             "application/x-teleport",
+		CacheControl:            "no-cache",
+		ContentDisposition:      `attachment; filename="session.tar"`,
+		ContentLanguage:         "en",
+		WebsiteRedirectLocation: "/elsewhere",
+		UserMetadata:            map[string]string{"session-id": "abc123"},
+		Tags:                    map[string]string{"retention": "long", "app": "teleport"},
+		StorageClass:            types.StorageClassStandardIa,
+	}
+
+	for _, multipart := range []bool{false, true} {
+		t.Run(map[bool]string{false: "whole object", true: "in parts"}[multipart], func(t *testing.T) {
+			fake := newFakeS3(t)
+			fake.setObject("bucket", "prefix/session.tar", fakeS3Object{
+				contents: randomContents(minPartSize),
+				headers:  headers,
+				tags:     tags,
+			})
+
+			opts := SyncOptions{ManifestPath: filepath.Join(t.TempDir(), "manifest.json")}
+			if multipart {
+				opts.Multipart = testMultipart
+			}
+
+			destDir := t.TempDir()
+			rt := NewLocalRuntime()
+			require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+
+			manifest, err := readManifest(opts.ManifestPath)
+			require.NoError(t, err)
+			assert.Equal(t, expectedMetadata, manifest.Objects["session.tar"].ObjectMetadata)
+
+			// The restored object gets the original's metadata back.
+			require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), destDir, "s3://restored/prefix", time.Time{}, opts))
+
+			fake.lock.Lock()
+			restored := fake.objects["restored/prefix/session.tar"]
+			fake.lock.Unlock()
+			for name, values := range headers {
+				assert.Equal(t, values, restored.headers[name], name)
+			}
+			assert.Equal(t, tags, restored.tags)
+		})
+	}
+}
+
+func TestSyncUploadDetectsContentTypeWithoutRecord(t *testing.T) {
+	fake := newFakeS3(t)
+
+	srcDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "page.html"), []byte("<html></html>"), 0o644))
+	require.NoError(t, NewLocalRuntime().Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://bucket/prefix", time.Time{}, SyncOptions{}))
+
+	fake.lock.Lock()
+	uploaded := fake.objects["bucket/prefix/page.html"]
+	fake.lock.Unlock()
+	assert.Equal(t, "text/html; charset=utf-8", uploaded.headers.Get("Content-Type"))
+}
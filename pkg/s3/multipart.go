@@ -0,0 +1,520 @@
+package s3
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/cleanup"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultMultipartThreshold is the object size at and above which objects are transferred in parts, when
+	// not configured.
+	DefaultMultipartThreshold = 64 << 20 // 64 MiB
+	// DefaultPartSize is the size of each part of a multipart transfer, when not configured.
+	DefaultPartSize = 16 << 20 // 16 MiB
+
+	// S3 limits for multipart uploads. Every part other than the last must be at least minPartSize.
+	minPartSize = 5 << 20 // 5 MiB
+	maxPartSize = 5 << 30 // 5 GiB
+	maxParts    = 10000
+	// maxSingleRequestSize is the largest object that a single PutObject or CopyObject can write. Larger
+	// objects must be written in parts, whatever the configured threshold.
+	maxSingleRequestSize = 5 << 30 // 5 GiB
+
+	// partParallelism caps the number of concurrent part transfers for a single object.
+	partParallelism = 4
+	// maxPartDownloadAttempts caps the number of times that a single part is fetched before the download
+	// fails. The SDK retries failed requests itself, but not a response body that fails part way through.
+	maxPartDownloadAttempts = 3
+
+	// partialSuffix is appended to an object's local path to form the path that a multipart download is
+	// written to. The file is renamed into place once it is complete. The download's progress is recorded
+	// beside it, in a file with an additional ".json" suffix, so that an interrupted download resumes rather
+	// than restarting.
+	partialSuffix = ".s3sync-partial"
+)
+
+// MultipartOptions configures how large objects are split into parts. Each part is a separate request, so
+// a failure only retries the affected part, and an interrupted download resumes from its completed parts.
+type MultipartOptions struct {
+	// Threshold is the object size, in bytes, at and above which objects are transferred in parts. Zero
+	// means DefaultMultipartThreshold.
+	Threshold int64 `yaml:"threshold,omitempty"`
+	// PartSize is the size, in bytes, of each part. Zero means DefaultPartSize. It is raised for objects that
+	// would otherwise need more than the 10,000 parts that S3 allows.
+	PartSize int64 `yaml:"partSize,omitempty"`
+}
+
+// Validate checks that the options are within S3's multipart limits.
+func (o MultipartOptions) Validate() error {
+	if o.Threshold < 0 {
+		return trace.BadParameter("multipart threshold must not be negative")
+	}
+
+	if o.PartSize != 0 && (o.PartSize < minPartSize || o.PartSize > maxPartSize) {
+		return trace.BadParameter("part size must be between %d and %d bytes", minPartSize, maxPartSize)
+	}
+
+	return nil
+}
+
+// usesParts reports whether an object of the given size is transferred in parts. Objects too large for a
+// single request always are.
+func (o MultipartOptions) usesParts(size int64) bool {
+	threshold := o.Threshold
+	if threshold == 0 {
+		threshold = DefaultMultipartThreshold
+	}
+
+	return size >= threshold || size > maxSingleRequestSize
+}
+
+// partSizeFor returns the part size to use for an object of the given size.
+func (o MultipartOptions) partSizeFor(size int64) int64 {
+	partSize := o.PartSize
+	if partSize == 0 {
+		partSize = DefaultPartSize
+	}
+
+	// Round up to a whole MiB so that the part count stays within the limit.
+	if minimum := (size + maxParts - 1) / maxParts; partSize < minimum {
+		partSize = (minimum + 1<<20 - 1) &^ (1<<20 - 1)
+	}
+
+	return partSize
+}
+
+// part is a single byte range of an object. Part numbers start at 1.
+type part struct {
+	number int32
+	offset int64
+	size   int64
+}
+
+// splitIntoParts splits an object of the given size into parts of partSize bytes (the last may be shorter).
+func splitIntoParts(size, partSize int64) []part {
+	parts := make([]part, 0, (size+partSize-1)/partSize)
+	for offset := int64(0); offset < size; offset += partSize {
+		parts = append(parts, part{
+			number: int32(len(parts) + 1),
+			offset: offset,
+			size:   min(partSize, size-offset),
+		})
+	}
+
+	return parts
+}
+
+// partialDownload records the progress of a multipart download. It identifies the object version that the
+// partial file holds, so that parts of a different version are never combined.
+type partialDownload struct {
+	ETag           string  `json:"etag"`
+	VersionID      string  `json:"versionId,omitempty"`
+	Size           int64   `json:"size"`
+	PartSize       int64   `json:"partSize"`
+	CompletedParts []int32 `json:"completedParts"`
+}
+
+// matches reports whether the recorded progress is for the same object version and part layout.
+func (pd partialDownload) matches(other partialDownload) bool {
+	// Without an ETag or version ID, there is no way to tell whether the object has changed.
+	if pd.ETag == "" && pd.VersionID == "" {
+		return false
+	}
+
+	return pd.ETag == other.ETag && pd.VersionID == other.VersionID && pd.Size == other.Size && pd.PartSize == other.PartSize
+}
+
+// isPartialDownloadFile reports whether the file name belongs to an incomplete multipart download: either the
+// partial file itself, or the record of its progress.
+func isPartialDownloadFile(name string) bool {
+	return strings.HasSuffix(name, partialSuffix) || strings.HasSuffix(name, partialSuffix+".json")
+}
+
+// downloadObjectInParts downloads a single object to target with concurrent ranged GETs. The parts are
+// written to a partial file whose progress is recorded after each part, so that a later download of the
+// same object version only fetches the parts that are missing. The partial file is moved to target once it
+// is complete.
+func downloadObjectInParts(ctx *contexts.Context, client s3API, bucket string, obj remoteObject, target string, opts MultipartOptions, limiter *throttle.Limiter) (err error) {
+	partialPath := target + partialSuffix
+	statePath := partialPath + ".json"
+
+	wanted := partialDownload{
+		ETag:      obj.etag,
+		VersionID: aws.ToString(obj.versionID),
+		Size:      obj.size,
+		PartSize:  opts.partSizeFor(obj.size),
+	}
+
+	// Any previous progress is only usable if it is for this object version, and the partial file that it
+	// describes still exists.
+	completed := make(map[int32]struct{})
+	var previous partialDownload
+	if err := readJSONFile(statePath, &previous); err == nil && previous.matches(wanted) {
+		if _, err := os.Stat(partialPath); err == nil {
+			for _, partNumber := range previous.CompletedParts {
+				completed[partNumber] = struct{}{}
+			}
+		}
+	}
+	if len(completed) > 0 {
+		ctx.Log.With("key", obj.key, "completedParts", len(completed)).Info("Resuming interrupted download")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return trace.Wrap(err, "failed to create parent directory for %q", target)
+	}
+
+	flags := os.O_RDWR | os.O_CREATE
+	if len(completed) == 0 {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(partialPath, flags, 0o644)
+	if err != nil {
+		return trace.Wrap(err, "failed to open %q", partialPath)
+	}
+	defer cleanup.To(func(_ *contexts.Context) error { return f.Close() }).
+		WithErrMessage("failed to close %q", partialPath).
+		WithOriginalErr(&err).
+		Run()
+
+	if err := f.Truncate(obj.size); err != nil {
+		return trace.Wrap(err, "failed to resize %q", partialPath)
+	}
+
+	// Progress is recorded only after the part's bytes are flushed to disk, so a crash can lose progress but
+	// never record a part that wasn't written.
+	var stateLock sync.Mutex
+	recordPart := func(partNumber int32) error {
+		stateLock.Lock()
+		defer stateLock.Unlock()
+
+		if err := f.Sync(); err != nil {
+			return trace.Wrap(err, "failed to flush %q", partialPath)
+		}
+
+		completed[partNumber] = struct{}{}
+		state := wanted
+		for completedPart := range completed {
+			state.CompletedParts = append(state.CompletedParts, completedPart)
+		}
+		slices.Sort(state.CompletedParts)
+
+		return trace.Wrap(writeJSONFile(statePath, state), "failed to record download progress")
+	}
+
+	var pending []part
+	for _, p := range splitIntoParts(obj.size, wanted.PartSize) {
+		if _, ok := completed[p.number]; !ok {
+			pending = append(pending, p)
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(partParallelism)
+	for _, p := range pending {
+		g.Go(func() error {
+			var partErr error
+			for attempt := 1; attempt <= maxPartDownloadAttempts; attempt++ {
+				partErr = downloadPart(ctx, client, bucket, obj, p, f, limiter)
+				if partErr == nil {
+					return recordPart(p.number)
+				}
+
+				if ctx.Err() != nil {
+					break
+				}
+
+				ctx.Log.Warn("Failed to download part", "key", obj.key, "part", p.number, "attempt", attempt, "error", partErr)
+			}
+
+			return trace.Wrap(partErr, "failed to download part %d of object %q", p.number, obj.key)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return trace.Wrap(err, "failed to download one or more parts")
+	}
+
+	if err := os.Rename(partialPath, target); err != nil {
+		return trace.Wrap(err, "failed to move %q to %q", partialPath, target)
+	}
+
+	if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+		return trace.Wrap(err, "failed to remove %q", statePath)
+	}
+
+	return nil
+}
+
+// downloadPart fetches a single part of an object and writes it at the part's offset in f. The request is
+// pinned to the listed version (or ETag), so every part comes from the same version of the object.
+func downloadPart(ctx *contexts.Context, client s3API, bucket string, obj remoteObject, p part, f *os.File, limiter *throttle.Limiter) error {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(obj.key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", p.offset, p.offset+p.size-1)),
+	}
+	if obj.versionID != nil {
+		input.VersionId = obj.versionID
+	} else if obj.etag != "" {
+		input.IfMatch = aws.String(obj.etag)
+	}
+
+	out, err := client.GetObject(ctx, input)
+	if err != nil {
+		return trace.Wrap(err, "failed to get object %q", obj.key)
+	}
+	defer cleanup.To(func(_ *contexts.Context) error { return out.Body.Close() }).
+		WithErrMessage("failed to close response body for object %q", obj.key).
+		Run()
+
+	written, err := io.Copy(io.NewOffsetWriter(f, p.offset), io.LimitReader(limiter.Reader(ctx, out.Body), p.size))
+	if err != nil {
+		return trace.Wrap(err, "failed to write part to %q", f.Name())
+	}
+
+	if written != p.size {
+		return trace.Errorf("received %d bytes, expected %d", written, p.size)
+	}
+
+	return nil
+}
+
+// uploadObjectInParts uploads the contents of f to key with the given metadata and retention, as a multipart
+// upload. Each part is a separate request with a seekable body, so the SDK retries a failed part on its own
+// without resending the others.
+func uploadObjectInParts(ctx *contexts.Context, client s3API, bucket, key string, f *os.File, size int64, metadata ObjectMetadata, retention ObjectRetention, opts MultipartOptions, limiter *throttle.Limiter) error {
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(key),
+		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+	}
+	metadata.applyToCreateMultipartUpload(input)
+	retention.applyToCreateMultipartUpload(input)
+
+	return multipartUpload(ctx, client, input, splitIntoParts(size, opts.partSizeFor(size)), func(uploadID *string, p part) (types.CompletedPart, error) {
+		out, err := client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:            aws.String(bucket),
+			Key:               aws.String(key),
+			UploadId:          uploadID,
+			PartNumber:        aws.Int32(p.number),
+			Body:              limiter.Reader(ctx, io.NewSectionReader(f, p.offset, p.size)),
+			ContentLength:     aws.Int64(p.size),
+			ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+		})
+		if err != nil {
+			return types.CompletedPart{}, err
+		}
+
+		return types.CompletedPart{ETag: out.ETag, ChecksumCRC32: out.ChecksumCRC32}, nil
+	})
+}
+
+// copyObjectInParts copies a single object (at its selected version) to destKey with server-side UploadPartCopy
+// requests, for objects too large for a single CopyObject. Unlike CopyObject, a multipart upload doesn't carry
+// the source's metadata and tags over, so they are read from the source and set on the upload. When the source
+// was itself uploaded in parts, the copy reuses its part size, so that the copy has the same ETag as the source.
+func copyObjectInParts(ctx *contexts.Context, client s3API, srcBucket string, obj remoteObject, destBucket, destKey string, retention ObjectRetention, opts MultipartOptions) error {
+	record, err := fetchObjectRecord(ctx, client, srcBucket, obj)
+	if err != nil {
+		return trace.Wrap(err, "failed to get metadata of object %q", obj.key)
+	}
+
+	partSize, err := sourcePartSize(ctx, client, srcBucket, obj)
+	if err != nil {
+		return trace.Wrap(err, "failed to get the part size of object %q", obj.key)
+	}
+	if partSize == 0 {
+		partSize = opts.partSizeFor(obj.size)
+	}
+
+	input := &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(destBucket),
+		Key:    aws.String(destKey),
+	}
+	record.ObjectMetadata.applyToCreateMultipartUpload(input)
+	retention.applyToCreateMultipartUpload(input)
+
+	copySource := objectCopySource(srcBucket, obj)
+	return multipartUpload(ctx, client, input, splitIntoParts(obj.size, partSize), func(uploadID *string, p part) (types.CompletedPart, error) {
+		out, err := client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+			Bucket:          aws.String(destBucket),
+			Key:             aws.String(destKey),
+			UploadId:        uploadID,
+			PartNumber:      aws.Int32(p.number),
+			CopySource:      aws.String(copySource),
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", p.offset, p.offset+p.size-1)),
+		})
+		if err != nil {
+			return types.CompletedPart{}, err
+		}
+
+		if out.CopyPartResult == nil {
+			return types.CompletedPart{}, trace.Errorf("no result returned for the copy of part %d", p.number)
+		}
+
+		return types.CompletedPart{ETag: out.CopyPartResult.ETag}, nil
+	})
+}
+
+// sourcePartSize returns the size of the parts that the object's selected version was uploaded in, or zero if
+// it wasn't uploaded in parts (or its parts can't be reproduced, such as when they differ in size).
+func sourcePartSize(ctx *contexts.Context, client s3API, bucket string, obj remoteObject) (int64, error) {
+	// Only the ETag of a multipart upload has a part count suffix, so other objects don't need to be checked.
+	if !strings.Contains(obj.etag, "-") {
+		return 0, nil
+	}
+
+	input := &s3.HeadObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(obj.key),
+		PartNumber: aws.Int32(1),
+	}
+	if obj.versionID != nil {
+		input.VersionId = obj.versionID
+	} else if obj.etag != "" {
+		input.IfMatch = aws.String(obj.etag)
+	}
+
+	out, err := client.HeadObject(ctx, input)
+	if err != nil {
+		return 0, trace.Wrap(err, "failed to get the first part of object %q", obj.key)
+	}
+
+	partSize := aws.ToInt64(out.ContentLength)
+	if partSize < minPartSize || partSize > maxPartSize || int64(len(splitIntoParts(obj.size, partSize))) != int64(aws.ToInt32(out.PartsCount)) {
+		return 0, nil
+	}
+
+	return partSize, nil
+}
+
+// streamObjectInParts copies a single object (at its selected version) to destKey as a multipart upload, where
+// each part is fetched from the source with a ranged GET and uploaded as it is read, so the object is never
+// staged locally. The source's content type and user-defined metadata are carried over, as with a streamed
+// single-part copy.
+func streamObjectInParts(ctx *contexts.Context, srcClient s3API, srcBucket string, obj remoteObject, destClient s3API, destBucket, destKey string, putOptFns []func(*s3.Options), retention ObjectRetention, opts MultipartOptions, limiter *throttle.Limiter) error {
+	record, err := fetchObjectRecord(ctx, srcClient, srcBucket, obj)
+	if err != nil {
+		return trace.Wrap(err, "failed to get metadata of object %q", obj.key)
+	}
+
+	// Without TLS, a body that can't be rewound can't be checksummed (see streamingPutOptions), so the parts
+	// are only checksummed when no options were needed to send them.
+	var checksumAlgorithm types.ChecksumAlgorithm
+	if len(putOptFns) == 0 {
+		checksumAlgorithm = types.ChecksumAlgorithmCrc32
+	}
+
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:            aws.String(destBucket),
+		Key:               aws.String(destKey),
+		ContentType:       optionalString(record.ContentType),
+		Metadata:          record.UserMetadata,
+		ChecksumAlgorithm: checksumAlgorithm,
+	}
+	retention.applyToCreateMultipartUpload(input)
+
+	return multipartUpload(ctx, destClient, input, splitIntoParts(obj.size, opts.partSizeFor(obj.size)), func(uploadID *string, p part) (completed types.CompletedPart, err error) {
+		getInput := &s3.GetObjectInput{
+			Bucket: aws.String(srcBucket),
+			Key:    aws.String(obj.key),
+			Range:  aws.String(fmt.Sprintf("bytes=%d-%d", p.offset, p.offset+p.size-1)),
+		}
+		if obj.versionID != nil {
+			getInput.VersionId = obj.versionID
+		} else if obj.etag != "" {
+			getInput.IfMatch = aws.String(obj.etag)
+		}
+
+		got, err := srcClient.GetObject(ctx, getInput)
+		if err != nil {
+			return types.CompletedPart{}, trace.Wrap(err, "failed to get part %d of object %q", p.number, obj.key)
+		}
+		defer cleanup.To(func(_ *contexts.Context) error { return got.Body.Close() }).
+			WithErrMessage("failed to close response body for object %q", obj.key).
+			WithOriginalErr(&err).
+			Run()
+
+		out, err := destClient.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:            aws.String(destBucket),
+			Key:               aws.String(destKey),
+			UploadId:          uploadID,
+			PartNumber:        aws.Int32(p.number),
+			Body:              limiter.Reader(ctx, got.Body),
+			ContentLength:     aws.Int64(p.size),
+			ChecksumAlgorithm: checksumAlgorithm,
+		}, putOptFns...)
+		if err != nil {
+			return types.CompletedPart{}, err
+		}
+
+		return types.CompletedPart{ETag: out.ETag, ChecksumCRC32: out.ChecksumCRC32}, nil
+	})
+}
+
+// multipartUpload creates a multipart upload with input, uploads its parts with uploadPart (up to partParallelism
+// parts at once), and completes it. The upload is aborted if any part fails, so that the bucket doesn't keep (and
+// bill for) the uploaded parts.
+func multipartUpload(ctx *contexts.Context, client s3API, input *s3.CreateMultipartUploadInput, parts []part, uploadPart func(uploadID *string, p part) (types.CompletedPart, error)) (err error) {
+	created, err := client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return trace.Wrap(err, "failed to create multipart upload")
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		cleanup.To(func(ctx *contexts.Context) error {
+			_, err := client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   input.Bucket,
+				Key:      input.Key,
+				UploadId: created.UploadId,
+			})
+			return err
+		}).WithErrMessage("failed to abort multipart upload of %q", aws.ToString(input.Key)).WithOriginalErr(&err).WithParentCtx(ctx).Run()
+	}()
+
+	completedParts := make([]types.CompletedPart, len(parts))
+
+	var g errgroup.Group
+	g.SetLimit(partParallelism)
+	for i, p := range parts {
+		g.Go(func() error {
+			completedPart, err := uploadPart(created.UploadId, p)
+			if err != nil {
+				return trace.Wrap(err, "failed to upload part %d", p.number)
+			}
+
+			completedPart.PartNumber = aws.Int32(p.number)
+			completedParts[i] = completedPart
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return trace.Wrap(err, "failed to upload one or more parts")
+	}
+
+	_, err = client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          input.Bucket,
+		Key:             input.Key,
+		UploadId:        created.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completedParts},
+	})
+	return trace.Wrap(err, "failed to complete multipart upload")
+}
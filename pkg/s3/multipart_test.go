@@ -0,0 +1,310 @@
+package s3
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMultipart splits objects of at least 5 MiB into 5 MiB parts, the smallest that S3 allows.
+var testMultipart = MultipartOptions{Threshold: minPartSize, PartSize: minPartSize}
+
+// randomContents returns size bytes that differ from part to part, so misplaced parts are detected.
+func randomContents(size int) []byte {
+	contents := make([]byte, size)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range contents {
+		contents[i] = byte(rng.Uint32())
+	}
+
+	return contents
+}
+
+func TestMultipartOptionsValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    MultipartOptions
+		errFunc assert.ErrorAssertionFunc
+	}{
+		{
+			desc:    "defaults",
+			errFunc: assert.NoError,
+		},
+		{
+			desc:    "configured",
+			opts:    MultipartOptions{Threshold: 1 << 30, PartSize: 64 << 20},
+			errFunc: assert.NoError,
+		},
+		{
+			desc:    "negative threshold",
+			opts:    MultipartOptions{Threshold: -1},
+			errFunc: assert.Error,
+		},
+		{
+			desc:    "part size too small",
+			opts:    MultipartOptions{PartSize: minPartSize - 1},
+			errFunc: assert.Error,
+		},
+		{
+			desc:    "part size too large",
+			opts:    MultipartOptions{PartSize: maxPartSize + 1},
+			errFunc: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.errFunc(t, tt.opts.Validate())
+		})
+	}
+}
+
+func TestMultipartOptionsPartSizeFor(t *testing.T) {
+	assert.EqualValues(t, DefaultPartSize, MultipartOptions{}.partSizeFor(1<<30))
+	assert.EqualValues(t, minPartSize, testMultipart.partSizeFor(1<<30))
+
+	// 1 TiB in 5 MiB parts would need far more than 10,000 parts.
+	partSize := testMultipart.partSizeFor(1 << 40)
+	assert.LessOrEqual(t, int64(1<<40)/partSize, int64(maxParts))
+	assert.Zero(t, partSize%(1<<20))
+}
+
+func TestSplitIntoParts(t *testing.T) {
+	assert.Equal(t, []part{
+		{number: 1, offset: 0, size: 4},
+		{number: 2, offset: 4, size: 4},
+		{number: 3, offset: 8, size: 2},
+	}, splitIntoParts(10, 4))
+	assert.Equal(t, []part{{number: 1, offset: 0, size: 8}}, splitIntoParts(8, 8))
+}
+
+func TestSyncMultipartRoundTrip(t *testing.T) {
+	fake := newFakeS3(t)
+	contents := randomContents(2*minPartSize + 1)
+
+	srcDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "large.bin"), contents, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "small.txt"), []byte("abc"), 0o644))
+
+	rt := NewLocalRuntime()
+	opts := SyncOptions{Multipart: testMultipart}
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://bucket/prefix", time.Time{}, opts))
+
+	uploaded, ok := fake.getObject("bucket", "prefix/large.bin")
+	require.True(t, ok)
+	assert.Equal(t, contents, uploaded)
+	assert.ElementsMatch(t, []string{
+		"CREATE prefix/large.bin",
+		"PART prefix/large.bin 1",
+		"PART prefix/large.bin 2",
+		"PART prefix/large.bin 3",
+		"COMPLETE prefix/large.bin",
+		"PUT prefix/small.txt",
+	}, fake.takeRequests())
+
+	destDir := t.TempDir()
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+
+	downloaded, err := os.ReadFile(filepath.Join(destDir, "large.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, contents, downloaded)
+	assert.ElementsMatch(t, []string{
+		"HEAD prefix/large.bin",
+		"GET prefix/large.bin bytes=0-5242879",
+		"GET prefix/large.bin bytes=5242880-10485759",
+		"GET prefix/large.bin bytes=10485760-10485760",
+		"GET prefix/small.txt",
+	}, fake.takeRequests())
+	assert.NoFileExists(t, filepath.Join(destDir, "large.bin"+partialSuffix))
+	assert.NoFileExists(t, filepath.Join(destDir, "large.bin"+partialSuffix+".json"))
+}
+
+func TestSyncMultipartDownloadRetriesPart(t *testing.T) {
+	fake := newFakeS3(t)
+	contents := randomContents(2 * minPartSize)
+	fake.putObject("bucket", "prefix/large.bin", contents)
+
+	failures := 0
+	fake.failGet = func(_, byteRange string) bool {
+		if strings.HasPrefix(byteRange, "bytes=5242880-") && failures == 0 {
+			failures++
+			return true
+		}
+		return false
+	}
+
+	destDir := t.TempDir()
+	err := NewLocalRuntime().Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, SyncOptions{Multipart: testMultipart})
+	require.NoError(t, err)
+
+	downloaded, err := os.ReadFile(filepath.Join(destDir, "large.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, contents, downloaded)
+
+	// Only the failed part is fetched again.
+	assert.ElementsMatch(t, []string{
+		"HEAD prefix/large.bin",
+		"GET prefix/large.bin bytes=0-5242879",
+		"GET prefix/large.bin bytes=5242880-10485759",
+		"GET prefix/large.bin bytes=5242880-10485759",
+	}, fake.takeRequests())
+}
+
+func TestSyncMultipartDownloadResumes(t *testing.T) {
+	fake := newFakeS3(t)
+	contents := randomContents(3 * minPartSize)
+	fake.putObject("bucket", "prefix/large.bin", contents)
+
+	// The middle part fails on every attempt, so the first download is interrupted.
+	fake.failGet = func(_, byteRange string) bool {
+		return strings.HasPrefix(byteRange, "bytes=5242880-")
+	}
+
+	destDir := t.TempDir()
+	rt := NewLocalRuntime()
+	opts := SyncOptions{Multipart: testMultipart}
+	require.Error(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+	assert.NoFileExists(t, filepath.Join(destDir, "large.bin"))
+	assert.FileExists(t, filepath.Join(destDir, "large.bin"+partialSuffix))
+	fake.takeRequests()
+
+	// The partial download is not an object, so it is never uploaded.
+	localFiles, err := listLocalFiles(destDir)
+	require.NoError(t, err)
+	assert.Empty(t, localFiles)
+
+	fake.failGet = nil
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+
+	downloaded, err := os.ReadFile(filepath.Join(destDir, "large.bin"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(contents, downloaded))
+	assert.Equal(t, []string{"HEAD prefix/large.bin", "GET prefix/large.bin bytes=5242880-10485759"}, fake.takeRequests())
+	assert.NoFileExists(t, filepath.Join(destDir, "large.bin"+partialSuffix))
+	assert.NoFileExists(t, filepath.Join(destDir, "large.bin"+partialSuffix+".json"))
+}
+
+func TestSyncMultipartDownloadRestartsWhenObjectChanges(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.putObject("bucket", "prefix/large.bin", randomContents(2*minPartSize))
+	fake.failGet = func(_, byteRange string) bool {
+		return strings.HasPrefix(byteRange, "bytes=5242880-")
+	}
+
+	destDir := t.TempDir()
+	rt := NewLocalRuntime()
+	opts := SyncOptions{Multipart: testMultipart}
+	require.Error(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+	fake.takeRequests()
+
+	// The object is replaced before the download is retried, so none of the downloaded parts can be reused.
+	replaced := bytes.Repeat([]byte("b"), 2*minPartSize)
+	fake.putObject("bucket", "prefix/large.bin", replaced)
+	fake.failGet = nil
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+
+	downloaded, err := os.ReadFile(filepath.Join(destDir, "large.bin"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(replaced, downloaded))
+	assert.Len(t, fake.takeRequests(), 3) // a HEAD and both parts
+}
+
+func TestSyncMultipartUploadAbortsOnFailure(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.failUploadPart = func(_ string, partNumber int32) bool {
+		return partNumber == 2
+	}
+
+	srcDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "large.bin"), randomContents(2*minPartSize), 0o644))
+
+	err := NewLocalRuntime().Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://bucket/prefix", time.Time{}, SyncOptions{Multipart: testMultipart})
+	require.Error(t, err)
+
+	_, ok := fake.getObject("bucket", "prefix/large.bin")
+	assert.False(t, ok)
+	assert.Contains(t, fake.takeRequests(), "ABORT prefix/large.bin")
+}
+
+func TestIsPartialDownloadFile(t *testing.T) {
+	assert.True(t, isPartialDownloadFile(filepath.Join("dir", "large.bin"+partialSuffix)))
+	assert.True(t, isPartialDownloadFile(filepath.Join("dir", "large.bin"+partialSuffix+".json")))
+	assert.False(t, isPartialDownloadFile(filepath.Join("dir", "large.bin")))
+	// A user's file that only contains the suffix part way through its name is uploaded like any other.
+	assert.False(t, isPartialDownloadFile(filepath.Join("dir", "notes"+partialSuffix+".txt")))
+}
+
+func TestMultipartOptionsUsesParts(t *testing.T) {
+	assert.False(t, MultipartOptions{}.usesParts(DefaultMultipartThreshold-1))
+	assert.True(t, MultipartOptions{}.usesParts(DefaultMultipartThreshold))
+	// Objects too large for a single request are always transferred in parts.
+	assert.True(t, MultipartOptions{Threshold: 1 << 40}.usesParts(maxSingleRequestSize+1))
+}
+
+func TestSyncMirrorServerSideInParts(t *testing.T) {
+	fake := newFakeS3(t)
+	contents := randomContents(2*minPartSize + 1)
+
+	srcDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "large.bin"), contents, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "small.txt"), []byte("abc"), 0o644))
+
+	rt := NewLocalRuntime()
+	opts := SyncOptions{Multipart: testMultipart}
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://src-bucket/prefix", time.Time{}, opts))
+	fake.takeRequests()
+
+	// Both buckets are behind the same endpoint, so the objects are copied server-side.
+	require.NoError(t, rt.Sync(th.NewTestContext(), fake.credentials(), "s3://src-bucket/prefix", "s3://dest-bucket/backup", time.Time{}, opts))
+
+	copied, ok := fake.getObject("dest-bucket", "backup/large.bin")
+	require.True(t, ok)
+	assert.Equal(t, contents, copied)
+	// The copy reuses the source's part size, so it has the same ETag.
+	assert.Equal(t, fake.getETag("src-bucket", "prefix/large.bin"), fake.getETag("dest-bucket", "backup/large.bin"))
+
+	requests := fake.takeRequests()
+	assert.Subset(t, requests, []string{
+		"CREATE backup/large.bin",
+		"COPYPART backup/large.bin 1",
+		"COPYPART backup/large.bin 2",
+		"COPYPART backup/large.bin 3",
+		"COMPLETE backup/large.bin",
+		"COPY backup/small.txt",
+	})
+	assert.NotContains(t, requests, "GET prefix/large.bin")
+}
+
+func TestSyncMirrorStreamsInParts(t *testing.T) {
+	srcFake := newFakeS3(t)
+	destFake := newFakeS3(t)
+	contents := randomContents(2*minPartSize + 1)
+	srcFake.putObject("src-bucket", "prefix/large.bin", contents)
+
+	opts := SyncOptions{Multipart: testMultipart, DestCredentials: destFake.credentials()}
+	require.NoError(t, NewLocalRuntime().Sync(th.NewTestContext(), srcFake.credentials(), "s3://src-bucket/prefix", "s3://dest-bucket/backup", time.Time{}, opts))
+
+	copied, ok := destFake.getObject("dest-bucket", "backup/large.bin")
+	require.True(t, ok)
+	assert.Equal(t, contents, copied)
+	assert.ElementsMatch(t, []string{
+		"CREATE backup/large.bin",
+		"PART backup/large.bin 1",
+		"PART backup/large.bin 2",
+		"PART backup/large.bin 3",
+		"COMPLETE backup/large.bin",
+	}, destFake.takeRequests())
+	assert.Subset(t, srcFake.takeRequests(), []string{
+		"GET prefix/large.bin bytes=0-5242879",
+		"GET prefix/large.bin bytes=5242880-10485759",
+		"GET prefix/large.bin bytes=10485760-10485760",
+	})
+}
@@ -0,0 +1,195 @@
+package s3
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// ObjectLockMode is the S3 Object Lock retention mode of an object.
+type ObjectLockMode string
+
+const (
+	// ObjectLockModeGovernance keeps objects from being deleted or overwritten until their retention expires,
+	// except by users with the s3:BypassGovernanceRetention permission.
+	ObjectLockModeGovernance ObjectLockMode = "GOVERNANCE"
+	// ObjectLockModeCompliance keeps objects from being deleted or overwritten by anyone (including the
+	// account's root user) until their retention expires.
+	ObjectLockModeCompliance ObjectLockMode = "COMPLIANCE"
+)
+
+// ObjectLock configures the S3 Object Lock protection given to each uploaded object, so that someone holding
+// the credentials used to write a backup can't use them to delete it. The destination bucket must have
+// Object Lock enabled, which can only be done when the bucket is created. The zero value locks nothing.
+type ObjectLock struct {
+	// Mode is the retention mode. It is required when Retention is set.
+	Mode ObjectLockMode `yaml:"mode,omitempty"`
+	// Retention is how long each object is retained for, from when it is uploaded.
+	Retention time.Duration `yaml:"retention,omitempty"`
+	// LegalHold places a legal hold on each object, which keeps it until the hold is removed, regardless of
+	// its retention.
+	LegalHold bool `yaml:"legalHold,omitempty"`
+}
+
+// Validate checks that the mode and retention are set together.
+func (l ObjectLock) Validate() error {
+	switch l.Mode {
+	case "":
+		if l.Retention != 0 {
+			return trace.BadParameter("a retention mode is required with a retention period")
+		}
+	case ObjectLockModeGovernance, ObjectLockModeCompliance:
+		if l.Retention <= 0 {
+			return trace.BadParameter("a positive retention period is required with retention mode %q", l.Mode)
+		}
+	default:
+		return trace.BadParameter("invalid retention mode %q (must be %q or %q)", l.Mode, ObjectLockModeGovernance, ObjectLockModeCompliance)
+	}
+
+	return nil
+}
+
+// IsZero reports whether the lock protects nothing.
+func (l ObjectLock) IsZero() bool {
+	return l == ObjectLock{}
+}
+
+// At returns the protection given to objects uploaded at now.
+func (l ObjectLock) At(now time.Time) ObjectRetention {
+	retention := ObjectRetention{LegalHold: l.LegalHold}
+	if l.Mode != "" {
+		retention.Mode = l.Mode
+		// S3 keeps retain until dates to the second. Rounding up keeps the objects for at least the retention.
+		retention.RetainUntil = now.Add(l.Retention + time.Second - 1).UTC().Truncate(time.Second)
+	}
+
+	return retention
+}
+
+// ObjectRetention is the Object Lock protection that objects were given when they were uploaded.
+type ObjectRetention struct {
+	Mode ObjectLockMode `json:"mode,omitempty"`
+	// RetainUntil is when the retention expires. It is only set along with Mode.
+	RetainUntil time.Time `json:"retainUntil,omitzero"`
+	LegalHold   bool      `json:"legalHold,omitempty"`
+}
+
+// IsZero reports whether the objects are unprotected.
+func (r ObjectRetention) IsZero() bool {
+	return r == ObjectRetention{}
+}
+
+// ProtectsAt reports whether the objects can't be deleted at the given time.
+func (r ObjectRetention) ProtectsAt(at time.Time) bool {
+	return r.LegalHold || (r.Mode != "" && r.RetainUntil.After(at))
+}
+
+func (r ObjectRetention) legalHoldStatus() types.ObjectLockLegalHoldStatus {
+	if !r.LegalHold {
+		return ""
+	}
+
+	return types.ObjectLockLegalHoldStatusOn
+}
+
+// applyToPutObject sets the protection on a single-part upload.
+func (r ObjectRetention) applyToPutObject(input *s3.PutObjectInput) {
+	input.ObjectLockMode = types.ObjectLockMode(r.Mode)
+	input.ObjectLockRetainUntilDate = optionalTime(r.RetainUntil)
+	input.ObjectLockLegalHoldStatus = r.legalHoldStatus()
+}
+
+// applyToCreateMultipartUpload sets the protection on a multipart upload.
+func (r ObjectRetention) applyToCreateMultipartUpload(input *s3.CreateMultipartUploadInput) {
+	input.ObjectLockMode = types.ObjectLockMode(r.Mode)
+	input.ObjectLockRetainUntilDate = optionalTime(r.RetainUntil)
+	input.ObjectLockLegalHoldStatus = r.legalHoldStatus()
+}
+
+// applyToCopyObject sets the protection on the copy made by a server-side copy. Copies don't inherit the
+// protection of their source.
+func (r ObjectRetention) applyToCopyObject(input *s3.CopyObjectInput) {
+	input.ObjectLockMode = types.ObjectLockMode(r.Mode)
+	input.ObjectLockRetainUntilDate = optionalTime(r.RetainUntil)
+	input.ObjectLockLegalHoldStatus = r.legalHoldStatus()
+}
+
+// extendRetention raises the protection of an existing object to at least r. Retention can only be extended,
+// so an object already retained for longer is left as it is, and an object in compliance mode stays in it.
+func extendRetention(ctx *contexts.Context, client s3API, bucket, key string, r ObjectRetention) error {
+	if r.IsZero() {
+		return nil
+	}
+
+	current, err := client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return trace.Wrap(err, "failed to get the retention of object %q", key)
+	}
+
+	if r.Mode != "" && (current.ObjectLockRetainUntilDate == nil || current.ObjectLockRetainUntilDate.Before(r.RetainUntil)) {
+		mode := types.ObjectLockRetentionMode(r.Mode)
+		if current.ObjectLockMode == types.ObjectLockModeCompliance {
+			mode = types.ObjectLockRetentionModeCompliance
+		}
+
+		_, err := client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Retention: &types.ObjectLockRetention{
+				Mode:            mode,
+				RetainUntilDate: aws.Time(r.RetainUntil),
+			},
+		})
+		if err != nil {
+			return trace.Wrap(err, "failed to extend the retention of object %q", key)
+		}
+	}
+
+	if r.LegalHold && current.ObjectLockLegalHoldStatus != types.ObjectLockLegalHoldStatusOn {
+		_, err := client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(key),
+			LegalHold: &types.ObjectLockLegalHold{Status: types.ObjectLockLegalHoldStatusOn},
+		})
+		if err != nil {
+			return trace.Wrap(err, "failed to place a legal hold on object %q", key)
+		}
+	}
+
+	return nil
+}
+
+// CheckObjectLock verifies that the bucket of the s3://bucket/prefix path has Object Lock enabled, so that
+// objects can be uploaded to it with an ObjectLock. It is called before a backup starts, so that a bucket that
+// can't protect the backup fails it before any resource is created, rather than once the backup is uploaded.
+func (lr *LocalRuntime) CheckObjectLock(ctx *contexts.Context, credentials CredentialsInterface, path string) (err error) {
+	ctx.Log.With("path", path).Info("Checking that the bucket has Object Lock enabled")
+	defer ctx.Log.Info("Finished checking the bucket's Object Lock configuration", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+
+	parsed, isS3, err := parseS3Path(path)
+	if err != nil {
+		return trace.Wrap(err, "failed to parse path")
+	}
+
+	if !isS3 {
+		return trace.BadParameter("path %q is not an s3:// URL", path)
+	}
+
+	out, err := lr.newClient(credentials).GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(parsed.bucket),
+	})
+	if err != nil {
+		// S3 returns an error rather than an empty configuration for buckets without Object Lock.
+		return trace.Wrap(err, "failed to get the Object Lock configuration of bucket %q (Object Lock may not be enabled)", parsed.bucket)
+	}
+
+	if out.ObjectLockConfiguration == nil || out.ObjectLockConfiguration.ObjectLockEnabled != types.ObjectLockEnabledEnabled {
+		return trace.BadParameter("bucket %q does not have Object Lock enabled", parsed.bucket)
+	}
+
+	return nil
+}
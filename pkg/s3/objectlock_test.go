@@ -0,0 +1,217 @@
+package s3
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectLockValidate(t *testing.T) {
+	tests := []struct {
+		desc        string
+		lock        ObjectLock
+		expectedErr bool
+	}{
+		{desc: "zero value"},
+		{desc: "governance", lock: ObjectLock{Mode: ObjectLockModeGovernance, Retention: time.Hour}},
+		{desc: "compliance with legal hold", lock: ObjectLock{Mode: ObjectLockModeCompliance, Retention: time.Hour, LegalHold: true}},
+		{desc: "legal hold only", lock: ObjectLock{LegalHold: true}},
+		{desc: "retention without mode", lock: ObjectLock{Retention: time.Hour}, expectedErr: true},
+		{desc: "mode without retention", lock: ObjectLock{Mode: ObjectLockModeGovernance}, expectedErr: true},
+		{desc: "negative retention", lock: ObjectLock{Mode: ObjectLockModeGovernance, Retention: -time.Hour}, expectedErr: true},
+		{desc: "invalid mode", lock: ObjectLock{Mode: "forever", Retention: time.Hour}, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.lock.Validate()
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestObjectLockAt(t *testing.T) {
+	now := time.Date(2030, time.January, 2, 3, 4, 5, 6, time.UTC)
+
+	retention := ObjectLock{Mode: ObjectLockModeCompliance, Retention: 24 * time.Hour, LegalHold: true}.At(now)
+	assert.Equal(t, ObjectRetention{
+		Mode:        ObjectLockModeCompliance,
+		RetainUntil: time.Date(2030, time.January, 3, 3, 4, 6, 0, time.UTC),
+		LegalHold:   true,
+	}, retention)
+	assert.True(t, retention.ProtectsAt(now))
+
+	assert.True(t, ObjectLock{}.At(now).IsZero())
+	assert.False(t, ObjectLock{}.At(now).ProtectsAt(now))
+}
+
+func TestObjectRetentionProtectsAt(t *testing.T) {
+	retainUntil := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	retention := ObjectRetention{Mode: ObjectLockModeGovernance, RetainUntil: retainUntil}
+
+	assert.True(t, retention.ProtectsAt(retainUntil.Add(-time.Second)))
+	assert.False(t, retention.ProtectsAt(retainUntil))
+
+	retention.LegalHold = true
+	assert.True(t, retention.ProtectsAt(retainUntil.Add(time.Hour)))
+}
+
+func TestObjectRetentionApply(t *testing.T) {
+	retainUntil := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	retention := ObjectRetention{Mode: ObjectLockModeGovernance, RetainUntil: retainUntil, LegalHold: true}
+
+	putInput := &s3.PutObjectInput{}
+	retention.applyToPutObject(putInput)
+	assert.Equal(t, &s3.PutObjectInput{
+		ObjectLockMode:            types.ObjectLockModeGovernance,
+		ObjectLockRetainUntilDate: &retainUntil,
+		ObjectLockLegalHoldStatus: types.ObjectLockLegalHoldStatusOn,
+	}, putInput)
+
+	copyInput := &s3.CopyObjectInput{}
+	retention.applyToCopyObject(copyInput)
+	assert.Equal(t, &s3.CopyObjectInput{
+		ObjectLockMode:            types.ObjectLockModeGovernance,
+		ObjectLockRetainUntilDate: &retainUntil,
+		ObjectLockLegalHoldStatus: types.ObjectLockLegalHoldStatusOn,
+	}, copyInput)
+
+	// No retention leaves the upload unprotected.
+	emptyInput := &s3.CreateMultipartUploadInput{}
+	ObjectRetention{}.applyToCreateMultipartUpload(emptyInput)
+	assert.Equal(t, &s3.CreateMultipartUploadInput{}, emptyInput)
+}
+
+func TestSyncUploadObjectLock(t *testing.T) {
+	fake := newFakeS3(t)
+
+	srcDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "large.bin"), randomContents(minPartSize+1), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "small.txt"), []byte("abc"), 0o644))
+
+	opts := SyncOptions{
+		Multipart:  testMultipart,
+		ObjectLock: ObjectLock{Mode: ObjectLockModeCompliance, Retention: 24 * time.Hour, LegalHold: true},
+	}
+	before := time.Now()
+	require.NoError(t, NewLocalRuntime().Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://bucket/prefix", time.Time{}, opts))
+
+	// Both objects are retained until the same instant, whether they were uploaded in parts or not.
+	var retainUntil string
+	for _, key := range []string{"prefix/large.bin", "prefix/small.txt"} {
+		headers := fake.getHeaders("bucket", key)
+		assert.Equal(t, "COMPLIANCE", headers.Get("X-Amz-Object-Lock-Mode"), key)
+		assert.Equal(t, "ON", headers.Get("X-Amz-Object-Lock-Legal-Hold"), key)
+
+		if retainUntil == "" {
+			retainUntil = headers.Get("X-Amz-Object-Lock-Retain-Until-Date")
+		}
+		assert.Equal(t, retainUntil, headers.Get("X-Amz-Object-Lock-Retain-Until-Date"), key)
+	}
+
+	parsed, err := time.Parse(time.RFC3339, retainUntil)
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(24*time.Hour), parsed, time.Minute)
+
+	err = NewLocalRuntime().Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://bucket/prefix", time.Time{}, SyncOptions{
+		ObjectLock: ObjectLock{Retention: time.Hour},
+	})
+	assert.Error(t, err)
+}
+
+func TestObjectStoreRetention(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.putObject("bucket", "repository/packs/ab/abcd", []byte("unprotected"))
+
+	retainUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	store, err := NewLocalRuntime().newObjectStore(fake.credentials(), "s3://bucket/repository")
+	require.NoError(t, err)
+	store.WithRetention(ObjectRetention{Mode: ObjectLockModeGovernance, RetainUntil: retainUntil, LegalHold: true})
+	ctx := th.NewTestContext()
+
+	require.NoError(t, store.Put(ctx, "snapshots/1234", []byte("snapshot")))
+	headers := fake.getHeaders("bucket", "repository/snapshots/1234")
+	assert.Equal(t, "GOVERNANCE", headers.Get("X-Amz-Object-Lock-Mode"))
+	assert.Equal(t, retainUntil.Format(time.RFC3339), headers.Get("X-Amz-Object-Lock-Retain-Until-Date"))
+	assert.Equal(t, "ON", headers.Get("X-Amz-Object-Lock-Legal-Hold"))
+	fake.takeRequests()
+
+	// An existing object is given the retention.
+	require.NoError(t, store.Retain(ctx, "packs/ab/abcd"))
+	headers = fake.getHeaders("bucket", "repository/packs/ab/abcd")
+	assert.Equal(t, "GOVERNANCE", headers.Get("X-Amz-Object-Lock-Mode"))
+	assert.Equal(t, retainUntil.Format(time.RFC3339), headers.Get("X-Amz-Object-Lock-Retain-Until-Date"))
+	assert.Equal(t, "ON", headers.Get("X-Amz-Object-Lock-Legal-Hold"))
+	assert.Equal(t, []string{
+		"HEAD repository/packs/ab/abcd",
+		"RETENTION repository/packs/ab/abcd",
+		"LEGALHOLD repository/packs/ab/abcd",
+	}, fake.takeRequests())
+
+	// An object that is already retained for as long is left alone.
+	require.NoError(t, store.Retain(ctx, "packs/ab/abcd"))
+	assert.Equal(t, []string{"HEAD repository/packs/ab/abcd"}, fake.takeRequests())
+
+	// An object in compliance mode stays in it when its retention is extended.
+	fake.setObject("bucket", "repository/packs/cd/cdef", fakeS3Object{
+		contents: []byte("compliance"),
+		headers: map[string][]string{
+			"X-Amz-Object-Lock-Mode":              {"COMPLIANCE"},
+			"X-Amz-Object-Lock-Retain-Until-Date": {retainUntil.Add(-time.Minute).Format(time.RFC3339)},
+		},
+	})
+	require.NoError(t, store.Retain(ctx, "packs/cd/cdef"))
+	headers = fake.getHeaders("bucket", "repository/packs/cd/cdef")
+	assert.Equal(t, "COMPLIANCE", headers.Get("X-Amz-Object-Lock-Mode"))
+	assert.Equal(t, retainUntil.Format(time.RFC3339), headers.Get("X-Amz-Object-Lock-Retain-Until-Date"))
+
+	assert.Error(t, store.Retain(ctx, "packs/ef/missing"))
+
+	// A store without a retention doesn't retain anything.
+	unprotected, err := NewLocalRuntime().newObjectStore(fake.credentials(), "s3://bucket/repository")
+	require.NoError(t, err)
+	fake.takeRequests()
+	require.NoError(t, unprotected.Retain(ctx, "packs/ab/abcd"))
+	assert.Empty(t, fake.takeRequests())
+}
+
+func TestCheckObjectLock(t *testing.T) {
+	ctx := th.NewTestContext()
+
+	t.Run("enabled", func(t *testing.T) {
+		fake := newFakeS3(t)
+		fake.objectLockEnabled = true
+		assert.NoError(t, NewLocalRuntime().CheckObjectLock(ctx, fake.credentials(), "s3://bucket/prefix"))
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		fake := newFakeS3(t)
+		assert.Error(t, NewLocalRuntime().CheckObjectLock(ctx, fake.credentials(), "s3://bucket/prefix"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client := NewMocks3API(t)
+		client.EXPECT().GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{Bucket: aws.String("bucket")}).
+			Return(&s3.GetObjectLockConfigurationOutput{ObjectLockConfiguration: &types.ObjectLockConfiguration{}}, nil)
+
+		rt := NewLocalRuntime()
+		injectClient(rt, client)
+		assert.Error(t, rt.CheckObjectLock(ctx, NewCredentials("id", "secret"), "s3://bucket/prefix"))
+	})
+
+	t.Run("local path", func(t *testing.T) {
+		assert.Error(t, NewLocalRuntime().CheckObjectLock(ctx, NewCredentials("id", "secret"), "/some/dir"))
+	})
+}
@@ -0,0 +1,157 @@
+package s3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+)
+
+// ObjectStore reads and writes whole objects under an s3://bucket/prefix path, by their keys relative to the
+// prefix. It is a building block for formats that manage their own objects (such as a deduplicating
+// repository), rather than mirroring a directory.
+type ObjectStore struct {
+	client    s3API
+	path      s3Path
+	retention ObjectRetention
+}
+
+// NewObjectStore creates a store for the objects under the s3://bucket/prefix path.
+func NewObjectStore(credentials CredentialsInterface, storePath string) (*ObjectStore, error) {
+	return NewLocalRuntime().newObjectStore(credentials, storePath)
+}
+
+func (lr *LocalRuntime) newObjectStore(credentials CredentialsInterface, storePath string) (*ObjectStore, error) {
+	parsed, isS3, err := parseS3Path(storePath)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to parse store path")
+	}
+
+	if !isS3 {
+		return nil, trace.BadParameter("store path %q is not an s3:// URL", storePath)
+	}
+
+	return &ObjectStore{
+		client: lr.newClient(credentials),
+		path:   parsed,
+	}, nil
+}
+
+// WithRetention protects the objects that the store writes (and those that it retains) with the retention.
+func (store *ObjectStore) WithRetention(retention ObjectRetention) *ObjectStore {
+	store.retention = retention
+	return store
+}
+
+func (store *ObjectStore) key(key string) string {
+	return path.Join(store.path.prefix, key)
+}
+
+// Get reads the whole object. It returns a trace.NotFound error when the object does not exist.
+func (store *ObjectStore) Get(ctx *contexts.Context, key string) ([]byte, error) {
+	return store.get(ctx, key, nil)
+}
+
+// GetRange reads length bytes of the object, starting at offset.
+func (store *ObjectStore) GetRange(ctx *contexts.Context, key string, offset, length int64) ([]byte, error) {
+	if length <= 0 {
+		return nil, trace.BadParameter("range length must be positive")
+	}
+
+	data, err := store.get(ctx, key, aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)))
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+
+	if int64(len(data)) != length {
+		return nil, trace.Errorf("read %d bytes of object %q at offset %d, expected %d", len(data), key, offset, length)
+	}
+
+	return data, nil
+}
+
+func (store *ObjectStore) get(ctx *contexts.Context, key string, byteRange *string) ([]byte, error) {
+	out, err := store.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(store.path.bucket),
+		Key:    aws.String(store.key(key)),
+		Range:  byteRange,
+	})
+	if err != nil {
+		if noSuchKey := (*types.NoSuchKey)(nil); errors.As(err, &noSuchKey) {
+			return nil, trace.NotFound("object %q does not exist", key)
+		}
+		return nil, trace.Wrap(err, "failed to get object %q", key)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	return data, trace.Wrap(err, "failed to read object %q", key)
+}
+
+// Put writes the object, replacing it if it exists.
+func (store *ObjectStore) Put(ctx *contexts.Context, key string, data []byte) error {
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(store.path.bucket),
+		Key:           aws.String(store.key(key)),
+		Body:          bytes.NewReader(data),
+		ContentLength: aws.Int64(int64(len(data))),
+	}
+	store.retention.applyToPutObject(input)
+
+	_, err := store.client.PutObject(ctx, input)
+	return trace.Wrap(err, "failed to put object %q", key)
+}
+
+// Retain extends the protection of an existing object to the store's retention, for objects that were
+// written earlier (possibly without any protection) but that newer data depends on. It does nothing when the
+// store has no retention.
+func (store *ObjectStore) Retain(ctx *contexts.Context, key string) error {
+	return trace.Wrap(extendRetention(ctx, store.client, store.path.bucket, store.key(key), store.retention))
+}
+
+// List returns the keys of the objects under prefix, relative to the store's path, in lexical order.
+func (store *ObjectStore) List(ctx *contexts.Context, prefix string) ([]string, error) {
+	// path.Join drops a trailing slash, which keeps a listing of "a/" (or of the whole store) from matching
+	// the keys of a sibling such as "ab/".
+	listPrefix := store.key(prefix)
+	if listPrefix != "" && (prefix == "" || strings.HasSuffix(prefix, "/")) {
+		listPrefix += "/"
+	}
+
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(store.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(store.path.bucket),
+		Prefix: aws.String(listPrefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, trace.Wrap(err, "failed to list objects under %q", prefix)
+		}
+
+		for _, obj := range page.Contents {
+			if rel := relativeKey(store.path.prefix, aws.ToString(obj.Key)); rel != "" {
+				keys = append(keys, rel)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// Delete removes the object. Deleting an object that does not exist is not an error.
+func (store *ObjectStore) Delete(ctx *contexts.Context, key string) error {
+	_, err := store.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(store.path.bucket),
+		Key:    aws.String(store.key(key)),
+	})
+	return trace.Wrap(err, "failed to delete object %q", key)
+}
@@ -0,0 +1,102 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/gravitational/trace"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewObjectStore(t *testing.T) {
+	tests := []struct {
+		desc        string
+		path        string
+		expected    s3Path
+		expectedErr bool
+	}{
+		{desc: "bucket and prefix", path: "s3://bucket/prefix", expected: s3Path{bucket: "bucket", prefix: "prefix"}},
+		{desc: "bucket only", path: "s3://bucket", expected: s3Path{bucket: "bucket"}},
+		{desc: "local path", path: "/some/dir", expectedErr: true},
+		{desc: "missing bucket", path: "s3:///prefix", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			store, err := NewObjectStore(NewCredentials("id", "secret"), tt.path)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, store.client)
+			assert.Equal(t, tt.expected, store.path)
+		})
+	}
+}
+
+func TestObjectStore(t *testing.T) {
+	fake := newFakeS3(t)
+	fake.putObject("bucket", "repository-sibling/config", []byte("sibling"))
+
+	store, err := NewLocalRuntime().newObjectStore(fake.credentials(), "s3://bucket/repository")
+	require.NoError(t, err)
+	ctx := th.NewTestContext()
+
+	t.Run("get a missing object", func(t *testing.T) {
+		_, err := store.Get(ctx, "config")
+		assert.True(t, trace.IsNotFound(err))
+	})
+
+	t.Run("put and get", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "config", []byte("config contents")))
+		require.NoError(t, store.Put(ctx, "packs/ab/abcd", []byte("0123456789")))
+		require.NoError(t, store.Put(ctx, "packs/cd/cdef", []byte("abcdefghij")))
+
+		contents, ok := fake.getObject("bucket", "repository/config")
+		require.True(t, ok)
+		assert.Equal(t, "config contents", string(contents))
+
+		data, err := store.Get(ctx, "config")
+		require.NoError(t, err)
+		assert.Equal(t, "config contents", string(data))
+	})
+
+	t.Run("get a range", func(t *testing.T) {
+		data, err := store.GetRange(ctx, "packs/ab/abcd", 2, 5)
+		require.NoError(t, err)
+		assert.Equal(t, "23456", string(data))
+
+		_, err = store.GetRange(ctx, "packs/ab/abcd", 2, 0)
+		assert.Error(t, err)
+
+		_, err = store.GetRange(ctx, "packs/ab/abcd", 8, 5)
+		assert.Error(t, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		keys, err := store.List(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"config", "packs/ab/abcd", "packs/cd/cdef"}, keys)
+
+		keys, err = store.List(ctx, "packs/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"packs/ab/abcd", "packs/cd/cdef"}, keys)
+
+		keys, err = store.List(ctx, "snapshots/")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, "packs/ab/abcd"))
+		require.NoError(t, store.Delete(ctx, "packs/ab/abcd"))
+
+		_, ok := fake.getObject("bucket", "repository/packs/ab/abcd")
+		assert.False(t, ok)
+		_, ok = fake.getObject("bucket", "repository-sibling/config")
+		assert.True(t, ok)
+	})
+}
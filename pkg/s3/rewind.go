@@ -0,0 +1,207 @@
+package s3
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gravitational/trace"
+	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
+	"golang.org/x/sync/errgroup"
+)
+
+// rewindMultipart copies every object that a single CopyObject can in one request. A CopyObject keeps the ETag
+// of a version that wasn't uploaded in parts, which a copy in parts would not.
+var rewindMultipart = MultipartOptions{Threshold: maxSingleRequestSize + 1}
+
+// Rewind rolls a versioned bucket prefix back to the instant recorded in a point-in-time download's
+// manifest. See Runtime.Rewind.
+func (lr *LocalRuntime) Rewind(ctx *contexts.Context, credentials CredentialsInterface, target, manifestPath string, opts RewindOptions) (changes []RewindChange, err error) {
+	limiter := throttle.NewLimiter(opts.Limits)
+	ctx.Log.With("path", target, "manifest", manifestPath, "dryRun", opts.DryRun).Info("Rewinding bucket")
+	defer ctx.Log.Info("Finished rewinding bucket", ctx.Stopwatch.Keyval(), limiter, contexts.ErrorKeyvals(&err))
+
+	if err := opts.Limits.Validate(); err != nil {
+		return nil, trace.Wrap(err, "invalid rate limits")
+	}
+
+	targetPath, isS3, err := parseS3Path(target)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to parse path")
+	}
+
+	if !isS3 {
+		return nil, trace.BadParameter("path %q is not an s3:// URL", target)
+	}
+
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to read the backup's manifest")
+	}
+
+	if !manifest.PointInTime {
+		return nil, trace.BadParameter("the backup of %q was not captured as of a point in time, so the bucket cannot be rewound to it", manifest.Source)
+	}
+
+	// Versions belong to the bucket they were written to, so the only prefix that can be rewound to them is
+	// the one that they were captured from.
+	sourcePath, _, err := parseS3Path(manifest.Source)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to parse the backup's source path")
+	}
+
+	if !targetPath.equals(sourcePath) {
+		return nil, trace.BadParameter("the backup was captured from %q, not %q", manifest.Source, target)
+	}
+
+	client := lr.newClient(credentials)
+
+	// Without versioning, the versions listed below may have been overwritten, and the restores would not
+	// preserve the objects' current versions.
+	enabled, err := bucketVersioningEnabled(ctx, client, targetPath.bucket)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to determine bucket versioning status")
+	}
+
+	if !enabled {
+		return nil, trace.BadParameter("bucket %q does not have versioning enabled, so it cannot be rewound", targetPath.bucket)
+	}
+
+	versions, deleteMarkers, err := listObjectVersions(ctx, client, targetPath)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to list object versions in bucket %q", targetPath.bucket)
+	}
+
+	ctx.Log.With("asOf", manifest.AsOf).Info("Planning changes to rewind the bucket to the backup's consistency point")
+	// Objects that the backup's filter excluded weren't captured, so they are left as they are.
+	wanted := filterObjects(selectObjectsAsOf(versions, deleteMarkers, targetPath.prefix, manifest.AsOf), manifest.Filter)
+	current := filterObjects(selectLatestVersions(versions, targetPath.prefix), manifest.Filter)
+	changes = planRewind(wanted, current)
+	if opts.DryRun {
+		return changes, nil
+	}
+
+	wantedByKey := make(map[string]remoteObject, len(wanted))
+	for _, obj := range wanted {
+		wantedByKey[obj.key] = obj
+	}
+
+	var g errgroup.Group
+	g.SetLimit(syncParallelism)
+	for _, change := range changes {
+		g.Go(func() error {
+			switch change.Action {
+			case RewindActionRestore:
+				// Copying a version onto its own key makes the copy the current version, without touching the
+				// versions written since. Objects are only copied in parts when they are too large for a single
+				// CopyObject.
+				return copyObject(ctx, client, targetPath.bucket, wantedByKey[change.Key], targetPath.bucket, change.Key, ObjectRetention{}, rewindMultipart, limiter)
+			case RewindActionDelete:
+				if err := limiter.WaitFile(ctx); err != nil {
+					return trace.Wrap(err, "failed to wait to delete object %q", change.Key)
+				}
+
+				// No version is given, so this only adds a delete marker. The object can still be recovered.
+				_, err := client.DeleteObject(ctx, &s3.DeleteObjectInput{
+					Bucket: aws.String(targetPath.bucket),
+					Key:    aws.String(change.Key),
+				})
+				return trace.Wrap(err, "failed to delete object %q", change.Key)
+			default:
+				return trace.BadParameter("unknown rewind action %q", change.Action)
+			}
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, trace.Wrap(err, "failed to restore or delete one or more objects")
+	}
+
+	return changes, nil
+}
+
+// selectLatestVersions returns the current version of each object under the prefix. Objects whose latest
+// entry is a delete marker have no current version, and are omitted.
+func selectLatestVersions(versions []types.ObjectVersion, prefix string) []remoteObject {
+	var objects []remoteObject
+	for _, version := range versions {
+		if !aws.ToBool(version.IsLatest) {
+			continue
+		}
+
+		key := aws.ToString(version.Key)
+		rel := relativeKey(prefix, key)
+		if rel == "" {
+			continue
+		}
+
+		objects = append(objects, remoteObject{
+			key:          key,
+			relPath:      rel,
+			versionID:    version.VersionId,
+			etag:         aws.ToString(version.ETag),
+			size:         aws.ToInt64(version.Size),
+			lastModified: aws.ToTime(version.LastModified),
+		})
+	}
+
+	return objects
+}
+
+// planRewind compares the objects wanted after the rewind against the current objects. Wanted objects whose
+// current version has different contents (or that are currently deleted) are restored, and current objects
+// that are not wanted are deleted. The changes are sorted by key.
+func planRewind(wanted, current []remoteObject) []RewindChange {
+	currentByKey := make(map[string]remoteObject, len(current))
+	for _, obj := range current {
+		currentByKey[obj.key] = obj
+	}
+
+	var changes []RewindChange
+	wantedKeys := make(map[string]struct{}, len(wanted))
+	for _, obj := range wanted {
+		wantedKeys[obj.key] = struct{}{}
+
+		if existing, ok := currentByKey[obj.key]; ok && sameObjectContents(existing, obj) {
+			continue // already current
+		}
+
+		changes = append(changes, RewindChange{
+			Key:       obj.key,
+			Action:    RewindActionRestore,
+			VersionID: aws.ToString(obj.versionID),
+		})
+	}
+
+	for _, obj := range current {
+		if _, ok := wantedKeys[obj.key]; ok {
+			continue
+		}
+
+		changes = append(changes, RewindChange{
+			Key:    obj.key,
+			Action: RewindActionDelete,
+		})
+	}
+
+	slices.SortFunc(changes, func(a, b RewindChange) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return changes
+}
+
+// sameObjectContents reports whether the current version of an object has the contents of the wanted version:
+// either because it is that version, or because it is a copy of it, such as the one made by an earlier rewind.
+// A restore keeps the ETag of the version that it copies (see rewindMultipart), so a repeated rewind leaves
+// the objects that it already restored as they are, rather than adding another version of each.
+func sameObjectContents(current, wanted remoteObject) bool {
+	if aws.ToString(current.versionID) == aws.ToString(wanted.versionID) {
+		return true
+	}
+
+	return wanted.etag != "" && current.etag == wanted.etag && current.size == wanted.size
+}
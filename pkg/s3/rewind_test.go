@@ -0,0 +1,213 @@
+package s3
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func latestVersion(version types.ObjectVersion) types.ObjectVersion {
+	version.IsLatest = aws.Bool(true)
+	return version
+}
+
+func writeTestManifest(t *testing.T, manifest Manifest) string {
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, writeManifest(manifestPath, manifest))
+	return manifestPath
+}
+
+func TestRewind(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	asOf := base.Add(30 * time.Minute)
+	manifest := Manifest{Source: "s3://bucket/prefix", PointInTime: true, AsOf: asOf}
+
+	expectedChanges := []RewindChange{
+		{Key: "prefix/changed.txt", Action: RewindActionRestore, VersionID: "v1"},
+		{Key: "prefix/created.txt", Action: RewindActionDelete},
+		{Key: "prefix/deleted.txt", Action: RewindActionRestore, VersionID: "v1"},
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		t.Run(map[bool]string{false: "apply", true: "dry run"}[dryRun], func(t *testing.T) {
+			client := NewMocks3API(t)
+			client.EXPECT().GetBucketVersioning(mock.Anything, mock.Anything).
+				Return(&awss3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusEnabled}, nil)
+			client.EXPECT().ListObjectVersions(mock.Anything, mock.Anything, mock.Anything).Return(&awss3.ListObjectVersionsOutput{
+				Versions: []types.ObjectVersion{
+					// Overwritten since the consistency point
+					latestVersion(objectVersion("prefix/changed.txt", "v2", 2, asOf.Add(time.Minute))),
+					objectVersion("prefix/changed.txt", "v1", 1, base),
+					// Untouched since the consistency point
+					latestVersion(objectVersion("prefix/same.txt", "v1", 1, base)),
+					// Created since the consistency point
+					latestVersion(objectVersion("prefix/created.txt", "v1", 1, asOf.Add(time.Minute))),
+					// Deleted since the consistency point
+					objectVersion("prefix/deleted.txt", "v1", 1, base),
+				},
+				DeleteMarkers: []types.DeleteMarkerEntry{
+					deleteMarker("prefix/deleted.txt", asOf.Add(time.Minute)),
+				},
+			}, nil)
+
+			if !dryRun {
+				for _, key := range []string{"prefix/changed.txt", "prefix/deleted.txt"} {
+					client.EXPECT().CopyObject(mock.Anything, mock.MatchedBy(func(in *awss3.CopyObjectInput) bool {
+						return aws.ToString(in.Bucket) == "bucket" &&
+							aws.ToString(in.Key) == key &&
+							aws.ToString(in.CopySource) == "bucket/"+key+"?versionId=v1"
+					})).Return(&awss3.CopyObjectOutput{}, nil)
+				}
+				client.EXPECT().DeleteObject(mock.Anything, mock.MatchedBy(func(in *awss3.DeleteObjectInput) bool {
+					return aws.ToString(in.Bucket) == "bucket" && aws.ToString(in.Key) == "prefix/created.txt" && in.VersionId == nil
+				})).Return(&awss3.DeleteObjectOutput{}, nil)
+			}
+
+			rt := NewLocalRuntime()
+			injectClient(rt, client)
+
+			changes, err := rt.Rewind(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix/", writeTestManifest(t, manifest), RewindOptions{DryRun: dryRun})
+			require.NoError(t, err)
+			assert.Equal(t, expectedChanges, changes)
+		})
+	}
+}
+
+func TestPlanRewind(t *testing.T) {
+	object := func(key, versionID, etag string, size int64) remoteObject {
+		return remoteObject{key: key, versionID: aws.String(versionID), etag: etag, size: size}
+	}
+
+	wanted := []remoteObject{
+		object("prefix/current.txt", "v1", `"a"`, 1),
+		// Restored by an earlier rewind, so the current version is a copy with the same contents
+		object("prefix/restored.txt", "v1", `"b"`, 1),
+		object("prefix/changed.txt", "v1", `"c"`, 1),
+		object("prefix/resized.txt", "v1", `"d"`, 1),
+		// Without ETags, only the version ID identifies the contents
+		object("prefix/unknown.txt", "v1", "", 1),
+		object("prefix/deleted.txt", "v1", `"e"`, 1),
+	}
+	current := []remoteObject{
+		object("prefix/current.txt", "v1", `"a"`, 1),
+		object("prefix/restored.txt", "v3", `"b"`, 1),
+		object("prefix/changed.txt", "v2", `"x"`, 1),
+		object("prefix/resized.txt", "v2", `"d"`, 2),
+		object("prefix/unknown.txt", "v2", "", 1),
+		object("prefix/created.txt", "v1", `"f"`, 1),
+	}
+
+	assert.Equal(t, []RewindChange{
+		{Key: "prefix/changed.txt", Action: RewindActionRestore, VersionID: "v1"},
+		{Key: "prefix/created.txt", Action: RewindActionDelete},
+		{Key: "prefix/deleted.txt", Action: RewindActionRestore, VersionID: "v1"},
+		{Key: "prefix/resized.txt", Action: RewindActionRestore, VersionID: "v1"},
+		{Key: "prefix/unknown.txt", Action: RewindActionRestore, VersionID: "v1"},
+	}, planRewind(wanted, current))
+}
+
+func TestRewindLeavesFilteredObjects(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	asOf := base.Add(30 * time.Minute)
+	manifest := Manifest{
+		Source:      "s3://bucket/prefix",
+		PointInTime: true,
+		AsOf:        asOf,
+		Filter:      files.FileFilter{Exclude: []files.FilePattern{{Glob: "thumbnails"}}},
+	}
+
+	client := NewMocks3API(t)
+	client.EXPECT().GetBucketVersioning(mock.Anything, mock.Anything).
+		Return(&awss3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusEnabled}, nil)
+	client.EXPECT().ListObjectVersions(mock.Anything, mock.Anything, mock.Anything).Return(&awss3.ListObjectVersionsOutput{
+		Versions: []types.ObjectVersion{
+			// Both created since the consistency point, but only one was in the backup's scope.
+			latestVersion(objectVersion("prefix/created.txt", "v1", 1, asOf.Add(time.Minute))),
+			latestVersion(objectVersion("prefix/thumbnails/created.jpg", "v1", 1, asOf.Add(time.Minute))),
+		},
+	}, nil)
+
+	rt := NewLocalRuntime()
+	injectClient(rt, client)
+
+	changes, err := rt.Rewind(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", writeTestManifest(t, manifest), RewindOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []RewindChange{{Key: "prefix/created.txt", Action: RewindActionDelete}}, changes)
+}
+
+func TestRewindErrors(t *testing.T) {
+	asOf := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		desc                string
+		path                string
+		manifest            *Manifest
+		versioningStatus    types.BucketVersioningStatus
+		queriesVersioning   bool
+		expectedErrContains string
+	}{
+		{
+			desc:                "missing manifest",
+			path:                "s3://bucket/prefix",
+			expectedErrContains: "manifest",
+		},
+		{
+			desc:                "local path",
+			path:                "/local/path",
+			manifest:            &Manifest{Source: "s3://bucket/prefix", PointInTime: true, AsOf: asOf},
+			expectedErrContains: "not an s3:// URL",
+		},
+		{
+			desc:                "latest-state capture",
+			path:                "s3://bucket/prefix",
+			manifest:            &Manifest{Source: "s3://bucket/prefix"},
+			expectedErrContains: "point in time",
+		},
+		{
+			desc:                "different source",
+			path:                "s3://other-bucket/prefix",
+			manifest:            &Manifest{Source: "s3://bucket/prefix", PointInTime: true, AsOf: asOf},
+			expectedErrContains: "captured from",
+		},
+		{
+			desc:                "versioning suspended",
+			path:                "s3://bucket/prefix",
+			manifest:            &Manifest{Source: "s3://bucket/prefix", PointInTime: true, AsOf: asOf},
+			versioningStatus:    types.BucketVersioningStatusSuspended,
+			queriesVersioning:   true,
+			expectedErrContains: "versioning",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			client := NewMocks3API(t)
+			if tt.queriesVersioning {
+				client.EXPECT().GetBucketVersioning(mock.Anything, mock.Anything).
+					Return(&awss3.GetBucketVersioningOutput{Status: tt.versioningStatus}, nil)
+			}
+
+			rt := NewLocalRuntime()
+			injectClient(rt, client)
+
+			manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+			if tt.manifest != nil {
+				manifestPath = writeTestManifest(t, *tt.manifest)
+			}
+
+			changes, err := rt.Rewind(th.NewTestContext(), NewCredentials("id", "secret"), tt.path, manifestPath, RewindOptions{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErrContains)
+			assert.Nil(t, changes)
+		})
+	}
+}
@@ -7,29 +7,110 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 )
 
+// SyncOptions are the optional parameters for an S3 sync.
+type SyncOptions struct {
+	// Limits caps the rate that objects are transferred at, across all concurrent transfers. The zero
+	// value is unlimited.
+	Limits throttle.Limits
+	// DestCredentials authenticates to the destination bucket of a bucket-to-bucket sync. When nil, the
+	// sync's credentials are used for both buckets. It is ignored when either side is a local directory.
+	DestCredentials CredentialsInterface
+	// Multipart configures how objects at or above a size threshold are split into parts when downloading or
+	// uploading. The zero value uses the defaults.
+	Multipart MultipartOptions
+	// ManifestPath is where a download writes a manifest recording how the bucket was captured and the
+	// checksum and metadata of each object, which a later Rewind reads. An upload verifies each file against
+	// the checksum recorded in the manifest at this path, if there is one, and re-applies its metadata. When
+	// empty, no manifest is written or read. It is ignored for bucket-to-bucket syncs.
+	ManifestPath string
+	// Rehash revalidates the local copies that a download skips as up to date, against their recorded (or
+	// failing that, the object's) checksum. Copies that fail are downloaded again.
+	Rehash bool
+	// Filter selects which objects are synced, by their keys relative to the prefix (and so their paths
+	// relative to the local directory). Objects that it excludes are never transferred. A download prunes
+	// local copies of them like any other file that isn't part of the capture, but an upload or
+	// bucket-to-bucket sync leaves them in the destination bucket, so restoring a filtered backup doesn't
+	// delete the objects that were deliberately not captured. A download records the filter in its manifest,
+	// so that a rewind leaves the same objects alone. The zero value syncs everything.
+	Filter files.FileFilter
+	// ObjectLock protects the objects that an upload or bucket-to-bucket sync writes with S3 Object Lock.
+	// Every object written by the sync is retained until the same instant, counted from when the sync
+	// started. It is ignored for downloads. The zero value locks nothing.
+	ObjectLock ObjectLock
+}
+
+// RewindOptions are the optional parameters for rewinding a bucket.
+type RewindOptions struct {
+	// DryRun plans the changes without making them.
+	DryRun bool
+	// Limits caps the rate that objects are restored or deleted at. Only the files per second limit
+	// applies, as the restores are server-side copies. The zero value is unlimited.
+	Limits throttle.Limits
+}
+
+// RewindAction is the change made to a single object when rewinding a bucket.
+type RewindAction string
+
+const (
+	// RewindActionRestore makes a historical version of the object its current version again.
+	RewindActionRestore RewindAction = "restore"
+	// RewindActionDelete deletes an object that did not exist at the rewind instant.
+	RewindActionDelete RewindAction = "delete"
+)
+
+// RewindChange is a single object change made (or planned, for a dry run) when rewinding a bucket.
+type RewindChange struct {
+	Key    string
+	Action RewindAction
+	// VersionID is the version made current by a restore. It is empty for a delete.
+	VersionID string
+}
+
 // Represents a place (i.e. local or remote) where commands can run.
 type Runtime interface {
-	// Sync copies objects from src to dest. Exactly one of src/dest is an s3://bucket/prefix URL and the
-	// other is a local directory path. asOf is the event's shared consistency point: on a download
-	// (s3 -> local) a non-zero asOf captures the bucket as of that instant (point-in-time) rather than its
-	// latest state, provided the bucket has versioning enabled; a zero asOf (and every upload) is a
-	// latest-state sync.
-	Sync(ctx *contexts.Context, credentials CredentialsInterface, src string, dest string, asOf time.Time) error
+	// Sync copies objects from src to dest. At least one of src/dest is an s3://bucket/prefix URL, and the
+	// other is either a local directory path or another s3:// URL (a bucket-to-bucket mirror, authenticated
+	// to the destination with opts.DestCredentials). asOf is the event's shared consistency point: when the
+	// source is a bucket (a download or a mirror), a non-zero asOf captures it as of that instant
+	// (point-in-time) rather than its latest state, provided the bucket has versioning enabled; a zero asOf
+	// (and every upload) is a latest-state sync.
+	Sync(ctx *contexts.Context, credentials CredentialsInterface, src string, dest string, asOf time.Time, opts SyncOptions) error
+	// Rewind rolls the s3://bucket/prefix path back in place to the instant that it was captured as of, as
+	// recorded by the manifest at manifestPath (written by a point-in-time download). Each object's version
+	// current at that instant is made current again, and objects created since are deleted. Objects whose
+	// current version already has the contents of that version are left alone, so repeating a rewind changes
+	// nothing. The changes are returned in key order; with opts.DryRun they are only planned.
+	Rewind(ctx *contexts.Context, credentials CredentialsInterface, path string, manifestPath string, opts RewindOptions) ([]RewindChange, error)
 }
 
 // s3API is the subset of the aws-sdk-go-v2 *s3.Client used by the sync engine. It exists as a seam for
 // unit testing and is satisfied by *s3.Client (and by the paginators, which accept this interface).
-// Objects are transferred with a single streaming GetObject/PutObject each; downloads have no size limit,
-// while a single PutObject caps an uploaded object at 5 GiB (well beyond DR media/audit-log object sizes).
+// Objects below the multipart threshold are transferred with a single streaming GetObject/PutObject each,
+// and larger objects in parts (see MultipartOptions). Mirrored objects are transferred the same way, with
+// server-side CopyObject and UploadPartCopy requests in place of the uploads when both buckets are behind the
+// same endpoint and accessed with the same credentials.
 type s3API interface {
+	AbortMultipartUpload(context.Context, *s3.AbortMultipartUploadInput, ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	CompleteMultipartUpload(context.Context, *s3.CompleteMultipartUploadInput, ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	CopyObject(context.Context, *s3.CopyObjectInput, ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+	CreateMultipartUpload(context.Context, *s3.CreateMultipartUploadInput, ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
 	GetBucketVersioning(context.Context, *s3.GetBucketVersioningInput, ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error)
+	GetObjectLockConfiguration(context.Context, *s3.GetObjectLockConfigurationInput, ...func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error)
+	GetObjectTagging(context.Context, *s3.GetObjectTaggingInput, ...func(*s3.Options)) (*s3.GetObjectTaggingOutput, error)
 	ListObjectsV2(context.Context, *s3.ListObjectsV2Input, ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
 	ListObjectVersions(context.Context, *s3.ListObjectVersionsInput, ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
 	GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(context.Context, *s3.HeadObjectInput, ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
 	PutObject(context.Context, *s3.PutObjectInput, ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	PutObjectLegalHold(context.Context, *s3.PutObjectLegalHoldInput, ...func(*s3.Options)) (*s3.PutObjectLegalHoldOutput, error)
+	PutObjectRetention(context.Context, *s3.PutObjectRetentionInput, ...func(*s3.Options)) (*s3.PutObjectRetentionOutput, error)
 	DeleteObject(context.Context, *s3.DeleteObjectInput, ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	UploadPart(context.Context, *s3.UploadPartInput, ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	UploadPartCopy(context.Context, *s3.UploadPartCopyInput, ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error)
 }
 
 // newS3ClientFunc builds an s3API from a config and client options. It is a field on LocalRuntime so tests
@@ -22,17 +22,79 @@ func (_m *MockRuntime) EXPECT() *MockRuntime_Expecter {
 	return &MockRuntime_Expecter{mock: &_m.Mock}
 }
 
-// Sync provides a mock function with given fields: ctx, credentials, src, dest, asOf
-func (_m *MockRuntime) Sync(ctx *contexts.Context, credentials CredentialsInterface, src string, dest string, asOf time.Time) error {
-	ret := _m.Called(ctx, credentials, src, dest, asOf)
+// Rewind provides a mock function with given fields: ctx, credentials, path, manifestPath, opts
+func (_m *MockRuntime) Rewind(ctx *contexts.Context, credentials CredentialsInterface, path string, manifestPath string, opts RewindOptions) ([]RewindChange, error) {
+	ret := _m.Called(ctx, credentials, path, manifestPath, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rewind")
+	}
+
+	var r0 []RewindChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contexts.Context, CredentialsInterface, string, string, RewindOptions) ([]RewindChange, error)); ok {
+		return rf(ctx, credentials, path, manifestPath, opts)
+	}
+	if rf, ok := ret.Get(0).(func(*contexts.Context, CredentialsInterface, string, string, RewindOptions) []RewindChange); ok {
+		r0 = rf(ctx, credentials, path, manifestPath, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]RewindChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*contexts.Context, CredentialsInterface, string, string, RewindOptions) error); ok {
+		r1 = rf(ctx, credentials, path, manifestPath, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRuntime_Rewind_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rewind'
+type MockRuntime_Rewind_Call struct {
+	*mock.Call
+}
+
+// Rewind is a helper method to define mock.On call
+//   - ctx *contexts.Context
+//   - credentials CredentialsInterface
+//   - path string
+//   - manifestPath string
+//   - opts RewindOptions
+func (_e *MockRuntime_Expecter) Rewind(ctx interface{}, credentials interface{}, path interface{}, manifestPath interface{}, opts interface{}) *MockRuntime_Rewind_Call {
+	return &MockRuntime_Rewind_Call{Call: _e.mock.On("Rewind", ctx, credentials, path, manifestPath, opts)}
+}
+
+func (_c *MockRuntime_Rewind_Call) Run(run func(ctx *contexts.Context, credentials CredentialsInterface, path string, manifestPath string, opts RewindOptions)) *MockRuntime_Rewind_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contexts.Context), args[1].(CredentialsInterface), args[2].(string), args[3].(string), args[4].(RewindOptions))
+	})
+	return _c
+}
+
+func (_c *MockRuntime_Rewind_Call) Return(_a0 []RewindChange, _a1 error) *MockRuntime_Rewind_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRuntime_Rewind_Call) RunAndReturn(run func(*contexts.Context, CredentialsInterface, string, string, RewindOptions) ([]RewindChange, error)) *MockRuntime_Rewind_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sync provides a mock function with given fields: ctx, credentials, src, dest, asOf, opts
+func (_m *MockRuntime) Sync(ctx *contexts.Context, credentials CredentialsInterface, src string, dest string, asOf time.Time, opts SyncOptions) error {
+	ret := _m.Called(ctx, credentials, src, dest, asOf, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Sync")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*contexts.Context, CredentialsInterface, string, string, time.Time) error); ok {
-		r0 = rf(ctx, credentials, src, dest, asOf)
+	if rf, ok := ret.Get(0).(func(*contexts.Context, CredentialsInterface, string, string, time.Time, SyncOptions) error); ok {
+		r0 = rf(ctx, credentials, src, dest, asOf, opts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,13 +113,14 @@ type MockRuntime_Sync_Call struct {
 //   - src string
 //   - dest string
 //   - asOf time.Time
-func (_e *MockRuntime_Expecter) Sync(ctx interface{}, credentials interface{}, src interface{}, dest interface{}, asOf interface{}) *MockRuntime_Sync_Call {
-	return &MockRuntime_Sync_Call{Call: _e.mock.On("Sync", ctx, credentials, src, dest, asOf)}
+//   - opts SyncOptions
+func (_e *MockRuntime_Expecter) Sync(ctx interface{}, credentials interface{}, src interface{}, dest interface{}, asOf interface{}, opts interface{}) *MockRuntime_Sync_Call {
+	return &MockRuntime_Sync_Call{Call: _e.mock.On("Sync", ctx, credentials, src, dest, asOf, opts)}
 }
 
-func (_c *MockRuntime_Sync_Call) Run(run func(ctx *contexts.Context, credentials CredentialsInterface, src string, dest string, asOf time.Time)) *MockRuntime_Sync_Call {
+func (_c *MockRuntime_Sync_Call) Run(run func(ctx *contexts.Context, credentials CredentialsInterface, src string, dest string, asOf time.Time, opts SyncOptions)) *MockRuntime_Sync_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*contexts.Context), args[1].(CredentialsInterface), args[2].(string), args[3].(string), args[4].(time.Time))
+		run(args[0].(*contexts.Context), args[1].(CredentialsInterface), args[2].(string), args[3].(string), args[4].(time.Time), args[5].(SyncOptions))
 	})
 	return _c
 }
@@ -67,7 +130,7 @@ func (_c *MockRuntime_Sync_Call) Return(_a0 error) *MockRuntime_Sync_Call {
 	return _c
 }
 
-func (_c *MockRuntime_Sync_Call) RunAndReturn(run func(*contexts.Context, CredentialsInterface, string, string, time.Time) error) *MockRuntime_Sync_Call {
+func (_c *MockRuntime_Sync_Call) RunAndReturn(run func(*contexts.Context, CredentialsInterface, string, string, time.Time, SyncOptions) error) *MockRuntime_Sync_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -22,6 +22,302 @@ func (_m *Mocks3API) EXPECT() *Mocks3API_Expecter {
 	return &Mocks3API_Expecter{mock: &_m.Mock}
 }
 
+// AbortMultipartUpload provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) AbortMultipartUpload(_a0 context.Context, _a1 *services3.AbortMultipartUploadInput, _a2 ...func(*services3.Options)) (*services3.AbortMultipartUploadOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AbortMultipartUpload")
+	}
+
+	var r0 *services3.AbortMultipartUploadOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.AbortMultipartUploadInput, ...func(*services3.Options)) (*services3.AbortMultipartUploadOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.AbortMultipartUploadInput, ...func(*services3.Options)) *services3.AbortMultipartUploadOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.AbortMultipartUploadOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.AbortMultipartUploadInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_AbortMultipartUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AbortMultipartUpload'
+type Mocks3API_AbortMultipartUpload_Call struct {
+	*mock.Call
+}
+
+// AbortMultipartUpload is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.AbortMultipartUploadInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) AbortMultipartUpload(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_AbortMultipartUpload_Call {
+	return &Mocks3API_AbortMultipartUpload_Call{Call: _e.mock.On("AbortMultipartUpload",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_AbortMultipartUpload_Call) Run(run func(_a0 context.Context, _a1 *services3.AbortMultipartUploadInput, _a2 ...func(*services3.Options))) *Mocks3API_AbortMultipartUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.AbortMultipartUploadInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_AbortMultipartUpload_Call) Return(_a0 *services3.AbortMultipartUploadOutput, _a1 error) *Mocks3API_AbortMultipartUpload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_AbortMultipartUpload_Call) RunAndReturn(run func(context.Context, *services3.AbortMultipartUploadInput, ...func(*services3.Options)) (*services3.AbortMultipartUploadOutput, error)) *Mocks3API_AbortMultipartUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteMultipartUpload provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) CompleteMultipartUpload(_a0 context.Context, _a1 *services3.CompleteMultipartUploadInput, _a2 ...func(*services3.Options)) (*services3.CompleteMultipartUploadOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteMultipartUpload")
+	}
+
+	var r0 *services3.CompleteMultipartUploadOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.CompleteMultipartUploadInput, ...func(*services3.Options)) (*services3.CompleteMultipartUploadOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.CompleteMultipartUploadInput, ...func(*services3.Options)) *services3.CompleteMultipartUploadOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.CompleteMultipartUploadOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.CompleteMultipartUploadInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_CompleteMultipartUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteMultipartUpload'
+type Mocks3API_CompleteMultipartUpload_Call struct {
+	*mock.Call
+}
+
+// CompleteMultipartUpload is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.CompleteMultipartUploadInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) CompleteMultipartUpload(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_CompleteMultipartUpload_Call {
+	return &Mocks3API_CompleteMultipartUpload_Call{Call: _e.mock.On("CompleteMultipartUpload",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_CompleteMultipartUpload_Call) Run(run func(_a0 context.Context, _a1 *services3.CompleteMultipartUploadInput, _a2 ...func(*services3.Options))) *Mocks3API_CompleteMultipartUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.CompleteMultipartUploadInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_CompleteMultipartUpload_Call) Return(_a0 *services3.CompleteMultipartUploadOutput, _a1 error) *Mocks3API_CompleteMultipartUpload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_CompleteMultipartUpload_Call) RunAndReturn(run func(context.Context, *services3.CompleteMultipartUploadInput, ...func(*services3.Options)) (*services3.CompleteMultipartUploadOutput, error)) *Mocks3API_CompleteMultipartUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CopyObject provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) CopyObject(_a0 context.Context, _a1 *services3.CopyObjectInput, _a2 ...func(*services3.Options)) (*services3.CopyObjectOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CopyObject")
+	}
+
+	var r0 *services3.CopyObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.CopyObjectInput, ...func(*services3.Options)) (*services3.CopyObjectOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.CopyObjectInput, ...func(*services3.Options)) *services3.CopyObjectOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.CopyObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.CopyObjectInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_CopyObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CopyObject'
+type Mocks3API_CopyObject_Call struct {
+	*mock.Call
+}
+
+// CopyObject is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.CopyObjectInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) CopyObject(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_CopyObject_Call {
+	return &Mocks3API_CopyObject_Call{Call: _e.mock.On("CopyObject",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_CopyObject_Call) Run(run func(_a0 context.Context, _a1 *services3.CopyObjectInput, _a2 ...func(*services3.Options))) *Mocks3API_CopyObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.CopyObjectInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_CopyObject_Call) Return(_a0 *services3.CopyObjectOutput, _a1 error) *Mocks3API_CopyObject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_CopyObject_Call) RunAndReturn(run func(context.Context, *services3.CopyObjectInput, ...func(*services3.Options)) (*services3.CopyObjectOutput, error)) *Mocks3API_CopyObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMultipartUpload provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) CreateMultipartUpload(_a0 context.Context, _a1 *services3.CreateMultipartUploadInput, _a2 ...func(*services3.Options)) (*services3.CreateMultipartUploadOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMultipartUpload")
+	}
+
+	var r0 *services3.CreateMultipartUploadOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.CreateMultipartUploadInput, ...func(*services3.Options)) (*services3.CreateMultipartUploadOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.CreateMultipartUploadInput, ...func(*services3.Options)) *services3.CreateMultipartUploadOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.CreateMultipartUploadOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.CreateMultipartUploadInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_CreateMultipartUpload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMultipartUpload'
+type Mocks3API_CreateMultipartUpload_Call struct {
+	*mock.Call
+}
+
+// CreateMultipartUpload is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.CreateMultipartUploadInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) CreateMultipartUpload(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_CreateMultipartUpload_Call {
+	return &Mocks3API_CreateMultipartUpload_Call{Call: _e.mock.On("CreateMultipartUpload",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_CreateMultipartUpload_Call) Run(run func(_a0 context.Context, _a1 *services3.CreateMultipartUploadInput, _a2 ...func(*services3.Options))) *Mocks3API_CreateMultipartUpload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.CreateMultipartUploadInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_CreateMultipartUpload_Call) Return(_a0 *services3.CreateMultipartUploadOutput, _a1 error) *Mocks3API_CreateMultipartUpload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_CreateMultipartUpload_Call) RunAndReturn(run func(context.Context, *services3.CreateMultipartUploadInput, ...func(*services3.Options)) (*services3.CreateMultipartUploadOutput, error)) *Mocks3API_CreateMultipartUpload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteObject provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Mocks3API) DeleteObject(_a0 context.Context, _a1 *services3.DeleteObjectInput, _a2 ...func(*services3.Options)) (*services3.DeleteObjectOutput, error) {
 	_va := make([]interface{}, len(_a2))
@@ -34,23 +330,467 @@ func (_m *Mocks3API) DeleteObject(_a0 context.Context, _a1 *services3.DeleteObje
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteObject")
+		panic("no return value specified for DeleteObject")
+	}
+
+	var r0 *services3.DeleteObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.DeleteObjectInput, ...func(*services3.Options)) (*services3.DeleteObjectOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.DeleteObjectInput, ...func(*services3.Options)) *services3.DeleteObjectOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.DeleteObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.DeleteObjectInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_DeleteObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteObject'
+type Mocks3API_DeleteObject_Call struct {
+	*mock.Call
+}
+
+// DeleteObject is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.DeleteObjectInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) DeleteObject(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_DeleteObject_Call {
+	return &Mocks3API_DeleteObject_Call{Call: _e.mock.On("DeleteObject",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_DeleteObject_Call) Run(run func(_a0 context.Context, _a1 *services3.DeleteObjectInput, _a2 ...func(*services3.Options))) *Mocks3API_DeleteObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.DeleteObjectInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_DeleteObject_Call) Return(_a0 *services3.DeleteObjectOutput, _a1 error) *Mocks3API_DeleteObject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_DeleteObject_Call) RunAndReturn(run func(context.Context, *services3.DeleteObjectInput, ...func(*services3.Options)) (*services3.DeleteObjectOutput, error)) *Mocks3API_DeleteObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBucketVersioning provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) GetBucketVersioning(_a0 context.Context, _a1 *services3.GetBucketVersioningInput, _a2 ...func(*services3.Options)) (*services3.GetBucketVersioningOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBucketVersioning")
+	}
+
+	var r0 *services3.GetBucketVersioningOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetBucketVersioningInput, ...func(*services3.Options)) (*services3.GetBucketVersioningOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetBucketVersioningInput, ...func(*services3.Options)) *services3.GetBucketVersioningOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.GetBucketVersioningOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.GetBucketVersioningInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_GetBucketVersioning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBucketVersioning'
+type Mocks3API_GetBucketVersioning_Call struct {
+	*mock.Call
+}
+
+// GetBucketVersioning is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.GetBucketVersioningInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) GetBucketVersioning(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_GetBucketVersioning_Call {
+	return &Mocks3API_GetBucketVersioning_Call{Call: _e.mock.On("GetBucketVersioning",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_GetBucketVersioning_Call) Run(run func(_a0 context.Context, _a1 *services3.GetBucketVersioningInput, _a2 ...func(*services3.Options))) *Mocks3API_GetBucketVersioning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.GetBucketVersioningInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_GetBucketVersioning_Call) Return(_a0 *services3.GetBucketVersioningOutput, _a1 error) *Mocks3API_GetBucketVersioning_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_GetBucketVersioning_Call) RunAndReturn(run func(context.Context, *services3.GetBucketVersioningInput, ...func(*services3.Options)) (*services3.GetBucketVersioningOutput, error)) *Mocks3API_GetBucketVersioning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetObject provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) GetObject(_a0 context.Context, _a1 *services3.GetObjectInput, _a2 ...func(*services3.Options)) (*services3.GetObjectOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObject")
+	}
+
+	var r0 *services3.GetObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetObjectInput, ...func(*services3.Options)) (*services3.GetObjectOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetObjectInput, ...func(*services3.Options)) *services3.GetObjectOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.GetObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.GetObjectInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_GetObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetObject'
+type Mocks3API_GetObject_Call struct {
+	*mock.Call
+}
+
+// GetObject is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.GetObjectInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) GetObject(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_GetObject_Call {
+	return &Mocks3API_GetObject_Call{Call: _e.mock.On("GetObject",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_GetObject_Call) Run(run func(_a0 context.Context, _a1 *services3.GetObjectInput, _a2 ...func(*services3.Options))) *Mocks3API_GetObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.GetObjectInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_GetObject_Call) Return(_a0 *services3.GetObjectOutput, _a1 error) *Mocks3API_GetObject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_GetObject_Call) RunAndReturn(run func(context.Context, *services3.GetObjectInput, ...func(*services3.Options)) (*services3.GetObjectOutput, error)) *Mocks3API_GetObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetObjectLockConfiguration provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) GetObjectLockConfiguration(_a0 context.Context, _a1 *services3.GetObjectLockConfigurationInput, _a2 ...func(*services3.Options)) (*services3.GetObjectLockConfigurationOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObjectLockConfiguration")
+	}
+
+	var r0 *services3.GetObjectLockConfigurationOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetObjectLockConfigurationInput, ...func(*services3.Options)) (*services3.GetObjectLockConfigurationOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetObjectLockConfigurationInput, ...func(*services3.Options)) *services3.GetObjectLockConfigurationOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.GetObjectLockConfigurationOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.GetObjectLockConfigurationInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_GetObjectLockConfiguration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetObjectLockConfiguration'
+type Mocks3API_GetObjectLockConfiguration_Call struct {
+	*mock.Call
+}
+
+// GetObjectLockConfiguration is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.GetObjectLockConfigurationInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) GetObjectLockConfiguration(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_GetObjectLockConfiguration_Call {
+	return &Mocks3API_GetObjectLockConfiguration_Call{Call: _e.mock.On("GetObjectLockConfiguration",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_GetObjectLockConfiguration_Call) Run(run func(_a0 context.Context, _a1 *services3.GetObjectLockConfigurationInput, _a2 ...func(*services3.Options))) *Mocks3API_GetObjectLockConfiguration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.GetObjectLockConfigurationInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_GetObjectLockConfiguration_Call) Return(_a0 *services3.GetObjectLockConfigurationOutput, _a1 error) *Mocks3API_GetObjectLockConfiguration_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_GetObjectLockConfiguration_Call) RunAndReturn(run func(context.Context, *services3.GetObjectLockConfigurationInput, ...func(*services3.Options)) (*services3.GetObjectLockConfigurationOutput, error)) *Mocks3API_GetObjectLockConfiguration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetObjectTagging provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) GetObjectTagging(_a0 context.Context, _a1 *services3.GetObjectTaggingInput, _a2 ...func(*services3.Options)) (*services3.GetObjectTaggingOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObjectTagging")
+	}
+
+	var r0 *services3.GetObjectTaggingOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetObjectTaggingInput, ...func(*services3.Options)) (*services3.GetObjectTaggingOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetObjectTaggingInput, ...func(*services3.Options)) *services3.GetObjectTaggingOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.GetObjectTaggingOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.GetObjectTaggingInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_GetObjectTagging_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetObjectTagging'
+type Mocks3API_GetObjectTagging_Call struct {
+	*mock.Call
+}
+
+// GetObjectTagging is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.GetObjectTaggingInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) GetObjectTagging(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_GetObjectTagging_Call {
+	return &Mocks3API_GetObjectTagging_Call{Call: _e.mock.On("GetObjectTagging",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_GetObjectTagging_Call) Run(run func(_a0 context.Context, _a1 *services3.GetObjectTaggingInput, _a2 ...func(*services3.Options))) *Mocks3API_GetObjectTagging_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.GetObjectTaggingInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_GetObjectTagging_Call) Return(_a0 *services3.GetObjectTaggingOutput, _a1 error) *Mocks3API_GetObjectTagging_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_GetObjectTagging_Call) RunAndReturn(run func(context.Context, *services3.GetObjectTaggingInput, ...func(*services3.Options)) (*services3.GetObjectTaggingOutput, error)) *Mocks3API_GetObjectTagging_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HeadObject provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) HeadObject(_a0 context.Context, _a1 *services3.HeadObjectInput, _a2 ...func(*services3.Options)) (*services3.HeadObjectOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeadObject")
+	}
+
+	var r0 *services3.HeadObjectOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.HeadObjectInput, ...func(*services3.Options)) (*services3.HeadObjectOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.HeadObjectInput, ...func(*services3.Options)) *services3.HeadObjectOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.HeadObjectOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.HeadObjectInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_HeadObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HeadObject'
+type Mocks3API_HeadObject_Call struct {
+	*mock.Call
+}
+
+// HeadObject is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.HeadObjectInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) HeadObject(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_HeadObject_Call {
+	return &Mocks3API_HeadObject_Call{Call: _e.mock.On("HeadObject",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_HeadObject_Call) Run(run func(_a0 context.Context, _a1 *services3.HeadObjectInput, _a2 ...func(*services3.Options))) *Mocks3API_HeadObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.HeadObjectInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_HeadObject_Call) Return(_a0 *services3.HeadObjectOutput, _a1 error) *Mocks3API_HeadObject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_HeadObject_Call) RunAndReturn(run func(context.Context, *services3.HeadObjectInput, ...func(*services3.Options)) (*services3.HeadObjectOutput, error)) *Mocks3API_HeadObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListObjectVersions provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) ListObjectVersions(_a0 context.Context, _a1 *services3.ListObjectVersionsInput, _a2 ...func(*services3.Options)) (*services3.ListObjectVersionsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListObjectVersions")
 	}
 
-	var r0 *services3.DeleteObjectOutput
+	var r0 *services3.ListObjectVersionsOutput
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.DeleteObjectInput, ...func(*services3.Options)) (*services3.DeleteObjectOutput, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.ListObjectVersionsInput, ...func(*services3.Options)) (*services3.ListObjectVersionsOutput, error)); ok {
 		return rf(_a0, _a1, _a2...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.DeleteObjectInput, ...func(*services3.Options)) *services3.DeleteObjectOutput); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.ListObjectVersionsInput, ...func(*services3.Options)) *services3.ListObjectVersionsOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*services3.DeleteObjectOutput)
+			r0 = ret.Get(0).(*services3.ListObjectVersionsOutput)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *services3.DeleteObjectInput, ...func(*services3.Options)) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.ListObjectVersionsInput, ...func(*services3.Options)) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -59,21 +799,21 @@ func (_m *Mocks3API) DeleteObject(_a0 context.Context, _a1 *services3.DeleteObje
 	return r0, r1
 }
 
-// Mocks3API_DeleteObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteObject'
-type Mocks3API_DeleteObject_Call struct {
+// Mocks3API_ListObjectVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListObjectVersions'
+type Mocks3API_ListObjectVersions_Call struct {
 	*mock.Call
 }
 
-// DeleteObject is a helper method to define mock.On call
+// ListObjectVersions is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *services3.DeleteObjectInput
+//   - _a1 *services3.ListObjectVersionsInput
 //   - _a2 ...func(*services3.Options)
-func (_e *Mocks3API_Expecter) DeleteObject(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_DeleteObject_Call {
-	return &Mocks3API_DeleteObject_Call{Call: _e.mock.On("DeleteObject",
+func (_e *Mocks3API_Expecter) ListObjectVersions(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_ListObjectVersions_Call {
+	return &Mocks3API_ListObjectVersions_Call{Call: _e.mock.On("ListObjectVersions",
 		append([]interface{}{_a0, _a1}, _a2...)...)}
 }
 
-func (_c *Mocks3API_DeleteObject_Call) Run(run func(_a0 context.Context, _a1 *services3.DeleteObjectInput, _a2 ...func(*services3.Options))) *Mocks3API_DeleteObject_Call {
+func (_c *Mocks3API_ListObjectVersions_Call) Run(run func(_a0 context.Context, _a1 *services3.ListObjectVersionsInput, _a2 ...func(*services3.Options))) *Mocks3API_ListObjectVersions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]func(*services3.Options), len(args)-2)
 		for i, a := range args[2:] {
@@ -81,23 +821,23 @@ func (_c *Mocks3API_DeleteObject_Call) Run(run func(_a0 context.Context, _a1 *se
 				variadicArgs[i] = a.(func(*services3.Options))
 			}
 		}
-		run(args[0].(context.Context), args[1].(*services3.DeleteObjectInput), variadicArgs...)
+		run(args[0].(context.Context), args[1].(*services3.ListObjectVersionsInput), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Mocks3API_DeleteObject_Call) Return(_a0 *services3.DeleteObjectOutput, _a1 error) *Mocks3API_DeleteObject_Call {
+func (_c *Mocks3API_ListObjectVersions_Call) Return(_a0 *services3.ListObjectVersionsOutput, _a1 error) *Mocks3API_ListObjectVersions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Mocks3API_DeleteObject_Call) RunAndReturn(run func(context.Context, *services3.DeleteObjectInput, ...func(*services3.Options)) (*services3.DeleteObjectOutput, error)) *Mocks3API_DeleteObject_Call {
+func (_c *Mocks3API_ListObjectVersions_Call) RunAndReturn(run func(context.Context, *services3.ListObjectVersionsInput, ...func(*services3.Options)) (*services3.ListObjectVersionsOutput, error)) *Mocks3API_ListObjectVersions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBucketVersioning provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Mocks3API) GetBucketVersioning(_a0 context.Context, _a1 *services3.GetBucketVersioningInput, _a2 ...func(*services3.Options)) (*services3.GetBucketVersioningOutput, error) {
+// ListObjectsV2 provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) ListObjectsV2(_a0 context.Context, _a1 *services3.ListObjectsV2Input, _a2 ...func(*services3.Options)) (*services3.ListObjectsV2Output, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -108,23 +848,23 @@ func (_m *Mocks3API) GetBucketVersioning(_a0 context.Context, _a1 *services3.Get
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBucketVersioning")
+		panic("no return value specified for ListObjectsV2")
 	}
 
-	var r0 *services3.GetBucketVersioningOutput
+	var r0 *services3.ListObjectsV2Output
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetBucketVersioningInput, ...func(*services3.Options)) (*services3.GetBucketVersioningOutput, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.ListObjectsV2Input, ...func(*services3.Options)) (*services3.ListObjectsV2Output, error)); ok {
 		return rf(_a0, _a1, _a2...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetBucketVersioningInput, ...func(*services3.Options)) *services3.GetBucketVersioningOutput); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.ListObjectsV2Input, ...func(*services3.Options)) *services3.ListObjectsV2Output); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*services3.GetBucketVersioningOutput)
+			r0 = ret.Get(0).(*services3.ListObjectsV2Output)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *services3.GetBucketVersioningInput, ...func(*services3.Options)) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.ListObjectsV2Input, ...func(*services3.Options)) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -133,21 +873,21 @@ func (_m *Mocks3API) GetBucketVersioning(_a0 context.Context, _a1 *services3.Get
 	return r0, r1
 }
 
-// Mocks3API_GetBucketVersioning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBucketVersioning'
-type Mocks3API_GetBucketVersioning_Call struct {
+// Mocks3API_ListObjectsV2_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListObjectsV2'
+type Mocks3API_ListObjectsV2_Call struct {
 	*mock.Call
 }
 
-// GetBucketVersioning is a helper method to define mock.On call
+// ListObjectsV2 is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *services3.GetBucketVersioningInput
+//   - _a1 *services3.ListObjectsV2Input
 //   - _a2 ...func(*services3.Options)
-func (_e *Mocks3API_Expecter) GetBucketVersioning(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_GetBucketVersioning_Call {
-	return &Mocks3API_GetBucketVersioning_Call{Call: _e.mock.On("GetBucketVersioning",
+func (_e *Mocks3API_Expecter) ListObjectsV2(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_ListObjectsV2_Call {
+	return &Mocks3API_ListObjectsV2_Call{Call: _e.mock.On("ListObjectsV2",
 		append([]interface{}{_a0, _a1}, _a2...)...)}
 }
 
-func (_c *Mocks3API_GetBucketVersioning_Call) Run(run func(_a0 context.Context, _a1 *services3.GetBucketVersioningInput, _a2 ...func(*services3.Options))) *Mocks3API_GetBucketVersioning_Call {
+func (_c *Mocks3API_ListObjectsV2_Call) Run(run func(_a0 context.Context, _a1 *services3.ListObjectsV2Input, _a2 ...func(*services3.Options))) *Mocks3API_ListObjectsV2_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]func(*services3.Options), len(args)-2)
 		for i, a := range args[2:] {
@@ -155,23 +895,23 @@ func (_c *Mocks3API_GetBucketVersioning_Call) Run(run func(_a0 context.Context,
 				variadicArgs[i] = a.(func(*services3.Options))
 			}
 		}
-		run(args[0].(context.Context), args[1].(*services3.GetBucketVersioningInput), variadicArgs...)
+		run(args[0].(context.Context), args[1].(*services3.ListObjectsV2Input), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Mocks3API_GetBucketVersioning_Call) Return(_a0 *services3.GetBucketVersioningOutput, _a1 error) *Mocks3API_GetBucketVersioning_Call {
+func (_c *Mocks3API_ListObjectsV2_Call) Return(_a0 *services3.ListObjectsV2Output, _a1 error) *Mocks3API_ListObjectsV2_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Mocks3API_GetBucketVersioning_Call) RunAndReturn(run func(context.Context, *services3.GetBucketVersioningInput, ...func(*services3.Options)) (*services3.GetBucketVersioningOutput, error)) *Mocks3API_GetBucketVersioning_Call {
+func (_c *Mocks3API_ListObjectsV2_Call) RunAndReturn(run func(context.Context, *services3.ListObjectsV2Input, ...func(*services3.Options)) (*services3.ListObjectsV2Output, error)) *Mocks3API_ListObjectsV2_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetObject provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Mocks3API) GetObject(_a0 context.Context, _a1 *services3.GetObjectInput, _a2 ...func(*services3.Options)) (*services3.GetObjectOutput, error) {
+// PutObject provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) PutObject(_a0 context.Context, _a1 *services3.PutObjectInput, _a2 ...func(*services3.Options)) (*services3.PutObjectOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -182,23 +922,23 @@ func (_m *Mocks3API) GetObject(_a0 context.Context, _a1 *services3.GetObjectInpu
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetObject")
+		panic("no return value specified for PutObject")
 	}
 
-	var r0 *services3.GetObjectOutput
+	var r0 *services3.PutObjectOutput
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetObjectInput, ...func(*services3.Options)) (*services3.GetObjectOutput, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.PutObjectInput, ...func(*services3.Options)) (*services3.PutObjectOutput, error)); ok {
 		return rf(_a0, _a1, _a2...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.GetObjectInput, ...func(*services3.Options)) *services3.GetObjectOutput); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.PutObjectInput, ...func(*services3.Options)) *services3.PutObjectOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*services3.GetObjectOutput)
+			r0 = ret.Get(0).(*services3.PutObjectOutput)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *services3.GetObjectInput, ...func(*services3.Options)) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.PutObjectInput, ...func(*services3.Options)) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -207,21 +947,21 @@ func (_m *Mocks3API) GetObject(_a0 context.Context, _a1 *services3.GetObjectInpu
 	return r0, r1
 }
 
-// Mocks3API_GetObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetObject'
-type Mocks3API_GetObject_Call struct {
+// Mocks3API_PutObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutObject'
+type Mocks3API_PutObject_Call struct {
 	*mock.Call
 }
 
-// GetObject is a helper method to define mock.On call
+// PutObject is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *services3.GetObjectInput
+//   - _a1 *services3.PutObjectInput
 //   - _a2 ...func(*services3.Options)
-func (_e *Mocks3API_Expecter) GetObject(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_GetObject_Call {
-	return &Mocks3API_GetObject_Call{Call: _e.mock.On("GetObject",
+func (_e *Mocks3API_Expecter) PutObject(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_PutObject_Call {
+	return &Mocks3API_PutObject_Call{Call: _e.mock.On("PutObject",
 		append([]interface{}{_a0, _a1}, _a2...)...)}
 }
 
-func (_c *Mocks3API_GetObject_Call) Run(run func(_a0 context.Context, _a1 *services3.GetObjectInput, _a2 ...func(*services3.Options))) *Mocks3API_GetObject_Call {
+func (_c *Mocks3API_PutObject_Call) Run(run func(_a0 context.Context, _a1 *services3.PutObjectInput, _a2 ...func(*services3.Options))) *Mocks3API_PutObject_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]func(*services3.Options), len(args)-2)
 		for i, a := range args[2:] {
@@ -229,23 +969,23 @@ func (_c *Mocks3API_GetObject_Call) Run(run func(_a0 context.Context, _a1 *servi
 				variadicArgs[i] = a.(func(*services3.Options))
 			}
 		}
-		run(args[0].(context.Context), args[1].(*services3.GetObjectInput), variadicArgs...)
+		run(args[0].(context.Context), args[1].(*services3.PutObjectInput), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Mocks3API_GetObject_Call) Return(_a0 *services3.GetObjectOutput, _a1 error) *Mocks3API_GetObject_Call {
+func (_c *Mocks3API_PutObject_Call) Return(_a0 *services3.PutObjectOutput, _a1 error) *Mocks3API_PutObject_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Mocks3API_GetObject_Call) RunAndReturn(run func(context.Context, *services3.GetObjectInput, ...func(*services3.Options)) (*services3.GetObjectOutput, error)) *Mocks3API_GetObject_Call {
+func (_c *Mocks3API_PutObject_Call) RunAndReturn(run func(context.Context, *services3.PutObjectInput, ...func(*services3.Options)) (*services3.PutObjectOutput, error)) *Mocks3API_PutObject_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListObjectVersions provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Mocks3API) ListObjectVersions(_a0 context.Context, _a1 *services3.ListObjectVersionsInput, _a2 ...func(*services3.Options)) (*services3.ListObjectVersionsOutput, error) {
+// PutObjectLegalHold provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) PutObjectLegalHold(_a0 context.Context, _a1 *services3.PutObjectLegalHoldInput, _a2 ...func(*services3.Options)) (*services3.PutObjectLegalHoldOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -256,23 +996,23 @@ func (_m *Mocks3API) ListObjectVersions(_a0 context.Context, _a1 *services3.List
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListObjectVersions")
+		panic("no return value specified for PutObjectLegalHold")
 	}
 
-	var r0 *services3.ListObjectVersionsOutput
+	var r0 *services3.PutObjectLegalHoldOutput
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.ListObjectVersionsInput, ...func(*services3.Options)) (*services3.ListObjectVersionsOutput, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.PutObjectLegalHoldInput, ...func(*services3.Options)) (*services3.PutObjectLegalHoldOutput, error)); ok {
 		return rf(_a0, _a1, _a2...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.ListObjectVersionsInput, ...func(*services3.Options)) *services3.ListObjectVersionsOutput); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.PutObjectLegalHoldInput, ...func(*services3.Options)) *services3.PutObjectLegalHoldOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*services3.ListObjectVersionsOutput)
+			r0 = ret.Get(0).(*services3.PutObjectLegalHoldOutput)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *services3.ListObjectVersionsInput, ...func(*services3.Options)) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.PutObjectLegalHoldInput, ...func(*services3.Options)) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -281,21 +1021,21 @@ func (_m *Mocks3API) ListObjectVersions(_a0 context.Context, _a1 *services3.List
 	return r0, r1
 }
 
-// Mocks3API_ListObjectVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListObjectVersions'
-type Mocks3API_ListObjectVersions_Call struct {
+// Mocks3API_PutObjectLegalHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutObjectLegalHold'
+type Mocks3API_PutObjectLegalHold_Call struct {
 	*mock.Call
 }
 
-// ListObjectVersions is a helper method to define mock.On call
+// PutObjectLegalHold is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *services3.ListObjectVersionsInput
+//   - _a1 *services3.PutObjectLegalHoldInput
 //   - _a2 ...func(*services3.Options)
-func (_e *Mocks3API_Expecter) ListObjectVersions(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_ListObjectVersions_Call {
-	return &Mocks3API_ListObjectVersions_Call{Call: _e.mock.On("ListObjectVersions",
+func (_e *Mocks3API_Expecter) PutObjectLegalHold(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_PutObjectLegalHold_Call {
+	return &Mocks3API_PutObjectLegalHold_Call{Call: _e.mock.On("PutObjectLegalHold",
 		append([]interface{}{_a0, _a1}, _a2...)...)}
 }
 
-func (_c *Mocks3API_ListObjectVersions_Call) Run(run func(_a0 context.Context, _a1 *services3.ListObjectVersionsInput, _a2 ...func(*services3.Options))) *Mocks3API_ListObjectVersions_Call {
+func (_c *Mocks3API_PutObjectLegalHold_Call) Run(run func(_a0 context.Context, _a1 *services3.PutObjectLegalHoldInput, _a2 ...func(*services3.Options))) *Mocks3API_PutObjectLegalHold_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]func(*services3.Options), len(args)-2)
 		for i, a := range args[2:] {
@@ -303,23 +1043,23 @@ func (_c *Mocks3API_ListObjectVersions_Call) Run(run func(_a0 context.Context, _
 				variadicArgs[i] = a.(func(*services3.Options))
 			}
 		}
-		run(args[0].(context.Context), args[1].(*services3.ListObjectVersionsInput), variadicArgs...)
+		run(args[0].(context.Context), args[1].(*services3.PutObjectLegalHoldInput), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Mocks3API_ListObjectVersions_Call) Return(_a0 *services3.ListObjectVersionsOutput, _a1 error) *Mocks3API_ListObjectVersions_Call {
+func (_c *Mocks3API_PutObjectLegalHold_Call) Return(_a0 *services3.PutObjectLegalHoldOutput, _a1 error) *Mocks3API_PutObjectLegalHold_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Mocks3API_ListObjectVersions_Call) RunAndReturn(run func(context.Context, *services3.ListObjectVersionsInput, ...func(*services3.Options)) (*services3.ListObjectVersionsOutput, error)) *Mocks3API_ListObjectVersions_Call {
+func (_c *Mocks3API_PutObjectLegalHold_Call) RunAndReturn(run func(context.Context, *services3.PutObjectLegalHoldInput, ...func(*services3.Options)) (*services3.PutObjectLegalHoldOutput, error)) *Mocks3API_PutObjectLegalHold_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListObjectsV2 provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Mocks3API) ListObjectsV2(_a0 context.Context, _a1 *services3.ListObjectsV2Input, _a2 ...func(*services3.Options)) (*services3.ListObjectsV2Output, error) {
+// PutObjectRetention provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) PutObjectRetention(_a0 context.Context, _a1 *services3.PutObjectRetentionInput, _a2 ...func(*services3.Options)) (*services3.PutObjectRetentionOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -330,23 +1070,23 @@ func (_m *Mocks3API) ListObjectsV2(_a0 context.Context, _a1 *services3.ListObjec
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListObjectsV2")
+		panic("no return value specified for PutObjectRetention")
 	}
 
-	var r0 *services3.ListObjectsV2Output
+	var r0 *services3.PutObjectRetentionOutput
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.ListObjectsV2Input, ...func(*services3.Options)) (*services3.ListObjectsV2Output, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.PutObjectRetentionInput, ...func(*services3.Options)) (*services3.PutObjectRetentionOutput, error)); ok {
 		return rf(_a0, _a1, _a2...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.ListObjectsV2Input, ...func(*services3.Options)) *services3.ListObjectsV2Output); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.PutObjectRetentionInput, ...func(*services3.Options)) *services3.PutObjectRetentionOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*services3.ListObjectsV2Output)
+			r0 = ret.Get(0).(*services3.PutObjectRetentionOutput)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *services3.ListObjectsV2Input, ...func(*services3.Options)) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.PutObjectRetentionInput, ...func(*services3.Options)) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -355,21 +1095,21 @@ func (_m *Mocks3API) ListObjectsV2(_a0 context.Context, _a1 *services3.ListObjec
 	return r0, r1
 }
 
-// Mocks3API_ListObjectsV2_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListObjectsV2'
-type Mocks3API_ListObjectsV2_Call struct {
+// Mocks3API_PutObjectRetention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutObjectRetention'
+type Mocks3API_PutObjectRetention_Call struct {
 	*mock.Call
 }
 
-// ListObjectsV2 is a helper method to define mock.On call
+// PutObjectRetention is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *services3.ListObjectsV2Input
+//   - _a1 *services3.PutObjectRetentionInput
 //   - _a2 ...func(*services3.Options)
-func (_e *Mocks3API_Expecter) ListObjectsV2(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_ListObjectsV2_Call {
-	return &Mocks3API_ListObjectsV2_Call{Call: _e.mock.On("ListObjectsV2",
+func (_e *Mocks3API_Expecter) PutObjectRetention(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_PutObjectRetention_Call {
+	return &Mocks3API_PutObjectRetention_Call{Call: _e.mock.On("PutObjectRetention",
 		append([]interface{}{_a0, _a1}, _a2...)...)}
 }
 
-func (_c *Mocks3API_ListObjectsV2_Call) Run(run func(_a0 context.Context, _a1 *services3.ListObjectsV2Input, _a2 ...func(*services3.Options))) *Mocks3API_ListObjectsV2_Call {
+func (_c *Mocks3API_PutObjectRetention_Call) Run(run func(_a0 context.Context, _a1 *services3.PutObjectRetentionInput, _a2 ...func(*services3.Options))) *Mocks3API_PutObjectRetention_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]func(*services3.Options), len(args)-2)
 		for i, a := range args[2:] {
@@ -377,23 +1117,23 @@ func (_c *Mocks3API_ListObjectsV2_Call) Run(run func(_a0 context.Context, _a1 *s
 				variadicArgs[i] = a.(func(*services3.Options))
 			}
 		}
-		run(args[0].(context.Context), args[1].(*services3.ListObjectsV2Input), variadicArgs...)
+		run(args[0].(context.Context), args[1].(*services3.PutObjectRetentionInput), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Mocks3API_ListObjectsV2_Call) Return(_a0 *services3.ListObjectsV2Output, _a1 error) *Mocks3API_ListObjectsV2_Call {
+func (_c *Mocks3API_PutObjectRetention_Call) Return(_a0 *services3.PutObjectRetentionOutput, _a1 error) *Mocks3API_PutObjectRetention_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Mocks3API_ListObjectsV2_Call) RunAndReturn(run func(context.Context, *services3.ListObjectsV2Input, ...func(*services3.Options)) (*services3.ListObjectsV2Output, error)) *Mocks3API_ListObjectsV2_Call {
+func (_c *Mocks3API_PutObjectRetention_Call) RunAndReturn(run func(context.Context, *services3.PutObjectRetentionInput, ...func(*services3.Options)) (*services3.PutObjectRetentionOutput, error)) *Mocks3API_PutObjectRetention_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PutObject provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Mocks3API) PutObject(_a0 context.Context, _a1 *services3.PutObjectInput, _a2 ...func(*services3.Options)) (*services3.PutObjectOutput, error) {
+// UploadPart provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) UploadPart(_a0 context.Context, _a1 *services3.UploadPartInput, _a2 ...func(*services3.Options)) (*services3.UploadPartOutput, error) {
 	_va := make([]interface{}, len(_a2))
 	for _i := range _a2 {
 		_va[_i] = _a2[_i]
@@ -404,23 +1144,23 @@ func (_m *Mocks3API) PutObject(_a0 context.Context, _a1 *services3.PutObjectInpu
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PutObject")
+		panic("no return value specified for UploadPart")
 	}
 
-	var r0 *services3.PutObjectOutput
+	var r0 *services3.UploadPartOutput
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.PutObjectInput, ...func(*services3.Options)) (*services3.PutObjectOutput, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.UploadPartInput, ...func(*services3.Options)) (*services3.UploadPartOutput, error)); ok {
 		return rf(_a0, _a1, _a2...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *services3.PutObjectInput, ...func(*services3.Options)) *services3.PutObjectOutput); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.UploadPartInput, ...func(*services3.Options)) *services3.UploadPartOutput); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*services3.PutObjectOutput)
+			r0 = ret.Get(0).(*services3.UploadPartOutput)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *services3.PutObjectInput, ...func(*services3.Options)) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.UploadPartInput, ...func(*services3.Options)) error); ok {
 		r1 = rf(_a0, _a1, _a2...)
 	} else {
 		r1 = ret.Error(1)
@@ -429,21 +1169,21 @@ func (_m *Mocks3API) PutObject(_a0 context.Context, _a1 *services3.PutObjectInpu
 	return r0, r1
 }
 
-// Mocks3API_PutObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutObject'
-type Mocks3API_PutObject_Call struct {
+// Mocks3API_UploadPart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadPart'
+type Mocks3API_UploadPart_Call struct {
 	*mock.Call
 }
 
-// PutObject is a helper method to define mock.On call
+// UploadPart is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *services3.PutObjectInput
+//   - _a1 *services3.UploadPartInput
 //   - _a2 ...func(*services3.Options)
-func (_e *Mocks3API_Expecter) PutObject(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_PutObject_Call {
-	return &Mocks3API_PutObject_Call{Call: _e.mock.On("PutObject",
+func (_e *Mocks3API_Expecter) UploadPart(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_UploadPart_Call {
+	return &Mocks3API_UploadPart_Call{Call: _e.mock.On("UploadPart",
 		append([]interface{}{_a0, _a1}, _a2...)...)}
 }
 
-func (_c *Mocks3API_PutObject_Call) Run(run func(_a0 context.Context, _a1 *services3.PutObjectInput, _a2 ...func(*services3.Options))) *Mocks3API_PutObject_Call {
+func (_c *Mocks3API_UploadPart_Call) Run(run func(_a0 context.Context, _a1 *services3.UploadPartInput, _a2 ...func(*services3.Options))) *Mocks3API_UploadPart_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]func(*services3.Options), len(args)-2)
 		for i, a := range args[2:] {
@@ -451,17 +1191,91 @@ func (_c *Mocks3API_PutObject_Call) Run(run func(_a0 context.Context, _a1 *servi
 				variadicArgs[i] = a.(func(*services3.Options))
 			}
 		}
-		run(args[0].(context.Context), args[1].(*services3.PutObjectInput), variadicArgs...)
+		run(args[0].(context.Context), args[1].(*services3.UploadPartInput), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *Mocks3API_PutObject_Call) Return(_a0 *services3.PutObjectOutput, _a1 error) *Mocks3API_PutObject_Call {
+func (_c *Mocks3API_UploadPart_Call) Return(_a0 *services3.UploadPartOutput, _a1 error) *Mocks3API_UploadPart_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Mocks3API_PutObject_Call) RunAndReturn(run func(context.Context, *services3.PutObjectInput, ...func(*services3.Options)) (*services3.PutObjectOutput, error)) *Mocks3API_PutObject_Call {
+func (_c *Mocks3API_UploadPart_Call) RunAndReturn(run func(context.Context, *services3.UploadPartInput, ...func(*services3.Options)) (*services3.UploadPartOutput, error)) *Mocks3API_UploadPart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadPartCopy provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Mocks3API) UploadPartCopy(_a0 context.Context, _a1 *services3.UploadPartCopyInput, _a2 ...func(*services3.Options)) (*services3.UploadPartCopyOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadPartCopy")
+	}
+
+	var r0 *services3.UploadPartCopyOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.UploadPartCopyInput, ...func(*services3.Options)) (*services3.UploadPartCopyOutput, error)); ok {
+		return rf(_a0, _a1, _a2...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services3.UploadPartCopyInput, ...func(*services3.Options)) *services3.UploadPartCopyOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services3.UploadPartCopyOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services3.UploadPartCopyInput, ...func(*services3.Options)) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mocks3API_UploadPartCopy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadPartCopy'
+type Mocks3API_UploadPartCopy_Call struct {
+	*mock.Call
+}
+
+// UploadPartCopy is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *services3.UploadPartCopyInput
+//   - _a2 ...func(*services3.Options)
+func (_e *Mocks3API_Expecter) UploadPartCopy(_a0 interface{}, _a1 interface{}, _a2 ...interface{}) *Mocks3API_UploadPartCopy_Call {
+	return &Mocks3API_UploadPartCopy_Call{Call: _e.mock.On("UploadPartCopy",
+		append([]interface{}{_a0, _a1}, _a2...)...)}
+}
+
+func (_c *Mocks3API_UploadPartCopy_Call) Run(run func(_a0 context.Context, _a1 *services3.UploadPartCopyInput, _a2 ...func(*services3.Options))) *Mocks3API_UploadPartCopy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(*services3.Options), len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(*services3.Options))
+			}
+		}
+		run(args[0].(context.Context), args[1].(*services3.UploadPartCopyInput), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Mocks3API_UploadPartCopy_Call) Return(_a0 *services3.UploadPartCopyOutput, _a1 error) *Mocks3API_UploadPartCopy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Mocks3API_UploadPartCopy_Call) RunAndReturn(run func(context.Context, *services3.UploadPartCopyInput, ...func(*services3.Options)) (*services3.UploadPartCopyOutput, error)) *Mocks3API_UploadPartCopy_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -34,3 +34,30 @@ func parseS3Path(raw string) (parsed s3Path, isS3 bool, err error) {
 		prefix: strings.TrimPrefix(u.Path, "/"),
 	}, true, nil
 }
+
+// String returns the path as an s3://bucket/prefix URL.
+func (p s3Path) String() string {
+	return (&url.URL{Scheme: "s3", Host: p.bucket, Path: "/" + p.prefix}).String()
+}
+
+// equals reports whether both paths refer to the same prefix, ignoring any trailing slash.
+func (p s3Path) equals(other s3Path) bool {
+	return p.bucket == other.bucket && strings.TrimSuffix(p.prefix, "/") == strings.TrimSuffix(other.prefix, "/")
+}
+
+// overlaps reports whether either path contains the other, so mirroring one to the other would copy (or
+// prune) objects that are also being read.
+func (p s3Path) overlaps(other s3Path) bool {
+	if p.bucket != other.bucket {
+		return false
+	}
+
+	return isKeyUnderPrefix(p.prefix, other.prefix) || isKeyUnderPrefix(other.prefix, p.prefix)
+}
+
+// isKeyUnderPrefix reports whether key is prefix, or is below prefix when it is treated as a directory.
+func isKeyUnderPrefix(key, prefix string) bool {
+	key = strings.TrimSuffix(key, "/")
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || key == prefix || strings.HasPrefix(key, prefix+"/")
+}
@@ -0,0 +1,63 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3PathOverlaps(t *testing.T) {
+	tests := []struct {
+		desc     string
+		a        s3Path
+		b        s3Path
+		overlaps bool
+	}{
+		{
+			desc: "different buckets",
+			a:    s3Path{bucket: "a", prefix: "x"},
+			b:    s3Path{bucket: "b", prefix: "x"},
+		},
+		{
+			desc:     "same prefix",
+			a:        s3Path{bucket: "a", prefix: "x"},
+			b:        s3Path{bucket: "a", prefix: "x/"},
+			overlaps: true,
+		},
+		{
+			desc:     "nested prefix",
+			a:        s3Path{bucket: "a", prefix: "x"},
+			b:        s3Path{bucket: "a", prefix: "x/y"},
+			overlaps: true,
+		},
+		{
+			desc:     "whole bucket",
+			a:        s3Path{bucket: "a"},
+			b:        s3Path{bucket: "a", prefix: "x"},
+			overlaps: true,
+		},
+		{
+			desc: "sibling prefixes sharing a name prefix",
+			a:    s3Path{bucket: "a", prefix: "x"},
+			b:    s3Path{bucket: "a", prefix: "xy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.overlaps, tt.a.overlaps(tt.b))
+			assert.Equal(t, tt.overlaps, tt.b.overlaps(tt.a))
+		})
+	}
+}
+
+func TestS3PathString(t *testing.T) {
+	assert.Equal(t, "s3://bucket/prefix/sub", s3Path{bucket: "bucket", prefix: "prefix/sub"}.String())
+	assert.Equal(t, "s3://bucket/", s3Path{bucket: "bucket"}.String())
+}
+
+func TestS3PathEquals(t *testing.T) {
+	assert.True(t, s3Path{bucket: "a", prefix: "x"}.equals(s3Path{bucket: "a", prefix: "x/"}))
+	assert.False(t, s3Path{bucket: "a", prefix: "x"}.equals(s3Path{bucket: "b", prefix: "x"}))
+	assert.False(t, s3Path{bucket: "a", prefix: "x"}.equals(s3Path{bucket: "a", prefix: "x/y"}))
+}
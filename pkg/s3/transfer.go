@@ -5,18 +5,24 @@ import (
 	"io/fs"
 	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/gravitational/trace"
 	"github.com/solidDoWant/backup-tool/pkg/cleanup"
 	"github.com/solidDoWant/backup-tool/pkg/contexts"
+	"github.com/solidDoWant/backup-tool/pkg/files"
+	"github.com/solidDoWant/backup-tool/pkg/throttle"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -29,6 +35,7 @@ type remoteObject struct {
 	key          string // full S3 key
 	relPath      string // key relative to the prefix, slash-separated; the path under the local directory
 	versionID    *string
+	etag         string
 	size         int64
 	lastModified time.Time
 }
@@ -36,17 +43,29 @@ type remoteObject struct {
 // Sync makes the destination an exact mirror of the source: changed/new objects are transferred and items
 // missing from the source are removed. See Runtime.Sync for the asOf semantics and selectObjectsAsOf for
 // the point-in-time reconstruction.
-func (lr *LocalRuntime) Sync(ctx *contexts.Context, credentials CredentialsInterface, src, dest string, asOf time.Time) (err error) {
+func (lr *LocalRuntime) Sync(ctx *contexts.Context, credentials CredentialsInterface, src, dest string, asOf time.Time, opts SyncOptions) (err error) {
+	limiter := throttle.NewLimiter(opts.Limits)
 	ctx.Log.With("src", src, "dest", dest).Info("Syncing files")
-	defer ctx.Log.Info("Finished syncing files", ctx.Stopwatch.Keyval(), contexts.ErrorKeyvals(&err))
+	defer ctx.Log.Info("Finished syncing files", ctx.Stopwatch.Keyval(), limiter, contexts.ErrorKeyvals(&err))
 
-	client := lr.newS3Client(credentials.AWSConfig(), func(o *s3.Options) {
-		// Endpoint and path-style are S3 client options in the v2 SDK rather than fields on aws.Config.
-		if endpoint := credentials.GetEndpoint(); endpoint != "" {
-			o.BaseEndpoint = aws.String(endpoint)
-		}
-		o.UsePathStyle = credentials.GetS3ForcePathStyle()
-	})
+	if err := opts.Limits.Validate(); err != nil {
+		return trace.Wrap(err, "invalid rate limits")
+	}
+
+	if err := opts.Multipart.Validate(); err != nil {
+		return trace.Wrap(err, "invalid multipart options")
+	}
+
+	if err := opts.Filter.Validate(); err != nil {
+		return trace.Wrap(err, "invalid filter")
+	}
+
+	if err := opts.ObjectLock.Validate(); err != nil {
+		return trace.Wrap(err, "invalid object lock")
+	}
+	retention := opts.ObjectLock.At(time.Now())
+
+	client := lr.newClient(credentials)
 
 	srcPath, srcIsS3, err := parseS3Path(src)
 	if err != nil {
@@ -60,20 +79,135 @@ func (lr *LocalRuntime) Sync(ctx *contexts.Context, credentials CredentialsInter
 
 	switch {
 	case srcIsS3 && !destIsS3:
-		return trace.Wrap(lr.download(ctx.Child(), client, srcPath, dest, asOf), "failed to download from %q to %q", src, dest)
+		err := lr.download(ctx.Child(), client, srcPath, dest, asOf, opts, limiter)
+		return trace.Wrap(err, "failed to download from %q to %q", src, dest)
 	case !srcIsS3 && destIsS3:
-		return trace.Wrap(lr.upload(ctx.Child(), client, src, destPath), "failed to upload from %q to %q", src, dest)
+		return trace.Wrap(lr.upload(ctx.Child(), client, src, destPath, opts, retention, limiter), "failed to upload from %q to %q", src, dest)
 	case srcIsS3 && destIsS3:
-		return trace.Errorf("s3-to-s3 sync is not supported")
+		destCredentials := opts.DestCredentials
+		if destCredentials == nil {
+			destCredentials = credentials
+		}
+
+		sameEndpoint := credentials.GetEndpoint() == destCredentials.GetEndpoint()
+		if sameEndpoint && srcPath.overlaps(destPath) {
+			return trace.BadParameter("source %q and destination %q overlap", src, dest)
+		}
+
+		// Buckets behind the same endpoint can copy between themselves, so the object contents don't need to
+		// pass through this process at all. The copy is made with the destination credentials, which can't be
+		// assumed to be able to read the source bucket unless they are the source credentials.
+		serverSide := sameEndpoint && sameCredentials(credentials, destCredentials)
+
+		destClient := lr.newClient(destCredentials)
+		err := lr.mirror(ctx.Child(), client, srcPath, destClient, destPath, asOf, serverSide, streamingPutOptions(destCredentials), opts.Filter, retention, opts.Multipart, limiter)
+		return trace.Wrap(err, "failed to mirror from %q to %q", src, dest)
 	default:
 		return trace.Errorf("local-to-local sync is not supported")
 	}
 }
 
+// newClient builds a client for the bucket(s) that the credentials authenticate to.
+func (lr *LocalRuntime) newClient(credentials CredentialsInterface) s3API {
+	return lr.newS3Client(credentials.AWSConfig(), func(o *s3.Options) {
+		// Endpoint and path-style are S3 client options in the v2 SDK rather than fields on aws.Config.
+		if endpoint := credentials.GetEndpoint(); endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = credentials.GetS3ForcePathStyle()
+	})
+}
+
 // download syncs an S3 prefix down to a local directory. When asOf is non-zero and the bucket has
 // versioning enabled, the directory is reconstructed as of asOf; otherwise it mirrors the latest state.
-func (lr *LocalRuntime) download(ctx *contexts.Context, client s3API, src s3Path, destDir string, asOf time.Time) error {
-	pointInTime := false
+// Large objects are downloaded in parts, as configured by opts.Multipart, and every downloaded object is
+// verified against its checksum. When opts.ManifestPath is set, a manifest describing the capture (including
+// each object's checksum and metadata) is written there once the directory is complete. Object transfers are
+// paced by the limiter.
+func (lr *LocalRuntime) download(ctx *contexts.Context, client s3API, src s3Path, destDir string, asOf time.Time, opts SyncOptions, limiter *throttle.Limiter) error {
+	objects, pointInTime, err := selectSourceObjects(ctx, client, src, asOf)
+	if err != nil {
+		return trace.Wrap(err, "failed to select objects to download")
+	}
+	objects = filterObjects(objects, opts.Filter)
+
+	previousRecords := previousObjectRecords(ctx, opts.ManifestPath, src)
+
+	keep := make(map[string]struct{}, len(objects))
+	records := make(map[string]ObjectRecord, len(objects))
+	var recordsLock sync.Mutex
+
+	var g errgroup.Group
+	g.SetLimit(syncParallelism)
+	for _, obj := range objects {
+		obj := obj
+		keep[filepath.FromSlash(obj.relPath)] = struct{}{}
+		g.Go(func() error {
+			record, err := downloadObject(ctx, client, src.bucket, obj, destDir, previousRecords[obj.relPath], opts, limiter)
+			if err != nil {
+				return err
+			}
+
+			if !record.Checksum.IsZero() {
+				recordsLock.Lock()
+				records[obj.relPath] = record
+				recordsLock.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return trace.Wrap(err, "failed to download one or more objects")
+	}
+
+	// Prune files that should no longer be present: deleted from the bucket since the last backup, or
+	// (point-in-time) absent as of the consistency point, along with any partial downloads of objects that
+	// are no longer present. This keeps the destination an exact mirror,
+	// which matters when the DR volume is an incremental clone of a previous backup.
+	if err := removeExtraneousLocalFiles(destDir, keep); err != nil {
+		return trace.Wrap(err, "failed to prune stale files from %q", destDir)
+	}
+
+	if opts.ManifestPath == "" {
+		return nil
+	}
+
+	manifest := Manifest{Source: src.String(), PointInTime: pointInTime, Filter: opts.Filter, Objects: records}
+	if pointInTime {
+		manifest.AsOf = asOf
+	}
+
+	return trace.Wrap(writeManifest(opts.ManifestPath, manifest), "failed to write manifest to %q", opts.ManifestPath)
+}
+
+// previousObjectRecords returns the object records of the manifest at manifestPath, when it was written by a
+// previous download of the same source. These are carried over for the objects whose local copies are
+// up to date, and are what a rehash validates those copies against.
+func previousObjectRecords(ctx *contexts.Context, manifestPath string, src s3Path) map[string]ObjectRecord {
+	if manifestPath == "" {
+		return nil
+	}
+
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		if !trace.IsNotFound(err) {
+			ctx.Log.Warn("Failed to read the previous manifest; the checksums of up-to-date objects will not be recorded",
+				"manifestPath", manifestPath, "error", err)
+		}
+		return nil
+	}
+
+	if manifest.Source != src.String() {
+		return nil
+	}
+
+	return manifest.Objects
+}
+
+// selectSourceObjects lists the objects to capture from the source prefix. When asOf is non-zero and the
+// bucket has versioning enabled, these are the object versions current as of asOf; otherwise they are the
+// latest objects. pointInTime reports which of the two was captured.
+func selectSourceObjects(ctx *contexts.Context, client s3API, src s3Path, asOf time.Time) (objects []remoteObject, pointInTime bool, err error) {
 	if !asOf.IsZero() {
 		enabled, err := bucketVersioningEnabled(ctx, client, src.bucket)
 		switch {
@@ -93,107 +227,213 @@ func (lr *LocalRuntime) download(ctx *contexts.Context, client s3API, src s3Path
 		}
 	}
 
-	var (
-		objects []remoteObject
-		err     error
-	)
 	if pointInTime {
 		ctx.Log.With("bucket", src.bucket, "asOf", asOf).Info("Capturing bucket as of the consistency point")
 		objects, err = listObjectsAsOf(ctx, client, src, asOf)
 	} else {
 		objects, err = listLatestObjects(ctx, client, src)
 	}
-	if err != nil {
-		return trace.Wrap(err, "failed to list objects in bucket %q", src.bucket)
+
+	return objects, pointInTime, trace.Wrap(err, "failed to list objects in bucket %q", src.bucket)
+}
+
+// downloadObject downloads a single object into destDir at its relative path, in parts if it is large
+// enough, and verifies the written file against the object's checksum. Existing files with the object's size
+// and modification time are skipped (unless opts.Rehash is set and they fail revalidation), and the object's
+// modification time is preserved so re-runs are idempotent. The returned record describes the local copy;
+// for a skipped copy, this is the previous record, if there is one.
+func downloadObject(ctx *contexts.Context, client s3API, bucket string, obj remoteObject, destDir string, previous ObjectRecord, opts SyncOptions, limiter *throttle.Limiter) (ObjectRecord, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(obj.relPath))
+
+	info, err := os.Stat(target)
+	switch {
+	case err == nil && info.Size() == obj.size && !obj.lastModified.After(info.ModTime()):
+		// An up-to-date copy already exists. Copies downloaded before their records were kept have no record,
+		// so one is fetched if it will be written to the manifest or rehashed against.
+		record := previous
+		if record.Checksum.IsZero() && (opts.ManifestPath != "" || opts.Rehash) {
+			if record, err = fetchObjectRecord(ctx, client, bucket, obj); err != nil {
+				return ObjectRecord{}, trace.Wrap(err, "failed to get record of object %q", obj.key)
+			}
+		}
+
+		if !opts.Rehash {
+			return record, nil
+		}
+
+		intact, err := rehashLocalCopy(ctx, obj, target, record.Checksum)
+		if err != nil {
+			return ObjectRecord{}, trace.Wrap(err, "failed to revalidate local copy of object %q", obj.key)
+		}
+
+		if intact {
+			return record, nil
+		}
+	case err != nil && !os.IsNotExist(err):
+		return ObjectRecord{}, trace.Wrap(err, "failed to stat %q", target)
 	}
 
-	keep := make(map[string]struct{}, len(objects))
+	if err := limiter.WaitFile(ctx); err != nil {
+		return ObjectRecord{}, trace.Wrap(err, "failed to wait to download object %q", obj.key)
+	}
 
-	var g errgroup.Group
-	g.SetLimit(syncParallelism)
-	for _, obj := range objects {
-		obj := obj
-		keep[filepath.FromSlash(obj.relPath)] = struct{}{}
-		g.Go(func() error {
-			return downloadObject(ctx, client, src.bucket, obj, destDir)
-		})
+	var record ObjectRecord
+	if opts.Multipart.usesParts(obj.size) {
+		// The record is fetched first, so that a failure doesn't waste the download.
+		if record, err = fetchObjectRecord(ctx, client, bucket, obj); err != nil {
+			return ObjectRecord{}, trace.Wrap(err, "failed to get record of object %q", obj.key)
+		}
+
+		if err := downloadObjectInParts(ctx, client, bucket, obj, target, opts.Multipart, limiter); err != nil {
+			return ObjectRecord{}, trace.Wrap(err, "failed to download object %q in parts", obj.key)
+		}
+	} else if record, err = downloadWholeObject(ctx, client, bucket, obj, target, limiter); err != nil {
+		return ObjectRecord{}, err
 	}
-	if err := g.Wait(); err != nil {
-		return trace.Wrap(err, "failed to download one or more objects")
+
+	if record.Checksum, err = verifyDownload(obj, target, record.Checksum); err != nil {
+		return ObjectRecord{}, err
 	}
 
-	// Prune files that should no longer be present: deleted from the bucket since the last backup, or
-	// (point-in-time) absent as of the consistency point. This keeps the destination an exact mirror,
-	// which matters when the DR volume is an incremental clone of a previous backup.
-	if err := removeExtraneousLocalFiles(destDir, keep); err != nil {
-		return trace.Wrap(err, "failed to prune stale files from %q", destDir)
+	if err := os.Chtimes(target, obj.lastModified, obj.lastModified); err != nil {
+		return ObjectRecord{}, trace.Wrap(err, "failed to set modification time on %q", target)
 	}
-	return nil
+
+	return record, nil
 }
 
-// downloadObject downloads a single object into destDir at its relative path. Existing identical files are
-// skipped, and the object's modification time is preserved so re-runs are idempotent.
-func downloadObject(ctx *contexts.Context, client s3API, bucket string, obj remoteObject, destDir string) error {
-	target := filepath.Join(destDir, filepath.FromSlash(obj.relPath))
+// verifyDownload checks that the file written to target matches the object's expected checksum, returning the
+// checksum to record. When the object has no usable checksum, the file's own SHA256 is recorded instead, so
+// that later rehashes and uploads can at least detect changes to the copy. A file that fails verification is
+// removed, as it would otherwise be skipped as up to date by the next download.
+func verifyDownload(obj remoteObject, target string, expected Checksum) (Checksum, error) {
+	if expected.IsZero() {
+		computed, err := computeFileChecksum(target, ChecksumAlgorithmSHA256)
+		if err != nil {
+			return Checksum{}, trace.Wrap(err, "failed to compute checksum of object %q", obj.key)
+		}
 
-	info, err := os.Stat(target)
-	if err == nil && info.Size() == obj.size && !obj.lastModified.After(info.ModTime()) {
-		return nil // an up-to-date copy already exists
-	} else if !os.IsNotExist(err) {
-		return trace.Wrap(err, "failed to stat %q", target)
+		computed.Computed = true
+		return computed, nil
+	}
+
+	if err := verifyFileChecksum(target, obj.key, expected); err != nil {
+		return Checksum{}, trace.NewAggregate(
+			trace.Wrap(err, "failed to verify object %q", obj.key),
+			trace.Wrap(os.Remove(target), "failed to remove %q", target),
+		)
+	}
+
+	return expected, nil
+}
+
+// rehashLocalCopy revalidates a local copy that is up to date by size and modification time, against the
+// checksum recorded when it was downloaded or, failing that, the object's own checksum. It reports whether the
+// copy is intact; a copy that isn't (or that has no checksum to validate against) should be downloaded again.
+func rehashLocalCopy(ctx *contexts.Context, obj remoteObject, target string, expected Checksum) (bool, error) {
+	if expected.IsZero() {
+		ctx.Log.Warn("Local copy has no checksum to revalidate it against; downloading it again", "key", obj.key)
+		return false, nil
+	}
+
+	actual, err := computeFileChecksum(target, expected.Algorithm)
+	if err != nil {
+		return false, trace.Wrap(err, "failed to compute checksum of %q", target)
+	}
+
+	if actual.Value != expected.Value {
+		ctx.Log.Warn("Local copy failed revalidation; downloading it again", "key", obj.key, "path", target,
+			"algorithm", expected.Algorithm, "expected", expected.Value, "actual", actual.Value)
+		return false, nil
 	}
 
-	input := &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(obj.key)}
+	return true, nil
+}
+
+// downloadWholeObject downloads a single object to target with one streaming GetObject, and returns the
+// object's record (with its tags, if it has any).
+func downloadWholeObject(ctx *contexts.Context, client s3API, bucket string, obj remoteObject, target string, limiter *throttle.Limiter) (ObjectRecord, error) {
+	input := &s3.GetObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(obj.key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	}
 	if obj.versionID != nil {
 		input.VersionId = obj.versionID
 	}
 
 	out, err := client.GetObject(ctx, input)
 	if err != nil {
-		return trace.Wrap(err, "failed to get object %q", obj.key)
+		return ObjectRecord{}, trace.Wrap(err, "failed to get object %q", obj.key)
 	}
 	defer cleanup.To(func(_ *contexts.Context) error { return out.Body.Close() }).
 		WithErrMessage("failed to close response body for object %q", obj.key).
 		Run()
 
 	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
-		return trace.Wrap(err, "failed to create parent directory for %q", target)
+		return ObjectRecord{}, trace.Wrap(err, "failed to create parent directory for %q", target)
 	}
 
 	f, err := os.Create(target)
 	if err != nil {
-		return trace.Wrap(err, "failed to create %q", target)
+		return ObjectRecord{}, trace.Wrap(err, "failed to create %q", target)
 	}
 
-	_, copyErr := io.Copy(f, out.Body)
+	_, copyErr := io.Copy(f, limiter.Reader(ctx, out.Body))
 	closeErr := f.Close()
 	copyCloseErr := trace.NewAggregate(
 		trace.Wrap(copyErr, "failed to write object %q to %q", obj.key, target),
 		trace.Wrap(closeErr, "failed to close %q", target),
 	)
 	if copyCloseErr != nil {
-		return copyCloseErr
+		return ObjectRecord{}, copyCloseErr
 	}
 
-	if err := os.Chtimes(target, obj.lastModified, obj.lastModified); err != nil {
-		return trace.Wrap(err, "failed to set modification time on %q", target)
+	record, hasTags := getObjectRecord(out)
+	if hasTags {
+		if record.Tags, err = getObjectTags(ctx, client, bucket, obj); err != nil {
+			return ObjectRecord{}, trace.Wrap(err, "failed to get tags of object %q", obj.key)
+		}
 	}
 
-	return nil
+	return record, nil
 }
 
 // upload syncs a local directory up to an S3 prefix (latest-state), pruning objects with no local
-// counterpart so the bucket mirrors the directory.
-func (lr *LocalRuntime) upload(ctx *contexts.Context, client s3API, srcDir string, dest s3Path) error {
+// counterpart so the bucket mirrors the directory. Large files are uploaded in parts, as configured by
+// opts.Multipart. When opts.ManifestPath names the manifest written by the download that captured the
+// directory, each file is verified against its recorded checksum before it is uploaded, and is uploaded with
+// its recorded metadata. Each uploaded object is given the retention. Object transfers are paced by the
+// limiter.
+func (lr *LocalRuntime) upload(ctx *contexts.Context, client s3API, srcDir string, dest s3Path, opts SyncOptions, retention ObjectRetention, limiter *throttle.Limiter) error {
 	localFiles, err := listLocalFiles(srcDir)
 	if err != nil {
 		return trace.Wrap(err, "failed to enumerate local files under %q", srcDir)
 	}
+	localFiles = slices.DeleteFunc(localFiles, func(lf localFile) bool {
+		return !opts.Filter.ShouldTransferKey(lf.relPath)
+	})
+
+	var records map[string]ObjectRecord
+	if opts.ManifestPath != "" {
+		manifest, err := readManifest(opts.ManifestPath)
+		switch {
+		case err == nil:
+			records = manifest.Objects
+		case trace.IsNotFound(err):
+			ctx.Log.Warn("No manifest found; files will be uploaded without verifying their checksums or restoring their metadata",
+				"manifestPath", opts.ManifestPath)
+		default:
+			return trace.Wrap(err, "failed to read manifest %q", opts.ManifestPath)
+		}
+	}
 
 	remoteObjects, err := listLatestObjects(ctx, client, dest)
 	if err != nil {
 		return trace.Wrap(err, "failed to list existing objects in bucket %q", dest.bucket)
 	}
+	// Excluded objects are left alone, rather than pruned for being absent from the (filtered) local files.
+	remoteObjects = filterObjects(remoteObjects, opts.Filter)
 
 	remoteByRel := make(map[string]remoteObject, len(remoteObjects))
 	for _, obj := range remoteObjects {
@@ -211,7 +451,7 @@ func (lr *LocalRuntime) upload(ctx *contexts.Context, client s3API, srcDir strin
 			continue // already up to date
 		}
 		g.Go(func() error {
-			err := uploadObject(ctx, client, dest, lf)
+			err := uploadObject(ctx, client, dest, lf, records[lf.relPath], opts.Multipart, retention, limiter)
 			return trace.Wrap(err, "failed to upload %q to %q", lf.absPath, path.Join(dest.bucket, dest.prefix, lf.relPath))
 		})
 	}
@@ -233,10 +473,23 @@ func (lr *LocalRuntime) upload(ctx *contexts.Context, client s3API, srcDir strin
 	return trace.Wrap(g.Wait(), "failed to upload or prune one or more objects")
 }
 
-// uploadObject uploads a single local file to its key under the destination prefix.
-func uploadObject(ctx *contexts.Context, client s3API, dest s3Path, lf localFile) (err error) {
+// uploadObject uploads a single local file to its key under the destination prefix, in parts if it is large
+// enough. When the file has a recorded checksum, it is verified before the upload, and a single-part upload
+// also carries it so that the bucket verifies what it receives. The object is given its recorded metadata,
+// and a content type derived from the file when none was recorded, along with the retention.
+func uploadObject(ctx *contexts.Context, client s3API, dest s3Path, lf localFile, record ObjectRecord, multipart MultipartOptions, retention ObjectRetention, limiter *throttle.Limiter) (err error) {
 	key := path.Join(dest.prefix, filepath.ToSlash(lf.relPath))
 
+	if err := limiter.WaitFile(ctx); err != nil {
+		return trace.Wrap(err, "failed to wait to upload %q", lf.absPath)
+	}
+
+	if !record.Checksum.IsZero() {
+		if err := verifyFileChecksum(lf.absPath, key, record.Checksum); err != nil {
+			return trace.Wrap(err, "failed to verify %q before uploading it", lf.absPath)
+		}
+	}
+
 	f, err := os.Open(lf.absPath)
 	if err != nil {
 		return trace.Wrap(err, "failed to open %q", lf.absPath)
@@ -248,22 +501,213 @@ func uploadObject(ctx *contexts.Context, client s3API, dest s3Path, lf localFile
 		}
 	}()
 
-	contentType, err := detectContentType(f, lf.absPath)
-	if err != nil {
-		return trace.Wrap(err, "failed to detect content type of %q", lf.absPath)
+	metadata := record.ObjectMetadata
+	if metadata.ContentType == "" {
+		if metadata.ContentType, err = detectContentType(f, lf.absPath); err != nil {
+			return trace.Wrap(err, "failed to detect content type of %q", lf.absPath)
+		}
 	}
 
-	// Body is an *os.File (an io.ReadSeeker), so the SDK can compute the payload signature and rewind on
-	// retry without buffering the file in memory.
-	_, err = client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(dest.bucket),
-		Key:         aws.String(key),
-		Body:        f,
-		ContentType: aws.String(contentType),
-	})
+	if multipart.usesParts(lf.size) {
+		err := uploadObjectInParts(ctx, client, dest.bucket, key, f, lf.size, metadata, retention, multipart, limiter)
+		return trace.Wrap(err, "failed to upload %q to %q in parts", lf.absPath, key)
+	}
+
+	// Body wraps an *os.File (an io.ReadSeeker), and the limiter's reader preserves seeking, so the SDK can
+	// compute the payload signature and rewind on retry without buffering the file in memory.
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(dest.bucket),
+		Key:    aws.String(key),
+		Body:   limiter.Reader(ctx, f),
+	}
+	metadata.applyToPutObject(input)
+	record.Checksum.applyToPutObject(input)
+	retention.applyToPutObject(input)
+
+	_, err = client.PutObject(ctx, input)
 	return trace.Wrap(err, "failed to upload %q to %q", lf.absPath, key)
 }
 
+// mirror syncs an S3 prefix to another S3 prefix, which may be in a different bucket, behind a different
+// endpoint, and accessed with different credentials. Objects are selected exactly as for a download
+// (including the point-in-time reconstruction), and objects under the destination prefix with no selected
+// counterpart are deleted, so the destination is an exact mirror. When serverSide is set, the destination
+// endpoint copies each object itself with CopyObject (so the destination client must be able to read the
+// source bucket); otherwise each object is streamed from the source to the destination, with
+// putOptFns applied to the upload. Large objects are copied or streamed in parts, as configured by multipart.
+// Each copy is given the retention. Object transfers are paced by the limiter, although server-side copies are
+// only subject to the files per second limit as their contents are never read here.
+func (lr *LocalRuntime) mirror(ctx *contexts.Context, srcClient s3API, src s3Path, destClient s3API, dest s3Path, asOf time.Time, serverSide bool, putOptFns []func(*s3.Options), filter files.FileFilter, retention ObjectRetention, multipart MultipartOptions, limiter *throttle.Limiter) error {
+	ctx.Log.With("serverSide", serverSide).Info("Mirroring objects between buckets")
+
+	objects, _, err := selectSourceObjects(ctx, srcClient, src, asOf)
+	if err != nil {
+		return trace.Wrap(err, "failed to select objects to mirror")
+	}
+	objects = filterObjects(objects, filter)
+
+	destObjects, err := listLatestObjects(ctx, destClient, dest)
+	if err != nil {
+		return trace.Wrap(err, "failed to list existing objects in bucket %q", dest.bucket)
+	}
+	destObjects = filterObjects(destObjects, filter)
+
+	destByRel := make(map[string]remoteObject, len(destObjects))
+	for _, obj := range destObjects {
+		destByRel[obj.relPath] = obj
+	}
+
+	srcRel := make(map[string]struct{}, len(objects))
+
+	var g errgroup.Group
+	g.SetLimit(syncParallelism)
+	for _, obj := range objects {
+		srcRel[obj.relPath] = struct{}{}
+
+		// The destination copy is always written after the source version it was copied from, so a newer
+		// source object (or a different size) means the copy is stale.
+		if existing, ok := destByRel[obj.relPath]; ok && existing.size == obj.size && !obj.lastModified.After(existing.lastModified) {
+			continue // already up to date
+		}
+
+		destKey := path.Join(dest.prefix, obj.relPath)
+		g.Go(func() error {
+			if serverSide {
+				return copyObject(ctx, destClient, src.bucket, obj, dest.bucket, destKey, retention, multipart, limiter)
+			}
+			return streamObject(ctx, srcClient, src.bucket, obj, destClient, dest.bucket, destKey, putOptFns, retention, multipart, limiter)
+		})
+	}
+
+	for _, obj := range destObjects {
+		if _, ok := srcRel[obj.relPath]; ok {
+			continue
+		}
+
+		g.Go(func() error {
+			_, err := destClient.DeleteObject(ctx, &s3.DeleteObjectInput{
+				Bucket: aws.String(dest.bucket),
+				Key:    aws.String(obj.key),
+			})
+			return trace.Wrap(err, "failed to delete object %q", obj.key)
+		})
+	}
+
+	return trace.Wrap(g.Wait(), "failed to copy or prune one or more objects")
+}
+
+// copyObject copies a single object (at its selected version) to destKey with a server-side CopyObject, or
+// with UploadPartCopy requests for objects that multipart says to copy in parts. The object's metadata and
+// content type are carried over by the copy, and the copy is given the retention.
+func copyObject(ctx *contexts.Context, client s3API, srcBucket string, obj remoteObject, destBucket, destKey string, retention ObjectRetention, multipart MultipartOptions, limiter *throttle.Limiter) error {
+	if err := limiter.WaitFile(ctx); err != nil {
+		return trace.Wrap(err, "failed to wait to copy object %q", obj.key)
+	}
+
+	if multipart.usesParts(obj.size) {
+		err := copyObjectInParts(ctx, client, srcBucket, obj, destBucket, destKey, retention, multipart)
+		return trace.Wrap(err, "failed to copy object %q to %q in parts", obj.key, path.Join(destBucket, destKey))
+	}
+
+	input := &s3.CopyObjectInput{
+		Bucket:     aws.String(destBucket),
+		Key:        aws.String(destKey),
+		CopySource: aws.String(objectCopySource(srcBucket, obj)),
+	}
+	retention.applyToCopyObject(input)
+
+	_, err := client.CopyObject(ctx, input)
+	return trace.Wrap(err, "failed to copy object %q to %q", obj.key, path.Join(destBucket, destKey))
+}
+
+// objectCopySource returns the source of a server-side copy of the object: a URL-encoded "bucket/key",
+// pinned to the object's selected version if it has one.
+func objectCopySource(bucket string, obj remoteObject) string {
+	copySource := (&url.URL{Path: path.Join(bucket, obj.key)}).EscapedPath()
+	if obj.versionID != nil {
+		copySource += "?versionId=" + url.QueryEscape(*obj.versionID)
+	}
+
+	return copySource
+}
+
+// streamObject copies a single object (at its selected version) to destKey by reading it from the source
+// and writing it to the destination as it is read, so it is never staged locally. Objects that multipart
+// says to transfer in parts are streamed a part at a time. The object's metadata and content type are
+// carried over, and the copy is given the retention.
+func streamObject(ctx *contexts.Context, srcClient s3API, srcBucket string, obj remoteObject, destClient s3API, destBucket, destKey string, putOptFns []func(*s3.Options), retention ObjectRetention, multipart MultipartOptions, limiter *throttle.Limiter) error {
+	if err := limiter.WaitFile(ctx); err != nil {
+		return trace.Wrap(err, "failed to wait to copy object %q", obj.key)
+	}
+
+	if multipart.usesParts(obj.size) {
+		err := streamObjectInParts(ctx, srcClient, srcBucket, obj, destClient, destBucket, destKey, putOptFns, retention, multipart, limiter)
+		return trace.Wrap(err, "failed to upload object %q to %q in parts", obj.key, path.Join(destBucket, destKey))
+	}
+
+	input := &s3.GetObjectInput{Bucket: aws.String(srcBucket), Key: aws.String(obj.key)}
+	if obj.versionID != nil {
+		input.VersionId = obj.versionID
+	}
+
+	out, err := srcClient.GetObject(ctx, input)
+	if err != nil {
+		return trace.Wrap(err, "failed to get object %q", obj.key)
+	}
+	defer cleanup.To(func(_ *contexts.Context) error { return out.Body.Close() }).
+		WithErrMessage("failed to close response body for object %q", obj.key).
+		Run()
+
+	// The body can't be rewound, so the content length must be known up front for the upload to be sent
+	// without buffering it.
+	putInput := &s3.PutObjectInput{
+		Bucket:        aws.String(destBucket),
+		Key:           aws.String(destKey),
+		Body:          limiter.Reader(ctx, out.Body),
+		ContentLength: aws.Int64(obj.size),
+		ContentType:   out.ContentType,
+		Metadata:      out.Metadata,
+	}
+	retention.applyToPutObject(putInput)
+
+	_, err = destClient.PutObject(ctx, putInput, putOptFns...)
+	return trace.Wrap(err, "failed to upload object %q to %q", obj.key, path.Join(destBucket, destKey))
+}
+
+// sameCredentials reports whether both sets of credentials authenticate as the same identity.
+func sameCredentials(a, b CredentialsInterface) bool {
+	if a.GetAccessKeyID() != b.GetAccessKeyID() ||
+		a.GetSecretAccessKey() != b.GetSecretAccessKey() ||
+		a.GetSessionToken() != b.GetSessionToken() ||
+		a.GetRegion() != b.GetRegion() {
+		return false
+	}
+
+	aRole, bRole := a.GetAssumeRole(), b.GetAssumeRole()
+	if aRole == nil || bRole == nil {
+		return aRole == bRole
+	}
+
+	return *aRole == *bRole
+}
+
+// streamingPutOptions returns the client options needed to upload a body that can't be rewound. Over TLS the
+// SDK handles this itself by sending the payload unsigned, with a trailing checksum. Without TLS, neither the
+// payload hash nor a checksum can be computed without reading the body twice, so the payload is sent unsigned
+// and without a checksum.
+func streamingPutOptions(credentials CredentialsInterface) []func(*s3.Options) {
+	if !strings.HasPrefix(strings.ToLower(credentials.GetEndpoint()), "http://") {
+		return nil
+	}
+
+	return []func(*s3.Options){
+		func(o *s3.Options) {
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.APIOptions = append(o.APIOptions, v4.SwapComputePayloadSHA256ForUnsignedPayloadMiddleware)
+		},
+	}
+}
+
 // bucketVersioningEnabled reports whether the bucket has versioning enabled (as opposed to never enabled
 // or suspended). Only an enabled bucket can be reconstructed to a past instant.
 func bucketVersioningEnabled(ctx *contexts.Context, client s3API, bucket string) (bool, error) {
@@ -297,6 +741,7 @@ func listLatestObjects(ctx *contexts.Context, client s3API, src s3Path) ([]remot
 			objects = append(objects, remoteObject{
 				key:          key,
 				relPath:      rel,
+				etag:         aws.ToString(obj.ETag),
 				size:         aws.ToInt64(obj.Size),
 				lastModified: aws.ToTime(obj.LastModified),
 			})
@@ -309,10 +754,16 @@ func listLatestObjects(ctx *contexts.Context, client s3API, src s3Path) ([]remot
 // listObjectsAsOf lists every object version and delete marker under the prefix, then resolves the set of
 // objects current as of asOf via selectObjectsAsOf.
 func listObjectsAsOf(ctx *contexts.Context, client s3API, src s3Path, asOf time.Time) ([]remoteObject, error) {
-	var (
-		versions      []types.ObjectVersion
-		deleteMarkers []types.DeleteMarkerEntry
-	)
+	versions, deleteMarkers, err := listObjectVersions(ctx, client, src)
+	if err != nil {
+		return nil, trace.Wrap(err, "failed to list object versions")
+	}
+
+	return selectObjectsAsOf(versions, deleteMarkers, src.prefix, asOf), nil
+}
+
+// listObjectVersions lists every object version and delete marker under the prefix.
+func listObjectVersions(ctx *contexts.Context, client s3API, src s3Path) (versions []types.ObjectVersion, deleteMarkers []types.DeleteMarkerEntry, err error) {
 	paginator := s3.NewListObjectVersionsPaginator(client, &s3.ListObjectVersionsInput{
 		Bucket: aws.String(src.bucket),
 		Prefix: aws.String(src.prefix),
@@ -320,13 +771,13 @@ func listObjectsAsOf(ctx *contexts.Context, client s3API, src s3Path, asOf time.
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return nil, trace.Wrap(err, "failed to list object versions")
+			return nil, nil, trace.Wrap(err, "failed to list object versions")
 		}
 		versions = append(versions, page.Versions...)
 		deleteMarkers = append(deleteMarkers, page.DeleteMarkers...)
 	}
 
-	return selectObjectsAsOf(versions, deleteMarkers, src.prefix, asOf), nil
+	return versions, deleteMarkers, nil
 }
 
 // selectObjectsAsOf reconstructs the set of objects that were current at asOf. For each key it keeps the
@@ -344,6 +795,7 @@ func selectObjectsAsOf(versions []types.ObjectVersion, deleteMarkers []types.Del
 		deleted      bool
 		size         int64
 		versionID    *string
+		etag         string
 	}
 
 	current := make(map[string]candidate, len(versions))
@@ -365,6 +817,7 @@ func selectObjectsAsOf(versions []types.ObjectVersion, deleteMarkers []types.Del
 			lastModified: lastModified,
 			size:         aws.ToInt64(version.Size),
 			versionID:    version.VersionId,
+			etag:         aws.ToString(version.ETag),
 		})
 	}
 
@@ -391,6 +844,7 @@ func selectObjectsAsOf(versions []types.ObjectVersion, deleteMarkers []types.Del
 			key:          key,
 			relPath:      rel,
 			versionID:    candidate.versionID,
+			etag:         candidate.etag,
 			size:         candidate.size,
 			lastModified: candidate.lastModified,
 		})
@@ -411,6 +865,17 @@ func relativeKey(prefix, key string) string {
 	return rel
 }
 
+// filterObjects removes the objects that the filter excludes, by their keys relative to the prefix.
+func filterObjects(objects []remoteObject, filter files.FileFilter) []remoteObject {
+	if filter.IsZero() {
+		return objects
+	}
+
+	return slices.DeleteFunc(objects, func(obj remoteObject) bool {
+		return !filter.ShouldTransferKey(obj.relPath)
+	})
+}
+
 // removeExtraneousLocalFiles deletes files under destDir whose relative path is not in keep.
 func removeExtraneousLocalFiles(destDir string, keep map[string]struct{}) error {
 	info, err := os.Stat(destDir)
@@ -483,7 +948,8 @@ func listLocalFiles(baseDir string) ([]localFile, error) {
 		}
 
 		// Skip directories and special files (symlinks, sockets, devices); only regular files are objects.
-		if d.IsDir() || !d.Type().IsRegular() {
+		// Incomplete multipart downloads are not objects either.
+		if d.IsDir() || !d.Type().IsRegular() || isPartialDownloadFile(p) {
 			return nil
 		}
 
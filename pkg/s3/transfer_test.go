@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/solidDoWant/backup-tool/pkg/files"
 	th "github.com/solidDoWant/backup-tool/pkg/testhelpers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +25,27 @@ func injectClient(rt *LocalRuntime, client s3API) {
 	}
 }
 
+// injectClientsByEndpoint points the runtime's client factory at the mock for the endpoint that the client
+// is being built for.
+func injectClientsByEndpoint(t *testing.T, rt *LocalRuntime, clients map[string]s3API) {
+	rt.newS3Client = func(_ aws.Config, optFns ...func(*awss3.Options)) s3API {
+		var opts awss3.Options
+		for _, optFn := range optFns {
+			optFn(&opts)
+		}
+
+		client, ok := clients[aws.ToString(opts.BaseEndpoint)]
+		require.True(t, ok, "unexpected endpoint %q", aws.ToString(opts.BaseEndpoint))
+		return client
+	}
+}
+
+func listObjectsWithPrefix(prefix string) interface{} {
+	return mock.MatchedBy(func(in *awss3.ListObjectsV2Input) bool {
+		return aws.ToString(in.Prefix) == prefix
+	})
+}
+
 func objectVersion(key, versionID string, size int64, lastModified time.Time) types.ObjectVersion {
 	return types.ObjectVersion{
 		Key:          aws.String(key),
@@ -111,8 +133,9 @@ func TestSyncRejectsUnsupportedDirections(t *testing.T) {
 	injectClient(rt, NewMocks3API(t))
 	creds := NewCredentials("id", "secret")
 
-	assert.Error(t, rt.Sync(th.NewTestContext(), creds, "s3://a/x", "s3://b/y", time.Time{}))
-	assert.Error(t, rt.Sync(th.NewTestContext(), creds, "/local/x", "/local/y", time.Time{}))
+	// Mirroring a prefix into itself (or a prefix within it) would read back what it writes
+	assert.Error(t, rt.Sync(th.NewTestContext(), creds, "s3://a/x", "s3://a/x/y", time.Time{}, SyncOptions{}))
+	assert.Error(t, rt.Sync(th.NewTestContext(), creds, "/local/x", "/local/y", time.Time{}, SyncOptions{}))
 }
 
 func TestSyncDownloadLatestState(t *testing.T) {
@@ -136,7 +159,7 @@ func TestSyncDownloadLatestState(t *testing.T) {
 	injectClient(rt, client)
 
 	// Zero asOf => latest-state sync; versioning is never queried.
-	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", destDir, time.Time{})
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", destDir, time.Time{}, SyncOptions{})
 	require.NoError(t, err)
 
 	got, err := os.ReadFile(filepath.Join(destDir, "a.txt"))
@@ -172,7 +195,8 @@ func TestSyncDownloadPointInTime(t *testing.T) {
 	rt := NewLocalRuntime()
 	injectClient(rt, client)
 
-	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", destDir, asOf)
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", destDir, asOf, SyncOptions{ManifestPath: manifestPath})
 	require.NoError(t, err)
 
 	got, err := os.ReadFile(filepath.Join(destDir, "keep.txt"))
@@ -180,6 +204,12 @@ func TestSyncDownloadPointInTime(t *testing.T) {
 	assert.Equal(t, "keep", string(got))
 	assert.NoFileExists(t, filepath.Join(destDir, "after.txt"))
 	assert.NoFileExists(t, filepath.Join(destDir, "deleted.txt"))
+
+	manifest, err := readManifest(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, "s3://bucket/prefix", manifest.Source)
+	assert.True(t, manifest.PointInTime)
+	assert.True(t, asOf.Equal(manifest.AsOf))
 }
 
 func TestSyncDownloadFallsBackWhenVersioningDisabled(t *testing.T) {
@@ -202,9 +232,16 @@ func TestSyncDownloadFallsBackWhenVersioningDisabled(t *testing.T) {
 	rt := NewLocalRuntime()
 	injectClient(rt, client)
 
-	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", destDir, time.Now())
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", destDir, time.Now(), SyncOptions{ManifestPath: manifestPath})
 	require.NoError(t, err)
 	assert.FileExists(t, filepath.Join(destDir, "a.txt"))
+
+	// The capture is recorded as latest-state, so it can't be rewound to.
+	manifest, err := readManifest(manifestPath)
+	require.NoError(t, err)
+	assert.False(t, manifest.PointInTime)
+	assert.True(t, manifest.AsOf.IsZero())
 }
 
 func TestSyncDownloadFallsBackWhenVersioningCheckErrors(t *testing.T) {
@@ -227,7 +264,7 @@ func TestSyncDownloadFallsBackWhenVersioningCheckErrors(t *testing.T) {
 	rt := NewLocalRuntime()
 	injectClient(rt, client)
 
-	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", destDir, time.Now())
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", destDir, time.Now(), SyncOptions{})
 	require.NoError(t, err)
 	assert.FileExists(t, filepath.Join(destDir, "a.txt"))
 }
@@ -255,7 +292,7 @@ func TestSyncUpload(t *testing.T) {
 	rt := NewLocalRuntime()
 	injectClient(rt, client)
 
-	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), srcDir, "s3://bucket/prefix", time.Time{})
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), srcDir, "s3://bucket/prefix", time.Time{}, SyncOptions{})
 	require.NoError(t, err)
 }
 
@@ -266,6 +303,251 @@ func TestSyncDownloadPropagatesListError(t *testing.T) {
 	rt := NewLocalRuntime()
 	injectClient(rt, client)
 
-	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", t.TempDir(), time.Time{})
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", t.TempDir(), time.Time{}, SyncOptions{})
 	assert.Error(t, err)
 }
+
+func TestSyncMirrorServerSide(t *testing.T) {
+	now := time.Now()
+
+	client := NewMocks3API(t)
+	client.EXPECT().ListObjectsV2(mock.Anything, listObjectsWithPrefix("prefix"), mock.Anything).Return(&awss3.ListObjectsV2Output{
+		Contents: []types.Object{
+			{Key: aws.String("prefix/new.txt"), Size: aws.Int64(3), LastModified: aws.Time(now)},
+			{Key: aws.String("prefix/same.txt"), Size: aws.Int64(4), LastModified: aws.Time(now.Add(-time.Hour))},
+		},
+	}, nil)
+	client.EXPECT().ListObjectsV2(mock.Anything, listObjectsWithPrefix("backup"), mock.Anything).Return(&awss3.ListObjectsV2Output{
+		Contents: []types.Object{
+			// Copied after the source was last written, so it is up to date and must not be copied again.
+			{Key: aws.String("backup/same.txt"), Size: aws.Int64(4), LastModified: aws.Time(now.Add(-time.Minute))},
+			// No longer in the source, so it must be deleted.
+			{Key: aws.String("backup/stale.txt"), Size: aws.Int64(9), LastModified: aws.Time(now)},
+		},
+	}, nil)
+	// Both buckets are behind the same endpoint, so the object is copied server-side.
+	client.EXPECT().CopyObject(mock.Anything, mock.MatchedBy(func(in *awss3.CopyObjectInput) bool {
+		return aws.ToString(in.Bucket) == "dest-bucket" &&
+			aws.ToString(in.Key) == "backup/new.txt" &&
+			aws.ToString(in.CopySource) == "src-bucket/prefix/new.txt"
+	})).Return(&awss3.CopyObjectOutput{}, nil)
+	client.EXPECT().DeleteObject(mock.Anything, mock.MatchedBy(func(in *awss3.DeleteObjectInput) bool {
+		return aws.ToString(in.Bucket) == "dest-bucket" && aws.ToString(in.Key) == "backup/stale.txt"
+	})).Return(&awss3.DeleteObjectOutput{}, nil)
+
+	rt := NewLocalRuntime()
+	injectClient(rt, client)
+
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://src-bucket/prefix", "s3://dest-bucket/backup", time.Time{}, SyncOptions{
+		DestCredentials: NewCredentials("id", "secret"),
+	})
+	require.NoError(t, err)
+}
+
+func TestSyncMirrorStreamsWithDifferentCredentials(t *testing.T) {
+	now := time.Now()
+
+	client := NewMocks3API(t)
+	client.EXPECT().ListObjectsV2(mock.Anything, listObjectsWithPrefix("prefix"), mock.Anything).Return(&awss3.ListObjectsV2Output{
+		Contents: []types.Object{
+			{Key: aws.String("prefix/a.txt"), Size: aws.Int64(3), LastModified: aws.Time(now)},
+		},
+	}, nil)
+	client.EXPECT().ListObjectsV2(mock.Anything, listObjectsWithPrefix("backup"), mock.Anything).Return(&awss3.ListObjectsV2Output{}, nil)
+	// The destination credentials may not be able to read the source bucket, so a server-side copy can't be
+	// used even though both buckets are behind the same endpoint.
+	client.EXPECT().GetObject(mock.Anything, mock.MatchedBy(func(in *awss3.GetObjectInput) bool {
+		return aws.ToString(in.Bucket) == "src-bucket" && aws.ToString(in.Key) == "prefix/a.txt"
+	})).Return(&awss3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("abc"))}, nil)
+	client.EXPECT().PutObject(mock.Anything, mock.MatchedBy(func(in *awss3.PutObjectInput) bool {
+		body, err := io.ReadAll(in.Body)
+		return err == nil &&
+			aws.ToString(in.Bucket) == "dest-bucket" &&
+			aws.ToString(in.Key) == "backup/a.txt" &&
+			string(body) == "abc"
+	})).Return(&awss3.PutObjectOutput{}, nil)
+
+	rt := NewLocalRuntime()
+	injectClient(rt, client)
+
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://src-bucket/prefix", "s3://dest-bucket/backup", time.Time{}, SyncOptions{
+		DestCredentials: NewCredentials("other-id", "other-secret"),
+	})
+	require.NoError(t, err)
+}
+
+func TestSameCredentials(t *testing.T) {
+	role := func(arn string) *AssumeRole { return &AssumeRole{RoleARN: arn} }
+
+	tests := []struct {
+		desc     string
+		a, b     *Credentials
+		expected bool
+	}{
+		{
+			desc:     "identical keys",
+			a:        NewCredentials("id", "secret"),
+			b:        NewCredentials("id", "secret"),
+			expected: true,
+		},
+		{
+			desc: "different keys",
+			a:    NewCredentials("id", "secret"),
+			b:    NewCredentials("other-id", "secret"),
+		},
+		{
+			desc: "different session tokens",
+			a:    NewCredentials("id", "secret").WithSessionToken("a"),
+			b:    NewCredentials("id", "secret").WithSessionToken("b"),
+		},
+		{
+			desc:     "same role",
+			a:        NewCredentials("id", "secret").WithAssumeRole(role("arn:role")),
+			b:        NewCredentials("id", "secret").WithAssumeRole(role("arn:role")),
+			expected: true,
+		},
+		{
+			desc: "different roles",
+			a:    NewCredentials("id", "secret").WithAssumeRole(role("arn:role")),
+			b:    NewCredentials("id", "secret").WithAssumeRole(role("arn:other-role")),
+		},
+		{
+			desc: "only one assumes a role",
+			a:    NewCredentials("id", "secret").WithAssumeRole(role("arn:role")),
+			b:    NewCredentials("id", "secret"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, sameCredentials(test.a, test.b))
+		})
+	}
+}
+
+func TestSyncMirrorStreamsAcrossEndpoints(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	asOf := base.Add(30 * time.Minute)
+
+	srcClient := NewMocks3API(t)
+	srcClient.EXPECT().GetBucketVersioning(mock.Anything, mock.Anything).
+		Return(&awss3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusEnabled}, nil)
+	srcClient.EXPECT().ListObjectVersions(mock.Anything, mock.Anything, mock.Anything).Return(&awss3.ListObjectVersionsOutput{
+		Versions: []types.ObjectVersion{
+			objectVersion("prefix/keep.txt", "v2", 4, base.Add(10*time.Minute)),
+			objectVersion("prefix/keep.txt", "v1", 1, base),
+			objectVersion("prefix/after.txt", "vfuture", 5, asOf.Add(time.Minute)),
+		},
+	}, nil)
+	// The version current as of the consistency point is the one that is copied.
+	srcClient.EXPECT().GetObject(mock.Anything, mock.MatchedBy(func(in *awss3.GetObjectInput) bool {
+		return aws.ToString(in.Key) == "prefix/keep.txt" && aws.ToString(in.VersionId) == "v2"
+	})).Return(&awss3.GetObjectOutput{
+		Body:        io.NopCloser(strings.NewReader("keep")),
+		ContentType: aws.String("text/plain"),
+		Metadata:    map[string]string{"owner": "app"},
+	}, nil)
+
+	destClient := NewMocks3API(t)
+	destClient.EXPECT().ListObjectsV2(mock.Anything, listObjectsWithPrefix("backup"), mock.Anything).Return(&awss3.ListObjectsV2Output{}, nil)
+	// The destination endpoint doesn't use TLS, so a single option func is supplied to allow the unseekable
+	// body to be sent.
+	destClient.EXPECT().PutObject(mock.Anything, mock.MatchedBy(func(in *awss3.PutObjectInput) bool {
+		body, err := io.ReadAll(in.Body)
+		return err == nil &&
+			aws.ToString(in.Bucket) == "dest-bucket" &&
+			aws.ToString(in.Key) == "backup/keep.txt" &&
+			aws.ToInt64(in.ContentLength) == 4 &&
+			aws.ToString(in.ContentType) == "text/plain" &&
+			in.Metadata["owner"] == "app" &&
+			string(body) == "keep"
+	}), mock.Anything).Return(&awss3.PutObjectOutput{}, nil)
+
+	rt := NewLocalRuntime()
+	injectClientsByEndpoint(t, rt, map[string]s3API{
+		"https://src.example": srcClient,
+		"http://dest.example": destClient,
+	})
+
+	err := rt.Sync(th.NewTestContext(), NewCredentials("id", "secret").WithEndpoint("https://src.example"), "s3://src-bucket/prefix", "s3://dest-bucket/backup", asOf, SyncOptions{
+		DestCredentials: NewCredentials("other-id", "other-secret").WithEndpoint("http://dest.example"),
+	})
+	require.NoError(t, err)
+}
+
+func TestSyncFilter(t *testing.T) {
+	filter := files.FileFilter{Exclude: []files.FilePattern{{Glob: "thumbnails"}}}
+
+	t.Run("download", func(t *testing.T) {
+		fake := newFakeS3(t)
+		fake.putObject("bucket", "prefix/a.txt", []byte("a"))
+		fake.putObject("bucket", "prefix/thumbnails/a.jpg", []byte("thumbnail"))
+
+		// A copy left by a backup taken before the filter was added is pruned.
+		destDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(destDir, "thumbnails"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "thumbnails", "old.jpg"), []byte("old"), 0o644))
+
+		opts := SyncOptions{ManifestPath: filepath.Join(t.TempDir(), "manifest.json"), Filter: filter}
+		require.NoError(t, NewLocalRuntime().Sync(th.NewTestContext(), fake.credentials(), "s3://bucket/prefix", destDir, time.Time{}, opts))
+
+		assert.FileExists(t, filepath.Join(destDir, "a.txt"))
+		assert.NoFileExists(t, filepath.Join(destDir, "thumbnails", "a.jpg"))
+		assert.NoFileExists(t, filepath.Join(destDir, "thumbnails", "old.jpg"))
+		assert.NotContains(t, fake.takeRequests(), "GET thumbnails/a.jpg")
+
+		manifest, err := readManifest(opts.ManifestPath)
+		require.NoError(t, err)
+		assert.Equal(t, filter, manifest.Filter)
+		assert.Contains(t, manifest.Objects, "a.txt")
+		assert.NotContains(t, manifest.Objects, "thumbnails/a.jpg")
+	})
+
+	t.Run("upload", func(t *testing.T) {
+		fake := newFakeS3(t)
+		fake.putObject("bucket", "prefix/stale.txt", []byte("stale"))
+		fake.putObject("bucket", "prefix/thumbnails/live.jpg", []byte("live"))
+
+		srcDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "thumbnails"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "a.txt"), []byte("a"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, "thumbnails", "a.jpg"), []byte("thumbnail"), 0o644))
+
+		require.NoError(t, NewLocalRuntime().Sync(th.NewTestContext(), fake.credentials(), srcDir, "s3://bucket/prefix", time.Time{}, SyncOptions{Filter: filter}))
+
+		_, ok := fake.getObject("bucket", "prefix/a.txt")
+		assert.True(t, ok)
+		_, ok = fake.getObject("bucket", "prefix/stale.txt")
+		assert.False(t, ok)
+		// Excluded files aren't uploaded, and excluded objects aren't pruned.
+		_, ok = fake.getObject("bucket", "prefix/thumbnails/a.jpg")
+		assert.False(t, ok)
+		_, ok = fake.getObject("bucket", "prefix/thumbnails/live.jpg")
+		assert.True(t, ok)
+	})
+
+	t.Run("bucket to bucket", func(t *testing.T) {
+		src := newFakeS3(t)
+		src.putObject("bucket", "prefix/a.txt", []byte("a"))
+		src.putObject("bucket", "prefix/thumbnails/a.jpg", []byte("thumbnail"))
+		dest := newFakeS3(t)
+		dest.putObject("bucket", "prefix/thumbnails/live.jpg", []byte("live"))
+
+		opts := SyncOptions{DestCredentials: dest.credentials(), Filter: filter}
+		require.NoError(t, NewLocalRuntime().Sync(th.NewTestContext(), src.credentials(), "s3://bucket/prefix", "s3://bucket/prefix", time.Time{}, opts))
+
+		_, ok := dest.getObject("bucket", "prefix/a.txt")
+		assert.True(t, ok)
+		_, ok = dest.getObject("bucket", "prefix/thumbnails/a.jpg")
+		assert.False(t, ok)
+		_, ok = dest.getObject("bucket", "prefix/thumbnails/live.jpg")
+		assert.True(t, ok)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		opts := SyncOptions{Filter: files.FileFilter{Exclude: []files.FilePattern{{Glob: "[bad"}}}}
+		err := NewLocalRuntime().Sync(th.NewTestContext(), NewCredentials("id", "secret"), "s3://bucket/prefix", t.TempDir(), time.Time{}, opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid filter")
+	})
+}
@@ -0,0 +1,159 @@
+package throttle
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/gravitational/trace"
+	"golang.org/x/time/rate"
+)
+
+// Limits caps the rate of a transfer, so that a backup or restore running alongside an application
+// doesn't saturate the storage (or network) the application depends on. The zero value is unlimited.
+type Limits struct {
+	// BytesPerSecond caps the rate at which file or object contents are read. Zero means unlimited.
+	BytesPerSecond int64 `yaml:"bytesPerSecond,omitempty"`
+	// FilesPerSecond caps the rate at which files (or objects) are transferred, bounding the IOPS spent on
+	// many small files. Zero means unlimited.
+	FilesPerSecond float64 `yaml:"filesPerSecond,omitempty"`
+}
+
+// IsZero reports whether the limits constrain nothing.
+func (l Limits) IsZero() bool {
+	return l.BytesPerSecond == 0 && l.FilesPerSecond == 0
+}
+
+// Validate reports whether the limits are well-formed.
+func (l Limits) Validate() error {
+	if l.BytesPerSecond < 0 {
+		return trace.BadParameter("bytes per second limit must not be negative")
+	}
+
+	if l.FilesPerSecond < 0 || math.IsNaN(l.FilesPerSecond) || math.IsInf(l.FilesPerSecond, 0) {
+		return trace.BadParameter("files per second limit must be a non-negative, finite number")
+	}
+
+	return nil
+}
+
+// Limiter enforces Limits with a pair of token buckets: one refilled at BytesPerSecond and drained by
+// every byte read, and one refilled at FilesPerSecond and drained once per file. A single Limiter may be
+// shared by concurrent transfers, in which case the limits apply to their aggregate. Transfers are
+// counted even when unlimited, so the effective rate can always be reported.
+type Limiter struct {
+	limits           Limits
+	bytes            *rate.Limiter
+	files            *rate.Limiter
+	startTime        time.Time
+	transferredBytes atomic.Int64
+	transferredFiles atomic.Int64
+}
+
+func NewLimiter(limits Limits) *Limiter {
+	// Each bucket holds up to one second of tokens. Reads are capped at the bucket size (see Reader), so a
+	// single read never needs more tokens than the bucket can hold.
+	bytes := rate.NewLimiter(rate.Inf, 0)
+	if limits.BytesPerSecond > 0 {
+		bytes = rate.NewLimiter(rate.Limit(limits.BytesPerSecond), int(min(limits.BytesPerSecond, math.MaxInt32)))
+	}
+
+	files := rate.NewLimiter(rate.Inf, 0)
+	if limits.FilesPerSecond > 0 {
+		files = rate.NewLimiter(rate.Limit(limits.FilesPerSecond), max(1, int(limits.FilesPerSecond)))
+	}
+
+	return &Limiter{
+		limits:    limits,
+		bytes:     bytes,
+		files:     files,
+		startTime: time.Now(),
+	}
+}
+
+// WaitFile blocks until the limiter permits another file to be transferred, or the context is done.
+func (l *Limiter) WaitFile(ctx context.Context) error {
+	if err := l.files.Wait(ctx); err != nil {
+		return trace.Wrap(err, "failed waiting for the files per second limit")
+	}
+
+	l.transferredFiles.Add(1)
+	return nil
+}
+
+// Reader wraps r so that reads from it are counted, and paced to the bytes per second limit. The returned
+// reader also implements io.Seeker when r does, so it can be used where a rewindable body is required.
+// Bytes that are read again after seeking are counted (and paced) again.
+func (l *Limiter) Reader(ctx context.Context, r io.Reader) io.Reader {
+	tr := &reader{ctx: ctx, limiter: l, reader: r}
+	if seeker, ok := r.(io.Seeker); ok {
+		return &readSeeker{reader: tr, seeker: seeker}
+	}
+	return tr
+}
+
+// EffectiveBytesPerSecond is the average rate that bytes have been read at since the limiter was created.
+func (l *Limiter) EffectiveBytesPerSecond() float64 {
+	elapsed := time.Since(l.startTime).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(l.transferredBytes.Load()) / elapsed
+}
+
+// Keyval reports the configured limits, the amount transferred and the effective rate. It implements
+// contexts.DeferredKeyvalInterface, so a limiter can be passed directly to a (deferred) log call.
+func (l *Limiter) Keyval() []any {
+	keyvals := []any{
+		"transferredBytes", l.transferredBytes.Load(),
+		"transferredFiles", l.transferredFiles.Load(),
+		"effectiveBytesPerSecond", int64(l.EffectiveBytesPerSecond()),
+	}
+
+	if l.limits.BytesPerSecond > 0 {
+		keyvals = append(keyvals, "bytesPerSecondLimit", l.limits.BytesPerSecond)
+	}
+
+	if l.limits.FilesPerSecond > 0 {
+		keyvals = append(keyvals, "filesPerSecondLimit", l.limits.FilesPerSecond)
+	}
+
+	return keyvals
+}
+
+type reader struct {
+	ctx     context.Context
+	limiter *Limiter
+	reader  io.Reader
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if burst := r.limiter.bytes.Burst(); burst > 0 && len(p) > burst {
+		p = p[:burst]
+	}
+
+	n, err := r.reader.Read(p)
+	if n <= 0 {
+		return n, err
+	}
+
+	r.limiter.transferredBytes.Add(int64(n))
+	// Pay for the bytes after reading them, as the number of bytes read is only known afterwards. This paces
+	// the next read rather than this one, which averages out to the same rate.
+	if waitErr := r.limiter.bytes.WaitN(r.ctx, n); waitErr != nil {
+		return n, trace.Wrap(waitErr, "failed waiting for the bytes per second limit")
+	}
+
+	return n, err
+}
+
+type readSeeker struct {
+	*reader
+	seeker io.Seeker
+}
+
+func (rs *readSeeker) Seek(offset int64, whence int) (int64, error) {
+	return rs.seeker.Seek(offset, whence)
+}
@@ -0,0 +1,143 @@
+package throttle
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitsIsZero(t *testing.T) {
+	assert.True(t, Limits{}.IsZero())
+	assert.False(t, Limits{BytesPerSecond: 1}.IsZero())
+	assert.False(t, Limits{FilesPerSecond: 0.5}.IsZero())
+}
+
+func TestLimitsValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		limits  Limits
+		errFunc assert.ErrorAssertionFunc
+	}{
+		{
+			desc:    "unlimited",
+			errFunc: assert.NoError,
+		},
+		{
+			desc:    "limited",
+			limits:  Limits{BytesPerSecond: 1024, FilesPerSecond: 0.5},
+			errFunc: assert.NoError,
+		},
+		{
+			desc:    "negative bytes per second",
+			limits:  Limits{BytesPerSecond: -1},
+			errFunc: assert.Error,
+		},
+		{
+			desc:    "negative files per second",
+			limits:  Limits{FilesPerSecond: -1},
+			errFunc: assert.Error,
+		},
+		{
+			desc:    "NaN files per second",
+			limits:  Limits{FilesPerSecond: math.NaN()},
+			errFunc: assert.Error,
+		},
+		{
+			desc:    "infinite files per second",
+			limits:  Limits{FilesPerSecond: math.Inf(1)},
+			errFunc: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.errFunc(t, tt.limits.Validate())
+		})
+	}
+}
+
+func TestReaderUnlimited(t *testing.T) {
+	limiter := NewLimiter(Limits{})
+	contents := strings.Repeat("a", 1<<20)
+
+	read, err := io.ReadAll(limiter.Reader(context.Background(), strings.NewReader(contents)))
+	require.NoError(t, err)
+	assert.Equal(t, contents, string(read))
+	assert.Equal(t, int64(len(contents)), limiter.transferredBytes.Load())
+}
+
+func TestReaderPacesBytes(t *testing.T) {
+	// The first second's worth of bytes is available immediately (the burst), so reading two seconds'
+	// worth should take roughly one second.
+	limiter := NewLimiter(Limits{BytesPerSecond: 1024})
+	contents := bytes.Repeat([]byte("a"), 2048)
+
+	start := time.Now()
+	read, err := io.ReadAll(limiter.Reader(context.Background(), bytes.NewReader(contents)))
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.Equal(t, contents, read)
+	assert.GreaterOrEqual(t, elapsed, 900*time.Millisecond)
+	assert.Less(t, elapsed, 3*time.Second)
+}
+
+func TestReaderStopsWhenContextIsDone(t *testing.T) {
+	limiter := NewLimiter(Limits{BytesPerSecond: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := io.ReadAll(limiter.Reader(ctx, strings.NewReader("abc")))
+	assert.Error(t, err)
+}
+
+func TestReaderPreservesSeeker(t *testing.T) {
+	limiter := NewLimiter(Limits{})
+
+	_, isSeeker := limiter.Reader(context.Background(), strings.NewReader("abc")).(io.Seeker)
+	assert.True(t, isSeeker)
+
+	_, isSeeker = limiter.Reader(context.Background(), io.LimitReader(strings.NewReader("abc"), 1)).(io.Seeker)
+	assert.False(t, isSeeker)
+}
+
+func TestWaitFile(t *testing.T) {
+	limiter := NewLimiter(Limits{FilesPerSecond: 2})
+
+	start := time.Now()
+	for range 4 {
+		require.NoError(t, limiter.WaitFile(context.Background()))
+	}
+	elapsed := time.Since(start)
+
+	// Two files are available immediately (the burst), and the other two take half a second each.
+	assert.GreaterOrEqual(t, elapsed, 900*time.Millisecond)
+	assert.Equal(t, int64(4), limiter.transferredFiles.Load())
+}
+
+func TestWaitFileStopsWhenContextIsDone(t *testing.T) {
+	limiter := NewLimiter(Limits{FilesPerSecond: 0.001})
+	require.NoError(t, limiter.WaitFile(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, limiter.WaitFile(ctx))
+}
+
+func TestKeyval(t *testing.T) {
+	keyvals := NewLimiter(Limits{}).Keyval()
+	assert.Len(t, keyvals, 6)
+	assert.NotContains(t, keyvals, "bytesPerSecondLimit")
+	assert.NotContains(t, keyvals, "filesPerSecondLimit")
+
+	keyvals = NewLimiter(Limits{BytesPerSecond: 1024, FilesPerSecond: 10}).Keyval()
+	assert.Contains(t, keyvals, "bytesPerSecondLimit")
+	assert.Contains(t, keyvals, "filesPerSecondLimit")
+}